@@ -0,0 +1,286 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// SymbolAliasesFileName is the file in the root directory of the catalog that has the symbol aliases.
+// It's not a directory, so it's not loaded as a part of the catalog tree.
+const SymbolAliasesFileName = "symbol_aliases.json"
+
+// symbolAliases is the alias table of the symbols, the items at the first level of the root directory.
+// An alias always refers to a symbol that isn't an alias, so that it's resolved in one step.
+type symbolAliases struct {
+	mu       sync.RWMutex
+	filePath string
+	// aliases[Key]: Key is the alias, and the value is the symbol that it refers to
+	aliases map[string]string
+}
+
+func loadSymbolAliases(rootPath string) (*symbolAliases, error) {
+	sa := &symbolAliases{
+		filePath: filepath.Join(rootPath, SymbolAliasesFileName),
+		aliases:  map[string]string{},
+	}
+	buf, err := os.ReadFile(sa.filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return sa, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read symbol aliases file %s: %w", sa.filePath, err)
+	}
+	if err = json.Unmarshal(buf, &sa.aliases); err != nil {
+		return nil, fmt.Errorf("parse symbol aliases file %s: %w", sa.filePath, err)
+	}
+	return sa, nil
+}
+
+// save writes the aliases to a temporary file and renames it, so that the file is never partially written.
+// The caller must hold the lock.
+func (sa *symbolAliases) save() error {
+	buf, err := json.MarshalIndent(sa.aliases, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal symbol aliases: %w", err)
+	}
+	tmpPath := sa.filePath + ".tmp"
+	if err = os.WriteFile(tmpPath, buf, 0o600); err != nil {
+		return fmt.Errorf("write symbol aliases file %s: %w", tmpPath, err)
+	}
+	if err = os.Rename(tmpPath, sa.filePath); err != nil {
+		return fmt.Errorf("rename symbol aliases file %s: %w", tmpPath, err)
+	}
+	return nil
+}
+
+// ResolveSymbol returns the symbol that the alias refers to,
+// or the symbol itself if it's in the catalog or not an alias. This is used for a root catalog directory.
+func (d *Directory) ResolveSymbol(symbol string) string {
+	if d.aliases == nil || d.GetSubDirWithItemName(symbol) != nil {
+		return symbol
+	}
+	d.aliases.mu.RLock()
+	defer d.aliases.mu.RUnlock()
+	if target, found := d.aliases.aliases[symbol]; found {
+		return target
+	}
+	return symbol
+}
+
+// ResolveKey returns the time bucket key with its symbol resolved by ResolveSymbol,
+// or the key itself if the symbol isn't an alias.
+func (d *Directory) ResolveKey(tbk *io.TimeBucketKey) *io.TimeBucketKey {
+	items := tbk.GetItems()
+	if len(items) == 0 {
+		return tbk
+	}
+	symbol := d.ResolveSymbol(items[0])
+	if symbol == items[0] {
+		return tbk
+	}
+	items[0] = symbol
+	return io.NewTimeBucketKey(strings.Join(items, "/"), tbk.GetCatKey())
+}
+
+// SymbolAliases returns a copy of the alias table, from the aliases to the symbols that they refer to.
+func (d *Directory) SymbolAliases() map[string]string {
+	aliases := map[string]string{}
+	if d.aliases == nil {
+		return aliases
+	}
+	d.aliases.mu.RLock()
+	defer d.aliases.mu.RUnlock()
+	for alias, symbol := range d.aliases.aliases {
+		aliases[alias] = symbol
+	}
+	return aliases
+}
+
+// AddSymbolAlias makes the alias refer to the symbol, which must be in the catalog or be an alias of one.
+// An alias can't be a symbol in the catalog. This is used for a root catalog directory.
+func (d *Directory) AddSymbolAlias(alias, symbol string) error {
+	if err := validateSymbolName(alias); err != nil {
+		return err
+	}
+	if d.GetSubDirWithItemName(alias) != nil {
+		return fmt.Errorf("%s is a symbol in the catalog and can't be an alias", alias)
+	}
+
+	d.aliases.mu.Lock()
+	defer d.aliases.mu.Unlock()
+	if target, found := d.aliases.aliases[symbol]; found {
+		symbol = target
+	}
+	if symbol == alias {
+		return fmt.Errorf("%s can't be an alias of itself", alias)
+	}
+	if d.GetSubDirWithItemName(symbol) == nil {
+		return fmt.Errorf("symbol %s is not in the catalog", symbol)
+	}
+	d.aliases.aliases[alias] = symbol
+	return d.aliases.save()
+}
+
+// RemoveSymbolAlias removes the alias. This is used for a root catalog directory.
+func (d *Directory) RemoveSymbolAlias(alias string) error {
+	d.aliases.mu.Lock()
+	defer d.aliases.mu.Unlock()
+	if _, found := d.aliases.aliases[alias]; !found {
+		return fmt.Errorf("%s is not an alias", alias)
+	}
+	delete(d.aliases.aliases, alias)
+	return d.aliases.save()
+}
+
+// RenameSymbol moves the directory tree of the symbol to the new symbol, which must not be in the catalog,
+// and makes the old symbol an alias of the new one so that the queries and writes for either symbol resolve.
+// The aliases of the old symbol are changed to refer to the new one.
+// The pending writes to the symbol must be flushed before the rename. This is used for a root catalog directory.
+func (d *Directory) RenameSymbol(oldSymbol, newSymbol string) error {
+	d.aliases.mu.Lock()
+	defer d.aliases.mu.Unlock()
+	oldDir, err := d.prepareSymbolCopy(oldSymbol, newSymbol)
+	if err != nil {
+		return err
+	}
+
+	newPath := filepath.Join(d.GetPath(), newSymbol)
+	if err = os.Rename(oldDir.GetPath(), newPath); err != nil {
+		return fmt.Errorf("rename symbol directory %s: %w", oldDir.GetPath(), err)
+	}
+	d.removeSymbolSubDir(oldDir)
+	if err = d.addSymbolSubDir(newSymbol); err != nil {
+		return err
+	}
+
+	for alias, symbol := range d.aliases.aliases {
+		if symbol == oldSymbol {
+			d.aliases.aliases[alias] = newSymbol
+		}
+	}
+	delete(d.aliases.aliases, newSymbol)
+	d.aliases.aliases[oldSymbol] = newSymbol
+	return d.aliases.save()
+}
+
+// LinkSymbol creates the directory tree of the new symbol, which must not be in the catalog,
+// with the hard links to the year files of the symbol, so that the data of the symbol is readable from both.
+// As the links share the year files, a write to either symbol changes the other as well,
+// but a year file added after the link is only in the written symbol.
+// The pending writes to the symbol must be flushed before the link. This is used for a root catalog directory.
+func (d *Directory) LinkSymbol(symbol, newSymbol string) error {
+	d.aliases.mu.Lock()
+	defer d.aliases.mu.Unlock()
+	srcDir, err := d.prepareSymbolCopy(symbol, newSymbol)
+	if err != nil {
+		return err
+	}
+
+	newPath := filepath.Join(d.GetPath(), newSymbol)
+	if err = linkDirFiles(srcDir.GetPath(), newPath); err != nil {
+		if err2 := os.RemoveAll(newPath); err2 != nil {
+			return fmt.Errorf("remove partially linked directory %s: %w (link error: %v)", newPath, err2, err)
+		}
+		return err
+	}
+	if err = d.addSymbolSubDir(newSymbol); err != nil {
+		return err
+	}
+	if _, found := d.aliases.aliases[newSymbol]; found {
+		delete(d.aliases.aliases, newSymbol)
+		return d.aliases.save()
+	}
+	return nil
+}
+
+// prepareSymbolCopy validates the symbols to rename or link, and returns the directory of the source symbol.
+// The caller must hold the lock of the aliases.
+func (d *Directory) prepareSymbolCopy(symbol, newSymbol string) (*Directory, error) {
+	if err := validateSymbolName(newSymbol); err != nil {
+		return nil, err
+	}
+	srcDir := d.GetSubDirWithItemName(symbol)
+	if srcDir == nil {
+		return nil, fmt.Errorf("symbol %s is not in the catalog", symbol)
+	}
+	if d.GetSubDirWithItemName(newSymbol) != nil || fileExists(filepath.Join(d.GetPath(), newSymbol)) {
+		return nil, fmt.Errorf("symbol %s already exists", newSymbol)
+	}
+	return srcDir, nil
+}
+
+// addSymbolSubDir loads the directory tree of the symbol and adds it to the root directory.
+func (d *Directory) addSymbolSubDir(symbol string) error {
+	childDirectory, err := NewDirectory(filepath.Join(d.GetPath(), symbol))
+	if err != nil {
+		return fmt.Errorf("load symbol directory %s: %w", symbol, err)
+	}
+	d.Lock()
+	d.addSubdir(childDirectory, symbol)
+	d.Unlock()
+	d.index.addTree(childDirectory)
+	return nil
+}
+
+// removeSymbolSubDir removes the directory tree of the symbol from the root directory,
+// and drops the memory mappings of its year files.
+func (d *Directory) removeSymbolSubDir(symbolDir *Directory) {
+	prefix := symbolDir.GetPath() + "/"
+	d.directMap.Range(func(key, _ interface{}) bool {
+		if dirPath, ok := key.(string); ok && strings.HasPrefix(dirPath, prefix) {
+			d.directMap.Delete(key)
+		}
+		return true
+	})
+	d.removeSubDir(symbolDir.GetName(), d.directMap)
+	d.mappings.Invalidate(symbolDir.GetPath())
+	d.index.remove(symbolDir.GetPath())
+}
+
+// linkDirFiles creates the directory tree of srcPath at dstPath,
+// with the hard links to the year files and the copies of the other files.
+func linkDirFiles(srcPath, dstPath string) error {
+	return filepath.WalkDir(srcPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcPath, filePath)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dstPath, relPath)
+		switch {
+		case entry.IsDir():
+			if err = os.Mkdir(target, 0o770); err != nil {
+				return fmt.Errorf("create directory %s: %w", target, err)
+			}
+		case filepath.Ext(filePath) == ".bin":
+			if err = os.Link(filePath, target); err != nil {
+				return fmt.Errorf("link year file %s: %w", filePath, err)
+			}
+		default:
+			buf, err := os.ReadFile(filePath)
+			if err != nil {
+				return fmt.Errorf("read %s: %w", filePath, err)
+			}
+			if err = os.WriteFile(target, buf, 0o600); err != nil {
+				return fmt.Errorf("write %s: %w", target, err)
+			}
+		}
+		return nil
+	})
+}
+
+func validateSymbolName(symbol string) error {
+	if symbol == "" || symbol == "." || symbol == ".." || strings.ContainsAny(symbol, "/:,") {
+		return fmt.Errorf("invalid symbol name: %q", symbol)
+	}
+	return nil
+}
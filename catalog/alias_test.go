@@ -0,0 +1,109 @@
+package catalog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func TestRenameSymbol(t *testing.T) {
+	t.Parallel()
+	rootDir, catalogDir := setup(t)
+	require.Nil(t, catalogDir.AddSymbolAlias("YEN", "USDJPY"))
+
+	// --- when ---
+	err := catalogDir.RenameSymbol("USDJPY", "JPYUSD")
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.NoDirExists(t, filepath.Join(rootDir, "USDJPY"))
+	assert.Nil(t, catalogDir.GetSubDirWithItemName("USDJPY"))
+	tbi, err := catalogDir.GetLatestTimeBucketInfoFromKey(io.NewTimeBucketKey("JPYUSD/1Min/OHLC"))
+	require.Nil(t, err)
+	assert.Equal(t, filepath.Join(rootDir, "JPYUSD/1Min/OHLC/2002.bin"), tbi.Path)
+	_, err = catalogDir.GetLatestTimeBucketInfoFromKey(io.NewTimeBucketKey("USDJPY/1Min/OHLC"))
+	assert.NotNil(t, err)
+
+	// the old symbol and its aliases refer to the new symbol
+	assert.Equal(t, map[string]string{"USDJPY": "JPYUSD", "YEN": "JPYUSD"}, catalogDir.SymbolAliases())
+	assert.Equal(t, "JPYUSD", catalogDir.ResolveSymbol("USDJPY"))
+	assert.Equal(t, "JPYUSD/1Min/OHLC:Symbol/Timeframe/AttributeGroup",
+		catalogDir.ResolveKey(io.NewTimeBucketKey("USDJPY/1Min/OHLC")).String())
+
+	// the aliases are persisted
+	reloaded, err := catalog.NewDirectory(rootDir)
+	require.Nil(t, err)
+	assert.Equal(t, catalogDir.SymbolAliases(), reloaded.SymbolAliases())
+	assert.NotNil(t, reloaded.GetSubDirWithItemName("JPYUSD"))
+
+	// the new symbol must not exist
+	assert.NotNil(t, catalogDir.RenameSymbol("EURUSD", "JPYUSD"))
+	assert.NotNil(t, catalogDir.RenameSymbol("USDJPY", "EURUSD2"))
+	assert.NotNil(t, catalogDir.RenameSymbol("EURUSD", "EUR/USD"))
+}
+
+func TestLinkSymbol(t *testing.T) {
+	t.Parallel()
+	rootDir, catalogDir := setup(t)
+
+	// --- when ---
+	err := catalogDir.LinkSymbol("EURUSD", "EUR")
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.NotNil(t, catalogDir.GetSubDirWithItemName("EURUSD"))
+	tbi, err := catalogDir.GetLatestTimeBucketInfoFromKey(io.NewTimeBucketKey("EUR/1Min/OHLC"))
+	require.Nil(t, err)
+
+	// the year files are shared
+	oldInfo, err := os.Stat(filepath.Join(rootDir, "EURUSD/1Min/OHLC/2002.bin"))
+	require.Nil(t, err)
+	newInfo, err := os.Stat(tbi.Path)
+	require.Nil(t, err)
+	assert.True(t, os.SameFile(oldInfo, newInfo))
+	assert.Empty(t, catalogDir.SymbolAliases())
+}
+
+func TestSymbolAliases(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		alias, symbol string
+		wantErr       bool
+	}{
+		"ok/ alias of a symbol":              {alias: "EUR", symbol: "EURUSD"},
+		"ok/ alias of an alias":              {alias: "YEN", symbol: "JPY"},
+		"ok/ the alias is changed":           {alias: "JPY", symbol: "EURUSD"},
+		"ng/ symbol can't be an alias":       {alias: "EURUSD", symbol: "USDJPY", wantErr: true},
+		"ng/ symbol is not in the catalog":   {alias: "GBP", symbol: "GBPUSD", wantErr: true},
+		"ng/ alias name is not a valid item": {alias: "EUR/USD", symbol: "EURUSD", wantErr: true},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, catalogDir := setup(t)
+			require.Nil(t, catalogDir.AddSymbolAlias("JPY", "USDJPY"))
+
+			// --- when ---
+			err := catalogDir.AddSymbolAlias(tt.alias, tt.symbol)
+
+			// --- then ---
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if !tt.wantErr {
+				resolved := catalogDir.ResolveSymbol(tt.symbol)
+				assert.Equal(t, resolved, catalogDir.ResolveSymbol(tt.alias))
+				assert.Equal(t, resolved, catalogDir.SymbolAliases()[tt.alias])
+
+				require.Nil(t, catalogDir.RemoveSymbolAlias(tt.alias))
+				assert.Equal(t, tt.alias, catalogDir.ResolveSymbol(tt.alias))
+			}
+		})
+	}
+}
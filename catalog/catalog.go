@@ -35,6 +35,16 @@ type Directory struct {
 	categorySet map[string]struct{}
 	// datafile[Key]: Key is the fully specified path to the datafile, including rootPath and filename
 	datafile map[string]*io.TimeBucketInfo
+	// mappings are the memory mappings of the datafiles, only set to the root directory
+	mappings *FileMappings
+	// aliases is the alias table of the symbols, only set to the root directory
+	aliases *symbolAliases
+	// metadata are the static attributes of a symbol, only set to the directories of the symbols.
+	// stored in "symbol_metadata.json" file next to "category_name".
+	metadata map[string]string
+	// index keeps the catalog index file in sync with the catalog, only set to the root directory
+	// loaded by NewIndexedDirectory
+	index *catalogIndex
 }
 
 // NewDirectory scans files under the rootPath and return a new Directory struct.
@@ -44,11 +54,18 @@ func NewDirectory(rootPath string) (*Directory, error) {
 	d := &Directory{
 		// Directmap will point to each directory node using a composite key
 		directMap: &sync.Map{},
+		mappings:  NewFileMappings(),
 	}
 
 	// Load is single thread compatible - no concurrent access is anticipated
 	err := load(d.directMap, d, rootPath, rootPath)
 
+	// the aliases are loaded even when the root directory is new and has no category_name file yet
+	aliases, err2 := loadSymbolAliases(rootPath)
+	if err2 != nil {
+		return d, err2
+	}
+	d.aliases = aliases
 	return d, err
 }
 
@@ -63,6 +80,9 @@ func load(rootDmap *sync.Map, d *Directory, subPath, rootPath string) error {
 		return ErrCategoryFileNotFound{filePath: catFilePath, msg: io.GetCallerFileContext(0) + err.Error()}
 	}
 	d.category = string(catname)
+	if d.metadata, err = loadSymbolMetadata(subPath); err != nil {
+		return err
+	}
 
 	// Load up the child directories
 	d.subDirs = make(map[string]*Directory)
@@ -208,6 +228,7 @@ func (d *Directory) AddTimeBucket(tbk *io.TimeBucketKey, f *io.TimeBucketInfo) (
 		return err
 	}
 	d.addSubdir(childDirectory, childNodeName)
+	d.index.addTimeBucket(tbk, f.Path)
 	return nil
 }
 
@@ -237,6 +258,7 @@ func (d *Directory) RemoveTimeBucket(tbk *io.TimeBucketKey) (err error) {
 			if err2 := removeDirFiles(tree[i]); err2 != nil {
 				return err2
 			}
+			d.mappings.Invalidate(tree[i].pathToItemName)
 			deleteMap[i] = true // This dir was deleted, we'll remove it from the parent's subdir list later
 		} else if deleteMap[i+1] {
 			tree[i].removeSubDir(tree[i+1].itemName, d.directMap)
@@ -254,9 +276,20 @@ func (d *Directory) RemoveTimeBucket(tbk *io.TimeBucketKey) (err error) {
 		}
 		d.removeSubDir(tree[0].itemName, d.directMap)
 	}
+	for i := range deleteMap {
+		if deleteMap[i] {
+			d.index.remove(tree[i].pathToItemName)
+			break
+		}
+	}
 	return nil
 }
 
+// Mappings returns the memory mappings of the datafiles shared by the readers of a root catalog directory.
+func (d *Directory) Mappings() *FileMappings {
+	return d.mappings
+}
+
 func (d *Directory) GetTimeBucketInfoSlice() (tbinfolist []*io.TimeBucketInfo) {
 	// Returns a list of fileinfo for all datafiles in this directory or nil if there are none
 	d.RLock()
@@ -421,7 +454,11 @@ func (d *Directory) GetSubDirectoryAndAddFile(fullFilePath string, year int16) (
 	if d.directMap != nil {
 		if dir, ok := d.directMap.Load(dirPath); ok {
 			if dir2, ok2 := dir.(*Directory); ok2 {
-				return dir2.AddFile(year)
+				tbi, err := dir2.AddFile(year)
+				if err == nil {
+					d.index.addFile(tbi.Path)
+				}
+				return tbi, err
 			}
 			return nil, fmt.Errorf("cast directory type: %v", dir)
 		}
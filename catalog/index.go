@@ -0,0 +1,473 @@
+package catalog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	stdio "io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alpacahq/marketstore/v4/utils/io"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// IndexFileName is the file under the root directory that has the catalog index,
+// the list of the directories with their categories and the year files under the root directory,
+// so that the catalog can be loaded without walking all the directories on startup.
+const IndexFileName = "catalog_index"
+
+// indexHeader is the first line of the index file, and changed when the format of the index changes.
+const indexHeader = "#marketstore-catalog-index v1"
+
+// The index file is a log of the changes to the catalog, one change per line.
+// A path is relative to the root directory and quoted, so that any character can be in the item names.
+//
+//	C "AAPL/1Min" "AttributeGroup"   the directory with its category ("." for the root directory)
+//	F "AAPL/1Min/OHLCV/2021.bin"     the year file
+//	R "AAPL/1Min"                    the directory and everything under it are removed
+//
+// The log is compacted to the list of the directories and the year files every time the catalog is loaded.
+const (
+	indexOpCategory = 'C'
+	indexOpFile     = 'F'
+	indexOpRemove   = 'R'
+)
+
+var errStaleIndex = errors.New("catalog index is stale")
+
+// catalogIndex appends the changes of the catalog to the index file. This is only set to the root directory.
+type catalogIndex struct {
+	mu       sync.Mutex
+	rootPath string
+	filePath string
+	// invalid is set when a change failed to be appended, and the index file is removed
+	invalid bool
+}
+
+// NewIndexedDirectory loads the catalog of the rootPath from its index file and keeps the index in sync
+// with the changes of the catalog. The catalog is loaded by scanning the files under the rootPath as NewDirectory,
+// and the index is rebuilt, if the index file is not found or it doesn't match the symbol directories.
+// The changes to the directories made by hand while marketstore is stopped are not detected
+// except for the added or removed symbols, so the index must be rebuilt by "marketstore tool reindex" after that.
+func NewIndexedDirectory(rootPath string) (*Directory, error) {
+	d, err := loadIndexedDirectory(rootPath)
+	if err != nil {
+		log.Info(fmt.Sprintf("loading the catalog by scanning %s: %v", rootPath, err))
+		d, err = NewDirectory(rootPath)
+		var e ErrCategoryFileNotFound
+		if err != nil && !errors.As(err, &e) {
+			return d, err
+		}
+	}
+
+	// compact the index. The catalog works without the index if it fails
+	if err2 := writeIndex(rootPath, d); err2 != nil {
+		log.Error(fmt.Sprintf("failed to write the catalog index: %v", err2))
+		RemoveIndex(rootPath)
+		return d, err
+	}
+	d.index = &catalogIndex{rootPath: filepath.Clean(rootPath), filePath: filepath.Join(rootPath, IndexFileName)}
+	return d, err
+}
+
+// Reindex scans the files under the rootPath and rebuilds its index file, and returns the number of the year files.
+// marketstore must be stopped while the index is rebuilt.
+func Reindex(rootPath string) (int, error) {
+	d, err := NewDirectory(rootPath)
+	var e ErrCategoryFileNotFound
+	if err != nil && !errors.As(err, &e) {
+		return 0, err
+	}
+	if err = writeIndex(rootPath, d); err != nil {
+		return 0, err
+	}
+	filePaths, err := d.GatherFilePaths()
+	if err != nil {
+		return 0, err
+	}
+	return len(filePaths), nil
+}
+
+// RemoveIndex removes the index file of the rootPath if it exists, so that it's not used after
+// the catalog is changed without the index.
+func RemoveIndex(rootPath string) {
+	filePath := filepath.Join(rootPath, IndexFileName)
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Error(fmt.Sprintf("failed to remove the catalog index %s: %v", filePath, err))
+	}
+}
+
+func loadIndexedDirectory(rootPath string) (*Directory, error) {
+	entries, err := readIndex(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = entries.validate(rootPath); err != nil {
+		return nil, err
+	}
+	d := &Directory{
+		directMap: &sync.Map{},
+		mappings:  NewFileMappings(),
+	}
+	if err = entries.build(d, rootPath); err != nil {
+		return nil, err
+	}
+	if d.aliases, err = loadSymbolAliases(rootPath); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// indexEntries are the directories and the year files in the index.
+type indexEntries struct {
+	// categories[Key]: Key is the path of the directory relative to the root directory
+	categories map[string]string
+	// files[Key]: Key is the path of the year file relative to the root directory
+	files map[string]struct{}
+}
+
+func readIndex(rootPath string) (*indexEntries, error) {
+	filePath := filepath.Join(rootPath, IndexFileName)
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("open catalog index %s: %w", filePath, err)
+	}
+	defer func() {
+		if err2 := fp.Close(); err2 != nil {
+			log.Error(fmt.Sprintf("failed to close catalog index %s: %v", filePath, err2))
+		}
+	}()
+
+	entries := &indexEntries{categories: map[string]string{}, files: map[string]struct{}{}}
+	r := bufio.NewReader(fp)
+	for lineNum := 1; ; lineNum++ {
+		line, err := r.ReadString('\n')
+		if errors.Is(err, stdio.EOF) && line == "" {
+			break
+		} else if errors.Is(err, stdio.EOF) {
+			// a line that was partially appended
+			return nil, fmt.Errorf("%w: unterminated line %d", errStaleIndex, lineNum)
+		} else if err != nil {
+			return nil, fmt.Errorf("read catalog index %s: %w", filePath, err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if lineNum == 1 {
+			if line != indexHeader {
+				return nil, fmt.Errorf("%w: unknown header %q", errStaleIndex, line)
+			}
+			continue
+		}
+		if err = entries.apply(line); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", errStaleIndex, lineNum, err)
+		}
+	}
+	if _, found := entries.categories["."]; !found {
+		return nil, fmt.Errorf("%w: no root directory", errStaleIndex)
+	}
+	return entries, nil
+}
+
+// apply applies a change of the catalog in the index file to the entries.
+func (e *indexEntries) apply(line string) error {
+	if len(line) < 2 || line[1] != ' ' {
+		return fmt.Errorf("invalid line %q", line)
+	}
+	args, err := unquoteFields(line[2:])
+	if err != nil {
+		return err
+	}
+	switch {
+	case line[0] == indexOpCategory && len(args) == 2:
+		e.categories[args[0]] = args[1]
+	case line[0] == indexOpFile && len(args) == 1:
+		e.files[args[0]] = struct{}{}
+	case line[0] == indexOpRemove && len(args) == 1:
+		prefix := args[0] + "/"
+		for dirPath := range e.categories {
+			if dirPath == args[0] || strings.HasPrefix(dirPath, prefix) {
+				delete(e.categories, dirPath)
+			}
+		}
+		for filePath := range e.files {
+			if strings.HasPrefix(filePath, prefix) {
+				delete(e.files, filePath)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid line %q", line)
+	}
+	return nil
+}
+
+// validate checks that the symbol directories in the index are the ones under the root directory,
+// which is cheap to check and catches the symbols added or removed while marketstore is stopped.
+func (e *indexEntries) validate(rootPath string) error {
+	dirEntries, err := os.ReadDir(rootPath)
+	if err != nil {
+		return fmt.Errorf("read dir %s: %w", rootPath, err)
+	}
+	onDisk := map[string]struct{}{}
+	for _, de := range dirEntries {
+		if !de.IsDir() || de.Name() == "metadata.db" {
+			continue
+		}
+		onDisk[de.Name()] = struct{}{}
+		if _, found := e.categories[de.Name()]; !found {
+			return fmt.Errorf("%w: %s is not in the index", errStaleIndex, de.Name())
+		}
+	}
+	for dirPath := range e.categories {
+		if _, found := onDisk[dirPath]; !found && dirPath != "." && !strings.Contains(dirPath, "/") {
+			return fmt.Errorf("%w: %s is not found", errStaleIndex, dirPath)
+		}
+	}
+	return nil
+}
+
+// build makes the directory tree from the entries as load does.
+// The headers of the year files are read when they are first used.
+func (e *indexEntries) build(root *Directory, rootPath string) error {
+	dirPaths := make([]string, 0, len(e.categories))
+	for dirPath := range e.categories {
+		dirPaths = append(dirPaths, dirPath)
+	}
+	// a parent directory comes before its children
+	sort.Strings(dirPaths)
+
+	dirs := make(map[string]*Directory, len(dirPaths))
+	for _, dirPath := range dirPaths {
+		d := root
+		if dirPath != "." {
+			parent, found := dirs[path.Dir(dirPath)]
+			if !found {
+				return fmt.Errorf("%w: no parent directory of %s", errStaleIndex, dirPath)
+			}
+			d = &Directory{}
+			parent.subDirs[path.Base(dirPath)] = d
+		}
+		d.itemName = path.Base(dirPath)
+		d.pathToItemName = filepath.Join(rootPath, dirPath)
+		d.category = e.categories[dirPath]
+		d.subDirs = make(map[string]*Directory)
+		if strings.Count(dirPath, "/") == 0 && dirPath != "." {
+			var err error
+			if d.metadata, err = loadSymbolMetadata(d.pathToItemName); err != nil {
+				return err
+			}
+		}
+		dirs[dirPath] = d
+	}
+
+	for filePath := range e.files {
+		d, found := dirs[path.Dir(filePath)]
+		if !found {
+			return fmt.Errorf("%w: no directory of %s", errStaleIndex, filePath)
+		}
+		yearFileBase := path.Base(filePath)
+		year, err := strconv.Atoi(strings.TrimSuffix(yearFileBase, ".bin"))
+		if err != nil || path.Ext(yearFileBase) != ".bin" {
+			return fmt.Errorf("%w: invalid year file %s", errStaleIndex, filePath)
+		}
+		root.directMap.Store(d.pathToItemName, d)
+		if d.datafile == nil {
+			d.datafile = make(map[string]*io.TimeBucketInfo)
+		}
+		fullPath := filepath.Join(rootPath, filePath)
+		d.datafile[fullPath] = &io.TimeBucketInfo{Year: int16(year), Path: fullPath, IsRead: false}
+	}
+	return nil
+}
+
+// writeIndex writes the directories and the year files of the catalog to the index file.
+// The index is written to a temporary file and renamed, so that the index file is never partially written.
+func writeIndex(rootPath string, d *Directory) error {
+	lines, err := indexLines(rootPath, d)
+	if err != nil {
+		return err
+	}
+	sort.Strings(lines)
+
+	filePath := filepath.Join(rootPath, IndexFileName)
+	tmpPath := filePath + ".tmp"
+	var sb strings.Builder
+	sb.WriteString(indexHeader + "\n")
+	for _, line := range lines {
+		sb.WriteString(line)
+	}
+	if err = os.WriteFile(tmpPath, []byte(sb.String()), 0o600); err != nil {
+		return fmt.Errorf("write catalog index %s: %w", tmpPath, err)
+	}
+	if err = os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("rename catalog index %s: %w", tmpPath, err)
+	}
+	return nil
+}
+
+// indexLines returns the lines of the index for the directory and everything under it.
+func indexLines(rootPath string, d *Directory) ([]string, error) {
+	lines := make([]string, 0)
+	err := d.recurse(&lines, func(d *Directory, iList interface{}) error {
+		pList, ok := iList.(*[]string)
+		if !ok {
+			return fmt.Errorf("unexpected iList type: %v", iList)
+		}
+		relPath, err := filepath.Rel(rootPath, d.pathToItemName)
+		if err != nil {
+			return err
+		}
+		if relPath == "." && d.category == "" {
+			// a new root directory
+			return nil
+		}
+		*pList = append(*pList, indexLine(indexOpCategory, filepath.ToSlash(relPath), d.category))
+		for filePath := range d.datafile {
+			if relPath, err = filepath.Rel(rootPath, filePath); err != nil {
+				return err
+			}
+			*pList = append(*pList, indexLine(indexOpFile, filepath.ToSlash(relPath)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list catalog index entries: %w", err)
+	}
+	return lines, nil
+}
+
+func indexLine(op byte, args ...string) string {
+	var sb strings.Builder
+	sb.WriteByte(op)
+	for _, arg := range args {
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.Quote(arg))
+	}
+	sb.WriteByte('\n')
+	return sb.String()
+}
+
+func unquoteFields(s string) ([]string, error) {
+	fields := make([]string, 0, 2)
+	for {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", s, err)
+		}
+		field, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", quoted, err)
+		}
+		fields = append(fields, field)
+		s = s[len(quoted):]
+		if s == "" {
+			return fields, nil
+		}
+		if s[0] != ' ' {
+			return nil, fmt.Errorf("invalid field separator %q", s)
+		}
+		s = s[1:]
+	}
+}
+
+// addTimeBucket appends the categories of the directories from the root directory to the time bucket,
+// and its year file.
+func (ci *catalogIndex) addTimeBucket(tbk *io.TimeBucketKey, yearFilePath string) {
+	if ci == nil {
+		return
+	}
+	categories := append(tbk.GetCategories(), "Year")
+	items := tbk.GetItems()
+	lines := make([]string, 0, len(categories)+1)
+	dirPath := "."
+	for i, category := range categories {
+		lines = append(lines, indexLine(indexOpCategory, dirPath, category))
+		if i < len(items) {
+			dirPath = path.Join(dirPath, items[i])
+		}
+	}
+	ci.appendLines(append(lines, ci.fileLine(yearFilePath))...)
+}
+
+// addFile appends the year file.
+func (ci *catalogIndex) addFile(yearFilePath string) {
+	if ci == nil {
+		return
+	}
+	ci.appendLines(ci.fileLine(yearFilePath))
+}
+
+// addTree appends the directory and everything under it.
+func (ci *catalogIndex) addTree(d *Directory) {
+	if ci == nil {
+		return
+	}
+	lines, err := indexLines(ci.rootPath, d)
+	if err != nil {
+		ci.invalidate(err)
+		return
+	}
+	sort.Strings(lines)
+	ci.appendLines(lines...)
+}
+
+// remove appends the removal of the directory and everything under it.
+func (ci *catalogIndex) remove(dirPath string) {
+	if ci == nil {
+		return
+	}
+	relPath, err := filepath.Rel(ci.rootPath, dirPath)
+	if err != nil {
+		ci.invalidate(err)
+		return
+	}
+	ci.appendLines(indexLine(indexOpRemove, filepath.ToSlash(relPath)))
+}
+
+func (ci *catalogIndex) fileLine(yearFilePath string) string {
+	relPath, err := filepath.Rel(ci.rootPath, yearFilePath)
+	if err != nil {
+		// never happens as the year files are under the root directory
+		relPath = yearFilePath
+	}
+	return indexLine(indexOpFile, filepath.ToSlash(relPath))
+}
+
+func (ci *catalogIndex) appendLines(lines ...string) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if ci.invalid {
+		return
+	}
+	fp, err := os.OpenFile(ci.filePath, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		ci.invalidateLocked(err)
+		return
+	}
+	_, err = fp.WriteString(strings.Join(lines, ""))
+	if err2 := fp.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		ci.invalidateLocked(err)
+	}
+}
+
+func (ci *catalogIndex) invalidate(err error) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.invalidateLocked(err)
+}
+
+// invalidateLocked removes the index file that can't be kept in sync,
+// so that the catalog is loaded by scanning the files on the next startup.
+func (ci *catalogIndex) invalidateLocked(err error) {
+	log.Error(fmt.Sprintf("failed to update the catalog index, it will be rebuilt on the next startup: %v", err))
+	ci.invalid = true
+	RemoveIndex(ci.rootPath)
+}
@@ -0,0 +1,187 @@
+package catalog_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+	"github.com/alpacahq/marketstore/v4/utils/test"
+)
+
+func addTestTimeBucket(t *testing.T, rootDir string, catalogDir *catalog.Directory) {
+	t.Helper()
+
+	dsv := io.NewDataShapeVector(
+		[]string{"Open", "High", "Low", "Close", "Volume"},
+		[]io.EnumElementType{io.FLOAT32, io.FLOAT32, io.FLOAT32, io.FLOAT32, io.INT32},
+	)
+	tbinfo := io.NewTimeBucketInfo(*utils.TimeframeFromString("1Min"), filepath.Join(rootDir, test1MinBucket),
+		"Test item", 2016, dsv, io.FIXED)
+	require.Nil(t, catalogDir.AddTimeBucket(io.NewTimeBucketKey(test1MinBucket), tbinfo))
+}
+
+// requireSameCatalog asserts that the catalogs have the same time buckets and year files.
+func requireSameCatalog(t *testing.T, want, got *catalog.Directory) {
+	t.Helper()
+
+	wantKeys, gotKeys := catalog.ListTimeBucketKeyNames(want), catalog.ListTimeBucketKeyNames(got)
+	sort.Strings(wantKeys)
+	sort.Strings(gotKeys)
+	require.Equal(t, wantKeys, gotKeys)
+	wantFiles, err := want.GatherFilePaths()
+	require.Nil(t, err)
+	gotFiles, err := got.GatherFilePaths()
+	require.Nil(t, err)
+	sort.Strings(wantFiles)
+	sort.Strings(gotFiles)
+	require.Equal(t, wantFiles, gotFiles)
+	wantItems, err := want.GatherCategoriesAndItems()
+	require.Nil(t, err)
+	gotItems, err := got.GatherCategoriesAndItems()
+	require.Nil(t, err)
+	require.Equal(t, wantItems, gotItems)
+}
+
+func TestIndexedDirectory(t *testing.T) {
+	t.Parallel()
+	rootDir := t.TempDir()
+	test.MakeDummyCurrencyDir(rootDir, false, false)
+	catalogDir, err := catalog.NewIndexedDirectory(rootDir)
+	require.Nil(t, err)
+	require.FileExists(t, filepath.Join(rootDir, catalog.IndexFileName))
+
+	// --- when the catalog is changed ---
+	addTestTimeBucket(t, rootDir, catalogDir)
+	_, err = catalogDir.GetSubDirectoryAndAddFile(filepath.Join(rootDir, "EURUSD/1Min/OHLC/2000.bin"), 2003)
+	require.Nil(t, err)
+	require.Nil(t, catalogDir.RemoveTimeBucket(io.NewTimeBucketKey("USDJPY/1D/OHLC")))
+	require.Nil(t, catalogDir.RenameSymbol("NZDUSD", "NZD"))
+	require.Nil(t, catalogDir.LinkSymbol("NZD", "KIWI"))
+
+	// --- then the index is kept in sync ---
+	got, err := catalog.NewIndexedDirectory(rootDir)
+	require.Nil(t, err)
+	want, err := catalog.NewDirectory(rootDir)
+	require.Nil(t, err)
+	requireSameCatalog(t, want, got)
+	assert.Equal(t, map[string]string{"NZDUSD": "NZD"}, got.SymbolAliases())
+
+	// the headers of the year files are read when they are used
+	dsv, err := got.GetDataShapes(io.NewTimeBucketKey(test1MinBucket))
+	require.Nil(t, err)
+	assert.Equal(t, "Volume", dsv[len(dsv)-1].Name)
+	tbi, err := got.GetLatestTimeBucketInfoFromKey(io.NewTimeBucketKey("EURUSD/1Min/OHLC"))
+	require.Nil(t, err)
+	assert.Equal(t, int16(2003), tbi.Year)
+	assert.Equal(t, time.Minute, tbi.GetTimeframe())
+
+	// --- when the index is rebuilt ---
+	files, err := catalog.Reindex(rootDir)
+
+	// --- then ---
+	require.Nil(t, err)
+	wantFiles, err := want.GatherFilePaths()
+	require.Nil(t, err)
+	assert.Len(t, wantFiles, files)
+	got, err = catalog.NewIndexedDirectory(rootDir)
+	require.Nil(t, err)
+	requireSameCatalog(t, want, got)
+}
+
+func TestNewIndexedDirectory(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		// modify changes the root directory after the index is written
+		modify     func(t *testing.T, rootDir string)
+		key        string
+		wantExists bool
+	}{
+		"ok/ loaded from the index": {
+			// the time bucket is ignored if the catalog is scanned
+			modify: func(t *testing.T, rootDir string) {
+				t.Helper()
+				require.Nil(t, os.Remove(filepath.Join(rootDir, "NZDUSD/1D/category_name")))
+			},
+			key: "NZDUSD/1D/OHLC", wantExists: true,
+		},
+		"ok/ a symbol added without the index is scanned": {
+			modify: func(t *testing.T, rootDir string) {
+				t.Helper()
+				catalogDir, err := catalog.NewDirectory(rootDir)
+				require.Nil(t, err)
+				addTestTimeBucket(t, rootDir, catalogDir)
+			},
+			key: test1MinBucket, wantExists: true,
+		},
+		"ok/ a symbol removed without the index is scanned": {
+			modify: func(t *testing.T, rootDir string) {
+				t.Helper()
+				require.Nil(t, os.RemoveAll(filepath.Join(rootDir, "USDJPY")))
+			},
+			key: "USDJPY/1Min/OHLC", wantExists: false,
+		},
+		"ok/ a partially appended index is scanned": {
+			modify: func(t *testing.T, rootDir string) {
+				t.Helper()
+				require.Nil(t, os.Remove(filepath.Join(rootDir, "NZDUSD/1D/category_name")))
+				fp, err := os.OpenFile(filepath.Join(rootDir, catalog.IndexFileName), os.O_WRONLY|os.O_APPEND, 0o600)
+				require.Nil(t, err)
+				_, err = fp.WriteString(`F "EURUSD/1Min/OHLC/20`)
+				require.Nil(t, err)
+				require.Nil(t, fp.Close())
+			},
+			key: "NZDUSD/1D/OHLC", wantExists: false,
+		},
+		"ok/ an index of another version is scanned": {
+			modify: func(t *testing.T, rootDir string) {
+				t.Helper()
+				require.Nil(t, os.Remove(filepath.Join(rootDir, "NZDUSD/1D/category_name")))
+				require.Nil(t, os.WriteFile(filepath.Join(rootDir, catalog.IndexFileName),
+					[]byte("#marketstore-catalog-index v0\n"), 0o600))
+			},
+			key: "NZDUSD/1D/OHLC", wantExists: false,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			rootDir := t.TempDir()
+			test.MakeDummyCurrencyDir(rootDir, false, false)
+			_, err := catalog.NewIndexedDirectory(rootDir)
+			require.Nil(t, err)
+			tt.modify(t, rootDir)
+
+			// --- when ---
+			catalogDir, err := catalog.NewIndexedDirectory(rootDir)
+
+			// --- then ---
+			require.Nil(t, err)
+			assert.Equal(t, tt.wantExists, contains(catalog.ListTimeBucketKeyNames(catalogDir), tt.key))
+			// the index is rebuilt
+			want, err := catalog.NewIndexedDirectory(rootDir)
+			require.Nil(t, err)
+			requireSameCatalog(t, want, catalogDir)
+		})
+	}
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SymbolMetadataFileName is the file next to "category_name" in the directory of a symbol
+// that has the static attributes of the symbol, e.g. CUSIP, exchange, sector or lot size.
+const SymbolMetadataFileName = "symbol_metadata.json"
+
+// loadSymbolMetadata reads the metadata file in the directory if it exists.
+func loadSymbolMetadata(dirPath string) (map[string]string, error) {
+	filePath := filepath.Join(dirPath, SymbolMetadataFileName)
+	buf, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read symbol metadata file %s: %w", filePath, err)
+	}
+	metadata := map[string]string{}
+	if err = json.Unmarshal(buf, &metadata); err != nil {
+		return nil, fmt.Errorf("parse symbol metadata file %s: %w", filePath, err)
+	}
+	return metadata, nil
+}
+
+// saveSymbolMetadata writes the metadata to a temporary file and renames it,
+// so that the file is never partially written.
+func saveSymbolMetadata(dirPath string, metadata map[string]string) error {
+	filePath := filepath.Join(dirPath, SymbolMetadataFileName)
+	buf, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal symbol metadata: %w", err)
+	}
+	tmpPath := filePath + ".tmp"
+	if err = os.WriteFile(tmpPath, buf, 0o600); err != nil {
+		return fmt.Errorf("write symbol metadata file %s: %w", tmpPath, err)
+	}
+	if err = os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("rename symbol metadata file %s: %w", tmpPath, err)
+	}
+	return nil
+}
+
+// GetSymbolMetadata returns a copy of the metadata of the symbol or its alias,
+// or an empty map if the symbol has no metadata. This is used for a root catalog directory.
+func (d *Directory) GetSymbolMetadata(symbol string) (map[string]string, error) {
+	symbolDir := d.GetSubDirWithItemName(d.ResolveSymbol(symbol))
+	if symbolDir == nil {
+		return nil, fmt.Errorf("symbol %s is not in the catalog", symbol)
+	}
+	return symbolDir.symbolMetadata(), nil
+}
+
+// SetSymbolMetadata merges the attributes to the metadata of the symbol or its alias and persists it.
+// An attribute with an empty value is removed. This is used for a root catalog directory.
+func (d *Directory) SetSymbolMetadata(symbol string, attributes map[string]string) error {
+	symbolDir := d.GetSubDirWithItemName(d.ResolveSymbol(symbol))
+	if symbolDir == nil {
+		return fmt.Errorf("symbol %s is not in the catalog", symbol)
+	}
+	for key := range attributes {
+		if err := validateMetadataKey(key); err != nil {
+			return err
+		}
+	}
+
+	symbolDir.Lock()
+	defer symbolDir.Unlock()
+	metadata := make(map[string]string, len(symbolDir.metadata)+len(attributes))
+	for key, value := range symbolDir.metadata {
+		metadata[key] = value
+	}
+	for key, value := range attributes {
+		if value == "" {
+			delete(metadata, key)
+			continue
+		}
+		metadata[key] = value
+	}
+	if err := saveSymbolMetadata(symbolDir.GetPath(), metadata); err != nil {
+		return err
+	}
+	symbolDir.metadata = metadata
+	return nil
+}
+
+// FilterSymbols returns the sorted symbols in the catalog whose metadata matches the filter expression,
+// e.g. "exchange=NASDAQ AND sector=Tech". See ParseSymbolFilter for the syntax.
+// This is used for a root catalog directory.
+func (d *Directory) FilterSymbols(expr string) ([]string, error) {
+	filter, err := ParseSymbolFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	symbols := make([]string, 0)
+	for _, symbolDir := range d.GetListOfSubDirs() {
+		if filter.Match(symbolDir.symbolMetadata()) {
+			symbols = append(symbols, symbolDir.GetName())
+		}
+	}
+	sort.Strings(symbols)
+	return symbols, nil
+}
+
+func (d *Directory) symbolMetadata() map[string]string {
+	d.RLock()
+	defer d.RUnlock()
+	metadata := make(map[string]string, len(d.metadata))
+	for key, value := range d.metadata {
+		metadata[key] = value
+	}
+	return metadata
+}
+
+// SymbolFilter is a conjunction of the conditions on the metadata of a symbol.
+type SymbolFilter []SymbolCondition
+
+// SymbolCondition matches the metadata of a symbol whose attribute is (or is not, if Negate is set) the value.
+type SymbolCondition struct {
+	Key    string
+	Value  string
+	Negate bool
+}
+
+var filterAndRegex = regexp.MustCompile(`(?i)\s+AND\s+`)
+
+// ParseSymbolFilter parses a filter expression of the conditions joined by "AND" (case-insensitive),
+// each of which is "key=value" or "key!=value". A value can be quoted with " or ' to contain spaces.
+// e.g. `exchange=NASDAQ AND sector="Information Technology" AND lot_size!=1`.
+func ParseSymbolFilter(expr string) (SymbolFilter, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, errors.New("empty symbol filter")
+	}
+	terms := filterAndRegex.Split(expr, -1)
+	filter := make(SymbolFilter, 0, len(terms))
+	for _, term := range terms {
+		var cond SymbolCondition
+		idx := strings.Index(term, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid condition %q in symbol filter %q, must be key=value or key!=value",
+				term, expr)
+		}
+		cond.Key, cond.Value = term[:idx], term[idx+1:]
+		if strings.HasSuffix(cond.Key, "!") {
+			cond.Key = strings.TrimSuffix(cond.Key, "!")
+			cond.Negate = true
+		}
+		cond.Key = strings.TrimSpace(cond.Key)
+		cond.Value = unquote(strings.TrimSpace(cond.Value))
+		if err := validateMetadataKey(cond.Key); err != nil {
+			return nil, fmt.Errorf("invalid condition %q in symbol filter %q: %w", term, expr, err)
+		}
+		filter = append(filter, cond)
+	}
+	return filter, nil
+}
+
+// Match returns true if the metadata satisfies all the conditions.
+// A missing attribute is treated as an empty value.
+func (f SymbolFilter) Match(metadata map[string]string) bool {
+	for _, cond := range f {
+		if (metadata[cond.Key] == cond.Value) == cond.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+func unquote(s string) string {
+	const minQuotedLen = 2
+	if len(s) >= minQuotedLen && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func validateMetadataKey(key string) error {
+	if key == "" || strings.ContainsAny(key, "=! \t\n") {
+		return fmt.Errorf("invalid metadata key: %q", key)
+	}
+	return nil
+}
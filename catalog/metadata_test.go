@@ -0,0 +1,94 @@
+package catalog_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+)
+
+func TestSymbolMetadata(t *testing.T) {
+	t.Parallel()
+	rootDir, catalogDir := setup(t)
+	require.Nil(t, catalogDir.AddSymbolAlias("YEN", "USDJPY"))
+
+	// --- when ---
+	err := catalogDir.SetSymbolMetadata("USDJPY", map[string]string{"exchange": "FX", "lot_size": "1000"})
+	require.Nil(t, err)
+	err = catalogDir.SetSymbolMetadata("YEN", map[string]string{"sector": "Currency", "lot_size": ""})
+	require.Nil(t, err)
+
+	// --- then ---
+	want := map[string]string{"exchange": "FX", "sector": "Currency"}
+	got, err := catalogDir.GetSymbolMetadata("USDJPY")
+	require.Nil(t, err)
+	assert.Equal(t, want, got)
+	assert.FileExists(t, filepath.Join(rootDir, "USDJPY", catalog.SymbolMetadataFileName))
+	got, err = catalogDir.GetSymbolMetadata("EURUSD")
+	require.Nil(t, err)
+	assert.Empty(t, got)
+
+	// the metadata is persisted, and moved with the symbol
+	require.Nil(t, catalogDir.RenameSymbol("USDJPY", "JPYUSD"))
+	reloaded, err := catalog.NewDirectory(rootDir)
+	require.Nil(t, err)
+	got, err = reloaded.GetSymbolMetadata("JPYUSD")
+	require.Nil(t, err)
+	assert.Equal(t, want, got)
+
+	// the linked symbol has a copy of the metadata
+	require.Nil(t, reloaded.LinkSymbol("JPYUSD", "JPY"))
+	require.Nil(t, reloaded.SetSymbolMetadata("JPY", map[string]string{"exchange": "OTC"}))
+	got, err = reloaded.GetSymbolMetadata("JPYUSD")
+	require.Nil(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = catalogDir.GetSymbolMetadata("GBPUSD")
+	assert.NotNil(t, err)
+	assert.NotNil(t, catalogDir.SetSymbolMetadata("GBPUSD", map[string]string{"exchange": "FX"}))
+	assert.NotNil(t, catalogDir.SetSymbolMetadata("EURUSD", map[string]string{"lot size": "1"}))
+}
+
+func TestFilterSymbols(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter      string
+		wantSymbols []string
+		wantErr     bool
+	}{
+		"ok/ equal":                       {filter: "exchange=NASDAQ", wantSymbols: []string{"EURUSD", "USDJPY"}},
+		"ok/ not equal":                   {filter: "exchange!=NASDAQ", wantSymbols: []string{"NZDUSD"}},
+		"ok/ and":                         {filter: "exchange=NASDAQ and sector=Tech", wantSymbols: []string{"EURUSD"}},
+		"ok/ quoted value with spaces":    {filter: `sector="Consumer Goods"`, wantSymbols: []string{"NZDUSD"}},
+		"ok/ missing attribute is empty":  {filter: "sector!=Tech AND exchange=NASDAQ", wantSymbols: []string{"USDJPY"}},
+		"ok/ no match":                    {filter: "exchange=NYSE AND sector=Tech", wantSymbols: []string{}},
+		"ng/ empty filter":                {filter: " ", wantErr: true},
+		"ng/ condition without the value": {filter: "exchange=NASDAQ AND sector", wantErr: true},
+		"ng/ condition without the key":   {filter: "=NASDAQ", wantErr: true},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			_, catalogDir := setup(t)
+			require.Nil(t, catalogDir.SetSymbolMetadata("EURUSD", map[string]string{"exchange": "NASDAQ", "sector": "Tech"}))
+			require.Nil(t, catalogDir.SetSymbolMetadata("USDJPY", map[string]string{"exchange": "NASDAQ"}))
+			require.Nil(t, catalogDir.SetSymbolMetadata("NZDUSD", map[string]string{"sector": "Consumer Goods"}))
+
+			// --- when ---
+			symbols, err := catalogDir.FilterSymbols(tt.filter)
+
+			// --- then ---
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantSymbols, symbols)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// FileMappings keeps the read-only memory mappings of the year files, shared by the readers.
+// A mapping is replaced by a new one when its file has grown, e.g. by the writes of variable length records,
+// and is unmapped once all the readers using it have released it.
+type FileMappings struct {
+	mu sync.Mutex
+	// files[Key]: Key is the fully specified path to the datafile
+	files map[string]*MappedFile
+}
+
+// MappedFile is a read-only memory mapping of a year file.
+// Data must not be used after Release.
+type MappedFile struct {
+	Data []byte
+
+	owner *FileMappings
+	// refs is the number of the readers using the mapping, and stale is true once the mapping is replaced.
+	// They are guarded by the mutex of the owner
+	refs  int
+	stale bool
+}
+
+func NewFileMappings() *FileMappings {
+	return &FileMappings{files: map[string]*MappedFile{}}
+}
+
+// Map returns the mapping of the whole file, which must be released after use.
+func (fm *FileMappings) Map(filePath string) (*MappedFile, error) {
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s to map: %w", filePath, err)
+	}
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if m, found := fm.files[filePath]; found {
+		if int64(len(m.Data)) >= fi.Size() {
+			m.refs++
+			return m, nil
+		}
+		// the file has grown
+		fm.replace(filePath, m)
+	}
+
+	// a reference for the reader, and another kept for the next readers until the mapping is replaced
+	m := &MappedFile{owner: fm, refs: 2}
+	if fi.Size() > 0 {
+		if m.Data, err = mmap(filePath, fi.Size()); err != nil {
+			return nil, err
+		}
+	}
+	fm.files[filePath] = m
+	return m, nil
+}
+
+// Invalidate drops the mappings of the files under the directory, e.g. when a time bucket is removed.
+func (fm *FileMappings) Invalidate(dirPath string) {
+	if fm == nil {
+		return
+	}
+	prefix := strings.TrimSuffix(dirPath, "/") + "/"
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	for filePath, m := range fm.files {
+		if strings.HasPrefix(filePath, prefix) {
+			fm.replace(filePath, m)
+		}
+	}
+}
+
+// Len returns the number of the files mapped.
+func (fm *FileMappings) Len() int {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	return len(fm.files)
+}
+
+// Release releases the mapping used by a reader.
+func (m *MappedFile) Release() {
+	m.owner.mu.Lock()
+	defer m.owner.mu.Unlock()
+	m.release()
+}
+
+// replace removes the mapping of the file, which is unmapped when all the readers have released it.
+func (fm *FileMappings) replace(filePath string, m *MappedFile) {
+	delete(fm.files, filePath)
+	m.stale = true
+	// the reference kept for the next readers
+	m.release()
+}
+
+func (m *MappedFile) release() {
+	m.refs--
+	if m.refs > 0 || !m.stale || m.Data == nil {
+		return
+	}
+	if err := syscall.Munmap(m.Data); err != nil {
+		log.Error("failed to unmap a data file", zap.Error(err))
+	}
+	m.Data = nil
+}
+
+func mmap(filePath string, size int64) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("open %s to map: %w", filePath, err)
+	}
+	// the mapping stays valid after the file is closed
+	defer func() {
+		if err2 := f.Close(); err2 != nil {
+			log.Error("failed to close a data file", zap.String("filepath", filePath), zap.Error(err2))
+		}
+	}()
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("map %s: %w", filePath, err)
+	}
+	return data, nil
+}
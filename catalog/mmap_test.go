@@ -0,0 +1,59 @@
+package catalog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+)
+
+func TestFileMappings(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "AAPL", "2021.bin")
+	require.Nil(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	require.Nil(t, os.WriteFile(filePath, []byte("abc"), 0o600))
+	fm := catalog.NewFileMappings()
+
+	// --- when a file is mapped twice ---
+	m1, err := fm.Map(filePath)
+	require.Nil(t, err)
+	m2, err := fm.Map(filePath)
+	require.Nil(t, err)
+
+	// --- then the mapping is shared ---
+	assert.Equal(t, []byte("abc"), m1.Data)
+	assert.Same(t, m1, m2)
+	assert.Equal(t, 1, fm.Len())
+	m2.Release()
+
+	// --- when the file grows ---
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.Nil(t, err)
+	_, err = f.WriteString("def")
+	require.Nil(t, err)
+	require.Nil(t, f.Close())
+	m3, err := fm.Map(filePath)
+	require.Nil(t, err)
+
+	// --- then the file is mapped again, and the old mapping stays valid until it's released ---
+	assert.Equal(t, []byte("abcdef"), m3.Data)
+	assert.Equal(t, []byte("abc"), m1.Data)
+	m1.Release()
+	assert.Nil(t, m1.Data)
+	assert.Equal(t, 1, fm.Len())
+
+	// --- when the directory of the file is removed ---
+	fm.Invalidate(dir + "/AAPL")
+
+	// --- then the mapping is dropped once it's released ---
+	assert.Equal(t, 0, fm.Len())
+	assert.Equal(t, []byte("abcdef"), m3.Data)
+	m3.Release()
+	assert.Nil(t, m3.Data)
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/alpacahq/marketstore/v4/utils/io"
 )
 
 func TestParseTime(t *testing.T) {
@@ -41,3 +43,30 @@ func TestParseTimestamp(t *testing.T) {
 	assert.Equal(t, err == nil, true)
 	assert.Equal(t, tt1 == tTest, true)
 }
+
+func TestColumnSeriesMapFromCSVData_Nullable(t *testing.T) {
+	t.Parallel()
+
+	tbk := io.NewTimeBucketKey("TEST/1Min/OHLCV")
+	csvRows := [][]string{
+		{"20161230 21:37:57", "1.5", "100"},
+		{"20161230 21:38:57", "", ""},
+		{"20161230 21:39:57", "2.5", "0"},
+	}
+	dataShapes := []io.DataShape{
+		{Name: "Close", Type: io.FLOAT32},
+		{Name: "Volume", Type: io.INT64, Nullable: true},
+	}
+
+	csm, err := columnSeriesMapFromCSVData(nil, *tbk, csvRows, []int{1, 2}, dataShapes)
+	assert.NotNil(t, err) // an empty field of a non-nullable column can't be parsed
+	assert.Nil(t, csm)
+
+	dataShapes[0].Nullable = true
+	csm, err = columnSeriesMapFromCSVData(nil, *tbk, csvRows, []int{1, 2}, dataShapes)
+	assert.Nil(t, err)
+	assert.Equal(t, []float32{1.5, 0, 2.5}, csm[*tbk].GetColumn("Close"))
+	assert.Equal(t, []bool{false, true, false}, csm[*tbk].GetNullMask("Close"))
+	assert.Equal(t, []int64{100, 0, 0}, csm[*tbk].GetColumn("Volume"))
+	assert.Equal(t, []bool{false, true, false}, csm[*tbk].GetNullMask("Volume"))
+}
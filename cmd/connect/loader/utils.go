@@ -93,7 +93,12 @@ func ReadMetadata(dataFD, controlFD *os.File, dbDataShapes []io.DataShape) (csvR
 		io.DataShape{Name: "Epoch-date", Type: io.INT64},
 		io.DataShape{Name: "Epoch-time", Type: io.INT64},
 	)
-	cvm.DSV = append(cvm.DSV, dbDataShapes...)
+	for _, ds := range dbDataShapes {
+		// the null mask column is built from the empty fields of the nullable columns
+		if ds.Name != io.NullMaskColumn {
+			cvm.DSV = append(cvm.DSV, ds)
+		}
+	}
 
 	var inputColNames []string
 	if dataFD == nil {
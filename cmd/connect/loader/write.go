@@ -3,6 +3,7 @@ package loader
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/alpacahq/marketstore/v4/utils/io"
 	"github.com/alpacahq/marketstore/v4/utils/log"
@@ -22,6 +23,10 @@ func columnSeriesMapFromCSVData(csmInit io.ColumnSeriesMap, key io.TimeBucketKey
 			/*
 				We skip the first column, as it's the Epoch and we parse that independently
 			*/
+			var nullMask []bool
+			if shape.Nullable {
+				nullMask = nullMaskFromCSVRows(csvRows, index, csvZeroValue(shape.Type))
+			}
 			switch shape.Type {
 			case io.STRING:
 				col := getStringColumnFromCSVRows(csvRows, index)
@@ -86,6 +91,13 @@ func columnSeriesMapFromCSVData(csmInit io.ColumnSeriesMap, key io.TimeBucketKey
 					return nil, fmt.Errorf("error obtaining column \"%s\" from csv data", shape.Name)
 				}
 				csm.AddColumn(key, shape.Name, col)
+			case io.DECIMAL64:
+				col, err := getDecimal64ColumnFromCSVRows(csvRows, index, shape.Scale)
+				if err != nil {
+					return nil, fmt.Errorf("error obtaining column \"%s\" from csv data", shape.Name)
+				}
+				csm.AddColumn(key, shape.Name, col)
+				csm[key].SetScale(shape.Name, shape.Scale)
 			case io.STRING16:
 				col := getString16ColumnFromCSVRows(csvRows, index)
 				csm.AddColumn(key, shape.Name, col)
@@ -100,11 +112,38 @@ func columnSeriesMapFromCSVData(csmInit io.ColumnSeriesMap, key io.TimeBucketKey
 					shape.Name,
 				)
 			}
+			if nullMask != nil {
+				csm[key].SetNullMask(shape.Name, nullMask)
+			}
 		}
 	}
 	return csm, nil
 }
 
+// nullMaskFromCSVRows returns the null mask of a nullable column, where an empty field is null.
+// The empty fields are replaced with the zero value so that the column can be parsed.
+func nullMaskFromCSVRows(csvRows [][]string, index int, zero string) []bool {
+	mask := make([]bool, len(csvRows))
+	for i, row := range csvRows {
+		if strings.TrimSpace(row[index]) == "" {
+			mask[i] = true
+			row[index] = zero
+		}
+	}
+	return mask
+}
+
+func csvZeroValue(elemType io.EnumElementType) string {
+	switch elemType {
+	case io.STRING, io.STRING16:
+		return ""
+	case io.BOOL:
+		return "false"
+	default:
+		return "0"
+	}
+}
+
 func getBoolColumnFromCSVRows(csvRows [][]string, index int) (col []bool, err error) {
 	col = make([]bool, len(csvRows))
 	for i, row := range csvRows {
@@ -242,6 +281,19 @@ func getUInt64ColumnFromCSVRows(csvRows [][]string, index int) (col []uint64, er
 	return col, nil
 }
 
+// getDecimal64ColumnFromCSVRows parses decimal strings without converting them to floats
+// so that the values are stored exactly.
+func getDecimal64ColumnFromCSVRows(csvRows [][]string, index int, scale int8) (col []io.Decimal64, err error) {
+	col = make([]io.Decimal64, len(csvRows))
+	for i, row := range csvRows {
+		col[i], err = io.ParseDecimal64(row[index], scale)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return col, nil
+}
+
 const String16RuneSize = 16
 
 func getString16ColumnFromCSVRows(csvRows [][]string, index int) (col [][String16RuneSize]rune) {
@@ -27,6 +27,11 @@ const (
 	urlFlag    = "url"
 	defaultURL = ""
 	urlDesc    = "network address to database instance at \"hostname:port\" when used in remote mode"
+	// Network Address of the gRPC API.
+	grpcURLFlag    = "grpc_url"
+	defaultGRPCURL = ""
+	grpcURLDesc    = "network address to the gRPC API of database instance at \"hostname:port\" " +
+		"to load data in a stream when used in remote mode"
 	// Local directory.
 	dirFlag           = "dir"
 	defaultDir        = ""
@@ -49,6 +54,8 @@ var (
 
 	// url set via flag for remote db address.
 	url string
+	// grpcURL set via flag for the gRPC API address of remote db.
+	grpcURL string
 	// dir set via flag for local directory location.
 	dir string
 	// turns compression of variable data off.
@@ -58,6 +65,7 @@ var (
 // nolint:gochecknoinits // cobra's standard way to initialize flags
 func init() {
 	Cmd.Flags().StringVarP(&url, urlFlag, "u", defaultURL, urlDesc)
+	Cmd.Flags().StringVarP(&grpcURL, grpcURLFlag, "g", defaultGRPCURL, grpcURLDesc)
 	Cmd.Flags().StringVarP(&dir, dirFlag, "d", defaultDir, dirDesc)
 	Cmd.Flags().BoolVarP(&varCompOff, "disable_variable_compression", "c", defaultVarCompOff, varCompOffDesc)
 }
@@ -104,7 +112,20 @@ func executeConnect(cmd *cobra.Command, args []string) error {
 			return err2
 		}
 
-		conn = session.NewRemoteAPIClient(url, rpcClient)
+		remote := session.NewRemoteAPIClient(url, rpcClient)
+		if grpcURL != "" {
+			grpcClient, err2 := client.NewGRPCClient(grpcURL)
+			if err2 != nil {
+				return err2
+			}
+			defer func() {
+				if err3 := grpcClient.Close(); err3 != nil {
+					log.Error(fmt.Sprintf("failed to close gRPC client connection. err=%v", err3))
+				}
+			}()
+			remote.SetGRPCClient(grpcClient)
+		}
+		conn = remote
 	}
 
 	if varCompOff {
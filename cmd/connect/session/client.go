@@ -49,6 +49,9 @@ type APIClient interface {
 	Create(reqs *frontend.MultiCreateRequest, responses *frontend.MultiServerResponse) error
 	// Write executes a write operation to the marketstore server.
 	Write(reqs *frontend.MultiWriteRequest, responses *frontend.MultiServerResponse) error
+	// WriteStream opens a stream to write a sequence of the requests to the marketstore server.
+	// It returns ErrWriteStreamUnavailable if the server can't be connected with a stream.
+	WriteStream() (frontend.WriteStreamer, error)
 	// Destroy deletes a bucket from the marketstore server.
 	Destroy(reqs *frontend.MultiKeyRequest, responses *frontend.MultiServerResponse) error
 	// ProcessShow returns data stored in the marketstore server.
@@ -57,8 +60,23 @@ type APIClient interface {
 	GetBucketInfo(reqs *frontend.MultiKeyRequest, responses *frontend.MultiGetInfoResponse) error
 	// SQL executes the specified sql statement
 	SQL(line string) (cs *dbio.ColumnSeries, err error)
+	// ImportCorporateActions stores the corporate actions used by the adjust function.
+	ImportCorporateActions(reqs *frontend.MultiCorporateActionRequest, responses *frontend.MultiServerResponse) error
+	// RenameSymbols renames the symbols, keeping the old symbols as the aliases of the new ones.
+	RenameSymbols(reqs *frontend.MultiRenameSymbolRequest, responses *frontend.MultiServerResponse) error
+	// SetSymbolAliases adds or removes the aliases of the symbols.
+	SetSymbolAliases(reqs *frontend.MultiSymbolAliasRequest, responses *frontend.MultiServerResponse) error
+	// ListSymbolAliases returns the alias table of the symbols.
+	ListSymbolAliases(response *frontend.ListSymbolAliasesResponse) error
+	// SetSymbolMetadata merges the attributes to the metadata of the symbols.
+	SetSymbolMetadata(reqs *frontend.MultiSymbolMetadataRequest, responses *frontend.MultiServerResponse) error
+	// GetSymbolMetadata returns the metadata of the symbols.
+	GetSymbolMetadata(req *frontend.GetSymbolMetadataRequest, response *frontend.GetSymbolMetadataResponse) error
 }
 
+// ErrWriteStreamUnavailable is returned by APIClient.WriteStream if the address of the gRPC API is not set.
+var ErrWriteStreamUnavailable = errors.New("write stream is unavailable without the address of the gRPC API")
+
 // RPCClient is a marketstore API client interface.
 type RPCClient interface {
 	DoRPC(functionName string, args interface{}) (response interface{}, err error)
@@ -66,17 +84,22 @@ type RPCClient interface {
 
 func commandMap(c *Client) map[string]func(line string) error {
 	return map[string]func(line string) error{
-		`\o`:       c.setOutputTarget,
-		`\timing`:  c.flipPrintTimeFlag,
-		`\show`:    c.show,
-		`\trim`:    c.trim,
-		`\load`:    c.load,
-		`\create`:  c.create,
-		`\destroy`: c.destroy,
-		`\getinfo`: c.getinfo,
-		`help`:     c.functionHelp,
-		`\help`:    c.functionHelp,
-		`\?`:       c.functionHelp,
+		`\o`:           c.setOutputTarget,
+		`\timing`:      c.flipPrintTimeFlag,
+		`\show`:        c.show,
+		`\trim`:        c.trim,
+		`\load`:        c.load,
+		`\create`:      c.create,
+		`\destroy`:     c.destroy,
+		`\getinfo`:     c.getinfo,
+		`\corpactions`: c.corpactions,
+		`\rename`:      c.rename,
+		`\alias`:       c.alias,
+		`\unalias`:     c.unalias,
+		`\meta`:        c.meta,
+		`help`:         c.functionHelp,
+		`\help`:        c.functionHelp,
+		`\?`:           c.functionHelp,
 	}
 }
 
@@ -289,11 +312,18 @@ func printResult(queryText string, cs *dbio.ColumnSeries, optionalFile ...string
 			element string
 		)
 		for _, name := range cs.GetColumnNames() {
-			if strings.EqualFold(name, "Epoch") {
+			switch {
+			case strings.EqualFold(name, "Epoch"):
 				element = fmt.Sprintf("%29s", dbio.ToSystemTimezone(time.Unix(ts, 0)).String()) // Epoch
-			} else {
+			case cs.IsNull(name, i):
+				// a null value is an empty field in CSV so that it's loaded as null again
+				element = ""
+				if w == nil {
+					element = fmt.Sprintf("%*s", columnFormatLength(name, cs.GetColumn(name)), "NULL")
+				}
+			default:
 				// colType := reflect.TypeOf(icol).Elem().Kind()
-				element, err = convertToElement(cs.GetColumn(name), i, name)
+				element, err = convertToElement(cs.GetColumn(name), i, name, cs.GetScale(name))
 				if err != nil {
 					return err
 				}
@@ -325,7 +355,7 @@ func printResult(queryText string, cs *dbio.ColumnSeries, optionalFile ...string
 	return err
 }
 
-func convertToElement(icol interface{}, i int, columnName string) (string, error) {
+func convertToElement(icol interface{}, i int, columnName string, scale int8) (string, error) {
 	var element string
 	switch col := icol.(type) {
 	case []float32:
@@ -348,6 +378,8 @@ func convertToElement(icol interface{}, i int, columnName string) (string, error
 		element = strconv.FormatUint(uint64(col[i]), 10)
 	case []uint64:
 		element = strconv.FormatUint(col[i], 10)
+	case []dbio.Decimal64:
+		element = col[i].Format(scale)
 	case []bool:
 		element = "FALSE"
 		if col[i] {
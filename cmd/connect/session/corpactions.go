@@ -0,0 +1,141 @@
+package session
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// corporateActionsDateLayout is the layout of the dates in the corporate actions CSV file.
+const corporateActionsDateLayout = "2006-01-02"
+
+// corporateActionsColumns are the columns of the corporate actions CSV file, in any order.
+// known_at, ex_date and effective_date are dates such as 2021-06-30.
+var corporateActionsColumns = []string{
+	"symbol", "id", "type", "known_at", "ex_date", "effective_date", "ratio", "cash_amount", "old_symbol", "canceled",
+}
+
+// corpactions imports the corporate actions in a CSV file.
+func (c *Client) corpactions(line string) error {
+	args := strings.Split(line, " ")
+	args = args[1:] // chop off the first word which should be "corpactions"
+	if len(args) != 1 {
+		log.Error("Please specify a CSV file of the corporate actions. (e.g. \\corpactions actions.csv)\n")
+		return nil
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("open corporate actions file %s: %w", args[0], err)
+	}
+	defer func() {
+		if err2 := f.Close(); err2 != nil {
+			log.Error("failed to close %s: %v", args[0], err2)
+		}
+	}()
+	reqs, err := readCorporateActions(f)
+	if err != nil {
+		return fmt.Errorf("read corporate actions file %s: %w", args[0], err)
+	}
+
+	responses := &frontend.MultiServerResponse{}
+	if err = c.apiClient.ImportCorporateActions(reqs, responses); err != nil {
+		return fmt.Errorf("import corporate actions: %w", err)
+	}
+	var failed int
+	for i, resp := range responses.Responses {
+		if resp.Error != "" {
+			// the header is the first line
+			log.Error("line %d: %s\n", i+2, resp.Error)
+			failed++
+		}
+	}
+	fmt.Printf("Imported %d corporate actions\n", len(reqs.Requests)-failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d corporate actions", failed)
+	}
+	return nil
+}
+
+// readCorporateActions reads the corporate actions from a CSV file with a header line of the column names.
+func readCorporateActions(r io.Reader) (*frontend.MultiCorporateActionRequest, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no header line")
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, name := range []string{"symbol", "type", "known_at", "ex_date"} {
+		if _, found := columns[name]; !found {
+			return nil, fmt.Errorf("%s column is required, the columns are %v", name, corporateActionsColumns)
+		}
+	}
+
+	reqs := &frontend.MultiCorporateActionRequest{Requests: make([]frontend.CorporateActionRequest, 0, len(records)-1)}
+	for i, record := range records[1:] {
+		req, err := parseCorporateAction(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		reqs.Requests = append(reqs.Requests, *req)
+	}
+	return reqs, nil
+}
+
+func parseCorporateAction(record []string, columns map[string]int) (*frontend.CorporateActionRequest, error) {
+	value := func(name string) string {
+		if i, found := columns[name]; found && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	req := &frontend.CorporateActionRequest{
+		Symbol:    value("symbol"),
+		Type:      value("type"),
+		OldSymbol: value("old_symbol"),
+	}
+
+	var err error
+	if s := value("id"); s != "" {
+		if req.ID, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("parse id: %w", err)
+		}
+	}
+	dates := map[string]*int64{"known_at": &req.KnownAt, "ex_date": &req.ExDate, "effective_date": &req.EffectiveDate}
+	for name, epoch := range dates {
+		if s := value(name); s != "" {
+			t, err2 := time.Parse(corporateActionsDateLayout, s)
+			if err2 != nil {
+				return nil, fmt.Errorf("parse %s: %w", name, err2)
+			}
+			*epoch = t.Unix()
+		}
+	}
+	floats := map[string]*float64{"ratio": &req.Ratio, "cash_amount": &req.CashAmount}
+	for name, f := range floats {
+		if s := value(name); s != "" {
+			if *f, err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", name, err)
+			}
+		}
+	}
+	if s := value("canceled"); s != "" {
+		if req.Canceled, err = strconv.ParseBool(s); err != nil {
+			return nil, fmt.Errorf("parse canceled: %w", err)
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,105 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/cmd/connect/session/mock"
+	"github.com/alpacahq/marketstore/v4/frontend"
+)
+
+func TestClient_corpactions(t *testing.T) {
+	t.Parallel()
+
+	unixDate := func(year int, month time.Month, day int) int64 {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix()
+	}
+
+	tests := map[string]struct {
+		csv      string
+		resp     *frontend.MultiServerResponse
+		wantReqs []frontend.CorporateActionRequest
+		wantErr  bool
+	}{
+		"ok/ split, dividend and ticker change": {
+			csv: "symbol,id,type,known_at,ex_date,effective_date,ratio,cash_amount,old_symbol,canceled\n" +
+				"AAPL,1,split,2020-07-30,2020-08-31,2020-08-28,4,,,\n" +
+				"AAPL,2,cash_dividend,2020-07-30,2020-08-07,,,0.82,,false\n" +
+				"META,3,ticker_change,2022-06-01,2022-06-09,,,,FB,\n",
+			resp: &frontend.MultiServerResponse{Responses: []frontend.ServerResponse{{}, {}, {}}},
+			wantReqs: []frontend.CorporateActionRequest{
+				{
+					Symbol: "AAPL", ID: 1, Type: "split", KnownAt: unixDate(2020, 7, 30), ExDate: unixDate(2020, 8, 31),
+					EffectiveDate: unixDate(2020, 8, 28), Ratio: 4,
+				},
+				{
+					Symbol: "AAPL", ID: 2, Type: "cash_dividend", KnownAt: unixDate(2020, 7, 30),
+					ExDate: unixDate(2020, 8, 7), CashAmount: 0.82,
+				},
+				{
+					Symbol: "META", ID: 3, Type: "ticker_change", KnownAt: unixDate(2022, 6, 1),
+					ExDate: unixDate(2022, 6, 9), OldSymbol: "FB",
+				},
+			},
+		},
+		"ok/ columns in any order": {
+			csv:  "type,ex_date,symbol,known_at,canceled\nsplit,2020-08-31,AAPL,2020-08-01,true\n",
+			resp: &frontend.MultiServerResponse{Responses: []frontend.ServerResponse{{}}},
+			wantReqs: []frontend.CorporateActionRequest{
+				{
+					Symbol: "AAPL", Type: "split", KnownAt: unixDate(2020, 8, 1), ExDate: unixDate(2020, 8, 31),
+					Canceled: true,
+				},
+			},
+		},
+		"ng/ an action is rejected by the server": {
+			csv:  "symbol,type,known_at,ex_date,ratio\nAAPL,split,2020-07-30,2020-08-31,0\n",
+			resp: &frontend.MultiServerResponse{Responses: []frontend.ServerResponse{{Error: "invalid ratio"}}},
+			wantReqs: []frontend.CorporateActionRequest{
+				{Symbol: "AAPL", Type: "split", KnownAt: unixDate(2020, 7, 30), ExDate: unixDate(2020, 8, 31)},
+			},
+			wantErr: true,
+		},
+		"ng/ no ex_date column": {
+			csv:     "symbol,type,known_at\nAAPL,split,2020-07-30\n",
+			wantErr: true,
+		},
+		"ng/ invalid date": {
+			csv:     "symbol,type,known_at,ex_date\nAAPL,split,2020/07/30,2020-08-31\n",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			csvFile := filepath.Join(t.TempDir(), "actions.csv")
+			require.Nil(t, os.WriteFile(csvFile, []byte(tt.csv), 0o600))
+			mockCtrl := gomock.NewController(t)
+			mockClient := mock.NewMockAPIClient(mockCtrl)
+			if tt.wantReqs != nil {
+				mockClient.EXPECT().ImportCorporateActions(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(reqs *frontend.MultiCorporateActionRequest, responses *frontend.MultiServerResponse) error {
+						assert.Equal(t, tt.wantReqs, reqs.Requests)
+						*responses = *tt.resp
+						return nil
+					},
+				)
+			}
+
+			// --- when ---
+			err := NewClient(mockClient).corpactions(`\corpactions ` + csvFile)
+
+			// --- then ---
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -56,17 +56,34 @@ where:
 	in a row: name1,name2,name3/type:name4,name5/type:name6/type
 - Example: We have OHLCV data where prices are 32-bit floats and volume is 32-bit int:
 	<row data shape schema> = Open,High,Low,Close/float32:Volume/int32
+- Example: A "?" suffix makes the columns nullable, so that a missing volume is stored as null instead of 0:
+	<row data shape schema> = Open,High,Low,Close/float32:Volume/int32?
 
 <row-type>: The type of rows to be stored, one of "fixed" or "variable":
 - Example: We are storing tick data, where each time interval can contain a variable
 number of rows:
 	<row-type> = variable`
+
+	helpAlias = `The alias and unalias commands manage the aliases of the symbols.
+The queries and writes for an alias resolve to the symbol that it refers to.
+
+Syntax:
+
+	>> \alias                    (lists the aliases)
+	>> \alias <alias> <symbol>
+	>> \unalias <alias>
+
+- Example:
+
+	>> \alias FB META
+	>> \unalias FB
+`
 )
 
 var helps = map[string]string{
 	"help": `Usage: \help command_name
 
-Available commands: o, timing, show, trim, gaps, load, create, destroy, feed`,
+Available commands: o, timing, show, trim, gaps, load, create, destroy, corpactions, rename, alias, unalias, meta, feed`,
 	"o": `Sends output to the provided file name
 
 Syntax:
@@ -108,6 +125,60 @@ specify the epoch-date and epoch-time columns in the columnNameMap
 `,
 	"create":  helpCreateDestroy,
 	"destroy": helpCreateDestroy,
+	"corpactions": `The corpactions command imports the corporate actions used by the adjust function from a csv file.
+
+Syntax:
+
+	>> \corpactions <csv input file>
+
+The first row has the column names, in any order:
+	symbol:         the symbol of the action
+	id:             identifies the action among its revisions, unique per symbol
+	type:           split, stock_dividend, cash_dividend or ticker_change
+	known_at:       the knowledge date of the revision, e.g. 2021-06-30
+	ex_date:        the first day the prices reflect the action
+	effective_date: (optional) the date the action takes effect
+	ratio:          the new shares per old share of a split or stock dividend, e.g. 2 for a 2-for-1 split
+	cash_amount:    the cash dividend per share
+	old_symbol:     the previous symbol of a ticker change
+	canceled:       (optional) true to cancel the action as of the knowledge date
+
+- Example:
+
+	symbol,id,type,known_at,ex_date,ratio,cash_amount,old_symbol,canceled
+	AAPL,1,split,2020-07-30,2020-08-31,4,,,
+	AAPL,2,cash_dividend,2020-07-30,2020-08-07,,0.82,,
+	AAPL,2,cash_dividend,2020-08-01,2020-08-07,,,,true
+`,
+	"rename": `The rename command renames a symbol, e.g. when the company changes its ticker.
+
+Syntax:
+
+	>> \rename <old symbol> <new symbol> [link]
+
+The data of the old symbol is moved to the new symbol, which must not exist,
+and the old symbol becomes an alias of the new one so that the queries and writes for either symbol resolve.
+With "link", the year files of the old symbol are hard-linked to the new symbol instead, and the old symbol is kept.
+
+- Example:
+
+	>> \rename FB META
+`,
+	"alias":   helpAlias,
+	"unalias": helpAlias,
+	"meta": `The meta command shows or sets the metadata of a symbol, the static attributes like CUSIP, exchange,
+sector or lot size. The attributes are merged to the metadata of the symbol, and an attribute with an empty value
+is removed.
+
+Syntax:
+
+	>> \meta <symbol>                          (shows the metadata)
+	>> \meta <symbol> <key>=<value> [<key>=<value>...]
+
+- Example:
+
+	>> \meta AAPL exchange=NASDAQ sector=Tech lot_size=
+`,
 }
 
 // functionHelp prints helpful information about specific commands.
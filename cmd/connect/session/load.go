@@ -23,7 +23,7 @@ import (
 			Epoch
 			20161230 21:37:57 140000
 */
-func (c *Client) load(line string) error {
+func (c *Client) load(line string) (err error) {
 	tbk, dataFD, loaderFD, cleanup, err := parseLine(line)
 	if err != nil {
 		return fmt.Errorf("failed to parse line: %w", err)
@@ -48,6 +48,29 @@ func (c *Client) load(line string) error {
 		return fmt.Errorf("error: %w", err)
 	}
 
+	/*
+		Write the chunks in a stream if it's available, otherwise by a Write request for each chunk
+	*/
+	isVariable := resp.RecordType == io.VARIABLE
+	write := func(npm *io.NumpyMultiDataset) error { return writeNumpy(c, npm, isVariable) }
+	ws, err := c.apiClient.WriteStream()
+	switch {
+	case err == nil:
+		defer func() {
+			// the chunks sent before an error are written too
+			if err2 := closeWriteStream(ws); err == nil {
+				err = err2
+			}
+		}()
+		write = func(npm *io.NumpyMultiDataset) error {
+			return ws.Send(&frontend.WriteRequest{Data: npm, IsVariableLength: isVariable})
+		}
+	case errors.Is(err, ErrWriteStreamUnavailable):
+		log.Debug("write the chunks by Write requests: %v", err)
+	default:
+		return fmt.Errorf("open a write stream: %w", err)
+	}
+
 	/*
 		Read the CSV data in chunks until the end of the file
 	*/
@@ -55,7 +78,7 @@ func (c *Client) load(line string) error {
 		chunkSize := 1000000
 		// chunkSize := 100
 
-		npm, endReached, err := loader.CSVtoNumpyMulti(csvReader, *tbk, cvm, chunkSize, resp.RecordType == io.VARIABLE)
+		npm, endReached, err := loader.CSVtoNumpyMulti(csvReader, *tbk, cvm, chunkSize, isVariable)
 		if err != nil {
 			log.Error("Error: ", err.Error())
 			return fmt.Errorf("error: %w", err)
@@ -74,7 +97,7 @@ func (c *Client) load(line string) error {
 			*/
 			// LAL ^^^^^^^^^^^^^^^^^^ DEBUG
 
-			err = writeNumpy(c, npm, resp.RecordType == io.VARIABLE)
+			err = write(npm)
 			if err != nil {
 				log.Error("Error: ", err.Error())
 				return fmt.Errorf("error: %w", err)
@@ -92,6 +115,29 @@ func (c *Client) load(line string) error {
 	return nil
 }
 
+// closeWriteStream closes the write stream, and reports the results of the written chunks.
+func closeWriteStream(ws frontend.WriteStreamer) error {
+	resp, err := ws.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	var errs []string
+	errs = append(errs, resp.Errors...)
+	for key, result := range resp.Results {
+		for _, v := range result.Violations {
+			log.Warn("%d rows of %s violated %s (%s): %s", v.Rows, v.Key, v.Rule, v.Action, v.Message)
+		}
+		for _, e := range result.Errors {
+			errs = append(errs, fmt.Sprintf("%s: %s", key, e))
+		}
+	}
+	log.Info("%d rows written", resp.Rows())
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
 func parseLine(line string) (tbk *io.TimeBucketKey, dataFD, loaderFD *os.File, cleanup func(), err error) {
 	cleanup = func() {}
 	args := strings.Split(line, " ")
@@ -131,7 +177,13 @@ func writeNumpy(c *Client, npm *io.NumpyMultiDataset, isVariable bool) (err erro
 		Process the single response
 	*/
 	if len(responses.Responses) != 0 {
-		return fmt.Errorf("%s", responses.Responses[0].Error)
+		resp := responses.Responses[0]
+		for _, v := range resp.Violations {
+			log.Warn("%d rows of %s violated %s (%s): %s", v.Rows, v.Key, v.Rule, v.Action, v.Message)
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -69,6 +70,24 @@ func (lc *LocalAPIClient) Write(reqs *frontend.MultiWriteRequest, responses *fro
 	return nil
 }
 
+func (lc *LocalAPIClient) WriteStream() (frontend.WriteStreamer, error) {
+	return &localWriteStream{sw: frontend.NewStreamWriter(lc.writer)}, nil
+}
+
+// localWriteStream writes the requests of a write stream to the local db instance.
+type localWriteStream struct {
+	sw *frontend.StreamWriter
+}
+
+func (s *localWriteStream) Send(req *frontend.WriteRequest) error {
+	s.sw.Write(req)
+	return nil
+}
+
+func (s *localWriteStream) CloseAndRecv() (*frontend.WriteStreamResponse, error) {
+	return s.sw.Close(), nil
+}
+
 func (lc *LocalAPIClient) Show(tbk *io.TimeBucketKey, start, end *time.Time,
 ) (csm io.ColumnSeriesMap, err error) {
 	if start == nil && end == nil {
@@ -84,7 +103,7 @@ func (lc *LocalAPIClient) Show(tbk *io.TimeBucketKey, start, end *time.Time,
 	log.Info("Query range: %v to %v\n", start, end)
 
 	qs := frontend.NewQueryService(lc.catalogDir)
-	csm, err = qs.ExecuteQuery(tbk, *start, *end, 0, false, nil)
+	csm, err = qs.ExecuteQuery(context.Background(), tbk, *start, *end, 0, false, nil)
 	if err != nil {
 		log.Error("Error return from query: %v", err)
 		return
@@ -109,6 +128,46 @@ func (lc *LocalAPIClient) GetBucketInfo(reqs *frontend.MultiKeyRequest, response
 	return ds.GetInfo(nil, reqs, responses)
 }
 
+func (lc *LocalAPIClient) ImportCorporateActions(reqs *frontend.MultiCorporateActionRequest,
+	responses *frontend.MultiServerResponse,
+) error {
+	ds := frontend.NewDataService(lc.dir, lc.catalogDir, lc.aggRunner, lc.writer, lc.query)
+	return ds.ImportCorporateActions(nil, reqs, responses)
+}
+
+func (lc *LocalAPIClient) RenameSymbols(reqs *frontend.MultiRenameSymbolRequest,
+	responses *frontend.MultiServerResponse,
+) error {
+	ds := frontend.NewDataService(lc.dir, lc.catalogDir, lc.aggRunner, lc.writer, lc.query)
+	return ds.RenameSymbols(nil, reqs, responses)
+}
+
+func (lc *LocalAPIClient) SetSymbolAliases(reqs *frontend.MultiSymbolAliasRequest,
+	responses *frontend.MultiServerResponse,
+) error {
+	ds := frontend.NewDataService(lc.dir, lc.catalogDir, lc.aggRunner, lc.writer, lc.query)
+	return ds.SetSymbolAliases(nil, reqs, responses)
+}
+
+func (lc *LocalAPIClient) ListSymbolAliases(response *frontend.ListSymbolAliasesResponse) error {
+	ds := frontend.NewDataService(lc.dir, lc.catalogDir, lc.aggRunner, lc.writer, lc.query)
+	return ds.ListSymbolAliases(nil, &frontend.ListSymbolAliasesRequest{}, response)
+}
+
+func (lc *LocalAPIClient) SetSymbolMetadata(reqs *frontend.MultiSymbolMetadataRequest,
+	responses *frontend.MultiServerResponse,
+) error {
+	ds := frontend.NewDataService(lc.dir, lc.catalogDir, lc.aggRunner, lc.writer, lc.query)
+	return ds.SetSymbolMetadata(nil, reqs, responses)
+}
+
+func (lc *LocalAPIClient) GetSymbolMetadata(req *frontend.GetSymbolMetadataRequest,
+	response *frontend.GetSymbolMetadataResponse,
+) error {
+	ds := frontend.NewDataService(lc.dir, lc.catalogDir, lc.aggRunner, lc.writer, lc.query)
+	return ds.GetSymbolMetadata(nil, req, response)
+}
+
 func (lc *LocalAPIClient) SQL(line string) (cs *io.ColumnSeries, err error) {
 	queryTree, err := sqlparser.BuildQueryTree(line)
 	if err != nil {
@@ -118,7 +177,7 @@ func (lc *LocalAPIClient) SQL(line string) (cs *io.ColumnSeries, err error) {
 	if err != nil {
 		return nil, err
 	}
-	cs, err = es.Materialize(lc.aggRunner, lc.catalogDir)
+	cs, err = es.Materialize(lc.aggRunner, lc.catalogDir, lc.writer)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +78,48 @@ func (mr *MockAPIClientMockRecorder) GetBucketInfo(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketInfo", reflect.TypeOf((*MockAPIClient)(nil).GetBucketInfo), arg0, arg1)
 }
 
+// GetSymbolMetadata mocks base method.
+func (m *MockAPIClient) GetSymbolMetadata(arg0 *frontend.GetSymbolMetadataRequest, arg1 *frontend.GetSymbolMetadataResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSymbolMetadata", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetSymbolMetadata indicates an expected call of GetSymbolMetadata.
+func (mr *MockAPIClientMockRecorder) GetSymbolMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSymbolMetadata", reflect.TypeOf((*MockAPIClient)(nil).GetSymbolMetadata), arg0, arg1)
+}
+
+// ImportCorporateActions mocks base method.
+func (m *MockAPIClient) ImportCorporateActions(arg0 *frontend.MultiCorporateActionRequest, arg1 *frontend.MultiServerResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCorporateActions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCorporateActions indicates an expected call of ImportCorporateActions.
+func (mr *MockAPIClientMockRecorder) ImportCorporateActions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCorporateActions", reflect.TypeOf((*MockAPIClient)(nil).ImportCorporateActions), arg0, arg1)
+}
+
+// ListSymbolAliases mocks base method.
+func (m *MockAPIClient) ListSymbolAliases(arg0 *frontend.ListSymbolAliasesResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSymbolAliases", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListSymbolAliases indicates an expected call of ListSymbolAliases.
+func (mr *MockAPIClientMockRecorder) ListSymbolAliases(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSymbolAliases", reflect.TypeOf((*MockAPIClient)(nil).ListSymbolAliases), arg0)
+}
+
 // PrintConnectInfo mocks base method.
 func (m *MockAPIClient) PrintConnectInfo() {
 	m.ctrl.T.Helper()
@@ -90,6 +132,20 @@ func (mr *MockAPIClientMockRecorder) PrintConnectInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintConnectInfo", reflect.TypeOf((*MockAPIClient)(nil).PrintConnectInfo))
 }
 
+// RenameSymbols mocks base method.
+func (m *MockAPIClient) RenameSymbols(arg0 *frontend.MultiRenameSymbolRequest, arg1 *frontend.MultiServerResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameSymbols", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameSymbols indicates an expected call of RenameSymbols.
+func (mr *MockAPIClientMockRecorder) RenameSymbols(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameSymbols", reflect.TypeOf((*MockAPIClient)(nil).RenameSymbols), arg0, arg1)
+}
+
 // SQL mocks base method.
 func (m *MockAPIClient) SQL(arg0 string) (*io.ColumnSeries, error) {
 	m.ctrl.T.Helper()
@@ -105,6 +161,34 @@ func (mr *MockAPIClientMockRecorder) SQL(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SQL", reflect.TypeOf((*MockAPIClient)(nil).SQL), arg0)
 }
 
+// SetSymbolAliases mocks base method.
+func (m *MockAPIClient) SetSymbolAliases(arg0 *frontend.MultiSymbolAliasRequest, arg1 *frontend.MultiServerResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSymbolAliases", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSymbolAliases indicates an expected call of SetSymbolAliases.
+func (mr *MockAPIClientMockRecorder) SetSymbolAliases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSymbolAliases", reflect.TypeOf((*MockAPIClient)(nil).SetSymbolAliases), arg0, arg1)
+}
+
+// SetSymbolMetadata mocks base method.
+func (m *MockAPIClient) SetSymbolMetadata(arg0 *frontend.MultiSymbolMetadataRequest, arg1 *frontend.MultiServerResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSymbolMetadata", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSymbolMetadata indicates an expected call of SetSymbolMetadata.
+func (mr *MockAPIClientMockRecorder) SetSymbolMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSymbolMetadata", reflect.TypeOf((*MockAPIClient)(nil).SetSymbolMetadata), arg0, arg1)
+}
+
 // Show mocks base method.
 func (m *MockAPIClient) Show(arg0 *io.TimeBucketKey, arg1, arg2 *time.Time) (io.ColumnSeriesMap, error) {
 	m.ctrl.T.Helper()
@@ -133,3 +217,18 @@ func (mr *MockAPIClientMockRecorder) Write(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockAPIClient)(nil).Write), arg0, arg1)
 }
+
+// WriteStream mocks base method.
+func (m *MockAPIClient) WriteStream() (frontend.WriteStreamer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteStream")
+	ret0, _ := ret[0].(frontend.WriteStreamer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteStream indicates an expected call of WriteStream.
+func (mr *MockAPIClientMockRecorder) WriteStream() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteStream", reflect.TypeOf((*MockAPIClient)(nil).WriteStream))
+}
@@ -1,11 +1,13 @@
 package session
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/frontend/client"
 	"github.com/alpacahq/marketstore/v4/planner"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
@@ -23,6 +25,13 @@ type RemoteAPIClient struct {
 	url string
 	// rpcClient is the optional remote client.
 	rpcClient RPCClient
+	// grpcClient is the optional client of the gRPC API for the write streams.
+	grpcClient *client.GRPCClient
+}
+
+// SetGRPCClient enables the write streams of the gRPC API.
+func (rc *RemoteAPIClient) SetGRPCClient(cl *client.GRPCClient) {
+	rc.grpcClient = cl
 }
 
 func (rc *RemoteAPIClient) PrintConnectInfo() {
@@ -46,6 +55,17 @@ func (rc *RemoteAPIClient) Write(reqs *frontend.MultiWriteRequest, responses *fr
 	return nil
 }
 
+func (rc *RemoteAPIClient) WriteStream() (frontend.WriteStreamer, error) {
+	if rc.grpcClient == nil {
+		return nil, ErrWriteStreamUnavailable
+	}
+	ws, err := rc.grpcClient.WriteStream(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
 func (rc *RemoteAPIClient) Show(tbk *io.TimeBucketKey, start, end *time.Time) (csm io.ColumnSeriesMap, err error) {
 	if end == nil {
 		t := planner.MaxTime
@@ -113,6 +133,110 @@ func (rc *RemoteAPIClient) Destroy(reqs *frontend.MultiKeyRequest, responses *fr
 	return nil
 }
 
+func (rc *RemoteAPIClient) ImportCorporateActions(reqs *frontend.MultiCorporateActionRequest,
+	responses *frontend.MultiServerResponse,
+) error {
+	var respI interface{}
+	respI, err := rc.rpcClient.DoRPC("ImportCorporateActions", reqs)
+	if err != nil {
+		return fmt.Errorf("DoRPC:ImportCorporateActions error:%w", err)
+	}
+	if respI != nil {
+		if val, ok := respI.(*frontend.MultiServerResponse); ok {
+			*responses = *val
+		} else {
+			return fmt.Errorf("[bug] unexpected data type returned from DoRPC:ImportCorporateActions func. resp=%v", respI)
+		}
+	}
+	return nil
+}
+
+func (rc *RemoteAPIClient) RenameSymbols(reqs *frontend.MultiRenameSymbolRequest,
+	responses *frontend.MultiServerResponse,
+) error {
+	var respI interface{}
+	respI, err := rc.rpcClient.DoRPC("RenameSymbols", reqs)
+	if err != nil {
+		return fmt.Errorf("DoRPC:RenameSymbols error:%w", err)
+	}
+	if respI != nil {
+		if val, ok := respI.(*frontend.MultiServerResponse); ok {
+			*responses = *val
+		} else {
+			return fmt.Errorf("[bug] unexpected data type returned from DoRPC:RenameSymbols func. resp=%v", respI)
+		}
+	}
+	return nil
+}
+
+func (rc *RemoteAPIClient) SetSymbolAliases(reqs *frontend.MultiSymbolAliasRequest,
+	responses *frontend.MultiServerResponse,
+) error {
+	var respI interface{}
+	respI, err := rc.rpcClient.DoRPC("SetSymbolAliases", reqs)
+	if err != nil {
+		return fmt.Errorf("DoRPC:SetSymbolAliases error:%w", err)
+	}
+	if respI != nil {
+		if val, ok := respI.(*frontend.MultiServerResponse); ok {
+			*responses = *val
+		} else {
+			return fmt.Errorf("[bug] unexpected data type returned from DoRPC:SetSymbolAliases func. resp=%v", respI)
+		}
+	}
+	return nil
+}
+
+func (rc *RemoteAPIClient) ListSymbolAliases(response *frontend.ListSymbolAliasesResponse) error {
+	respI, err := rc.rpcClient.DoRPC("ListSymbolAliases", &frontend.ListSymbolAliasesRequest{})
+	if err != nil {
+		return fmt.Errorf("DoRPC:ListSymbolAliases error:%w", err)
+	}
+	if respI != nil {
+		if val, ok := respI.(map[string]string); ok {
+			response.Aliases = val
+		} else {
+			return fmt.Errorf("[bug] unexpected data type returned from DoRPC:ListSymbolAliases func. resp=%v", respI)
+		}
+	}
+	return nil
+}
+
+func (rc *RemoteAPIClient) SetSymbolMetadata(reqs *frontend.MultiSymbolMetadataRequest,
+	responses *frontend.MultiServerResponse,
+) error {
+	var respI interface{}
+	respI, err := rc.rpcClient.DoRPC("SetSymbolMetadata", reqs)
+	if err != nil {
+		return fmt.Errorf("DoRPC:SetSymbolMetadata error:%w", err)
+	}
+	if respI != nil {
+		if val, ok := respI.(*frontend.MultiServerResponse); ok {
+			*responses = *val
+		} else {
+			return fmt.Errorf("[bug] unexpected data type returned from DoRPC:SetSymbolMetadata func. resp=%v", respI)
+		}
+	}
+	return nil
+}
+
+func (rc *RemoteAPIClient) GetSymbolMetadata(req *frontend.GetSymbolMetadataRequest,
+	response *frontend.GetSymbolMetadataResponse,
+) error {
+	respI, err := rc.rpcClient.DoRPC("GetSymbolMetadata", req)
+	if err != nil {
+		return fmt.Errorf("DoRPC:GetSymbolMetadata error:%w", err)
+	}
+	if respI != nil {
+		if val, ok := respI.(map[string]map[string]string); ok {
+			response.Metadata = val
+		} else {
+			return fmt.Errorf("[bug] unexpected data type returned from DoRPC:GetSymbolMetadata func. resp=%v", respI)
+		}
+	}
+	return nil
+}
+
 func (rc *RemoteAPIClient) GetBucketInfo(reqs *frontend.MultiKeyRequest, responses *frontend.MultiGetInfoResponse,
 ) error {
 	var respI interface{}
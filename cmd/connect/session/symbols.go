@@ -0,0 +1,143 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// rename renames a symbol, or hard-links its data to a new symbol with the "link" option.
+func (c *Client) rename(line string) error {
+	args := strings.Fields(line)
+	args = args[1:] // chop off the first word which should be "rename"
+	const minArgLen, maxArgLen = 2, 3
+	if len(args) < minArgLen || len(args) > maxArgLen || (len(args) == maxArgLen && args[2] != "link") {
+		log.Error("Please specify the old and new symbols. (e.g. \\rename FB META, or \\rename FB META link)\n")
+		return nil
+	}
+
+	reqs := &frontend.MultiRenameSymbolRequest{
+		Requests: []frontend.RenameSymbolRequest{
+			{OldSymbol: args[0], NewSymbol: args[1], Link: len(args) == maxArgLen},
+		},
+	}
+	responses := &frontend.MultiServerResponse{}
+	if err := c.apiClient.RenameSymbols(reqs, responses); err != nil {
+		return fmt.Errorf("rename symbol: %w", err)
+	}
+	if err := firstResponseError(responses); err != nil {
+		return fmt.Errorf("rename symbol: %w", err)
+	}
+	fmt.Printf("Renamed %s to %s\n", args[0], args[1])
+	return nil
+}
+
+// alias lists the aliases of the symbols without arguments, or makes an alias refer to a symbol.
+func (c *Client) alias(line string) error {
+	args := strings.Fields(line)
+	args = args[1:] // chop off the first word which should be "alias"
+	const argLen = 2
+	switch len(args) {
+	case 0:
+		response := &frontend.ListSymbolAliasesResponse{}
+		if err := c.apiClient.ListSymbolAliases(response); err != nil {
+			return fmt.Errorf("list symbol aliases: %w", err)
+		}
+		aliases := make([]string, 0, len(response.Aliases))
+		for alias := range response.Aliases {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		for _, alias := range aliases {
+			fmt.Printf("%s -> %s\n", alias, response.Aliases[alias])
+		}
+		return nil
+	case argLen:
+		return c.setSymbolAlias(args[0], args[1])
+	default:
+		log.Error("Please specify an alias and its symbol. (e.g. \\alias FB META)\n")
+		return nil
+	}
+}
+
+// unalias removes an alias.
+func (c *Client) unalias(line string) error {
+	args := strings.Fields(line)
+	args = args[1:] // chop off the first word which should be "unalias"
+	if len(args) != 1 {
+		log.Error("Please specify an alias to remove. (e.g. \\unalias FB)\n")
+		return nil
+	}
+	return c.setSymbolAlias(args[0], "")
+}
+
+func (c *Client) setSymbolAlias(alias, symbol string) error {
+	reqs := &frontend.MultiSymbolAliasRequest{
+		Requests: []frontend.SymbolAliasRequest{{Alias: alias, Symbol: symbol}},
+	}
+	responses := &frontend.MultiServerResponse{}
+	if err := c.apiClient.SetSymbolAliases(reqs, responses); err != nil {
+		return fmt.Errorf("set symbol alias: %w", err)
+	}
+	return firstResponseError(responses)
+}
+
+// meta shows the metadata of a symbol, or merges the key=value attributes to it.
+func (c *Client) meta(line string) error {
+	args := strings.Fields(line)
+	args = args[1:] // chop off the first word which should be "meta"
+	if len(args) == 0 {
+		log.Error("Please specify a symbol. (e.g. \\meta AAPL, or \\meta AAPL exchange=NASDAQ sector=Tech)\n")
+		return nil
+	}
+	symbol := args[0]
+
+	if len(args) == 1 {
+		response := &frontend.GetSymbolMetadataResponse{}
+		err := c.apiClient.GetSymbolMetadata(&frontend.GetSymbolMetadataRequest{Symbols: []string{symbol}}, response)
+		if err != nil {
+			return fmt.Errorf("get symbol metadata: %w", err)
+		}
+		metadata := response.Metadata[symbol]
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("%s=%s\n", key, metadata[key])
+		}
+		return nil
+	}
+
+	metadata := make(map[string]string, len(args)-1)
+	for _, arg := range args[1:] {
+		key, value, found := strings.Cut(arg, "=")
+		if !found || key == "" {
+			log.Error("Please specify the attributes as key=value. (e.g. \\meta AAPL exchange=NASDAQ sector=Tech)\n")
+			return nil
+		}
+		metadata[key] = value
+	}
+	reqs := &frontend.MultiSymbolMetadataRequest{
+		Requests: []frontend.SymbolMetadataRequest{{Symbol: symbol, Metadata: metadata}},
+	}
+	responses := &frontend.MultiServerResponse{}
+	if err := c.apiClient.SetSymbolMetadata(reqs, responses); err != nil {
+		return fmt.Errorf("set symbol metadata: %w", err)
+	}
+	return firstResponseError(responses)
+}
+
+func firstResponseError(responses *frontend.MultiServerResponse) error {
+	for _, resp := range responses.Responses {
+		if resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/alpacahq/marketstore/v4/cmd/connect/session/mock"
+	"github.com/alpacahq/marketstore/v4/frontend"
+)
+
+func TestClient_rename(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		line     string
+		resp     *frontend.MultiServerResponse
+		wantReqs []frontend.RenameSymbolRequest
+		wantErr  bool
+	}{
+		"ok/ rename": {
+			line:     `\rename FB META`,
+			resp:     &frontend.MultiServerResponse{Responses: []frontend.ServerResponse{{}}},
+			wantReqs: []frontend.RenameSymbolRequest{{OldSymbol: "FB", NewSymbol: "META"}},
+		},
+		"ok/ link": {
+			line:     `\rename FB META link`,
+			resp:     &frontend.MultiServerResponse{Responses: []frontend.ServerResponse{{}}},
+			wantReqs: []frontend.RenameSymbolRequest{{OldSymbol: "FB", NewSymbol: "META", Link: true}},
+		},
+		"ng/ the rename is rejected by the server": {
+			line:     `\rename FB META`,
+			resp:     &frontend.MultiServerResponse{Responses: []frontend.ServerResponse{{Error: "META already exists"}}},
+			wantReqs: []frontend.RenameSymbolRequest{{OldSymbol: "FB", NewSymbol: "META"}},
+			wantErr:  true,
+		},
+		"ok/ no request for an invalid option": {
+			line: `\rename FB META copy`,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			mockCtrl := gomock.NewController(t)
+			mockClient := mock.NewMockAPIClient(mockCtrl)
+			if tt.wantReqs != nil {
+				mockClient.EXPECT().RenameSymbols(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(reqs *frontend.MultiRenameSymbolRequest, responses *frontend.MultiServerResponse) error {
+						assert.Equal(t, tt.wantReqs, reqs.Requests)
+						*responses = *tt.resp
+						return nil
+					},
+				)
+			}
+
+			// --- when ---
+			err := NewClient(mockClient).rename(tt.line)
+
+			// --- then ---
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestClient_alias(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	mockCtrl := gomock.NewController(t)
+	mockClient := mock.NewMockAPIClient(mockCtrl)
+	mockClient.EXPECT().ListSymbolAliases(gomock.Any()).DoAndReturn(
+		func(response *frontend.ListSymbolAliasesResponse) error {
+			response.Aliases = map[string]string{"FB": "META"}
+			return nil
+		},
+	)
+	var gotReqs []frontend.SymbolAliasRequest
+	mockClient.EXPECT().SetSymbolAliases(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(reqs *frontend.MultiSymbolAliasRequest, responses *frontend.MultiServerResponse) error {
+			gotReqs = append(gotReqs, reqs.Requests...)
+			responses.Responses = []frontend.ServerResponse{{}}
+			return nil
+		},
+	)
+	c := NewClient(mockClient)
+
+	// --- when ---
+	err1 := c.alias(`\alias`)
+	err2 := c.alias(`\alias FB META`)
+	err3 := c.unalias(`\unalias FB`)
+
+	// --- then ---
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, []frontend.SymbolAliasRequest{{Alias: "FB", Symbol: "META"}, {Alias: "FB"}}, gotReqs)
+}
+
+func TestClient_meta(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	mockCtrl := gomock.NewController(t)
+	mockClient := mock.NewMockAPIClient(mockCtrl)
+	mockClient.EXPECT().GetSymbolMetadata(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(req *frontend.GetSymbolMetadataRequest, response *frontend.GetSymbolMetadataResponse) error {
+			assert.Equal(t, []string{"AAPL"}, req.Symbols)
+			response.Metadata = map[string]map[string]string{"AAPL": {"exchange": "NASDAQ"}}
+			return nil
+		},
+	)
+	mockClient.EXPECT().SetSymbolMetadata(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(reqs *frontend.MultiSymbolMetadataRequest, responses *frontend.MultiServerResponse) error {
+			assert.Equal(t, []frontend.SymbolMetadataRequest{
+				{Symbol: "AAPL", Metadata: map[string]string{"sector": "Tech", "lot_size": ""}},
+			}, reqs.Requests)
+			responses.Responses = []frontend.ServerResponse{{}}
+			return nil
+		},
+	)
+	c := NewClient(mockClient)
+
+	// --- when ---
+	err1 := c.meta(`\meta AAPL`)
+	err2 := c.meta(`\meta AAPL sector=Tech lot_size=`)
+	// no request for an invalid attribute
+	err3 := c.meta(`\meta AAPL sector`)
+
+	// --- then ---
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+}
@@ -21,6 +21,7 @@ import (
 	pb "github.com/alpacahq/marketstore/v4/proto"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/log"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 )
@@ -84,10 +85,21 @@ func executeStart(cmd *cobra.Command, _ []string) error {
 
 	// New gRPC stream server for replication.
 	c := di.NewContainer(config)
+	// the triggers and the bgworkers can be reloaded from the config file without a restart
+	bgWorkers := NewBgWorkers()
+	reloader := NewConfigReloader(configFilePath, config, bgWorkers)
+	c.InjectTriggerMatchers(reloader.TriggerMatchers())
+	c.InjectConfigReloader(reloader)
+	c.InjectBgWorkerManager(bgWorkers)
+	writeValidator, err := executor.NewWriteValidator(config.WriteValidation, c.GetCatalogDir())
+	if err != nil {
+		return fmt.Errorf("invalid write_validation: %w", err)
+	}
+	c.InjectWriteValidator(writeValidator)
 	// initialize replication master or client
 	c.GetReplicationSender().Run(ctx)
 	// start TriggerPluginDispatcher
-	c.GetStartTriggerPluginDispatcher()
+	reloader.SetTriggerPluginDispatcher(c.GetStartTriggerPluginDispatcher())
 
 	// Initialize marketstore services.
 	// --------------------------------
@@ -99,8 +111,16 @@ func executeStart(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("craete new instance setup: %w", err)
 	}
+	// the writes of the bgworkers and the triggers by executor.WriteCSM are validated too
+	executor.ThisInstance.Validator = writeValidator
 
 	go metrics.StartDiskUsageMonitor(metrics.TotalDiskUsageBytes, config.RootDirectory, diskUsageMonitorInterval)
+	// scan the statistics of the time buckets in the background, so that the first request doesn't scan them
+	go func() {
+		if err2 := c.GetStatsTracker().Seed(); err2 != nil {
+			log.Error("failed to seed the stats of the time buckets: %v", err2)
+		}
+	}()
 
 	startupTime := time.Since(start)
 	metrics.StartupTime.Set(startupTime.Seconds())
@@ -130,15 +150,20 @@ func executeStart(cmd *cobra.Command, _ []string) error {
 
 	// Set monitoring handler.
 	log.Info("launching prometheus metrics server...")
+	prometheus.MustRegister(metrics.NewBgWorkerCollector(bgWorkers.Metrics))
 	http.Handle("/metrics", promhttp.Handler())
 
 	// Initialize any provided bgWorker plugins.
-	RunBgWorkers(config.BgWorkers)
+	_, _, errs := bgWorkers.Run(config.BgWorkers)
+	for _, e := range errs {
+		log.Error(e)
+	}
 
 	if config.UtilitiesURL != "" {
 		// Start utility endpoints.
 		log.Info("launching utility service...")
 		uah := frontend.NewUtilityAPIHandlers(config.StartTime)
+		uah.SetBgWorkerManager(bgWorkers)
 		go func() {
 			err = uah.Handle(config.UtilitiesURL)
 			if err != nil {
@@ -179,6 +204,11 @@ func executeStart(cmd *cobra.Command, _ []string) error {
 					log.Error("failed to write goroutine pprof: %w", err)
 					return
 				}
+			case syscall.SIGHUP:
+				log.Info("reloading triggers and bgworkers due to SIGHUP request")
+				if _, err2 := reloader.ReloadConfig(); err2 != nil {
+					log.Error("failed to reload the configuration: %v", err2)
+				}
 			case syscall.SIGINT, syscall.SIGTERM:
 				log.Info("initiating graceful shutdown due to '%v' request", s)
 				c.GetGRPCServer().GracefulStop()
@@ -192,12 +222,14 @@ func executeStart(cmd *cobra.Command, _ []string) error {
 				atomic.StoreUint32(&frontend.Queryable, uint32(0))
 				log.Info("waiting a grace period of %v to shutdown...", config.StopGracePeriod)
 				time.Sleep(config.StopGracePeriod)
+				bgWorkers.Stop()
+				log.Info("shutdown bgworkers...")
 				c.GetInitWALFile().Shutdown()
 				shutdown()
 			}
 		}
 	}()
-	signal.Notify(signalChan, syscall.SIGUSR1, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(signalChan, syscall.SIGUSR1, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
 
 	if err := http.ListenAndServe(config.ListenURL, nil); err != nil {
 		return fmt.Errorf("failed to start server - error: %w", err)
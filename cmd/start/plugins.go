@@ -1,27 +1,430 @@
 package start
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/metrics"
 	"github.com/alpacahq/marketstore/v4/plugins"
 	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
-func RunBgWorkers(bgWorkers []*utils.BgWorkerSetting) {
+const (
+	// bgWorkerStopTimeout is how long to wait for a bgworker to stop after its context is canceled.
+	bgWorkerStopTimeout = 30 * time.Second
+	// bgWorkerMinBackoff is the wait before a bgworker that panicked or whose RunContext returned is restarted.
+	// It doubles for each restart in a row up to bgWorkerMaxBackoff, and is reset once the bgworker
+	// keeps running longer than bgWorkerMaxBackoff.
+	bgWorkerMinBackoff = time.Second
+	bgWorkerMaxBackoff = 5 * time.Minute
+)
+
+// BgWorkers runs the bgworker plugins, restarts them when they panic or their RunContext returns,
+// and stops and starts them when the config is reloaded or by the admin APIs.
+type BgWorkers struct {
+	mu sync.Mutex
+	// workers[Key]: Key is the name of the bgworker, or its module if it has no name
+	workers map[string]*managedBgWorker
+	// newBgWorker loads a bgworker plugin, or returns nil if it fails
+	newBgWorker func(s *utils.BgWorkerSetting) bgworker.BgWorker
+	stopTimeout time.Duration
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+type managedBgWorker struct {
+	name    string
+	setting *utils.BgWorkerSetting
+
+	mu    sync.Mutex
+	state string
+	// worker is the last loaded instance of the bgworker, or nil if it's never loaded
+	worker    bgworker.BgWorker
+	startedAt time.Time
+	restarts  int64
+	// errors is the number of the restarts and the failures to load
+	errors    int64
+	lastError string
+	// cancel and done are nil unless the bgworker is running or in backoff
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewBgWorkers returns a new BgWorkers with no running bgworker.
+func NewBgWorkers() *BgWorkers {
+	return &BgWorkers{
+		workers:     map[string]*managedBgWorker{},
+		newBgWorker: NewBgWorker,
+		stopTimeout: bgWorkerStopTimeout,
+		minBackoff:  bgWorkerMinBackoff,
+		maxBackoff:  bgWorkerMaxBackoff,
+	}
+}
+
+// Run makes the bgworkers match the settings. The bgworkers not in the settings or whose settings are changed
+// are stopped, and the bgworkers in the settings that are not running are started, except the ones stopped
+// by StopBgWorker whose settings are not changed.
+// It returns the names of the started and the stopped bgworkers, and the errors of the bgworkers that failed to be
+// started or stopped. A bgworker that can't be stopped keeps running with its previous setting.
+func (b *BgWorkers) Run(settings []*utils.BgWorkerSetting) (started, stopped, errs []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	log.Info("InitializeBgWorkers")
-	for _, bgWorkerSetting := range bgWorkers {
-		// bgWorkerSetting may contain sensitive data such as a password or token.
-		log.Debug("bgWorkerSetting = %v", bgWorkerSetting)
-		bgWorker := NewBgWorker(bgWorkerSetting)
-		if bgWorker != nil {
-			// we should probably keep track of this process status
-			// and may want to kill it or get info.  utils.Process may help
-			// but will figure it out later.
-			log.Info("Start running BgWorker %s...", bgWorkerSetting.Name)
-			go bgWorker.Run()
+	started, stopped, errs = []string{}, []string{}, []string{}
+
+	want := map[string]*utils.BgWorkerSetting{}
+	names := make([]string, 0, len(settings))
+	for i, s := range settings {
+		name := bgWorkerName(s)
+		if _, found := want[name]; found {
+			name = fmt.Sprintf("%s#%d", name, i)
 		}
+		want[name] = s
+		names = append(names, name)
+	}
+
+	for _, name := range sortedKeys(b.workers) {
+		mw := b.workers[name]
+		if s, found := want[name]; found && reflect.DeepEqual(s, mw.setting) {
+			continue
+		}
+		wasActive := mw.isActive()
+		if err := b.stop(mw); err != nil {
+			errs = append(errs, err.Error())
+			if errors.Is(err, errBgWorkerNotStoppable) {
+				continue
+			}
+		}
+		delete(b.workers, name)
+		if wasActive {
+			stopped = append(stopped, name)
+		}
+	}
+
+	for _, name := range names {
+		mw, found := b.workers[name]
+		if found && mw.currentState() != frontend.BgWorkerFailed {
+			continue
+		}
+		if !found {
+			mw = &managedBgWorker{name: name, setting: want[name], state: frontend.BgWorkerStopped}
+			b.workers[name] = mw
+		}
+		if err := b.start(mw); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		started = append(started, name)
 	}
 	log.Info("InitializeBgWorkers Done")
+	return started, stopped, errs
+}
+
+// Stop stops all the bgworkers that can be stopped.
+func (b *BgWorkers) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, name := range sortedKeys(b.workers) {
+		mw := b.workers[name]
+		if !mw.isActive() {
+			continue
+		}
+		if err := b.stop(mw); err != nil && !errors.Is(err, errBgWorkerNotStoppable) {
+			log.Error(err.Error())
+		}
+	}
+}
+
+// ListBgWorkers returns the status of the bgworkers sorted by name.
+func (b *BgWorkers) ListBgWorkers() []frontend.BgWorkerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	statuses := make([]frontend.BgWorkerStatus, 0, len(b.workers))
+	for _, name := range sortedKeys(b.workers) {
+		statuses = append(statuses, b.workers[name].status())
+	}
+	return statuses
+}
+
+// StartBgWorker starts a stopped or failed bgworker. It does nothing if the bgworker is already running.
+func (b *BgWorkers) StartBgWorker(name string) (frontend.BgWorkerStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	mw, found := b.workers[name]
+	if !found {
+		return frontend.BgWorkerStatus{}, fmt.Errorf("%w: %s", frontend.ErrBgWorkerNotFound, name)
+	}
+	if mw.isActive() {
+		return mw.status(), nil
+	}
+	err := b.start(mw)
+	return mw.status(), err
+}
+
+// StopBgWorker stops a bgworker until it's started by StartBgWorker or its setting is changed by a reload.
+func (b *BgWorkers) StopBgWorker(name string) (frontend.BgWorkerStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	mw, found := b.workers[name]
+	if !found {
+		return frontend.BgWorkerStatus{}, fmt.Errorf("%w: %s", frontend.ErrBgWorkerNotFound, name)
+	}
+	err := b.stop(mw)
+	return mw.status(), err
+}
+
+// RestartBgWorker stops a bgworker and starts a new instance of it with no backoff.
+func (b *BgWorkers) RestartBgWorker(name string) (frontend.BgWorkerStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	mw, found := b.workers[name]
+	if !found {
+		return frontend.BgWorkerStatus{}, fmt.Errorf("%w: %s", frontend.ErrBgWorkerNotFound, name)
+	}
+	if err := b.stop(mw); err != nil {
+		return mw.status(), err
+	}
+	err := b.start(mw)
+	return mw.status(), err
+}
+
+// Metrics returns the metrics of the bgworkers, for metrics.NewBgWorkerCollector.
+func (b *BgWorkers) Metrics() []metrics.BgWorkerMetric {
+	statuses := b.ListBgWorkers()
+	ms := make([]metrics.BgWorkerMetric, len(statuses))
+	for i, st := range statuses {
+		ms[i] = metrics.BgWorkerMetric{
+			Name:      st.Name,
+			Running:   st.State == frontend.BgWorkerRunning,
+			Restarts:  st.Restarts,
+			Errors:    st.Errors,
+			LastWrite: st.LastWrite,
+		}
+	}
+	return ms
+}
+
+var errBgWorkerNotStoppable = errors.New("can't be stopped without restarting the server")
+
+// start loads a new instance of the bgworker, and runs it under a separate goroutine.
+func (b *BgWorkers) start(mw *managedBgWorker) error {
+	// bgWorkerSetting may contain sensitive data such as a password or token.
+	log.Debug("bgWorkerSetting = %v", mw.setting)
+	w := b.newBgWorker(mw.setting)
+	if w == nil {
+		err := fmt.Errorf("failed to load bgworker %s", mw.name)
+		mw.fail(err)
+		return err
+	}
+	log.Info("Start running BgWorker %s...", mw.name)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	mw.mu.Lock()
+	mw.cancel, mw.done = cancel, done
+	mw.mu.Unlock()
+	mw.setRunning(ctx, w)
+	go b.supervise(ctx, mw, w, done)
+	return nil
+}
+
+// supervise runs the bgworker until ctx is canceled. When the bgworker panics, or a StoppableBgWorker returns
+// before ctx is canceled, a new instance of it is loaded and started after the backoff.
+// A BgWorker whose Run returns without a panic is considered to have started its own goroutines,
+// so it's kept running and is never started again.
+func (b *BgWorkers) supervise(ctx context.Context, mw *managedBgWorker, w bgworker.BgWorker, done chan struct{}) {
+	defer close(done)
+	backoff := b.minBackoff
+	for {
+		startedAt := time.Now()
+		err := runBgWorker(ctx, w)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			<-ctx.Done()
+			return
+		}
+		if time.Since(startedAt) > b.maxBackoff {
+			backoff = b.minBackoff
+		}
+		err = fmt.Errorf("bgworker %s %w, restarting in %v", mw.name, err, backoff)
+		log.Error(err.Error())
+		mw.setBackoff(err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if backoff *= 2; backoff > b.maxBackoff {
+			backoff = b.maxBackoff
+		}
+
+		if w = b.newBgWorker(mw.setting); w == nil {
+			err = fmt.Errorf("failed to load bgworker %s", mw.name)
+			log.Error(err.Error())
+			mw.fail(err)
+			return
+		}
+		if !mw.setRunning(ctx, w) {
+			return
+		}
+	}
+}
+
+// errBgWorkerReturned is returned by runBgWorker when a StoppableBgWorker returns before ctx is canceled.
+var errBgWorkerReturned = errors.New("returned")
+
+// runBgWorker runs the bgworker until it returns, and returns the reason to restart it.
+// It returns nil when the Run of a BgWorker returns without a panic, as many plugins return from Run
+// once they start their goroutines.
+func runBgWorker(ctx context.Context, w bgworker.BgWorker) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked: %v", r)
+		}
+	}()
+	if sbw, ok := w.(bgworker.StoppableBgWorker); ok {
+		sbw.RunContext(ctx)
+		return errBgWorkerReturned
+	}
+	w.Run()
+	return nil
+}
+
+// stop stops the bgworker if it's running or in backoff, and waits for it to return.
+func (b *BgWorkers) stop(mw *managedBgWorker) error {
+	mw.mu.Lock()
+	if mw.cancel == nil {
+		mw.state = frontend.BgWorkerStopped
+		mw.mu.Unlock()
+		return nil
+	}
+	if _, ok := mw.worker.(bgworker.StoppableBgWorker); !ok && mw.state == frontend.BgWorkerRunning {
+		mw.mu.Unlock()
+		return fmt.Errorf("bgworker %s %w", mw.name, errBgWorkerNotStoppable)
+	}
+	log.Info("Stop running BgWorker %s...", mw.name)
+	cancel, done := mw.cancel, mw.done
+	mw.cancel, mw.done = nil, nil
+	mw.state = frontend.BgWorkerStopped
+	mw.startedAt = time.Time{}
+	mw.mu.Unlock()
+
+	cancel()
+	timer := time.NewTimer(b.stopTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("bgworker %s did not stop in %v", mw.name, b.stopTimeout)
+	}
+}
+
+// setRunning sets the running instance of the bgworker. It returns false if ctx is already canceled,
+// so that a bgworker that can't be stopped is not started after StopBgWorker.
+func (mw *managedBgWorker) setRunning(ctx context.Context, w bgworker.BgWorker) bool {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	if ctx.Err() != nil {
+		return false
+	}
+	mw.state = frontend.BgWorkerRunning
+	mw.worker = w
+	mw.startedAt = time.Now()
+	return true
+}
+
+func (mw *managedBgWorker) setBackoff(err error) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	mw.state = frontend.BgWorkerBackoff
+	mw.startedAt = time.Time{}
+	mw.restarts++
+	mw.errors++
+	mw.lastError = err.Error()
+}
+
+func (mw *managedBgWorker) fail(err error) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	mw.state = frontend.BgWorkerFailed
+	mw.startedAt = time.Time{}
+	mw.cancel, mw.done = nil, nil
+	mw.errors++
+	mw.lastError = err.Error()
+}
+
+// currentState returns the lifecycle state of the bgworker.
+func (mw *managedBgWorker) currentState() string {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	return mw.state
+}
+
+// isActive returns true if the bgworker is running or in backoff.
+func (mw *managedBgWorker) isActive() bool {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	return mw.cancel != nil
+}
+
+func (mw *managedBgWorker) status() frontend.BgWorkerStatus {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	_, stoppable := mw.worker.(bgworker.StoppableBgWorker)
+	st := frontend.BgWorkerStatus{
+		Name:      mw.name,
+		Module:    mw.setting.Module,
+		State:     mw.state,
+		Stoppable: stoppable,
+		Restarts:  mw.restarts,
+		Errors:    mw.errors,
+		LastError: mw.lastError,
+	}
+	if !mw.startedAt.IsZero() {
+		st.StartedAt = mw.startedAt.Unix()
+	}
+	if sr, ok := mw.worker.(bgworker.StatusReporter); ok {
+		ws := sr.Status()
+		st.Reporting = true
+		st.WorkerState = ws.State
+		if !ws.LastWrite.IsZero() {
+			st.LastWrite = ws.LastWrite.Unix()
+		}
+		st.Errors += ws.Errors
+		if ws.LastError != "" {
+			st.LastError = ws.LastError
+		}
+	}
+	return st
+}
+
+func bgWorkerName(s *utils.BgWorkerSetting) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Module
+}
+
+func sortedKeys(m map[string]*managedBgWorker) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func NewBgWorker(s *utils.BgWorkerSetting) bgworker.BgWorker {
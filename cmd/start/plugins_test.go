@@ -0,0 +1,201 @@
+package start
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
+	"github.com/alpacahq/marketstore/v4/utils"
+)
+
+// crashingBgWorker panics soon after it's started, or runs until ctx is canceled if it doesn't crash.
+type crashingBgWorker struct {
+	bgworker.StatusTracker
+	crash bool
+}
+
+func (w *crashingBgWorker) Run() {
+	w.RunContext(context.Background())
+}
+
+func (w *crashingBgWorker) RunContext(ctx context.Context) {
+	w.SetState("streaming")
+	w.RecordWrite()
+	if w.crash {
+		w.RecordError(errors.New("connection reset"))
+		panic("crashed")
+	}
+	<-ctx.Done()
+}
+
+func waitForState(t *testing.T, b *BgWorkers, name, state string) frontend.BgWorkerStatus {
+	t.Helper()
+	var st frontend.BgWorkerStatus
+	require.Eventually(t, func() bool {
+		for _, st = range b.ListBgWorkers() {
+			if st.Name == name {
+				return st.State == state
+			}
+		}
+		return false
+	}, 5*time.Second, time.Millisecond, "bgworker %s is not %s", name, state)
+	return st
+}
+
+func TestBgWorkers_Restart(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	var mu sync.Mutex
+	loaded := 0
+	b := NewBgWorkers()
+	b.minBackoff = 10 * time.Millisecond
+	b.maxBackoff = 20 * time.Millisecond
+	b.newBgWorker = func(s *utils.BgWorkerSetting) bgworker.BgWorker {
+		mu.Lock()
+		defer mu.Unlock()
+		loaded++
+		// the first 3 instances crash
+		return &crashingBgWorker{crash: loaded <= 3}
+	}
+
+	// --- when ---
+	started, _, errs := b.Run([]*utils.BgWorkerSetting{{Module: "feeder.so", Name: "feeder"}})
+
+	// --- then ---
+	assert.Equal(t, []string{"feeder"}, started)
+	assert.Empty(t, errs)
+	// restarted 3 times with backoff and keeps running
+	st := waitForState(t, b, "feeder", frontend.BgWorkerRunning)
+	require.Eventually(t, func() bool {
+		st = b.ListBgWorkers()[0]
+		return st.Restarts == 3 && st.State == frontend.BgWorkerRunning
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, "feeder.so", st.Module)
+	assert.True(t, st.Stoppable)
+	assert.True(t, st.Reporting)
+	assert.Equal(t, "streaming", st.WorkerState)
+	assert.NotZero(t, st.StartedAt)
+	assert.NotZero(t, st.LastWrite)
+	// the errors of the restarts. the new instance has no error
+	assert.Equal(t, int64(3), st.Errors)
+	assert.Equal(t, "bgworker feeder panicked: crashed, restarting in 20ms", st.LastError)
+
+	m := b.Metrics()
+	require.Len(t, m, 1)
+	assert.True(t, m[0].Running)
+	assert.Equal(t, int64(3), m[0].Restarts)
+	b.Stop()
+}
+
+// subscribingBgWorker returns from Run right after it starts its own goroutines, like the polygon fetcher.
+type subscribingBgWorker struct{}
+
+func (w *subscribingBgWorker) Run() {}
+
+func TestBgWorkers_RunReturns(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	var mu sync.Mutex
+	loaded := 0
+	b := NewBgWorkers()
+	b.minBackoff = time.Millisecond
+	b.maxBackoff = time.Millisecond
+	b.newBgWorker = func(s *utils.BgWorkerSetting) bgworker.BgWorker {
+		mu.Lock()
+		defer mu.Unlock()
+		loaded++
+		return &subscribingBgWorker{}
+	}
+
+	// --- when ---
+	started, _, errs := b.Run([]*utils.BgWorkerSetting{{Module: "polygon.so", Name: "polygon"}})
+
+	// --- then ---
+	assert.Equal(t, []string{"polygon"}, started)
+	assert.Empty(t, errs)
+	// the bgworker is considered to be running its goroutines, and is not started again
+	time.Sleep(50 * time.Millisecond)
+	st := waitForState(t, b, "polygon", frontend.BgWorkerRunning)
+	assert.Zero(t, st.Restarts)
+	assert.Zero(t, st.Errors)
+	mu.Lock()
+	assert.Equal(t, 1, loaded)
+	mu.Unlock()
+}
+
+func TestBgWorkers_StartStop(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	b := NewBgWorkers()
+	b.stopTimeout = time.Second
+	b.newBgWorker = func(s *utils.BgWorkerSetting) bgworker.BgWorker {
+		if s.Module == "broken.so" {
+			return nil
+		}
+		if s.Name == "legacy" {
+			return &fakeBgWorker{running: make(chan struct{})}
+		}
+		return &crashingBgWorker{}
+	}
+	settings := []*utils.BgWorkerSetting{
+		{Module: "feeder.so", Name: "feeder"},
+		{Module: "feeder.so", Name: "legacy"},
+		{Module: "broken.so"},
+	}
+	_, _, errs := b.Run(settings)
+	assert.Equal(t, []string{"failed to load bgworker broken.so"}, errs)
+
+	// --- when ---
+	st, err := b.StopBgWorker("feeder")
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, frontend.BgWorkerStopped, st.State)
+	assert.Zero(t, st.StartedAt)
+
+	// a stopped bgworker whose setting is not changed is not started by a reload, and the failed one is retried
+	started, stopped, errs := b.Run(settings)
+	assert.Empty(t, started)
+	assert.Empty(t, stopped)
+	assert.Equal(t, []string{"failed to load bgworker broken.so"}, errs)
+	statuses := b.ListBgWorkers()
+	require.Len(t, statuses, 3)
+	assert.Equal(t, []string{"broken.so", "feeder", "legacy"},
+		[]string{statuses[0].Name, statuses[1].Name, statuses[2].Name})
+	assert.Equal(t, frontend.BgWorkerFailed, statuses[0].State)
+	assert.Equal(t, frontend.BgWorkerStopped, statuses[1].State)
+	assert.Equal(t, frontend.BgWorkerRunning, statuses[2].State)
+	assert.False(t, statuses[2].Stoppable)
+	assert.False(t, statuses[2].Reporting)
+
+	// --- when ---
+	st, err = b.StartBgWorker("feeder")
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, frontend.BgWorkerRunning, st.State)
+
+	// --- when ---
+	_, err = b.RestartBgWorker("legacy")
+
+	// --- then ---
+	assert.True(t, errors.Is(err, errBgWorkerNotStoppable))
+
+	// --- when ---
+	_, err = b.StopBgWorker("unknown")
+
+	// --- then ---
+	assert.True(t, errors.Is(err, frontend.ErrBgWorkerNotFound))
+	b.Stop()
+	assert.Equal(t, frontend.BgWorkerStopped, waitForState(t, b, "feeder", frontend.BgWorkerStopped).State)
+}
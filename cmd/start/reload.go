@@ -0,0 +1,144 @@
+package start
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/plugins/trigger"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// TriggerMatcherSetter replaces the trigger matchers, implemented by executor.TriggerPluginDispatcher.
+type TriggerMatcherSetter interface {
+	SetTriggerMatchers(triggerMatchers []*trigger.Matcher)
+}
+
+// ConfigReloader reloads the triggers and the bgworkers from the config file on SIGHUP or the ReloadConfig API.
+// The other settings in the config file require a restart of the server.
+type ConfigReloader struct {
+	mu             sync.Mutex
+	configFilePath string
+	triggers       []loadedTrigger
+	tpd            TriggerMatcherSetter
+	bgWorkers      *BgWorkers
+	// newTriggerMatcher loads a trigger plugin, or returns nil if it fails
+	newTriggerMatcher func(ts *utils.TriggerSetting) *trigger.Matcher
+}
+
+type loadedTrigger struct {
+	setting *utils.TriggerSetting
+	// matcher is nil if the trigger failed to be loaded
+	matcher *trigger.Matcher
+}
+
+// NewConfigReloader loads the triggers in the config. Their matchers are used for the TriggerPluginDispatcher,
+// which must be set by SetTriggerPluginDispatcher.
+func NewConfigReloader(configFilePath string, config *utils.MktsConfig, bgWorkers *BgWorkers) *ConfigReloader {
+	r := &ConfigReloader{
+		configFilePath:    configFilePath,
+		bgWorkers:         bgWorkers,
+		newTriggerMatcher: trigger.NewTriggerMatcher,
+	}
+	log.Info("InitializeTriggers")
+	r.triggers, _, _, _ = r.loadTriggers(config.Triggers)
+	log.Info("InitializeTriggers - Done")
+	return r
+}
+
+// TriggerMatchers returns the matchers of the loaded triggers.
+func (r *ConfigReloader) TriggerMatchers() []*trigger.Matcher {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return matchers(r.triggers)
+}
+
+// SetTriggerPluginDispatcher sets the dispatcher whose trigger matchers are replaced by the reload.
+func (r *ConfigReloader) SetTriggerPluginDispatcher(tpd TriggerMatcherSetter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tpd = tpd
+}
+
+// ReloadConfig reads the config file, and replaces the triggers and the bgworkers whose settings are changed.
+// Nothing is changed if the config file can't be read.
+func (r *ConfigReloader) ReloadConfig() (*frontend.ReloadConfigResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	log.Info("reloading %v", r.configFilePath)
+	data, err := os.ReadFile(r.configFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("read configuration file: %w", err)
+	}
+	config, err := utils.ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse configuration file: %w", err)
+	}
+
+	resp := &frontend.ReloadConfigResponse{}
+	r.triggers, resp.TriggersAdded, resp.TriggersRemoved, resp.Errors = r.loadTriggers(config.Triggers)
+	if r.tpd != nil {
+		r.tpd.SetTriggerMatchers(matchers(r.triggers))
+	}
+	var errs []string
+	resp.BgWorkersStarted, resp.BgWorkersStopped, errs = r.bgWorkers.Run(config.BgWorkers)
+	resp.Errors = append(resp.Errors, errs...)
+
+	log.Info("reloaded %v: triggers added=%v, removed=%v, bgworkers started=%v, stopped=%v",
+		r.configFilePath, resp.TriggersAdded, resp.TriggersRemoved, resp.BgWorkersStarted, resp.BgWorkersStopped)
+	for _, e := range resp.Errors {
+		log.Error("reload error: %s", e)
+	}
+	return resp, nil
+}
+
+// loadTriggers returns the triggers of the settings in order. The loaded triggers whose settings are not changed
+// are reused, and the others are loaded.
+func (r *ConfigReloader) loadTriggers(settings []*utils.TriggerSetting,
+) (triggers []loadedTrigger, added, removed, errs []string) {
+	added, removed, errs = []string{}, []string{}, []string{}
+	reused := make([]bool, len(r.triggers))
+	triggers = make([]loadedTrigger, 0, len(settings))
+	for _, ts := range settings {
+		lt := loadedTrigger{setting: ts}
+		for i, prev := range r.triggers {
+			if !reused[i] && prev.matcher != nil && reflect.DeepEqual(prev.setting, ts) {
+				reused[i] = true
+				lt.matcher = prev.matcher
+				break
+			}
+		}
+		if lt.matcher == nil {
+			log.Info("triggerSetting = %v", ts)
+			if lt.matcher = r.newTriggerMatcher(ts); lt.matcher == nil {
+				errs = append(errs, fmt.Sprintf("failed to load trigger %s", triggerName(ts)))
+			} else {
+				added = append(added, triggerName(ts))
+			}
+		}
+		triggers = append(triggers, lt)
+	}
+	for i, prev := range r.triggers {
+		if !reused[i] && prev.matcher != nil {
+			removed = append(removed, triggerName(prev.setting))
+		}
+	}
+	return triggers, added, removed, errs
+}
+
+func matchers(triggers []loadedTrigger) []*trigger.Matcher {
+	ms := make([]*trigger.Matcher, 0, len(triggers))
+	for _, lt := range triggers {
+		if lt.matcher != nil {
+			ms = append(ms, lt.matcher)
+		}
+	}
+	return ms
+}
+
+func triggerName(ts *utils.TriggerSetting) string {
+	return fmt.Sprintf("%s on %s", ts.Module, ts.On)
+}
@@ -0,0 +1,249 @@
+package start
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/api"
+	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/feed"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
+	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
+	"github.com/alpacahq/marketstore/v4/plugins/trigger"
+	"github.com/alpacahq/marketstore/v4/utils"
+)
+
+type fakeBgWorker struct {
+	running chan struct{}
+}
+
+func (w *fakeBgWorker) Run() {
+	close(w.running)
+	select {}
+}
+
+type fakeStoppableBgWorker struct {
+	fakeBgWorker
+	stopped chan struct{}
+}
+
+func (w *fakeStoppableBgWorker) RunContext(ctx context.Context) {
+	close(w.running)
+	<-ctx.Done()
+	close(w.stopped)
+}
+
+type fakeTrigger struct{}
+
+func (fakeTrigger) Fire(string, []trigger.Record) {}
+
+type fakeDispatcher struct {
+	matchers []*trigger.Matcher
+}
+
+func (d *fakeDispatcher) SetTriggerMatchers(ms []*trigger.Matcher) {
+	d.matchers = ms
+}
+
+func TestConfigReloader(t *testing.T) {
+	t.Parallel()
+	configFilePath := filepath.Join(t.TempDir(), "mkts.yml")
+	writeConfig := func(config string) {
+		require.Nil(t, os.WriteFile(configFilePath, []byte("root_directory: data\nlisten_port: 5993\n"+config), 0o600))
+	}
+
+	// the bgworker named "legacy" can't be stopped, and the module "broken.so" fails to be loaded
+	workers := map[string]*fakeStoppableBgWorker{}
+	bgWorkers := NewBgWorkers()
+	bgWorkers.stopTimeout = time.Second
+	bgWorkers.newBgWorker = func(s *utils.BgWorkerSetting) bgworker.BgWorker {
+		if s.Module == "broken.so" {
+			return nil
+		}
+		w := &fakeStoppableBgWorker{
+			fakeBgWorker: fakeBgWorker{running: make(chan struct{})},
+			stopped:      make(chan struct{}),
+		}
+		workers[s.Name] = w
+		if s.Name == "legacy" {
+			return &w.fakeBgWorker
+		}
+		return w
+	}
+	tpd := &fakeDispatcher{}
+	r := &ConfigReloader{
+		configFilePath: configFilePath,
+		bgWorkers:      bgWorkers,
+		tpd:            tpd,
+		newTriggerMatcher: func(ts *utils.TriggerSetting) *trigger.Matcher {
+			if ts.Module == "broken.so" {
+				return nil
+			}
+			return trigger.NewMatcher(fakeTrigger{}, ts.On)
+		},
+	}
+	writeConfig(`
+triggers:
+  - module: ondiskagg.so
+    on: "*/1Min/OHLCV"
+    config:
+      destinations: [5Min]
+bgworkers:
+  - module: feeder.so
+    name: feeder
+    config:
+      symbols: [AAPL]
+  - module: feeder.so
+    name: legacy
+`)
+	resp, err := r.ReloadConfig()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"ondiskagg.so on */1Min/OHLCV"}, resp.TriggersAdded)
+	assert.Equal(t, []string{"feeder", "legacy"}, resp.BgWorkersStarted)
+	firstMatcher := tpd.matchers[0]
+	firstFeeder := workers["feeder"]
+	<-firstFeeder.running
+
+	// --- when ---
+	writeConfig(`
+triggers:
+  - module: ondiskagg.so
+    on: "*/1Min/OHLCV"
+    config:
+      destinations: [5Min]
+  - module: broken.so
+    on: "*/1Sec/OHLCV"
+bgworkers:
+  - module: feeder.so
+    name: feeder
+    config:
+      symbols: [AAPL, AMZN]
+`)
+	resp, err = r.ReloadConfig()
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Empty(t, resp.TriggersAdded)
+	assert.Empty(t, resp.TriggersRemoved)
+	// the unchanged trigger is reused
+	require.Len(t, tpd.matchers, 1)
+	assert.Same(t, firstMatcher, tpd.matchers[0])
+	// the changed bgworker is restarted, and the one that can't be stopped keeps running
+	assert.Equal(t, []string{"feeder"}, resp.BgWorkersStarted)
+	assert.Equal(t, []string{"feeder"}, resp.BgWorkersStopped)
+	<-firstFeeder.stopped
+	assert.NotSame(t, firstFeeder, workers["feeder"])
+	assert.Equal(t, []string{
+		"failed to load trigger broken.so on */1Sec/OHLCV",
+		"bgworker legacy can't be stopped without restarting the server",
+	}, resp.Errors)
+
+	// --- when the config is invalid ---
+	writeConfig("timezone: Nowhere/Nowhere\n")
+	_, err = r.ReloadConfig()
+
+	// --- then nothing is changed ---
+	assert.NotNil(t, err)
+	assert.Len(t, tpd.matchers, 1)
+
+	// --- when the trigger is removed ---
+	writeConfig("bgworkers: []\n")
+	resp, err = r.ReloadConfig()
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, []string{"ondiskagg.so on */1Min/OHLCV"}, resp.TriggersRemoved)
+	assert.Empty(t, tpd.matchers)
+	assert.Equal(t, []string{"feeder"}, resp.BgWorkersStopped)
+}
+
+// snapshotsAPIClient counts the calls of the Alpaca snapshots API.
+type snapshotsAPIClient struct {
+	calls int64
+}
+
+func (c *snapshotsAPIClient) GetSnapshots(_ []string) (map[string]*api.Snapshot, error) {
+	atomic.AddInt64(&c.calls, 1)
+	return map[string]*api.Snapshot{}, nil
+}
+
+func (c *snapshotsAPIClient) callCount() int64 {
+	return atomic.LoadInt64(&c.calls)
+}
+
+type openMarketTimeChecker struct{}
+
+func (openMarketTimeChecker) IsOpen(time.Time) bool { return true }
+
+func (openMarketTimeChecker) Sub(date time.Time, _ int) (time.Time, error) { return date, nil }
+
+type nopSnapshotWriter struct{}
+
+func (nopSnapshotWriter) Write(map[string]*api.Snapshot) error { return nil }
+
+func TestConfigReloader_AlpacaBrokerFeeder(t *testing.T) {
+	t.Parallel()
+	configFilePath := filepath.Join(t.TempDir(), "mkts.yml")
+	writeConfig := func(config string) {
+		require.Nil(t, os.WriteFile(configFilePath, []byte("root_directory: data\nlisten_port: 5993\n"+config), 0o600))
+	}
+
+	// the feeder polls the API of its own client
+	var clients []*snapshotsAPIClient
+	bgWorkers := NewBgWorkers()
+	bgWorkers.stopTimeout = time.Second
+	bgWorkers.newBgWorker = func(s *utils.BgWorkerSetting) bgworker.BgWorker {
+		c := &snapshotsAPIClient{}
+		clients = append(clients, c)
+		return &feed.Worker{
+			MarketTimeChecker: openMarketTimeChecker{},
+			APIClient:         c,
+			SymbolManager:     symbols.StaticManager{"AAPL"},
+			SnapshotWriter:    nopSnapshotWriter{},
+			Interval:          1,
+		}
+	}
+	r := &ConfigReloader{
+		configFilePath: configFilePath,
+		bgWorkers:      bgWorkers,
+		tpd:            &fakeDispatcher{},
+	}
+	writeConfig(`
+bgworkers:
+  - module: alpacabkfeeder.so
+    name: alpaca
+    config:
+      exchanges: [NYSE]
+`)
+	resp, err := r.ReloadConfig()
+	require.Nil(t, err)
+	require.Equal(t, []string{"alpaca"}, resp.BgWorkersStarted)
+	require.Eventually(t, func() bool { return clients[0].callCount() > 0 }, time.Second, 10*time.Millisecond)
+
+	// --- when ---
+	writeConfig(`
+bgworkers:
+  - module: alpacabkfeeder.so
+    name: alpaca
+    config:
+      exchanges: [NYSE, NASDAQ]
+`)
+	resp, err = r.ReloadConfig()
+
+	// --- then ---
+	// the feeder with the previous config is stopped in time, and the new one polls the API
+	require.Nil(t, err)
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, []string{"alpaca"}, resp.BgWorkersStopped)
+	assert.Equal(t, []string{"alpaca"}, resp.BgWorkersStarted)
+	require.Len(t, clients, 2)
+	stoppedCalls := clients[0].callCount()
+	require.Eventually(t, func() bool { return clients[1].callCount() > 1 }, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, stoppedCalls, clients[0].callCount())
+}
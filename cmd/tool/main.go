@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/alpacahq/marketstore/v4/cmd/tool/integrity"
+	"github.com/alpacahq/marketstore/v4/cmd/tool/reindex"
 	"github.com/alpacahq/marketstore/v4/cmd/tool/wal"
 )
 
@@ -19,12 +20,13 @@ var Cmd = &cobra.Command{
 	Use:        usage,
 	Short:      short,
 	Long:       long,
-	SuggestFor: []string{"wal", "integrity"},
+	SuggestFor: []string{"wal", "integrity", "reindex"},
 	Example:    example,
 }
 
 // nolint:gochecknoinits // cobra's standard way to initialize flags
 func init() {
 	Cmd.AddCommand(integrity.Cmd)
+	Cmd.AddCommand(reindex.Cmd)
 	Cmd.AddCommand(wal.Cmd)
 }
@@ -0,0 +1,57 @@
+package reindex
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+const (
+	usage       = "reindex"
+	short       = "Rebuild the catalog index"
+	long        = "This command scans the data directory and rebuilds its catalog index. Stop marketstore before running it"
+	example     = "marketstore tool reindex --dir <path>"
+	rootDirDesc = "set the path to the root directory of the database"
+)
+
+var (
+	// Cmd is the reindex command.
+	Cmd = &cobra.Command{
+		Use:     usage,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		RunE:    executeReindex,
+	}
+	// rootDirPath is the path to the root directory.
+	rootDirPath string
+)
+
+// nolint:gochecknoinits // cobra's standard way to initialize flags
+func init() {
+	// Parse flags.
+	Cmd.Flags().StringVarP(&rootDirPath, "dir", "d", "", rootDirDesc)
+	err := Cmd.MarkFlagRequired("dir")
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to mark 'dir' flag required. err=%v", err.Error()))
+	}
+}
+
+func executeReindex(cmd *cobra.Command, args []string) error {
+	log.SetLevel(log.INFO)
+
+	rootDir, err := filepath.Abs(filepath.Clean(rootDirPath))
+	if err != nil {
+		return err
+	}
+	files, err := catalog.Reindex(rootDir)
+	if err != nil {
+		return fmt.Errorf("rebuild the catalog index of %s: %w", rootDir, err)
+	}
+	log.Info(fmt.Sprintf("rebuilt the catalog index of %s with %d year files", rootDir, files))
+	return nil
+}
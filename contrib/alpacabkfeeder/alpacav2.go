@@ -12,8 +12,12 @@ import (
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/configs"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/feed"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/symbols"
-	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/timer"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/writer"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
+	feedersymbols "github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/timer"
+	feederwriter "github.com/alpacahq/marketstore/v4/contrib/feeder/writer"
 	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/log"
@@ -21,6 +25,8 @@ import (
 
 const getJSONFileTimeout = 10 * time.Second
 
+var ny, _ = time.LoadLocation("America/New_York")
+
 // NewBgWorker returns the new instance of Alpaca Broker API Feeder.
 // See configs.Config for the details of available configurations.
 // nolint:deadcode // used as a plugin
@@ -34,9 +40,9 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 	apiCli := apiClient(config)
 
 	// init Market Time Checker
-	var timeChecker feed.MarketTimeChecker = defaultTimeChecker(config)
+	var timeChecker markettime.MarketTimeChecker = defaultTimeChecker(config)
 	if config.OffHoursSchedule != "" {
-		scheduleMin, err := feed.ParseSchedule(config.OffHoursSchedule)
+		scheduleMin, err := markettime.ParseSchedule(config.OffHoursSchedule)
 		if err != nil {
 			return nil, fmt.Errorf("parse off_hours_schedule %s: %w", config.OffHoursSchedule, err)
 		}
@@ -44,7 +50,7 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 			"The data will be retrieved at %s [minute] even when the market is closed.",
 			config.OffHoursSchedule, config.OffHoursSchedule),
 		)
-		timeChecker = feed.NewScheduledMarketTimeChecker(
+		timeChecker = markettime.NewScheduledMarketTimeChecker(
 			timeChecker,
 			scheduleMin,
 		)
@@ -57,7 +63,7 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 
 	ctx := context.Background()
 	// init symbols Manager to update symbols in the target exchanges
-	var sm symbols.Manager
+	var sm feedersymbols.Manager
 	sm = symbols.NewManager(apiCli, config.Exchanges)
 	if config.StocksJSONURL != "" {
 		// use a remote JSON file instead of the config.Exchanges to list up the symbols
@@ -66,15 +72,15 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 		)
 		log.Info("updating symbols using a remote json file.")
 	}
-	sm.UpdateSymbols()
+	sm.UpdateSymbols(ctx)
 	if config.SymbolsUpdateTime.IsZero() {
 		config.SymbolsUpdateTime = config.UpdateTime
 	}
-	timer.RunEveryDayAt(ctx, config.SymbolsUpdateTime, sm.UpdateSymbols)
+	jobs := []timer.Job{{At: config.SymbolsUpdateTime, Run: sm.UpdateSymbols}}
 
 	// init BarWriter
 	var bw writer.BarWriter = writer.BarWriterImpl{
-		MarketStoreWriter: &writer.MarketStoreWriterImpl{},
+		MarketStoreWriter: &feederwriter.MarketStoreWriterImpl{},
 		Timeframe:         config.Backfill.Timeframe,
 		Timezone:          utils.InstanceConfig.Timezone,
 	}
@@ -84,9 +90,9 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 		const maxBarsPerRequest = 1000
 		const maxSymbolsPerRequest = 100
 		bf := feed.NewBackfill(sm, apiCli, bw, time.Time(config.Backfill.Since),
-			maxBarsPerRequest, maxSymbolsPerRequest,
+			maxBarsPerRequest, maxSymbolsPerRequest, backfill.NewLimiter(config.Backfill.RequestsPerSecond),
 		)
-		timer.RunEveryDayAt(ctx, config.UpdateTime, bf.UpdateSymbols)
+		jobs = append(jobs, timer.Job{At: config.UpdateTime, Run: bf.UpdateSymbols})
 	}
 
 	return &feed.Worker{
@@ -96,6 +102,7 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 		SnapshotWriter:    snapshotWriter(config),
 		BarWriter:         bw,
 		Interval:          config.Interval,
+		Jobs:              jobs,
 	}, nil
 }
 
@@ -115,12 +122,13 @@ func apiClient(config *configs.DefaultConfig) *api.Client {
 	return api.NewClient(cred)
 }
 
-func defaultTimeChecker(config *configs.DefaultConfig) *feed.DefaultMarketTimeChecker {
-	return feed.NewDefaultMarketTimeChecker(
+func defaultTimeChecker(config *configs.DefaultConfig) *markettime.DefaultMarketTimeChecker {
+	return markettime.NewDefaultMarketTimeChecker(
+		ny,
 		config.ClosedDaysOfTheWeek,
 		config.ClosedDays,
-		config.OpenHourNY, config.OpenMinuteNY,
-		config.CloseHourNY, config.CloseMinuteNY)
+		markettime.Clock(config.OpenHourNY, config.OpenMinuteNY),
+		markettime.Clock(config.CloseHourNY, config.CloseMinuteNY))
 }
 
 func snapshotWriter(config *configs.DefaultConfig) writer.SnapshotWriter {
@@ -130,7 +138,7 @@ func snapshotWriter(config *configs.DefaultConfig) writer.SnapshotWriter {
 	}
 
 	return writer.NewSnapshotWriterImpl(
-		&writer.MarketStoreWriterImpl{},
+		&feederwriter.MarketStoreWriterImpl{},
 		config.Timeframe,
 		utils.InstanceConfig.Timezone,
 		tc,
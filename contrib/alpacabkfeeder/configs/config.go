@@ -49,6 +49,8 @@ type DefaultConfig struct {
 		// Since has only year, month, and day (00:00:00, UTC)
 		Since     CustomDay `json:"since"`
 		Timeframe string    `json:"timeframe"`
+		// RequestsPerSecond limits the rate of the API calls for the backfill. 0 means no limit.
+		RequestsPerSecond float64 `json:"requests_per_second"`
 	} `json:"backfill"`
 }
 
@@ -1,11 +1,16 @@
 package feed
 
 import (
+	"context"
+	"fmt"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/api"
-	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/symbols"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/writer"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
@@ -19,6 +24,7 @@ type Backfill struct {
 	since            time.Time
 	maxBarsPerReq    int
 	maxSymbolsPerReq int
+	limiter          *rate.Limiter
 }
 
 type GetMultiBarsAPIClient interface {
@@ -28,55 +34,62 @@ type GetMultiBarsAPIClient interface {
 // NewBackfill initializes the module to backfill the historical daily chart data to marketstore.
 // Alpaca API spec: maxBarsPerRequest: 1000 bars per symbol per request at maximum
 // Alpaca API spec: maxSymbolsPerRequest: 100 symbols per request at maximum.
+// limiter limits the rate of the API calls for the backfill. nil means no limit.
 func NewBackfill(symbolManager symbols.Manager, apiClient GetMultiBarsAPIClient, barWriter writer.BarWriter,
-	since time.Time, maxBarsPerReq, maxSymbolsPerReq int,
+	since time.Time, maxBarsPerReq, maxSymbolsPerReq int, limiter *rate.Limiter,
 ) *Backfill {
 	return &Backfill{
 		symbolManager: symbolManager, apiClient: apiClient, barWriter: barWriter, since: since,
-		maxBarsPerReq: maxBarsPerReq, maxSymbolsPerReq: maxSymbolsPerReq,
+		maxBarsPerReq: maxBarsPerReq, maxSymbolsPerReq: maxSymbolsPerReq, limiter: limiter,
 	}
 }
 
 // UpdateSymbols aggregates daily chart data since the specified date
 // and store it to "{symbol}/{timeframe}/OHLCV" bucket in marketstore.
-func (b *Backfill) UpdateSymbols() {
+func (b *Backfill) UpdateSymbols(ctx context.Context) {
 	allSymbols := b.symbolManager.GetAllSymbols()
 	y, m, d := time.Now().UTC().Date()
 	until := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
 
 	// paginate symbols & paginate bars
-	for idx := range pageIndex(len(allSymbols), b.maxSymbolsPerReq) {
-		for dateRange := range datePageIndex(b.since, until, b.maxBarsPerReq) {
-			// fmt.Printf("start=%v, end=%v, symbols=%v\n", dateRange.From, dateRange.To, allSymbols[idx.From:idx.To])
-			params := api.GetBarsParams{
-				TimeFrame: backfillTimeframe,
-				Start:     time230000utc(dateRange.From),
-				End:       maxPast16min(time230000utc(dateRange.To)),
-				PageLimit: b.maxBarsPerReq,
-			}
+	p := backfill.Paginator{
+		MaxSymbolsPerReq: b.maxSymbolsPerReq, MaxDaysPerReq: b.maxBarsPerReq, Limiter: b.limiter,
+	}
+	if err := p.Run(ctx, allSymbols, b.since, until, b.fetch); err != nil {
+		log.Error("[Alpaca Broker Feeder] daily chart backfill is stopped: %v", err)
+		return
+	}
 
-			// get data
-			symbolBarsMap, err := b.apiClient.GetMultiBars(allSymbols[idx.From:idx.To], params)
-			if err != nil {
-				log.Error("Alpaca MarketData GetMultiBars API call error. params=%v, Err=%v", params, err)
-				return
-			}
-			log.Info("Alpaca GetMultiBars API call: From=%v, To=%v, symbols=%v",
-				dateRange.From, dateRange.To, allSymbols[idx.From:idx.To],
-			)
+	log.Info("[Alpaca Broker Feeder] daily chart backfill is successfully done.")
+}
 
-			// write data
-			for symbl, bars := range symbolBarsMap {
-				err := b.barWriter.Write(symbl, bars)
-				if err != nil {
-					log.Error("failed to backfill the daily chart data "+
-						"to marketstore in UpdateSymbols. symbol=%v, err=%v", symbl, err)
-				}
-			}
-		}
+// fetch gets the daily chart data of the symbols in the date range and writes it to marketstore.
+func (b *Backfill) fetch(_ context.Context, symbls []string, dateRange backfill.DateRange) error {
+	params := api.GetBarsParams{
+		TimeFrame: backfillTimeframe,
+		Start:     time230000utc(dateRange.From),
+		End:       maxPast16min(time230000utc(dateRange.To)),
+		PageLimit: b.maxBarsPerReq,
 	}
 
-	log.Info("[Alpaca Broker Feeder] daily chart backfill is successfully done.")
+	// get data
+	symbolBarsMap, err := b.apiClient.GetMultiBars(symbls, params)
+	if err != nil {
+		return fmt.Errorf("alpaca MarketData GetMultiBars API call error. params=%v: %w", params, err)
+	}
+	log.Info("Alpaca GetMultiBars API call: From=%v, To=%v, symbols=%v",
+		dateRange.From, dateRange.To, symbls,
+	)
+
+	// write data
+	for symbl, bars := range symbolBarsMap {
+		err := b.barWriter.Write(symbl, bars)
+		if err != nil {
+			log.Error("failed to backfill the daily chart data "+
+				"to marketstore in UpdateSymbols. symbol=%v, err=%v", symbl, err)
+		}
+	}
+	return nil
 }
 
 // Alpaca GetMultiBars API returns daily chart data based on US time.
@@ -102,66 +115,3 @@ func maxPast16min(time2 time.Time) time.Time {
 	}
 	return time2
 }
-
-// utilities for pagination.
-type index struct {
-	From, To int
-}
-
-func pageIndex(length, pageSize int) <-chan index {
-	ch := make(chan index)
-
-	go func() {
-		defer close(ch)
-
-		for i := 0; i < length; i += pageSize {
-			idx := index{i, i + pageSize}
-			if length < idx.To {
-				idx.To = length
-			}
-			ch <- idx
-		}
-	}()
-
-	return ch
-}
-
-type dateRange struct {
-	From, To time.Time
-}
-
-// datePageIndex returns a channel with paginated date ranges.
-// datePageIndex assumes that start and end have only year, month, and day information
-// like time.Date(yyyy, mm, dd, 0,0,0,0, time.UTC)
-// e.g. start = 2021-12-01, end = 2021-12-06, pageDays = 2
-// -> chan will return
-// [
-//	{From:2021-12-01, To:2021-12-03},
-//	{From:2021-12-03, To:2021-12-05},
-//	{From:2021-12-05, To:2021-12-06}
-// ].
-func datePageIndex(start, end time.Time, pageDays int) <-chan dateRange {
-	ch := make(chan dateRange)
-
-	go func() {
-		defer close(ch)
-
-		i := start
-		for {
-			pageStart := i
-			pageEnd := i.AddDate(0, 0, pageDays)
-			if pageEnd.After(end) {
-				pageEnd = end
-			}
-			page := dateRange{From: pageStart, To: pageEnd}
-			ch <- page
-
-			i = i.AddDate(0, 0, pageDays)
-			if i.Equal(end) || i.After(end) {
-				break
-			}
-		}
-	}()
-
-	return ch
-}
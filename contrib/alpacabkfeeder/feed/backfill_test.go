@@ -1,6 +1,7 @@
 package feed_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/feed"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/internal"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/writer"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
 )
 
 var (
@@ -136,15 +138,15 @@ func TestBackfill_UpdateSymbols(t *testing.T) {
 
 			var barWriter writer.BarWriter = &MockBarWriter{}
 
-			symbolManager := internal.MockSymbolsManager{Symbols: tt.smbls}
+			symbolManager := symbols.StaticManager(tt.smbls)
 
 			b := feed.NewBackfill(symbolManager,
 				&MockErrorAPIClient{testBars: tt.testBars},
 				barWriter,
-				tt.since, tt.maxBarsPerReq, tt.maxSymbolsPerReq,
+				tt.since, tt.maxBarsPerReq, tt.maxSymbolsPerReq, nil,
 			)
 
-			b.UpdateSymbols()
+			b.UpdateSymbols(context.Background())
 
 			if mbw, ok := barWriter.(*MockBarWriter); ok {
 				assert.Equal(t, tt.wantWrittenBarCount, mbw.WriteCount)
@@ -1,25 +1,40 @@
 package feed
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/api"
-	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/symbols"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/writer"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/timer"
+	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
-// Worker is the main worker instance.  It implements bgworker.Run().
+// states reported by the Worker.
+const (
+	statePolling      = "polling"
+	stateMarketClosed = "market closed"
+)
+
+// Worker is the main worker instance.  It implements bgworker.StoppableBgWorker and bgworker.StatusReporter.
+// The status has the time of the last successful poll and the error of the last failed one.
 type Worker struct {
-	MarketTimeChecker MarketTimeChecker
+	bgworker.StatusTracker
+	MarketTimeChecker markettime.MarketTimeChecker
 	APIClient         GetSnapShotsAPIClient
 	SymbolManager     symbols.Manager
 	SnapshotWriter    writer.SnapshotWriter
 	BarWriter         writer.BarWriter
 	Interval          int
+	// Jobs are the daily jobs such as the symbol updates and the backfill. optional.
+	Jobs []timer.Job
 }
 
 type GetSnapShotsAPIClient interface {
@@ -29,10 +44,36 @@ type GetSnapShotsAPIClient interface {
 // Run runs forever to get quotes data for each symbol in the target exchanges using Alpaca API periodically,
 // and writes the data to the local marketstore server.
 func (w *Worker) Run() {
+	w.RunContext(context.Background())
+}
+
+// RunContext runs as Run and the daily jobs until ctx is canceled.
+// It waits for the running request, write and jobs before it returns.
+func (w *Worker) RunContext(ctx context.Context) {
+	jobsDone := make([]<-chan struct{}, len(w.Jobs))
+	for i, job := range w.Jobs {
+		jobsDone[i] = timer.RunEveryDayAt(ctx, job.At, job.Run)
+	}
+
+	var wg sync.WaitGroup
 	for {
 		// try to get the data and write them every second
-		go w.tryPrintErr()
-		time.Sleep(time.Duration(w.Interval) * time.Second)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.tryPrintErr()
+		}()
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			for _, done := range jobsDone {
+				<-done
+			}
+			log.Info("[Alpaca Broker Feeder] stopped")
+			return
+		case <-time.After(time.Duration(w.Interval) * time.Second):
+		}
 	}
 }
 
@@ -40,6 +81,7 @@ func (w *Worker) Run() {
 func (w *Worker) tryPrintErr() {
 	if err := w.try(); err != nil {
 		log.Error(err.Error())
+		w.RecordError(err)
 	}
 
 	// nolint:gocritic // (unnecessaryDefer) this defer is for recovering from panic
@@ -56,8 +98,10 @@ func (w *Worker) tryPrintErr() {
 func (w *Worker) try() error {
 	// check if it needs to work now
 	if !w.MarketTimeChecker.IsOpen(time.Now().UTC()) {
+		w.SetState(stateMarketClosed)
 		return nil
 	}
+	w.SetState(statePolling)
 	// call Alpaca API to get Quotes data
 	symbls := w.SymbolManager.GetAllSymbols()
 	snapshots, err := w.APIClient.GetSnapshots(symbls)
@@ -74,5 +118,6 @@ func (w *Worker) try() error {
 		return errors.Wrap(err, "failed to write quotes data")
 	}
 
+	w.RecordWrite()
 	return nil
 }
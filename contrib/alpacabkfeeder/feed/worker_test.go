@@ -1,11 +1,15 @@
 package feed
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/api"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/internal"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
 )
 
 // MockTimeChecker always returns Open.
@@ -39,7 +43,7 @@ func TestWorker_try_normal(t *testing.T) {
 	SUT := Worker{
 		MarketTimeChecker: &MockTimeChecker{},
 		APIClient:         &internal.MockAPIClient{},
-		SymbolManager:     internal.MockSymbolsManager{},
+		SymbolManager:     symbols.StaticManager{},
 		SnapshotWriter:    w,
 		Interval:          1,
 	}
@@ -53,3 +57,54 @@ func TestWorker_try_normal(t *testing.T) {
 		t.Errorf("write should be performed once")
 	}
 }
+
+// MockFailingSnapshotsAPIClient always fails to get the snapshots.
+type MockFailingSnapshotsAPIClient struct {
+	internal.MockAPIClient
+}
+
+// GetSnapshots always returns an error.
+func (mac *MockFailingSnapshotsAPIClient) GetSnapshots(symbols []string) (map[string]*api.Snapshot, error) {
+	return nil, errors.New("api error")
+}
+
+func TestWorker_tryPrintErr_Status(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		apiClient     GetSnapShotsAPIClient
+		wantLastWrite bool
+		wantLastError bool
+	}{
+		"ok/ the successful poll is reported": {
+			apiClient:     &internal.MockAPIClient{},
+			wantLastWrite: true,
+		},
+		"ng/ the error of the failed poll is reported": {
+			apiClient:     &MockFailingSnapshotsAPIClient{},
+			wantLastError: true,
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// --- given ---
+			SUT := Worker{
+				MarketTimeChecker: &MockTimeChecker{},
+				APIClient:         tt.apiClient,
+				SymbolManager:     symbols.StaticManager{},
+				SnapshotWriter:    &MockSnapshotWriter{},
+				Interval:          1,
+			}
+
+			// --- when ---
+			SUT.tryPrintErr()
+
+			// --- then ---
+			status := SUT.Status()
+			assert.Equal(t, statePolling, status.State)
+			assert.Equal(t, tt.wantLastWrite, !status.LastWrite.IsZero())
+			assert.Equal(t, tt.wantLastError, status.LastError != "")
+		})
+	}
+}
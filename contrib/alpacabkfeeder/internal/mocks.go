@@ -8,19 +8,6 @@ import (
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
 
-// MockSymbolsManager is a no-op SymbolsManager.
-type MockSymbolsManager struct {
-	Symbols []string
-}
-
-// GetAllSymbols returns the static symbols.
-func (msm MockSymbolsManager) GetAllSymbols() []string {
-	return msm.Symbols
-}
-
-// UpdateSymbols does nothing.
-func (msm MockSymbolsManager) UpdateSymbols() {}
-
 // MockAPIClient is a no-op API client.
 type MockAPIClient struct{}
 
@@ -1,11 +1,12 @@
 package symbols
 
 import (
+	"context"
 	"fmt"
 
 	v1 "github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/api/v1"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/configs"
-	"github.com/alpacahq/marketstore/v4/utils/log"
+	feedersymbols "github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
 )
 
 // memo: enum for status should be defined, but since the ListAssets function of the Alpaca SDK
@@ -16,62 +17,40 @@ var (
 	// inactiveStatus = "inactive".
 )
 
-// Manager manages symbols in the target stock exchanges.
-// symbol(s) can be newly registered / removed from the exchange,
-// so target symbols should be updated periodically.
-type Manager interface {
-	GetAllSymbols() []string
-	UpdateSymbols()
-}
-
 type APIClient interface {
 	ListAssets(status *string) ([]v1.Asset, error)
 }
 
-// ManagerImpl is an implementation of the Manager.
-type ManagerImpl struct {
+// AssetLister lists the active symbols in the target stock exchanges using the ListAssets API.
+type AssetLister struct {
 	APIClient APIClient
 	// Key: exchange(e.g. "NYSE")
 	TargetExchanges map[configs.Exchange]struct{}
-	Symbols         []string
 }
 
-// NewManager initializes the SymbolManager object with the specified parameters.
-func NewManager(apiClient APIClient, targetExchanges []configs.Exchange) *ManagerImpl {
+// NewManager initializes the symbols Manager which updates the symbols in the target exchanges.
+func NewManager(apiClient APIClient, targetExchanges []configs.Exchange) *feedersymbols.ManagerImpl {
 	exchanges := make(map[configs.Exchange]struct{})
 	for _, exchange := range targetExchanges {
 		exchanges[exchange] = struct{}{}
 	}
 
-	return &ManagerImpl{
-		APIClient: apiClient, TargetExchanges: exchanges,
-		Symbols: []string{},
-	}
-}
-
-// GetAllSymbols returns Symbols for all the target exchanges.
-func (m *ManagerImpl) GetAllSymbols() []string {
-	return m.Symbols
+	return feedersymbols.NewManager(&AssetLister{APIClient: apiClient, TargetExchanges: exchanges})
 }
 
-// UpdateSymbols calls the ListSymbols endpoint, convert the symbols to the Symbols and store them to the Symbols map.
-func (m *ManagerImpl) UpdateSymbols() {
-	assets, err := m.APIClient.ListAssets(&activeStatus)
-	// if ListAssets API returns an error, don't update the target symbols
+// ListSymbols calls the ListAssets endpoint and returns the symbols in the target exchanges.
+func (l *AssetLister) ListSymbols(_ context.Context) ([]string, error) {
+	assets, err := l.APIClient.ListAssets(&activeStatus)
 	if err != nil {
-		log.Error(fmt.Sprintf("ListAssets: err=%v, API response=%v", err, assets))
-		return
+		return nil, fmt.Errorf("ListAssets: API response=%v: %w", assets, err)
 	}
 
 	// add symbols of exchanges in the target exchange list
 	var symbols []string
 	for _, asset := range assets {
-		if _, found := m.TargetExchanges[configs.Exchange(asset.Exchange)]; found {
+		if _, found := l.TargetExchanges[configs.Exchange(asset.Exchange)]; found {
 			symbols = append(symbols, asset.Symbol)
 		}
 	}
-
-	// replace target symbols
-	m.Symbols = symbols
-	log.Debug(fmt.Sprintf("Updated symbols. The number of symbols is %d", len(m.Symbols)))
+	return symbols, nil
 }
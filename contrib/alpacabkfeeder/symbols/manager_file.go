@@ -9,52 +9,35 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	feedersymbols "github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
-// JSONFileManager is a symbol manager that reads a json file to get the list of symbols.
-type JSONFileManager struct {
+// JSONFileLister reads a json file to get the list of symbols.
+type JSONFileLister struct {
 	httpClient          *http.Client
 	stocksJSONURL       string
 	stocksJSONBasicAuth string
-	symbols             []string
 }
 
-// NewJSONFileManager initializes the SymbolManager object with the specified parameters.
-func NewJSONFileManager(hc *http.Client, stocksJSONURL, stocksJSONBasicAuth string) *JSONFileManager {
-	return &JSONFileManager{
+// NewJSONFileManager initializes the symbols Manager which reads a remote json file to update the symbols.
+func NewJSONFileManager(hc *http.Client, stocksJSONURL, stocksJSONBasicAuth string) *feedersymbols.ManagerImpl {
+	return feedersymbols.NewManager(&JSONFileLister{
 		httpClient:          hc,
 		stocksJSONURL:       stocksJSONURL,
 		stocksJSONBasicAuth: stocksJSONBasicAuth,
-		symbols:             []string{},
-	}
-}
-
-// GetAllSymbols returns symbols for all the target exchanges.
-func (m *JSONFileManager) GetAllSymbols() []string {
-	return m.symbols
-}
-
-// UpdateSymbols gets a remote json file, store the symbols in the file to the symbols map.
-func (m *JSONFileManager) UpdateSymbols() {
-	if symbols := m.downloadSymbols(context.Background()); symbols != nil {
-		// replace target symbols
-		m.symbols = symbols
-		log.Debug(fmt.Sprintf("Updated symbols. The number of symbols is %d", len(m.symbols)))
-	}
+	})
 }
 
 type Stocks struct {
 	Data map[string]interface{} `json:"data"`
 }
 
-func (m *JSONFileManager) downloadSymbols(ctx context.Context) []string {
+// ListSymbols downloads the remote json file and returns the symbols in the file.
+func (m *JSONFileLister) ListSymbols(ctx context.Context) ([]string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", m.stocksJSONURL, http.NoBody)
 	if err != nil {
-		log.Error(fmt.Sprintf("failed to create a http req for stocks Json(URL=%s). err=%v",
-			m.stocksJSONURL, err,
-		))
-		return nil
+		return nil, fmt.Errorf("create a http req for stocks Json(URL=%s): %w", m.stocksJSONURL, err)
 	}
 	// set basic auth
 	if m.stocksJSONBasicAuth != "" {
@@ -63,28 +46,19 @@ func (m *JSONFileManager) downloadSymbols(ctx context.Context) []string {
 
 	resp, err := m.httpClient.Do(req)
 	if err != nil {
-		log.Error("failed to download stocks Json(URL=%s). err=%v",
-			m.stocksJSONURL, err,
-		)
-		return nil
+		return nil, fmt.Errorf("download stocks Json(URL=%s): %w", m.stocksJSONURL, err)
 	}
 	defer func(Body io.ReadCloser) { _ = Body.Close() }(resp.Body)
 
 	b, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Error("failed to read body(URL=%s). err=%v",
-			m.stocksJSONURL, err,
-		)
-		return nil
+		return nil, fmt.Errorf("read body(URL=%s): %w", m.stocksJSONURL, err)
 	}
 
 	var s Stocks
 	err = json.Unmarshal(b, &s)
 	if err != nil {
-		log.Error("failed to unmarshal json(URL=%s). err=%v",
-			m.stocksJSONURL, err,
-		)
-		return nil
+		return nil, fmt.Errorf("unmarshal json(URL=%s): %w", m.stocksJSONURL, err)
 	}
 
 	symbols := make([]string, len(s.Data))
@@ -94,5 +68,5 @@ func (m *JSONFileManager) downloadSymbols(ctx context.Context) []string {
 		i++
 	}
 	log.Info(fmt.Sprintf("downloaded a json file(URL=%s), len(symbols)=%d", m.stocksJSONURL, len(symbols)))
-	return symbols
+	return symbols, nil
 }
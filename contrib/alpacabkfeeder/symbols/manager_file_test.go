@@ -2,6 +2,7 @@ package symbols_test
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"sort"
@@ -73,7 +74,7 @@ func TestJsonFileManager_UpdateSymbols(t *testing.T) {
 			m := symbols.NewJSONFileManager(httpClient, "test", "user:pass")
 
 			// --- when ---
-			m.UpdateSymbols()
+			m.UpdateSymbols(context.Background())
 
 			// --- then ---
 			require.Equal(t, sortStrSlice(tt.wantSymbols), sortStrSlice(m.GetAllSymbols()))
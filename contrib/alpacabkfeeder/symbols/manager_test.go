@@ -1,6 +1,7 @@
 package symbols
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -44,15 +45,15 @@ func TestManagerImpl_UpdateSymbols(t *testing.T) {
 	SUT := NewManager(&MockListAssetsAPIClient{}, []configs.Exchange{"NASDAQ", "NYSE"})
 
 	// --- when ---
-	SUT.UpdateSymbols()
+	SUT.UpdateSymbols(context.Background())
 
 	// --- then ---
 	expectedSymbols := []string{"EFGH", "IJKL", "MNOP"}
 
 	if !reflect.DeepEqual(
-		SUT.Symbols,
+		SUT.GetAllSymbols(),
 		expectedSymbols,
 	) {
-		t.Errorf("symbols: want=%v, got=%v", expectedSymbols, SUT.Symbols)
+		t.Errorf("symbols: want=%v, got=%v", expectedSymbols, SUT.GetAllSymbols())
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/api"
+	feederwriter "github.com/alpacahq/marketstore/v4/contrib/feeder/writer"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
@@ -25,7 +26,7 @@ func (nc *NoopMarketTimeChecker) IsOpen(t time.Time) bool {
 	return true
 }
 
-func NewSnapshotWriterImpl(w MarketStoreWriter, tf string, tz *time.Location, tc MarketTimeChecker,
+func NewSnapshotWriterImpl(w feederwriter.MarketStoreWriter, tf string, tz *time.Location, tc MarketTimeChecker,
 ) *SnapshotWriterImpl {
 	return &SnapshotWriterImpl{
 		MarketStoreWriter: w,
@@ -37,7 +38,7 @@ func NewSnapshotWriterImpl(w MarketStoreWriter, tf string, tz *time.Location, tc
 
 // SnapshotWriterImpl is an implementation of the SnapshotWriter interface.
 type SnapshotWriterImpl struct {
-	MarketStoreWriter MarketStoreWriter
+	MarketStoreWriter feederwriter.MarketStoreWriter
 	Timeframe         string
 	// SnapshotWriterImpl writes data with the timezone
 	Timezone    *time.Location
@@ -5,14 +5,13 @@ import (
 	"testing"
 	"time"
 
-	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/feed"
-	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/writer"
-
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/api"
 	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/internal"
+	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/writer"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
 
@@ -64,8 +63,8 @@ var (
 		BidPrice:  2,
 		Timestamp: time.Date(2019, 7, 19, 9, 31, 0, 0, ny), // open
 	}
-	timeChecker = feed.NewDefaultMarketTimeChecker(nil, nil,
-		9, 30, 16, 30,
+	timeChecker = markettime.NewDefaultMarketTimeChecker(ny, nil, nil,
+		markettime.Clock(9, 30), markettime.Clock(16, 30),
 	)
 )
 
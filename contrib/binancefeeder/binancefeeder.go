@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -14,8 +13,8 @@ import (
 
 	binance "github.com/adshao/go-binance"
 
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
 	"github.com/alpacahq/marketstore/v4/executor"
-	"github.com/alpacahq/marketstore/v4/planner"
 	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/io"
@@ -203,38 +202,11 @@ func getTradingSymbols(quoteAssets []string, m ExchangeInfo) []string {
 }
 
 func findLastTimestamp(tbk *io.TimeBucketKey) time.Time {
-	cDir := executor.ThisInstance.CatalogDir
-	query := planner.NewQuery(cDir)
-	query.AddTargetKey(tbk)
-	start := time.Unix(0, 0).In(utils.InstanceConfig.Timezone)
-	end := time.Unix(math.MaxInt64, 0).In(utils.InstanceConfig.Timezone)
-	query.SetRange(start, end)
-	query.SetRowLimit(io.LAST, 1)
-	parsed, err := query.Parse()
+	lastTimestamp, err := backfill.LastWrittenTime(executor.ThisInstance.CatalogDir, tbk)
 	if err != nil {
-		log.Error(fmt.Sprintf("failed to parse query for %s", tbk))
-		return time.Time{}
+		log.Error(fmt.Sprintf("failed to find the last timestamp of %s: %v", tbk, err))
 	}
-	reader, err := executor.NewReader(parsed)
-	if err != nil {
-		log.Error(fmt.Sprintf("failed to create new reader for %s", tbk))
-		return time.Time{}
-	}
-	csm, err := reader.Read()
-	if err != nil {
-		log.Error(fmt.Sprintf("failed to read query for %s", tbk))
-		return time.Time{}
-	}
-	cs := csm[*tbk]
-	if cs == nil || cs.Len() == 0 {
-		return time.Time{}
-	}
-	ts, err := cs.GetTime()
-	if err != nil {
-		log.Error(fmt.Sprintf("failed to get time from query(tbk=%s)", tbk))
-		return time.Time{}
-	}
-	return ts[0]
+	return lastTimestamp
 }
 
 // NewBgWorker registers a new background worker.
@@ -3,13 +3,12 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"time"
 
 	bitmex "github.com/alpacahq/marketstore/v4/contrib/bitmexfeeder/api"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
 	"github.com/alpacahq/marketstore/v4/executor"
-	"github.com/alpacahq/marketstore/v4/planner"
 	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/io"
@@ -116,37 +115,11 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 }
 
 func findLastTimestamp(tbk *io.TimeBucketKey) time.Time {
-	cDir := executor.ThisInstance.CatalogDir
-	query := planner.NewQuery(cDir)
-	query.AddTargetKey(tbk)
-	start := time.Unix(0, 0).In(utils.InstanceConfig.Timezone)
-	end := time.Unix(math.MaxInt64, 0).In(utils.InstanceConfig.Timezone)
-	query.SetRange(start, end)
-	query.SetRowLimit(io.LAST, 1)
-	parsed, err := query.Parse()
+	lastTimestamp, err := backfill.LastWrittenTime(executor.ThisInstance.CatalogDir, tbk)
 	if err != nil {
-		return time.Time{}
+		log.Error(fmt.Sprintf("failed to find the last timestamp of %s: %v", tbk, err))
 	}
-	reader, err := executor.NewReader(parsed)
-	if err != nil {
-		log.Error(fmt.Sprintf("failed to create a new reader for %s", tbk))
-		return time.Time{}
-	}
-	csm, err := reader.Read()
-	if err != nil {
-		log.Error(fmt.Sprintf("failed to read a query for %s", tbk))
-		return time.Time{}
-	}
-	cs := csm[*tbk]
-	if cs == nil || cs.Len() == 0 {
-		return time.Time{}
-	}
-	ts, err := cs.GetTime()
-	if err != nil {
-		log.Error(fmt.Sprintf("failed to get time from query for %s", tbk))
-		return time.Time{}
-	}
-	return ts[0]
+	return lastTimestamp
 }
 
 // Run runs forever to get public historical rate for each configured symbol,
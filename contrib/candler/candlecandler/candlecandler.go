@@ -69,21 +69,40 @@ func (c *CandleCandler) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap,
 	highCols := argMap.GetMappedColumns(requiredColumns[1].Name)
 	lowCols := argMap.GetMappedColumns(requiredColumns[2].Name)
 	closeCols := argMap.GetMappedColumns(requiredColumns[3].Name)
-	open, err := candler.GetAverageColumnFloat32(cols, openCols)
-	if err != nil {
-		return nil, err
-	}
-	high, err := candler.GetAverageColumnFloat32(cols, highCols)
-	if err != nil {
-		return nil, err
+
+	/*
+		Decimal prices are used as is when all of them have the same scale
+	*/
+	openDec, scale, decimal := candler.GetDecimalColumn(cols, openCols)
+	var decCols [3][]io.Decimal64
+	for i, srcCols := range [][]io.DataShape{highCols, lowCols, closeCols} {
+		col, s, ok := candler.GetDecimalColumn(cols, srcCols)
+		decimal = decimal && ok && s == scale
+		decCols[i] = col
 	}
-	low, err := candler.GetAverageColumnFloat32(cols, lowCols)
-	if err != nil {
+	if err := c.SetDecimal(decimal, scale); err != nil {
 		return nil, err
 	}
-	clos, err := candler.GetAverageColumnFloat32(cols, closeCols)
-	if err != nil {
-		return nil, err
+
+	var open, high, low, clos []float32
+	if !decimal {
+		var err error
+		open, err = candler.GetAverageColumnFloat32(cols, openCols)
+		if err != nil {
+			return nil, err
+		}
+		high, err = candler.GetAverageColumnFloat32(cols, highCols)
+		if err != nil {
+			return nil, err
+		}
+		low, err = candler.GetAverageColumnFloat32(cols, lowCols)
+		if err != nil {
+			return nil, err
+		}
+		clos, err = candler.GetAverageColumnFloat32(cols, closeCols)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	/*
@@ -110,7 +129,11 @@ func (c *CandleCandler) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap,
 	var candle *candler.Candle
 	for i, t := range ts {
 		candle = c.GetCandle(t, candle)
-		candle.AddCandle(t, open[i], high[i], low[i], clos[i])
+		if decimal {
+			candle.AddDecimalCandle(t, openDec[i], decCols[0][i], decCols[1][i], decCols[2][i])
+		} else {
+			candle.AddCandle(t, open[i], high[i], low[i], clos[i])
+		}
 		/*
 			Iterate over the candle's named columns that need sums
 		*/
@@ -56,6 +56,12 @@ type Candler struct {
 	*/
 	SumNames, AvgNames []string // A cache of the column names that are summed and averaged in the candles
 	AccumSumNames      []string // Consolidated list of names either summed or averaged
+	/*
+	   When all the price inputs are DECIMAL64 columns of the same scale, the candles are
+	   made in decimal to keep the prices exact, and output as DECIMAL64 columns
+	*/
+	Decimal bool
+	Scale   int8
 }
 
 func (ca *Candler) GetRequiredArgs() []io.DataShape {
@@ -183,6 +189,11 @@ func (ca *Candler) Output() (*io.ColumnSeries, error) {
 		{Name: "Low", Type: io.FLOAT32},
 		{Name: "Close", Type: io.FLOAT32},
 	}
+	if ca.Decimal {
+		for i := 1; i < len(dataShapes); i++ {
+			dataShapes[i].Type, dataShapes[i].Scale = io.DECIMAL64, ca.Scale
+		}
+	}
 	for _, name := range ca.SumNames {
 		dataShapes = append(dataShapes,
 			io.DataShape{Name: name + "_SUM", Type: io.FLOAT64})
@@ -202,7 +213,7 @@ func (ca *Candler) Output() (*io.ColumnSeries, error) {
 	var dataBuf []byte
 	for _, tkey := range tsa {
 		cdl := ca.CMap[tkey]
-		dataBuf = append(dataBuf, cdl.SerializeToRowData(ca.SumNames, ca.AvgNames)...)
+		dataBuf = append(dataBuf, cdl.SerializeToRowData(ca.SumNames, ca.AvgNames, ca.Decimal)...)
 	}
 
 	rows := io.NewRows(dataShapes, dataBuf)
@@ -234,6 +245,16 @@ func (ca *Candler) GetCandle(t time.Time, cndl ...*Candle) *Candle {
 	return ca.CMap[candleTime]
 }
 
+// SetDecimal switches the candler to make the candles in decimal with the scale, or in float32.
+// It fails when the candles have already been made with the other type.
+func (ca *Candler) SetDecimal(decimal bool, scale int8) error {
+	if len(ca.CMap) != 0 && (ca.Decimal != decimal || ca.Scale != scale) {
+		return fmt.Errorf("price column types changed from the previous input")
+	}
+	ca.Decimal, ca.Scale = decimal, scale
+	return nil
+}
+
 /*
 *********************************** Candle *******************************************
 - Represents quantities within an interval of time
@@ -248,6 +269,11 @@ type EOHLCStruct struct {
 	Open, High, Low, Close float32
 }
 
+type DecimalEOHLCStruct struct {
+	Epoch                  int64
+	Open, High, Low, Close io.Decimal64
+}
+
 type Candle struct {
 	StartTime time.Time
 	Duration  *utils.CandleDuration
@@ -255,7 +281,8 @@ type Candle struct {
 		Every candle has OHLC
 	*/
 	EOHLC               EOHLCStruct
-	OpenTime, CloseTime time.Time // The time at which the Open and Close prices happened
+	DecimalEOHLC        DecimalEOHLCStruct // Used instead of EOHLC when the input prices are decimal
+	OpenTime, CloseTime time.Time          // The time at which the Open and Close prices happened
 	/*
 		Some candles optionally sum quantities like "Volume" from the
 		input columns
@@ -277,6 +304,8 @@ func NewCandle(startTime time.Time, cd *utils.CandleDuration, sumColumns, avgCol
 		CloseTime: time.Time{},
 		Duration:  cd,
 		EOHLC:     EOHLCStruct{ep, 0, 0, 0, 0},
+
+		DecimalEOHLC: DecimalEOHLCStruct{ep, 0, 0, 0, 0},
 	}
 	if len(sumColumns) != 0 || len(avgColumns) != 0 {
 		ca.SumMap = make(map[string]float64)
@@ -334,8 +363,44 @@ func (ca *Candle) AddCandle(ts time.Time, prices ...float32) bool {
 	return true
 }
 
-func (ca *Candle) SerializeToRowData(sumNames, avgNames []string) (rowBuf []byte) {
-	rowBuf, _ = io.Serialize([]byte{}, ca.EOHLC)
+// AddDecimalCandle is the decimal version of AddCandle.
+func (ca *Candle) AddDecimalCandle(ts time.Time, prices ...io.Decimal64) bool {
+	var open, high, low, clos io.Decimal64
+	if len(prices) == 1 {
+		open, high, low, clos = prices[0], prices[0], prices[0], prices[0]
+	} else {
+		open, high, low, clos = prices[0], prices[1], prices[2], prices[3]
+	}
+	if !ca.IsWithin(ts) {
+		return false
+	}
+	if ca.OpenTime.IsZero() {
+		ca.DecimalEOHLC.Open, ca.DecimalEOHLC.High, ca.DecimalEOHLC.Low, ca.DecimalEOHLC.Close = open, high, low, clos
+		ca.OpenTime, ca.CloseTime = ts, ts
+	}
+	if ts.Before(ca.OpenTime) {
+		ca.DecimalEOHLC.Open = open
+		ca.OpenTime = ts
+	}
+	if ts.After(ca.CloseTime) {
+		ca.DecimalEOHLC.Close = clos
+		ca.CloseTime = ts
+	}
+	if high > ca.DecimalEOHLC.High {
+		ca.DecimalEOHLC.High = high
+	}
+	if low < ca.DecimalEOHLC.Low {
+		ca.DecimalEOHLC.Low = low
+	}
+	return true
+}
+
+func (ca *Candle) SerializeToRowData(sumNames, avgNames []string, decimal bool) (rowBuf []byte) {
+	if decimal {
+		rowBuf, _ = io.Serialize([]byte{}, ca.DecimalEOHLC)
+	} else {
+		rowBuf, _ = io.Serialize([]byte{}, ca.EOHLC)
+	}
 	for _, name := range sumNames {
 		rowBuf, _ = io.Serialize(rowBuf, ca.SumMap[name])
 	}
@@ -352,6 +417,19 @@ type CandleMap map[time.Time]*Candle
 Utility Functions.
 */
 
+// GetDecimalColumn returns the input column as is when the column is a single DECIMAL64 column.
+// ok=false is returned otherwise, and the prices should be averaged in float32 by GetAverageColumnFloat32.
+func GetDecimalColumn(cols io.ColumnInterface, srcCols []io.DataShape) (col []io.Decimal64, scale int8, ok bool) {
+	if len(srcCols) != 1 {
+		return nil, 0, false
+	}
+	col, ok = cols.GetColumn(srcCols[0].Name).([]io.Decimal64)
+	if !ok {
+		return nil, 0, false
+	}
+	return col, uda.ColumnScale(cols, srcCols[0].Name), true
+}
+
 func GetAverageColumnFloat32(cols io.ColumnInterface, srcCols []io.DataShape) (avgCol []float32, err error) {
 	numberCols := len(srcCols)
 	if numberCols == 1 {
@@ -63,10 +63,21 @@ func (c *TickCandler) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, c
 		Get the input column for "Price"
 	*/
 	priceCols := argMap.GetMappedColumns(requiredColumns[0].Name)
-	price, err := candler.GetAverageColumnFloat32(cols, priceCols)
-	if err != nil {
+	/*
+		Decimal prices are used as is to keep them exact
+	*/
+	decPrice, scale, decimal := candler.GetDecimalColumn(cols, priceCols)
+	if err := c.SetDecimal(decimal, scale); err != nil {
 		return nil, err
 	}
+	var price []float32
+	if !decimal {
+		var err error
+		price, err = candler.GetAverageColumnFloat32(cols, priceCols)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	/*
 		Get the time column
@@ -92,7 +103,11 @@ func (c *TickCandler) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, c
 	var candle *candler.Candle
 	for i, t := range ts {
 		candle = c.GetCandle(t, candle)
-		candle.AddCandle(t, price[i])
+		if decimal {
+			candle.AddDecimalCandle(t, decPrice[i])
+		} else {
+			candle.AddCandle(t, price[i])
+		}
 		/*
 			Iterate over the candle's named columns that need sums
 		*/
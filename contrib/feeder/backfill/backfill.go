@@ -0,0 +1,55 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// FetchFunc gets the historical data of the symbols in the date range and writes it to marketstore.
+// Returning an error stops the backfill. Errors which should not stop the backfill
+// (e.g. a symbol that has not been listed yet) should be handled in the FetchFunc.
+type FetchFunc func(ctx context.Context, symbols []string, dateRange DateRange) error
+
+// Paginator splits a backfill into requests which fit the limits of a vendor API.
+type Paginator struct {
+	// MaxSymbolsPerReq is the maximum number of symbols in a request. 0 means no limit.
+	MaxSymbolsPerReq int
+	// MaxDaysPerReq is the maximum number of days in a request. 0 means no limit.
+	MaxDaysPerReq int
+	// Limiter limits the rate of the requests. nil means no limit.
+	Limiter *rate.Limiter
+}
+
+// NewLimiter returns the Limiter of a Paginator that allows requestsPerSecond requests per second,
+// or nil for no limit when requestsPerSecond is not positive.
+func NewLimiter(requestsPerSecond float64) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), 1)
+}
+
+// Run calls fetch for every page of symbols and dates between start and end.
+// It stops at the first error returned by fetch, or when ctx is cancelled.
+func (p *Paginator) Run(ctx context.Context, symbols []string, start, end time.Time, fetch FetchFunc) error {
+	for _, idx := range PageIndex(len(symbols), p.MaxSymbolsPerReq) {
+		for _, dateRange := range DatePageIndex(start, end, p.MaxDaysPerReq) {
+			if p.Limiter != nil {
+				if err := p.Limiter.Wait(ctx); err != nil {
+					return fmt.Errorf("wait for rate limiter: %w", err)
+				}
+			} else if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if err := fetch(ctx, symbols[idx.From:idx.To], dateRange); err != nil {
+				return fmt.Errorf("backfill symbols=%v, from=%v, to=%v: %w",
+					symbols[idx.From:idx.To], dateRange.From, dateRange.To, err)
+			}
+		}
+	}
+	return nil
+}
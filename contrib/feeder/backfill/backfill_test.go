@@ -0,0 +1,99 @@
+package backfill_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
+)
+
+type fetchCall struct {
+	symbols   []string
+	dateRange backfill.DateRange
+}
+
+func TestPaginator_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		paginator backfill.Paginator
+		symbols   []string
+		errSymbol string
+		wantCalls []fetchCall
+		wantErr   bool
+	}{
+		"ok/symbols and dates are paginated": {
+			paginator: backfill.Paginator{MaxSymbolsPerReq: 2, MaxDaysPerReq: 2},
+			symbols:   []string{"AAPL", "AMZN", "FB"},
+			wantCalls: []fetchCall{
+				{symbols: []string{"AAPL", "AMZN"}, dateRange: backfill.DateRange{From: date(1), To: date(3)}},
+				{symbols: []string{"AAPL", "AMZN"}, dateRange: backfill.DateRange{From: date(3), To: date(4)}},
+				{symbols: []string{"FB"}, dateRange: backfill.DateRange{From: date(1), To: date(3)}},
+				{symbols: []string{"FB"}, dateRange: backfill.DateRange{From: date(3), To: date(4)}},
+			},
+		},
+		"ok/rate limited": {
+			paginator: backfill.Paginator{
+				MaxSymbolsPerReq: 1,
+				Limiter:          rate.NewLimiter(rate.Every(time.Millisecond), 1),
+			},
+			symbols: []string{"AAPL", "AMZN"},
+			wantCalls: []fetchCall{
+				{symbols: []string{"AAPL"}, dateRange: backfill.DateRange{From: date(1), To: date(4)}},
+				{symbols: []string{"AMZN"}, dateRange: backfill.DateRange{From: date(1), To: date(4)}},
+			},
+		},
+		"ng/backfill stops at the first error": {
+			paginator: backfill.Paginator{MaxSymbolsPerReq: 1},
+			symbols:   []string{"AAPL", "ERROR", "FB"},
+			errSymbol: "ERROR",
+			wantCalls: []fetchCall{
+				{symbols: []string{"AAPL"}, dateRange: backfill.DateRange{From: date(1), To: date(4)}},
+				{symbols: []string{"ERROR"}, dateRange: backfill.DateRange{From: date(1), To: date(4)}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []fetchCall
+			err := tt.paginator.Run(context.Background(), tt.symbols, date(1), date(4),
+				func(_ context.Context, symbols []string, dr backfill.DateRange) error {
+					calls = append(calls, fetchCall{symbols: symbols, dateRange: dr})
+					if symbols[0] == tt.errSymbol {
+						return errors.New("error")
+					}
+					return nil
+				},
+			)
+
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestPaginator_Run_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := backfill.Paginator{MaxSymbolsPerReq: 1}
+	err := p.Run(ctx, []string{"AAPL"}, date(1), date(4),
+		func(_ context.Context, _ []string, _ backfill.DateRange) error {
+			t.Fatal("fetch should not be called after ctx is cancelled")
+			return nil
+		},
+	)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,48 @@
+package backfill
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/planner"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// LastWrittenTime returns the time of the last record in the bucket,
+// so that a backfill can resume from there.
+// A zero time is returned when the bucket doesn't exist or has no record.
+func LastWrittenTime(catalogDir *catalog.Directory, tbk *io.TimeBucketKey) (time.Time, error) {
+	if _, err := catalogDir.GetLatestTimeBucketInfoFromKey(tbk); err != nil {
+		// the bucket has not been created yet
+		return time.Time{}, nil
+	}
+
+	query := planner.NewQuery(catalogDir)
+	query.AddTargetKey(tbk)
+	query.SetRange(time.Unix(0, 0), time.Unix(math.MaxInt64, 0))
+	query.SetRowLimit(io.LAST, 1)
+	parsed, err := query.Parse()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse query for %s: %w", tbk, err)
+	}
+	reader, err := executor.NewReader(parsed)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("create query reader for %s: %w", tbk, err)
+	}
+	csm, err := reader.Read()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("read query for %s: %w", tbk, err)
+	}
+	cs := csm[*tbk]
+	if cs == nil || cs.Len() == 0 {
+		return time.Time{}, nil
+	}
+	ts, err := cs.GetTime()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get time from query for %s: %w", tbk, err)
+	}
+	return ts[0], nil
+}
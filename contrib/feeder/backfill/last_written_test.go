@@ -0,0 +1,42 @@
+package backfill_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func TestLastWrittenTime(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+
+	tbk := io.NewTimeBucketKey("AAPL/1D/OHLCV")
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{date(1).Unix(), date(2).Unix()})
+	cs.AddColumn("Close", []float32{1, 2})
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	require.NoError(t, c.GetWriter().WriteCSM(csm, false))
+
+	// --- when ---
+	got, err := backfill.LastWrittenTime(c.GetCatalogDir(), tbk)
+
+	// --- then ---
+	require.NoError(t, err)
+	require.True(t, date(2).Equal(got), got)
+
+	// a zero time is returned for a bucket which doesn't exist
+	got, err = backfill.LastWrittenTime(c.GetCatalogDir(), io.NewTimeBucketKey("AMZN/1D/OHLCV"))
+	require.NoError(t, err)
+	require.Equal(t, time.Time{}, got)
+}
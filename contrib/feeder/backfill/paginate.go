@@ -0,0 +1,61 @@
+package backfill
+
+import "time"
+
+// Index is a range of slice indices [From, To).
+type Index struct {
+	From, To int
+}
+
+// PageIndex splits [0, length) into pages of pageSize.
+// When pageSize is not positive, the whole range is returned as a single page.
+// e.g. length = 5, pageSize = 2
+// -> [{From:0, To:2}, {From:2, To:4}, {From:4, To:5}].
+func PageIndex(length, pageSize int) []Index {
+	if pageSize <= 0 {
+		pageSize = length
+	}
+
+	var ret []Index
+	for i := 0; i < length; i += pageSize {
+		idx := Index{From: i, To: i + pageSize}
+		if length < idx.To {
+			idx.To = length
+		}
+		ret = append(ret, idx)
+	}
+	return ret
+}
+
+// DateRange is a range of dates [From, To).
+type DateRange struct {
+	From, To time.Time
+}
+
+// DatePageIndex returns paginated date ranges.
+// DatePageIndex assumes that start and end have only year, month, and day information
+// like time.Date(yyyy, mm, dd, 0,0,0,0, time.UTC).
+// When pageDays is not positive, [start, end) is returned as a single page.
+// e.g. start = 2021-12-01, end = 2021-12-06, pageDays = 2
+// -> [{From:2021-12-01, To:2021-12-03}, {From:2021-12-03, To:2021-12-05}, {From:2021-12-05, To:2021-12-06}].
+func DatePageIndex(start, end time.Time, pageDays int) []DateRange {
+	if pageDays <= 0 {
+		return []DateRange{{From: start, To: end}}
+	}
+
+	var ret []DateRange
+	i := start
+	for {
+		pageEnd := i.AddDate(0, 0, pageDays)
+		if pageEnd.After(end) {
+			pageEnd = end
+		}
+		ret = append(ret, DateRange{From: i, To: pageEnd})
+
+		i = i.AddDate(0, 0, pageDays)
+		if !i.Before(end) {
+			break
+		}
+	}
+	return ret
+}
@@ -0,0 +1,109 @@
+package backfill_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
+)
+
+func date(day int) time.Time {
+	const (
+		year  = 2021
+		month = 12
+	)
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDatePageIndex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		pageDays int
+		want     []backfill.DateRange
+	}{
+		{
+			name:     "ok/5 days paginated by pageSize=2",
+			start:    date(1),
+			end:      date(5),
+			pageDays: 2,
+			want: []backfill.DateRange{
+				{From: date(1), To: date(3)},
+				{From: date(3), To: date(5)},
+			},
+		},
+		{
+			name:     "ok/3 days paginated by pageSize=5",
+			start:    date(1),
+			end:      date(3),
+			pageDays: 5,
+			want: []backfill.DateRange{
+				{From: date(1), To: date(3)},
+			},
+		},
+		{
+			name:     "ok/3 days paginated by pageSize=1",
+			start:    date(1),
+			end:      date(3),
+			pageDays: 1,
+			want: []backfill.DateRange{
+				{From: date(1), To: date(2)},
+				{From: date(2), To: date(3)},
+			},
+		},
+		{
+			name:     "ok/no pagination when pageSize=0",
+			start:    date(1),
+			end:      date(6),
+			pageDays: 0,
+			want: []backfill.DateRange{
+				{From: date(1), To: date(6)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, backfill.DatePageIndex(tt.start, tt.end, tt.pageDays))
+		})
+	}
+}
+
+func TestPageIndex(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		length, pageSize int
+		want             []backfill.Index
+	}{
+		"ok/5 items paginated by pageSize=2": {
+			length: 5, pageSize: 2,
+			want: []backfill.Index{{From: 0, To: 2}, {From: 2, To: 4}, {From: 4, To: 5}},
+		},
+		"ok/no pagination when pageSize=0": {
+			length: 5, pageSize: 0,
+			want: []backfill.Index{{From: 0, To: 5}},
+		},
+		"ok/no page for empty items": {
+			length: 0, pageSize: 2,
+			want: nil,
+		},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, backfill.PageIndex(tt.length, tt.pageSize))
+		})
+	}
+}
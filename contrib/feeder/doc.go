@@ -0,0 +1,12 @@
+// Package feeder is a set of building blocks shared by the data feeder plugins
+// which periodically get market data from a vendor API and write it to marketstore.
+//
+//   - markettime: checks if the market is open, with an optional off-hours schedule
+//   - timer: runs a job every day at a specified time
+//   - symbols: keeps the list of target symbols up to date
+//   - backfill: paginates historical data requests with a rate limit, and finds the last written record
+//   - writer: writes column series and bar/quote/trade models to marketstore
+//
+// A vendor adapter only has to implement the API client, the symbol lister and
+// the conversion from the API responses to column series.
+package feeder
@@ -1,15 +1,12 @@
-package feed
+package markettime
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
-
-	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
-var jst = time.FixedZone("Asia/Tokyo", 9*60*60)
+const hoursInDay = 24
 
 // MarketTimeChecker is an interface to check if the market is open at the specified time or not.
 type MarketTimeChecker interface {
@@ -25,21 +22,27 @@ type MarketTimeChecker interface {
 // - the market is open today (= check if today is a holiday or not)
 // all those settings should be defined in this object.
 type DefaultMarketTimeChecker struct {
+	// Location is the timezone of the market.
+	// The days of the week, the closed days and the open/close times are evaluated in this timezone.
+	Location *time.Location
 	// i.e. []string{"Saturday", "Sunday"}
 	ClosedDaysOfTheWeek []time.Weekday
 	ClosedDays          []time.Time
-	OpenTime            time.Time
-	CloseTime           time.Time
+	// OpenTime and CloseTime are the durations from 00:00 in the Location.
+	// The market is considered open from OpenTime to CloseTime, both inclusive.
+	// When CloseTime is earlier than OpenTime (i.e. open=23h, close=6h), the market closes on the next day.
+	OpenTime, CloseTime time.Duration
 }
 
-// NewDefaultMarketTimeChecker initializes the DefaultMarketTimeChecker object with the specifier parameters.s.
+// NewDefaultMarketTimeChecker initializes the DefaultMarketTimeChecker object with the specified parameters.
 func NewDefaultMarketTimeChecker(
+	loc *time.Location,
 	closedDaysOfTheWeek []time.Weekday,
 	closedDays []time.Time,
-	openTime time.Time,
-	closeTime time.Time,
+	openTime, closeTime time.Duration,
 ) *DefaultMarketTimeChecker {
 	return &DefaultMarketTimeChecker{
+		Location:            loc,
 		ClosedDaysOfTheWeek: closedDaysOfTheWeek,
 		ClosedDays:          closedDays,
 		OpenTime:            openTime,
@@ -47,42 +50,37 @@ func NewDefaultMarketTimeChecker(
 	}
 }
 
-// IsOpen returns true on weekdays from 08:55 to 15:10.
-// if closedDates are defined, return false on those days.
+// Clock returns the duration from 00:00 to {hour}:{minute}, which can be used for OpenTime and CloseTime.
+func Clock(hour, minute int) time.Duration {
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+}
+
+// IsOpen returns true when the specified time is between the OpenTime and the CloseTime
+// on the days the market is open.
 func (m *DefaultMarketTimeChecker) IsOpen(t time.Time) bool {
-	timeInJst := t.In(jst)
-	return m.isOpenDate(timeInJst) && m.isOpenWeekDay(timeInJst) && m.isOpenTime(t)
+	tl := t.In(m.Location)
+	return m.isOpenTime(tl) && m.isOpenWeekDay(tl) && m.isOpenDate(tl)
 }
 
 // isOpenTime returns true if the specified time is between the OpenTime and the CloseTime.
 func (m *DefaultMarketTimeChecker) isOpenTime(t time.Time) bool {
-	const (
-		minutesInHour = 60
-		hoursInDay    = 24
-	)
-	minFrom12am := t.Hour()*minutesInHour + t.Minute()
-
-	openMinFrom12am := m.OpenTime.Hour()*minutesInHour + m.OpenTime.Minute()
-	closeMinFrom12am := m.CloseTime.Hour()*minutesInHour + m.CloseTime.Minute()
-
-	// if the open hour is later than the close hour (i.e. open=23h, close=6h), +1day
-	if closeMinFrom12am < openMinFrom12am {
-		closeMinFrom12am += hoursInDay * minutesInHour
-	}
-	if minFrom12am < openMinFrom12am {
-		minFrom12am += hoursInDay * minutesInHour
+	// wall clock time is used so that the open/close times don't shift on the days of DST transitions
+	hour, minute, sec := t.Clock()
+	sinceMidnight := Clock(hour, minute) + time.Duration(sec)*time.Second + time.Duration(t.Nanosecond())
+
+	closeTime := m.CloseTime
+	// if the open time is later than the close time (i.e. open=23h, close=6h), +1day
+	if closeTime < m.OpenTime {
+		closeTime += hoursInDay * time.Hour
+		if sinceMidnight < m.OpenTime {
+			sinceMidnight += hoursInDay * time.Hour
+		}
 	}
 
-	if minFrom12am < openMinFrom12am || minFrom12am >= closeMinFrom12am {
-		log.Debug(fmt.Sprintf("[Xignite Feeder] market is not open. "+
-			"openTime=%02d:%02d, closeTime=%02d:%02d, now=%v",
-			m.OpenTime.Hour(), m.OpenTime.Minute(), m.CloseTime.Hour(), m.CloseTime.Minute(), t))
-		return false
-	}
-	return true
+	return m.OpenTime <= sinceMidnight && sinceMidnight <= closeTime
 }
 
-// isOpenWeekDay returns true when the specified time is in the closedDaysOfTheWeek.
+// isOpenWeekDay returns true when the specified time is not in the closedDaysOfTheWeek.
 func (m *DefaultMarketTimeChecker) isOpenWeekDay(t time.Time) bool {
 	w := t.Weekday()
 	for _, closedDay := range m.ClosedDaysOfTheWeek {
@@ -93,7 +91,7 @@ func (m *DefaultMarketTimeChecker) isOpenWeekDay(t time.Time) bool {
 	return true
 }
 
-// isOpenDate returns true if the specified time is on closedDates.
+// isOpenDate returns true if the specified time is not on closedDates.
 func (m *DefaultMarketTimeChecker) isOpenDate(t time.Time) bool {
 	for _, c := range m.ClosedDays {
 		if c.Year() == t.Year() && c.Month() == t.Month() && c.Day() == t.Day() {
@@ -104,19 +102,19 @@ func (m *DefaultMarketTimeChecker) isOpenDate(t time.Time) bool {
 }
 
 // Sub returns a date before X business days (= days which market is open). businessDay should be a positive value.
-func (m *DefaultMarketTimeChecker) Sub(dateInJST time.Time, businessDay int) (time.Time, error) {
+func (m *DefaultMarketTimeChecker) Sub(date time.Time, businessDay int) (time.Time, error) {
 	if businessDay < 0 {
 		return time.Time{}, errors.New("businessDay argument should be a positive integer")
 	}
 
 	if businessDay == 0 {
-		return dateInJST, nil
+		return date, nil
 	}
 
 	count := businessDay
-	d := dateInJST
+	d := date
 	for count > 0 {
-		d = d.Add(-24 * time.Hour)
+		d = d.Add(-hoursInDay * time.Hour)
 		if m.isOpenDate(d) && m.isOpenWeekDay(d) {
 			count--
 		}
@@ -1,43 +1,42 @@
-package feed
+package markettime_test
 
 import (
 	"testing"
 	"time"
-)
 
-var (
-	ClosedDaysOfTheWeek = []time.Weekday{time.Saturday, time.Sunday}
-	ClosedDays          = []time.Time{
-		// Marine day in Japan
-		time.Date(2019, 7, 15, 0, 0, 0, 0, time.UTC),
-		// Health and Sports day in Japan
-		time.Date(2019, 10, 14, 0, 0, 0, 0, time.UTC),
-	}
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
 )
 
-// 5 minutes before the market opens in Japan (UTC).
-var OpenTime = time.Date(0, 0, 0, 23, 55, 0, 0, time.UTC)
+var jpClosedDays = []time.Time{
+	// Marine day in Japan
+	time.Date(2019, 7, 15, 0, 0, 0, 0, time.UTC),
+	// Health and Sports day in Japan
+	time.Date(2019, 10, 14, 0, 0, 0, 0, time.UTC),
+}
 
-// 10 minutes after the market closes in Japan (UTC).
-var CloseTime = time.Date(0, 0, 0, 6, 10, 0, 0, time.UTC)
+// from 5 minutes before the market opens in Japan (08:55JST=23:55UTC)
+// to 10 minutes after the market closes in Japan (15:10JST=06:10UTC).
+func newJPMarketTimeChecker() *markettime.DefaultMarketTimeChecker {
+	return markettime.NewDefaultMarketTimeChecker(jst,
+		ClosedDaysOfTheWeek,
+		jpClosedDays,
+		markettime.Clock(8, 55),
+		markettime.Clock(15, 10),
+	)
+}
 
-type testCase struct {
+type jstTestCase struct {
 	name   string
 	arg    time.Time
 	isOpen bool
 }
 
-func TestDefaultMarketTimeChecker_isOpen(t *testing.T) {
+func TestDefaultMarketTimeChecker_isOpenJST(t *testing.T) {
 	t.Parallel()
 	// --- given ---
-	SUT := &DefaultMarketTimeChecker{
-		ClosedDaysOfTheWeek,
-		ClosedDays,
-		OpenTime,
-		CloseTime,
-	}
+	SUT := newJPMarketTimeChecker()
 	// test cases
-	tests := []testCase{
+	tests := []jstTestCase{
 		{
 			"open", // 09:00, Tuesday in JST
 			time.Date(2019, 7, 16, 23, 55, 0, 0, time.UTC), true,
@@ -47,8 +46,12 @@ func TestDefaultMarketTimeChecker_isOpen(t *testing.T) {
 			time.Date(2019, 7, 16, 3, 0, 0, 0, time.UTC), true,
 		},
 		{
-			"close", // 19:00 in JST
-			time.Date(2019, 7, 16, 6, 10, 0, 0, time.UTC), false,
+			"open", // 15:10 in JST. the close time is inclusive
+			time.Date(2019, 7, 16, 6, 10, 0, 0, time.UTC), true,
+		},
+		{
+			"close", // 15:11 in JST
+			time.Date(2019, 7, 16, 6, 11, 0, 0, time.UTC), false,
 		},
 
 		{
@@ -96,12 +99,7 @@ type subTestCase struct {
 func TestDefaultMarketTimeChecker_Sub(t *testing.T) {
 	t.Parallel()
 	// --- given ---
-	SUT := &DefaultMarketTimeChecker{
-		ClosedDaysOfTheWeek,
-		ClosedDays,
-		OpenTime,
-		CloseTime,
-	}
+	SUT := newJPMarketTimeChecker()
 	// test cases
 	tests := []subTestCase{
 		{
@@ -154,3 +152,35 @@ func TestDefaultMarketTimeChecker_Sub(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultMarketTimeChecker_isOpenOvernight(t *testing.T) {
+	t.Parallel()
+	// --- given ---
+	SUT := markettime.NewDefaultMarketTimeChecker(time.UTC, nil, nil,
+		markettime.Clock(23, 0),
+		markettime.Clock(6, 0),
+	)
+	tests := map[string]struct {
+		arg    time.Time
+		isOpen bool
+	}{
+		"open/before midnight":  {arg: time.Date(2019, 7, 16, 23, 30, 0, 0, time.UTC), isOpen: true},
+		"open/after midnight":   {arg: time.Date(2019, 7, 17, 5, 59, 0, 0, time.UTC), isOpen: true},
+		"close/after the close": {arg: time.Date(2019, 7, 17, 6, 1, 0, 0, time.UTC), isOpen: false},
+		"close/before the open": {arg: time.Date(2019, 7, 17, 22, 59, 0, 0, time.UTC), isOpen: false},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// --- when ---
+			got := SUT.IsOpen(tt.arg)
+
+			// --- then ---
+			if got != tt.isOpen {
+				t.Errorf("DefaultMarketTimeChecker.IsOpen() = %v, want %v", got, tt.isOpen)
+			}
+		})
+	}
+}
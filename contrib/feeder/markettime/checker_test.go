@@ -1,10 +1,10 @@
-package feed_test
+package markettime_test
 
 import (
 	"testing"
 	"time"
 
-	"github.com/alpacahq/marketstore/v4/contrib/alpacabkfeeder/feed"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
 )
 
 var (
@@ -79,6 +79,20 @@ func TestDefaultMarketTimeChecker_isOpen(t *testing.T) {
 			closeHour: 16, closeMinute: 0,
 			wantIsOpen: true,
 		},
+		{
+			name:     "open(2022-03-14(Monday) 13:30UTC = 2022-03-14 9:30EDT, the day after DST started)",
+			arg:      time.Date(2022, 3, 14, 13, 30, 0, 0, time.UTC),
+			openHour: 9, openMinute: 30,
+			closeHour: 16, closeMinute: 0,
+			wantIsOpen: true,
+		},
+		{
+			name:     "close(2022-03-14(Monday) 13:29UTC = 2022-03-14 9:29EDT, the day after DST started)",
+			arg:      time.Date(2022, 3, 14, 13, 29, 0, 0, time.UTC),
+			openHour: 9, openMinute: 30,
+			closeHour: 16, closeMinute: 0,
+			wantIsOpen: false,
+		},
 		{
 			name:     "close(weekend. 2019-07-07 is Sunday)",
 			arg:      time.Date(2019, 7, 7, 0, 0, 0, 0, time.UTC),
@@ -107,12 +121,12 @@ func TestDefaultMarketTimeChecker_isOpen(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			// --- given ---
-			SUT := &feed.DefaultMarketTimeChecker{
-				ClosedDaysOfTheWeek: ClosedDaysOfTheWeek,
-				ClosedDays:          ClosedDays,
-				OpenHourNY:          tt.openHour, OpenMinuteNY: tt.openMinute,
-				CloseHourNY: tt.closeHour, CloseMinuteNY: tt.closeMinute,
-			}
+			SUT := markettime.NewDefaultMarketTimeChecker(ny,
+				ClosedDaysOfTheWeek,
+				ClosedDays,
+				markettime.Clock(tt.openHour, tt.openMinute),
+				markettime.Clock(tt.closeHour, tt.closeMinute),
+			)
 
 			// --- when ---
 			got := SUT.IsOpen(tt.arg)
@@ -1,10 +1,11 @@
-package feed
+package markettime
 
 import (
 	"fmt"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/alpacahq/marketstore/v4/utils/log"
@@ -22,7 +23,7 @@ import (
 // "0-10" -> error (range is not supported).
 func ParseSchedule(s string) ([]int, error) {
 	if s == "" {
-		log.Debug("[xignite] no schedule is set for off_hours")
+		log.Debug("no schedule is set for off_hours")
 		return []int{}, nil
 	}
 	s = strings.ReplaceAll(s, " ", "")
@@ -33,7 +34,7 @@ func ParseSchedule(s string) ([]int, error) {
 	for i, m := range strs {
 		ret[i], err = strconv.Atoi(m)
 		if err != nil {
-			return nil, fmt.Errorf("parse %s for scheduling of xignite feeder: %w", m, err)
+			return nil, fmt.Errorf("parse %s for off_hours_schedule: %w", m, err)
 		}
 
 		if ret[i] < 0 || ret[i] >= 60 {
@@ -48,11 +49,15 @@ func ParseSchedule(s string) ([]int, error) {
 // ScheduledMarketTimeChecker is used where periodic processing is needed to run even when the market is closed.
 type ScheduledMarketTimeChecker struct {
 	MarketTimeChecker
-	// LastTime holds the last time that IntervalTimeChceker.IsOpen returned true.
+	// LastTime holds the last time that IsOpen returned true.
 	LastTime    time.Time
 	ScheduleMin []int
+	// mu guards LastTime because the feeder workers can check the time concurrently
+	mu sync.Mutex
 }
 
+// NewScheduledMarketTimeChecker wraps the MarketTimeChecker so that IsOpen also returns true
+// at the minutes in scheduleMin.
 func NewScheduledMarketTimeChecker(
 	mtc MarketTimeChecker,
 	scheduleMin []int,
@@ -70,6 +75,9 @@ func (c *ScheduledMarketTimeChecker) IsOpen(t time.Time) bool {
 }
 
 func (c *ScheduledMarketTimeChecker) tick(t time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	m := t.Minute()
 	for _, sche := range c.ScheduleMin {
 		if m != sche {
@@ -81,7 +89,7 @@ func (c *ScheduledMarketTimeChecker) tick(t time.Time) bool {
 			continue
 		}
 
-		log.Debug(fmt.Sprintf("[Xignite Feeder] run data feed based on the schedule: %v(min)", c.ScheduleMin))
+		log.Debug("run data feed based on the schedule: %v(min)", c.ScheduleMin)
 		c.LastTime = t
 		return true
 	}
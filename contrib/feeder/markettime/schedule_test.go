@@ -1,11 +1,11 @@
-package feed_test
+package markettime_test
 
 import (
 	"reflect"
 	"testing"
 	"time"
 
-	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/feed"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
 )
 
 type mockMarketTimeChecker struct {
@@ -70,7 +70,7 @@ func TestParseSchedule(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := feed.ParseSchedule(tt.s)
+			got, err := markettime.ParseSchedule(tt.s)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ParseSchedule() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -85,7 +85,7 @@ func TestParseSchedule(t *testing.T) {
 func TestScheduledMarketTimeChecker_IsOpen(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
-		MarketTimeChecker feed.MarketTimeChecker
+		MarketTimeChecker markettime.MarketTimeChecker
 		ScheduleMin       []int
 		CurrentTime       time.Time
 		LastTime          time.Time
@@ -125,7 +125,7 @@ func TestScheduledMarketTimeChecker_IsOpen(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			// --- given ---
-			c := feed.NewScheduledMarketTimeChecker(
+			c := markettime.NewScheduledMarketTimeChecker(
 				tt.MarketTimeChecker,
 				tt.ScheduleMin,
 			)
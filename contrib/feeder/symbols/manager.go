@@ -0,0 +1,77 @@
+package symbols
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// Manager manages symbols in the target stock exchanges.
+// symbol(s) can be newly registered / removed from the exchange,
+// so target symbols should be updated periodically.
+type Manager interface {
+	GetAllSymbols() []string
+	UpdateSymbols(ctx context.Context)
+}
+
+// Lister returns the latest list of the target symbols, typically by calling a vendor API.
+type Lister interface {
+	ListSymbols(ctx context.Context) ([]string, error)
+}
+
+// ListerFunc is an adapter to use an ordinary function as a Lister.
+type ListerFunc func(ctx context.Context) ([]string, error)
+
+// ListSymbols calls f(ctx).
+func (f ListerFunc) ListSymbols(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// ManagerImpl is an implementation of the Manager that caches the symbols returned by the Lister.
+type ManagerImpl struct {
+	lister Lister
+
+	mu      sync.RWMutex
+	symbols []string
+}
+
+// NewManager initializes the Manager object with the specified Lister.
+// The symbols are empty until UpdateSymbols is called.
+func NewManager(lister Lister) *ManagerImpl {
+	return &ManagerImpl{lister: lister, symbols: []string{}}
+}
+
+// GetAllSymbols returns the symbols listed by the last successful UpdateSymbols.
+func (m *ManagerImpl) GetAllSymbols() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.symbols
+}
+
+// UpdateSymbols replaces the symbols with the ones returned by the Lister.
+// If the Lister returns an error, the symbols are not updated.
+func (m *ManagerImpl) UpdateSymbols(ctx context.Context) {
+	symbols, err := m.lister.ListSymbols(ctx)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to list symbols. The target symbols are not updated: %v", err))
+		return
+	}
+
+	m.mu.Lock()
+	m.symbols = symbols
+	m.mu.Unlock()
+	log.Debug(fmt.Sprintf("Updated symbols. The number of symbols is %d", len(symbols)))
+}
+
+// StaticManager is a Manager with a fixed list of symbols.
+type StaticManager []string
+
+// GetAllSymbols returns the static symbols.
+func (s StaticManager) GetAllSymbols() []string {
+	return s
+}
+
+// UpdateSymbols does nothing.
+func (s StaticManager) UpdateSymbols(_ context.Context) {}
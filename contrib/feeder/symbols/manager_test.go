@@ -0,0 +1,61 @@
+package symbols_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
+)
+
+func TestManagerImpl_UpdateSymbols(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		listed  [][]string
+		errs    []error
+		want    []string
+		updates int
+	}{
+		"ok/ symbols are replaced by the listed ones": {
+			listed:  [][]string{{"AAPL", "AMZN"}, {"FB"}},
+			errs:    []error{nil, nil},
+			want:    []string{"FB"},
+			updates: 2,
+		},
+		"ok/ symbols are not updated on error": {
+			listed:  [][]string{{"AAPL", "AMZN"}, nil},
+			errs:    []error{nil, errors.New("error")},
+			want:    []string{"AAPL", "AMZN"},
+			updates: 2,
+		},
+		"ok/ empty before the first update": {
+			want:    []string{},
+			updates: 0,
+		},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			i := 0
+			m := symbols.NewManager(symbols.ListerFunc(func(_ context.Context) ([]string, error) {
+				defer func() { i++ }()
+				return tt.listed[i], tt.errs[i]
+			}))
+
+			// --- when ---
+			for j := 0; j < tt.updates; j++ {
+				m.UpdateSymbols(context.Background())
+			}
+
+			// --- then ---
+			require.Equal(t, tt.want, m.GetAllSymbols())
+		})
+	}
+}
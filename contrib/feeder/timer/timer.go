@@ -7,28 +7,33 @@ import (
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
-// RunEveryDayAt runs a specified function every day at a specified hour.
-func RunEveryDayAt(ctx context.Context, t time.Time, f func(context.Context)) {
-	timeToNextRun := timeToNext(time.Now(), t)
-
-	// run at a specified time on the next day
-	time.AfterFunc(timeToNextRun, func() { f(ctx) })
-
-	// at the same time, run  every 24 hour
-	time.AfterFunc(timeToNextRun, func() {
-		ticker := time.NewTicker(24 * time.Hour)
+// Job is a function to run every day at a time of day.
+type Job struct {
+	At  time.Time
+	Run func(ctx context.Context)
+}
 
+// RunEveryDayAt runs a specified function every day at a specified hour until ctx is canceled.
+// The returned channel is closed once ctx is canceled and the running call of the function returns.
+func RunEveryDayAt(ctx context.Context, t time.Time, f func(context.Context)) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// run at a specified time on the next day, and then every 24 hour
+		next := time.NewTimer(timeToNext(time.Now(), t))
+		defer next.Stop()
 		for {
 			select {
-			case <-ticker.C:
+			case <-next.C:
 				f(ctx)
+				next.Reset(24 * time.Hour)
 			case <-ctx.Done():
 				log.Debug("job stopped due to ctx.Done()")
-				ticker.Stop()
 				return
 			}
 		}
-	})
+	}()
+	return done
 }
 
 // timeToNext returns the time duration from now to next {hour}:{minute}:{second}
@@ -1,6 +1,7 @@
 package timer
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -47,3 +48,22 @@ func Test_timeToNext(t *testing.T) {
 		})
 	}
 }
+
+func TestRunEveryDayAt_Cancel(t *testing.T) {
+	t.Parallel()
+	// --- given ---
+	ctx, cancel := context.WithCancel(context.Background())
+	done := RunEveryDayAt(ctx, time.Now().Add(time.Hour), func(context.Context) {
+		t.Error("the job should not run before the time")
+	})
+
+	// --- when ---
+	cancel()
+
+	// --- then ---
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the job should stop when ctx is canceled")
+	}
+}
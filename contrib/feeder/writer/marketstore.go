@@ -0,0 +1,39 @@
+package writer
+
+import (
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// MarketStoreWriter is an interface to write data to marketstore.
+// this interface is necessary for writing unit tests of the feeders without actually saving data to the marketstore.
+type MarketStoreWriter interface {
+	Write(csm io.ColumnSeriesMap) error
+}
+
+// MarketStoreWriterImpl writes the column series map data to the local marketstore data.
+type MarketStoreWriterImpl struct{}
+
+func (m *MarketStoreWriterImpl) Write(csm io.ColumnSeriesMap) error {
+	// no new data to write
+	if len(csm) == 0 {
+		return nil
+	}
+	return executor.WriteCSM(csm, false)
+}
+
+// CSMWriter is an interface to write both fixed-length and variable-length records to marketstore.
+type CSMWriter interface {
+	WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error
+}
+
+// CSMWriterImpl writes the column series map data to the local marketstore data.
+type CSMWriterImpl struct{}
+
+func (m *CSMWriterImpl) WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error {
+	// no new data to write
+	if len(csm) == 0 {
+		return nil
+	}
+	return executor.WriteCSM(csm, isVariableLength)
+}
@@ -0,0 +1,52 @@
+package writer
+
+import (
+	"fmt"
+
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// ModelWriter writes bars, quotes and trades in the schemas defined in the models package,
+// so that the data from different vendors can be queried in the same way.
+type ModelWriter struct {
+	CSMWriter CSMWriter
+}
+
+// NewModelWriter returns a ModelWriter which writes to the local marketstore.
+func NewModelWriter() *ModelWriter {
+	return &ModelWriter{CSMWriter: &CSMWriterImpl{}}
+}
+
+// WriteBar writes the bars to the "{symbol}/{timeframe}/OHLCV" bucket.
+func (w *ModelWriter) WriteBar(bar *models.Bar) error {
+	if bar.Len() == 0 {
+		return nil
+	}
+	return w.write(*bar.BuildCsm(), false, bar.Key())
+}
+
+// WriteQuote writes the quotes to the "{symbol}/1Sec/QUOTE" bucket.
+func (w *ModelWriter) WriteQuote(quote *models.Quote) error {
+	if quote.Len() == 0 {
+		return nil
+	}
+	return w.write(*quote.BuildCsm(), true, quote.Key())
+}
+
+// WriteTrade writes the trades to the "{symbol}/1Sec/TRADE" bucket.
+func (w *ModelWriter) WriteTrade(trade *models.Trade) error {
+	if trade.Len() == 0 {
+		return nil
+	}
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*trade.Tbk, trade.GetCs())
+	return w.write(csm, true, trade.Key())
+}
+
+func (w *ModelWriter) write(csm io.ColumnSeriesMap, isVariableLength bool, key string) error {
+	if err := w.CSMWriter.WriteCSM(csm, isVariableLength); err != nil {
+		return fmt.Errorf("write %s to marketstore: %w", key, err)
+	}
+	return nil
+}
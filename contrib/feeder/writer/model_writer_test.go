@@ -0,0 +1,105 @@
+package writer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/writer"
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+type mockCSMWriter struct {
+	written          io.ColumnSeriesMap
+	isVariableLength bool
+	err              error
+}
+
+func (m *mockCSMWriter) WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.written = csm
+	m.isVariableLength = isVariableLength
+	return nil
+}
+
+func TestModelWriter(t *testing.T) {
+	t.Parallel()
+
+	bar := models.NewBar("AAPL", "1Min", 1)
+	bar.Add(60, 1, 2, 0.5, 1.5, 100)
+	quote := models.NewQuote("AAPL", 1)
+	quote.Add(1, 2, 100.1, 100.2, 3, 4, enum.NYSE, enum.Nasdaq, 0)
+	trade := models.NewTrade("AAPL", 1)
+	trade.Add(1, 2, 100.1, 5, enum.NYSE, enum.TapeA)
+
+	tests := map[string]struct {
+		write                func(w *writer.ModelWriter) error
+		err                  error
+		wantKey              string
+		wantColumns          []string
+		wantIsVariableLength bool
+		wantErr              bool
+	}{
+		"ok/ bar": {
+			write:       func(w *writer.ModelWriter) error { return w.WriteBar(bar) },
+			wantKey:     "AAPL/1Min/OHLCV",
+			wantColumns: []string{"Epoch", "Open", "High", "Low", "Close", "Volume"},
+		},
+		"ok/ quote": {
+			write:   func(w *writer.ModelWriter) error { return w.WriteQuote(quote) },
+			wantKey: "AAPL/1Sec/QUOTE",
+			wantColumns: []string{
+				"Epoch", "Nanoseconds", "AskPrice", "BidPrice", "AskSize", "BidSize",
+				"BidExchange", "AskExchange", "Cond",
+			},
+			wantIsVariableLength: true,
+		},
+		"ok/ trade": {
+			write:   func(w *writer.ModelWriter) error { return w.WriteTrade(trade) },
+			wantKey: "AAPL/1Sec/TRADE",
+			wantColumns: []string{
+				"Epoch", "Nanoseconds", "Price", "Size", "Exchange", "TapeID",
+				"Cond1", "Cond2", "Cond3", "Cond4",
+			},
+			wantIsVariableLength: true,
+		},
+		"ok/ nothing is written for an empty model": {
+			write: func(w *writer.ModelWriter) error { return w.WriteBar(models.NewBar("AAPL", "1Min", 0)) },
+		},
+		"ng/ write error": {
+			write:   func(w *writer.ModelWriter) error { return w.WriteBar(bar) },
+			err:     errors.New("error"),
+			wantErr: true,
+		},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &mockCSMWriter{err: tt.err}
+			err := tt.write(&writer.ModelWriter{CSMWriter: m})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.wantKey == "" {
+				require.Nil(t, m.written)
+				return
+			}
+			cs := m.written[*io.NewTimeBucketKey(tt.wantKey)]
+			require.NotNil(t, cs)
+			require.Equal(t, tt.wantColumns, cs.GetColumnNames())
+			require.Equal(t, 1, cs.Len())
+			require.Equal(t, tt.wantIsVariableLength, m.isVariableLength)
+		})
+	}
+}
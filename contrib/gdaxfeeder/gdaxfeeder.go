@@ -5,15 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	goio "io"
-	"math"
 	"net/http"
 	"sort"
 	"time"
 
 	gdax "github.com/preichenberger/go-gdax"
 
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
 	"github.com/alpacahq/marketstore/v4/executor"
-	"github.com/alpacahq/marketstore/v4/planner"
 	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/io"
@@ -39,8 +38,10 @@ type FetcherConfig struct {
 	BaseTimeframe string `json:"base_timeframe"`
 }
 
-// GdaxFetcher is the main worker instance.  It implements bgworker.Run().
+// GdaxFetcher is the main worker instance.  It implements bgworker.StoppableBgWorker
+// and bgworker.StatusReporter.
 type GdaxFetcher struct {
+	bgworker.StatusTracker
 	config        map[string]interface{}
 	symbols       []string
 	queryStart    time.Time
@@ -130,43 +131,22 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 }
 
 func findLastTimestamp(tbk *io.TimeBucketKey) time.Time {
-	cDir := executor.ThisInstance.CatalogDir
-	query := planner.NewQuery(cDir)
-	query.AddTargetKey(tbk)
-	start := time.Unix(0, 0).In(utils.InstanceConfig.Timezone)
-	end := time.Unix(math.MaxInt64, 0).In(utils.InstanceConfig.Timezone)
-	query.SetRange(start, end)
-	query.SetRowLimit(io.LAST, 1)
-	parsed, err := query.Parse()
+	lastTimestamp, err := backfill.LastWrittenTime(executor.ThisInstance.CatalogDir, tbk)
 	if err != nil {
-		return time.Time{}
+		log.Error(fmt.Sprintf("failed to find the last timestamp of %s: %v", tbk, err))
 	}
-	reader, err := executor.NewReader(parsed)
-	if err != nil {
-		log.Error(fmt.Sprintf("create query reader for tbk=%s", tbk))
-		return time.Time{}
-	}
-	csm, err := reader.Read()
-	if err != nil {
-		log.Error(fmt.Sprintf("failed to read a query for %s", tbk))
-		return time.Time{}
-	}
-	cs := csm[*tbk]
-	if cs == nil || cs.Len() == 0 {
-		return time.Time{}
-	}
-	ts, err := cs.GetTime()
-	if err != nil {
-		log.Error(fmt.Sprintf("failed to get time from a query for %s", tbk))
-		return time.Time{}
-	}
-	return ts[0]
+	return lastTimestamp
 }
 
 // Run () runs forever to get public historical rate for each configured symbol,
 // and writes in marketstore data format.  In case any error including rate limit
 // is returned from GDAX, it waits for a minute.
 func (gd *GdaxFetcher) Run() {
+	gd.RunContext(context.Background())
+}
+
+// RunContext runs as Run until ctx is canceled.
+func (gd *GdaxFetcher) RunContext(ctx context.Context) {
 	symbols := gd.symbols
 	client := gdax.NewClient("", "", "")
 	timeStart := time.Time{}
@@ -191,6 +171,9 @@ func (gd *GdaxFetcher) Run() {
 		timeEnd := timeStart.Add(gd.baseTimeframe.Duration * getHistoricRatesChunksize)
 		lastTime := timeStart
 		for _, symbol := range symbols {
+			if ctx.Err() != nil {
+				return
+			}
 			params := gdax.GetHistoricRatesParams{
 				Start:       timeStart,
 				End:         timeEnd,
@@ -200,8 +183,9 @@ func (gd *GdaxFetcher) Run() {
 			rates, err := client.GetHistoricRates(symbol, params)
 			if err != nil {
 				log.Info("Response error: %v", err)
+				gd.RecordError(err)
 				// including rate limit case
-				time.Sleep(time.Second)
+				sleep(ctx, time.Second)
 				continue
 			}
 			if len(rates) == 0 {
@@ -242,7 +226,10 @@ func (gd *GdaxFetcher) Run() {
 			err = executor.WriteCSM(csm, false)
 			if err != nil {
 				log.Error("[gdaxfeeder] failed to write csm", err.Error())
+				gd.RecordError(err)
+				continue
 			}
+			gd.RecordWrite()
 		}
 		// next fetch start point
 		timeStart = lastTime.Add(gd.baseTimeframe.Duration)
@@ -253,14 +240,28 @@ func (gd *GdaxFetcher) Run() {
 		log.Info("next expected(%v) - now(%v) = %v", nextExpected, now, toSleep)
 		if toSleep > 0 {
 			log.Debug("Sleep for %v\n", toSleep)
-			time.Sleep(toSleep)
+			sleep(ctx, toSleep)
 		} else if time.Since(lastTime) < time.Hour {
 			// let's not go too fast if the catch up is less than an hour
-			time.Sleep(time.Second)
+			sleep(ctx, time.Second)
+		}
+		if ctx.Err() != nil {
+			log.Info("[gdaxfeeder] stopped")
+			return
 		}
 	}
 }
 
+// sleep pauses for the duration or until ctx is canceled.
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
 func main() {
 	client := gdax.NewClient("", "", "")
 	params := gdax.GetHistoricRatesParams{
@@ -15,6 +15,7 @@ import (
 var (
 	reimport            bool
 	storeWithoutSymbols bool
+	applyTickerChanges  bool
 	disableVarComp      bool
 )
 
@@ -38,6 +39,10 @@ var ImportCmd = &cobra.Command{
 
 	--fallback-to-cusip allows Marketstore to store corporate action records by their TargetCusipID 
     if a matching symbol is not found. Default is false, so only records with matching symbols are stored 
+
+	--apply-ticker-changes renames the old symbols of the ticker changes (name changes or CUSIP changes
+	whose CUSIPs map to different symbols) to the new ones, keeping the old symbols as aliases,
+	and stores the changes as corporate actions so that the adjust function follows them
 	`,
 	SilenceUsage: false,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -56,7 +61,7 @@ var ImportCmd = &cobra.Command{
 		executor.NewInstanceSetup(c.GetCatalogDir(), c.GetInitWALFile())
 
 		utils.InstanceConfig.DisableVariableCompression = disableVarComp
-		err := reorg.Import(reorgDir, reimport, storeWithoutSymbols)
+		err := reorg.Import(reorgDir, reimport, storeWithoutSymbols, applyTickerChanges)
 		if err != nil {
 			return fmt.Errorf("failed to import: %w", err)
 		}
@@ -68,6 +73,7 @@ var ImportCmd = &cobra.Command{
 func init() {
 	ImportCmd.Flags().BoolVarP(&reimport, "reimport", "r", false, "reimport")
 	ImportCmd.Flags().BoolVarP(&storeWithoutSymbols, "fallback-to-cusip", "c", false, "fallback-to-cusip")
+	ImportCmd.Flags().BoolVarP(&applyTickerChanges, "apply-ticker-changes", "t", false, "apply-ticker-changes")
 	// Please set the same value as disable_variable_compression in the marketstore's mkts.yml
 	// where this plugin is running.
 	// Different disable_variable_compression values between this plugin and mkts.yml
@@ -13,7 +13,9 @@ import (
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
-func Import(reorgDir string, reimport, storeWithoutSymbol bool) error {
+// Import stores the announcements in the reorg files. With applyTickerChanges, the ticker changes
+// in the announcements are applied to the symbols in the catalog as well (see applyTickerChangeAnnouncements).
+func Import(reorgDir string, reimport, storeWithoutSymbol, applyTickerChanges bool) error {
 	reorgFiles, err := fileList(reorgDir, enum.ReorgFilePrefix, reimport)
 	if err != nil {
 		return fmt.Errorf("cannot read reorg files directory - dir=%s: %w", reorgDir, err)
@@ -45,6 +47,11 @@ func Import(reorgDir string, reimport, storeWithoutSymbol bool) error {
 		if err != nil {
 			return fmt.Errorf("error occurred while processing announcements from %s: %w", reorgFile, err)
 		}
+		if applyTickerChanges {
+			if err = applyTickerChangeAnnouncements(*announcements, cusipSymbolMap); err != nil {
+				return fmt.Errorf("error occurred while applying ticker changes from %s: %w", reorgFile, err)
+			}
+		}
 		if !reimport {
 			err = os.Rename(pathToReorgFile, pathToReorgFile+enum.ProcessedFlag)
 			if err != nil {
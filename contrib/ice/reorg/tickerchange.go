@@ -0,0 +1,90 @@
+package reorg
+
+import (
+	"fmt"
+
+	"github.com/alpacahq/marketstore/v4/contrib/ice/enum"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/models"
+	modelsenum "github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// tickerChange returns the old and new symbols of a name change or CUSIP change announcement,
+// when its target and initiating CUSIPs are mapped to different symbols.
+func tickerChange(note *Announcement, cusipSymbolMap map[string]string) (oldSymbol, newSymbol string, ok bool) {
+	if !(note.Is(enum.NameChange) || note.Is(enum.CUSIPChange)) {
+		return "", "", false
+	}
+	oldSymbol = cusipSymbolMap[note.TargetCusip]
+	newSymbol = cusipSymbolMap[note.InitiatingCusip]
+	if oldSymbol == "" || newSymbol == "" || oldSymbol == newSymbol {
+		return "", "", false
+	}
+	return oldSymbol, newSymbol, true
+}
+
+// applyTickerChangeAnnouncements stores the ticker changes in the announcements as the corporate actions of the new symbols,
+// so that the adjust function follows them, and renames the old symbols to the new ones in the catalog.
+// The old symbols become the aliases of the new ones. A symbol is not renamed when the new symbol already
+// has its own data, as the data of the two symbols can't be merged by a rename.
+func applyTickerChangeAnnouncements(notes []Announcement, cusipSymbolMap map[string]string) error {
+	for i := range notes {
+		oldSymbol, newSymbol, ok := tickerChange(&notes[i], cusipSymbolMap)
+		if !ok {
+			continue
+		}
+		if notes[i].EffectiveDate.IsZero() {
+			log.Warn("Ticker change %s to %s has no effective date, skipped", oldSymbol, newSymbol)
+			continue
+		}
+
+		// the updates and deletions of an announcement are the revisions of the same action
+		id := notes[i].TextNumber
+		if notes[i].UpdateTextNumber != 0 {
+			id = notes[i].UpdateTextNumber
+		} else if notes[i].DeleteTextNumber != 0 {
+			id = notes[i].DeleteTextNumber
+		}
+		canceled := notes[i].Status == enum.DeletedAnnouncement || notes[i].DeleteTextNumber != 0
+		if !canceled {
+			// renamed first, so that the new symbol doesn't exist yet unless it has its own data
+			if err := renameSymbol(oldSymbol, newSymbol); err != nil {
+				return err
+			}
+		}
+
+		actions := models.NewCorporateActions(newSymbol, 1)
+		actions.Add(&models.CorporateAction{
+			ID:            int64(id),
+			KnownAt:       notes[i].EntryDate,
+			Type:          modelsenum.TickerChange,
+			ExDate:        notes[i].EffectiveDate,
+			EffectiveDate: notes[i].EffectiveDate,
+			OldSymbol:     oldSymbol,
+			Canceled:      canceled,
+		})
+		if err := actions.Write(); err != nil {
+			return fmt.Errorf("store ticker change %s to %s: %w", oldSymbol, newSymbol, err)
+		}
+	}
+	return nil
+}
+
+// renameSymbol renames the old symbol to the new one in the catalog, unless the new symbol already exists.
+func renameSymbol(oldSymbol, newSymbol string) error {
+	catalogDir := executor.ThisInstance.CatalogDir
+	if catalogDir.GetSubDirWithItemName(oldSymbol) == nil {
+		// the symbol has been renamed already, or has no data
+		return nil
+	}
+	if catalogDir.GetSubDirWithItemName(newSymbol) != nil {
+		log.Warn("Symbol %s already exists, %s is not renamed to it", newSymbol, oldSymbol)
+		return nil
+	}
+	w, err := executor.NewWriter(catalogDir, executor.ThisInstance.WALFile)
+	if err != nil {
+		return err
+	}
+	return w.RenameSymbol(oldSymbol, newSymbol, false)
+}
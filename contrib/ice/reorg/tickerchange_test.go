@@ -0,0 +1,74 @@
+package reorg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/contrib/ice/enum"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/models"
+	modelsenum "github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/test"
+)
+
+func TestApplyTickerChangeAnnouncements(t *testing.T) {
+	// --- given ---
+	rootDir := t.TempDir()
+	test.MakeDummyCurrencyDir(rootDir, true, false)
+	cfg := utils.NewDefaultConfig(rootDir)
+	cfg.WALBypass = true
+	c := di.NewContainer(cfg)
+	metadata := executor.NewInstanceSetup(c.GetCatalogDir(), c.GetInitWALFile())
+
+	cusipSymbolMap := map[string]string{
+		"000000001": "USDJPY", "000000002": "JPYUSD",
+		"000000003": "EURUSD", "000000004": "NZDUSD",
+	}
+	notes := []Announcement{
+		{
+			TextNumber: 1, NotificationType: enum.NameChange, Status: enum.NewAnnouncement,
+			EntryDate: date(2022, 6, 1), EffectiveDate: date(2022, 6, 9),
+			TargetCusip: "000000001", InitiatingCusip: "000000002",
+		},
+		// the new symbol already exists
+		{
+			TextNumber: 2, NotificationType: enum.CUSIPChange, Status: enum.NewAnnouncement,
+			EntryDate: date(2022, 6, 1), EffectiveDate: date(2022, 6, 9),
+			TargetCusip: "000000003", InitiatingCusip: "000000004",
+		},
+		// not a ticker change
+		{
+			TextNumber: 3, NotificationType: enum.StockSplit, Status: enum.NewAnnouncement,
+			EntryDate: date(2022, 6, 1), EffectiveDate: date(2022, 6, 9),
+			TargetCusip: "000000003", InitiatingCusip: "000000004",
+		},
+	}
+
+	// --- when ---
+	err := applyTickerChangeAnnouncements(notes, cusipSymbolMap)
+
+	// --- then ---
+	require.Nil(t, err)
+	catalogDir := metadata.CatalogDir
+	assert.Nil(t, catalogDir.GetSubDirWithItemName("USDJPY"))
+	assert.Equal(t, "JPYUSD", catalogDir.ResolveSymbol("USDJPY"))
+	assert.NotNil(t, catalogDir.GetSubDirWithItemName("EURUSD"))
+	assert.NotNil(t, catalogDir.GetSubDirWithItemName("NZDUSD"))
+
+	actions, err := models.ReadCorporateActions(catalogDir, "JPYUSD")
+	require.Nil(t, err)
+	assert.Equal(t, []models.CorporateAction{
+		{
+			ID: 1, KnownAt: date(2022, 6, 1), Type: modelsenum.TickerChange,
+			ExDate: date(2022, 6, 9), EffectiveDate: date(2022, 6, 9), OldSymbol: "USDJPY",
+		},
+	}, actions)
+	actions, err = models.ReadCorporateActions(catalogDir, "NZDUSD")
+	require.Nil(t, err)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "EURUSD", actions[0].OldSymbol)
+}
@@ -0,0 +1,112 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/configs"
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/proto"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// Decoder decodes a message payload to a NumpyMultiDataset.
+type Decoder func(data []byte) (*io.NumpyMultiDataset, error)
+
+// NewDecoder returns the Decoder for the encoding name ("msgpack", "json" or "protobuf").
+func NewDecoder(encoding string) (Decoder, error) {
+	switch encoding {
+	case configs.EncodingMsgpack:
+		return decodeMsgpack, nil
+	case configs.EncodingJSON:
+		return decodeJSON, nil
+	case configs.EncodingProtobuf:
+		return decodeProtobuf, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding: %s", encoding)
+	}
+}
+
+func decodeMsgpack(data []byte) (*io.NumpyMultiDataset, error) {
+	nmds := &io.NumpyMultiDataset{}
+	if err := msgpack.Unmarshal(data, nmds); err != nil {
+		return nil, fmt.Errorf("unmarshal msgpack NumpyMultiDataset: %w", err)
+	}
+	return nmds, validate(nmds)
+}
+
+// jsonDataset uses the same field names as the msgpack representation.
+// column data are base64 strings as encoding/json does for []byte.
+type jsonDataset struct {
+	ColumnTypes []string       `json:"types"`
+	ColumnNames []string       `json:"names"`
+	ColumnData  [][]byte       `json:"data"`
+	Length      int            `json:"length"`
+	StartIndex  map[string]int `json:"startindex"`
+	Lengths     map[string]int `json:"lengths"`
+}
+
+func decodeJSON(data []byte) (*io.NumpyMultiDataset, error) {
+	var ds jsonDataset
+	if err := json.Unmarshal(data, &ds); err != nil {
+		return nil, fmt.Errorf("unmarshal json NumpyMultiDataset: %w", err)
+	}
+	nmds := &io.NumpyMultiDataset{
+		NumpyDataset: io.NumpyDataset{
+			ColumnTypes: ds.ColumnTypes,
+			ColumnNames: ds.ColumnNames,
+			ColumnData:  ds.ColumnData,
+			Length:      ds.Length,
+		},
+		StartIndex: ds.StartIndex,
+		Lengths:    ds.Lengths,
+	}
+	return nmds, validate(nmds)
+}
+
+func decodeProtobuf(data []byte) (*io.NumpyMultiDataset, error) {
+	var p proto.NumpyMultiDataset
+	if err := protobuf.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal protobuf NumpyMultiDataset: %w", err)
+	}
+	if p.Data == nil {
+		return nil, fmt.Errorf("protobuf NumpyMultiDataset has no data")
+	}
+	nmds := frontend.ToNumpyMultiDataSet(&p)
+	return nmds, validate(nmds)
+}
+
+// validate checks the dataset is consistent enough to be converted to a ColumnSeriesMap
+// without panicking on a malformed message.
+func validate(nmds *io.NumpyMultiDataset) error {
+	if len(nmds.ColumnNames) == 0 {
+		return fmt.Errorf("dataset has no column")
+	}
+	if len(nmds.ColumnNames) != len(nmds.ColumnTypes) || len(nmds.ColumnNames) != len(nmds.ColumnData) {
+		return fmt.Errorf("mismatched number of column names(%d), types(%d) and data(%d)",
+			len(nmds.ColumnNames), len(nmds.ColumnTypes), len(nmds.ColumnData),
+		)
+	}
+	for i, typeStr := range nmds.ColumnTypes {
+		elemType, ok := io.TypeStrToElemType(typeStr)
+		if !ok {
+			return fmt.Errorf("unsupported type string %s for column %s", typeStr, nmds.ColumnNames[i])
+		}
+		if len(nmds.ColumnData[i]) != elemType.Size()*nmds.Length {
+			return fmt.Errorf("column %s has %d bytes, expected %d rows of %s",
+				nmds.ColumnNames[i], len(nmds.ColumnData[i]), nmds.Length, typeStr,
+			)
+		}
+	}
+	for key, start := range nmds.StartIndex {
+		if start < 0 || start+nmds.Lengths[key] > nmds.Length {
+			return fmt.Errorf("rows of %s [%d:%d] are out of the dataset length %d",
+				key, start, start+nmds.Lengths[key], nmds.Length,
+			)
+		}
+	}
+	return nil
+}
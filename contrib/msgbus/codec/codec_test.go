@@ -0,0 +1,98 @@
+package codec_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/codec"
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func testDataset(t *testing.T) *io.NumpyMultiDataset {
+	t.Helper()
+
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{1600000000, 1600000060})
+	cs.AddColumn("Price", []float32{100.5, 101.25})
+	nds, err := io.NewNumpyDataset(cs)
+	require.NoError(t, err)
+	nmds, err := io.NewNumpyMultiDataset(nds, *io.NewTimeBucketKey("AAPL/1Min/TICK"))
+	require.NoError(t, err)
+	return nmds
+}
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	nmds := testDataset(t)
+
+	msgpackData, err := msgpack.Marshal(nmds)
+	require.NoError(t, err)
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"types":      nmds.ColumnTypes,
+		"names":      nmds.ColumnNames,
+		"data":       nmds.ColumnData,
+		"length":     nmds.Length,
+		"startindex": nmds.StartIndex,
+		"lengths":    nmds.Lengths,
+	})
+	require.NoError(t, err)
+	protobufData, err := protobuf.Marshal(frontend.ToProtoNumpyMultiDataSet(nmds))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		encoding string
+		data     []byte
+	}{
+		"msgpack":  {encoding: "msgpack", data: msgpackData},
+		"json":     {encoding: "json", data: jsonData},
+		"protobuf": {encoding: "protobuf", data: protobufData},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			decode, err := codec.NewDecoder(tt.encoding)
+			require.NoError(t, err)
+			got, err := decode(tt.data)
+			require.NoError(t, err)
+
+			csm, err := got.ToColumnSeriesMap()
+			require.NoError(t, err)
+			cs := csm[*io.NewTimeBucketKey("AAPL/1Min/TICK")]
+			require.NotNil(t, cs)
+			require.Equal(t, []int64{1600000000, 1600000060}, cs.GetEpoch())
+			require.Equal(t, []float32{100.5, 101.25}, cs.GetColumn("Price"))
+		})
+	}
+}
+
+func TestDecoder_Malformed(t *testing.T) {
+	t.Parallel()
+
+	decode, err := codec.NewDecoder("json")
+	require.NoError(t, err)
+
+	// not a json object
+	_, err = decode([]byte("hello"))
+	require.Error(t, err)
+
+	// column data is shorter than the length
+	_, err = decode([]byte(`{"types":["i8"],"names":["Epoch"],"data":["AQ=="],"length":1,` +
+		`"startindex":{"AAPL/1Min/TICK":0},"lengths":{"AAPL/1Min/TICK":1}}`))
+	require.Error(t, err)
+
+	// rows out of range
+	_, err = decode([]byte(`{"types":["i8"],"names":["Epoch"],"data":["AQAAAAAAAAA="],"length":1,` +
+		`"startindex":{"AAPL/1Min/TICK":0},"lengths":{"AAPL/1Min/TICK":2}}`))
+	require.Error(t, err)
+
+	_, err = codec.NewDecoder("avro")
+	require.Error(t, err)
+}
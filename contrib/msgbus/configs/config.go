@@ -0,0 +1,164 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Use json iter because it supports marshal/unmarshal of map[interface{}]interface{} type.
+// The config is parsed from a yaml file (mkts.yml) to map[string]interface{} and nested
+// structures such as "subscriptions" have map[interface{}]interface{} type.
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const (
+	// DriverNATS subscribes to NATS subjects.
+	DriverNATS = "nats"
+	// DriverKafka consumes Kafka topics in a consumer group.
+	DriverKafka = "kafka"
+
+	// EncodingMsgpack is the same NumpyMultiDataset encoding used by the msgpack RPC API.
+	EncodingMsgpack = "msgpack"
+	// EncodingJSON is a NumpyMultiDataset encoded as a JSON object.
+	EncodingJSON = "json"
+	// EncodingProtobuf is a proto.NumpyMultiDataset message.
+	EncodingProtobuf = "protobuf"
+
+	defaultBatchSize     = 1000
+	defaultFlushInterval = time.Second
+	defaultKafkaGroupID  = "marketstore"
+	// about 5 minutes with the backoff of the retries from 1 second up to 1 minute
+	defaultMaxWriteRetries = 10
+)
+
+// Config is the configuration for the message bus ingestion worker you can define in
+// marketstore's config file through bgworker extension.
+type Config struct {
+	// Driver is either "nats" or "kafka"
+	Driver string `json:"driver"`
+	// NATSServers is a comma separated list of nats servers to connect to
+	NATSServers string `json:"nats_servers"`
+	// NATSQueueGroup makes the subscriptions join a queue group so that
+	// multiple marketstore instances can share the load. optional.
+	NATSQueueGroup string `json:"nats_queue_group"`
+	// KafkaBrokers is a list of kafka brokers (host:port) to bootstrap from
+	KafkaBrokers []string `json:"kafka_brokers"`
+	// KafkaGroupID is the consumer group the offsets are committed for
+	KafkaGroupID string `json:"kafka_group_id"`
+	// Encoding of the NumpyMultiDataset messages. "msgpack", "json" or "protobuf"
+	Encoding string `json:"encoding"`
+	// BatchSize is the number of records buffered before they are written to marketstore
+	BatchSize int `json:"batch_size"`
+	// FlushInterval is the maximum duration records are buffered (e.g. "500ms", "1s")
+	FlushInterval time.Duration `json:"-"`
+	// MaxWriteRetries is the number of the retries of a failed write before the batch is dropped.
+	// -1 retries forever
+	MaxWriteRetries int `json:"max_write_retries"`
+	// Subscriptions lists the NATS subjects or Kafka topics to consume
+	Subscriptions []*Subscription `json:"subscriptions"`
+}
+
+// Subscription is a NATS subject (wildcards are allowed) or a Kafka topic
+// and how the messages received from it are stored.
+type Subscription struct {
+	// Subject is the NATS subject or the Kafka topic name
+	Subject string `json:"subject"`
+	// TimeBucketKey overrides the keys of the received datasets. optional.
+	// "{symbol}" is replaced with the symbol of the dataset key, the Kafka message key,
+	// or the last token of the NATS subject in this order. e.g. "{symbol}/1Sec/TICK"
+	TimeBucketKey string `json:"time_bucket_key"`
+	// IsVariableLength should be true to write the records to a variable-length bucket (e.g. TICK)
+	IsVariableLength bool `json:"is_variable_length"`
+}
+
+// NewConfig casts a map object to Config struct and returns it through json marshal->unmarshal.
+func NewConfig(config map[string]interface{}) (*Config, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the config file through json marshal->unmarshal: %w", err)
+	}
+
+	ret := &Config{
+		Encoding:        EncodingMsgpack,
+		BatchSize:       defaultBatchSize,
+		FlushInterval:   defaultFlushInterval,
+		KafkaGroupID:    defaultKafkaGroupID,
+		MaxWriteRetries: defaultMaxWriteRetries,
+	}
+	if err = json.Unmarshal(data, ret); err != nil {
+		return nil, err
+	}
+
+	if err = validate(ret); err != nil {
+		return nil, fmt.Errorf("config validation error: %w", err)
+	}
+	return ret, nil
+}
+
+// UnmarshalJSON parses the flush_interval string into a time.Duration in addition to the other fields.
+func (c *Config) UnmarshalJSON(input []byte) error {
+	type Alias Config
+
+	aux := &struct {
+		FlushInterval string `json:"flush_interval"`
+		*Alias
+	}{Alias: (*Alias)(c)}
+
+	if err := json.Unmarshal(input, &aux); err != nil {
+		return err
+	}
+	if aux.FlushInterval != "" {
+		d, err := time.ParseDuration(aux.FlushInterval)
+		if err != nil {
+			return fmt.Errorf("invalid flush_interval %q: %w", aux.FlushInterval, err)
+		}
+		c.FlushInterval = d
+	}
+	return nil
+}
+
+func validate(cfg *Config) error {
+	switch cfg.Driver {
+	case DriverNATS:
+		if cfg.NATSServers == "" {
+			return errors.New("nats_servers must be set for the nats driver")
+		}
+	case DriverKafka:
+		if len(cfg.KafkaBrokers) == 0 {
+			return errors.New("kafka_brokers must be set for the kafka driver")
+		}
+	default:
+		return fmt.Errorf("unknown driver %q. must be one of %s or %s", cfg.Driver, DriverNATS, DriverKafka)
+	}
+
+	switch cfg.Encoding {
+	case EncodingMsgpack, EncodingJSON, EncodingProtobuf:
+	default:
+		return fmt.Errorf("unknown encoding %q. must be one of %s, %s or %s",
+			cfg.Encoding, EncodingMsgpack, EncodingJSON, EncodingProtobuf,
+		)
+	}
+
+	if cfg.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive. got=%d", cfg.BatchSize)
+	}
+	if cfg.FlushInterval <= 0 {
+		return fmt.Errorf("flush_interval must be positive. got=%v", cfg.FlushInterval)
+	}
+
+	if len(cfg.Subscriptions) == 0 {
+		return errors.New("must have 1 or more subscriptions in the config file")
+	}
+	for _, sub := range cfg.Subscriptions {
+		if sub.Subject == "" {
+			return errors.New("subject of a subscription must not be empty")
+		}
+		if sub.TimeBucketKey != "" && len(strings.Split(sub.TimeBucketKey, "/")) != 3 {
+			return fmt.Errorf("time_bucket_key %q should be like {symbol}/1Min/OHLCV", sub.TimeBucketKey)
+		}
+	}
+	return nil
+}
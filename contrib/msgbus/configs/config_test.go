@@ -0,0 +1,126 @@
+package configs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/configs"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config  map[string]interface{}
+		want    *configs.Config
+		wantErr bool
+	}{
+		"ok/ defaults are applied": {
+			config: map[string]interface{}{
+				"driver":       "nats",
+				"nats_servers": "nats://127.0.0.1:4222",
+				"subscriptions": []interface{}{
+					map[interface{}]interface{}{"subject": "ticks.*", "time_bucket_key": "{symbol}/1Sec/TICK"},
+				},
+			},
+			want: &configs.Config{
+				Driver:          "nats",
+				NATSServers:     "nats://127.0.0.1:4222",
+				KafkaGroupID:    "marketstore",
+				Encoding:        "msgpack",
+				BatchSize:       1000,
+				FlushInterval:   time.Second,
+				MaxWriteRetries: 10,
+				Subscriptions: []*configs.Subscription{
+					{Subject: "ticks.*", TimeBucketKey: "{symbol}/1Sec/TICK"},
+				},
+			},
+		},
+		"ok/ kafka with a custom flush interval": {
+			config: map[string]interface{}{
+				"driver":            "kafka",
+				"kafka_brokers":     []string{"localhost:9092"},
+				"kafka_group_id":    "mkts-test",
+				"encoding":          "protobuf",
+				"batch_size":        10,
+				"flush_interval":    "250ms",
+				"max_write_retries": -1,
+				"subscriptions": []interface{}{
+					map[interface{}]interface{}{"subject": "bars", "is_variable_length": false},
+					map[interface{}]interface{}{"subject": "trades", "is_variable_length": true},
+				},
+			},
+			want: &configs.Config{
+				Driver:          "kafka",
+				KafkaBrokers:    []string{"localhost:9092"},
+				KafkaGroupID:    "mkts-test",
+				Encoding:        "protobuf",
+				BatchSize:       10,
+				FlushInterval:   250 * time.Millisecond,
+				MaxWriteRetries: -1,
+				Subscriptions: []*configs.Subscription{
+					{Subject: "bars"},
+					{Subject: "trades", IsVariableLength: true},
+				},
+			},
+		},
+		"ng/ unknown driver": {
+			config: map[string]interface{}{
+				"driver":        "zeromq",
+				"subscriptions": []interface{}{map[interface{}]interface{}{"subject": "a"}},
+			},
+			wantErr: true,
+		},
+		"ng/ unknown encoding": {
+			config: map[string]interface{}{
+				"driver":        "nats",
+				"nats_servers":  "nats://127.0.0.1:4222",
+				"encoding":      "avro",
+				"subscriptions": []interface{}{map[interface{}]interface{}{"subject": "a"}},
+			},
+			wantErr: true,
+		},
+		"ng/ no subscription": {
+			config: map[string]interface{}{
+				"driver":       "nats",
+				"nats_servers": "nats://127.0.0.1:4222",
+			},
+			wantErr: true,
+		},
+		"ng/ malformed time bucket key": {
+			config: map[string]interface{}{
+				"driver":       "nats",
+				"nats_servers": "nats://127.0.0.1:4222",
+				"subscriptions": []interface{}{
+					map[interface{}]interface{}{"subject": "a", "time_bucket_key": "{symbol}/1Sec"},
+				},
+			},
+			wantErr: true,
+		},
+		"ng/ invalid flush interval": {
+			config: map[string]interface{}{
+				"driver":         "nats",
+				"nats_servers":   "nats://127.0.0.1:4222",
+				"flush_interval": "soon",
+				"subscriptions":  []interface{}{map[interface{}]interface{}{"subject": "a"}},
+			},
+			wantErr: true,
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := configs.NewConfig(tt.config)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
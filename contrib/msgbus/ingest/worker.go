@@ -0,0 +1,322 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/codec"
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/source"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+const (
+	symbolPlaceholder = "{symbol}"
+	// number of items in a "{Symbol}/{Timeframe}/{AttributeGroup}" key
+	timeBucketKeyItemsLen = 3
+	messageBufferLen      = 1024
+	// defaultRetryInterval is the first wait before a failed write is retried.
+	// It doubles for each failure in a row up to maxRetryInterval.
+	defaultRetryInterval = time.Second
+	maxRetryInterval     = time.Minute
+)
+
+// droppedRecords counts the records of the batches dropped after all the retries of the write failed.
+var droppedRecords = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: "alpaca",
+		Subsystem: "marketstore",
+		Name:      "msgbus_dropped_records_total",
+		Help:      "Number of the records dropped by the msgbus ingester after all the retries of the write failed",
+	},
+)
+
+// CSMWriter writes a ColumnSeriesMap to marketstore.
+// WriteCSM should return only after the data is flushed to the WAL.
+type CSMWriter interface {
+	WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error
+}
+
+// StatusRecorder records the writes and the errors of the Worker, e.g. bgworker.StatusTracker.
+type StatusRecorder interface {
+	RecordWrite()
+	RecordError(err error)
+}
+
+// Worker consumes messages from a Source, batches the decoded datasets
+// and writes them to marketstore. Messages are committed to the Source only
+// after the batch they belong to is written. A batch that fails to be written
+// is retried up to MaxRetries times, so that no message after it is committed meanwhile,
+// and then dropped: its messages are committed without being written.
+type Worker struct {
+	Source        source.Source
+	Decode        codec.Decoder
+	Writer        CSMWriter
+	BatchSize     int
+	FlushInterval time.Duration
+	// RetryInterval is the first wait before a failed write is retried. defaultRetryInterval is used if it's zero.
+	RetryInterval time.Duration
+	// MaxRetries is the number of the retries of a failed write before the batch is dropped.
+	// A negative value retries forever.
+	MaxRetries int
+	// Status records the successful writes and the failed ones. optional.
+	Status StatusRecorder
+}
+
+// Run starts the Source and keeps writing the received data until ctx is cancelled.
+// The buffered records are written before Run returns. If ctx is cancelled while a failed write
+// is retried, Run returns an error without committing the messages of the batch and the later ones.
+func (w *Worker) Run(ctx context.Context) error {
+	msgs := make(chan *source.Message, messageBufferLen)
+	if err := w.Source.Start(ctx, msgs); err != nil {
+		return fmt.Errorf("start message source: %w", err)
+	}
+
+	ticker := time.NewTicker(w.FlushInterval)
+	defer ticker.Stop()
+
+	b := newBatch()
+	for {
+		select {
+		case msg := <-msgs:
+			if !b.add(msg, w.Decode) {
+				// the dataset cannot be merged into the pending records
+				if err := w.flush(ctx, b); err != nil {
+					return err
+				}
+				b = newBatch()
+				b.add(msg, w.Decode)
+			}
+			if b.rows >= w.BatchSize {
+				if err := w.flush(ctx, b); err != nil {
+					return err
+				}
+				b = newBatch()
+			}
+		case <-ticker.C:
+			if err := w.flush(ctx, b); err != nil {
+				return err
+			}
+			b = newBatch()
+		case <-ctx.Done():
+			if err := w.write(b); err != nil {
+				w.recordError(err)
+				return fmt.Errorf("write %d records before stop: %w", b.rows, err)
+			}
+			// commit with a fresh context because ctx is already cancelled
+			w.commit(context.Background(), b)
+			return nil
+		}
+	}
+}
+
+// flush writes the batch and commits its messages. A failed write is retried with backoff
+// up to MaxRetries times, which blocks the consumption of the later messages, and the batch is dropped
+// if all the retries fail. It returns an error without committing the messages if ctx is cancelled
+// before the write succeeds, so sources that track offsets redeliver them.
+func (w *Worker) flush(ctx context.Context, b *batch) error {
+	if len(b.msgs) == 0 {
+		return nil
+	}
+	retryInterval := w.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = defaultRetryInterval
+	}
+	for retries := 0; ; retries++ {
+		err := w.write(b)
+		if err == nil {
+			w.recordWrite()
+			break
+		}
+		w.recordError(err)
+		if w.MaxRetries >= 0 && retries >= w.MaxRetries {
+			log.Error("[msgbus] drop %d records of %d messages from %s after %d retries: %v",
+				b.rows, len(b.msgs), b.subjects(), retries, err)
+			droppedRecords.Add(float64(b.rows))
+			break
+		}
+		log.Error("[msgbus] failed to write %d records, retrying in %v: %v", b.rows, retryInterval, err)
+		timer := time.NewTimer(retryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("write %d records: %w", b.rows, err)
+		case <-timer.C:
+		}
+		if retryInterval *= 2; retryInterval > maxRetryInterval {
+			retryInterval = maxRetryInterval
+		}
+	}
+	w.commit(ctx, b)
+	return nil
+}
+
+// write writes the records of the batch.
+// The fixed-length records are written first, and overwriting them again on a retry is harmless.
+func (w *Worker) write(b *batch) error {
+	for _, isVariableLength := range []bool{false, true} {
+		csm := b.csm[isVariableLength]
+		if len(csm) == 0 {
+			continue
+		}
+		if err := w.Writer.WriteCSM(csm, isVariableLength); err != nil {
+			return fmt.Errorf("write the records of %d keys: %w", len(csm), err)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) recordWrite() {
+	if w.Status != nil {
+		w.Status.RecordWrite()
+	}
+}
+
+func (w *Worker) recordError(err error) {
+	if w.Status != nil {
+		w.Status.RecordError(err)
+	}
+}
+
+// commit commits the messages of the written or dropped batch.
+// A failure is only logged, as the messages are redelivered and written again at worst.
+func (w *Worker) commit(ctx context.Context, b *batch) {
+	if len(b.msgs) == 0 {
+		return
+	}
+	if err := w.Source.Commit(ctx, b.msgs); err != nil {
+		log.Error("[msgbus] failed to commit %d messages: %v", len(b.msgs), err)
+		return
+	}
+	log.Debug("[msgbus] wrote %d records from %d messages", b.rows, len(b.msgs))
+}
+
+type batch struct {
+	// csm[isVariableLength]
+	csm  map[bool]io.ColumnSeriesMap
+	msgs []*source.Message
+	rows int
+}
+
+func newBatch() *batch {
+	return &batch{
+		csm: map[bool]io.ColumnSeriesMap{
+			false: io.NewColumnSeriesMap(),
+			true:  io.NewColumnSeriesMap(),
+		},
+	}
+}
+
+// subjects returns the distinct subjects of the messages in the batch.
+func (b *batch) subjects() []string {
+	var subjects []string
+	seen := map[string]bool{}
+	for _, msg := range b.msgs {
+		if !seen[msg.Subject] {
+			seen[msg.Subject] = true
+			subjects = append(subjects, msg.Subject)
+		}
+	}
+	return subjects
+}
+
+// add decodes the message and appends its records to the batch.
+// It returns false without modifying the batch when the records have different columns
+// from the ones already buffered for the same key.
+// Messages that cannot be decoded are logged and kept in the batch so that they are committed
+// and not redelivered forever.
+func (b *batch) add(msg *source.Message, decode codec.Decoder) bool {
+	csm, err := decodeMessage(msg, decode)
+	if err != nil {
+		log.Error("[msgbus] drop a message from %s: %v", msg.Subject, err)
+		b.msgs = append(b.msgs, msg)
+		return true
+	}
+
+	dst := b.csm[msg.Subscription.IsVariableLength]
+	for tbk, cs := range csm {
+		if prev, ok := dst[tbk]; ok && !sameColumns(prev, cs) {
+			return false
+		}
+	}
+	for tbk, cs := range csm {
+		if prev, ok := dst[tbk]; ok {
+			dst[tbk] = appendColumnSeries(prev, cs)
+		} else {
+			dst[tbk] = cs
+		}
+		b.rows += cs.Len()
+	}
+	b.msgs = append(b.msgs, msg)
+	return true
+}
+
+// decodeMessage decodes the message payload and maps the dataset keys to time bucket keys.
+func decodeMessage(msg *source.Message, decode codec.Decoder) (io.ColumnSeriesMap, error) {
+	nmds, err := decode(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	csm, err := nmds.ToColumnSeriesMap()
+	if err != nil {
+		return nil, fmt.Errorf("convert dataset to column series: %w", err)
+	}
+
+	out := io.NewColumnSeriesMap()
+	for tbk, cs := range csm {
+		if cs.Len() == 0 {
+			continue
+		}
+		key, err2 := MapTimeBucketKey(tbk, msg)
+		if err2 != nil {
+			return nil, err2
+		}
+		out[*key] = cs
+	}
+	return out, nil
+}
+
+// MapTimeBucketKey returns the destination of a dataset entry received in msg.
+// Without a time_bucket_key template the entry key itself has to be a complete time bucket key.
+func MapTimeBucketKey(tbk io.TimeBucketKey, msg *source.Message) (*io.TimeBucketKey, error) {
+	items := tbk.GetItems()
+	template := msg.Subscription.TimeBucketKey
+	if template == "" {
+		if len(items) != timeBucketKeyItemsLen || items[0] == "" {
+			return nil, fmt.Errorf("dataset key %q is not a time bucket key "+
+				"and no time_bucket_key is configured for %s", tbk.GetItemKey(), msg.Subscription.Subject)
+		}
+		return &tbk, nil
+	}
+
+	symbol := items[0]
+	if symbol == "" {
+		symbol = msg.Key
+	}
+	if symbol == "" {
+		tokens := strings.Split(msg.Subject, ".")
+		symbol = tokens[len(tokens)-1]
+	}
+	return io.NewTimeBucketKey(strings.ReplaceAll(template, symbolPlaceholder, symbol)), nil
+}
+
+func sameColumns(a, b *io.ColumnSeries) bool {
+	return reflect.DeepEqual(a.GetDataShapes(), b.GetDataShapes())
+}
+
+// appendColumnSeries returns a new ColumnSeries with the rows of b appended to the rows of a.
+// a and b must have the same data shapes.
+func appendColumnSeries(a, b *io.ColumnSeries) *io.ColumnSeries {
+	out := io.NewColumnSeries()
+	for _, name := range a.GetColumnNames() {
+		col := reflect.AppendSlice(reflect.ValueOf(a.GetColumn(name)), reflect.ValueOf(b.GetColumn(name)))
+		out.AddColumn(name, col.Interface())
+	}
+	return out
+}
@@ -0,0 +1,317 @@
+package ingest_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/codec"
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/configs"
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/ingest"
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/source"
+	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+type writeCall struct {
+	csm              io.ColumnSeriesMap
+	isVariableLength bool
+}
+
+type mockWriter struct {
+	mu    sync.Mutex
+	calls []writeCall
+	// failures is the number of the writes to fail before the first success. -1 fails all the writes
+	failures int
+}
+
+func (w *mockWriter) WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failures != 0 {
+		if w.failures > 0 {
+			w.failures--
+		}
+		return errors.New("disk full")
+	}
+	w.calls = append(w.calls, writeCall{csm: csm, isVariableLength: isVariableLength})
+	return nil
+}
+
+// fakeSource delivers the messages in order, and records the committed messages.
+type fakeSource struct {
+	msgs []*source.Message
+
+	mu        sync.Mutex
+	committed [][]*source.Message
+}
+
+func (s *fakeSource) Start(ctx context.Context, out chan<- *source.Message) error {
+	go func() {
+		for _, msg := range s.msgs {
+			select {
+			case out <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *fakeSource) Commit(_ context.Context, msgs []*source.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.committed = append(s.committed, msgs)
+	return nil
+}
+
+func (s *fakeSource) Close() error { return nil }
+
+func (s *fakeSource) commits() [][]*source.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]*source.Message{}, s.committed...)
+}
+
+func (w *mockWriter) rows(tbk string) []int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var epochs []int64
+	for _, c := range w.calls {
+		if cs, ok := c.csm[*io.NewTimeBucketKey(tbk)]; ok {
+			epochs = append(epochs, cs.GetEpoch()...)
+		}
+	}
+	return epochs
+}
+
+func encode(t *testing.T, key string, epochs []int64) []byte {
+	t.Helper()
+
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", epochs)
+	cs.AddColumn("Price", make([]float64, len(epochs)))
+	nds, err := io.NewNumpyDataset(cs)
+	require.NoError(t, err)
+	nmds, err := io.NewNumpyMultiDataset(nds, *io.NewTimeBucketKey(key))
+	require.NoError(t, err)
+	data, err := msgpack.Marshal(nmds)
+	require.NoError(t, err)
+	return data
+}
+
+func TestWorker_NATS(t *testing.T) {
+	t.Parallel()
+
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	srv := natsserver.RunServer(&opts)
+	defer srv.Shutdown()
+
+	subs := []*configs.Subscription{
+		{Subject: "ticks.>", TimeBucketKey: "{symbol}/1Sec/TICK", IsVariableLength: true},
+		{Subject: "bars"},
+	}
+	decode, err := codec.NewDecoder("msgpack")
+	require.NoError(t, err)
+	w := &mockWriter{}
+	worker := &ingest.Worker{
+		Source:        source.NewNATSSource(srv.ClientURL(), "", subs),
+		Decode:        decode,
+		Writer:        w,
+		BatchSize:     3,
+		FlushInterval: 50 * time.Millisecond,
+	}
+
+	// the server has internal subscriptions of the system account
+	initialSubs := srv.NumSubscriptions()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- worker.Run(ctx) }()
+
+	// wait for the subscriptions
+	require.Eventually(t, func() bool { return srv.NumSubscriptions() == initialSubs+uint32(len(subs)) },
+		time.Second, 10*time.Millisecond)
+
+	nc, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	defer nc.Close()
+	require.NoError(t, nc.Publish("ticks.AAPL", encode(t, "", []int64{1, 2})))
+	require.NoError(t, nc.Publish("ticks.AAPL", encode(t, "", []int64{3})))
+	require.NoError(t, nc.Publish("bars", encode(t, "MSFT/1Min/OHLCV", []int64{60})))
+	require.NoError(t, nc.Publish("bars", []byte("corrupted message")))
+	require.NoError(t, nc.Flush())
+
+	require.Eventually(t, func() bool {
+		return len(w.rows("AAPL/1Sec/TICK")) == 3 && len(w.rows("MSFT/1Min/OHLCV")) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	require.Equal(t, []int64{1, 2, 3}, w.rows("AAPL/1Sec/TICK"))
+	require.Equal(t, []int64{60}, w.rows("MSFT/1Min/OHLCV"))
+	for _, c := range w.calls {
+		_, isTick := c.csm[*io.NewTimeBucketKey("AAPL/1Sec/TICK")]
+		require.Equal(t, isTick, c.isVariableLength)
+	}
+}
+
+func TestWorker_WriteFailure(t *testing.T) {
+	t.Parallel()
+
+	sub := &configs.Subscription{Subject: "bars"}
+	newMessages := func() []*source.Message {
+		msgs := make([]*source.Message, 3)
+		for i := range msgs {
+			msgs[i] = &source.Message{
+				Subscription: sub, Subject: "bars", Data: encode(t, "AAPL/1Min/OHLCV", []int64{int64(i+1) * 60}),
+			}
+		}
+		return msgs
+	}
+
+	tests := map[string]struct {
+		failures    int
+		maxRetries  int
+		wantEpochs  []int64
+		wantCommits int
+		wantErr     bool
+	}{
+		"ok/ the failed batch is retried before the later messages are committed": {
+			failures:    1,
+			maxRetries:  1,
+			wantEpochs:  []int64{60, 120, 180},
+			wantCommits: 3,
+		},
+		"ok/ the batch is dropped and committed after all the retries fail": {
+			failures:    2,
+			maxRetries:  1,
+			wantEpochs:  []int64{120, 180},
+			wantCommits: 3,
+		},
+		"ng/ nothing is committed if it stops while retrying": {
+			failures:    -1,
+			maxRetries:  -1,
+			wantEpochs:  nil,
+			wantCommits: 0,
+			wantErr:     true,
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			decode, err := codec.NewDecoder("msgpack")
+			require.NoError(t, err)
+			msgs := newMessages()
+			src := &fakeSource{msgs: msgs}
+			w := &mockWriter{failures: tt.failures}
+			status := &bgworker.StatusTracker{}
+			worker := &ingest.Worker{
+				Source:        src,
+				Decode:        decode,
+				Writer:        w,
+				BatchSize:     1,
+				FlushInterval: time.Hour,
+				RetryInterval: 10 * time.Millisecond,
+				MaxRetries:    tt.maxRetries,
+				Status:        status,
+			}
+
+			// --- when ---
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() { done <- worker.Run(ctx) }()
+			if tt.wantCommits > 0 {
+				require.Eventually(t, func() bool { return len(src.commits()) == tt.wantCommits },
+					2*time.Second, 10*time.Millisecond)
+			} else {
+				time.Sleep(50 * time.Millisecond)
+			}
+			cancel()
+			err = <-done
+
+			// --- then ---
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantEpochs, w.rows("AAPL/1Min/OHLCV"))
+			// the messages are committed in order, each after it's written
+			commits := src.commits()
+			require.Len(t, commits, tt.wantCommits)
+			for i, c := range commits {
+				require.Equal(t, []*source.Message{msgs[i]}, c)
+			}
+			// the failed writes and the successful ones are reported
+			got := status.Status()
+			require.NotEmpty(t, got.LastError)
+			require.Equal(t, len(tt.wantEpochs) > 0, !got.LastWrite.IsZero())
+		})
+	}
+}
+
+func TestMapTimeBucketKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		datasetKey string
+		msg        *source.Message
+		want       string
+		wantErr    bool
+	}{
+		"ok/ the dataset key is used as is without a template": {
+			datasetKey: "AAPL/1Min/OHLCV",
+			msg:        &source.Message{Subscription: &configs.Subscription{}, Subject: "bars"},
+			want:       "AAPL/1Min/OHLCV",
+		},
+		"ok/ symbol of the dataset key": {
+			datasetKey: "AAPL",
+			msg: &source.Message{
+				Subscription: &configs.Subscription{TimeBucketKey: "{symbol}/1Sec/TICK"}, Subject: "ticks.MSFT",
+			},
+			want: "AAPL/1Sec/TICK",
+		},
+		"ok/ kafka message key": {
+			datasetKey: "",
+			msg: &source.Message{
+				Subscription: &configs.Subscription{TimeBucketKey: "{symbol}/1Sec/TICK"}, Subject: "ticks", Key: "TSLA",
+			},
+			want: "TSLA/1Sec/TICK",
+		},
+		"ok/ last token of the nats subject": {
+			datasetKey: "",
+			msg: &source.Message{
+				Subscription: &configs.Subscription{TimeBucketKey: "{symbol}/1Sec/TICK"}, Subject: "ticks.us.MSFT",
+			},
+			want: "MSFT/1Sec/TICK",
+		},
+		"ng/ not a time bucket key without a template": {
+			datasetKey: "AAPL",
+			msg:        &source.Message{Subscription: &configs.Subscription{}, Subject: "bars"},
+			wantErr:    true,
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ingest.MapTimeBucketKey(*io.NewTimeBucketKey(tt.datasetKey), tt.msg)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, *io.NewTimeBucketKey(tt.want), *got)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/codec"
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/configs"
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/ingest"
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/source"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// MsgBusIngester subscribes to NATS subjects or Kafka topics and writes
+// the NumpyMultiDataset messages to marketstore. It implements bgworker.StoppableBgWorker
+// and bgworker.StatusReporter.
+type MsgBusIngester struct {
+	bgworker.StatusTracker
+	worker *ingest.Worker
+}
+
+// csmWriter writes through the local WAL. executor.WriteCSM only requests the WAL flush,
+// so it waits for the flush to make sure the data is on disk before the messages are committed.
+type csmWriter struct{}
+
+func (csmWriter) WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error {
+	if err := executor.WriteCSM(csm, isVariableLength); err != nil {
+		return err
+	}
+	executor.ThisInstance.WALFile.WaitFlush()
+	return nil
+}
+
+// NewBgWorker returns a new instance of MsgBusIngester.
+// See configs.Config for the details of available configurations.
+// nolint:deadcode // used by plugin
+func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
+	cfg, err := configs.NewConfig(conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load msgbus config. %v: %w", conf, err)
+	}
+
+	decode, err := codec.NewDecoder(cfg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	src, err := source.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MsgBusIngester{}
+	m.worker = &ingest.Worker{
+		Source:        src,
+		Decode:        decode,
+		Writer:        csmWriter{},
+		BatchSize:     cfg.BatchSize,
+		FlushInterval: cfg.FlushInterval,
+		MaxRetries:    cfg.MaxWriteRetries,
+		Status:        &m.StatusTracker,
+	}
+	return m, nil
+}
+
+// Run starts consuming the message bus.
+func (m *MsgBusIngester) Run() {
+	m.RunContext(context.Background())
+}
+
+// RunContext consumes the message bus until ctx is canceled or the worker fails,
+// and disconnects from the message bus before it returns.
+func (m *MsgBusIngester) RunContext(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		// stop the consumption of the source before closing it
+		cancel()
+		if err := m.worker.Source.Close(); err != nil {
+			log.Error("[msgbus] failed to close the message source: %v", err)
+		}
+	}()
+
+	if err := m.worker.Run(ctx); err != nil {
+		log.Error("[msgbus] stopped: %v", err)
+		m.RecordError(err)
+		return
+	}
+	log.Info("[msgbus] stopped")
+}
+
+func main() {}
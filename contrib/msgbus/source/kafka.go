@@ -0,0 +1,140 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/configs"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+const (
+	// fetchRetryInterval is the first wait before a failed fetch is retried.
+	// It doubles for each failure in a row up to maxFetchRetryInterval.
+	fetchRetryInterval    = 100 * time.Millisecond
+	maxFetchRetryInterval = 30 * time.Second
+)
+
+// KafkaSource consumes Kafka topics in a consumer group.
+// Offsets are committed only by Commit, so messages that were fetched but not
+// written to marketstore are redelivered after a restart (at-least-once).
+type KafkaSource struct {
+	brokers       []string
+	groupID       string
+	subscriptions []*configs.Subscription
+
+	mu      sync.Mutex
+	readers []*kafka.Reader
+}
+
+type kafkaOrigin struct {
+	reader *kafka.Reader
+	msg    kafka.Message
+}
+
+// NewKafkaSource returns a Source that consumes the topics of the subscriptions in the groupID consumer group.
+func NewKafkaSource(brokers []string, groupID string, subscriptions []*configs.Subscription) *KafkaSource {
+	return &KafkaSource{
+		brokers:       brokers,
+		groupID:       groupID,
+		subscriptions: subscriptions,
+	}
+}
+
+// Start creates a consumer group reader per topic and sends the fetched messages to out
+// until ctx is cancelled.
+func (s *KafkaSource) Start(ctx context.Context, out chan<- *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subscriptions {
+		r := kafka.NewReader(kafka.ReaderConfig{
+			Brokers: s.brokers,
+			GroupID: s.groupID,
+			Topic:   sub.Subject,
+		})
+		s.readers = append(s.readers, r)
+		go s.fetch(ctx, r, sub, out)
+	}
+	log.Info("[msgbus] consuming %d kafka topic(s) as group %s", len(s.readers), s.groupID)
+	return nil
+}
+
+// fetch sends the messages fetched by the reader to out until ctx is cancelled or the reader is closed.
+// A failed fetch is retried with backoff.
+func (s *KafkaSource) fetch(ctx context.Context, r *kafka.Reader, sub *configs.Subscription, out chan<- *Message) {
+	retryInterval := fetchRetryInterval
+	for {
+		m, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				// cancelled or the reader is closed
+				return
+			}
+			log.Error("[msgbus] failed to fetch a message from kafka topic %s, retrying in %v: %v",
+				sub.Subject, retryInterval, err)
+			timer := time.NewTimer(retryInterval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if retryInterval *= 2; retryInterval > maxFetchRetryInterval {
+				retryInterval = maxFetchRetryInterval
+			}
+			continue
+		}
+		retryInterval = fetchRetryInterval
+
+		msg := &Message{
+			Subscription: sub,
+			Subject:      m.Topic,
+			Key:          string(m.Key),
+			Data:         m.Value,
+			origin:       kafkaOrigin{reader: r, msg: m},
+		}
+		select {
+		case out <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Commit commits the offsets of msgs to the consumer group.
+func (s *KafkaSource) Commit(ctx context.Context, msgs []*Message) error {
+	perReader := map[*kafka.Reader][]kafka.Message{}
+	for _, msg := range msgs {
+		o, ok := msg.origin.(kafkaOrigin)
+		if !ok {
+			continue
+		}
+		perReader[o.reader] = append(perReader[o.reader], o.msg)
+	}
+	for r, kms := range perReader {
+		if err := r.CommitMessages(ctx, kms...); err != nil {
+			return fmt.Errorf("commit %d kafka messages: %w", len(kms), err)
+		}
+	}
+	return nil
+}
+
+// Close closes all the readers.
+func (s *KafkaSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var lastErr error
+	for _, r := range s.readers {
+		if err := r.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	s.readers = nil
+	return lastErr
+}
@@ -0,0 +1,108 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/configs"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// NATSSource subscribes to NATS subjects.
+// Core NATS does not keep offsets, so the delivery is at-most-once and Commit is a no-op.
+type NATSSource struct {
+	servers       string
+	queueGroup    string
+	subscriptions []*configs.Subscription
+
+	mu   sync.Mutex
+	conn *nats.Conn
+	subs []*nats.Subscription
+}
+
+// NewNATSSource returns a Source that subscribes to the subjects of the subscriptions.
+// servers is a comma separated list of nats server URLs.
+func NewNATSSource(servers, queueGroup string, subscriptions []*configs.Subscription) *NATSSource {
+	return &NATSSource{
+		servers:       servers,
+		queueGroup:    queueGroup,
+		subscriptions: subscriptions,
+	}
+}
+
+// Start connects to the NATS servers and subscribes to all the subjects.
+// It returns once the subscriptions are registered, and messages are sent to out
+// from the NATS client goroutine until ctx is cancelled.
+func (s *NATSSource) Start(ctx context.Context, out chan<- *Message) error {
+	conn, err := nats.Connect(s.servers,
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			log.Warn("[msgbus] disconnected from nats: %v", err)
+		}),
+		nats.ReconnectHandler(func(c *nats.Conn) {
+			log.Info("[msgbus] reconnected to nats %s", c.ConnectedUrl())
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("connect to nats servers %s: %w", s.servers, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conn = conn
+	for _, sub := range s.subscriptions {
+		sub := sub
+		handler := func(m *nats.Msg) {
+			msg := &Message{Subscription: sub, Subject: m.Subject, Data: m.Data}
+			select {
+			case out <- msg:
+			case <-ctx.Done():
+			}
+		}
+
+		var ns *nats.Subscription
+		if s.queueGroup != "" {
+			ns, err = conn.QueueSubscribe(sub.Subject, s.queueGroup, handler)
+		} else {
+			ns, err = conn.Subscribe(sub.Subject, handler)
+		}
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("subscribe to nats subject %s: %w", sub.Subject, err)
+		}
+		s.subs = append(s.subs, ns)
+	}
+	// make sure the subscriptions are registered on the server before returning
+	if err = conn.Flush(); err != nil {
+		conn.Close()
+		return fmt.Errorf("flush nats subscriptions: %w", err)
+	}
+	log.Info("[msgbus] subscribed to %d nats subject(s) on %s", len(s.subs), conn.ConnectedUrl())
+
+	go func() {
+		<-ctx.Done()
+		if err := s.Close(); err != nil {
+			log.Error("[msgbus] failed to close the nats connection: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Commit does nothing because core NATS subscriptions don't have offsets.
+func (s *NATSSource) Commit(_ context.Context, _ []*Message) error {
+	return nil
+}
+
+// Close drains the subscriptions so that the messages already received are handled, and disconnects.
+func (s *NATSSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil || s.conn.IsClosed() {
+		return nil
+	}
+	s.subs = nil
+	return s.conn.Drain()
+}
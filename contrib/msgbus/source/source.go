@@ -0,0 +1,42 @@
+package source
+
+import (
+	"context"
+
+	"github.com/alpacahq/marketstore/v4/contrib/msgbus/configs"
+)
+
+// Message is a payload received from a NATS subject or a Kafka topic.
+type Message struct {
+	// Subscription is the configured subscription the message was received through
+	Subscription *configs.Subscription
+	// Subject is the concrete NATS subject or Kafka topic of the message
+	Subject string
+	// Key is the Kafka message key. empty for NATS
+	Key string
+	// Data is the encoded NumpyMultiDataset
+	Data []byte
+
+	// origin is a source-specific handle that Commit uses to acknowledge the message.
+	origin interface{}
+}
+
+// Source delivers messages from a message bus.
+type Source interface {
+	// Start subscribes to the configured subjects/topics and sends the received messages
+	// to out until ctx is cancelled.
+	Start(ctx context.Context, out chan<- *Message) error
+	// Commit acknowledges msgs so that they are not redelivered.
+	// It should be called only after the messages are durably written to marketstore.
+	Commit(ctx context.Context, msgs []*Message) error
+	// Close unsubscribes and disconnects from the message bus.
+	Close() error
+}
+
+// New returns the Source for the configured driver.
+func New(cfg *configs.Config) (Source, error) {
+	if cfg.Driver == configs.DriverKafka {
+		return NewKafkaSource(cfg.KafkaBrokers, cfg.KafkaGroupID, cfg.Subscriptions), nil
+	}
+	return NewNATSSource(cfg.NATSServers, cfg.NATSQueueGroup, cfg.Subscriptions), nil
+}
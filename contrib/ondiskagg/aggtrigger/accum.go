@@ -21,6 +21,9 @@ type accumulator struct {
 	ivalues interface{} // input column(s)
 	iout    interface{} // output slice
 	ifunc   interface{} // function
+	// decimal columns are aggregated as int64 and output as DECIMAL64 with the scale of the input
+	decimal bool
+	scale   int8
 }
 
 func newAccumGroup(cs *io.ColumnSeries, params []accumParam) *accumGroup {
@@ -47,7 +50,13 @@ func (ag *accumGroup) apply(start, end int) error {
 
 func (ag *accumGroup) addColumns(cs *io.ColumnSeries) {
 	for i, param := range ag.params {
-		cs.AddColumn(param.outputName, ag.accumulators[i].iout)
+		ac := ag.accumulators[i]
+		if !ac.decimal {
+			cs.AddColumn(param.outputName, ac.iout)
+			continue
+		}
+		name := cs.AddColumn(param.outputName, io.SwapSliceData(ac.iout, io.Decimal64(0)))
+		cs.SetScale(name, ac.scale)
 	}
 }
 
@@ -182,6 +191,11 @@ var accumMap = map[io.EnumElementType]func(funcName string, column interface{})
 		iout := make([]uint64, 0)
 		return &accumulator{iout: iout, ifunc: ifunc, ivalues: column}
 	},
+	io.DECIMAL64: func(funcName string, column interface{}) *accumulator {
+		ifunc := int64AccumFunc[funcName]
+		iout := make([]int64, 0)
+		return &accumulator{iout: iout, ifunc: ifunc, ivalues: io.SwapSliceData(column, int64(0)), decimal: true}
+	},
 	io.BOOL: func(funcName string, column interface{}) *accumulator {
 		log.Error("no compatible accum func for BOOL type column")
 		return nil
@@ -202,7 +216,11 @@ var accumMap = map[io.EnumElementType]func(funcName string, column interface{})
 
 func newAccumulator(cs *io.ColumnSeries, param accumParam) *accumulator {
 	inColumn := cs.GetColumn(param.inputName)
-	return accumMap[io.GetElementType(inColumn)](param.funcName, inColumn)
+	ac := accumMap[io.GetElementType(inColumn)](param.funcName, inColumn)
+	if ac != nil && ac.decimal {
+		ac.scale = cs.GetScale(param.inputName)
+	}
+	return ac
 }
 
 func (ac *accumulator) float32out(fn func([]float32) float32, start, end int) error {
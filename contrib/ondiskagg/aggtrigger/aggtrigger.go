@@ -25,6 +25,7 @@ package aggtrigger
 // is set to "nasdaq", it filters the scan data by NASDAQ market hours.
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -411,7 +412,7 @@ func (s *OnDiskAggTrigger) query(
 
 	// Scan
 	qs := frontend.NewQueryService(cDir)
-	csm, err := qs.ExecuteQuery(tbk, start, end, 0, false, nil)
+	csm, err := qs.ExecuteQuery(context.Background(), tbk, start, end, 0, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -15,7 +15,8 @@ import (
 type PolygonWebSocket struct {
 	maxMessageSize int64
 	pingPeriod     time.Duration
-	doneChan       chan struct{}
+	doneChan       chan struct{} // closed to stop listen
+	stopped        chan struct{} // closed when listen returns
 	Servers        []*url.URL
 	apiKey         string
 	scope          *SubscriptionScope
@@ -38,6 +39,7 @@ func NewPolygonWebSocket(servers, apiKey string, pref Prefix, symbols []string,
 		maxMessageSize: defaultMaxMessageRecvBytes,
 		pingPeriod:     10 * time.Second,
 		doneChan:       make(chan struct{}),
+		stopped:        make(chan struct{}),
 		Servers:        setURLs(servers, apiKey),
 		apiKey:         apiKey,
 		scope:          NewSubscriptionScope(pref, symbols),
@@ -66,7 +68,10 @@ func (p *PolygonWebSocket) pongHandler(_ string) (err error) {
 	return nil
 }
 
+// listen receives the messages and sends them to the output channel, reconnecting on errors,
+// until doneChan is closed.
 func (p *PolygonWebSocket) listen() {
+	defer close(p.stopped)
 restartConnection:
 	// start the upstream websocket connection
 	err := p.connect()
@@ -75,7 +80,10 @@ restartConnection:
 			"server", p.Servers[0].String(),
 			"subscription", p.scope.GetSubScope(),
 			"error", err.Error())
-		time.Sleep(time.Second)
+		if !p.sleep(time.Second) {
+			p.disconnect()
+			return
+		}
 		goto restartConnection // try again
 	}
 
@@ -83,7 +91,10 @@ restartConnection:
 	p.ping()
 
 	if !p.subscribe() {
-		time.Sleep(time.Second)
+		if !p.sleep(time.Second) {
+			p.disconnect()
+			return
+		}
 		goto restartConnection // try again
 	}
 
@@ -107,15 +118,34 @@ restartConnection:
 			case nil:
 				goto restartConnection
 			default:
-				p.outputChan <- msg
+				select {
+				case p.outputChan <- msg:
+				case <-p.doneChan:
+					p.disconnect()
+					return
+				}
 			}
 		}
 	}
 }
 
+// sleep pauses for the duration, and returns false if doneChan is closed meanwhile.
+func (p *PolygonWebSocket) sleep(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-p.doneChan:
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
 func (p *PolygonWebSocket) receiveMessages(out chan []byte) {
+	// listen may disconnect and reset p.conn while reading
+	conn := p.conn
 	for {
-		tt, pp, err := p.conn.ReadMessage()
+		tt, pp, err := conn.ReadMessage()
 		switch tt {
 		case -1: // "NoFrame" error from the websocket library
 			log.Warn("failed websocket connection, restarting... {%s:%v}",
@@ -143,10 +173,17 @@ func (p *PolygonWebSocket) receiveMessages(out chan []byte) {
 				"error", err)
 			continue
 		}
-		out <- pp
+		select {
+		case out <- pp:
+		case <-p.doneChan:
+			return
+		}
 	}
 ErrorOut:
-	out <- nil
+	select {
+	case out <- nil:
+	case <-p.doneChan:
+	}
 }
 
 func (p *PolygonWebSocket) connect() error {
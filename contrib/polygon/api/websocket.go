@@ -15,6 +15,9 @@ type Subscription struct {
 	pConn    *PolygonWebSocket
 	running  bool
 	handled  int64
+	// workerPool handles the incoming messages, and worked is closed once it finishes to take them
+	workerPool *pool.Pool
+	worked     chan struct{}
 	sync.Mutex
 }
 
@@ -41,13 +44,23 @@ func (s *Subscription) setRunning(state bool) {
 	s.running = state
 }
 
+// Hangup disconnects from the upstream and returns after the received messages are handled.
+// The subscription can't be used after that.
 func (s *Subscription) Hangup() {
 	s.Lock()
-	defer s.Unlock()
-	if s.pConn.doneChan != nil {
-		s.pConn.doneChan <- struct{}{}
+	if !s.running {
+		s.Unlock()
+		return
 	}
 	s.running = false
+	close(s.pConn.doneChan)
+	s.Unlock()
+
+	// the incoming channel is closed after listen stops sending to it, so that the worker pool finishes
+	<-s.pConn.stopped
+	close(s.Incoming)
+	<-s.worked
+	s.workerPool.Wait()
 }
 
 func (s *Subscription) IsActive() bool {
@@ -83,17 +96,27 @@ func (s *Subscription) Subscribe(handler func(msg []byte)) {
 	// initialize & start the async worker pool
 
 	s.ResetHandled()
-	workerPool := pool.NewPool(10, func(msg []byte) {
+	s.workerPool = pool.NewPool(10, func(msg []byte) {
 		handler(msg)
 		s.IncrementHandled()
 	})
+	s.worked = make(chan struct{})
 
-	go workerPool.Work(s.Incoming)
+	go func() {
+		defer close(s.worked)
+		s.workerPool.Work(s.Incoming)
+	}()
 
 	// monitoring goroutines
 	go func() {
 		tickDebug := time.NewTicker(time.Second)
-		for range tickDebug.C {
+		defer tickDebug.Stop()
+		for {
+			select {
+			case <-tickDebug.C:
+			case <-s.pConn.doneChan:
+				return
+			}
 			log.Debug(
 				"{%s:%v,%s:%v,%s:%v,%s:%v}",
 				"subscription", s.pConn.scope.GetSubScope(),
@@ -104,7 +127,13 @@ func (s *Subscription) Subscribe(handler func(msg []byte)) {
 	}()
 	go func() {
 		tickInfo := time.NewTicker(1 * time.Minute)
-		for range tickInfo.C {
+		defer tickInfo.Stop()
+		for {
+			select {
+			case <-tickInfo.C:
+			case <-s.pConn.doneChan:
+				return
+			}
 			log.Info("{%s:%v,%s:%v,%s:%v}",
 				"subscription", s.pConn.scope.GetSubScope(),
 				"channel_depth", len(s.Incoming),
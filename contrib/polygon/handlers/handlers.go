@@ -35,10 +35,10 @@ func conditionsPresent(conditions []int) (skip bool) {
 }
 
 // TradeHandler handles a Polygon WS trade
-// message and stores it to the cache.
-func TradeHandler(msg []byte) {
+// message and stores it to the cache. It returns the error of the decode or the write, which is logged too.
+func TradeHandler(msg []byte) error {
 	if msg == nil {
-		return
+		return nil
 	}
 	tt := make([]api.PolyTrade, 0)
 	err := json.Unmarshal(msg, &tt)
@@ -46,7 +46,7 @@ func TradeHandler(msg []byte) {
 		log.Warn("error processing upstream message",
 			"message", string(msg),
 			"error", err.Error())
-		return
+		return err
 	}
 	writeMap := make(map[io.TimeBucketKey][]*trade)
 	for _, rt := range tt {
@@ -67,16 +67,19 @@ func TradeHandler(msg []byte) {
 		appendTrade(writeMap, io.NewTimeBucketKey(key), &t)
 		_ = lagOnReceipt
 	}
-	writeTrades(writeMap)
+	if err = writeTrades(writeMap); err != nil {
+		return err
+	}
 
 	metrics.PolygonStreamLastUpdate.WithLabelValues("trade").SetToCurrentTime()
+	return nil
 }
 
 // QuoteHandler handles a Polygon WS quote
-// message and stores it to the cache.
-func QuoteHandler(msg []byte) {
+// message and stores it to the cache. It returns the error of the decode or the write, which is logged too.
+func QuoteHandler(msg []byte) error {
 	if msg == nil {
-		return
+		return nil
 	}
 	qq := make([]api.PolyQuote, 0)
 	err := json.Unmarshal(msg, &qq)
@@ -84,7 +87,7 @@ func QuoteHandler(msg []byte) {
 		log.Warn("error processing upstream message",
 			"message", string(msg),
 			"error", err.Error())
-		return
+		return err
 	}
 	writeMap := make(map[io.TimeBucketKey][]*quote)
 	for _, rq := range qq {
@@ -102,16 +105,21 @@ func QuoteHandler(msg []byte) {
 		appendQuote(writeMap, io.NewTimeBucketKey(key), &q)
 		_ = lagOnReceipt
 	}
-	writeQuotes(writeMap)
+	if err = writeQuotes(writeMap); err != nil {
+		return err
+	}
 
 	metrics.PolygonStreamLastUpdate.WithLabelValues("quote").SetToCurrentTime()
+	return nil
 }
 
-func BarsHandler(msg []byte) {
+// BarsHandler handles a Polygon WS aggregate message and writes the bars.
+// It returns the error of the decode or the last failed write, which are logged too.
+func BarsHandler(msg []byte) error {
 	const millisecToSec = 1000
 	const nanosecToMillisec = 1000 * 1000
 	if msg == nil {
-		return
+		return nil
 	}
 	am := make([]api.PolyAggregate, 0)
 	err := json.Unmarshal(msg, &am)
@@ -119,8 +127,9 @@ func BarsHandler(msg []byte) {
 		log.Warn("error processing upstream message",
 			"message", string(msg),
 			"error", err.Error())
-		return
+		return err
 	}
+	var lastErr error
 	for _, bar := range am {
 		timestamp := time.Unix(0, int64(nanosecToMillisec*float64(bar.EpochMillis)))
 		lagOnReceipt := time.Since(timestamp).Seconds()
@@ -143,12 +152,14 @@ func BarsHandler(msg []byte) {
 
 		if err := executor.WriteCSM(csm, false); err != nil {
 			log.Error("[polygon] csm write failure for key: [%v] (%v)", tbk.String(), err)
+			lastErr = fmt.Errorf("write bar of %s: %w", tbk.String(), err)
 		}
 
 		_ = lagOnReceipt
 	}
 
 	metrics.PolygonStreamLastUpdate.WithLabelValues("bar").SetToCurrentTime()
+	return lastErr
 }
 
 func appendTrade(writeMap map[io.TimeBucketKey][]*trade, tbkp *io.TimeBucketKey, tr *trade) {
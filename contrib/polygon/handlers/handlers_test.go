@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/alpacahq/marketstore/v4/internal/di"
 	"github.com/alpacahq/marketstore/v4/utils"
 
@@ -55,16 +57,16 @@ func TestHandlers(t *testing.T) {
 	// trade
 	{
 		buf, _ := json.Marshal(getTestTradeArray())
-		handlers.TradeHandler(buf)
+		require.Nil(t, handlers.TradeHandler(buf))
 
 		a := getTestTradeArray()
 		a[0].Conditions = []int{handlers.ConditionExchangeSummary}
 		buf, _ = json.Marshal(a)
-		handlers.TradeHandler(buf)
+		require.Nil(t, handlers.TradeHandler(buf))
 	}
 	// quote
 	{
 		buf, _ := json.Marshal(getTestQuoteArray())
-		handlers.QuoteHandler(buf)
+		require.Nil(t, handlers.QuoteHandler(buf))
 	}
 }
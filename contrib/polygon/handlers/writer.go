@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"fmt"
+
 	"github.com/alpacahq/marketstore/v4/executor"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 	"github.com/alpacahq/marketstore/v4/utils/log"
@@ -89,7 +91,7 @@ func Write(writeMap map[io.TimeBucketKey]interface{}) {
 	}
 }
 
-func writeTrades(writeMap map[io.TimeBucketKey][]*trade) {
+func writeTrades(writeMap map[io.TimeBucketKey][]*trade) error {
 	// preallocate the data structures for re-use
 	var (
 		csm   io.ColumnSeriesMap
@@ -122,10 +124,12 @@ func writeTrades(writeMap map[io.TimeBucketKey][]*trade) {
 
 	if err := executor.WriteCSM(csm, true); err != nil {
 		log.Error("[polygon] failed to write trades csm (%v)", err)
+		return fmt.Errorf("write trades: %w", err)
 	}
+	return nil
 }
 
-func writeQuotes(writeMap map[io.TimeBucketKey][]*quote) {
+func writeQuotes(writeMap map[io.TimeBucketKey][]*quote) error {
 	// preallocate the data structures for re-use
 	var (
 		csm   io.ColumnSeriesMap
@@ -165,5 +169,7 @@ func writeQuotes(writeMap map[io.TimeBucketKey][]*quote) {
 
 	if err := executor.WriteCSM(csm, true); err != nil {
 		log.Error("[polygon] failed to write quotes csm (%v)", err)
+		return fmt.Errorf("write quotes: %w", err)
 	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -10,9 +11,13 @@ import (
 	"github.com/alpacahq/marketstore/v4/contrib/polygon/handlers"
 	"github.com/alpacahq/marketstore/v4/contrib/polygon/polygonconfig"
 	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
+	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
+// PolygonFetcher streams the bars, quotes and trades of Polygon.
+// It implements bgworker.StoppableBgWorker and bgworker.StatusReporter.
 type PolygonFetcher struct {
+	bgworker.StatusTracker
 	config polygonconfig.FetcherConfig
 	types  map[string]struct{} // Bars, Quotes, Trades
 }
@@ -51,6 +56,11 @@ func NewBgWorker(conf map[string]interface{}) (w bgworker.BgWorker, err error) {
 // Run the PolygonFetcher. It starts the streaming API as well as the
 // asynchronous backfilling routine.
 func (pf *PolygonFetcher) Run() {
+	pf.RunContext(context.Background())
+}
+
+// RunContext runs as Run until ctx is canceled, and hangs up the subscriptions before it returns.
+func (pf *PolygonFetcher) RunContext(ctx context.Context) {
 	api.SetAPIKey(pf.config.APIKey)
 
 	if pf.config.BaseURL != "" {
@@ -61,9 +71,10 @@ func (pf *PolygonFetcher) Run() {
 		api.SetWSServers(pf.config.WSServers)
 	}
 
+	subs := make([]*api.Subscription, 0, len(pf.types))
 	for t := range pf.types {
 		var prefix api.Prefix
-		var handler func([]byte)
+		var handler func([]byte) error
 		switch t {
 		case "bars":
 			prefix = api.Agg
@@ -76,10 +87,27 @@ func (pf *PolygonFetcher) Run() {
 			handler = handlers.TradeHandler
 		}
 		s := api.NewSubscription(prefix, pf.config.Symbols)
-		s.Subscribe(handler)
+		s.Subscribe(pf.track(handler))
+		subs = append(subs, s)
 	}
+	pf.SetState("streaming")
 
-	select {}
+	<-ctx.Done()
+	for _, s := range subs {
+		s.Hangup()
+	}
+	log.Info("[polygon] stopped")
+}
+
+// track records the result of the handler in the status.
+func (pf *PolygonFetcher) track(handler func([]byte) error) func([]byte) {
+	return func(msg []byte) {
+		if err := handler(msg); err != nil {
+			pf.RecordError(err)
+			return
+		}
+		pf.RecordWrite()
+	}
 }
 
 func main() {}
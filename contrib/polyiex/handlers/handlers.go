@@ -9,6 +9,8 @@ import (
 
 	"github.com/alpacahq/marketstore/v4/contrib/polyiex/orderbook"
 	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/models/enum"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
@@ -68,24 +70,33 @@ func handleBook(raw []byte) {
 	millisec, _ := jsonparser.GetInt(raw, "t")
 	nanosec, _ := jsonparser.GetInt(raw, "T")
 
+	timestamp := time.Unix(0, 1000*1000*millisec+nanosec)
+	epoch, nanos := timestamp.Unix(), timestamp.Nanosecond()
+
 	book := getOrderBook(symbol)
+	delta := models.NewOrderBookDelta(symbol, 0)
 	jsonparser.ArrayEach(raw, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
 		px, _ := jsonparser.GetFloat(value, "[0]")
 		sz, _ := jsonparser.GetInt(value, "[1]")
 		book.Bid(orderbook.Entry{Price: float32(px), Size: int32(sz)})
+		delta.Add(epoch, nanos, enum.Bid, enum.Price(float32(px)), enum.Size(sz))
 	}, "b")
 	jsonparser.ArrayEach(raw, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
 		px, _ := jsonparser.GetFloat(value, "[0]")
 		sz, _ := jsonparser.GetInt(value, "[1]")
 		book.Ask(orderbook.Entry{Price: float32(px), Size: int32(sz)})
+		delta.Add(epoch, nanos, enum.Ask, enum.Price(float32(px)), enum.Size(sz))
 	}, "a")
 
+	if bookPersistence.enabled() {
+		persistBook(symbol, timestamp, book, delta)
+	}
+
 	b, a := book.BBO()
 
 	log.Debug("[polyiex] %v BBO[%s]=(%v)/(%v)\n", string(raw), symbol, b, a)
 
 	// maybe we should skip to write if BBO isn't changed
-	timestamp := time.Unix(0, 1000*1000*millisec+nanosec)
 	pkt := &writePacket{
 		io.NewTimeBucketKey(symbol + "/1Min/QUOTE"),
 		&quote{
@@ -137,6 +148,69 @@ func Tick(raw []byte) {
 	})
 }
 
+// bookPersister writes the order books with models.OrderBookDelta and models.OrderBookSnapshot.
+type bookPersister struct {
+	sync.Mutex
+	// snapshotInterval is the minimum interval between the snapshots of a symbol.
+	// 0 means the order books are not persisted.
+	snapshotInterval time.Duration
+	lastSnapshot     map[string]time.Time
+}
+
+var bookPersistence = &bookPersister{lastSnapshot: map[string]time.Time{}}
+
+// PersistBook enables writing every change of the order books to the {symbol}/1Sec/BOOKDELTA buckets
+// and the full depth of them to the {symbol}/1Sec/BOOKSNAP buckets at most once per snapshotInterval,
+// so that the books can be reconstructed by models.ReconstructOrderBook.
+func PersistBook(snapshotInterval time.Duration) {
+	bookPersistence.Lock()
+	defer bookPersistence.Unlock()
+	bookPersistence.snapshotInterval = snapshotInterval
+}
+
+func (bp *bookPersister) enabled() bool {
+	bp.Lock()
+	defer bp.Unlock()
+	return bp.snapshotInterval > 0
+}
+
+// needsSnapshot returns true and records the snapshot time
+// when snapshotInterval has passed since the last snapshot of the symbol.
+func (bp *bookPersister) needsSnapshot(symbol string, timestamp time.Time) bool {
+	bp.Lock()
+	defer bp.Unlock()
+	last, ok := bp.lastSnapshot[symbol]
+	if ok && timestamp.Sub(last) < bp.snapshotInterval {
+		return false
+	}
+	bp.lastSnapshot[symbol] = timestamp
+	return true
+}
+
+// persistBook writes the changed levels of the book, and the whole book when a snapshot is due.
+// The snapshot is taken after the delta is applied to the book, as it has the same timestamp as the delta.
+func persistBook(symbol string, timestamp time.Time, book *orderbook.OrderBook, delta *models.OrderBookDelta) {
+	if delta.Len() > 0 {
+		Write(&writePacket{delta.Tbk, delta})
+	}
+
+	if !bookPersistence.needsSnapshot(symbol, timestamp) {
+		return
+	}
+	bids, asks := book.Bids(), book.Asks()
+	epoch, nanos := timestamp.Unix(), timestamp.Nanosecond()
+	snapshot := models.NewOrderBookSnapshot(symbol, len(bids)+len(asks))
+	for _, e := range bids {
+		snapshot.Add(epoch, nanos, enum.Bid, enum.Price(e.Price), enum.Size(e.Size))
+	}
+	for _, e := range asks {
+		snapshot.Add(epoch, nanos, enum.Ask, enum.Price(e.Price), enum.Size(e.Size))
+	}
+	if snapshot.Len() > 0 {
+		Write(&writePacket{snapshot.Tbk, snapshot})
+	}
+}
+
 // orderBooks is a map of OrderBook with symbol key.
 var (
 	orderBooks = map[string]*orderbook.OrderBook{}
@@ -228,6 +302,10 @@ func (w *writer) write() {
 					w.dataBuckets[*packet.tbk] = append(bucket.([]*quote), d)
 				case *trade:
 					w.dataBuckets[*packet.tbk] = append(bucket.([]*trade), d)
+				case *models.OrderBookDelta:
+					w.dataBuckets[*packet.tbk] = append(bucket.([]*models.OrderBookDelta), d)
+				case *models.OrderBookSnapshot:
+					w.dataBuckets[*packet.tbk] = append(bucket.([]*models.OrderBookSnapshot), d)
 				}
 			} else {
 				switch d := packet.data.(type) {
@@ -235,6 +313,10 @@ func (w *writer) write() {
 					w.dataBuckets[*packet.tbk] = []*quote{d}
 				case *trade:
 					w.dataBuckets[*packet.tbk] = []*trade{d}
+				case *models.OrderBookDelta:
+					w.dataBuckets[*packet.tbk] = []*models.OrderBookDelta{d}
+				case *models.OrderBookSnapshot:
+					w.dataBuckets[*packet.tbk] = []*models.OrderBookSnapshot{d}
 				}
 			}
 
@@ -294,6 +376,28 @@ func (w *writer) write() {
 						sz = sz[:0]
 						w.dataBuckets[tbk] = b[:0]
 					}
+				case []*models.OrderBookDelta:
+					if len(b) > 0 {
+						delta := models.NewOrderBookDelta(tbk.GetItemInCategory("Symbol"), 0)
+						for _, d := range b {
+							for i := range d.Epoch {
+								delta.Add(d.Epoch[i], int(d.Nanos[i]), d.Side[i], d.Price[i], d.Size[i])
+							}
+						}
+						csm.AddColumnSeries(tbk, delta.GetCs())
+						w.dataBuckets[tbk] = b[:0]
+					}
+				case []*models.OrderBookSnapshot:
+					if len(b) > 0 {
+						snapshot := models.NewOrderBookSnapshot(tbk.GetItemInCategory("Symbol"), 0)
+						for _, d := range b {
+							for i := range d.Epoch {
+								snapshot.Add(d.Epoch[i], int(d.Nanos[i]), d.Side[i], d.Price[i], d.Size[i])
+							}
+						}
+						csm.AddColumnSeries(tbk, snapshot.GetCs())
+						w.dataBuckets[tbk] = b[:0]
+					}
 				}
 			}
 
@@ -69,3 +69,26 @@ func (ob *OrderBook) BBO() (bid, ask Entry) {
 	}
 	return be, ae
 }
+
+// Bids returns all the bid levels from the best (highest) price.
+func (ob *OrderBook) Bids() []Entry {
+	return levels(ob.bids)
+}
+
+// Asks returns all the ask levels from the best (lowest) price.
+func (ob *OrderBook) Asks() []Entry {
+	return levels(ob.asks)
+}
+
+func levels(sklist *skiplist.SkipList) []Entry {
+	entries := make([]Entry, 0, sklist.Len())
+	for it := sklist.Iterator(); it.Next(); {
+		e, ok := it.Value().(Entry)
+		if !ok {
+			log.Error("[bug]failed to cast a value to an Entry")
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
@@ -64,4 +64,17 @@ func TestOrderBook(t *testing.T) {
 		assert.Equal(t, float32(154.05), a.Price)
 		assert.Equal(t, int32(200), a.Size)
 	}
+
+	assert.Equal(t, []Entry{
+		{153.72, 100},
+		{153.71, 100},
+		{153.67, 100},
+		{153.66, 100},
+		{153.51, 200},
+	}, ob.Bids())
+	assert.Equal(t, []Entry{
+		{154.05, 200},
+		{154.09, 100},
+		{154.19, 100},
+	}, ob.Asks())
 }
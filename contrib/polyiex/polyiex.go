@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -17,14 +18,21 @@ import (
 )
 
 type PolyIEXFetcher struct {
-	config FetcherConfig
+	config               FetcherConfig
+	bookSnapshotInterval time.Duration
 }
 
 type FetcherConfig struct {
 	APIKey  string `json:"api_key"`
 	BaseURL string `json:"base_url"`
+	// PersistBook writes the order books to {symbol}/1Sec/BOOKDELTA and {symbol}/1Sec/BOOKSNAP when true.
+	PersistBook bool `json:"persist_book"`
+	// BookSnapshotInterval is the interval of the order book snapshots (e.g. "1m"). Default is 1 minute.
+	BookSnapshotInterval string `json:"book_snapshot_interval"`
 }
 
+const defaultBookSnapshotInterval = time.Minute
+
 // NewBgWorker creates a new bgworker for polygon/IEX.
 func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 	data, _ := json.Marshal(conf)
@@ -43,8 +51,23 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 		return nil, err
 	}
 
+	bookSnapshotInterval := defaultBookSnapshotInterval
+	if config.BookSnapshotInterval != "" {
+		d, err := time.ParseDuration(config.BookSnapshotInterval)
+		if err == nil && d <= 0 {
+			err = errors.New("must be positive")
+		}
+		if err != nil {
+			err = fmt.Errorf("[polyiex]: invalid book_snapshot_interval %q: %w", config.BookSnapshotInterval, err)
+			log.Error("%v", err)
+			return nil, err
+		}
+		bookSnapshotInterval = d
+	}
+
 	return &PolyIEXFetcher{
-		config: config,
+		config:               config,
+		bookSnapshotInterval: bookSnapshotInterval,
 	}, nil
 }
 
@@ -53,6 +76,9 @@ func (pf *PolyIEXFetcher) Run() {
 	// configure api package
 	api.SetAPIKey(pf.config.APIKey)
 	api.SetBaseURL(pf.config.BaseURL)
+	if pf.config.PersistBook {
+		handlers.PersistBook(pf.bookSnapshotInterval)
+	}
 
 	err := api.Stream(handlers.Tick, api.TradePrefix, nil)
 	if err != nil {
@@ -46,6 +46,8 @@ type DefaultConfig struct {
 		Enabled   bool      `json:"enabled"`
 		Since     CustomDay `json:"since"`
 		Timeframe string    `json:"timeframe"`
+		// RequestsPerSecond limits the rate of the API calls for the backfill. 0 means no limit.
+		RequestsPerSecond float64 `json:"requests_per_second"`
 	} `json:"backfill"`
 	// for the past X market-open days,
 	// Xignite Feeder can feed 5-minute chart data for the target symbols in addition to daily-chart data backfill.
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"golang.org/x/time/rate"
+
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/api"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/symbols"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/writer"
@@ -20,15 +23,17 @@ type Backfill struct {
 	writer        writer.QuotesWriter
 	rangeWriter   writer.QuotesRangeWriter
 	since         time.Time
+	limiter       *rate.Limiter
 }
 
 // NewBackfill initializes the module to backfill the historical daily chart data to marketstore.
+// limiter limits the rate of the API calls for the backfill. nil means no limit.
 func NewBackfill(symbolManager symbols.Manager, apiClient api.Client, quotesWriter writer.QuotesWriter,
-	rangeWriter writer.QuotesRangeWriter, since time.Time,
+	rangeWriter writer.QuotesRangeWriter, since time.Time, limiter *rate.Limiter,
 ) *Backfill {
 	return &Backfill{
 		symbolManager: symbolManager, apiClient: apiClient,
-		writer: quotesWriter, rangeWriter: rangeWriter, since: since,
+		writer: quotesWriter, rangeWriter: rangeWriter, since: since, limiter: limiter,
 	}
 }
 
@@ -42,66 +47,81 @@ func (b *Backfill) Update(ctx context.Context) {
 
 // UpdateSymbols aggregates daily chart data since the specified date
 // and store it to "{symbol}/{timeframe}/OHLCV" bucket in marketstore.
-// nolint: dupl // The impl of updateSymbols/updateIndexSymbols can change in the future, keep as it is for now
 func (b *Backfill) UpdateSymbols(ctx context.Context) {
-	endDate := time.Now().UTC()
-	for _, identifier := range b.symbolManager.GetAllIdentifiers() {
-		// call a Xignite API to get the historical data
-		resp, err := b.apiClient.GetQuotesRange(ctx, identifier, b.since, endDate)
-		if err != nil {
-			// The RequestError is returned when the symbol doesn't have any quotes data
-			// (i.e. the symbol has not been listed yet)
-			if resp.Outcome == requestError {
-				log.Info(fmt.Sprintf("failed to get the daily chart data for identifier=%s. Err=%v", identifier, err))
-				continue
-			}
-			log.Error("Xignite API call error. Err=%v, API response=%v", err, resp)
-			return
-		}
+	b.paginate(ctx, b.symbolManager.GetAllIdentifiers(), b.fetchQuotesRange)
+}
 
-		// write the data to marketstore
-		err = b.rangeWriter.Write(resp.Security.Symbol, resp.ArrayOfEndOfDayQuote, false)
-		if err != nil {
-			log.Error(fmt.Sprintf("failed to backfill the daily chart data"+
-				" to marketstore in UpdateSymbols. identifier=%v, err=%v", identifier, err))
-		}
+// UpdateIndexSymbols aggregates daily chart data of index symbols
+// since the specified date and store it to "{symbol}/{timeframe}/OHLCV" bucket in marketstore.
+func (b *Backfill) UpdateIndexSymbols(ctx context.Context) {
+	b.paginate(ctx, b.symbolManager.GetAllIndexIdentifiers(), b.fetchIndexQuotesRange)
+}
 
-		log.Info("backfilling the historical daily chart data... identifier=%s", identifier)
+// paginate calls fetch for each identifier because Xignite GetQuotesRange APIs accept only one identifier.
+func (b *Backfill) paginate(ctx context.Context, identifiers []string, fetch backfill.FetchFunc) {
+	p := backfill.Paginator{MaxSymbolsPerReq: 1, Limiter: b.limiter}
+	if err := p.Run(ctx, identifiers, b.since, time.Now().UTC(), fetch); err != nil {
+		log.Error("[Xignite Feeder] daily chart backfill is stopped: %v", err)
+		return
 	}
 
 	log.Info("Data backfill is successfully done.")
 }
 
-// UpdateIndexSymbols aggregates daily chart data of index symbols
-// since the specified date and store it to "{symbol}/{timeframe}/OHLCV" bucket in marketstore.
-// nolint: dupl // The impl of updateSymbols/updateIndexSymbols can change in the future, keep as it is for now
-func (b *Backfill) UpdateIndexSymbols(ctx context.Context) {
-	endDate := time.Now().UTC()
-	for _, identifier := range b.symbolManager.GetAllIndexIdentifiers() {
-		// call a Xignite API to get the historical data
-		resp, err := b.apiClient.GetIndexQuotesRange(ctx, identifier, b.since, endDate)
-		if err != nil {
-			// The RequestError is returned when the symbol doesn't have any quotes data
-			// (i.e. the symbol has not been listed yet)
-			if resp.Outcome == requestError {
-				log.Info(fmt.Sprintf("failed to get the daily chart data for identifier=%s. Err=%v", identifier, err))
-				continue
-			}
-			log.Error("Xignite API call error. Err=%v, API response=%v", err, resp)
-			return
+// fetchQuotesRange gets the daily chart data of the identifier in the date range and writes it to marketstore.
+// nolint: dupl // The impl of fetchQuotesRange/fetchIndexQuotesRange can change in the future
+func (b *Backfill) fetchQuotesRange(ctx context.Context, identifiers []string, dateRange backfill.DateRange) error {
+	identifier := identifiers[0]
+	// call a Xignite API to get the historical data
+	resp, err := b.apiClient.GetQuotesRange(ctx, identifier, dateRange.From, dateRange.To)
+	if err != nil {
+		// The RequestError is returned when the symbol doesn't have any quotes data
+		// (i.e. the symbol has not been listed yet)
+		if resp.Outcome == requestError {
+			log.Info(fmt.Sprintf("failed to get the daily chart data for identifier=%s. Err=%v", identifier, err))
+			return nil
 		}
+		return fmt.Errorf("xignite API call error. API response=%v: %w", resp, err)
+	}
+
+	// write the data to marketstore
+	err = b.rangeWriter.Write(resp.Security.Symbol, resp.ArrayOfEndOfDayQuote, false)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to backfill the daily chart data"+
+			" to marketstore in UpdateSymbols. identifier=%v, err=%v", identifier, err))
+	}
 
-		// write the data to marketstore
-		err = b.rangeWriter.Write(resp.IndexAndGroup.Symbol, resp.ArrayOfEndOfDayQuote, true)
-		if err != nil {
-			log.Error(fmt.Sprintf("failed to backfill the daily chart data"+
-				" to marketstore in UpdateIndexSymbols. identifier=%v, err=%v", identifier, err))
+	log.Info("backfilling the historical daily chart data... identifier=%s", identifier)
+	return nil
+}
+
+// fetchIndexQuotesRange gets the daily chart data of the index identifier in the date range
+// and writes it to marketstore.
+// nolint: dupl // The impl of fetchQuotesRange/fetchIndexQuotesRange can change in the future
+func (b *Backfill) fetchIndexQuotesRange(ctx context.Context, identifiers []string, dateRange backfill.DateRange,
+) error {
+	identifier := identifiers[0]
+	// call a Xignite API to get the historical data
+	resp, err := b.apiClient.GetIndexQuotesRange(ctx, identifier, dateRange.From, dateRange.To)
+	if err != nil {
+		// The RequestError is returned when the symbol doesn't have any quotes data
+		// (i.e. the symbol has not been listed yet)
+		if resp.Outcome == requestError {
+			log.Info(fmt.Sprintf("failed to get the daily chart data for identifier=%s. Err=%v", identifier, err))
+			return nil
 		}
+		return fmt.Errorf("xignite API call error. API response=%v: %w", resp, err)
+	}
 
-		log.Info("backfilling the historical daily chart data... identifier=%s", identifier)
+	// write the data to marketstore
+	err = b.rangeWriter.Write(resp.IndexAndGroup.Symbol, resp.ArrayOfEndOfDayQuote, true)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to backfill the daily chart data"+
+			" to marketstore in UpdateIndexSymbols. identifier=%v, err=%v", identifier, err))
 	}
 
-	log.Info("Data backfill is successfully done.")
+	log.Info("backfilling the historical daily chart data... identifier=%s", identifier)
+	return nil
 }
 
 // UpdateClosingPrice get real-time quotes data for the target symbols and store them into the local marketstore server.
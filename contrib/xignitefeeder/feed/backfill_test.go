@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/api"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/internal"
@@ -117,3 +118,46 @@ func TestBackfill_Update_RequestErrorIdentifier(t *testing.T) {
 		t.Fatalf("type error")
 	}
 }
+
+type MockFailingAPIClient struct {
+	internal.MockAPIClient
+}
+
+// GetQuotesRange returns an error other than "Request Error" to certain identifier.
+func (mac *MockFailingAPIClient) GetQuotesRange(_ context.Context, i string, _, _ time.Time,
+) (resp api.GetQuotesRangeResponse, err error) {
+	if i == "XTKS.1305" {
+		return api.GetQuotesRangeResponse{Outcome: "SystemError"}, errors.New("error")
+	}
+
+	return api.GetQuotesRangeResponse{
+		Outcome:              api.SuccessOutcome,
+		Security:             &api.Security{Symbol: "1301"},
+		ArrayOfEndOfDayQuote: []api.EndOfDayQuote{},
+	}, nil
+}
+
+// The backfill of the rest of the identifiers is stopped when Xignite returns an error other than "RequestError".
+func TestBackfill_UpdateSymbols_APIError(t *testing.T) {
+	t.Parallel()
+	// --- given ---
+	rw := &MockQuotesRangeWriter{WriteCount: 0}
+
+	SUT := NewBackfill(
+		internal.MockSymbolsManager{Identifiers: TestIdentifiers},
+		&MockFailingAPIClient{},
+		&MockQuotesWriter{WriteCount: 0},
+		rw,
+		time.Now().UTC(),
+		rate.NewLimiter(rate.Every(time.Millisecond), 1),
+	)
+
+	// --- when ---
+	SUT.UpdateSymbols(context.Background())
+
+	// --- then ---
+	// only the identifier before the error is written
+	if rw.WriteCount != 1 {
+		t.Errorf("1 write should be performed. got: WriteCount=%v", rw.WriteCount)
+	}
+}
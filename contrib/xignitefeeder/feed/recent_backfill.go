@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/api"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/symbols"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/writer"
@@ -14,14 +15,14 @@ import (
 // RecentBackfill aggregates daily chart data using Xignite API and store it to.
 type RecentBackfill struct {
 	symbolManager     symbols.Manager
-	marketTimeChecker MarketTimeChecker
+	marketTimeChecker markettime.MarketTimeChecker
 	apiClient         api.Client
 	writer            writer.BarWriter
 	days              int
 }
 
 // NewRecentBackfill initializes the module to backfill the historical 5-minute chart data to marketstore.
-func NewRecentBackfill(sm symbols.Manager, mtc MarketTimeChecker, ac api.Client, w writer.BarWriter, days int,
+func NewRecentBackfill(sm symbols.Manager, mtc markettime.MarketTimeChecker, ac api.Client, w writer.BarWriter, days int,
 ) *RecentBackfill {
 	return &RecentBackfill{symbolManager: sm, marketTimeChecker: mtc, apiClient: ac, writer: w, days: days}
 }
@@ -3,33 +3,72 @@ package feed
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/timer"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/api"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/symbols"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/writer"
+	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
-// Worker is the main worker instance.  It implements bgworker.Run().
+// states reported by the Worker.
+const (
+	statePolling      = "polling"
+	stateMarketClosed = "market closed"
+)
+
+// Worker is the main worker instance.  It implements bgworker.StoppableBgWorker and bgworker.StatusReporter.
+// The status has the time of the last successful poll and the error of the last failed one.
 type Worker struct {
-	MarketTimeChecker MarketTimeChecker
+	bgworker.StatusTracker
+	MarketTimeChecker markettime.MarketTimeChecker
 	APIClient         api.Client
 	SymbolManager     symbols.Manager
 	QuotesWriter      writer.QuotesWriter
 	Interval          int
+	// Jobs are the daily jobs such as the symbol updates and the backfill. optional.
+	Jobs []timer.Job
 }
 
 // Run runs forever to get quotes data for each symbol in the target exchanges using Xignite API periodically,
 // and writes the data to the local marketstore server.
 func (w *Worker) Run() {
-	ctx := context.Background()
+	w.RunContext(context.Background())
+}
+
+// RunContext runs as Run and the daily jobs until ctx is canceled.
+// It waits for the running request, write and jobs before it returns.
+func (w *Worker) RunContext(ctx context.Context) {
+	jobsDone := make([]<-chan struct{}, len(w.Jobs))
+	for i, job := range w.Jobs {
+		jobsDone[i] = timer.RunEveryDayAt(ctx, job.At, job.Run)
+	}
+
+	var wg sync.WaitGroup
 	for {
 		// try to get the data and write them every second
-		go w.tryPrintErr(ctx)
-		time.Sleep(time.Duration(w.Interval) * time.Second)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.tryPrintErr(ctx)
+		}()
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			for _, done := range jobsDone {
+				<-done
+			}
+			log.Info("[Xignite Feeder] stopped")
+			return
+		case <-time.After(time.Duration(w.Interval) * time.Second):
+		}
 	}
 }
 
@@ -37,6 +76,7 @@ func (w *Worker) Run() {
 func (w *Worker) tryPrintErr(ctx context.Context) {
 	if err := w.try(ctx); err != nil {
 		log.Error(err.Error())
+		w.RecordError(err)
 	}
 
 	// nolint: gocritic // this defer func is to recover from panic
@@ -53,8 +93,10 @@ func (w *Worker) tryPrintErr(ctx context.Context) {
 func (w *Worker) try(ctx context.Context) error {
 	// check if it needs to work now
 	if !w.MarketTimeChecker.IsOpen(time.Now().UTC()) {
+		w.SetState(stateMarketClosed)
 		return nil
 	}
+	w.SetState(statePolling)
 	// call Xignite API to get Quotes data
 	identifiers := w.SymbolManager.GetAllIdentifiers()
 	response, err := w.APIClient.GetRealTimeQuotes(ctx, identifiers)
@@ -68,5 +110,6 @@ func (w *Worker) try(ctx context.Context) error {
 		return errors.Wrap(err, "failed to write quotes data")
 	}
 
+	w.RecordWrite()
 	return nil
 }
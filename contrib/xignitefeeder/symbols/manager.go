@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	feedersymbols "github.com/alpacahq/marketstore/v4/contrib/feeder/symbols"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/api"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
@@ -18,22 +19,22 @@ type Manager interface {
 
 // ManagerImpl is an implementation of the Manager.
 type ManagerImpl struct {
-	APIClient         api.Client
-	TargetExchanges   []string
-	TargetIndexGroups []string
-	NotQuoteStockList map[string]struct{}
 	// identifier = {symbol}.{exchange} (i.e. "7203.XTKS").
-	Identifiers map[string][]string
+	Identifiers feedersymbols.Manager
 	// IndexIdentifiers are the identifiers for index symbols (ex. "151.INDXJPX" (=TOPIX))
-	IndexIdentifiers map[string][]string
+	IndexIdentifiers feedersymbols.Manager
 }
 
 // NewManager initializes the SymbolManager object with the specified parameters.
 func NewManager(apiClient api.Client, targetExchanges, targetIndexGroups, notQuoteStockList []string) *ManagerImpl {
+	notQuoteStocks := sliceToMap(notQuoteStockList)
 	return &ManagerImpl{
-		APIClient: apiClient, TargetExchanges: targetExchanges, TargetIndexGroups: targetIndexGroups,
-		NotQuoteStockList: sliceToMap(notQuoteStockList),
-		Identifiers:       map[string][]string{}, IndexIdentifiers: map[string][]string{},
+		Identifiers: feedersymbols.NewManager(&IdentifierLister{
+			APIClient: apiClient, TargetExchanges: targetExchanges, NotQuoteStockList: notQuoteStocks,
+		}),
+		IndexIdentifiers: feedersymbols.NewManager(&IndexIdentifierLister{
+			APIClient: apiClient, TargetIndexGroups: targetIndexGroups, NotQuoteStockList: notQuoteStocks,
+		}),
 	}
 }
 
@@ -48,43 +49,42 @@ func sliceToMap(s []string) map[string]struct{} {
 // GetAllIdentifiers returns Identifiers for the target symbols for all the target exchanges
 // identifier = {exchange}.{symbol} (ex. "XTKS.1301").
 func (m *ManagerImpl) GetAllIdentifiers() []string {
-	var identifiers []string
-	for _, exchange := range m.TargetExchanges {
-		identifiers = append(identifiers, m.Identifiers[exchange]...)
-	}
-	return identifiers
+	return m.Identifiers.GetAllSymbols()
 }
 
 // GetAllIndexIdentifiers returns Identifiers for the target index symbols for all the index groups
 // identifier = {exchange}.{symbol} (ex. "XTKS.1301").
 func (m *ManagerImpl) GetAllIndexIdentifiers() []string {
-	var identifiers []string
-	for _, exchange := range m.TargetIndexGroups {
-		identifiers = append(identifiers, m.IndexIdentifiers[exchange]...)
-	}
-	return identifiers
+	return m.IndexIdentifiers.GetAllSymbols()
 }
 
-// Update calls UpdateSymbols and UpdateIndexSymbols sequentially.
+// Update updates the identifiers and the index identifiers sequentially.
 func (m *ManagerImpl) Update(ctx context.Context) {
-	m.UpdateSymbols(ctx)
-	m.UpdateIndexSymbols(ctx)
+	m.Identifiers.UpdateSymbols(ctx)
+	m.IndexIdentifiers.UpdateSymbols(ctx)
 }
 
-// UpdateSymbols calls the ListSymbols endpoint, convert the symbols to the Identifiers
-// and store them to the Identifiers map.
-func (m *ManagerImpl) UpdateSymbols(ctx context.Context) {
-	for _, exchange := range m.TargetExchanges {
-		resp, err := m.APIClient.ListSymbols(ctx, exchange)
+// IdentifierLister lists the identifiers of the symbols in the target exchanges.
+type IdentifierLister struct {
+	APIClient         api.Client
+	TargetExchanges   []string
+	NotQuoteStockList map[string]struct{}
+}
+
+// ListSymbols calls the ListSymbols endpoint for each target exchange
+// and converts the symbols to the identifiers.
+func (l *IdentifierLister) ListSymbols(ctx context.Context) ([]string, error) {
+	var identifiers []string
+	for _, exchange := range l.TargetExchanges {
+		resp, err := l.APIClient.ListSymbols(ctx, exchange)
 
 		// if ListSymbols API returns an error, don't update the target symbols
 		if err != nil || resp.Outcome != api.SuccessOutcome {
-			log.Error(fmt.Sprintf("err=%v, List symbols API response=%v", err, resp))
-			return
+			return nil, fmt.Errorf("list symbols of %s. err=%v, API response=%v", exchange, err, resp)
 		}
 
-		// convert the symbol strings (i.e. "1234") to the identifier strings (i.e. "1234.XTKS") and store them to the map
-		var identifiers []string
+		// convert the symbol strings (i.e. "1234") to the identifier strings (i.e. "1234.XTKS")
+		n := 0
 		for _, securityDescription := range resp.ArrayOfSecurityDescription {
 			symbol := securityDescription.Symbol
 			if len(symbol) >= 5 {
@@ -93,52 +93,54 @@ func (m *ManagerImpl) UpdateSymbols(ctx context.Context) {
 				continue
 			}
 
-			if _, found := m.NotQuoteStockList[symbol]; found {
+			if _, found := l.NotQuoteStockList[symbol]; found {
 				// ignore symbols in not_quote_stock_list
 				continue
 			}
 			if symbol != "" {
-				identifier := fmt.Sprintf("%s.%s", securityDescription.Symbol, exchange)
-				identifiers = append(identifiers, identifier)
+				identifiers = append(identifiers, fmt.Sprintf("%s.%s", symbol, exchange))
+				n++
 			}
 		}
-
-		// update target symbols for the stock exchange
-		m.Identifiers[exchange] = identifiers
-		log.Debug(fmt.Sprintf("Updated symbols. The number of symbols in %s is %d", exchange, len(m.Identifiers[exchange])))
+		log.Debug(fmt.Sprintf("The number of symbols in %s is %d", exchange, n))
 	}
+	return identifiers, nil
 }
 
-// UpdateIndexSymbols calls the ListIndexSymbols endpoint, convert the index symbols to the Identifiers
-// and store them to the Identifiers map.
-func (m *ManagerImpl) UpdateIndexSymbols(ctx context.Context) {
-	for _, indexGroup := range m.TargetIndexGroups {
-		resp, err := m.APIClient.ListIndexSymbols(ctx, indexGroup)
+// IndexIdentifierLister lists the identifiers of the index symbols in the target index groups.
+type IndexIdentifierLister struct {
+	APIClient         api.Client
+	TargetIndexGroups []string
+	NotQuoteStockList map[string]struct{}
+}
 
-		// if ListSymbols API returns an error, don't update the target symbols
+// ListSymbols calls the ListIndexSymbols endpoint for each target index group
+// and converts the index symbols to the identifiers.
+func (l *IndexIdentifierLister) ListSymbols(ctx context.Context) ([]string, error) {
+	var identifiers []string
+	for _, indexGroup := range l.TargetIndexGroups {
+		resp, err := l.APIClient.ListIndexSymbols(ctx, indexGroup)
+
+		// if ListIndexSymbols API returns an error, don't update the target symbols
 		if err != nil || resp.Outcome != api.SuccessOutcome {
-			log.Error("UpdateIndexSymbols err=%v, API response=%v", err, resp)
-			return
+			return nil, fmt.Errorf("list index symbols of %s. err=%v, API response=%v", indexGroup, err, resp)
 		}
 
-		// convert the symbol strings (i.e. "1234") to the identifier strings (i.e. "1234.XTKS") and store them to the map
-		var identifiers []string
+		// convert the symbol strings (i.e. "1234") to the identifier strings (i.e. "1234.XTKS")
+		n := 0
 		for _, index := range resp.ArrayOfIndex {
 			symbol := index.Symbol
-			if _, found := m.NotQuoteStockList[symbol]; found {
+			if _, found := l.NotQuoteStockList[symbol]; found {
 				// ignore symbols in not_quote_stock_list
 				continue
 			}
 
 			if symbol != "" {
-				identifier := fmt.Sprintf("%s.%s", index.Symbol, indexGroup)
-				identifiers = append(identifiers, identifier)
+				identifiers = append(identifiers, fmt.Sprintf("%s.%s", symbol, indexGroup))
+				n++
 			}
 		}
-
-		// update target index symbols for the index group
-		m.IndexIdentifiers[indexGroup] = identifiers
-		log.Debug(fmt.Sprintf("Updated index symbols. The number of index symbols in %s is %d",
-			indexGroup, len(m.IndexIdentifiers[indexGroup])))
+		log.Debug(fmt.Sprintf("The number of index symbols in %s is %d", indexGroup, n))
 	}
+	return identifiers, nil
 }
@@ -38,28 +38,21 @@ func (mac *MockListSymbolsAPIClient) ListSymbols(_ context.Context, exchange str
 	return api.ListSymbolsResponse{}, nil
 }
 
-func TestManagerImpl_UpdateSymbols(t *testing.T) {
+func TestManagerImpl_Update(t *testing.T) {
 	t.Parallel()
 	// --- given ---
-	SUT := ManagerImpl{
-		APIClient:       &MockListSymbolsAPIClient{},
-		TargetExchanges: []string{"XTKS", "XJAS"},
-		Identifiers:     map[string][]string{},
-	}
+	SUT := NewManager(&MockListSymbolsAPIClient{}, []string{"XTKS", "XJAS"}, nil, []string{"5678"})
 
 	// --- when ---
-	SUT.UpdateSymbols(context.Background())
+	SUT.Update(context.Background())
 
 	// --- then ---
-	expectedIdentifiers := map[string][]string{
-		"XTKS": {"1234.XTKS", "5678.XTKS"},
-		"XJAS": {"9012.XJAS"},
-	}
+	expectedIdentifiers := []string{"1234.XTKS", "9012.XJAS"}
 
 	if !reflect.DeepEqual(
-		SUT.Identifiers,
+		SUT.GetAllIdentifiers(),
 		expectedIdentifiers,
 	) {
-		t.Errorf("Identifier: want=%v, got=%v", expectedIdentifiers, SUT.Identifiers)
+		t.Errorf("Identifier: want=%v, got=%v", expectedIdentifiers, SUT.GetAllIdentifiers())
 	}
 }
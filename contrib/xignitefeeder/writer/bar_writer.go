@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	feederwriter "github.com/alpacahq/marketstore/v4/contrib/feeder/writer"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/api"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 	"github.com/alpacahq/marketstore/v4/utils/log"
@@ -18,7 +19,7 @@ type BarWriter interface {
 
 // BarWriterImpl is an implementation of the BarWriter interface.
 type BarWriterImpl struct {
-	MarketStoreWriter MarketStoreWriter
+	MarketStoreWriter feederwriter.MarketStoreWriter
 	Timeframe         string
 	// BarWriterImpl writes data with the timezone
 	Timezone *time.Location
@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	feederwriter "github.com/alpacahq/marketstore/v4/contrib/feeder/writer"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/api"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
@@ -17,7 +18,7 @@ type QuotesRangeWriter interface {
 
 // QuotesRangeWriterImpl is an implementation of the QuotesRangeWriter interface.
 type QuotesRangeWriterImpl struct {
-	MarketStoreWriter MarketStoreWriter
+	MarketStoreWriter feederwriter.MarketStoreWriter
 	Timeframe         string
 }
 
@@ -7,17 +7,22 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/backfill"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/markettime"
+	"github.com/alpacahq/marketstore/v4/contrib/feeder/timer"
+	feederwriter "github.com/alpacahq/marketstore/v4/contrib/feeder/writer"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/api"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/configs"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/feed"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/symbols"
-	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/timer"
 	"github.com/alpacahq/marketstore/v4/contrib/xignitefeeder/writer"
 	"github.com/alpacahq/marketstore/v4/plugins/bgworker"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
 
+var jst = time.FixedZone("Asia/Tokyo", 9*60*60)
+
 // NewBgWorker returns the new instance of XigniteFeeder.
 // See configs.Config for the details of available configurations.
 // nolint:deadcode // used by plugin
@@ -40,14 +45,16 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 	})
 
 	// init Market Time Checker
-	var timeChecker feed.MarketTimeChecker
-	timeChecker = feed.NewDefaultMarketTimeChecker(
+	var timeChecker markettime.MarketTimeChecker
+	timeChecker = markettime.NewDefaultMarketTimeChecker(
+		jst,
 		config.ClosedDaysOfTheWeek,
 		config.ClosedDays,
-		config.OpenTime,
-		config.CloseTime)
+		clockInJST(config.OpenTime),
+		// CloseTime has been exclusive for the xignite feeder while it is inclusive in markettime
+		clockInJST(config.CloseTime)-time.Nanosecond)
 	if config.OffHoursSchedule != "" {
-		scheduleMin, err := feed.ParseSchedule(config.OffHoursSchedule)
+		scheduleMin, err := markettime.ParseSchedule(config.OffHoursSchedule)
 		if err != nil {
 			return nil, fmt.Errorf("parse off_hours_schedule %s: %w", config.OffHoursSchedule, err)
 		}
@@ -55,7 +62,7 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 			"The data will be retrieved at %s [minute] even when the market is closed.",
 			config.OffHoursSchedule, config.OffHoursSchedule),
 		)
-		timeChecker = feed.NewScheduledMarketTimeChecker(
+		timeChecker = markettime.NewScheduledMarketTimeChecker(
 			timeChecker,
 			scheduleMin,
 		)
@@ -68,35 +75,36 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 	// every day
 	sm := symbols.NewManager(apiClient, config.Exchanges, config.IndexGroups, config.NotQuoteStockList)
 	sm.Update(ctx)
-	timer.RunEveryDayAt(ctx, config.UpdateTime, sm.Update)
+	jobs := []timer.Job{{At: config.UpdateTime, Run: sm.Update}}
 	log.Info("updated symbols in the target exchanges")
 
 	// init Quotes Writer & QuotesRange Writer
 	var msqw writer.QuotesWriter = writer.QuotesWriterImpl{
-		MarketStoreWriter: &writer.MarketStoreWriterImpl{},
+		MarketStoreWriter: &feederwriter.MarketStoreWriterImpl{},
 		Timeframe:         config.Timeframe,
 		Timezone:          utils.InstanceConfig.Timezone,
 	}
 	var msqrw writer.QuotesRangeWriter = &writer.QuotesRangeWriterImpl{
-		MarketStoreWriter: &writer.MarketStoreWriterImpl{},
+		MarketStoreWriter: &feederwriter.MarketStoreWriterImpl{},
 		Timeframe:         config.Backfill.Timeframe,
 	}
 
 	// init QuotesRangeWriter to backfill daily chart data every day
 	if config.Backfill.Enabled {
-		bf := feed.NewBackfill(sm, apiClient, msqw, msqrw, time.Time(config.Backfill.Since))
-		timer.RunEveryDayAt(ctx, config.UpdateTime, bf.Update)
-		log.Info("backfilled daily chart in the target exchanges")
+		bf := feed.NewBackfill(sm, apiClient, msqw, msqrw, time.Time(config.Backfill.Since),
+			backfill.NewLimiter(config.Backfill.RequestsPerSecond),
+		)
+		jobs = append(jobs, timer.Job{At: config.UpdateTime, Run: bf.Update})
 	}
 
 	if config.RecentBackfill.Enabled {
 		msbw := &writer.BarWriterImpl{
-			MarketStoreWriter: &writer.MarketStoreWriterImpl{},
+			MarketStoreWriter: &feederwriter.MarketStoreWriterImpl{},
 			Timeframe:         config.RecentBackfill.Timeframe,
 			Timezone:          utils.InstanceConfig.Timezone,
 		}
 		rbf := feed.NewRecentBackfill(sm, timeChecker, apiClient, msbw, config.RecentBackfill.Days)
-		timer.RunEveryDayAt(ctx, config.UpdateTime, rbf.Update)
+		jobs = append(jobs, timer.Job{At: config.UpdateTime, Run: rbf.Update})
 	}
 
 	return &feed.Worker{
@@ -105,7 +113,14 @@ func NewBgWorker(conf map[string]interface{}) (bgworker.BgWorker, error) {
 		SymbolManager:     sm,
 		QuotesWriter:      msqw,
 		Interval:          config.Interval,
+		Jobs:              jobs,
 	}, nil
 }
 
+// clockInJST returns the duration from 00:00 in JST to the time of day of t.
+func clockInJST(t time.Time) time.Duration {
+	h, m, _ := t.In(jst).Clock()
+	return markettime.Clock(h, m)
+}
+
 func main() {}
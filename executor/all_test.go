@@ -1,6 +1,7 @@
 package executor_test
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -321,6 +322,197 @@ func TestFileRead(t *testing.T) {
 	}
 }
 
+func TestReadContext(t *testing.T) {
+	_, _, metadata := setup(t)
+
+	newReader := func(ctx context.Context) (*executor.Reader, error) {
+		q := NewQuery(metadata.CatalogDir)
+		q.AddTargetKey(NewTimeBucketKey("NZDUSD/1Min/OHLC"))
+		q.SetRange(
+			time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2002, time.December, 31, 23, 59, 59, 0, time.UTC),
+		)
+		parsed, err := q.ParseContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return executor.NewReader(parsed)
+	}
+
+	// the size of the whole result
+	reader, err := newReader(context.Background())
+	require.Nil(t, err)
+	csm, err := reader.Read()
+	require.Nil(t, err)
+	cs := csm[*NewTimeBucketKey("NZDUSD/1Min/OHLC")]
+	require.NotNil(t, cs)
+	rows := cs.Len()
+	// Epoch and OHLC
+	bytes := int64(rows * (8 + 4*4))
+
+	tests := map[string]struct {
+		limits  executor.ResultLimits
+		wantErr error
+	}{
+		"no limit": {
+			limits:  executor.ResultLimits{},
+			wantErr: nil,
+		},
+		"the result is within the limits": {
+			limits:  executor.ResultLimits{MaxRows: rows, MaxBytes: bytes},
+			wantErr: nil,
+		},
+		"too many rows": {
+			limits:  executor.ResultLimits{MaxRows: rows - 1},
+			wantErr: executor.ErrResultTooLarge,
+		},
+		"too many bytes": {
+			limits:  executor.ResultLimits{MaxBytes: bytes - 1},
+			wantErr: executor.ErrResultTooLarge,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			reader, err := newReader(context.Background())
+			require.Nil(t, err)
+			reader.SetResultLimits(tt.limits)
+			csm, err := reader.ReadContext(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, rows, csm[*NewTimeBucketKey("NZDUSD/1Min/OHLC")].Len())
+		})
+	}
+
+	// --- when the client of the query has gone away ---
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, err = newReader(ctx)
+	require.Nil(t, err)
+	cancel()
+
+	// --- then the query stops ---
+	_, err = reader.ReadContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = newReader(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// readAll reads the 1Min OHLC time buckets of all the symbols with the parallelism and the read mode.
+func readAll(tb testing.TB, catDir *Directory, parallelism int, mode utils.ReadMode, limit *RowLimit,
+) ColumnSeriesMap {
+	tb.Helper()
+
+	q := NewQuery(catDir)
+	q.AddRestriction("Timeframe", "1Min")
+	q.AddRestriction("AttributeGroup", "OHLC")
+	if limit != nil {
+		q.SetRowLimit(limit.Direction, int(limit.Number))
+	}
+	parsed, err := q.Parse()
+	require.Nil(tb, err)
+	reader, err := executor.NewReader(parsed)
+	require.Nil(tb, err)
+	reader.SetParallelism(parallelism)
+	reader.SetReadMode(mode)
+	csm, err := reader.Read()
+	require.Nil(tb, err)
+	return csm
+}
+
+func TestParallelRead(t *testing.T) {
+	_, _, metadata := setup(t)
+
+	tests := map[string]struct {
+		limit *RowLimit
+	}{
+		"all the years are read in parallel": {
+			limit: nil,
+		},
+		"the first rows are read in order": {
+			limit: &RowLimit{Direction: FIRST, Number: 1000},
+		},
+		"the last rows are read backward": {
+			limit: &RowLimit{Direction: LAST, Number: 1000},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			sequential := readAll(t, metadata.CatalogDir, 1, utils.ReadModePread, tt.limit)
+			parallel := readAll(t, metadata.CatalogDir, 8, utils.ReadModePread, tt.limit)
+
+			require.Len(t, sequential, 3)
+			assert.Equal(t, sequential, parallel)
+			for _, cs := range parallel {
+				epochs := cs.GetEpoch()
+				assert.True(t, sort.SliceIsSorted(epochs, func(i, j int) bool { return epochs[i] < epochs[j] }))
+				if tt.limit != nil {
+					assert.Equal(t, int(tt.limit.Number), cs.Len())
+				}
+			}
+		})
+	}
+}
+
+func TestReadModeMmap(t *testing.T) {
+	_, _, metadata := setup(t)
+
+	// --- when the fixed length records are read through the memory mappings ---
+	for _, limit := range []*RowLimit{nil, {Direction: FIRST, Number: 1000}, {Direction: LAST, Number: 1000}} {
+		pread := readAll(t, metadata.CatalogDir, 8, utils.ReadModePread, limit)
+		mmap := readAll(t, metadata.CatalogDir, 8, utils.ReadModeMmap, limit)
+		// --- then the results are the same as pread ---
+		assert.Equal(t, pread, mmap)
+	}
+	// the mappings are kept for the next reads
+	assert.Equal(t, 9, metadata.CatalogDir.Mappings().Len())
+
+	// --- when a year file of variable length records grows ---
+	tbk := NewTimeBucketKey("TEST-MMAP/1Sec/TICK")
+	dsv := NewDataShapeVector([]string{"Bid", "Ask"}, []EnumElementType{FLOAT32, FLOAT32})
+	tbi := NewTimeBucketInfo(*utils.TimeframeFromString("1Sec"),
+		tbk.GetPathToYearFiles(metadata.CatalogDir.GetPath()), "Test", 2016, dsv, VARIABLE)
+	require.Nil(t, metadata.CatalogDir.AddTimeBucket(tbk, tbi))
+	tbi, err := metadata.CatalogDir.GetLatestTimeBucketInfoFromKey(tbk)
+	require.Nil(t, err)
+	writer, err := executor.NewWriter(metadata.CatalogDir, metadata.WALFile)
+	require.Nil(t, err)
+	ts := time.Date(2016, time.December, 1, 0, 0, 0, 0, time.UTC)
+	writeTicks := func(n int) {
+		for i := 0; i < n; i++ {
+			ts = ts.Add(time.Second)
+			buffer, _ := Serialize([]byte{}, struct {
+				Epoch    int64
+				Bid, Ask float32
+			}{ts.Unix(), float32(i), float32(i + 1)})
+			require.Nil(t, writer.WriteRecords([]time.Time{ts}, buffer, dsv, tbi))
+		}
+		require.Nil(t, metadata.WALFile.FlushToWAL())
+	}
+	readTicks := func(mode utils.ReadMode) int {
+		q := NewQuery(metadata.CatalogDir)
+		q.AddTargetKey(tbk)
+		parsed, err := q.Parse()
+		require.Nil(t, err)
+		reader, err := executor.NewReader(parsed)
+		require.Nil(t, err)
+		reader.SetReadMode(mode)
+		csm, err := reader.Read()
+		require.Nil(t, err)
+		return csm[*tbk].Len()
+	}
+	writeTicks(10)
+	assert.Equal(t, 10, readTicks(utils.ReadModeMmap))
+	writeTicks(20)
+
+	// --- then the file is mapped again to read the new records ---
+	assert.Equal(t, 30, readTicks(utils.ReadModeMmap))
+	assert.Equal(t, 30, readTicks(utils.ReadModePread))
+}
+
 func TestDelete(t *testing.T) {
 	_, _, metadata := setup(t)
 
@@ -739,6 +931,126 @@ func TestWriter(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestWriteDecimal(t *testing.T) {
+	t.Parallel()
+	rootDir := t.TempDir()
+	cfg := utils.NewDefaultConfig(rootDir)
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+
+	tbk := NewTimeBucketKey("TEST/1Min/OHLC")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	// --- write decimal values, and float values to be coerced to the decimal scale ---
+	cs := NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{epoch})
+	cs.AddColumn("Price", []Decimal64{1000001})
+	cs.SetScale("Price", 4)
+	csm := NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	require.Nil(t, c.GetWriter().WriteCSM(csm, false))
+
+	cs = NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{epoch + 60})
+	cs.AddColumn("Price", []float32{100.25})
+	csm = NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	require.Nil(t, c.GetWriter().WriteCSM(csm, false))
+	require.Nil(t, c.GetInitWALFile().FlushToWAL())
+
+	// --- the scale is persisted in the file header ---
+	catDir, err := NewDirectory(rootDir)
+	require.Nil(t, err)
+	tbi, err := catDir.GetLatestTimeBucketInfoFromKey(tbk)
+	require.Nil(t, err)
+	require.Equal(t, []DataShape{{Name: "Price", Type: DECIMAL64, Scale: 4}}, tbi.GetDataShapes())
+
+	q := NewQuery(catDir)
+	q.AddTargetKey(tbk)
+	pr, err := q.Parse()
+	require.Nil(t, err)
+	rd, err := executor.NewReader(pr)
+	require.Nil(t, err)
+	result, err := rd.Read()
+	require.Nil(t, err)
+	require.Equal(t, []Decimal64{1000001, 1002500}, result[*tbk].GetColumn("Price"))
+	require.Equal(t, int8(4), result[*tbk].GetScale("Price"))
+}
+
+func TestWriteNullable(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		isVariableLength bool
+	}{
+		"ok/ fixed length records":    {isVariableLength: false},
+		"ok/ variable length records": {isVariableLength: true},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rootDir := t.TempDir()
+			cfg := utils.NewDefaultConfig(rootDir)
+			cfg.BackgroundSync = false
+			c := di.NewContainer(cfg)
+
+			tbk := NewTimeBucketKey("TEST/1Min/OHLCV")
+			epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+			// --- Volume=0 and Volume=null are written ---
+			cs := NewColumnSeries()
+			cs.AddColumn("Epoch", []int64{epoch, epoch + 60, epoch + 120})
+			cs.AddColumn("Close", []float32{1, 2, 3})
+			cs.AddColumn("Volume", []int64{0, 0, 10})
+			cs.SetNullMask("Volume", []bool{false, true, false})
+			if tt.isVariableLength {
+				cs.AddColumn("Nanoseconds", []int32{0, 0, 0})
+			}
+			csm := NewColumnSeriesMap()
+			csm.AddColumnSeries(*tbk, cs)
+			require.Nil(t, c.GetWriter().WriteCSM(csm, tt.isVariableLength))
+
+			// --- a missing nullable column is written as null ---
+			cs = NewColumnSeries()
+			cs.AddColumn("Epoch", []int64{epoch + 180})
+			cs.AddColumn("Close", []float32{4})
+			if tt.isVariableLength {
+				cs.AddColumn("Nanoseconds", []int32{0})
+			}
+			csm = NewColumnSeriesMap()
+			csm.AddColumnSeries(*tbk, cs)
+			require.Nil(t, c.GetWriter().WriteCSM(csm, tt.isVariableLength))
+			require.Nil(t, c.GetInitWALFile().FlushToWAL())
+
+			// --- the nullability is persisted in the file header ---
+			catDir, err := NewDirectory(rootDir)
+			require.Nil(t, err)
+			tbi, err := catDir.GetLatestTimeBucketInfoFromKey(tbk)
+			require.Nil(t, err)
+			require.Equal(t, []DataShape{
+				{Name: "Close", Type: FLOAT32},
+				{Name: "Volume", Type: INT64, Nullable: true},
+				{Name: NullMaskColumn, Type: UINT64},
+			}, tbi.GetDataShapes())
+
+			q := NewQuery(catDir)
+			q.AddTargetKey(tbk)
+			pr, err := q.Parse()
+			require.Nil(t, err)
+			rd, err := executor.NewReader(pr)
+			require.Nil(t, err)
+			result, err := rd.Read()
+			require.Nil(t, err)
+			require.False(t, result[*tbk].Exists(NullMaskColumn))
+			require.Equal(t, []int64{0, 0, 10, 0}, result[*tbk].GetColumn("Volume"))
+			require.Equal(t, []bool{false, true, false, true}, result[*tbk].GetNullMask("Volume"))
+			require.Nil(t, result[*tbk].GetNullMask("Close"))
+		})
+	}
+}
+
 /*
 	===================== Helper Functions =================================
 */
@@ -831,3 +1143,177 @@ func nearestSecond(seconds int64, nanos int32) int64 {
 	}
 	return seconds
 }
+
+func TestDeleteRange(t *testing.T) {
+	t.Parallel()
+
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	newYear := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		isVariableLength bool
+		times            []time.Time
+		start, end       time.Time
+		wantDeleted      int
+		wantTimes        []time.Time
+	}{
+		"ok/ fixed length records": {
+			isVariableLength: false,
+			times:            []time.Time{epoch, epoch.Add(time.Minute), epoch.Add(2 * time.Minute), epoch.Add(3 * time.Minute)},
+			start:            epoch.Add(30 * time.Second),
+			end:              epoch.Add(2 * time.Minute),
+			wantDeleted:      2,
+			wantTimes:        []time.Time{epoch, epoch.Add(3 * time.Minute)},
+		},
+		"ok/ variable length records are deleted by nanoseconds and the rest in the intervals are kept": {
+			isVariableLength: true,
+			times: []time.Time{
+				epoch, epoch.Add(10*time.Second + 500), epoch.Add(30 * time.Second),
+				epoch.Add(time.Minute + 100), epoch.Add(90 * time.Second),
+			},
+			start:       epoch.Add(10 * time.Second),
+			end:         epoch.Add(time.Minute + 50),
+			wantDeleted: 2,
+			wantTimes:   []time.Time{epoch, epoch.Add(time.Minute + 100), epoch.Add(90 * time.Second)},
+		},
+		"ok/ records in several year files are deleted": {
+			isVariableLength: false,
+			times:            []time.Time{newYear.Add(-time.Minute), newYear, newYear.Add(time.Minute)},
+			start:            newYear.Add(-time.Minute),
+			end:              newYear,
+			wantDeleted:      2,
+			wantTimes:        []time.Time{newYear.Add(time.Minute)},
+		},
+		"ok/ nothing is deleted when start is after end": {
+			isVariableLength: false,
+			times:            []time.Time{epoch, epoch.Add(time.Minute)},
+			start:            epoch.Add(time.Minute),
+			end:              epoch,
+			wantDeleted:      0,
+			wantTimes:        []time.Time{epoch, epoch.Add(time.Minute)},
+		},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rootDir := t.TempDir()
+			cfg := utils.NewDefaultConfig(rootDir)
+			cfg.BackgroundSync = false
+			c := di.NewContainer(cfg)
+
+			tbk := NewTimeBucketKey("TEST/1Min/TICK")
+			epochs := make([]int64, len(tt.times))
+			nanos := make([]int32, len(tt.times))
+			prices := make([]float32, len(tt.times))
+			for i, ts := range tt.times {
+				epochs[i], nanos[i], prices[i] = ts.Unix(), int32(ts.Nanosecond()), float32(i)
+			}
+			cs := NewColumnSeries()
+			cs.AddColumn("Epoch", epochs)
+			cs.AddColumn("Price", prices)
+			if tt.isVariableLength {
+				cs.AddColumn("Nanoseconds", nanos)
+			}
+			csm := NewColumnSeriesMap()
+			csm.AddColumnSeries(*tbk, cs)
+			writer, err := executor.NewWriter(c.GetCatalogDir(), c.GetInitWALFile())
+			require.Nil(t, err)
+			require.Nil(t, writer.WriteCSM(csm, tt.isVariableLength))
+
+			// --- when ---
+			deleted, err := writer.DeleteRange(tbk, tt.start, tt.end)
+
+			// --- then ---
+			require.Nil(t, err)
+			require.Equal(t, tt.wantDeleted, deleted)
+
+			q := NewQuery(c.GetCatalogDir())
+			q.AddTargetKey(tbk)
+			pr, err := q.Parse()
+			require.Nil(t, err)
+			rd, err := executor.NewReader(pr)
+			require.Nil(t, err)
+			result, err := rd.Read()
+			require.Nil(t, err)
+			gotTimes, err := result[*tbk].GetTime()
+			require.Nil(t, err)
+			require.Len(t, gotTimes, len(tt.wantTimes))
+			for i := range tt.wantTimes {
+				// the nanoseconds of variable length records are stored in the precision of the interval ticks
+				require.WithinDuration(t, tt.wantTimes[i], gotTimes[i], 20*time.Nanosecond)
+			}
+		})
+	}
+}
+
+func TestDeleteRange_Transaction(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	rootDir := t.TempDir()
+	cfg := utils.NewDefaultConfig(rootDir)
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	writer := c.GetDefaultWriter()
+	tbk := NewTimeBucketKey("TEST/1Min/TICK")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	_, err := writer.WriteCSMWithOptions(tickCSM(tbk,
+		[]int64{epoch, epoch + 30, epoch + 60}, []int32{0, 0, 0}, []int32{1, 1, 1}, []float32{1, 2, 3},
+	), true, executor.WriteOptions{})
+	require.Nil(t, err)
+	// a record that is queued but not flushed yet is not cleared by the deletion
+	_, err = writer.WriteCSMWithOptions(tickCSM(tbk, []int64{epoch + 10}, []int32{0}, []int32{1}, []float32{4}),
+		true, executor.WriteOptions{QueueOnly: true})
+	require.Nil(t, err)
+	sender := &fakeReplicationSender{}
+	c.GetInitWALFile().ReplicationSender = sender
+
+	// --- when ---
+	deleted, err := writer.DeleteRange(tbk, time.Unix(epoch+30, 0), time.Unix(epoch+60, 0))
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, 2, deleted)
+	// the queued record is flushed before the deletion. Then the second interval is cleared,
+	// and the first interval is cleared and its kept records are written back by the same command
+	require.Len(t, sender.sent, 2)
+	_, wtSets := executor.ParseTGData(sender.sent[1], rootDir)
+	require.Len(t, wtSets, 3)
+	assert.True(t, wtSets[0].Buffer.IsDeletion())
+	assert.True(t, wtSets[1].Buffer.IsDeletion())
+	assert.Equal(t, EpochToIndex(epoch, time.Minute), wtSets[2].Buffer.Index())
+	written := readBucket(t, rootDir, tbk)
+	assert.Equal(t, []float32{1, 4}, written.GetColumn("Bid"))
+}
+
+func TestDeleteRange_NoBucket(t *testing.T) {
+	t.Parallel()
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	writer, err := executor.NewWriter(c.GetCatalogDir(), c.GetInitWALFile())
+	require.Nil(t, err)
+
+	_, err = writer.DeleteRange(NewTimeBucketKey("TEST/1Min/TICK"), time.Unix(0, 0), time.Unix(100, 0))
+	require.NotNil(t, err)
+}
+
+func BenchmarkRead(b *testing.B) {
+	rootDir := b.TempDir()
+	MakeDummyCurrencyDir(rootDir, true, false)
+	cfg := utils.NewDefaultConfig(rootDir)
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+
+	for _, mode := range []utils.ReadMode{utils.ReadModePread, utils.ReadModeMmap} {
+		for _, parallelism := range []int{1, 2, 4, 8} {
+			mode, parallelism := mode, parallelism
+			b.Run(fmt.Sprintf("mode=%s/parallelism=%d", mode, parallelism), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					readAll(b, c.GetCatalogDir(), parallelism, mode, nil)
+				}
+			})
+		}
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"io"
 	"os"
 	"sort"
+	"time"
 
+	"github.com/alpacahq/marketstore/v4/executor/wal"
 	"github.com/alpacahq/marketstore/v4/planner"
 	utilsio "github.com/alpacahq/marketstore/v4/utils/io"
 	"github.com/alpacahq/marketstore/v4/utils/log"
@@ -95,7 +97,8 @@ func deleteInner(fp *ioFilePlan, recordLen int32) (err error) {
 		This will preserve the existing holes in the data area at the expense of
 		a potentially large number of file seeks
 	*/
-	bufferSize := int(fp.Length + int64(recordLen))
+	// the file plan already includes the record at the end of the range
+	bufferSize := int(fp.Length)
 	buffer := make([]byte, bufferSize)
 	n, err := f.Read(buffer)
 	if err != nil || n != bufferSize {
@@ -126,3 +129,173 @@ func deleteInner(fp *ioFilePlan, recordLen int32) (err error) {
 	buffer = nil
 	return nil
 }
+
+// DeleteRange deletes the records of the time bucket in the time range [start, end] through the WAL,
+// so that the deletion survives crashes and is replicated, and returns the number of deleted records.
+// Unlike Deleter, which zeroes the data files directly, the deletion is written as WriteCommands of
+// index 0 (see wal.OffsetIndexBuffer.IsDeletion):
+//   - FIXED: an empty record is written at the index of each deleted record
+//   - VARIABLE: the intervals that have deleted records are cleared. The intervals that also have records
+//     out of the range are replaced with those records by a WriteCommand with Replace instead,
+//     so that the clear and the rewrite are in the same transaction group
+//
+// The range is read and deleted by year file, so that only the records of a year are in memory at once.
+// The writes to the symbol are blocked while the records are read and the deletion is queued,
+// so that no record written meanwhile is cleared.
+func (w *Writer) DeleteRange(tbk *utilsio.TimeBucketKey, start, end time.Time) (deleted int, err error) {
+	if start.After(end) {
+		return 0, nil
+	}
+	tbk, unlock := w.lockSymbol(tbk)
+	years, err := w.yearFiles(tbk)
+	if err != nil {
+		unlock()
+		return 0, err
+	}
+	// the records in the WAL need to be in the primary storage to be read
+	w.walFile.WaitFlush()
+
+	for _, tbi := range years {
+		yearStart := time.Date(int(tbi.Year), time.January, 1, 0, 0, 0, 0, time.UTC)
+		yearEnd := yearStart.AddDate(1, 0, 0).Add(-time.Nanosecond)
+		if yearEnd.Before(start) || yearStart.After(end) {
+			continue
+		}
+		n, err2 := w.deleteRangeInYear(tbk, tbi, start, end, yearStart, yearEnd)
+		if err2 != nil {
+			unlock()
+			// the deletion queued for the previous years is flushed with the next writes
+			return deleted, err2
+		}
+		deleted += n
+	}
+	unlock()
+
+	if deleted > 0 {
+		// wait for the flush, so that the deletion is visible to the readers and the flush listeners,
+		// e.g. the query cache, when this returns
+		w.walFile.WaitFlush()
+	}
+	return deleted, nil
+}
+
+// yearFiles returns the year files of the time bucket in the ascending order of the year.
+func (w *Writer) yearFiles(tbk *utilsio.TimeBucketKey) ([]*utilsio.TimeBucketInfo, error) {
+	tbi, err := w.rootCatDir.GetLatestTimeBucketInfoFromKey(tbk)
+	if err != nil {
+		return nil, fmt.Errorf("time bucket %s does not exist: %w", tbk.String(), err)
+	}
+	subDir, err := w.rootCatDir.GetOwningSubDirectory(tbi.Path)
+	if err != nil {
+		return nil, fmt.Errorf("get the directory of %s: %w", tbk.String(), err)
+	}
+	years := subDir.GetTimeBucketInfoSlice()
+	sort.Slice(years, func(i, j int) bool { return years[i].Year < years[j].Year })
+	return years, nil
+}
+
+// deleteRangeInYear queues the deletion of the records in the time range [start, end]
+// from the year file of the time bucket, and returns the number of deleted records.
+// The caller must hold the symbol lock.
+func (w *Writer) deleteRangeInYear(tbk *utilsio.TimeBucketKey, tbi *utilsio.TimeBucketInfo,
+	start, end, yearStart, yearEnd time.Time,
+) (deleted int, err error) {
+	// read one more interval on both sides to get all the records in the first and last intervals.
+	// The intervals don't span year files, so the read is limited to the year
+	tf := tbi.GetTimeframe()
+	queryStart, queryEnd := start.Add(-tf), end.Add(tf)
+	if queryStart.Before(yearStart) {
+		queryStart = yearStart
+	}
+	if queryEnd.Before(end) || queryEnd.After(yearEnd) {
+		queryEnd = yearEnd
+	}
+	q := planner.NewQuery(w.rootCatDir)
+	q.AddTargetKey(tbk)
+	q.SetRange(queryStart, queryEnd)
+	parsed, err := q.Parse()
+	if err != nil {
+		return 0, fmt.Errorf("parse the query to delete records from %s: %w", tbk.String(), err)
+	}
+	reader, err := NewReader(parsed)
+	if err != nil {
+		return 0, fmt.Errorf("create a reader to delete records from %s: %w", tbk.String(), err)
+	}
+	csm, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("read records to delete from %s: %w", tbk.String(), err)
+	}
+	cs := csm[*tbk]
+	if cs == nil || cs.Len() == 0 {
+		return 0, nil
+	}
+	times, err := cs.GetTime()
+	if err != nil {
+		return 0, err
+	}
+
+	isVariable := tbi.GetRecordType() == utilsio.VARIABLE
+	recordLen := tbi.GetRecordLength()
+	deletedIntervals := map[int64]bool{}
+	var ordered []int64
+	for _, t := range times {
+		if t.Before(start) || t.After(end) {
+			continue
+		}
+		deleted++
+		index := utilsio.TimeToIndex(t, tf)
+		if !deletedIntervals[index] {
+			deletedIntervals[index] = true
+			ordered = append(ordered, index)
+		}
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	// the records that are out of the range in the deleted intervals of a variable-length time bucket
+	var kept []int
+	keptIntervals := map[int64]bool{}
+	if isVariable {
+		for i, t := range times {
+			index := utilsio.TimeToIndex(t, tf)
+			if deletedIntervals[index] && (t.Before(start) || t.After(end)) {
+				kept = append(kept, i)
+				keptIntervals[index] = true
+			}
+		}
+	}
+
+	for _, index := range ordered {
+		if keptIntervals[index] {
+			continue
+		}
+		var data []byte
+		if !isVariable {
+			// an empty record without the Epoch column
+			data = make([]byte, recordLen-8)
+		}
+		offset := utilsio.IndexToOffset(index, recordLen)
+		w.walFile.QueueWriteCommand(w.walFile.WriteCommand(tbi.GetRecordType(), tbi.Path,
+			int(tbi.GetVariableRecordLength()), offset, 0, data, tbi.GetDataShapesWithEpoch()))
+	}
+	if len(kept) > 0 {
+		// the records are written back without the validation
+		if err = w.writeColumnSeries(*tbk, cs.SelectRows(kept), true, true); err != nil {
+			return 0, fmt.Errorf("write back the records out of the range to %s: %w", tbk.String(), err)
+		}
+	}
+	return deleted, nil
+}
+
+// QueueDeletion queues the deletion in the write transaction set to the WAL without flushing it.
+// It's used by replicas to apply the deletions replicated from the master in the same transaction group
+// as the other writes replicated with them, e.g. the records of a replace.
+func (w *Writer) QueueDeletion(wtSet *wal.WTSet) error {
+	if !wtSet.Buffer.IsDeletion() {
+		return fmt.Errorf("write transaction set of %s is not a deletion", wtSet.FilePath)
+	}
+	w.walFile.QueueWriteCommand(w.walFile.WriteCommand(wtSet.RecordType, wtSet.FilePath, wtSet.VarRecLen,
+		wtSet.Buffer.Offset(), 0, wtSet.Buffer.Payload(), wtSet.DataShapes))
+	return nil
+}
@@ -2,6 +2,7 @@ package executor
 
 import (
 	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 )
 
 var ThisInstance *InstanceMetadata
@@ -9,6 +10,8 @@ var ThisInstance *InstanceMetadata
 type InstanceMetadata struct {
 	CatalogDir *catalog.Directory
 	WALFile    *WALFileType
+	// Validator validates the writes by the WriteCSM function, or nil if the writes are not validated
+	Validator *validation.Validator
 }
 
 func NewInstanceSetup(catalogDir *catalog.Directory, walfile *WALFileType) *InstanceMetadata {
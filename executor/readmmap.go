@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// dataFile is a year file opened to read, or the memory mapping of it in the mmap read mode.
+type dataFile interface {
+	io.ReadSeeker
+	io.ReaderAt
+}
+
+// openDataFile opens the year file, through its memory mapping if mappings is not nil.
+// The returned function must be called to close the file or release the mapping.
+func openDataFile(mappings *catalog.FileMappings, filePath string) (f dataFile, closeFile func(), err error) {
+	if mappings != nil {
+		m, err := mappings.Map(filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return bytes.NewReader(m.Data), m.Release, nil
+	}
+
+	const readWriteAll = 0o666
+	osFile, err := os.OpenFile(filePath, os.O_RDONLY, readWriteAll)
+	if err != nil {
+		return nil, nil, err
+	}
+	return osFile, func() {
+		if err2 := osFile.Close(); err2 != nil {
+			log.Error("failed to close datafile",
+				zap.String("filepath", filePath), zap.Error(err2),
+			)
+		}
+	}, nil
+}
+
+// readForwardMapped is readForward in the mmap read mode. The records are packed into the final buffer
+// directly from the mapping, without the intermediate copy to the read buffer.
+func (ex *ioExec) readForwardMapped(finalBuffer []byte, fp *ioFilePlan, bytesToRead int32) (
+	resultBuffer []byte, finished bool, err error,
+) {
+	m, err := ex.mappings.Map(fp.FullPath)
+	if err != nil {
+		log.Error("Read: mapping %s\n%s", fp.FullPath, err)
+		return finalBuffer, false, err
+	}
+	defer m.Release()
+
+	// the file can be shorter than the plan, e.g. a year file of variable length records being written
+	start, end := fp.Offset, fp.Offset+fp.Length
+	if end > int64(len(m.Data)) {
+		end = int64(len(m.Data))
+	}
+	if start < end {
+		region := m.Data[start:end]
+		if finalBuffer == nil {
+			finalBuffer = make([]byte, 0, len(region))
+		}
+		ex.packRecords(&finalBuffer, region, fp)
+	}
+	if int32(len(finalBuffer)) >= bytesToRead {
+		return finalBuffer[:bytesToRead], true, nil
+	}
+	return finalBuffer, false, nil
+}
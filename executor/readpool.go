@@ -0,0 +1,108 @@
+package executor
+
+import (
+	"context"
+	"sync"
+
+	utilsio "github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// readBuffers are the buffers of packingReader, one per worker.
+type readBuffers struct {
+	read []byte
+	file []byte
+}
+
+func (r *Reader) newReadBuffers() *readBuffers {
+	return &readBuffers{
+		read: make([]byte, r.readSize),
+		file: make([]byte, r.readSize),
+	}
+}
+
+// readTask reads the files of an IO plan, which are either all the files of a time bucket key
+// or one of the files of a forward scan without a row limit.
+type readTask struct {
+	iop        *IOPlan
+	recordType utilsio.EnumRecordType
+	rowLen     int
+	// result is where the packed rows are stored
+	result *[]byte
+}
+
+// runTasks runs the tasks with a pool of workers, and returns the first error of the tasks.
+// The other tasks are canceled after an error.
+func (r *Reader) runTasks(ctx context.Context, tasks []*readTask) error {
+	workers := r.parallelism
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+	if workers <= 1 {
+		for _, task := range tasks {
+			if err := r.runTask(ctx, task, r.buffers); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	taskCh := make(chan *readTask)
+	for i := 0; i < workers; i++ {
+		buffers := r.buffers
+		if i != 0 {
+			buffers = r.newReadBuffers()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range taskCh {
+				if err := r.runTask(ctx, task, buffers); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+dispatch:
+	for _, task := range tasks {
+		select {
+		case taskCh <- task:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+func (r *Reader) runTask(ctx context.Context, task *readTask, buffers *readBuffers) error {
+	buffer, err := r.read(ctx, task.iop, buffers)
+	if err != nil {
+		return err
+	}
+	rowLength := task.rowLen
+	if task.recordType == utilsio.VARIABLE {
+		buffer = trimResultsToRange(r.pr.Range, task.rowLen, buffer)
+		buffer = trimResultsToLimit(r.pr.Limit, task.rowLen, buffer)
+		rowLength = task.rowLen + epochLenBytes + nanosecLenBytes - intervalTicksLenBytes
+	}
+	if err = r.addResult(int64(len(buffer)/rowLength), int64(len(buffer))); err != nil {
+		return err
+	}
+	*task.result = buffer
+	return nil
+}
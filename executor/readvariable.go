@@ -1,7 +1,7 @@
 package executor
 
 import (
-	"os"
+	"context"
 
 	"github.com/klauspost/compress/snappy"
 
@@ -9,7 +9,11 @@ import (
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
 
-func (r *Reader) readSecondStage(bufMeta []bufferMeta) (rb []byte, err error) {
+// readSecondStage reads the variable length records of the index data. The result is checked against
+// the limits of the Reader while reading only if it's not limited by a number of records,
+// because the records out of the limit are trimmed afterwards.
+func (r *Reader) readSecondStage(ctx context.Context, bufMeta []bufferMeta, unlimited bool,
+) (rb []byte, err error) {
 	/*
 		Here we use the bufFileMap which has index data for each file, then we read
 		the target data into the resultBuffer up to the limitCount number of records
@@ -18,13 +22,21 @@ func (r *Reader) readSecondStage(bufMeta []bufferMeta) (rb []byte, err error) {
 	// resultBuffers for all bufMetas
 	totalBuf := make([]byte, 0)
 	for _, md := range bufMeta {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		if unlimited && varRecLen != 0 {
+			rowLength := varRecLen + epochLenBytes
+			if err = r.checkResult(ctx, int64(len(totalBuf)/rowLength), int64(len(totalBuf))); err != nil {
+				return nil, err
+			}
+		}
 		varRecLen = md.VarRecLen
 		file := md.FullPath
 		indexBuffer := md.Data
 
 		// Open the file to read the data
-		const readWriteAll = 0o666
-		fp, err := os.OpenFile(file, os.O_RDONLY, readWriteAll)
+		fp, closeFile, err := openDataFile(r.mappings(), file)
 		if err != nil {
 			return nil, err
 		}
@@ -61,12 +73,14 @@ func (r *Reader) readSecondStage(bufMeta []bufferMeta) (rb []byte, err error) {
 			buffer := make([]byte, datalen)
 			_, err = fp.ReadAt(buffer, offset)
 			if err != nil {
+				closeFile()
 				return nil, err
 			}
 
 			if !utils.InstanceConfig.DisableVariableCompression {
 				buffer, err = snappy.Decode(nil, buffer)
 				if err != nil {
+					closeFile()
 					return nil, err
 				}
 			}
@@ -87,7 +101,7 @@ func (r *Reader) readSecondStage(bufMeta []bufferMeta) (rb []byte, err error) {
 			rbCursor += len(rbTemp)
 		}
 		rb = rb[:rbCursor]
-		fp.Close()
+		closeFile()
 
 		totalBuf = append(totalBuf, rb...)
 	}
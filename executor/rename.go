@@ -0,0 +1,24 @@
+package executor
+
+import "fmt"
+
+// RenameSymbol moves the data of the symbol to the new symbol, and makes the old symbol an alias of the new one
+// (see catalog.Directory.RenameSymbol). With link, the year files are hard-linked to the new symbol instead,
+// and the old symbol is kept (see catalog.Directory.LinkSymbol).
+// The writes to both symbols are blocked until the rename is done, and the pending writes in the WAL
+// are flushed first, as they are written to the paths of the old symbol.
+func (w *Writer) RenameSymbol(oldSymbol, newSymbol string, link bool) error {
+	unlock := lockSymbols(oldSymbol, newSymbol)
+	defer unlock()
+	w.walFile.WaitFlush()
+	if link {
+		if err := w.rootCatDir.LinkSymbol(oldSymbol, newSymbol); err != nil {
+			return fmt.Errorf("link symbol %s to %s: %w", oldSymbol, newSymbol, err)
+		}
+		return nil
+	}
+	if err := w.rootCatDir.RenameSymbol(oldSymbol, newSymbol); err != nil {
+		return fmt.Errorf("rename symbol %s to %s: %w", oldSymbol, newSymbol, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package executor_test
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func TestWriter_RenameSymbol_ConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	rootDir := t.TempDir()
+	cfg := utils.NewDefaultConfig(rootDir)
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	writer := c.GetDefaultWriter()
+	tbk := io.NewTimeBucketKey("AAPL/1Min/OHLC")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	const (
+		writers            = 4
+		writesPerWriter    = 250
+		writesBeforeRename = 50
+	)
+
+	var wg sync.WaitGroup
+	started := make(chan struct{}, writers)
+	errs := make(chan error, writers)
+	for n := int64(0); n < writers; n++ {
+		wg.Add(1)
+		go func(n int64) {
+			defer wg.Done()
+			for i := int64(0); i < writesPerWriter; i++ {
+				cs := io.NewColumnSeries()
+				cs.AddColumn("Epoch", []int64{epoch + (n*writesPerWriter+i)*60})
+				cs.AddColumn("Close", []float32{float32(i)})
+				csm := io.NewColumnSeriesMap()
+				csm.AddColumnSeries(*tbk, cs)
+				if err := writer.WriteCSM(csm, false); err != nil {
+					errs <- err
+					return
+				}
+				if i == writesBeforeRename {
+					started <- struct{}{}
+				}
+			}
+		}(n)
+	}
+
+	// --- when ---
+	<-started
+	err := writer.RenameSymbol("AAPL", "AAPL2", false)
+
+	// --- then ---
+	require.Nil(t, err)
+	wg.Wait()
+	close(errs)
+	for err2 := range errs {
+		require.Nil(t, err2)
+	}
+	writer.WaitFlush()
+	// all the writes before and after the rename are in the new symbol, and the old symbol is not recreated
+	got := readBucket(t, rootDir, io.NewTimeBucketKey("AAPL2/1Min/OHLC"))
+	require.Equal(t, writers*writesPerWriter, got.Len())
+	for i, e := range got.GetEpoch() {
+		assert.Equal(t, epoch+int64(i)*60, e)
+	}
+	assert.NoDirExists(t, filepath.Join(rootDir, "AAPL"))
+}
@@ -1,16 +1,19 @@
 package executor
 
 import (
+	"bytes"
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
 	"sort"
+	"sync/atomic"
 	"time"
 
-	"go.uber.org/zap"
-
+	"github.com/alpacahq/marketstore/v4/catalog"
 	"github.com/alpacahq/marketstore/v4/planner"
 	"github.com/alpacahq/marketstore/v4/utils"
 	utilsio "github.com/alpacahq/marketstore/v4/utils/io"
@@ -136,13 +139,28 @@ func NewIOPlan(fl SortedFileList, limit *planner.RowLimit, range2 *planner.DateR
 	return iop, nil
 }
 
+// ErrResultTooLarge is returned by Reader when the result of a query exceeds its ResultLimits.
+var ErrResultTooLarge = errors.New("query result is too large")
+
+// ResultLimits are the maximum number of rows and bytes of the result of a Reader. Zero is unlimited.
+type ResultLimits struct {
+	MaxRows  int
+	MaxBytes int64
+}
+
 type Reader struct {
 	pr     planner.ParseResult
 	IOPMap map[utilsio.TimeBucketKey]*IOPlan
 	// for packingReader to avoid redundant allocation.
 	// really ought to be somewhere close to the function...
-	readBuffer []byte
-	fileBuffer []byte
+	buffers  *readBuffers
+	readSize int32
+	readMode utils.ReadMode
+	// parallelism is the number of the files read at the same time
+	parallelism int
+	limits      ResultLimits
+	// rows and bytes already read, updated atomically by the read tasks
+	rows, bytes int64
 }
 
 func NewReader(pr *planner.ParseResult) (r *Reader, err error) {
@@ -171,40 +189,126 @@ func NewReader(pr *planner.ParseResult) (r *Reader, err error) {
 	// Number of bytes to buffer, some multiple of record length
 	// This should be at least bigger than 4096 and be better multiple of 4KB,
 	// which is the common io size on most of the storage/filesystem.
-	readSize := recordsPerRead * maxRecordLen
-	r.readBuffer = make([]byte, readSize)
-	r.fileBuffer = make([]byte, readSize)
+	r.readSize = recordsPerRead * maxRecordLen
+	r.buffers = r.newReadBuffers()
+	r.parallelism = utils.InstanceConfig.ReadParallelism
+	r.readMode = utils.InstanceConfig.ReadMode
 
 	return r, nil
 }
 
+// SetResultLimits makes Read return ErrResultTooLarge as soon as the data read exceeds the limits,
+// instead of reading all of it in memory.
+func (r *Reader) SetResultLimits(limits ResultLimits) {
+	r.limits = limits
+}
+
+// SetParallelism sets the max number of the files read at the same time. It's the read_parallelism of the config
+// by default.
+func (r *Reader) SetParallelism(n int) {
+	if n < 1 {
+		n = 1
+	}
+	r.parallelism = n
+}
+
+// SetReadMode sets how the files are read. It's the read_mode of the config by default.
+func (r *Reader) SetReadMode(mode utils.ReadMode) {
+	r.readMode = mode
+}
+
+// mappings returns the memory mappings to read the files in the mmap read mode, or nil.
+func (r *Reader) mappings() *catalog.FileMappings {
+	if r.readMode != utils.ReadModeMmap {
+		return nil
+	}
+	return r.pr.Mappings
+}
+
 func (r *Reader) Read() (csm utilsio.ColumnSeriesMap, err error) {
+	return r.ReadContext(context.Background())
+}
+
+// ReadContext is Read that stops between the files when the context is done,
+// e.g. when the client of the query has gone away.
+//
+// The files are read by a pool of workers. The files of a time bucket key are read in parallel
+// only for a forward scan without a row limit, otherwise they are read in order by a single worker
+// to stop at the limit.
+func (r *Reader) ReadContext(ctx context.Context) (csm utilsio.ColumnSeriesMap, err error) {
 	// TODO: Need to consider the huge buffer which use loooong time gap to query.
 	// Which probably cause out of memory issue and need new mechanism to handle
 	// those data and not just simply return one ColumnSeriesMap.
 	// Solution: Hack ColumnSeries add subsection fields to break the one big query
 	// down to several parts of small query and each one's Range.Start follow the last's
 	// Range.End with same other conditions.
-	csm = utilsio.NewColumnSeriesMap()
 	rtMap := r.pr.GetRecordType()
 	dsMap := r.pr.GetDataShapes()
 	rlMap := r.pr.GetRowLen()
+	atomic.StoreInt64(&r.rows, 0)
+	atomic.StoreInt64(&r.bytes, 0)
+
+	// the results of the tasks of each key, in the order of the files
+	results := make(map[utilsio.TimeBucketKey][][]byte, len(r.IOPMap))
+	var tasks []*readTask
 	for key, iop := range r.IOPMap {
-		rt := rtMap[key]
-		rlen := rlMap[key]
-		buffer, err2 := r.read(iop)
-		if err2 != nil {
-			return nil, err2
+		if iop.Limit.Direction == utilsio.FIRST && iop.Limit.Number == math.MaxInt32 && len(iop.FilePlan) > 1 {
+			results[key] = make([][]byte, len(iop.FilePlan))
+			for i := range iop.FilePlan {
+				filePlan := *iop
+				filePlan.FilePlan = iop.FilePlan[i : i+1]
+				tasks = append(tasks, &readTask{
+					iop: &filePlan, recordType: rtMap[key], rowLen: rlMap[key], result: &results[key][i],
+				})
+			}
+			continue
 		}
-		if rt == utilsio.VARIABLE {
-			buffer = trimResultsToRange(r.pr.Range, rlen, buffer)
-			buffer = trimResultsToLimit(r.pr.Limit, rlen, buffer)
+		results[key] = make([][]byte, 1)
+		tasks = append(tasks, &readTask{
+			iop: iop, recordType: rtMap[key], rowLen: rlMap[key], result: &results[key][0],
+		})
+	}
+	if err = r.runTasks(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	csm = utilsio.NewColumnSeriesMap()
+	for key, buffers := range results {
+		buffer := buffers[0]
+		if len(buffers) > 1 {
+			buffer = bytes.Join(buffers, nil)
 		}
-		rs := utilsio.NewRowSeries(key, buffer, dsMap[key], rlen, rt)
+		rs := utilsio.NewRowSeries(key, buffer, dsMap[key], rlMap[key], rtMap[key])
 		key, cs := rs.ToColumnSeries()
 		csm[key] = cs
 	}
-	return csm, err
+	return csm, nil
+}
+
+// checkResult returns an error if the context is done, or if the result exceeds the limits
+// with the rows and bytes being read by a task.
+func (r *Reader) checkResult(ctx context.Context, rows, size int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.checkLimits(atomic.LoadInt64(&r.rows)+rows, atomic.LoadInt64(&r.bytes)+size)
+}
+
+// addResult adds the rows and bytes read by a task to the result, and checks the limits.
+func (r *Reader) addResult(rows, size int64) error {
+	return r.checkLimits(atomic.AddInt64(&r.rows, rows), atomic.AddInt64(&r.bytes, size))
+}
+
+func (r *Reader) checkLimits(rows, size int64) error {
+	if r.limits.MaxRows > 0 && rows > int64(r.limits.MaxRows) {
+		return fmt.Errorf("%w: more than %d rows, narrow the time range or limit the number of records",
+			ErrResultTooLarge, r.limits.MaxRows)
+	}
+	if r.limits.MaxBytes > 0 && size > r.limits.MaxBytes {
+		return fmt.Errorf("%w: more than %d bytes, narrow the time range, limit the number of records "+
+			"or select fewer columns", ErrResultTooLarge, r.limits.MaxBytes)
+	}
+	return nil
 }
 
 func trimResultsToRange(dr *planner.DateRange, rowlen int, src []byte) (dest []byte) {
@@ -273,7 +377,7 @@ type bufferMeta struct {
 
 // Reads the data from files, removing holes. The resulting buffer will be packed
 // Uses the index that prepends each row to identify filled rows versus holes.
-func (r *Reader) read(iop *IOPlan) ([]byte, error) {
+func (r *Reader) read(ctx context.Context, iop *IOPlan, buffers *readBuffers) ([]byte, error) {
 	var (
 		resultBuffer []byte
 		err          error
@@ -282,7 +386,7 @@ func (r *Reader) read(iop *IOPlan) ([]byte, error) {
 	// This should be at least bigger than 4096 and be better multiple of 4KB,
 	// which is the common io size on most of the storage/filesystem.
 	maxToBuffer := recordsPerRead * iop.RecordLen
-	readBuffer := r.readBuffer[:maxToBuffer]
+	readBuffer := buffers.read[:maxToBuffer]
 	// Scan direction
 	direction := iop.Limit.Direction
 
@@ -297,7 +401,7 @@ func (r *Reader) read(iop *IOPlan) ([]byte, error) {
 		}
 	}
 
-	ex := newIoExec(iop)
+	ex := newIoExec(iop, r.mappings())
 
 	/*
 		if direction == FIRST
@@ -321,6 +425,14 @@ func (r *Reader) read(iop *IOPlan) ([]byte, error) {
 	var finished bool
 	if direction == utilsio.FIRST {
 		for _, fp := range iop.FilePlan {
+			// the rows of variable length records are unknown until the second stage
+			var rows int64
+			if iop.RecordType != utilsio.VARIABLE {
+				rows = int64(len(resultBuffer)) / int64(iop.RecordLen)
+			}
+			if err = r.checkResult(ctx, rows, int64(len(resultBuffer))); err != nil {
+				return nil, err
+			}
 			dataLen := len(resultBuffer)
 			resultBuffer, finished, err = ex.readForward(resultBuffer,
 				fp,
@@ -347,13 +459,17 @@ func (r *Reader) read(iop *IOPlan) ([]byte, error) {
 		fp := iop.FilePlan
 		var bytesRead int32
 		for i := len(fp) - 1; i >= 0; i-- {
+			// the result is limited, so only the context is checked
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
 			// Backward scan - we know that we are going to produce a limited result set here
 			resultBuffer, finished, bytesRead, err = ex.readBackward(
 				resultBuffer,
 				fp[i],
 				bytesLeftToFill,
 				readBuffer,
-				r.fileBuffer)
+				buffers.file)
 
 			bytesLeftToFill -= bytesRead
 			if iop.RecordType == utilsio.VARIABLE {
@@ -402,7 +518,7 @@ func (r *Reader) read(iop *IOPlan) ([]byte, error) {
 		If this is a variable record type, we need a second stage of reading to get the data from the files
 	*/
 	if iop.RecordType == utilsio.VARIABLE {
-		resultBuffer, err = r.readSecondStage(bufMeta)
+		resultBuffer, err = r.readSecondStage(ctx, bufMeta, iop.Limit.Number == math.MaxInt32)
 		if err != nil {
 			return nil, err
 		}
@@ -412,6 +528,8 @@ func (r *Reader) read(iop *IOPlan) ([]byte, error) {
 
 type ioExec struct {
 	plan *IOPlan
+	// mappings are the memory mappings of the files in the mmap read mode, or nil
+	mappings *catalog.FileMappings
 }
 
 func (ex *ioExec) packingReader(packedBuffer *[]byte, f io.ReadSeeker, buffer []byte,
@@ -450,45 +568,51 @@ func (ex *ioExec) packingReader(packedBuffer *[]byte, f io.ReadSeeker, buffer []
 		}
 
 		numToRead := int32(nn) / recordSize
-		var i int32
-		var indexuint64 uint64
-
-		buf := buffer
-		for i = 0; i < numToRead; i++ {
-			indexuint64 = binary.LittleEndian.Uint64(buf)
-
-			if indexuint64 != 0 {
-				// Convert the index to a UNIX timestamp (seconds from epoch)
-				index := utilsio.IndexToTime(int64(indexuint64), fp.tbi.GetTimeframe(), fp.GetFileYear()).Unix()
-				if !ex.checkTimeQuals(index) {
-					continue
-				}
-				idxpos := len(*packedBuffer)
-				*packedBuffer = append(*packedBuffer, buf[:recordSize]...)
-				b := *packedBuffer
-				binary.LittleEndian.PutUint64(b[idxpos:], uint64(index))
-
-				// Update lastKnown only once the first time
-				if fp.seekingLast {
-					_, _ = f.Seek(0, io.SeekCurrent)
-					fp.seekingLast = false
-				}
-			}
-
-			buf = buf[recordSize:]
-		}
+		ex.packRecords(packedBuffer, buffer[:numToRead*recordSize], fp)
 		if leftBytes <= 0 {
 			return nil
 		}
 	}
 }
 
+// packRecords appends the valid records in buf to packedBuffer, converting their index values to UNIX epochs.
+func (ex *ioExec) packRecords(packedBuffer *[]byte, buf []byte, fp *ioFilePlan) {
+	recordSize := int(ex.plan.RecordLen)
+	numToRead := len(buf) / recordSize
+	var indexuint64 uint64
+
+	for i := 0; i < numToRead; i++ {
+		indexuint64 = binary.LittleEndian.Uint64(buf)
+
+		if indexuint64 != 0 {
+			// Convert the index to a UNIX timestamp (seconds from epoch)
+			index := utilsio.IndexToTime(int64(indexuint64), fp.tbi.GetTimeframe(), fp.GetFileYear()).Unix()
+			if !ex.checkTimeQuals(index) {
+				buf = buf[recordSize:]
+				continue
+			}
+			idxpos := len(*packedBuffer)
+			*packedBuffer = append(*packedBuffer, buf[:recordSize]...)
+			b := *packedBuffer
+			binary.LittleEndian.PutUint64(b[idxpos:], uint64(index))
+
+			// Update lastKnown only once the first time
+			fp.seekingLast = false
+		}
+
+		buf = buf[recordSize:]
+	}
+}
+
 func (ex *ioExec) readForward(finalBuffer []byte, fp *ioFilePlan, bytesToRead int32, readBuffer []byte) (
 	resultBuffer []byte, finished bool, err error,
 ) {
 	const readWriteAll = 0o666
 	// log.Info("reading forward [recordLen: %v bytesToRead: %v]", recordLen, bytesToRead)
 	filePath := fp.FullPath
+	if ex.mappings != nil {
+		return ex.readForwardMapped(finalBuffer, fp, bytesToRead)
+	}
 
 	if finalBuffer == nil {
 		finalBuffer = make([]byte, 0, len(readBuffer))
@@ -526,7 +650,6 @@ func (ex *ioExec) readBackward(finalBuffer []byte, fp *ioFilePlan,
 	bytesToRead int32, readBuffer, fileBuffer []byte) (
 	result []byte, finished bool, bytesRead int32, err error,
 ) {
-	const readWriteAll = 0o666
 	// log.Info("reading backward [recordLen: %v bytesToRead: %v offset: %v]", recordLen, bytesToRead, fp.Offset)
 
 	filePath := fp.FullPath
@@ -537,18 +660,12 @@ func (ex *ioExec) readBackward(finalBuffer []byte, fp *ioFilePlan,
 		finalBuffer = make([]byte, bytesToRead)
 	}
 
-	f, err := os.OpenFile(filePath, os.O_RDONLY, readWriteAll)
+	f, closeFile, err := openDataFile(ex.mappings, filePath)
 	if err != nil {
 		log.Error("Read: opening %s\n%s", filePath, err)
 		return nil, false, 0, err
 	}
-	defer func() {
-		if err2 := f.Close(); err2 != nil {
-			log.Error("failed to close datafile",
-				zap.String("filepath", filePath), zap.Error(err2),
-			)
-		}
-	}()
+	defer closeFile()
 
 	// Seek to the right end of the search set
 	if _, err2 := f.Seek(beginPos+fp.Length, io.SeekStart); err2 != nil {
@@ -646,8 +763,9 @@ func (ex *ioExec) checkTimeQuals(epoch int64) bool {
 	return true
 }
 
-func newIoExec(iop *IOPlan) *ioExec {
+func newIoExec(iop *IOPlan, mappings *catalog.FileMappings) *ioExec {
 	return &ioExec{
-		plan: iop,
+		plan:     iop,
+		mappings: mappings,
 	}
 }
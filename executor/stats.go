@@ -0,0 +1,339 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	stdio "io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// BucketStats are the statistics of a time bucket.
+type BucketStats struct {
+	Key        io.TimeBucketKey
+	DataShapes []io.DataShape
+	RecordType io.EnumRecordType
+	// Years are the years of the data files in ascending order
+	Years []int16
+	// FirstTime and LastTime are the times of the first and the last records
+	// to the resolution of the timeframe, or zero if the bucket has no records
+	FirstTime time.Time
+	LastTime  time.Time
+	// Rows is the approximate number of the records
+	Rows int64
+	// DiskSize is the number of the bytes allocated to the data files on disk, which are sparse files
+	DiskSize int64
+}
+
+// yearStats are the statistics of a data file.
+type yearStats struct {
+	// file identifies the scanned data file, so that a data file recreated after the bucket is destroyed is scanned
+	file os.FileInfo
+	// firstIndex and lastIndex are the indexes of the first and the last records, 0 if there is no record
+	firstIndex, lastIndex int64
+	rows                  int64
+}
+
+// StatsTracker maintains the statistics of the time buckets.
+// The data files are scanned once by Seed at startup, or when their statistics are first requested,
+// and the statistics are updated by the records written by each flush of the WAL after that,
+// so it must be registered to the WAL as a flush listener.
+type StatsTracker struct {
+	catalogDir *catalog.Directory
+
+	mu sync.Mutex
+	// years[Key]: Key is the full path to the data file
+	years map[string]*yearStats
+	// generations[Key] is incremented by each flush to the data file,
+	// so that a scan that races with a flush is not stored
+	generations map[string]uint64
+}
+
+// NewStatsTracker returns a new tracker of the statistics of the time buckets in the root catalog directory.
+func NewStatsTracker(catalogDir *catalog.Directory) *StatsTracker {
+	return &StatsTracker{
+		catalogDir:  catalogDir,
+		years:       map[string]*yearStats{},
+		generations: map[string]uint64{},
+	}
+}
+
+// Flushed implements FlushListener. The statistics are updated by FlushedRecords.
+func (st *StatsTracker) Flushed(_ map[io.TimeBucketKey][]int16) {}
+
+// FlushedRecords implements RecordsFlushListener, and updates the statistics of the written data files.
+// The rows written to the indexes within the known records of a FIXED record type are counted as updates,
+// so the number of the rows is approximate.
+// The statistics of a data file are scanned again in the background when some records are deleted from it.
+func (st *StatsTracker) FlushedRecords(written map[string]*FlushedRecords) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for filePath, fr := range written {
+		filePath = filepath.Clean(filePath)
+		st.generations[filePath]++
+		ys, found := st.years[filePath]
+		if !found {
+			continue
+		}
+		if fr.Deleted {
+			delete(st.years, filePath)
+			go st.rescan(filePath)
+			continue
+		}
+		ys.add(fr)
+	}
+}
+
+// Seed scans the data files of all the time buckets whose statistics are not known yet,
+// so that the first request of the statistics doesn't scan them. It's run in the background at startup.
+func (st *StatsTracker) Seed() error {
+	_, err := st.Stats("")
+	return err
+}
+
+// rescan scans the data file again after some records are deleted from it.
+func (st *StatsTracker) rescan(filePath string) {
+	subDir, err := st.catalogDir.GetOwningSubDirectory(filePath)
+	if err != nil {
+		// the bucket is deleted
+		return
+	}
+	for _, tbi := range subDir.GetTimeBucketInfoSlice() {
+		if filepath.Clean(tbi.Path) != filePath {
+			continue
+		}
+		info, err := os.Stat(tbi.Path)
+		if err != nil {
+			return
+		}
+		if _, err = st.yearStats(tbi, info); err != nil {
+			log.Error("failed to scan the stats of data file %s: %v", filePath, err)
+		}
+		return
+	}
+}
+
+func (ys *yearStats) add(fr *FlushedRecords) {
+	firstIndex, lastIndex := ys.firstIndex, ys.lastIndex
+	for i, index := range fr.Indexes {
+		// the records of a VARIABLE record type are appended to the index
+		if fr.RecordType == io.VARIABLE || ys.rows == 0 || index < firstIndex || index > lastIndex {
+			ys.rows += fr.Rows[i]
+		}
+		if ys.firstIndex == 0 || index < ys.firstIndex {
+			ys.firstIndex = index
+		}
+		if index > ys.lastIndex {
+			ys.lastIndex = index
+		}
+	}
+}
+
+// Stats returns the statistics of the time buckets whose keys match the glob pattern, sorted by the keys.
+// The pattern is matched against "{symbol}/{timeframe}/{attributeGroup}" (e.g. "AAPL/*/*" or "*/1D/OHLCV"),
+// or against the symbol if it has no "/" (e.g. "AA*"). An empty pattern matches all the time buckets.
+func (st *StatsTracker) Stats(pattern string) ([]BucketStats, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	keys := catalog.ListTimeBucketKeyNames(st.catalogDir)
+	sort.Strings(keys)
+
+	stats := make([]BucketStats, 0)
+	for _, key := range keys {
+		if pattern != "" && !matchKey(pattern, key) {
+			continue
+		}
+		bs, err := st.bucketStats(io.NewTimeBucketKey(key))
+		if errors.Is(err, errNoDataFile) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("get the stats of %s: %w", key, err)
+		}
+		stats = append(stats, *bs)
+	}
+	return stats, nil
+}
+
+func matchKey(pattern, key string) bool {
+	if !strings.Contains(pattern, "/") {
+		key = strings.SplitN(key, "/", 2)[0]
+	}
+	matched, _ := path.Match(pattern, key)
+	return matched
+}
+
+var errNoDataFile = errors.New("no data file")
+
+func (st *StatsTracker) bucketStats(tbk *io.TimeBucketKey) (*BucketStats, error) {
+	latest, err := st.catalogDir.GetLatestTimeBucketInfoFromKey(tbk)
+	if err != nil {
+		// the bucket is being created or deleted
+		return nil, errNoDataFile
+	}
+	subDir, err := st.catalogDir.GetOwningSubDirectory(latest.Path)
+	if err != nil {
+		return nil, errNoDataFile
+	}
+	tbis := subDir.GetTimeBucketInfoSlice()
+	sort.Slice(tbis, func(i, j int) bool { return tbis[i].Year < tbis[j].Year })
+
+	bs := &BucketStats{
+		Key:        *tbk,
+		DataShapes: latest.GetDataShapesWithEpoch(),
+		RecordType: latest.GetRecordType(),
+		Years:      make([]int16, 0, len(tbis)),
+	}
+	for _, tbi := range tbis {
+		info, err := os.Stat(tbi.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			// the bucket is being deleted
+			return nil, errNoDataFile
+		} else if err != nil {
+			return nil, fmt.Errorf("stat data file %s: %w", tbi.Path, err)
+		}
+		bs.Years = append(bs.Years, tbi.Year)
+		bs.DiskSize += diskSize(info)
+
+		ys, err := st.yearStats(tbi, info)
+		if err != nil {
+			return nil, err
+		}
+		if ys.rows == 0 {
+			continue
+		}
+		bs.Rows += ys.rows
+		if bs.FirstTime.IsZero() {
+			bs.FirstTime = io.IndexToTime(ys.firstIndex, tbi.GetTimeframe(), tbi.Year)
+		}
+		bs.LastTime = io.IndexToTime(ys.lastIndex, tbi.GetTimeframe(), tbi.Year)
+	}
+	return bs, nil
+}
+
+// yearStats returns the statistics of the data file, scanning it if they are not known yet.
+func (st *StatsTracker) yearStats(tbi *io.TimeBucketInfo, info os.FileInfo) (yearStats, error) {
+	filePath := filepath.Clean(tbi.Path)
+	st.mu.Lock()
+	if ys, found := st.years[filePath]; found && os.SameFile(ys.file, info) {
+		defer st.mu.Unlock()
+		return *ys, nil
+	}
+	generation := st.generations[filePath]
+	st.mu.Unlock()
+
+	ys, err := scanYearStats(tbi)
+	if err != nil {
+		return yearStats{}, err
+	}
+	ys.file = info
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	// a flush during the scan may not be in the result, so it's scanned again next time
+	if st.generations[filePath] == generation {
+		st.years[filePath] = ys
+	}
+	return *ys, nil
+}
+
+// scanYearStats reads the indexes of the records in the data file.
+// In case of a VARIABLE record type, the number of the rows at each index is read from the length of its data.
+func scanYearStats(tbi *io.TimeBucketInfo) (*yearStats, error) {
+	fp, err := os.Open(tbi.Path)
+	if err != nil {
+		return nil, fmt.Errorf("open data file %s: %w", tbi.Path, err)
+	}
+	defer func() {
+		if err2 := fp.Close(); err2 != nil {
+			log.Error("failed to close data file %s: %v", tbi.Path, err2)
+		}
+	}()
+
+	recordLen := int64(tbi.GetRecordLength())
+	variable := tbi.GetRecordType() == io.VARIABLE
+	varRecLen := int64(tbi.GetVariableRecordLength())
+	ys := &yearStats{}
+	buf := make([]byte, recordsPerRead*recordLen)
+	end := io.FileSize(tbi.GetTimeframe(), int(tbi.Year), int(recordLen))
+	for offset := int64(io.Headersize); offset < end; offset += int64(len(buf)) {
+		n, err := fp.ReadAt(buf, offset)
+		if err != nil && !errors.Is(err, stdio.EOF) {
+			return nil, fmt.Errorf("read data file %s: %w", tbi.Path, err)
+		}
+		// the data of a VARIABLE record type follows the indexes
+		if offset+int64(n) > end {
+			n = int(end - offset)
+		}
+		for pos := int64(0); pos+recordLen <= int64(n); pos += recordLen {
+			index := io.ToInt64(buf[pos:])
+			if index == 0 {
+				continue
+			}
+			rows := int64(1)
+			if variable {
+				dataOffset, dataLen := io.ToInt64(buf[pos+epochLenBytes:]), io.ToInt64(buf[pos+2*epochLenBytes:])
+				if rows, err = variableRows(fp, dataOffset, dataLen, varRecLen); err != nil {
+					return nil, fmt.Errorf("read the records at index %d of %s: %w", index, tbi.Path, err)
+				}
+			}
+			if ys.firstIndex == 0 {
+				ys.firstIndex = index
+			}
+			ys.lastIndex = index
+			ys.rows += rows
+		}
+		if n < len(buf) {
+			break
+		}
+	}
+	return ys, nil
+}
+
+// variableRows returns the number of the rows in the data of a VARIABLE record type,
+// reading only the header of the data if it's compressed.
+func variableRows(fp *os.File, dataOffset, dataLen, varRecLen int64) (int64, error) {
+	if varRecLen == 0 {
+		return 0, nil
+	}
+	if utils.InstanceConfig.DisableVariableCompression {
+		return dataLen / varRecLen, nil
+	}
+	const maxHeaderLen = 10
+	header := make([]byte, maxHeaderLen)
+	if dataLen < maxHeaderLen {
+		header = header[:dataLen]
+	}
+	if _, err := fp.ReadAt(header, dataOffset); err != nil {
+		return 0, err
+	}
+	decodedLen, err := snappy.DecodedLen(header)
+	if err != nil {
+		return 0, err
+	}
+	return int64(decodedLen) / varRecLen, nil
+}
+
+// diskSize returns the number of the bytes allocated to the file,
+// which is smaller than the file size for the sparse data files.
+func diskSize(info os.FileInfo) int64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		const blockSize = 512 // st_blocks is the number of 512-byte blocks
+		return stat.Blocks * blockSize
+	}
+	return info.Size()
+}
@@ -0,0 +1,181 @@
+package executor_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/utils"
+	. "github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func writePrices(t *testing.T, writer *executor.Writer, tbk *TimeBucketKey, isVariableLength bool,
+	times ...time.Time,
+) {
+	t.Helper()
+
+	epochs := make([]int64, len(times))
+	nanos := make([]int32, len(times))
+	prices := make([]float32, len(times))
+	for i, ts := range times {
+		epochs[i], nanos[i], prices[i] = ts.Unix(), int32(ts.Nanosecond()), float32(i)
+	}
+	cs := NewColumnSeries()
+	cs.AddColumn("Epoch", epochs)
+	cs.AddColumn("Price", prices)
+	if isVariableLength {
+		cs.AddColumn("Nanoseconds", nanos)
+	}
+	csm := NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	require.Nil(t, writer.WriteCSM(csm, isVariableLength))
+}
+
+func TestStatsTracker(t *testing.T) {
+	t.Parallel()
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	wf := c.GetInitWALFile()
+	tracker := executor.NewStatsTracker(c.GetCatalogDir())
+	wf.AddFlushListener(tracker)
+	writer, err := executor.NewWriter(c.GetCatalogDir(), wf)
+	require.Nil(t, err)
+
+	fixed, variable := NewTimeBucketKey("TEST/1Min/OHLC"), NewTimeBucketKey("TEST/1Min/TICK")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	writePrices(t, writer, fixed, false, epoch, epoch.Add(time.Minute), epoch.Add(2*time.Minute))
+	writePrices(t, writer, variable, true, epoch, epoch.Add(time.Second), epoch.Add(time.Minute))
+	require.Nil(t, wf.FlushToWAL())
+
+	// --- when ---
+	stats, err := tracker.Stats("")
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, *fixed, stats[0].Key)
+	assert.Equal(t, FIXED, stats[0].RecordType)
+	assert.Equal(t, []DataShape{{Name: "Epoch", Type: INT64}, {Name: "Price", Type: FLOAT32}}, stats[0].DataShapes)
+	assert.Equal(t, []int16{2021}, stats[0].Years)
+	assert.Equal(t, int64(3), stats[0].Rows)
+	assert.True(t, epoch.Equal(stats[0].FirstTime))
+	assert.True(t, epoch.Add(2*time.Minute).Equal(stats[0].LastTime))
+	assert.Positive(t, stats[0].DiskSize)
+	assert.Equal(t, *variable, stats[1].Key)
+	assert.Equal(t, VARIABLE, stats[1].RecordType)
+	assert.Equal(t, int64(3), stats[1].Rows)
+	assert.True(t, epoch.Add(time.Minute).Equal(stats[1].LastTime))
+
+	// --- when new records are written, the stats are updated by the flush ---
+	writePrices(t, writer, fixed, false, epoch.Add(time.Minute), epoch.Add(5*time.Minute),
+		time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC))
+	writePrices(t, writer, variable, true, epoch.Add(time.Minute+time.Second), epoch.Add(-time.Minute))
+	require.Nil(t, wf.FlushToWAL())
+	stats, err = tracker.Stats("")
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, []int16{2021, 2022}, stats[0].Years)
+	assert.Equal(t, int64(5), stats[0].Rows)
+	assert.True(t, time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC).Equal(stats[0].LastTime))
+	assert.Equal(t, int64(5), stats[1].Rows)
+	assert.True(t, epoch.Add(-time.Minute).Equal(stats[1].FirstTime))
+	scanned, err := executor.NewStatsTracker(c.GetCatalogDir()).Stats("")
+	require.Nil(t, err)
+	assert.Equal(t, scanned, stats)
+
+	// --- when records are deleted, the stats are scanned again ---
+	_, err = writer.DeleteRange(fixed, epoch, epoch.Add(2*time.Minute))
+	require.Nil(t, err)
+	require.Nil(t, wf.FlushToWAL())
+	stats, err = tracker.Stats("TEST/*/OHLC")
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(2), stats[0].Rows)
+	assert.True(t, epoch.Add(5*time.Minute).Equal(stats[0].FirstTime))
+}
+
+func TestStatsTracker_Seed(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	writer, err := executor.NewWriter(c.GetCatalogDir(), c.GetInitWALFile())
+	require.Nil(t, err)
+	tbk := NewTimeBucketKey("TEST/1Min/OHLC")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	writePrices(t, writer, tbk, false, epoch, epoch.Add(time.Minute))
+	require.Nil(t, c.GetInitWALFile().FlushToWAL())
+	tracker := executor.NewStatsTracker(c.GetCatalogDir())
+
+	// --- when ---
+	err = tracker.Seed()
+
+	// --- then ---
+	require.Nil(t, err)
+	// the seeded stats are returned without scanning the data file again
+	tbi, err := c.GetCatalogDir().GetLatestTimeBucketInfoFromKey(tbk)
+	require.Nil(t, err)
+	require.Nil(t, os.Truncate(tbi.Path, int64(Headersize)))
+	stats, err := tracker.Stats("")
+	require.Nil(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(2), stats[0].Rows)
+}
+
+func TestStatsTracker_Pattern(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		pattern  string
+		wantKeys []string
+		wantErr  bool
+	}{
+		"ok/ all":                  {pattern: "", wantKeys: []string{"AAPL/1D/OHLC", "AAPL/1Min/OHLC", "AMZN/1Min/OHLC"}},
+		"ok/ symbol glob":          {pattern: "AA*", wantKeys: []string{"AAPL/1D/OHLC", "AAPL/1Min/OHLC"}},
+		"ok/ time bucket key glob": {pattern: "*/1Min/*", wantKeys: []string{"AAPL/1Min/OHLC", "AMZN/1Min/OHLC"}},
+		"ok/ no match":             {pattern: "MSFT", wantKeys: []string{}},
+		"ng/ invalid pattern":      {pattern: "AA[", wantErr: true},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			cfg := utils.NewDefaultConfig(t.TempDir())
+			cfg.BackgroundSync = false
+			c := di.NewContainer(cfg)
+			writer, err := executor.NewWriter(c.GetCatalogDir(), c.GetInitWALFile())
+			require.Nil(t, err)
+			epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+			for _, key := range []string{"AAPL/1Min/OHLC", "AAPL/1D/OHLC", "AMZN/1Min/OHLC"} {
+				writePrices(t, writer, NewTimeBucketKey(key), false, epoch)
+			}
+			require.Nil(t, c.GetInitWALFile().FlushToWAL())
+
+			// --- when ---
+			stats, err := executor.NewStatsTracker(c.GetCatalogDir()).Stats(tt.pattern)
+
+			// --- then ---
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if !tt.wantErr {
+				keys := make([]string, len(stats))
+				for i := range stats {
+					keys[i] = stats[i].Key.GetItemKey()
+				}
+				assert.Equal(t, tt.wantKeys, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package executor
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// symbolLocks serializes the writes to a symbol with the operations that need no write to the symbol
+// to be queued while they run, e.g. DeleteRange, which reads the records and writes some of them back,
+// and RenameSymbol, which moves the files of the symbol. They are shared by all the Writers,
+// as executor.WriteCSM creates a Writer for each call.
+var symbolLocks = &keyedMutex{locks: map[string]*sync.Mutex{}}
+
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (km *keyedMutex) get(key string) *sync.Mutex {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	l, found := km.locks[key]
+	if !found {
+		l = &sync.Mutex{}
+		km.locks[key] = l
+	}
+	return l
+}
+
+// lockSymbols locks the symbols in order, and returns the function to unlock them.
+func lockSymbols(symbols ...string) (unlock func()) {
+	sorted := append([]string{}, symbols...)
+	sort.Strings(sorted)
+	var locked []*sync.Mutex
+	for i, symbol := range sorted {
+		if i > 0 && symbol == sorted[i-1] {
+			continue
+		}
+		l := symbolLocks.get(symbol)
+		l.Lock()
+		locked = append(locked, l)
+	}
+	return func() {
+		for _, l := range locked {
+			l.Unlock()
+		}
+	}
+}
+
+// lockSymbol resolves the alias of the key and locks the symbol that it refers to.
+// The key is resolved again after the lock in case the symbol is renamed while waiting for it.
+func (w *Writer) lockSymbol(key *io.TimeBucketKey) (tbk *io.TimeBucketKey, unlock func()) {
+	for {
+		tbk = w.rootCatDir.ResolveKey(key)
+		unlock = lockSymbols(symbolOf(tbk))
+		if w.rootCatDir.ResolveKey(key).GetItemKey() == tbk.GetItemKey() {
+			return tbk, unlock
+		}
+		unlock()
+	}
+}
+
+func symbolOf(tbk *io.TimeBucketKey) string {
+	return tbk.GetItems()[0]
+}
@@ -0,0 +1,372 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+const ohlcColumns = 4
+
+// rangeChecker checks the values of the columns are in [min, max], and not zero if nonZero is set.
+// The fix clamps the values to [min, max].
+type rangeChecker struct {
+	columns  []string
+	min, max *float64
+	nonZero  bool
+}
+
+func (c *rangeChecker) violates(val float64) bool {
+	return (c.nonZero && val == 0) || (c.min != nil && val < *c.min) || (c.max != nil && val > *c.max)
+}
+
+func (c *rangeChecker) check(b *batch) (rows []int, message string, err error) {
+	bad := map[int]bool{}
+	for _, name := range c.columns {
+		values, err := floatColumn(b.cs, name)
+		if err != nil {
+			return nil, "", err
+		}
+		for i, val := range values {
+			if b.cs.IsNull(name, i) || !c.violates(val) {
+				continue
+			}
+			if len(bad) == 0 || i < rows[0] {
+				message = fmt.Sprintf("%s=%v is out of range", name, val)
+			}
+			if !bad[i] {
+				bad[i] = true
+				rows = append(rows, i)
+				sort.Ints(rows)
+			}
+		}
+	}
+	return rows, message, nil
+}
+
+func (c *rangeChecker) fix(b *batch, rows []int) (*io.ColumnSeries, error) {
+	out := copyRows(b.cs)
+	for _, name := range c.columns {
+		values, err := floatColumn(out, name)
+		if err != nil || values == nil {
+			return nil, err
+		}
+		for _, i := range rows {
+			switch {
+			case out.IsNull(name, i):
+			case c.min != nil && values[i] < *c.min:
+				setFloat(out.GetColumn(name), i, *c.min)
+			case c.max != nil && values[i] > *c.max:
+				setFloat(out.GetColumn(name), i, *c.max)
+			}
+		}
+	}
+	return out, nil
+}
+
+// ohlcChecker checks High is the highest and Low is the lowest of Open, High, Low and Close.
+// The fix sets High and Low to the highest and the lowest of them.
+type ohlcChecker struct {
+	open, high, low, close string
+}
+
+// columns returns the values of Open, High, Low and Close, or nil if any of them is missing.
+func (c *ohlcChecker) columns(cs *io.ColumnSeries) ([ohlcColumns][]float64, bool, error) {
+	var cols [ohlcColumns][]float64
+	for j, name := range []string{c.open, c.high, c.low, c.close} {
+		values, err := floatColumn(cs, name)
+		if err != nil || values == nil {
+			return cols, false, err
+		}
+		cols[j] = values
+	}
+	return cols, true, nil
+}
+
+func (c *ohlcChecker) check(b *batch) (rows []int, message string, err error) {
+	cols, found, err := c.columns(b.cs)
+	if !found {
+		return nil, "", err
+	}
+	o, h, l, cl := cols[0], cols[1], cols[2], cols[3]
+	for i := range o {
+		if h[i] >= math.Max(math.Max(o[i], l[i]), cl[i]) && l[i] <= math.Min(math.Min(o[i], h[i]), cl[i]) {
+			continue
+		}
+		if len(rows) == 0 {
+			message = fmt.Sprintf("inconsistent %s=%v %s=%v %s=%v %s=%v",
+				c.open, o[i], c.high, h[i], c.low, l[i], c.close, cl[i])
+		}
+		rows = append(rows, i)
+	}
+	return rows, message, nil
+}
+
+func (c *ohlcChecker) fix(b *batch, rows []int) (*io.ColumnSeries, error) {
+	out := copyRows(b.cs)
+	cols, _, err := c.columns(out)
+	if err != nil {
+		return nil, err
+	}
+	o, h, l, cl := cols[0], cols[1], cols[2], cols[3]
+	for _, i := range rows {
+		setFloat(out.GetColumn(c.high), i, math.Max(math.Max(o[i], h[i]), math.Max(l[i], cl[i])))
+		setFloat(out.GetColumn(c.low), i, math.Min(math.Min(o[i], h[i]), math.Min(l[i], cl[i])))
+	}
+	return out, nil
+}
+
+// monotonicChecker checks the epochs of the rows are increasing. The records of the variable-length
+// time buckets can have the same epoch and nanoseconds. The fix sorts the rows by epoch, and keeps
+// only the last one of the rows that have the same epoch in the fixed-length time buckets.
+type monotonicChecker struct{}
+
+func (c *monotonicChecker) check(b *batch) (rows []int, message string, err error) {
+	epochs := b.cs.GetEpoch()
+	nanos, _ := b.cs.GetColumn("Nanoseconds").([]int32)
+	latest := 0
+	for i := 1; i < len(epochs); i++ {
+		violated := before(epochs, nanos, i, latest)
+		if !b.isVariableLength {
+			violated = epochs[i] <= epochs[latest]
+		}
+		if !violated {
+			latest = i
+			continue
+		}
+		if len(rows) == 0 {
+			message = fmt.Sprintf("epoch %d is not after %d", epochs[i], epochs[latest])
+		}
+		rows = append(rows, i)
+	}
+	return rows, message, nil
+}
+
+func (c *monotonicChecker) fix(b *batch, _ []int) (*io.ColumnSeries, error) {
+	order := epochOrder(b)
+	if b.isVariableLength {
+		return b.cs.SelectRows(order), nil
+	}
+	epochs := b.cs.GetEpoch()
+	kept := make([]int, 0, len(order))
+	for k, i := range order {
+		if k+1 < len(order) && epochs[order[k+1]] == epochs[i] {
+			continue
+		}
+		kept = append(kept, i)
+	}
+	return b.cs.SelectRows(kept), nil
+}
+
+// maxJumpChecker checks the change of the values of the columns from the previous row by epoch,
+// including the last row already written, is at most maxChange, e.g. 0.5 for 50%.
+// A violating row is not used as the previous row of the next one.
+type maxJumpChecker struct {
+	columns   []string
+	maxChange float64
+}
+
+func (c *maxJumpChecker) check(b *batch) (rows []int, message string, err error) {
+	order := epochOrder(b)
+	epochs := b.cs.GetEpoch()
+	bad := map[int]bool{}
+	firstBad := -1
+	for _, name := range c.columns {
+		values, err := floatColumn(b.cs, name)
+		if err != nil || values == nil {
+			return nil, "", err
+		}
+		prev, found, err := b.v.lastValueBefore(&b.key, name, epochs[order[0]])
+		if err != nil {
+			return nil, "", err
+		}
+		for k, i := range order {
+			if b.cs.IsNull(name, i) {
+				continue
+			}
+			val := values[i]
+			if !found || prev == 0 {
+				prev, found = val, true
+				continue
+			}
+			change := math.Abs(val-prev) / math.Abs(prev)
+			if change <= c.maxChange {
+				prev = val
+				continue
+			}
+			if firstBad < 0 || k < firstBad {
+				firstBad = k
+				message = fmt.Sprintf("%s=%v changed %.1f%% from %v", name, val, change*100, prev)
+			}
+			bad[i] = true
+		}
+	}
+	for i := range bad {
+		rows = append(rows, i)
+	}
+	sort.Ints(rows)
+	return rows, message, nil
+}
+
+// dedupChecker checks the records of the variable-length time buckets are not the same as
+// another record in the write or a record already written, comparing all the columns.
+// The fix drops the duplicates. The records in the WAL not flushed to the disk yet are not compared.
+type dedupChecker struct{}
+
+func (c *dedupChecker) check(b *batch) (rows []int, message string, err error) {
+	if !b.isVariableLength {
+		return nil, "", nil
+	}
+	names := b.cs.GetColumnNames()
+	epochs := b.cs.GetEpoch()
+	minEpoch, maxEpoch := epochs[0], epochs[0]
+	for _, epoch := range epochs {
+		if epoch < minEpoch {
+			minEpoch = epoch
+		}
+		if epoch > maxEpoch {
+			maxEpoch = epoch
+		}
+	}
+
+	seen := map[string]bool{}
+	written, err := b.v.store.Read(&b.key, minEpoch, maxEpoch, 0)
+	if err != nil {
+		return nil, "", fmt.Errorf("read the written records of %s: %w", b.key.GetItemKey(), err)
+	}
+	if written != nil && written.Len() > 0 && hasColumns(written, names) {
+		for i := 0; i < written.Len(); i++ {
+			seen[rowKey(written, names, i)] = true
+		}
+	}
+
+	for i := 0; i < b.cs.Len(); i++ {
+		key := rowKey(b.cs, names, i)
+		if !seen[key] {
+			seen[key] = true
+			continue
+		}
+		if len(rows) == 0 {
+			message = fmt.Sprintf("duplicate record at epoch %d", epochs[i])
+		}
+		rows = append(rows, i)
+	}
+	return rows, message, nil
+}
+
+func (c *dedupChecker) fix(b *batch, rows []int) (*io.ColumnSeries, error) {
+	return b.cs.SelectRows(complement(rows, b.cs.Len())), nil
+}
+
+func hasColumns(cs *io.ColumnSeries, names []string) bool {
+	for _, name := range names {
+		if !cs.Exists(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// rowKey returns the string of the values of the columns at the row. The values are formatted in
+// the shortest representation, so that a float64 value equals to the float32 value written for it.
+func rowKey(cs *io.ColumnSeries, names []string, i int) string {
+	var sb strings.Builder
+	for _, name := range names {
+		if cs.IsNull(name, i) {
+			sb.WriteString("null")
+		} else {
+			fmt.Fprint(&sb, indexValue(cs.GetColumn(name), i))
+		}
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+// copyRows returns a copy of the column series so that the fix doesn't change the written data.
+func copyRows(cs *io.ColumnSeries) *io.ColumnSeries {
+	all := make([]int, cs.Len())
+	for i := range all {
+		all[i] = i
+	}
+	return cs.SelectRows(all)
+}
+
+// floatColumn returns the values of the numeric column as float64, or nil if the column doesn't exist.
+func floatColumn(cs *io.ColumnSeries, name string) ([]float64, error) {
+	if !cs.Exists(name) {
+		return nil, nil
+	}
+	col := cs.GetColumn(name)
+	var values []float64
+	switch c := col.(type) {
+	case []float64:
+		return c, nil
+	case []float32:
+		values = make([]float64, len(c))
+		for i, v := range c {
+			values[i] = float64(v)
+		}
+	case []int64:
+		values = make([]float64, len(c))
+		for i, v := range c {
+			values[i] = float64(v)
+		}
+	case []int32:
+		values = make([]float64, len(c))
+		for i, v := range c {
+			values[i] = float64(v)
+		}
+	case []uint64:
+		values = make([]float64, len(c))
+		for i, v := range c {
+			values[i] = float64(v)
+		}
+	case []uint32:
+		values = make([]float64, len(c))
+		for i, v := range c {
+			values[i] = float64(v)
+		}
+	default:
+		return nil, fmt.Errorf("column %s of type %T is not numeric", name, col)
+	}
+	return values, nil
+}
+
+// setFloat sets the i-th value of the numeric column.
+func setFloat(col interface{}, i int, val float64) {
+	switch c := col.(type) {
+	case []float64:
+		c[i] = val
+	case []float32:
+		c[i] = float32(val)
+	case []int64:
+		c[i] = int64(math.Round(val))
+	case []int32:
+		c[i] = int32(math.Round(val))
+	case []uint64:
+		c[i] = uint64(math.Round(val))
+	case []uint32:
+		c[i] = uint32(math.Round(val))
+	}
+}
+
+func indexValue(col interface{}, i int) interface{} {
+	switch c := col.(type) {
+	case []float64:
+		return c[i]
+	case []float32:
+		return c[i]
+	case []int64:
+		return c[i]
+	case []int32:
+		return c[i]
+	case []string:
+		return c[i]
+	default:
+		return reflect.ValueOf(col).Index(i).Interface()
+	}
+}
@@ -0,0 +1,409 @@
+// Package validation validates the records written to the time buckets with the declarative rules
+// in the write_validation config, before they are written to the WAL.
+//
+//	write_validation:
+//	  - on: "*/1Min/OHLCV"
+//	    rules:
+//	      - check: ohlc
+//	        action: fix
+//	      - check: range
+//	        columns: [Open, High, Low, Close]
+//	        min: 0
+//	        nonzero: true
+//	        action: reject
+//	      - check: max_jump
+//	        columns: [Close]
+//	        max_change: 0.5
+//	        action: quarantine
+//
+// The rules of all the settings whose "on" glob matches the time bucket are applied in order,
+// and the rows rejected or quarantined by a rule are not checked by the following rules.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/alpacahq/marketstore/v4/metrics"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+// Checks of the rules.
+const (
+	// CheckRange checks the values of the columns are in [min, max], and not zero if nonzero is set.
+	CheckRange = "range"
+	// CheckOHLC checks High is the highest and Low is the lowest of Open, High, Low and Close.
+	CheckOHLC = "ohlc"
+	// CheckMonotonic checks the epochs of the written rows are increasing.
+	CheckMonotonic = "monotonic"
+	// CheckMaxJump checks the change of the values of the columns from the previous row is within max_change.
+	CheckMaxJump = "max_jump"
+	// CheckDedup checks the records of variable-length time buckets are not written twice.
+	CheckDedup = "dedup"
+)
+
+// Actions of the rules for the violating rows.
+const (
+	// Reject drops the rows.
+	Reject = "reject"
+	// Quarantine writes the rows to the quarantine time bucket instead, see QuarantineKey.
+	Quarantine = "quarantine"
+	// Fix corrects the rows and writes them.
+	Fix = "fix"
+)
+
+// QuarantineSuffix is appended to the attribute group of the quarantine time buckets.
+const QuarantineSuffix = "_QUARANTINE"
+
+// Violation is the rows of a write that violated a rule.
+type Violation struct {
+	// Key is the time bucket key, e.g. "AAPL/1Min/OHLCV"
+	Key    string
+	Rule   string
+	Action string
+	// Rows is the number of the violating rows
+	Rows int
+	// Epoch is the epoch of the first violating row
+	Epoch int64
+	// Message describes the first violation
+	Message string
+}
+
+// Store reads the records already written to the time buckets,
+// to compare them with the written rows by the max_jump and dedup checks.
+type Store interface {
+	// Read returns the records of the time bucket in the epoch range [start, end],
+	// or the last "last" records in the range if last is positive.
+	Read(tbk *io.TimeBucketKey, start, end int64, last int) (*io.ColumnSeries, error)
+}
+
+// Result is the rows of a write to a time bucket split by the validation.
+type Result struct {
+	// Valid is the rows to be written
+	Valid *io.ColumnSeries
+	// Quarantined is the rows to be written to the quarantine time bucket, quarantined by each rule
+	Quarantined []*io.ColumnSeries
+	Violations  []Violation
+}
+
+// Validator validates the writes to the time buckets. It's safe for concurrent use.
+type Validator struct {
+	ruleSets []ruleSet
+	store    Store
+
+	mu sync.Mutex
+	// last[Key][Column]: the last value of the column of the time bucket accepted by the max_jump rules
+	last map[string]map[string]lastValue
+}
+
+type lastValue struct {
+	epoch int64
+	value float64
+}
+
+type ruleSet struct {
+	on    string
+	rules []*rule
+}
+
+type rule struct {
+	name    string
+	action  string
+	checker checker
+}
+
+// checker finds the violating rows of a write.
+type checker interface {
+	// check returns the indexes of the violating rows in ascending order, and describes the first violation.
+	check(b *batch) (rows []int, message string, err error)
+}
+
+// fixer is a checker whose violations can be fixed.
+type fixer interface {
+	// fix returns the column series whose violating rows are fixed.
+	fix(b *batch, rows []int) (*io.ColumnSeries, error)
+}
+
+// batch is the rows of a write to a time bucket under validation.
+type batch struct {
+	v                *Validator
+	key              io.TimeBucketKey
+	cs               *io.ColumnSeries
+	isVariableLength bool
+}
+
+// New returns a validator of the rules in the settings. store can be nil if there is no max_jump or dedup rule.
+func New(settings []*utils.WriteValidationSetting, store Store) (*Validator, error) {
+	v := &Validator{store: store, last: map[string]map[string]lastValue{}}
+	for _, s := range settings {
+		if _, err := path.Match(s.On, ""); err != nil || s.On == "" {
+			return nil, fmt.Errorf("invalid on %q: %v", s.On, err)
+		}
+		rs := ruleSet{on: s.On}
+		for _, rs2 := range s.Rules {
+			r, err := newRule(rs2)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rule %q on %q: %w", rs2.Check, s.On, err)
+			}
+			if (rs2.Check == CheckMaxJump || rs2.Check == CheckDedup) && store == nil {
+				return nil, fmt.Errorf("rule %q on %q requires a store", rs2.Check, s.On)
+			}
+			rs.rules = append(rs.rules, r)
+		}
+		v.ruleSets = append(v.ruleSets, rs)
+	}
+	return v, nil
+}
+
+func newRule(s *utils.ValidationRuleSetting) (*rule, error) {
+	var c checker
+	switch s.Check {
+	case CheckRange:
+		if len(s.Columns) == 0 {
+			return nil, errors.New("no columns")
+		}
+		if s.Min == nil && s.Max == nil && !s.NonZero {
+			return nil, errors.New("no min, max or nonzero")
+		}
+		if s.Min != nil && s.Max != nil && *s.Min > *s.Max {
+			return nil, fmt.Errorf("min %v is greater than max %v", *s.Min, *s.Max)
+		}
+		if s.NonZero && s.Action == Fix {
+			return nil, errors.New("nonzero can't be fixed")
+		}
+		c = &rangeChecker{columns: s.Columns, min: s.Min, max: s.Max, nonZero: s.NonZero}
+	case CheckOHLC:
+		columns := s.Columns
+		if len(columns) == 0 {
+			columns = []string{"Open", "High", "Low", "Close"}
+		}
+		if len(columns) != ohlcColumns {
+			return nil, errors.New("columns must be the Open, High, Low and Close columns")
+		}
+		c = &ohlcChecker{open: columns[0], high: columns[1], low: columns[2], close: columns[3]}
+	case CheckMonotonic:
+		c = &monotonicChecker{}
+	case CheckMaxJump:
+		columns := s.Columns
+		if len(columns) == 0 {
+			columns = []string{"Close"}
+		}
+		if s.MaxChange <= 0 {
+			return nil, errors.New("max_change must be positive")
+		}
+		c = &maxJumpChecker{columns: columns, maxChange: s.MaxChange}
+	case CheckDedup:
+		c = &dedupChecker{}
+	default:
+		return nil, errors.New("unknown check")
+	}
+
+	switch s.Action {
+	case Reject, Quarantine:
+	case Fix:
+		if _, ok := c.(fixer); !ok {
+			return nil, fmt.Errorf("%s can't be fixed", s.Check)
+		}
+	default:
+		return nil, fmt.Errorf("unknown action %q", s.Action)
+	}
+
+	name := s.Name
+	if name == "" {
+		name = s.Check
+	}
+	return &rule{name: name, action: s.Action, checker: c}, nil
+}
+
+// Enabled returns true if the writes to the time bucket are validated.
+func (v *Validator) Enabled(tbk *io.TimeBucketKey) bool {
+	return len(v.rules(tbk)) > 0
+}
+
+func (v *Validator) rules(tbk *io.TimeBucketKey) []*rule {
+	if v == nil {
+		return nil
+	}
+	var rules []*rule
+	for _, rs := range v.ruleSets {
+		if matched, _ := path.Match(rs.on, tbk.GetItemKey()); matched {
+			rules = append(rules, rs.rules...)
+		}
+	}
+	return rules
+}
+
+// Validate applies the rules to the rows written to the time bucket. For the variable-length time buckets,
+// cs must have the Nanoseconds column. It returns an error if a column checked by a rule is not numeric.
+func (v *Validator) Validate(tbk *io.TimeBucketKey, cs *io.ColumnSeries, isVariableLength bool) (*Result, error) {
+	res := &Result{Valid: cs}
+	rules := v.rules(tbk)
+	if len(rules) == 0 || cs.Len() == 0 {
+		return res, nil
+	}
+
+	b := &batch{v: v, key: *tbk, cs: cs, isVariableLength: isVariableLength}
+	for _, r := range rules {
+		rows, message, err := r.checker.check(b)
+		if err != nil {
+			return nil, fmt.Errorf("validate %s by %s: %w", tbk.GetItemKey(), r.name, err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		res.Violations = append(res.Violations, Violation{
+			Key:     tbk.GetItemKey(),
+			Rule:    r.name,
+			Action:  r.action,
+			Rows:    len(rows),
+			Epoch:   b.cs.GetEpoch()[rows[0]],
+			Message: message,
+		})
+		metrics.WriteValidationViolations.WithLabelValues(r.name, r.action).Add(float64(len(rows)))
+		log.Debug("write validation: %d rows of %s violated %s (%s): %s",
+			len(rows), tbk.GetItemKey(), r.name, r.action, message)
+
+		switch r.action {
+		case Fix:
+			fixed, err := r.checker.(fixer).fix(b, rows)
+			if err != nil {
+				return nil, fmt.Errorf("fix %s by %s: %w", tbk.GetItemKey(), r.name, err)
+			}
+			b.cs = fixed
+		case Quarantine:
+			res.Quarantined = append(res.Quarantined, b.cs.SelectRows(rows))
+			b.cs = b.cs.SelectRows(complement(rows, b.cs.Len()))
+		case Reject:
+			b.cs = b.cs.SelectRows(complement(rows, b.cs.Len()))
+		}
+		if b.cs.Len() == 0 {
+			break
+		}
+	}
+	res.Valid = b.cs
+
+	v.remember(tbk, rules, res.Valid)
+	return res, nil
+}
+
+// Forget drops the last values of the time bucket remembered by the max_jump rules,
+// e.g. when the validated rows failed to be written.
+func (v *Validator) Forget(tbk *io.TimeBucketKey) {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.last, tbk.GetItemKey())
+}
+
+// remember keeps the values of the last row of the written rows for the max_jump rules,
+// so that the next write doesn't need to read the last row from the disk.
+func (v *Validator) remember(tbk *io.TimeBucketKey, rules []*rule, cs *io.ColumnSeries) {
+	if cs.Len() == 0 {
+		return
+	}
+	epochs := cs.GetEpoch()
+	lastRow := 0
+	for i, epoch := range epochs {
+		if epoch >= epochs[lastRow] {
+			lastRow = i
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, r := range rules {
+		mj, ok := r.checker.(*maxJumpChecker)
+		if !ok {
+			continue
+		}
+		for _, name := range mj.columns {
+			values, err := floatColumn(cs, name)
+			if err != nil || values == nil {
+				continue
+			}
+			last := v.last[tbk.GetItemKey()]
+			if last == nil {
+				last = map[string]lastValue{}
+				v.last[tbk.GetItemKey()] = last
+			}
+			if prev, found := last[name]; !found || prev.epoch <= epochs[lastRow] {
+				last[name] = lastValue{epoch: epochs[lastRow], value: values[lastRow]}
+			}
+		}
+	}
+}
+
+// lastValueBefore returns the value of the column of the last row before the epoch in the time bucket,
+// and false if there is no row.
+func (v *Validator) lastValueBefore(tbk *io.TimeBucketKey, column string, epoch int64) (float64, bool, error) {
+	v.mu.Lock()
+	prev, found := v.last[tbk.GetItemKey()][column]
+	v.mu.Unlock()
+	if found && prev.epoch < epoch {
+		return prev.value, true, nil
+	}
+
+	cs, err := v.store.Read(tbk, 0, epoch-1, 1)
+	if err != nil {
+		return 0, false, fmt.Errorf("read the last row of %s: %w", tbk.GetItemKey(), err)
+	}
+	if cs == nil || cs.Len() == 0 {
+		return 0, false, nil
+	}
+	values, err := floatColumn(cs, column)
+	if err != nil || values == nil {
+		return 0, false, err
+	}
+	return values[len(values)-1], true, nil
+}
+
+// QuarantineKey returns the time bucket that the rows of the time bucket are quarantined to,
+// e.g. "AAPL/1Min/OHLCV_QUARANTINE" for "AAPL/1Min/OHLCV".
+func QuarantineKey(tbk *io.TimeBucketKey) *io.TimeBucketKey {
+	return io.NewTimeBucketKey(fmt.Sprintf("%s/%s/%s%s",
+		tbk.GetItemInCategory("Symbol"), tbk.GetItemInCategory("Timeframe"),
+		tbk.GetItemInCategory("AttributeGroup"), QuarantineSuffix))
+}
+
+// complement returns the indexes in [0, n) that are not in the sorted indexes.
+func complement(indexes []int, n int) []int {
+	out := make([]int, 0, n-len(indexes))
+	j := 0
+	for i := 0; i < n; i++ {
+		if j < len(indexes) && indexes[j] == i {
+			j++
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// epochOrder returns the indexes of the rows sorted by epoch, and by nanoseconds for the variable-length records.
+func epochOrder(b *batch) []int {
+	epochs := b.cs.GetEpoch()
+	nanos, _ := b.cs.GetColumn("Nanoseconds").([]int32)
+	order := make([]int, len(epochs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return before(epochs, nanos, order[i], order[j])
+	})
+	return order
+}
+
+// before returns true if the row i is earlier than the row j.
+func before(epochs []int64, nanos []int32, i, j int) bool {
+	if epochs[i] != epochs[j] || nanos == nil {
+		return epochs[i] < epochs[j]
+	}
+	return nanos[i] < nanos[j]
+}
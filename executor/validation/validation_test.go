@@ -0,0 +1,341 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// fakeStore returns the written column series of the time buckets regardless of the range.
+type fakeStore struct {
+	written map[string]*io.ColumnSeries
+	reads   int
+}
+
+func (s *fakeStore) Read(tbk *io.TimeBucketKey, start, end int64, last int) (*io.ColumnSeries, error) {
+	s.reads++
+	cs := s.written[tbk.GetItemKey()]
+	if cs == nil || last <= 0 || cs.Len() <= last {
+		return cs, nil
+	}
+	rows := make([]int, 0, last)
+	for i := cs.Len() - last; i < cs.Len(); i++ {
+		rows = append(rows, i)
+	}
+	return cs.SelectRows(rows), nil
+}
+
+func float64p(v float64) *float64 {
+	return &v
+}
+
+func newOHLC(epochs []int64, open, high, low, cl []float32) *io.ColumnSeries {
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", epochs)
+	cs.AddColumn("Open", open)
+	cs.AddColumn("High", high)
+	cs.AddColumn("Low", low)
+	cs.AddColumn("Close", cl)
+	return cs
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		settings []*utils.WriteValidationSetting
+		store    Store
+		wantErr  bool
+	}{
+		"ok/ no setting": {},
+		"ok/ all the checks": {
+			settings: []*utils.WriteValidationSetting{{On: "*/1Min/OHLCV", Rules: []*utils.ValidationRuleSetting{
+				{Check: CheckRange, Columns: []string{"Close"}, Min: float64p(0), Action: Fix},
+				{Check: CheckOHLC, Action: Fix},
+				{Check: CheckMonotonic, Action: Reject},
+				{Check: CheckMaxJump, MaxChange: 0.5, Action: Quarantine},
+				{Check: CheckDedup, Action: Fix},
+			}}},
+			store: &fakeStore{},
+		},
+		"ng/ invalid on": {
+			settings: []*utils.WriteValidationSetting{{On: "[", Rules: []*utils.ValidationRuleSetting{
+				{Check: CheckMonotonic, Action: Reject},
+			}}},
+			wantErr: true,
+		},
+		"ng/ unknown check": {
+			settings: []*utils.WriteValidationSetting{{On: "*/*/*", Rules: []*utils.ValidationRuleSetting{
+				{Check: "spread", Action: Reject},
+			}}},
+			wantErr: true,
+		},
+		"ng/ unknown action": {
+			settings: []*utils.WriteValidationSetting{{On: "*/*/*", Rules: []*utils.ValidationRuleSetting{
+				{Check: CheckMonotonic, Action: "drop"},
+			}}},
+			wantErr: true,
+		},
+		"ng/ range without min, max or nonzero": {
+			settings: []*utils.WriteValidationSetting{{On: "*/*/*", Rules: []*utils.ValidationRuleSetting{
+				{Check: CheckRange, Columns: []string{"Close"}, Action: Reject},
+			}}},
+			wantErr: true,
+		},
+		"ng/ min is greater than max": {
+			settings: []*utils.WriteValidationSetting{{On: "*/*/*", Rules: []*utils.ValidationRuleSetting{
+				{Check: CheckRange, Columns: []string{"Close"}, Min: float64p(2), Max: float64p(1), Action: Reject},
+			}}},
+			wantErr: true,
+		},
+		"ng/ max_jump can't be fixed": {
+			settings: []*utils.WriteValidationSetting{{On: "*/*/*", Rules: []*utils.ValidationRuleSetting{
+				{Check: CheckMaxJump, MaxChange: 0.5, Action: Fix},
+			}}},
+			store:   &fakeStore{},
+			wantErr: true,
+		},
+		"ng/ max_jump without a store": {
+			settings: []*utils.WriteValidationSetting{{On: "*/*/*", Rules: []*utils.ValidationRuleSetting{
+				{Check: CheckMaxJump, MaxChange: 0.5, Action: Reject},
+			}}},
+			wantErr: true,
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- when ---
+			_, err := New(tt.settings, tt.store)
+
+			// --- then ---
+			if tt.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rule             *utils.ValidationRuleSetting
+		cs               *io.ColumnSeries
+		isVariableLength bool
+		written          *io.ColumnSeries
+		wantEpochs       []int64
+		wantColumn       string
+		wantValues       interface{}
+		wantQuarantined  []int64
+		wantViolation    *Violation
+	}{
+		"ok/ no violation": {
+			rule: &utils.ValidationRuleSetting{Check: CheckRange, Columns: []string{"Close"}, NonZero: true, Action: Reject},
+			cs: newOHLC([]int64{60, 120}, []float32{1, 2}, []float32{1, 2},
+				[]float32{1, 2}, []float32{1, 2}),
+			wantEpochs: []int64{60, 120},
+		},
+		"ok/ range reject": {
+			rule: &utils.ValidationRuleSetting{Check: CheckRange, Columns: []string{"Close"}, NonZero: true, Action: Reject},
+			cs: newOHLC([]int64{60, 120, 180}, []float32{1, 2, 3}, []float32{1, 2, 3},
+				[]float32{1, 2, 3}, []float32{1, 0, 3}),
+			wantEpochs: []int64{60, 180},
+			wantViolation: &Violation{
+				Key: "AAPL/1Min/OHLCV", Rule: CheckRange, Action: Reject, Rows: 1, Epoch: 120,
+				Message: "Close=0 is out of range",
+			},
+		},
+		"ok/ range fix clamps the values": {
+			rule: &utils.ValidationRuleSetting{
+				Name: "price", Check: CheckRange, Columns: []string{"Low", "High"},
+				Min: float64p(1), Max: float64p(10), Action: Fix,
+			},
+			cs: newOHLC([]int64{60, 120}, []float32{2, 2}, []float32{3, 12},
+				[]float32{-1, 2}, []float32{2, 2}),
+			wantEpochs: []int64{60, 120},
+			wantColumn: "High",
+			wantValues: []float32{3, 10},
+			wantViolation: &Violation{
+				Key: "AAPL/1Min/OHLCV", Rule: "price", Action: Fix, Rows: 2, Epoch: 60,
+				Message: "Low=-1 is out of range",
+			},
+		},
+		"ok/ ohlc fix": {
+			rule: &utils.ValidationRuleSetting{Check: CheckOHLC, Action: Fix},
+			cs: newOHLC([]int64{60, 120}, []float32{2, 2}, []float32{3, 1},
+				[]float32{1, 3}, []float32{2, 2}),
+			wantEpochs: []int64{60, 120},
+			wantColumn: "High",
+			wantValues: []float32{3, 3},
+			wantViolation: &Violation{
+				Key: "AAPL/1Min/OHLCV", Rule: CheckOHLC, Action: Fix, Rows: 1, Epoch: 120,
+				Message: "inconsistent Open=2 High=1 Low=3 Close=2",
+			},
+		},
+		"ok/ ohlc quarantine": {
+			rule: &utils.ValidationRuleSetting{Check: CheckOHLC, Action: Quarantine},
+			cs: newOHLC([]int64{60, 120}, []float32{2, 2}, []float32{3, 1},
+				[]float32{1, 3}, []float32{2, 2}),
+			wantEpochs:      []int64{60},
+			wantQuarantined: []int64{120},
+			wantViolation: &Violation{
+				Key: "AAPL/1Min/OHLCV", Rule: CheckOHLC, Action: Quarantine, Rows: 1, Epoch: 120,
+				Message: "inconsistent Open=2 High=1 Low=3 Close=2",
+			},
+		},
+		"ok/ monotonic fix sorts and keeps the last of the same epoch": {
+			rule: &utils.ValidationRuleSetting{Check: CheckMonotonic, Action: Fix},
+			cs: newOHLC([]int64{120, 60, 120}, []float32{1, 2, 3}, []float32{1, 2, 3},
+				[]float32{1, 2, 3}, []float32{1, 2, 3}),
+			wantEpochs: []int64{60, 120},
+			wantColumn: "Close",
+			wantValues: []float32{2, 3},
+			wantViolation: &Violation{
+				Key: "AAPL/1Min/OHLCV", Rule: CheckMonotonic, Action: Fix, Rows: 2, Epoch: 60,
+				Message: "epoch 60 is not after 120",
+			},
+		},
+		"ok/ max_jump quarantine compares with the written row": {
+			rule: &utils.ValidationRuleSetting{Check: CheckMaxJump, MaxChange: 0.5, Action: Quarantine},
+			cs: newOHLC([]int64{120, 180, 240}, []float32{1, 1, 1}, []float32{1, 1, 1},
+				[]float32{1, 1, 1}, []float32{100, 1000, 120}),
+			written: newOHLC([]int64{60}, []float32{1}, []float32{1},
+				[]float32{1}, []float32{90}),
+			wantEpochs:      []int64{120, 240},
+			wantQuarantined: []int64{180},
+			wantViolation: &Violation{
+				Key: "AAPL/1Min/OHLCV", Rule: CheckMaxJump, Action: Quarantine, Rows: 1, Epoch: 180,
+				Message: "Close=1000 changed 900.0% from 100",
+			},
+		},
+		"ok/ dedup fix drops the duplicates in the write and of the written records": {
+			rule: &utils.ValidationRuleSetting{Check: CheckDedup, Action: Fix},
+			cs: func() *io.ColumnSeries {
+				cs := newOHLC([]int64{60, 60, 60, 120}, []float32{1, 2, 2, 1}, []float32{1, 2, 2, 1},
+					[]float32{1, 2, 2, 1}, []float32{1, 2, 2, 1})
+				cs.AddColumn("Nanoseconds", []int32{0, 5, 5, 0})
+				return cs
+			}(),
+			isVariableLength: true,
+			written: func() *io.ColumnSeries {
+				cs := newOHLC([]int64{60}, []float32{1}, []float32{1}, []float32{1}, []float32{1})
+				cs.AddColumn("Nanoseconds", []int32{0})
+				return cs
+			}(),
+			wantEpochs: []int64{60, 120},
+			wantColumn: "Close",
+			wantValues: []float32{2, 1},
+			wantViolation: &Violation{
+				Key: "AAPL/1Min/OHLCV", Rule: CheckDedup, Action: Fix, Rows: 2, Epoch: 60,
+				Message: "duplicate record at epoch 60",
+			},
+		},
+		"ok/ dedup ignores fixed-length records": {
+			rule: &utils.ValidationRuleSetting{Check: CheckDedup, Action: Reject},
+			cs: newOHLC([]int64{60, 60}, []float32{1, 1}, []float32{1, 1},
+				[]float32{1, 1}, []float32{1, 1}),
+			wantEpochs: []int64{60, 60},
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			tbk := io.NewTimeBucketKey("AAPL/1Min/OHLCV")
+			store := &fakeStore{written: map[string]*io.ColumnSeries{}}
+			if tt.written != nil {
+				store.written[tbk.GetItemKey()] = tt.written
+			}
+			v, err := New([]*utils.WriteValidationSetting{
+				{On: "*/1Min/*", Rules: []*utils.ValidationRuleSetting{tt.rule}},
+			}, store)
+			require.Nil(t, err)
+
+			// --- when ---
+			res, err := v.Validate(tbk, tt.cs, tt.isVariableLength)
+
+			// --- then ---
+			require.Nil(t, err)
+			assert.Equal(t, tt.wantEpochs, res.Valid.GetEpoch())
+			if tt.wantColumn != "" {
+				assert.Equal(t, tt.wantValues, res.Valid.GetColumn(tt.wantColumn))
+			}
+			if tt.wantQuarantined != nil {
+				require.Len(t, res.Quarantined, 1)
+				assert.Equal(t, tt.wantQuarantined, res.Quarantined[0].GetEpoch())
+			} else {
+				assert.Empty(t, res.Quarantined)
+			}
+			if tt.wantViolation != nil {
+				assert.Equal(t, []Violation{*tt.wantViolation}, res.Violations)
+			} else {
+				assert.Empty(t, res.Violations)
+			}
+		})
+	}
+}
+
+func TestValidator_Validate_Rules(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	store := &fakeStore{}
+	v, err := New([]*utils.WriteValidationSetting{
+		{On: "*/1Min/OHLCV", Rules: []*utils.ValidationRuleSetting{
+			{Check: CheckOHLC, Action: Fix},
+			{Check: CheckRange, Columns: []string{"Close"}, NonZero: true, Action: Reject},
+		}},
+		{On: "AAPL/*/*", Rules: []*utils.ValidationRuleSetting{
+			{Check: CheckMaxJump, MaxChange: 0.5, Action: Reject},
+		}},
+	}, store)
+	require.Nil(t, err)
+	tbk := io.NewTimeBucketKey("AAPL/1Min/OHLCV")
+	cs := newOHLC([]int64{60, 120, 180}, []float32{1, 1, 1}, []float32{1, 1, 1},
+		[]float32{1, 1, 1}, []float32{10, 0, 11})
+
+	// --- when ---
+	res, err := v.Validate(tbk, cs, false)
+
+	// --- then ---
+	// the ohlc fix, then the range rejects Close=0, then the max_jump checks the rest
+	require.Nil(t, err)
+	assert.True(t, v.Enabled(tbk))
+	assert.False(t, v.Enabled(io.NewTimeBucketKey("TSLA/1D/OHLCV")))
+	assert.Equal(t, []int64{60, 180}, res.Valid.GetEpoch())
+	assert.Equal(t, []float32{10, 11}, res.Valid.GetColumn("High"))
+	require.Len(t, res.Violations, 2)
+	assert.Equal(t, CheckOHLC, res.Violations[0].Rule)
+	assert.Equal(t, 3, res.Violations[0].Rows)
+	assert.Equal(t, CheckRange, res.Violations[1].Rule)
+	assert.Equal(t, 1, store.reads)
+
+	// --- when ---
+	// the last value is remembered for the next write
+	res, err = v.Validate(tbk, newOHLC([]int64{240}, []float32{1}, []float32{30},
+		[]float32{1}, []float32{30}), false)
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, 1, store.reads)
+	assert.Zero(t, res.Valid.Len())
+	require.Len(t, res.Violations, 1)
+	assert.Equal(t, "Close=30 changed 172.7% from 11", res.Violations[0].Message)
+}
+
+func TestQuarantineKey(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "AAPL/1Min/OHLCV_QUARANTINE",
+		QuarantineKey(io.NewTimeBucketKey("AAPL/1Min/OHLCV")).GetItemKey())
+}
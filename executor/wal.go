@@ -42,6 +42,37 @@ type WALFileType struct {
 	walWaitGroup      *sync.WaitGroup
 	tpd               *TriggerPluginDispatcher
 	txnPipe           *TransactionPipe
+	flushListenerMu   sync.Mutex
+	flushListeners    []FlushListener
+	// syncFlushMu serializes the flushes done by the callers when there's no WAL writer goroutine,
+	// so that a flush doesn't return while the commands taken by another one are still being written
+	syncFlushMu sync.Mutex
+}
+
+// FlushListener is notified of the data files written by each flush of the WAL,
+// after the written data is visible to the readers.
+type FlushListener interface {
+	// Flushed is called with the years of the data files written for each time bucket key,
+	// including the deletions
+	Flushed(written map[io.TimeBucketKey][]int16)
+}
+
+// RecordsFlushListener is a FlushListener that is also notified of the records written by each flush of the WAL.
+type RecordsFlushListener interface {
+	FlushListener
+	// FlushedRecords is called with the records written to each data file, keyed by the full path of the file
+	FlushedRecords(written map[string]*FlushedRecords)
+}
+
+// FlushedRecords summarizes the records written to a data file by a flush of the WAL.
+type FlushedRecords struct {
+	RecordType io.EnumRecordType
+	// Indexes are the indexes of the written records in the data file,
+	// and Rows are the number of the rows written at each of them, which can be more than 1 for a VARIABLE record type
+	Indexes []int64
+	Rows    []int64
+	// Deleted is true if some records are deleted by the flush
+	Deleted bool
 }
 
 type ReplicationSender interface {
@@ -215,6 +246,13 @@ func walKeyToFullPath(rootPath, keyPath string) (fullPath string) {
 	return filepath.Join(rootPath, keyPath)
 }
 
+// AddFlushListener registers the listener to be notified of each flush.
+func (wf *WALFileType) AddFlushListener(l FlushListener) {
+	wf.flushListenerMu.Lock()
+	defer wf.flushListenerMu.Unlock()
+	wf.flushListeners = append(wf.flushListeners, l)
+}
+
 func (wf *WALFileType) QueueWriteCommand(wc *wal.WriteCommand) {
 	wf.txnPipe.writeChannel <- wc
 }
@@ -323,6 +361,10 @@ func (wf *WALFileType) FlushCommandsToWAL(writeCommands []*wal.WriteCommand) (er
 	/*
 		Write the buffers to primary files (should happen after WAL writes)
 	*/
+	var flushedRecords map[string]*FlushedRecords
+	if wf.hasRecordsFlushListener() {
+		flushedRecords = make(map[string]*FlushedRecords, len(writesPerFile))
+	}
 	for keyPath, writes := range writesPerFile {
 		recordType := fileRecordTypes[keyPath]
 		varRecLen := varRecLens[keyPath]
@@ -330,18 +372,88 @@ func (wf *WALFileType) FlushCommandsToWAL(writeCommands []*wal.WriteCommand) (er
 			// TODO: what should we do if the write commit partially failed?
 			log.Error(fmt.Sprintf("failed to write data to file %s: %s", keyPath, err.Error()))
 		}
+		if flushedRecords != nil {
+			flushedRecords[walKeyToFullPath(wf.rootDir, keyPath)] = summarizeWrites(writes, recordType, varRecLen)
+		}
 		for i, buffer := range writes {
-			wf.tpd.AppendRecord(keyPath, buffer.IndexAndPayload())
+			if !buffer.IsDeletion() {
+				wf.tpd.AppendRecord(keyPath, buffer.IndexAndPayload())
+			}
 			writes[i] = nil // for GC
 		}
 		writesPerFile[keyPath] = nil // for GC
 	}
+	wf.notifyFlushListeners(fileRecordTypes, flushedRecords)
 	return nil
 }
 
+// summarizeWrites returns the indexes and the number of the rows of the records written by the buffers.
+func summarizeWrites(writes []wal.OffsetIndexBuffer, recordType io.EnumRecordType, varRecLen int) *FlushedRecords {
+	fr := &FlushedRecords{
+		RecordType: recordType,
+		Indexes:    make([]int64, 0, len(writes)),
+		Rows:       make([]int64, 0, len(writes)),
+	}
+	for _, buffer := range writes {
+		if buffer.IsDeletion() {
+			fr.Deleted = true
+			continue
+		}
+		rows := int64(1)
+		if recordType == io.VARIABLE && varRecLen > 0 {
+			rows = int64(len(buffer.Payload()) / varRecLen)
+		}
+		fr.Indexes = append(fr.Indexes, buffer.Index())
+		fr.Rows = append(fr.Rows, rows)
+	}
+	return fr
+}
+
+func (wf *WALFileType) hasRecordsFlushListener() bool {
+	wf.flushListenerMu.Lock()
+	defer wf.flushListenerMu.Unlock()
+	for _, l := range wf.flushListeners {
+		if _, ok := l.(RecordsFlushListener); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (wf *WALFileType) notifyFlushListeners(keyPaths map[string]io.EnumRecordType,
+	flushedRecords map[string]*FlushedRecords,
+) {
+	wf.flushListenerMu.Lock()
+	listeners := wf.flushListeners
+	wf.flushListenerMu.Unlock()
+	if len(listeners) == 0 {
+		return
+	}
+	written := map[io.TimeBucketKey][]int16{}
+	for keyPath := range keyPaths {
+		tbk, year, err := io.NewTimeBucketKeyFromWalKeyPath(keyPath)
+		if err != nil {
+			log.Error("failed to notify the flush of %s: %v", keyPath, err)
+			continue
+		}
+		written[*tbk] = append(written[*tbk], int16(year))
+	}
+	for _, l := range listeners {
+		if rl, ok := l.(RecordsFlushListener); ok && flushedRecords != nil {
+			rl.FlushedRecords(flushedRecords)
+		}
+		l.Flushed(written)
+	}
+}
+
 func serializeTG(tgID int64, commands []*wal.WriteCommand,
 ) (tgSerialized2 []byte, writesPerFile map[string][]wal.OffsetIndexBuffer) {
 	WTCount := len(commands)
+	for _, wc := range commands {
+		if wc.Replace {
+			WTCount++
+		}
+	}
 
 	// Serialize all data to be written except for the size of this buffer
 	var tgSerialized []byte
@@ -351,32 +463,49 @@ func serializeTG(tgID int64, commands []*wal.WriteCommand,
 	/*
 		This loop serializes write transactions from the channel for writing to disk
 	*/
-	for i := 0; i < WTCount; i++ {
-		tgSerialized, _ = io.Serialize(tgSerialized, int8(commands[i].RecordType))
-		tgSerialized, _ = io.Serialize(tgSerialized, int16(len(commands[i].WALKeyPath)))
-		tgSerialized, _ = io.Serialize(tgSerialized, commands[i].WALKeyPath)
-		tgSerialized, _ = io.Serialize(tgSerialized, int32(len(commands[i].Data)))
-		tgSerialized, _ = io.Serialize(tgSerialized, int32(commands[i].VarRecLen))
-		oStart := len(tgSerialized)
-		bufferSize := 8 + 8 + len(commands[i].Data)
-		tgSerialized, _ = io.Serialize(tgSerialized, commands[i].Offset)
-		tgSerialized, _ = io.Serialize(tgSerialized, commands[i].Index)
-		tgSerialized = append(tgSerialized, commands[i].Data...)
-		// include DataShape information in TG because it's necessary for creating a new bucket from WAL
-		dsvBytes, err := io.DSVToBytes(commands[i].DataShapes)
-		if err == nil {
-			tgSerialized = append(tgSerialized, dsvBytes...)
+	for _, wc := range commands {
+		if wc.Replace {
+			// clear the records at the index before writing the new ones in the same transaction group
+			deletion := &wal.WriteCommand{
+				RecordType: wc.RecordType,
+				WALKeyPath: wc.WALKeyPath,
+				VarRecLen:  wc.VarRecLen,
+				Offset:     wc.Offset,
+				Index:      0,
+				DataShapes: wc.DataShapes,
+			}
+			tgSerialized = serializeWT(tgSerialized, deletion, writesPerFile)
 		}
-
-		keyPath := commands[i].WALKeyPath
-		// Store the data in a buffer for primary storage writes after WAL writes are done
-		writesPerFile[keyPath] = append(writesPerFile[keyPath],
-			tgSerialized[oStart:oStart+bufferSize])
+		tgSerialized = serializeWT(tgSerialized, wc, writesPerFile)
 	}
 
 	return tgSerialized, writesPerFile
 }
 
+// serializeWT appends the write transaction of the command to the serialized transaction group,
+// and stores its buffer in writesPerFile for primary storage writes after WAL writes are done.
+func serializeWT(tgSerialized []byte, wc *wal.WriteCommand, writesPerFile map[string][]wal.OffsetIndexBuffer) []byte {
+	tgSerialized, _ = io.Serialize(tgSerialized, int8(wc.RecordType))
+	tgSerialized, _ = io.Serialize(tgSerialized, int16(len(wc.WALKeyPath)))
+	tgSerialized, _ = io.Serialize(tgSerialized, wc.WALKeyPath)
+	tgSerialized, _ = io.Serialize(tgSerialized, int32(len(wc.Data)))
+	tgSerialized, _ = io.Serialize(tgSerialized, int32(wc.VarRecLen))
+	oStart := len(tgSerialized)
+	bufferSize := 8 + 8 + len(wc.Data)
+	tgSerialized, _ = io.Serialize(tgSerialized, wc.Offset)
+	tgSerialized, _ = io.Serialize(tgSerialized, wc.Index)
+	tgSerialized = append(tgSerialized, wc.Data...)
+	// include DataShape information in TG because it's necessary for creating a new bucket from WAL
+	dsvBytes, err := io.DSVToBytes(wc.DataShapes)
+	if err == nil {
+		tgSerialized = append(tgSerialized, dsvBytes...)
+	}
+
+	writesPerFile[wc.WALKeyPath] = append(writesPerFile[wc.WALKeyPath],
+		tgSerialized[oStart:oStart+bufferSize])
+	return tgSerialized
+}
+
 func writeFixedBuffer(writes []wal.OffsetIndexBuffer, fullPath string) error {
 	const batchThreshold = 100
 
@@ -786,6 +915,8 @@ func (wf *WALFileType) SyncWAL(walRefresh, primaryRefresh time.Duration, walRota
 // present in the write channel, as it will flush as soon as possible.
 func (wf *WALFileType) RequestFlush() {
 	if !haveWALWriter {
+		wf.syncFlushMu.Lock()
+		defer wf.syncFlushMu.Unlock()
 		if err := wf.FlushToWAL(); err != nil {
 			log.Error("failed to flush WAL", zap.Error(err))
 		}
@@ -800,6 +931,22 @@ func (wf *WALFileType) RequestFlush() {
 	<-f
 }
 
+// WaitFlush requests WAL Flush as RequestFlush does, but always waits for the flush
+// so that the writes queued before the call are in the primary storage when it returns.
+func (wf *WALFileType) WaitFlush() {
+	if !haveWALWriter {
+		wf.syncFlushMu.Lock()
+		defer wf.syncFlushMu.Unlock()
+		if err := wf.FlushToWAL(); err != nil {
+			log.Error("failed to flush WAL", zap.Error(err))
+		}
+		return
+	}
+	f := make(chan struct{})
+	wf.txnPipe.flushChannel <- f
+	<-f
+}
+
 func (wf *WALFileType) Shutdown() {
 	*wf.shutdownPending = true
 	wf.walWaitGroup.Wait()
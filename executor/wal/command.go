@@ -23,12 +23,16 @@ type WriteCommand struct {
 	Data []byte
 	// DataShapes with Epoch column
 	DataShapes []io.DataShape
+	// Replace is true if the records at the index are replaced with Data instead of Data being appended to them.
+	// Used only in case of VARIABLE recordType. It's written to the WAL as a deletion (see OffsetIndexBuffer.IsDeletion)
+	// followed by the write in the same transaction group, so that the replacement is atomic.
+	Replace bool
 }
 
 // Convert WriteCommand to string for debuging/presentation.
 func (wc *WriteCommand) String() string {
-	return fmt.Sprintf("WC[%v] WALKeyPath:%s (len:%d, off:%d, idx:%d, dsize:%d)",
-		wc.RecordType, wc.WALKeyPath, wc.VarRecLen, wc.Offset, wc.Index, len(wc.Data),
+	return fmt.Sprintf("WC[%v] WALKeyPath:%s (len:%d, off:%d, idx:%d, dsize:%d, replace:%v)",
+		wc.RecordType, wc.WALKeyPath, wc.VarRecLen, wc.Offset, wc.Index, len(wc.Data), wc.Replace,
 	)
 }
 
@@ -27,3 +27,18 @@ func (b OffsetIndexBuffer) IndexAndPayload() []byte {
 func (b OffsetIndexBuffer) Payload() []byte {
 	return b[16:]
 }
+
+// IsDeletion returns true if the buffer deletes the record(s) at the offset instead of writing them.
+// A deletion has index 0 and a zero-filled payload, which is an empty record for a FIXED record type,
+// or an empty payload for a VARIABLE record type.
+func (b OffsetIndexBuffer) IsDeletion() bool {
+	if b.Index() != 0 {
+		return false
+	}
+	for _, v := range b.Payload() {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
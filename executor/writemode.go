@@ -0,0 +1,307 @@
+package executor
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// WriteMode is how the records written to a variable-length time bucket are combined
+// with the records already written to the same intervals. The records of the fixed-length
+// time buckets are always overwritten, so the mode is ignored for them.
+type WriteMode string
+
+const (
+	// WriteModeAppend appends the records to the records already written to the intervals.
+	WriteModeAppend WriteMode = "append"
+	// WriteModeReplace atomically replaces all the records in each interval touched by the write.
+	WriteModeReplace WriteMode = "replace"
+	// WriteModeDedupe replaces the records already written that have the same dedupe key as
+	// a written record, and appends the others. It's not safe against the concurrent writes
+	// to the same intervals because the records already written are read before the write.
+	WriteModeDedupe WriteMode = "dedupe"
+)
+
+// defaultDedupeKey identifies a record of the variable-length time buckets by its timestamp.
+var defaultDedupeKey = []string{"Epoch", "Nanoseconds"}
+
+// WriteOptions are the options of a write by Writer.WriteCSMWithOptions.
+// The zero value appends the records.
+type WriteOptions struct {
+	Mode WriteMode
+	// DedupeKey is the columns that identify a record in WriteModeDedupe, e.g. Epoch, Nanoseconds and Exchange.
+	// Epoch and Nanoseconds are used if it's empty.
+	DedupeKey []string
+	// QueueOnly queues the records to the WAL without requesting the WAL flush, e.g. for the chunks of a stream.
+	// The records are flushed by the next flush, so the caller needs to call WaitFlush to make sure they're written.
+	QueueOnly bool
+}
+
+// ParseWriteMode returns the write mode of the string. An empty string is WriteModeAppend.
+func ParseWriteMode(mode string) (WriteMode, error) {
+	switch WriteMode(strings.ToLower(mode)) {
+	case "", WriteModeAppend:
+		return WriteModeAppend, nil
+	case WriteModeReplace:
+		return WriteModeReplace, nil
+	case WriteModeDedupe:
+		return WriteModeDedupe, nil
+	default:
+		return "", fmt.Errorf("unknown write mode %q: must be one of %s, %s or %s",
+			mode, WriteModeAppend, WriteModeReplace, WriteModeDedupe)
+	}
+}
+
+// writeCSMInMode writes the csm in the write mode of the options without the validation.
+func (w *Writer) writeCSMInMode(csm io.ColumnSeriesMap, isVariableLength bool, opts WriteOptions) error {
+	mode, err := ParseWriteMode(string(opts.Mode))
+	if err != nil {
+		return err
+	}
+	if !isVariableLength || mode == WriteModeAppend {
+		return w.writeCSM(csm, isVariableLength, false)
+	}
+
+	if mode == WriteModeDedupe {
+		// the records in the WAL need to be in the primary storage to be read
+		w.walFile.WaitFlush()
+		deduped := io.NewColumnSeriesMap()
+		for key, cs := range csm {
+			tbk := w.rootCatDir.ResolveKey(&key)
+			merged, err := w.dedupe(tbk, cs, opts.DedupeKey)
+			if err != nil {
+				return fmt.Errorf("dedupe the records of %s: %w", tbk.GetItemKey(), err)
+			}
+			deduped[*tbk] = merged
+		}
+		csm = deduped
+	}
+	return w.writeCSM(csm, isVariableLength, true)
+}
+
+// dedupe merges the records of the cs with the records already written to the intervals touched by the cs.
+// The records already written are dropped if a record of the cs has the same dedupe key,
+// and only the last one of the records of the cs that have the same dedupe key is kept.
+func (w *Writer) dedupe(tbk *io.TimeBucketKey, cs *io.ColumnSeries, dedupeKey []string) (*io.ColumnSeries, error) {
+	if len(dedupeKey) == 0 {
+		dedupeKey = defaultDedupeKey
+	}
+	if cs.Len() == 0 {
+		return cs, nil
+	}
+	tf, err := tbk.GetTimeFrame()
+	if err != nil {
+		return nil, err
+	}
+	if !cs.Exists("Nanoseconds") {
+		cs.AddColumn("Nanoseconds", make([]int32, cs.Len()))
+	}
+	for _, name := range dedupeKey {
+		if !cs.Exists(name) {
+			return nil, fmt.Errorf("dedupe key column %s is not in the written records", name)
+		}
+	}
+
+	written, err := w.writtenRecords(tbk, cs, tf)
+	if err != nil {
+		return nil, err
+	}
+	if written != nil {
+		if err = alignColumns(cs, written); err != nil {
+			return nil, err
+		}
+	}
+
+	// the timestamps of the records are compared at the precision stored in the time bucket
+	newTimes, err := storedTimes(cs, tf)
+	if err != nil {
+		return nil, err
+	}
+	newKeys := make([]string, cs.Len())
+	lastRow := map[string]int{}
+	for i := range newKeys {
+		newKeys[i] = dedupeKeyOf(cs, dedupeKey, i, newTimes[i])
+		lastRow[newKeys[i]] = i
+	}
+	newRows := make([]int, 0, len(lastRow))
+	for i, key := range newKeys {
+		if lastRow[key] == i {
+			newRows = append(newRows, i)
+		}
+	}
+	if written == nil {
+		return cs.SelectRows(newRows), nil
+	}
+
+	// the records already written are read at the precision stored in the time bucket
+	writtenTimes, err := written.GetTime()
+	if err != nil {
+		return nil, err
+	}
+	keptRows := make([]int, 0, written.Len())
+	for i := 0; i < written.Len(); i++ {
+		if _, found := lastRow[dedupeKeyOf(written, dedupeKey, i, writtenTimes[i])]; !found {
+			keptRows = append(keptRows, i)
+		}
+	}
+	return concatRows(written.SelectRows(keptRows), cs.SelectRows(newRows))
+}
+
+// writtenRecords returns the records already written to the intervals touched by the cs,
+// or nil if there is no record.
+func (w *Writer) writtenRecords(tbk *io.TimeBucketKey, cs *io.ColumnSeries, tf *utils.Timeframe,
+) (*io.ColumnSeries, error) {
+	type interval struct {
+		year  int
+		index int64
+	}
+	touched := map[interval]bool{}
+	var first, last time.Time
+	for i, epoch := range cs.GetEpoch() {
+		t := time.Unix(epoch, 0)
+		touched[interval{year: io.ToSystemTimezone(t).Year(), index: io.TimeToIndex(t, tf.Duration)}] = true
+		if i == 0 || t.Before(first) {
+			first = t
+		}
+		if i == 0 || t.After(last) {
+			last = t
+		}
+	}
+	firstIndex := io.TimeToIndex(first, tf.Duration)
+	lastIndex := io.TimeToIndex(last, tf.Duration)
+	start := io.IndexToTime(firstIndex, tf.Duration, int16(io.ToSystemTimezone(first).Year()))
+	end := io.IndexToTime(lastIndex+1, tf.Duration, int16(io.ToSystemTimezone(last).Year())).Add(-time.Second)
+
+	written, err := (&catalogStore{catDir: w.rootCatDir}).Read(tbk, start.Unix(), end.Unix(), 0)
+	if err != nil || written == nil || written.Len() == 0 {
+		return nil, err
+	}
+	var rows []int
+	for i, epoch := range written.GetEpoch() {
+		t := time.Unix(epoch, 0)
+		if touched[interval{year: io.ToSystemTimezone(t).Year(), index: io.TimeToIndex(t, tf.Duration)}] {
+			rows = append(rows, i)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return written.SelectRows(rows), nil
+}
+
+// alignColumns coerces the columns of the cs to the types of the written records,
+// and adds the nullable columns missing in the cs as null columns.
+func alignColumns(cs, written *io.ColumnSeries) error {
+	for _, ds := range written.GetDataShapes() {
+		if !cs.Exists(ds.Name) {
+			if !written.IsNullable(ds.Name) {
+				return fmt.Errorf("column %s of the time bucket is missing", ds.Name)
+			}
+			ds.Nullable = true
+			cs.AddNullColumn(ds)
+			continue
+		}
+		if io.GetElementType(cs.GetColumn(ds.Name)) == ds.Type {
+			continue
+		}
+		if err := cs.CoerceColumn(ds); err != nil {
+			return fmt.Errorf("coerce column %s to %s: %w", ds.Name, ds.Type.String(), err)
+		}
+	}
+	for _, name := range cs.GetColumnNames() {
+		if !written.Exists(name) {
+			return fmt.Errorf("column %s is not in the time bucket", name)
+		}
+	}
+	return nil
+}
+
+// storedTimes returns the epochs and nanoseconds of the records as they are read after written to
+// the time bucket, where the time in an interval is stored as 32-bit interval ticks.
+func storedTimes(cs *io.ColumnSeries, tf *utils.Timeframe) ([]time.Time, error) {
+	times, err := cs.GetTime()
+	if err != nil {
+		return nil, err
+	}
+	intervalsPerDay := int64(utils.Day / tf.Duration)
+	for i, t := range times {
+		index := io.TimeToIndex(t, tf.Duration)
+		year := int16(io.ToSystemTimezone(t).Year())
+		ticks := io.GetIntervalTicks32Bit(t, index, intervalsPerDay)
+		intervalStart := io.IndexToTime(index, tf.Duration, year).Unix()
+		sec, nanos := GetTimeFromTicks(uint64(intervalStart), uint32(intervalsPerDay), ticks)
+		times[i] = time.Unix(int64(sec), int64(nanos))
+	}
+	return times, nil
+}
+
+// dedupeKeyOf returns the string of the values of the dedupe key columns at the row.
+func dedupeKeyOf(cs *io.ColumnSeries, dedupeKey []string, i int, t time.Time) string {
+	var sb strings.Builder
+	for _, name := range dedupeKey {
+		switch {
+		case name == "Epoch":
+			fmt.Fprint(&sb, t.Unix())
+		case name == "Nanoseconds":
+			fmt.Fprint(&sb, t.Nanosecond())
+		case cs.IsNull(name, i):
+			sb.WriteString("null")
+		default:
+			fmt.Fprint(&sb, reflect.ValueOf(cs.GetColumn(name)).Index(i).Interface())
+		}
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+// concatRows returns the column series of the rows of the head followed by the rows of the tail,
+// which have the same columns as the head.
+func concatRows(head, tail *io.ColumnSeries) (*io.ColumnSeries, error) {
+	out := io.NewColumnSeries()
+	for _, name := range head.GetColumnNames() {
+		headCol, tailCol := reflect.ValueOf(head.GetColumn(name)), reflect.ValueOf(tail.GetColumn(name))
+		if headCol.Type() != tailCol.Type() {
+			return nil, fmt.Errorf("column %s has different types: %v, %v", name, headCol.Type(), tailCol.Type())
+		}
+		col := reflect.MakeSlice(headCol.Type(), 0, headCol.Len()+tailCol.Len())
+		out.AddColumn(name, reflect.AppendSlice(reflect.AppendSlice(col, headCol), tailCol).Interface())
+		if head.IsNullable(name) || tail.IsNullable(name) {
+			mask := make([]bool, 0, headCol.Len()+tailCol.Len())
+			mask = append(mask, nullMaskOf(head, name)...)
+			mask = append(mask, nullMaskOf(tail, name)...)
+			out.SetNullMask(name, mask)
+		}
+	}
+	return sortByTime(out), nil
+}
+
+func nullMaskOf(cs *io.ColumnSeries, name string) []bool {
+	if mask := cs.GetNullMask(name); mask != nil {
+		return mask
+	}
+	return make([]bool, cs.Len())
+}
+
+// sortByTime returns the column series whose rows are sorted stably by Epoch and Nanoseconds,
+// so that the rows of an interval are written by a write command.
+func sortByTime(cs *io.ColumnSeries) *io.ColumnSeries {
+	epochs := cs.GetEpoch()
+	nanos, _ := cs.GetColumn("Nanoseconds").([]int32)
+	order := make([]int, len(epochs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if epochs[i] != epochs[j] || nanos == nil {
+			return epochs[i] < epochs[j]
+		}
+		return nanos[i] < nanos[j]
+	})
+	return cs.SelectRows(order)
+}
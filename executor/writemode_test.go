@@ -0,0 +1,217 @@
+package executor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func tickCSM(tbk *io.TimeBucketKey, epochs []int64, nanos, exchanges []int32, bids []float32) io.ColumnSeriesMap {
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", epochs)
+	cs.AddColumn("Nanoseconds", nanos)
+	cs.AddColumn("Exchange", exchanges)
+	cs.AddColumn("Bid", bids)
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	return csm
+}
+
+func TestWriter_WriteCSMWithOptions(t *testing.T) {
+	t.Parallel()
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	const halfSecond = int32(500_000_000)
+
+	tests := map[string]struct {
+		opts          executor.WriteOptions
+		wantEpochs    []int64
+		wantExchanges []int32
+		wantBids      []float32
+	}{
+		"ok/ append": {
+			opts:          executor.WriteOptions{},
+			wantEpochs:    []int64{epoch, epoch, epoch, epoch + 60},
+			wantExchanges: []int32{1, 2, 2, 1},
+			wantBids:      []float32{1, 2, 9, 3},
+		},
+		"ok/ replace the records in the touched interval": {
+			opts:          executor.WriteOptions{Mode: executor.WriteModeReplace},
+			wantEpochs:    []int64{epoch, epoch + 60},
+			wantExchanges: []int32{2, 1},
+			wantBids:      []float32{9, 3},
+		},
+		"ok/ dedupe on Epoch and Nanoseconds by default": {
+			opts:          executor.WriteOptions{Mode: executor.WriteModeDedupe},
+			wantEpochs:    []int64{epoch, epoch + 60},
+			wantExchanges: []int32{2, 1},
+			wantBids:      []float32{9, 3},
+		},
+		"ok/ dedupe on Epoch, Nanoseconds and Exchange": {
+			opts: executor.WriteOptions{
+				Mode:      executor.WriteModeDedupe,
+				DedupeKey: []string{"Epoch", "Nanoseconds", "Exchange"},
+			},
+			wantEpochs:    []int64{epoch, epoch, epoch + 60},
+			wantExchanges: []int32{1, 2, 1},
+			wantBids:      []float32{1, 9, 3},
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- given ---
+			rootDir := t.TempDir()
+			cfg := utils.NewDefaultConfig(rootDir)
+			cfg.BackgroundSync = false
+			c := di.NewContainer(cfg)
+			writer := c.GetDefaultWriter()
+			tbk := io.NewTimeBucketKey("TEST/1Min/TICK")
+			_, err := writer.WriteCSMWithOptions(tickCSM(tbk,
+				[]int64{epoch, epoch, epoch + 60}, []int32{halfSecond, halfSecond, 0},
+				[]int32{1, 2, 1}, []float32{1, 2, 3},
+			), true, executor.WriteOptions{})
+			require.Nil(t, err)
+			require.Nil(t, c.GetInitWALFile().FlushToWAL())
+
+			// --- when ---
+			_, err = writer.WriteCSMWithOptions(tickCSM(tbk,
+				[]int64{epoch}, []int32{halfSecond}, []int32{2}, []float32{9},
+			), true, tt.opts)
+			require.Nil(t, err)
+			require.Nil(t, c.GetInitWALFile().FlushToWAL())
+
+			// --- then ---
+			written := readBucket(t, rootDir, tbk)
+			assert.Equal(t, tt.wantEpochs, written.GetEpoch())
+			assert.Equal(t, tt.wantExchanges, written.GetColumn("Exchange"))
+			assert.Equal(t, tt.wantBids, written.GetColumn("Bid"))
+		})
+	}
+}
+
+func TestWriter_WriteCSMWithOptions_Error(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	tbk := io.NewTimeBucketKey("TEST/1Min/TICK")
+	csm := tickCSM(tbk, []int64{0}, []int32{0}, []int32{1}, []float32{1})
+
+	// --- when ---
+	_, modeErr := c.GetDefaultWriter().WriteCSMWithOptions(csm, true, executor.WriteOptions{Mode: "upsert"})
+	_, keyErr := c.GetDefaultWriter().WriteCSMWithOptions(csm, true,
+		executor.WriteOptions{Mode: executor.WriteModeDedupe, DedupeKey: []string{"Venue"}})
+
+	// --- then ---
+	assert.EqualError(t, modeErr, `unknown write mode "upsert": must be one of append, replace or dedupe`)
+	assert.EqualError(t, keyErr, "dedupe the records of TEST/1Min/TICK: "+
+		"dedupe key column Venue is not in the written records")
+}
+
+type fakeReplicationSender struct {
+	sent [][]byte
+}
+
+func (s *fakeReplicationSender) Run(_ context.Context) {}
+func (s *fakeReplicationSender) Send(transactionGroup []byte) {
+	s.sent = append(s.sent, transactionGroup)
+}
+
+func TestWriter_WriteCSMWithOptions_ReplaceTransaction(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	rootDir := t.TempDir()
+	cfg := utils.NewDefaultConfig(rootDir)
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	sender := &fakeReplicationSender{}
+	c.GetInitWALFile().ReplicationSender = sender
+	tbk := io.NewTimeBucketKey("TEST/1Min/TICK")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	csm := tickCSM(tbk, []int64{epoch + 60, epoch}, []int32{0, 0}, []int32{1, 1}, []float32{2, 1})
+
+	// --- when ---
+	_, err := c.GetDefaultWriter().WriteCSMWithOptions(csm, true,
+		executor.WriteOptions{Mode: executor.WriteModeReplace})
+	require.Nil(t, err)
+	require.Nil(t, c.GetInitWALFile().FlushToWAL())
+
+	// --- then ---
+	// each interval is cleared right before its records are written in the same transaction group
+	require.Len(t, sender.sent, 1)
+	_, wtSets := executor.ParseTGData(sender.sent[0], rootDir)
+	require.Len(t, wtSets, 4)
+	for i, wantIndex := range []int64{0, 1, 0, 2} {
+		assert.Equal(t, wantIndex == 0, wtSets[i].Buffer.IsDeletion())
+		if wantIndex != 0 {
+			assert.Equal(t, io.EpochToIndex(epoch, time.Minute)+wantIndex-1, wtSets[i].Buffer.Index())
+		}
+	}
+}
+
+func TestWriter_WriteCSMWithOptions_QueueOnly(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	rootDir := t.TempDir()
+	cfg := utils.NewDefaultConfig(rootDir)
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	writer := c.GetDefaultWriter()
+	tbk := io.NewTimeBucketKey("TEST/1Min/TICK")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	// --- when ---
+	for i := int64(0); i < 3; i++ {
+		_, err := writer.WriteCSMWithOptions(tickCSM(tbk, []int64{epoch + i*60}, []int32{0}, []int32{1}, []float32{1}),
+			true, executor.WriteOptions{QueueOnly: true})
+		require.Nil(t, err)
+	}
+
+	// --- then ---
+	// the queued records are not flushed until WaitFlush
+	assert.Zero(t, readBucket(t, rootDir, tbk).Len())
+	writer.WaitFlush()
+	assert.Equal(t, []int64{epoch, epoch + 60, epoch + 120}, readBucket(t, rootDir, tbk).GetEpoch())
+}
+
+func TestParseWriteMode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mode    string
+		want    executor.WriteMode
+		wantErr bool
+	}{
+		"ok/ empty is append":  {mode: "", want: executor.WriteModeAppend},
+		"ok/ replace":          {mode: "replace", want: executor.WriteModeReplace},
+		"ok/ case-insensitive": {mode: "DEDUPE", want: executor.WriteModeDedupe},
+		"ng/ unknown mode":     {mode: "upsert", wantErr: true},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- when ---
+			got, err := executor.ParseWriteMode(tt.mode)
+
+			// --- then ---
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/klauspost/compress/snappy"
 
 	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 	"github.com/alpacahq/marketstore/v4/executor/wal"
 	"github.com/alpacahq/marketstore/v4/metrics"
 	"github.com/alpacahq/marketstore/v4/utils"
@@ -24,6 +25,7 @@ import (
 type Writer struct {
 	rootCatDir *catalog.Directory
 	walFile    *WALFileType
+	validator  *validation.Validator
 }
 
 func NewWriter(rootCatDir *catalog.Directory, walFile *WALFileType) (*Writer, error) {
@@ -39,6 +41,11 @@ func NewWriter(rootCatDir *catalog.Directory, walFile *WALFileType) (*Writer, er
 	}, nil
 }
 
+// SetValidator sets the validator of the writes by WriteCSM. The writes are not validated if it's nil.
+func (w *Writer) SetValidator(v *validation.Validator) {
+	w.validator = v
+}
+
 // formatRecord chops off the Epoch column(first 8bytes).
 // If the record type is VARIABLE, append IntervalTicks(4byte) after that.
 func formatRecord(buf, row []byte, t time.Time, index, intervalsPerDay int64, isVariable bool) []byte {
@@ -65,6 +72,14 @@ func formatRecord(buf, row []byte, t time.Time, index, intervalsPerDay int64, is
 // to the file regardless if it satisfies the on-disk data shape, possible corrupting
 // the data files. It is recommended to call WriteCSM() for any writes as it is safer.
 func (w *Writer) WriteRecords(ts []time.Time, data []byte, dsWithEpoch []io.DataShape, tbi *io.TimeBucketInfo) error {
+	return w.writeRecords(ts, data, dsWithEpoch, tbi, false)
+}
+
+// writeRecords is WriteRecords that replaces the records at each index written
+// instead of appending to them if replace is true.
+func (w *Writer) writeRecords(ts []time.Time, data []byte, dsWithEpoch []io.DataShape, tbi *io.TimeBucketInfo,
+	replace bool,
+) error {
 	/*
 		[]data contains a number of records, each including the epoch in the first 8 bytes
 	*/
@@ -105,6 +120,7 @@ func (w *Writer) WriteRecords(ts []time.Time, data []byte, dsWithEpoch []io.Data
 			prevYear = year
 			outBuf = formatRecord([]byte{}, record, t, index, tbi.GetIntervals(), tbi.GetRecordType() == io.VARIABLE)
 			cc = w.walFile.WriteCommand(rt, tbi.Path, int(vrl), offset, index, outBuf, dsWithEpoch)
+			cc.Replace = replace && rt == io.VARIABLE
 			continue
 		}
 		// Because index is relative time from the beginning of the year,
@@ -129,6 +145,7 @@ func (w *Writer) WriteRecords(ts []time.Time, data []byte, dsWithEpoch []io.Data
 			cc = w.walFile.WriteCommand(
 				tbi.GetRecordType(), tbi.Path, int(tbi.GetVariableRecordLength()), offset, index,
 				outBuf, dsWithEpoch)
+			cc.Replace = replace && rt == io.VARIABLE
 		}
 	}
 
@@ -167,6 +184,14 @@ func WriteBufferToFileIndirect(fp stdio.ReadWriteSeeker, buffer wal.OffsetIndexB
 		prepend it to the current data. This implements "append"
 	*/
 	primaryOffset := buffer.Offset() // Offset to storage of indirect record info
+	if buffer.IsDeletion() {
+		// clear the indirect record info so that the records at the index are deleted
+		if _, err = fp.Seek(primaryOffset, stdio.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek primaryOffset:%w", err)
+		}
+		_, err = fp.Write(make([]byte, indexOffsetLengthBytes))
+		return err
+	}
 	index := buffer.Index()
 	dataToBeWritten := buffer.Payload()
 	dataLen := int64(len(dataToBeWritten))
@@ -260,101 +285,203 @@ func WriteBufferToFileIndirect(fp stdio.ReadWriteSeeker, buffer wal.OffsetIndexB
 // WriteCSM has the same logic as the executor.WriteCSM function.
 // In order to improve testability, use this function instead of the static WriteCSM function.
 func (w *Writer) WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error {
-	start := time.Now()
-	for tbk, cs := range csm {
-		tf, err := tbk.GetTimeFrame()
-		if err != nil {
-			return err
+	_, err := w.WriteCSMWithOptions(csm, isVariableLength, WriteOptions{})
+	return err
+}
+
+// WriteCSMWithOptions validates the csm by the validator before writing it in the write mode of the options,
+// and returns the violations of the validation rules. The rows rejected by the rules are dropped,
+// and the quarantined rows are appended to the quarantine time buckets (see validation.QuarantineKey).
+func (w *Writer) WriteCSMWithOptions(csm io.ColumnSeriesMap, isVariableLength bool, opts WriteOptions,
+) ([]validation.Violation, error) {
+	if _, err := ParseWriteMode(string(opts.Mode)); err != nil {
+		return nil, err
+	}
+	if w.validator == nil {
+		if err := w.writeCSMInMode(csm, isVariableLength, opts); err != nil {
+			return nil, err
 		}
+		w.requestFlush(opts)
+		return nil, nil
+	}
 
-		/*
-			Prepare data for writing
-		*/
-		var alignData bool
-		times, err := cs.GetTime()
+	var (
+		violations []validation.Violation
+		validated  []*io.TimeBucketKey
+	)
+	validCSM := io.NewColumnSeriesMap()
+	quarantineCSMs := []io.ColumnSeriesMap{}
+	for key, cs := range csm {
+		tbk := w.rootCatDir.ResolveKey(&key)
+		if !w.validator.Enabled(tbk) {
+			validCSM[key] = cs
+			continue
+		}
+		res, err := w.validator.Validate(tbk, cs, isVariableLength)
 		if err != nil {
-			return err
+			w.forget(validated)
+			return nil, err
 		}
-		if isVariableLength {
-			if err = cs.Remove("Nanoseconds"); err != nil {
-				log.Warn(fmt.Sprintf("failed to remove 'Nanoseconds' column. err=%v", err))
-			}
-			alignData = false
+		validated = append(validated, tbk)
+		violations = append(violations, res.Violations...)
+		if res.Valid.Len() > 0 {
+			validCSM[*tbk] = res.Valid
+		}
+		for _, q := range res.Quarantined {
+			quarantineCSMs = append(quarantineCSMs, io.ColumnSeriesMap{*validation.QuarantineKey(tbk): q})
+		}
+	}
+
+	if err := w.writeCSMInMode(validCSM, isVariableLength, opts); err != nil {
+		w.forget(validated)
+		return violations, err
+	}
+	for _, qcsm := range quarantineCSMs {
+		if err := w.writeCSM(qcsm, isVariableLength, false); err != nil {
+			return violations, fmt.Errorf("write quarantined records: %w", err)
 		}
+	}
+	w.requestFlush(opts)
+	return violations, nil
+}
+
+// requestFlush requests the WAL flush of the written records unless the options only queue them.
+func (w *Writer) requestFlush(opts WriteOptions) {
+	if !opts.QueueOnly {
+		w.walFile.RequestFlush()
+	}
+}
 
-		tbi, err := w.rootCatDir.GetLatestTimeBucketInfoFromKey(&tbk)
+// WaitFlush waits for the WAL flush of the records written before the call, so that they are in the primary storage.
+func (w *Writer) WaitFlush() {
+	w.walFile.WaitFlush()
+}
+
+// forget drops the values remembered by the validator for the time buckets whose validated rows failed to be written.
+func (w *Writer) forget(tbks []*io.TimeBucketKey) {
+	for _, tbk := range tbks {
+		w.validator.Forget(tbk)
+	}
+}
+
+// writeCSM queues the csm to the WAL without the validation and the WAL flush. The records of the
+// variable-length time buckets replace the records already written to each interval if replace is true.
+func (w *Writer) writeCSM(csm io.ColumnSeriesMap, isVariableLength, replace bool) error {
+	start := time.Now()
+	for key, cs := range csm {
+		key := key
+		// the writes to an alias go to the symbol that it refers to
+		tbk, unlock := w.lockSymbol(&key)
+		err := w.writeColumnSeries(*tbk, cs, isVariableLength, replace)
+		unlock()
 		if err != nil {
-			/*
-				If we can't get the info, we try here to add a new one
-			*/
-			var recordType io.EnumRecordType
-			if isVariableLength {
-				recordType = io.VARIABLE
-			} else {
-				recordType = io.FIXED
-			}
+			return err
+		}
+	}
 
-			t, err2 := cs.GetTime()
-			if err2 != nil {
-				return err2
-			}
-			if len(t) == 0 {
-				continue
-			}
+	metrics.WriteCSMDuration.Observe(time.Since(start).Seconds())
+	return nil
+}
 
-			year := int16(t[0].Year())
-			tbi = io.NewTimeBucketInfo(
-				*tf,
-				tbk.GetPathToYearFiles(w.rootCatDir.GetPath()),
-				"Created By Writer", year,
-				cs.GetDataShapes(), recordType)
+// writeColumnSeries queues the cs to the WAL. The caller must hold the lock of the symbol (see lockSymbol).
+func (w *Writer) writeColumnSeries(tbk io.TimeBucketKey, cs *io.ColumnSeries, isVariableLength, replace bool,
+) error {
+	tf, err := tbk.GetTimeFrame()
+	if err != nil {
+		return err
+	}
 
-			/*
-				Verify there is an available TimeBucket for the destination
-			*/
-			if err2 := w.rootCatDir.AddTimeBucket(&tbk, tbi); err2 != nil {
-				// If File Exists error, ignore it, otherwise return the error
-				if !strings.Contains(err2.Error(), "Can not overwrite file") && !strings.Contains(err2.Error(), "file exists") {
-					return err
-				}
-			}
+	/*
+		Prepare data for writing
+	*/
+	var alignData bool
+	if isVariableLength && replace {
+		// all the records of an interval need to be in a write command to replace the interval
+		cs = sortByTime(cs)
+	}
+	times, err := cs.GetTime()
+	if err != nil {
+		return err
+	}
+	if isVariableLength {
+		if err = cs.Remove("Nanoseconds"); err != nil {
+			log.Warn(fmt.Sprintf("failed to remove 'Nanoseconds' column. err=%v", err))
 		}
-		// Check if the previously-written data schema matches the input
-		columnMismatchError := "unable to match data columns (%v) to bucket columns (%v)"
-		dbDSV := tbi.GetDataShapesWithEpoch()
-		csDSV := cs.GetDataShapes()
-		if len(dbDSV) != len(csDSV) {
-			return fmt.Errorf(columnMismatchError, csDSV, dbDSV)
+		alignData = false
+	}
+
+	tbi, err := w.rootCatDir.GetLatestTimeBucketInfoFromKey(&tbk)
+	if err != nil {
+		/*
+			If we can't get the info, we try here to add a new one
+		*/
+		var recordType io.EnumRecordType
+		if isVariableLength {
+			recordType = io.VARIABLE
+		} else {
+			recordType = io.FIXED
 		}
-		missing, coercion, err := io.GetMissingAndTypeCoercionColumns(dbDSV, csDSV)
-		if err != nil {
-			return fmt.Errorf("find missing and type coercion columns: %w", err)
+
+		t, err2 := cs.GetTime()
+		if err2 != nil {
+			return err2
 		}
-		if missing != nil {
-			return fmt.Errorf(columnMismatchError, csDSV, dbDSV)
+		if len(t) == 0 {
+			return nil
 		}
 
-		for _, dbDS := range coercion {
-			if err2 := cs.CoerceColumnType(dbDS.Name, dbDS.Type); err2 != nil {
-				csType := io.GetElementType(cs.GetColumn(dbDS.Name))
-				log.Error("[%s] error coercing %s from %s to %s", tbk.GetItemKey(), dbDS.Name, csType.String(), dbDS.Type.String())
-				return err2
+		year := int16(t[0].Year())
+		tbi = io.NewTimeBucketInfo(
+			*tf,
+			tbk.GetPathToYearFiles(w.rootCatDir.GetPath()),
+			"Created By Writer", year,
+			cs.GetDataShapes(), recordType)
+
+		/*
+			Verify there is an available TimeBucket for the destination
+		*/
+		if err2 := w.rootCatDir.AddTimeBucket(&tbk, tbi); err2 != nil {
+			// If File Exists error, ignore it, otherwise return the error
+			if !strings.Contains(err2.Error(), "Can not overwrite file") && !strings.Contains(err2.Error(), "file exists") {
+				return err
 			}
 		}
+	}
+	// Check if the previously-written data schema matches the input
+	columnMismatchError := "unable to match data columns (%v) to bucket columns (%v)"
+	dbDSV := tbi.GetDataShapesWithEpoch()
+	if err = cs.PackNullMask(dbDSV); err != nil {
+		return fmt.Errorf("pack null masks of %s: %w", tbk.GetItemKey(), err)
+	}
+	csDSV := cs.GetDataShapes()
+	if len(dbDSV) != len(csDSV) {
+		return fmt.Errorf(columnMismatchError, csDSV, dbDSV)
+	}
+	missing, coercion, err := io.GetMissingAndTypeCoercionColumns(dbDSV, csDSV)
+	if err != nil {
+		return fmt.Errorf("find missing and type coercion columns: %w", err)
+	}
+	if missing != nil {
+		return fmt.Errorf(columnMismatchError, csDSV, dbDSV)
+	}
 
-		rs, err := cs.ToRowSeries(tbk, alignData)
-		if err != nil {
-			return fmt.Errorf("convert column series to row series. tbk=%s: %w", tbk, err)
-		}
-		rowData := rs.GetData()
-		err = w.WriteRecords(times, rowData, dbDSV, tbi)
-		if err != nil {
-			return fmt.Errorf("write records to %v: %w", tbi, err)
+	for _, dbDS := range coercion {
+		if err2 := cs.CoerceColumn(dbDS); err2 != nil {
+			csType := io.GetElementType(cs.GetColumn(dbDS.Name))
+			log.Error("[%s] error coercing %s from %s to %s", tbk.GetItemKey(), dbDS.Name, csType.String(), dbDS.Type.String())
+			return err2
 		}
 	}
 
-	w.walFile.RequestFlush()
-	metrics.WriteCSMDuration.Observe(time.Since(start).Seconds())
+	rs, err := cs.ToRowSeries(tbk, alignData)
+	if err != nil {
+		return fmt.Errorf("convert column series to row series. tbk=%s: %w", tbk, err)
+	}
+	rowData := rs.GetData()
+	err = w.writeRecords(times, rowData, dbDSV, tbi, replace)
+	if err != nil {
+		return fmt.Errorf("write records to %v: %w", tbi, err)
+	}
 	return nil
 }
 
@@ -368,6 +495,7 @@ func WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) (err error) {
 	if err != nil {
 		return err
 	}
+	writer.SetValidator(ThisInstance.Validator)
 
 	return writer.WriteCSM(csm, isVariableLength)
 }
@@ -2,7 +2,9 @@ package executor
 
 import (
 	"errors"
+	"time"
 
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
 
@@ -11,3 +13,18 @@ type ErrorWriter struct{}
 func (w *ErrorWriter) WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error {
 	return errors.New("write is not allowed on replica")
 }
+
+func (w *ErrorWriter) WriteCSMWithOptions(csm io.ColumnSeriesMap, isVariableLength bool, opts WriteOptions,
+) ([]validation.Violation, error) {
+	return nil, errors.New("write is not allowed on replica")
+}
+
+func (w *ErrorWriter) WaitFlush() {}
+
+func (w *ErrorWriter) DeleteRange(tbk *io.TimeBucketKey, start, end time.Time) (int, error) {
+	return 0, errors.New("delete is not allowed on replica")
+}
+
+func (w *ErrorWriter) RenameSymbol(oldSymbol, newSymbol string, link bool) error {
+	return errors.New("rename is not allowed on replica")
+}
@@ -0,0 +1,67 @@
+package executor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
+	"github.com/alpacahq/marketstore/v4/planner"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// NewWriteValidator returns the validator of the write_validation settings, whose max_jump and
+// dedup rules read the records already written from the catalog directory.
+// It returns nil if there is no setting.
+func NewWriteValidator(settings []*utils.WriteValidationSetting, catDir *catalog.Directory,
+) (*validation.Validator, error) {
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	return validation.New(settings, &catalogStore{catDir: catDir})
+}
+
+// catalogStore reads the records of the time buckets for the write validation.
+type catalogStore struct {
+	catDir *catalog.Directory
+}
+
+func (s *catalogStore) Read(tbk *io.TimeBucketKey, start, end int64, last int) (*io.ColumnSeries, error) {
+	if _, err := s.catDir.GetLatestTimeBucketInfoFromKey(tbk); err != nil {
+		// the bucket has not been created yet
+		return nil, nil
+	}
+	queryStart := time.Unix(start, 0)
+	if queryStart.Before(planner.MinTime) {
+		queryStart = planner.MinTime
+	}
+	// include the records in the last second of the variable-length time buckets
+	queryEnd := time.Unix(end, 0).Add(time.Second - time.Nanosecond)
+	if queryEnd.After(planner.MaxTime) {
+		queryEnd = planner.MaxTime
+	}
+	if queryEnd.Before(queryStart) {
+		return nil, nil
+	}
+
+	q := planner.NewQuery(s.catDir)
+	q.AddTargetKey(tbk)
+	q.SetRange(queryStart, queryEnd)
+	if last > 0 {
+		q.SetRowLimit(io.LAST, last)
+	}
+	parsed, err := q.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("parse the query of %s: %w", tbk.String(), err)
+	}
+	reader, err := NewReader(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("create a reader of %s: %w", tbk.String(), err)
+	}
+	csm, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read records from %s: %w", tbk.String(), err)
+	}
+	return csm[*tbk], nil
+}
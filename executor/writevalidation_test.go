@@ -0,0 +1,97 @@
+package executor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/planner"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func readBucket(t *testing.T, rootDir string, tbk *io.TimeBucketKey) *io.ColumnSeries {
+	t.Helper()
+	catDir, err := catalog.NewDirectory(rootDir)
+	require.Nil(t, err)
+	q := planner.NewQuery(catDir)
+	q.AddTargetKey(tbk)
+	pr, err := q.Parse()
+	require.Nil(t, err)
+	rd, err := executor.NewReader(pr)
+	require.Nil(t, err)
+	csm, err := rd.Read()
+	require.Nil(t, err)
+	return csm[*tbk]
+}
+
+func TestWriter_WriteCSMWithValidator(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	rootDir := t.TempDir()
+	cfg := utils.NewDefaultConfig(rootDir)
+	cfg.BackgroundSync = false
+	cfg.WriteValidation = []*utils.WriteValidationSetting{{On: "*/1Min/OHLCV", Rules: []*utils.ValidationRuleSetting{
+		{Check: validation.CheckRange, Columns: []string{"Close"}, NonZero: true, Action: validation.Reject},
+		{Check: validation.CheckMaxJump, Columns: []string{"Close"}, MaxChange: 0.5, Action: validation.Quarantine},
+	}}}
+	c := di.NewContainer(cfg)
+	v, err := executor.NewWriteValidator(cfg.WriteValidation, c.GetCatalogDir())
+	require.Nil(t, err)
+	c.InjectWriteValidator(v)
+
+	tbk := io.NewTimeBucketKey("TEST/1Min/OHLCV")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{epoch, epoch + 60, epoch + 120, epoch + 180})
+	cs.AddColumn("Close", []float32{100, 0, 1000, 110})
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+
+	// --- when ---
+	violations, err := c.GetWriter().WriteCSMWithOptions(csm, false, executor.WriteOptions{})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Nil(t, c.GetInitWALFile().FlushToWAL())
+	assert.Equal(t, []validation.Violation{
+		{
+			Key: "TEST/1Min/OHLCV", Rule: validation.CheckRange, Action: validation.Reject, Rows: 1,
+			Epoch: epoch + 60, Message: "Close=0 is out of range",
+		},
+		{
+			Key: "TEST/1Min/OHLCV", Rule: validation.CheckMaxJump, Action: validation.Quarantine, Rows: 1,
+			Epoch: epoch + 120, Message: "Close=1000 changed 900.0% from 100",
+		},
+	}, violations)
+	written := readBucket(t, rootDir, tbk)
+	assert.Equal(t, []int64{epoch, epoch + 180}, written.GetEpoch())
+	quarantined := readBucket(t, rootDir, io.NewTimeBucketKey("TEST/1Min/OHLCV_QUARANTINE"))
+	assert.Equal(t, []int64{epoch + 120}, quarantined.GetEpoch())
+	assert.Equal(t, []float32{1000}, quarantined.GetColumn("Close"))
+
+	// --- when ---
+	// a new validator compares the next write with the last record on the disk
+	v, err = executor.NewWriteValidator(cfg.WriteValidation, c.GetCatalogDir())
+	require.Nil(t, err)
+	writer := c.GetDefaultWriter()
+	writer.SetValidator(v)
+	cs = io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{epoch + 240})
+	cs.AddColumn("Close", []float32{10})
+	csm = io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	violations, err = writer.WriteCSMWithOptions(csm, false, executor.WriteOptions{})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "Close=10 changed 90.9% from 110", violations[0].Message)
+}
@@ -9,9 +9,11 @@ import (
 )
 
 type TriggerPluginDispatcher struct {
-	c               chan writtenRecords
-	done            chan struct{}
-	m               map[string][]trigger.Record
+	c    chan writtenRecords
+	done chan struct{}
+	m    map[string][]trigger.Record
+	// mu protects triggerMatchers, which are replaced when the config is reloaded
+	mu              sync.RWMutex
 	triggerMatchers []*trigger.Matcher
 	triggerWg       *sync.WaitGroup
 }
@@ -38,7 +40,7 @@ func (tpd *TriggerPluginDispatcher) run() {
 	defer func() { tpd.done <- struct{}{} }()
 
 	for wr := range tpd.c {
-		for _, tmatcher := range tpd.triggerMatchers {
+		for _, tmatcher := range tpd.TriggerMatchers() {
 			if tmatcher.Match(wr.key) {
 				tpd.triggerWg.Add(1)
 				go tpd.fire(tmatcher.Trigger, wr.key, wr.records)
@@ -47,6 +49,22 @@ func (tpd *TriggerPluginDispatcher) run() {
 	}
 }
 
+// TriggerMatchers returns the current trigger matchers.
+func (tpd *TriggerPluginDispatcher) TriggerMatchers() []*trigger.Matcher {
+	tpd.mu.RLock()
+	defer tpd.mu.RUnlock()
+	return tpd.triggerMatchers
+}
+
+// SetTriggerMatchers replaces all the trigger matchers at once.
+// The records dispatched after this are fired to the new triggers,
+// while the triggers already fired for the previous records run to the end.
+func (tpd *TriggerPluginDispatcher) SetTriggerMatchers(triggerMatchers []*trigger.Matcher) {
+	tpd.mu.Lock()
+	defer tpd.mu.Unlock()
+	tpd.triggerMatchers = triggerMatchers
+}
+
 // AppendRecord collects the record from the serialized buffer.
 func (tpd *TriggerPluginDispatcher) AppendRecord(keyPath string, record []byte) {
 	if tpd.m == nil {
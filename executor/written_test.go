@@ -101,3 +101,25 @@ func TestTriggerPluginDispatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestTriggerPluginDispatcher_SetTriggerMatchers(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	oldTrigger, newTrigger := NewFakeTrigger(false), NewFakeTrigger(false)
+	tpd := executor.StartNewTriggerPluginDispatcher([]*trigger.Matcher{trigger.NewMatcher(oldTrigger, "AAPL/1Min/OHLCV")})
+	fakeBuffer, ok := io.SwapSliceData([]int64{0, 5}, byte(0)).([]byte)
+	require.True(t, ok)
+
+	// --- when ---
+	tpd.SetTriggerMatchers([]*trigger.Matcher{trigger.NewMatcher(newTrigger, "*/1Min/OHLCV")})
+	tpd.AppendRecord("TSLA/1Min/OHLCV/2017.bin", wal.OffsetIndexBuffer(fakeBuffer).IndexAndPayload())
+	tpd.DispatchRecords()
+	<-newTrigger.fireC // wait until fired
+
+	// --- then ---
+	require.Len(t, newTrigger.calledWith, 1)
+	assert.Equal(t, "TSLA/1Min/OHLCV/2017.bin", newTrigger.calledWith[0][0])
+	assert.Empty(t, oldTrigger.calledWith)
+	assert.Len(t, tpd.TriggerMatchers(), 1)
+}
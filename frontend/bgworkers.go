@@ -0,0 +1,109 @@
+package frontend
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Lifecycle states of the bgworkers.
+const (
+	BgWorkerRunning = "running"
+	// BgWorkerBackoff is the state of a bgworker waiting to be restarted after it panicked or its RunContext returned.
+	BgWorkerBackoff = "backoff"
+	BgWorkerStopped = "stopped"
+	// BgWorkerFailed is the state of a bgworker that failed to be loaded.
+	BgWorkerFailed = "failed"
+)
+
+// BgWorkerManager lists, starts and stops the bgworkers without restarting the server.
+type BgWorkerManager interface {
+	ListBgWorkers() []BgWorkerStatus
+	StartBgWorker(name string) (BgWorkerStatus, error)
+	StopBgWorker(name string) (BgWorkerStatus, error)
+	RestartBgWorker(name string) (BgWorkerStatus, error)
+}
+
+var (
+	errBgWorkerManagerNotEnabled = errors.New("bgworker management is not enabled")
+	// ErrBgWorkerNotFound is returned by BgWorkerManager for a bgworker not in the config.
+	ErrBgWorkerNotFound = errors.New("bgworker not found")
+)
+
+// BgWorkerStatus is the status of a bgworker.
+type BgWorkerStatus struct {
+	// Name is the name of the bgworker, or its module if it has no name
+	Name   string `msgpack:"name" json:"name"`
+	Module string `msgpack:"module" json:"module"`
+	// State is one of "running", "backoff", "stopped" and "failed"
+	State string `msgpack:"state" json:"state"`
+	// Stoppable is true if the bgworker can be stopped and restarted without restarting the server
+	Stoppable bool `msgpack:"stoppable" json:"stoppable"`
+	// StartedAt is the time the current instance of the bgworker is started in unix seconds, or 0 if not running
+	StartedAt int64 `msgpack:"started_at" json:"started_at"`
+	// Restarts is the number of the restarts after the bgworker panicked or its RunContext returned
+	Restarts int64 `msgpack:"restarts" json:"restarts"`
+	// Reporting is true if the bgworker reports the following status by itself
+	Reporting bool `msgpack:"reporting" json:"reporting"`
+	// WorkerState is the state reported by the bgworker
+	WorkerState string `msgpack:"worker_state" json:"worker_state"`
+	// LastWrite is the time of the last successful write in unix seconds, or 0 if unknown
+	LastWrite int64 `msgpack:"last_write" json:"last_write"`
+	// Errors is the number of errors reported by the bgworker and the restarts
+	Errors int64 `msgpack:"errors" json:"errors"`
+	// LastError is the last error reported by the bgworker, or the reason of the last restart or failure
+	LastError string `msgpack:"last_error" json:"last_error"`
+}
+
+type ListBgWorkersRequest struct{}
+
+type ListBgWorkersResponse struct {
+	BgWorkers []BgWorkerStatus `msgpack:"bgworkers"`
+}
+
+type BgWorkerRequest struct {
+	Name string `msgpack:"name"`
+}
+
+type BgWorkerResponse struct {
+	BgWorker BgWorkerStatus `msgpack:"bgworker"`
+}
+
+// ListBgWorkers returns the status of the bgworkers sorted by name.
+func (s *DataService) ListBgWorkers(_ *http.Request, _ *ListBgWorkersRequest, response *ListBgWorkersResponse,
+) (err error) {
+	if s.bgWorkers == nil {
+		return errBgWorkerManagerNotEnabled
+	}
+	response.BgWorkers = s.bgWorkers.ListBgWorkers()
+	return nil
+}
+
+// StartBgWorker starts a stopped or failed bgworker.
+func (s *DataService) StartBgWorker(_ *http.Request, req *BgWorkerRequest, response *BgWorkerResponse,
+) (err error) {
+	if s.bgWorkers == nil {
+		return errBgWorkerManagerNotEnabled
+	}
+	response.BgWorker, err = s.bgWorkers.StartBgWorker(req.Name)
+	return err
+}
+
+// StopBgWorker stops a bgworker until it's started by the StartBgWorker API or its setting is changed by a reload.
+func (s *DataService) StopBgWorker(_ *http.Request, req *BgWorkerRequest, response *BgWorkerResponse,
+) (err error) {
+	if s.bgWorkers == nil {
+		return errBgWorkerManagerNotEnabled
+	}
+	response.BgWorker, err = s.bgWorkers.StopBgWorker(req.Name)
+	return err
+}
+
+// RestartBgWorker stops a bgworker and starts a new instance of it, and resets its backoff.
+func (s *DataService) RestartBgWorker(_ *http.Request, req *BgWorkerRequest, response *BgWorkerResponse,
+) (err error) {
+	if s.bgWorkers == nil {
+		return errBgWorkerManagerNotEnabled
+	}
+	response.BgWorker, err = s.bgWorkers.RestartBgWorker(req.Name)
+	return err
+}
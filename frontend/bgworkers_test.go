@@ -0,0 +1,199 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/proto"
+)
+
+type fakeBgWorkerManager struct {
+	statuses map[string]BgWorkerStatus
+	calls    []string
+}
+
+func (m *fakeBgWorkerManager) ListBgWorkers() []BgWorkerStatus {
+	return []BgWorkerStatus{m.statuses["feeder"], m.statuses["legacy"]}
+}
+
+func (m *fakeBgWorkerManager) control(action, name string) (BgWorkerStatus, error) {
+	m.calls = append(m.calls, action+" "+name)
+	st, found := m.statuses[name]
+	if !found {
+		return BgWorkerStatus{}, fmt.Errorf("%w: %s", ErrBgWorkerNotFound, name)
+	}
+	if !st.Stoppable {
+		return st, errors.New("bgworker legacy can't be stopped without restarting the server")
+	}
+	return st, nil
+}
+
+func (m *fakeBgWorkerManager) StartBgWorker(name string) (BgWorkerStatus, error) {
+	return m.control("start", name)
+}
+
+func (m *fakeBgWorkerManager) StopBgWorker(name string) (BgWorkerStatus, error) {
+	return m.control("stop", name)
+}
+
+func (m *fakeBgWorkerManager) RestartBgWorker(name string) (BgWorkerStatus, error) {
+	return m.control("restart", name)
+}
+
+func newFakeBgWorkerManager() *fakeBgWorkerManager {
+	return &fakeBgWorkerManager{statuses: map[string]BgWorkerStatus{
+		"feeder": {
+			Name: "feeder", Module: "gdaxfeeder.so", State: BgWorkerRunning, Stoppable: true, StartedAt: 1600000000,
+			Restarts: 2, Reporting: true, WorkerState: "streaming", LastWrite: 1600000060, Errors: 3, LastError: "EOF",
+		},
+		"legacy": {Name: "legacy", Module: "xignitefeeder.so", State: BgWorkerRunning},
+	}}
+}
+
+func TestBgWorkerAPIs(t *testing.T) {
+	t.Parallel()
+	service := &DataService{}
+	grpcService := GRPCService{}
+
+	// --- when not enabled ---
+	err := service.ListBgWorkers(nil, &ListBgWorkersRequest{}, &ListBgWorkersResponse{})
+	_, grpcErr := grpcService.StopBgWorker(context.Background(), &proto.BgWorkerRequest{Name: "feeder"})
+
+	// --- then ---
+	assert.Equal(t, errBgWorkerManagerNotEnabled, err)
+	assert.Equal(t, errBgWorkerManagerNotEnabled, grpcErr)
+
+	// --- when ---
+	m := newFakeBgWorkerManager()
+	service.SetBgWorkerManager(m)
+	grpcService.SetBgWorkerManager(m)
+	var listResponse ListBgWorkersResponse
+	err = service.ListBgWorkers(nil, &ListBgWorkersRequest{}, &listResponse)
+	grpcListResponse, grpcErr := grpcService.ListBgWorkers(context.Background(), &proto.ListBgWorkersRequest{})
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, m.ListBgWorkers(), listResponse.BgWorkers)
+	require.Nil(t, grpcErr)
+	require.Len(t, grpcListResponse.Bgworkers, 2)
+	feeder := grpcListResponse.Bgworkers[0]
+	assert.Equal(t, "feeder", feeder.Name)
+	assert.Equal(t, "streaming", feeder.WorkerState)
+	assert.Equal(t, int64(1600000060), feeder.LastWrite)
+	assert.Equal(t, int64(2), feeder.Restarts)
+	assert.Equal(t, "EOF", feeder.LastError)
+
+	// --- when ---
+	var response BgWorkerResponse
+	err = service.RestartBgWorker(nil, &BgWorkerRequest{Name: "feeder"}, &response)
+	grpcResponse, grpcErr := grpcService.StartBgWorker(context.Background(), &proto.BgWorkerRequest{Name: "feeder"})
+	_, grpcStopErr := grpcService.StopBgWorker(context.Background(), &proto.BgWorkerRequest{Name: "legacy"})
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, "feeder", response.BgWorker.Name)
+	require.Nil(t, grpcErr)
+	assert.Equal(t, BgWorkerRunning, grpcResponse.Bgworker.State)
+	assert.NotNil(t, grpcStopErr)
+	assert.Equal(t, []string{"restart feeder", "start feeder", "stop legacy"}, m.calls)
+}
+
+func TestUtilityAPIHandlers_BgWorkers(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		method         string
+		path           string
+		wantStatusCode int
+		wantCall       string
+	}{
+		"ok/ restart": {
+			method:         http.MethodPost,
+			path:           "/bgworkers/feeder/restart",
+			wantStatusCode: http.StatusOK,
+			wantCall:       "restart feeder",
+		},
+		"ok/ stop": {
+			method:         http.MethodPost,
+			path:           "/bgworkers/feeder/stop",
+			wantStatusCode: http.StatusOK,
+			wantCall:       "stop feeder",
+		},
+		"ng/ not found": {
+			method:         http.MethodPost,
+			path:           "/bgworkers/unknown/start",
+			wantStatusCode: http.StatusNotFound,
+			wantCall:       "start unknown",
+		},
+		"ng/ can't be stopped": {
+			method:         http.MethodPost,
+			path:           "/bgworkers/legacy/stop",
+			wantStatusCode: http.StatusConflict,
+			wantCall:       "stop legacy",
+		},
+		"ng/ unknown action": {
+			method:         http.MethodPost,
+			path:           "/bgworkers/feeder/pause",
+			wantStatusCode: http.StatusNotFound,
+		},
+		"ng/ GET is not allowed": {
+			method:         http.MethodGet,
+			path:           "/bgworkers/feeder/stop",
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// --- given ---
+			m := newFakeBgWorkerManager()
+			uah := NewUtilityAPIHandlers(time.Time{})
+			uah.SetBgWorkerManager(m)
+			rec := httptest.NewRecorder()
+
+			// --- when ---
+			uah.controlBgWorker(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			// --- then ---
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+			if tt.wantCall != "" {
+				assert.Equal(t, []string{tt.wantCall}, m.calls)
+			} else {
+				assert.Empty(t, m.calls)
+			}
+			if tt.wantStatusCode == http.StatusOK {
+				var st BgWorkerStatus
+				require.Nil(t, json.NewDecoder(rec.Body).Decode(&st))
+				assert.Equal(t, "feeder", st.Name)
+			}
+		})
+	}
+
+	t.Run("ok/ list", func(t *testing.T) {
+		t.Parallel()
+		// --- given ---
+		m := newFakeBgWorkerManager()
+		uah := NewUtilityAPIHandlers(time.Time{})
+		uah.SetBgWorkerManager(m)
+		rec := httptest.NewRecorder()
+
+		// --- when ---
+		uah.listBgWorkers(rec, httptest.NewRequest(http.MethodGet, "/bgworkers", nil))
+
+		// --- then ---
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var statuses []BgWorkerStatus
+		require.Nil(t, json.NewDecoder(rec.Body).Decode(&statuses))
+		assert.Equal(t, m.ListBgWorkers(), statuses)
+	})
+}
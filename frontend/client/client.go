@@ -54,6 +54,14 @@ func decodeMultiServerResponse(resp *http.Response) (response interface{}, err e
 	return result, nil
 }
 
+func decodeMultiDeleteResponse(resp *http.Response) (response interface{}, err error) {
+	result := &frontend.MultiDeleteResponse{}
+	if err = msgpack2.DecodeClientResponse(resp.Body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func decodeMultiQueryResponse(resp *http.Response) (response interface{}, err error) {
 	result := &frontend.MultiQueryResponse{}
 	err = msgpack2.DecodeClientResponse(resp.Body, result)
@@ -73,13 +81,80 @@ func decodeListSymbols(resp *http.Response) (response interface{}, err error) {
 	return result.Results, nil
 }
 
+func decodeListSymbolAliases(resp *http.Response) (response interface{}, err error) {
+	result := &frontend.ListSymbolAliasesResponse{}
+	err = msgpack2.DecodeClientResponse(resp.Body, result)
+	if err != nil {
+		return nil, fmt.Errorf("decode ListSymbolAliases API client response:%w", err)
+	}
+	return result.Aliases, nil
+}
+
+func decodeGetSymbolMetadata(resp *http.Response) (response interface{}, err error) {
+	result := &frontend.GetSymbolMetadataResponse{}
+	err = msgpack2.DecodeClientResponse(resp.Body, result)
+	if err != nil {
+		return nil, fmt.Errorf("decode GetSymbolMetadata API client response:%w", err)
+	}
+	return result.Metadata, nil
+}
+
+func decodeCatalogStats(resp *http.Response) (response interface{}, err error) {
+	result := &frontend.CatalogStatsResponse{}
+	err = msgpack2.DecodeClientResponse(resp.Body, result)
+	if err != nil {
+		return nil, fmt.Errorf("decode CatalogStats API client response:%w", err)
+	}
+	return result.Buckets, nil
+}
+
+func decodeReloadConfig(resp *http.Response) (response interface{}, err error) {
+	result := &frontend.ReloadConfigResponse{}
+	err = msgpack2.DecodeClientResponse(resp.Body, result)
+	if err != nil {
+		return nil, fmt.Errorf("decode ReloadConfig API client response:%w", err)
+	}
+	return result, nil
+}
+
+func decodeListBgWorkers(resp *http.Response) (response interface{}, err error) {
+	result := &frontend.ListBgWorkersResponse{}
+	err = msgpack2.DecodeClientResponse(resp.Body, result)
+	if err != nil {
+		return nil, fmt.Errorf("decode ListBgWorkers API client response:%w", err)
+	}
+	return result, nil
+}
+
+func decodeBgWorkerResponse(resp *http.Response) (response interface{}, err error) {
+	result := &frontend.BgWorkerResponse{}
+	err = msgpack2.DecodeClientResponse(resp.Body, result)
+	if err != nil {
+		return nil, fmt.Errorf("decode BgWorker API client response:%w", err)
+	}
+	return result, nil
+}
+
 var decodeFuncMap = map[string]func(resp *http.Response) (response interface{}, err error){
-	"GetInfo":      decodeMultiGetInfoResponse,
-	"Create":       decodeMultiServerResponse,
-	"Destroy":      decodeMultiServerResponse,
-	"Query":        decodeMultiQueryResponse,
-	"SQLStatement": decodeMultiQueryResponse,
-	"ListSymbols":  decodeListSymbols,
+	"GetInfo":                decodeMultiGetInfoResponse,
+	"Create":                 decodeMultiServerResponse,
+	"Destroy":                decodeMultiServerResponse,
+	"Delete":                 decodeMultiDeleteResponse,
+	"Query":                  decodeMultiQueryResponse,
+	"SQLStatement":           decodeMultiQueryResponse,
+	"ListSymbols":            decodeListSymbols,
+	"ImportCorporateActions": decodeMultiServerResponse,
+	"RenameSymbols":          decodeMultiServerResponse,
+	"SetSymbolAliases":       decodeMultiServerResponse,
+	"ListSymbolAliases":      decodeListSymbolAliases,
+	"SetSymbolMetadata":      decodeMultiServerResponse,
+	"GetSymbolMetadata":      decodeGetSymbolMetadata,
+	"CatalogStats":           decodeCatalogStats,
+	"ReloadConfig":           decodeReloadConfig,
+	"ListBgWorkers":          decodeListBgWorkers,
+	"StartBgWorker":          decodeBgWorkerResponse,
+	"StopBgWorker":           decodeBgWorkerResponse,
+	"RestartBgWorker":        decodeBgWorkerResponse,
 	"Write": func(resp *http.Response) (response interface{}, err error) {
 		_, err = decodeMultiServerResponse(resp)
 		if err != nil {
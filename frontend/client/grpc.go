@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/proto"
+)
+
+// GRPCClient is a MarketStore gRPC API client.
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	client proto.MarketstoreClient
+}
+
+// NewGRPCClient initializes a new MarketStore gRPC client of the server at the target, e.g. "localhost:5995".
+// The transport security is disabled unless the options set it.
+func NewGRPCClient(target string, opts ...grpc.DialOption) (*GRPCClient, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", target, err)
+	}
+	return &GRPCClient{conn: conn, client: proto.NewMarketstoreClient(conn)}, nil
+}
+
+// Close closes the connection to the server.
+func (cl *GRPCClient) Close() error {
+	return cl.conn.Close()
+}
+
+// WriteStream opens a stream to write a sequence of the requests, e.g. the chunks of a backfill,
+// without building a large request bounded by the max message size of the server.
+// Send blocks while the server waits for the WAL flush of the written rows.
+func (cl *GRPCClient) WriteStream(ctx context.Context) (*WriteStream, error) {
+	stream, err := cl.client.WriteStream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open a write stream: %w", err)
+	}
+	return &WriteStream{stream: stream}, nil
+}
+
+// WriteStream is a stream of the write requests.
+type WriteStream struct {
+	stream proto.Marketstore_WriteStreamClient
+}
+
+// Send sends the write request to the server.
+func (ws *WriteStream) Send(req *frontend.WriteRequest) error {
+	if err := ws.stream.Send(frontend.ToProtoWriteRequest(req)); err != nil {
+		return fmt.Errorf("send a write request: %w", err)
+	}
+	return nil
+}
+
+// CloseAndRecv closes the stream, and returns the results of the requests after they are written.
+func (ws *WriteStream) CloseAndRecv() (*frontend.WriteStreamResponse, error) {
+	resp, err := ws.stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("close the write stream: %w", err)
+	}
+	return frontend.ToWriteStreamResponse(resp), nil
+}
@@ -0,0 +1,114 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/uda/adjust"
+)
+
+// CorporateActionRequest is a revision of a corporate action of a symbol.
+// The actions are stored in the <symbol>/1D/CORPACTIONS bucket (see models.CorporateActionsBucketKey),
+// and used by the adjust function.
+type CorporateActionRequest struct {
+	Symbol string `msgpack:"symbol"`
+	// ID identifies the action among its revisions, unique per symbol
+	ID int64 `msgpack:"id"`
+	// Type is "split", "stock_dividend", "cash_dividend" or "ticker_change"
+	Type string `msgpack:"type"`
+	// KnownAt is the knowledge date of the revision in unix epoch second
+	KnownAt int64 `msgpack:"known_at"`
+	// ExDate is the first day the prices reflect the action in unix epoch second
+	ExDate int64 `msgpack:"ex_date"`
+	// EffectiveDate is the date the action takes effect in unix epoch second, or 0
+	EffectiveDate int64 `msgpack:"effective_date,omitempty"`
+	// Ratio is the new shares per old share of a split or stock dividend (e.g. 2 for a 2-for-1 split)
+	Ratio float64 `msgpack:"ratio,omitempty"`
+	// CashAmount is the cash dividend per share
+	CashAmount float64 `msgpack:"cash_amount,omitempty"`
+	// OldSymbol is the previous symbol of a ticker change
+	OldSymbol string `msgpack:"old_symbol,omitempty"`
+	// Canceled cancels the action as of the knowledge date
+	Canceled bool `msgpack:"canceled,omitempty"`
+}
+
+type MultiCorporateActionRequest struct {
+	Requests []CorporateActionRequest `msgpack:"requests"`
+}
+
+// ImportCorporateActions stores the revisions of the corporate actions, with a response for each request.
+func (s *DataService) ImportCorporateActions(_ *http.Request, reqs *MultiCorporateActionRequest,
+	response *MultiServerResponse,
+) (err error) {
+	errs := importCorporateActions(s.writer, reqs.Requests, s.cache)
+	for _, err2 := range errs {
+		response.appendResponse(err2)
+	}
+	return nil
+}
+
+// importCorporateActions writes the valid actions of each symbol, and returns the error of each request.
+// The cached adjustments and query results of the symbols are invalidated.
+func importCorporateActions(w Writer, reqs []CorporateActionRequest, cache *QueryCache) []error {
+	errs := make([]error, len(reqs))
+	actions := map[string]*models.CorporateActions{}
+	indexes := map[string][]int{}
+	var symbols []string
+	for i := range reqs {
+		action, err := reqs[i].toCorporateAction()
+		if err != nil {
+			errs[i] = fmt.Errorf("corporate action %d of %s: %w", reqs[i].ID, reqs[i].Symbol, err)
+			continue
+		}
+		symbol := reqs[i].Symbol
+		if actions[symbol] == nil {
+			actions[symbol] = models.NewCorporateActions(symbol, 0)
+			symbols = append(symbols, symbol)
+		}
+		actions[symbol].Add(action)
+		indexes[symbol] = append(indexes[symbol], i)
+	}
+
+	for _, symbol := range symbols {
+		if err := w.WriteCSM(*actions[symbol].BuildCsm(), true); err != nil {
+			for _, i := range indexes[symbol] {
+				errs[i] = fmt.Errorf("write corporate actions of %s: %w", symbol, err)
+			}
+		}
+	}
+	adjust.InvalidateRateChanges(symbols...)
+	if cache != nil {
+		cache.InvalidateSymbols(symbols...)
+	}
+	return errs
+}
+
+func (req *CorporateActionRequest) toCorporateAction() (*models.CorporateAction, error) {
+	if req.Symbol == "" {
+		return nil, errors.New("symbol is required")
+	}
+	if req.KnownAt == 0 || req.ExDate == 0 {
+		return nil, errors.New("known_at and ex_date are required")
+	}
+	typ, err := models.ParseCorporateActionType(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	action := &models.CorporateAction{
+		ID:         req.ID,
+		KnownAt:    time.Unix(req.KnownAt, 0).UTC(),
+		Type:       typ,
+		ExDate:     time.Unix(req.ExDate, 0).UTC(),
+		Ratio:      req.Ratio,
+		CashAmount: req.CashAmount,
+		OldSymbol:  req.OldSymbol,
+		Canceled:   req.Canceled,
+	}
+	if req.EffectiveDate != 0 {
+		action.EffectiveDate = time.Unix(req.EffectiveDate, 0).UTC()
+	}
+	return action, action.Validate()
+}
@@ -0,0 +1,90 @@
+package frontend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/proto"
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+)
+
+func TestImportCorporateActions(t *testing.T) {
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	cache := frontend.NewQueryCache(1 << 20)
+	service.SetQueryCache(cache)
+	lastOpen(t, service, "USDJPY/1Min/OHLC", nil)
+	lastOpen(t, service, "EURUSD/1Min/OHLC", nil)
+	require.Equal(t, 2, cache.Len())
+
+	knownAt := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	exDate := time.Date(2021, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	// --- when ---
+	var response frontend.MultiServerResponse
+	err := service.ImportCorporateActions(nil, &frontend.MultiCorporateActionRequest{
+		Requests: []frontend.CorporateActionRequest{
+			{Symbol: "USDJPY", ID: 1, Type: "split", KnownAt: knownAt.Unix(), ExDate: exDate.Unix(), Ratio: 2},
+			// no ratio
+			{Symbol: "USDJPY", ID: 2, Type: "split", KnownAt: knownAt.Unix(), ExDate: exDate.Unix()},
+			{Symbol: "USDJPY", ID: 3, Type: "merger", KnownAt: knownAt.Unix(), ExDate: exDate.Unix()},
+		},
+	}, &response)
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Responses, 3)
+	assert.Empty(t, response.Responses[0].Error)
+	assert.NotEmpty(t, response.Responses[1].Error)
+	assert.NotEmpty(t, response.Responses[2].Error)
+
+	require.Nil(t, metadata.WALFile.FlushToWAL())
+	actions, err := models.ReadCorporateActions(metadata.CatalogDir, "USDJPY")
+	require.Nil(t, err)
+	assert.Equal(t, []models.CorporateAction{
+		{ID: 1, KnownAt: knownAt, Type: enum.StockSplit, ExDate: exDate, Ratio: 2},
+	}, actions)
+
+	// the cached results of the symbol are invalidated
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestGRPCImportCorporateActions(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+
+	knownAt := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	exDate := time.Date(2022, 6, 9, 0, 0, 0, 0, time.UTC)
+
+	// --- when ---
+	response, err := service.ImportCorporateActions(context.Background(), &proto.MultiCorporateActionRequest{
+		Requests: []*proto.CorporateActionRequest{
+			{
+				Symbol: "META", Id: 1, Type: "ticker_change", KnownAt: knownAt.Unix(), ExDate: exDate.Unix(),
+				OldSymbol: "FB",
+			},
+			{Symbol: "", Id: 2, Type: "split", KnownAt: knownAt.Unix(), ExDate: exDate.Unix(), Ratio: 2},
+		},
+	})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Responses, 2)
+	assert.Empty(t, response.Responses[0].Error)
+	assert.NotEmpty(t, response.Responses[1].Error)
+
+	require.Nil(t, metadata.WALFile.FlushToWAL())
+	actions, err := models.ReadCorporateActions(metadata.CatalogDir, "META")
+	require.Nil(t, err)
+	assert.Equal(t, []models.CorporateAction{
+		{ID: 1, KnownAt: knownAt, Type: enum.TickerChange, ExDate: exDate, OldSymbol: "FB"},
+	}, actions)
+}
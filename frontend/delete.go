@@ -0,0 +1,149 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/planner"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// DeleteRequest is the parameter interface for DataService.Delete method.
+type DeleteRequest struct {
+	// Destination is <symbol>/<timeframe>/<attributegroup>.
+	// A comma separated list of symbols (e.g. "TSLA,AAPL/1Min/OHLCV") and "*" for all symbols are supported.
+	Destination string `msgpack:"destination"`
+	// This is not usually set, defaults to Symbol/Timeframe/AttributeGroup
+	KeyCategory string `msgpack:"key_category,omitempty"`
+	// Lower time predicate (i.e. index >= start) in unix epoch second
+	EpochStart *int64 `msgpack:"epoch_start,omitempty"`
+	// Nanosecond of the lower time predicate
+	EpochStartNanos *int64 `msgpack:"epoch_start_nanos,omitempty"`
+	// Upper time predicate (i.e. index <= end) in unix epoch second
+	EpochEnd *int64 `msgpack:"epoch_end,omitempty"`
+	// Nanosecond of the upper time predicate
+	EpochEndNanos *int64 `msgpack:"epoch_end_nanos,omitempty"`
+}
+
+type MultiDeleteRequest struct {
+	Requests []DeleteRequest `msgpack:"requests"`
+}
+
+type DeleteResponse struct {
+	// Deleted is the number of deleted records for each time bucket key, e.g. {"TSLA/1Min/OHLCV": 10}
+	Deleted map[string]int64 `msgpack:"deleted"`
+	Error   string           `msgpack:"error"`
+	Version string           `msgpack:"version"` // Server Version
+}
+
+type MultiDeleteResponse struct {
+	Responses []DeleteResponse `msgpack:"responses"`
+}
+
+// Delete deletes the records in the time range from the time buckets.
+func (s *DataService) Delete(_ *http.Request, reqs *MultiDeleteRequest, response *MultiDeleteResponse) (err error) {
+	for i := range reqs.Requests {
+		req := &reqs.Requests[i]
+		start, end := deleteRange(req.EpochStart, req.EpochStartNanos, req.EpochEnd, req.EpochEndNanos)
+		deleted, err := deleteTimeBuckets(s.catalogDir, s.writer, req.Destination, req.KeyCategory, start, end)
+		response.appendResponse(deleted, err)
+	}
+	return nil
+}
+
+// deleteRange returns the time range to delete. The range is unbounded on the side the epoch is not specified.
+func deleteRange(epochStart, epochStartNanos, epochEnd, epochEndNanos *int64) (start, end time.Time) {
+	start, end = planner.MinTime, planner.MaxTime
+	if epochStart != nil {
+		var nanos int64
+		if epochStartNanos != nil {
+			nanos = *epochStartNanos
+		}
+		start = time.Unix(*epochStart, nanos)
+	}
+	if epochEnd != nil {
+		var nanos int64
+		if epochEndNanos != nil {
+			nanos = *epochEndNanos
+		}
+		end = time.Unix(*epochEnd, nanos)
+	}
+	return io.ToSystemTimezone(start), io.ToSystemTimezone(end)
+}
+
+// deleteTimeBuckets deletes the records in the time range from the time buckets of the destination,
+// and returns the number of deleted records for each time bucket key.
+func deleteTimeBuckets(catDir *catalog.Directory, w Writer, destination, keyCategory string, start, end time.Time,
+) (map[string]int64, error) {
+	tbks, err := deleteTargetKeys(catDir, destination, keyCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make(map[string]int64, len(tbks))
+	for _, tbk := range tbks {
+		n, err2 := w.DeleteRange(tbk, start, end)
+		if err2 != nil {
+			return deleted, fmt.Errorf("delete records from %s: %w", tbk.GetItemKey(), err2)
+		}
+		deleted[tbk.GetItemKey()] = int64(n)
+	}
+	return deleted, nil
+}
+
+// deleteTargetKeys returns the time bucket keys of the destination.
+// The keys of "*" are the existing buckets of all symbols that have the timeframe and attribute group,
+// and the other keys must exist.
+func deleteTargetKeys(catDir *catalog.Directory, destination, keyCategory string) ([]*io.TimeBucketKey, error) {
+	dest := io.NewTimeBucketKey(destination, keyCategory)
+	if dest == nil {
+		return nil, fmt.Errorf("destination \"%s\" is not in proper format, should be like: TSLA/1Min/OHLCV",
+			destination)
+	}
+	recordFormat := dest.GetItemInCategory("AttributeGroup")
+	timeframe := dest.GetItemInCategory("Timeframe")
+	symbols := dest.GetMultiItemInCategory("Symbol")
+	if len(timeframe) == 0 || len(recordFormat) == 0 || len(symbols) == 0 {
+		return nil, fmt.Errorf("destinations must have a Symbol, Timeframe and AttributeGroup, have: %s",
+			dest.String())
+	}
+
+	allSymbols := len(symbols) == 1 && symbols[0] == "*"
+	if allSymbols {
+		var err error
+		if symbols, err = gatherAllSymbols(catDir); err != nil {
+			return nil, err
+		}
+	}
+
+	tbks := make([]*io.TimeBucketKey, 0, len(symbols))
+	for _, symbol := range symbols {
+		tbk := io.NewTimeBucketKey(strings.Join([]string{symbol, timeframe, recordFormat}, "/"), keyCategory)
+		if _, err := catDir.GetLatestTimeBucketInfoFromKey(tbk); err != nil {
+			if allSymbols {
+				continue
+			}
+			return nil, fmt.Errorf("time bucket %s does not exist", tbk.GetItemKey())
+		}
+		tbks = append(tbks, tbk)
+	}
+	return tbks, nil
+}
+
+func (mr *MultiDeleteResponse) appendResponse(deleted map[string]int64, err error) {
+	var errorText string
+	if err != nil {
+		errorText = err.Error()
+	}
+	mr.Responses = append(mr.Responses,
+		DeleteResponse{
+			Deleted: deleted,
+			Error:   errorText,
+			Version: utils.GitHash,
+		},
+	)
+}
@@ -0,0 +1,85 @@
+package frontend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/proto"
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+)
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+
+	start := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	end := start + 9*60
+	newRequest := func(destination string) frontend.DeleteRequest {
+		return frontend.DeleteRequest{Destination: destination, EpochStart: &start, EpochEnd: &end}
+	}
+
+	// --- when ---
+	var response frontend.MultiDeleteResponse
+	err := service.Delete(nil, &frontend.MultiDeleteRequest{
+		Requests: []frontend.DeleteRequest{
+			newRequest("USDJPY,EURUSD/1Min/OHLC"),
+			newRequest("*/1Min/OHLC"),
+			newRequest("XXXXXX/1Min/OHLC"),
+		},
+	}, &response)
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Responses, 3)
+	require.Empty(t, response.Responses[0].Error)
+	require.Equal(t, map[string]int64{"USDJPY/1Min/OHLC": 10, "EURUSD/1Min/OHLC": 10}, response.Responses[0].Deleted)
+	// the records of USDJPY and EURUSD have been deleted by the first request
+	require.Empty(t, response.Responses[1].Error)
+	require.Equal(t, map[string]int64{"USDJPY/1Min/OHLC": 0, "EURUSD/1Min/OHLC": 0, "NZDUSD/1Min/OHLC": 10},
+		response.Responses[1].Deleted)
+	require.NotEmpty(t, response.Responses[2].Error)
+
+	// --- the deleted records are not returned to queries ---
+	var qresponse frontend.MultiQueryResponse
+	err = service.Query(nil, &frontend.MultiQueryRequest{
+		Requests: []frontend.QueryRequest{
+			frontend.NewQueryRequestBuilder("USDJPY/1Min/OHLC").EpochStart(start).EpochEnd(end + 60).End(),
+		},
+	}, &qresponse)
+	require.Nil(t, err)
+	csm, err := qresponse.Responses[0].Result.ToColumnSeriesMap()
+	require.Nil(t, err)
+	for _, cs := range csm {
+		require.Equal(t, []int64{end + 60}, cs.GetEpoch())
+	}
+}
+
+func TestGRPCDelete(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+
+	start := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// --- when ---
+	response, err := service.Delete(context.Background(), &proto.MultiDeleteRequest{
+		Requests: []*proto.DeleteRequest{
+			{Destination: "USDJPY/1H/OHLC", EpochStart: start.Unix(), EpochEnd: start.Add(2 * time.Hour).Unix()},
+			// no upper limit
+			{Destination: "EURUSD/1H/OHLC", EpochStart: time.Date(2002, 12, 31, 22, 0, 0, 0, time.UTC).Unix()},
+		},
+	})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Responses, 2)
+	require.Empty(t, response.Responses[0].Error)
+	require.Equal(t, map[string]int64{"USDJPY/1H/OHLC": 3}, response.Responses[0].Deleted)
+	require.Empty(t, response.Responses[1].Error)
+	require.Equal(t, map[string]int64{"EURUSD/1H/OHLC": 2}, response.Responses[1].Deleted)
+}
@@ -2,14 +2,19 @@ package frontend
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
 	"sync/atomic"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/alpacahq/marketstore/v4/catalog"
 	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 	"github.com/alpacahq/marketstore/v4/proto"
 	"github.com/alpacahq/marketstore/v4/sqlparser"
 	"github.com/alpacahq/marketstore/v4/utils"
@@ -25,6 +30,11 @@ type GRPCService struct {
 	aggRunner  *sqlparser.AggRunner
 	writer     Writer
 	query      QueryInterface
+	cache      *QueryCache
+	limiter    *QueryLimiter
+	stats      *executor.StatsTracker
+	reloader   ConfigReloader
+	bgWorkers  BgWorkerManager
 }
 
 func NewGRPCService(rootDir string, catDir *catalog.Directory, aggRunner *sqlparser.AggRunner,
@@ -39,11 +49,46 @@ func NewGRPCService(rootDir string, catDir *catalog.Directory, aggRunner *sqlpar
 	}
 }
 
-func (s GRPCService) Query(_ context.Context, reqs *proto.MultiQueryRequest) (*proto.MultiQueryResponse, error) {
+// SetQueryCache enables the cache of the query results, shared with the other services.
+func (s *GRPCService) SetQueryCache(qc *QueryCache) {
+	s.cache = qc
+}
+
+// SetQueryLimiter limits the query requests with the limiter shared with the other services.
+func (s *GRPCService) SetQueryLimiter(l *QueryLimiter) {
+	s.limiter = l
+}
+
+// SetStatsTracker sets the tracker of the statistics of the time buckets, shared with the other services.
+func (s *GRPCService) SetStatsTracker(st *executor.StatsTracker) {
+	s.stats = st
+}
+
+// SetConfigReloader enables the ReloadConfig API.
+func (s *GRPCService) SetConfigReloader(r ConfigReloader) {
+	s.reloader = r
+}
+
+// SetBgWorkerManager enables the ListBgWorkers, StartBgWorker, StopBgWorker and RestartBgWorker APIs.
+func (s *GRPCService) SetBgWorkerManager(m BgWorkerManager) {
+	s.bgWorkers = m
+}
+
+func (s GRPCService) Query(ctx context.Context, reqs *proto.MultiQueryRequest) (*proto.MultiQueryResponse, error) {
+	ctx, done, err := s.limiter.acquire(ctx)
+	if err != nil {
+		return nil, queryStatusError(err)
+	}
+	defer done()
+
 	response := proto.MultiQueryResponse{}
 	response.Version = utils.GitHash
 	response.Timezone = utils.InstanceConfig.Timezone.String()
 	for _, req := range reqs.Requests {
+		if err = ctx.Err(); err != nil {
+			countLimitError(err)
+			return nil, queryStatusError(err)
+		}
 		switch req.IsSqlStatement {
 		case true:
 			queryTree, err := sqlparser.BuildQueryTree(req.SqlStatement)
@@ -54,10 +99,13 @@ func (s GRPCService) Query(_ context.Context, reqs *proto.MultiQueryRequest) (*p
 			if err != nil {
 				return nil, err
 			}
-			cs, err := es.Materialize(s.aggRunner, s.catalogDir)
+			cs, err := es.Materialize(s.aggRunner, s.catalogDir, s.writer)
 			if err != nil {
 				return nil, err
 			}
+			if s.cache != nil {
+				s.cache.invalidateDroppedTables(es)
+			}
 			nds, err := io.NewNumpyDataset(cs)
 			if err != nil {
 				return nil, err
@@ -93,15 +141,10 @@ func (s GRPCService) Query(_ context.Context, reqs *proto.MultiQueryRequest) (*p
 			if len(Timeframe) == 0 || len(RecordFormat) == 0 || len(Symbols) == 0 {
 				return nil, fmt.Errorf("destinations must have a Symbol, Timeframe and AttributeGroup, have: %s",
 					dest.String())
-			} else if len(Symbols) == 1 && Symbols[0] == "*" {
-				// replace the * "symbol" with a list all known actual symbols
-				symbols, err := gatherAllSymbols(s.catalogDir)
-				if err != nil {
-					return nil, err
-				}
-				keyParts := []string{strings.Join(symbols, ","), Timeframe, RecordFormat}
-				itemKey := strings.Join(keyParts, "/")
-				dest = io.NewTimeBucketKey(itemKey, req.KeyCategory)
+			}
+			dest, err := expandSymbols(s.catalogDir, dest, req.KeyCategory, req.SymbolFilter)
+			if err != nil {
+				return nil, err
 			}
 
 			epochStart := req.EpochStart
@@ -117,29 +160,19 @@ func (s GRPCService) Query(_ context.Context, reqs *proto.MultiQueryRequest) (*p
 				columns = req.Columns
 			}
 
-			start := io.ToSystemTimezone(time.Unix(epochStart, req.EpochStartNanos))
-			end := io.ToSystemTimezone(time.Unix(epochEnd, req.EpochEndNanos))
-			csm, err := s.query.ExecuteQuery(
-				dest,
-				start, end,
-				limitRecordCount, limitFromStart,
-				columns,
-			)
+			csm, err := runQuery(ctx, s.query, s.aggRunner, s.cache, &queryParams{
+				dest:             dest,
+				epochStart:       epochStart,
+				epochStartNanos:  req.EpochStartNanos,
+				epochEnd:         epochEnd,
+				epochEndNanos:    req.EpochEndNanos,
+				limitRecordCount: limitRecordCount,
+				limitFromStart:   limitFromStart,
+				columns:          columns,
+				functions:        req.Functions,
+			})
 			if err != nil {
-				return nil, err
-			}
-
-			/*
-				Execute function pipeline, if requested
-			*/
-			if len(req.Functions) != 0 {
-				for tbkStr, cs := range csm {
-					csOut, err := s.aggRunner.Run(req.Functions, cs, tbkStr)
-					if err != nil {
-						return nil, err
-					}
-					csm[tbkStr] = csOut
-				}
+				return nil, queryStatusError(err)
 			}
 
 			/*
@@ -179,6 +212,18 @@ func (s GRPCService) Query(_ context.Context, reqs *proto.MultiQueryRequest) (*p
 	return &response, nil
 }
 
+// queryStatusError returns the gRPC status of the errors caused by the limits of the query requests.
+func queryStatusError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return status.Error(status.FromContextError(err).Code(), err.Error())
+	case errors.Is(err, executor.ErrResultTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return err
+	}
+}
+
 func gatherAllSymbols(catDir *catalog.Directory) ([]string, error) {
 	// replace the * "symbol" with a list all known actual symbols
 	ret, err := catDir.GatherCategoriesAndItems()
@@ -201,10 +246,16 @@ func (s GRPCService) Write(ctx context.Context, reqs *proto.MultiWriteRequest) (
 			appendResponse(&response, err)
 			continue
 		}
-		if err = executor.WriteCSM(csm, req.IsVariableLength); err != nil {
+		opts, err := writeOptions(req.WriteMode, req.DedupeKey)
+		if err != nil {
 			appendResponse(&response, err)
 			continue
 		}
+		violations, err := s.writer.WriteCSMWithOptions(csm, req.IsVariableLength, opts)
+		if err != nil || len(violations) > 0 {
+			appendWriteResponse(&response, err, violations)
+			continue
+		}
 		// TODO: There should be an error response for every server request, need to add the below commented line
 		// appendResponse(err, response)
 	}
@@ -217,6 +268,7 @@ func ToNumpyMultiDataSet(p *proto.NumpyMultiDataset) *io.NumpyMultiDataset {
 			ColumnTypes: p.Data.ColumnTypes,
 			ColumnNames: p.Data.ColumnNames,
 			ColumnData:  p.Data.ColumnData,
+			ColumnMasks: p.Data.ColumnMasks,
 			Length:      int(p.Data.Length),
 		},
 		StartIndex: convertInt32Map(p.StartIndex),
@@ -230,6 +282,7 @@ func ToProtoNumpyMultiDataSet(nmds *io.NumpyMultiDataset) *proto.NumpyMultiDatas
 			ColumnTypes: nmds.ColumnTypes,
 			ColumnNames: nmds.ColumnNames,
 			ColumnData:  nmds.ColumnData,
+			ColumnMasks: nmds.ColumnMasks,
 			Length:      int32(nmds.Length),
 		},
 		StartIndex: convertIntMap(nmds.StartIndex),
@@ -256,11 +309,11 @@ func convertIntMap(m map[string]int) map[string]int32 {
 // NewDataShapeVector returns a new array of io.DataShape for the given array of proto.DataShape inputs.
 func NewDataShapeVector(dataShapes []*proto.DataShape) (dsv []io.DataShape, err error) {
 	for _, ds := range dataShapes {
-		elemType, ok := io.TypeStrToElemType(ds.Type)
+		shape, ok := io.NewDataShapeFromTypeStr(ds.Name, ds.Type)
 		if !ok {
 			return nil, fmt.Errorf("not supported data type: %v", ds.Type)
 		}
-		dsv = append(dsv, io.DataShape{Name: ds.Name, Type: elemType})
+		dsv = append(dsv, shape)
 	}
 	return dsv, err
 }
@@ -280,6 +333,22 @@ func appendResponse(mr *proto.MultiServerResponse, err error) {
 	)
 }
 
+// appendWriteResponse appends the response of a write with the violations of the write validation rules.
+func appendWriteResponse(mr *proto.MultiServerResponse, err error, violations []validation.Violation) {
+	appendResponse(mr, err)
+	resp := mr.Responses[len(mr.Responses)-1]
+	for _, v := range violations {
+		resp.Violations = append(resp.Violations, &proto.WriteViolation{
+			Key:     v.Key,
+			Rule:    v.Rule,
+			Action:  v.Action,
+			Rows:    int64(v.Rows),
+			Epoch:   v.Epoch,
+			Message: v.Message,
+		})
+	}
+}
+
 func (s GRPCService) ListSymbols(ctx context.Context, req *proto.ListSymbolsRequest,
 ) (*proto.ListSymbolsResponse, error) {
 	response := proto.ListSymbolsResponse{}
@@ -287,19 +356,11 @@ func (s GRPCService) ListSymbols(ctx context.Context, req *proto.ListSymbolsRequ
 		return nil, errNotQueryable
 	}
 
-	switch req.Format {
-	case proto.ListSymbolsRequest_SYMBOL:
-		ret, err := s.catalogDir.GatherCategoriesAndItems()
-		if err != nil {
-			return nil, fmt.Errorf("gather categories and items from catDir: %w", err)
-		}
-		for symbol := range ret["Symbol"] {
-			response.Results = append(response.Results, symbol)
-		}
-	default: // proto.ListSymbolsRequest_TIME_BUCKET_KEY:
-		response.Results = catalog.ListTimeBucketKeyNames(s.catalogDir)
+	results, err := listSymbols(s.catalogDir, req.Format != proto.ListSymbolsRequest_SYMBOL, req.Filter)
+	if err != nil {
+		return nil, err
 	}
-
+	response.Results = results
 	return &response, nil
 }
 
@@ -372,12 +433,233 @@ func (s GRPCService) Destroy(ctx context.Context, req *proto.MultiKeyRequest) (*
 			appendResponse(&response, err)
 			continue
 		}
+		if s.cache != nil {
+			s.cache.InvalidateKey(tbk)
+		}
 		appendResponse(&response, err)
 	}
 
 	return &response, nil
 }
 
+func (s GRPCService) Delete(ctx context.Context, reqs *proto.MultiDeleteRequest) (*proto.MultiDeleteResponse, error) {
+	response := proto.MultiDeleteResponse{}
+	for _, req := range reqs.Requests {
+		var epochEnd, epochEndNanos *int64
+		if req.EpochEnd != 0 {
+			epochEnd, epochEndNanos = &req.EpochEnd, &req.EpochEndNanos
+		}
+		start, end := deleteRange(&req.EpochStart, &req.EpochStartNanos, epochEnd, epochEndNanos)
+		deleted, err := deleteTimeBuckets(s.catalogDir, s.writer, req.Destination, req.KeyCategory, start, end)
+
+		var errorText string
+		if err != nil {
+			errorText = err.Error()
+		}
+		response.Responses = append(response.Responses, &proto.DeleteResponse{
+			Deleted: deleted,
+			Error:   errorText,
+			Version: utils.GitHash,
+		})
+	}
+
+	return &response, nil
+}
+
+func (s GRPCService) ImportCorporateActions(ctx context.Context, reqs *proto.MultiCorporateActionRequest,
+) (*proto.MultiServerResponse, error) {
+	actions := make([]CorporateActionRequest, len(reqs.Requests))
+	for i, req := range reqs.Requests {
+		actions[i] = CorporateActionRequest{
+			Symbol:        req.Symbol,
+			ID:            req.Id,
+			Type:          req.Type,
+			KnownAt:       req.KnownAt,
+			ExDate:        req.ExDate,
+			EffectiveDate: req.EffectiveDate,
+			Ratio:         req.Ratio,
+			CashAmount:    req.CashAmount,
+			OldSymbol:     req.OldSymbol,
+			Canceled:      req.Canceled,
+		}
+	}
+
+	response := proto.MultiServerResponse{}
+	for _, err := range importCorporateActions(s.writer, actions, s.cache) {
+		appendResponse(&response, err)
+	}
+	return &response, nil
+}
+
+func (s GRPCService) RenameSymbols(ctx context.Context, reqs *proto.MultiRenameSymbolRequest,
+) (*proto.MultiServerResponse, error) {
+	renames := make([]RenameSymbolRequest, len(reqs.Requests))
+	for i, req := range reqs.Requests {
+		renames[i] = RenameSymbolRequest{OldSymbol: req.OldSymbol, NewSymbol: req.NewSymbol, Link: req.Link}
+	}
+
+	response := proto.MultiServerResponse{}
+	for _, err := range renameSymbols(s.writer, renames, s.cache) {
+		appendResponse(&response, err)
+	}
+	return &response, nil
+}
+
+func (s GRPCService) SetSymbolAliases(ctx context.Context, reqs *proto.MultiSymbolAliasRequest,
+) (*proto.MultiServerResponse, error) {
+	aliases := make([]SymbolAliasRequest, len(reqs.Requests))
+	for i, req := range reqs.Requests {
+		aliases[i] = SymbolAliasRequest{Alias: req.Alias, Symbol: req.Symbol}
+	}
+
+	response := proto.MultiServerResponse{}
+	for _, err := range setSymbolAliases(s.catalogDir, aliases, s.cache) {
+		appendResponse(&response, err)
+	}
+	return &response, nil
+}
+
+func (s GRPCService) ListSymbolAliases(ctx context.Context, req *proto.ListSymbolAliasesRequest,
+) (*proto.ListSymbolAliasesResponse, error) {
+	return &proto.ListSymbolAliasesResponse{Aliases: s.catalogDir.SymbolAliases()}, nil
+}
+
+func (s GRPCService) SetSymbolMetadata(ctx context.Context, reqs *proto.MultiSymbolMetadataRequest,
+) (*proto.MultiServerResponse, error) {
+	response := proto.MultiServerResponse{}
+	for _, req := range reqs.Requests {
+		appendResponse(&response, setSymbolMetadata(s.catalogDir, req.Symbol, req.Metadata))
+	}
+	return &response, nil
+}
+
+func (s GRPCService) GetSymbolMetadata(ctx context.Context, req *proto.GetSymbolMetadataRequest,
+) (*proto.GetSymbolMetadataResponse, error) {
+	metadata, err := getSymbolMetadata(s.catalogDir, req.Symbols)
+	if err != nil {
+		return nil, err
+	}
+	response := proto.GetSymbolMetadataResponse{Metadata: make(map[string]*proto.SymbolMetadata, len(metadata))}
+	for symbol, md := range metadata {
+		response.Metadata[symbol] = &proto.SymbolMetadata{Metadata: md}
+	}
+	return &response, nil
+}
+
+func (s GRPCService) CatalogStats(ctx context.Context, req *proto.CatalogStatsRequest,
+) (*proto.CatalogStatsResponse, error) {
+	stats, err := catalogStats(s.catalogDir, s.stats, req.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	response := proto.CatalogStatsResponse{Buckets: make([]*proto.BucketStats, len(stats))}
+	for i := range stats {
+		bs := &stats[i]
+		names, types, err := columnNamesAndTypes(bs.DataShapes)
+		if err != nil {
+			return nil, fmt.Errorf("get the columns of %s: %w", bs.Key.GetItemKey(), err)
+		}
+		dataShapes := make([]*proto.DataShape, len(names))
+		for j := range names {
+			dataShapes[j] = &proto.DataShape{Name: names[j], Type: types[j]}
+		}
+		yrs := make([]int32, len(bs.Years))
+		for j, year := range bs.Years {
+			yrs[j] = int32(year)
+		}
+		first, last := epochRange(bs)
+		response.Buckets[i] = &proto.BucketStats{
+			Key:        bs.Key.GetItemKey(),
+			DataShapes: dataShapes,
+			RecordType: strings.ToLower(bs.RecordType.String()),
+			Years:      yrs,
+			FirstEpoch: first,
+			LastEpoch:  last,
+			Rows:       bs.Rows,
+			DiskBytes:  bs.DiskSize,
+		}
+	}
+	return &response, nil
+}
+
+func (s GRPCService) ReloadConfig(ctx context.Context, req *proto.ReloadConfigRequest,
+) (*proto.ReloadConfigResponse, error) {
+	if s.reloader == nil {
+		return nil, errReloadNotEnabled
+	}
+	resp, err := s.reloader.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ReloadConfigResponse{
+		TriggersAdded:    resp.TriggersAdded,
+		TriggersRemoved:  resp.TriggersRemoved,
+		BgworkersStarted: resp.BgWorkersStarted,
+		BgworkersStopped: resp.BgWorkersStopped,
+		Errors:           resp.Errors,
+	}, nil
+}
+
+func (s GRPCService) ListBgWorkers(ctx context.Context, req *proto.ListBgWorkersRequest,
+) (*proto.ListBgWorkersResponse, error) {
+	if s.bgWorkers == nil {
+		return nil, errBgWorkerManagerNotEnabled
+	}
+	statuses := s.bgWorkers.ListBgWorkers()
+	response := proto.ListBgWorkersResponse{Bgworkers: make([]*proto.BgWorkerStatus, len(statuses))}
+	for i := range statuses {
+		response.Bgworkers[i] = toProtoBgWorkerStatus(statuses[i])
+	}
+	return &response, nil
+}
+
+func (s GRPCService) StartBgWorker(ctx context.Context, req *proto.BgWorkerRequest,
+) (*proto.BgWorkerResponse, error) {
+	if s.bgWorkers == nil {
+		return nil, errBgWorkerManagerNotEnabled
+	}
+	return bgWorkerResponse(s.bgWorkers.StartBgWorker(req.Name))
+}
+
+func (s GRPCService) StopBgWorker(ctx context.Context, req *proto.BgWorkerRequest,
+) (*proto.BgWorkerResponse, error) {
+	if s.bgWorkers == nil {
+		return nil, errBgWorkerManagerNotEnabled
+	}
+	return bgWorkerResponse(s.bgWorkers.StopBgWorker(req.Name))
+}
+
+func (s GRPCService) RestartBgWorker(ctx context.Context, req *proto.BgWorkerRequest,
+) (*proto.BgWorkerResponse, error) {
+	if s.bgWorkers == nil {
+		return nil, errBgWorkerManagerNotEnabled
+	}
+	return bgWorkerResponse(s.bgWorkers.RestartBgWorker(req.Name))
+}
+
+func bgWorkerResponse(status BgWorkerStatus, err error) (*proto.BgWorkerResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &proto.BgWorkerResponse{Bgworker: toProtoBgWorkerStatus(status)}, nil
+}
+
+func toProtoBgWorkerStatus(st BgWorkerStatus) *proto.BgWorkerStatus {
+	return &proto.BgWorkerStatus{
+		Name:        st.Name,
+		Module:      st.Module,
+		State:       st.State,
+		Stoppable:   st.Stoppable,
+		StartedAt:   st.StartedAt,
+		Restarts:    st.Restarts,
+		Reporting:   st.Reporting,
+		WorkerState: st.WorkerState,
+		LastWrite:   st.LastWrite,
+		Errors:      st.Errors,
+		LastError:   st.LastError,
+	}
+}
+
 func (s GRPCService) ServerVersion(ctx context.Context, req *proto.ServerVersionRequest,
 ) (*proto.ServerVersionResponse, error) {
 	return &proto.ServerVersionResponse{
@@ -43,6 +43,11 @@ func (b *QueryRequestBuilder) Functions(value []string) *QueryRequestBuilder {
 	return b
 }
 
+func (b *QueryRequestBuilder) SymbolFilter(value string) *QueryRequestBuilder {
+	b.qr.SymbolFilter = value
+	return b
+}
+
 func (b *QueryRequestBuilder) End() QueryRequest {
 	return *b.qr
 }
@@ -1,10 +1,10 @@
 package frontend
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"net/http"
-	"strings"
 	"sync/atomic"
 	"time"
 
@@ -44,6 +44,9 @@ type QueryRequest struct {
 
 	// Support for functions is experimental and subject to change
 	Functions []string `msgpack:"functions,omitempty"`
+	// Selects the symbols by their metadata (e.g. "exchange=NASDAQ AND sector=Tech")
+	// among the symbols of the destination, which can be "*" for all the symbols
+	SymbolFilter string `msgpack:"symbol_filter,omitempty"`
 }
 
 type MultiQueryRequest struct {
@@ -89,6 +92,16 @@ func (resp *MultiQueryResponse) ToColumnSeriesMap() (*io.ColumnSeriesMap, error)
 }
 
 func (s *DataService) Query(r *http.Request, reqs *MultiQueryRequest, response *MultiQueryResponse) (err error) {
+	ctx := context.Background()
+	if r != nil {
+		ctx = r.Context()
+	}
+	ctx, done, err := s.limiter.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
 	response.Version = utils.GitHash
 	response.Timezone = utils.InstanceConfig.Timezone.String()
 	for i := range reqs.Requests {
@@ -96,6 +109,10 @@ func (s *DataService) Query(r *http.Request, reqs *MultiQueryRequest, response *
 			resp *QueryResponse
 			err  error
 		)
+		if err = ctx.Err(); err != nil {
+			countLimitError(err)
+			return err
+		}
 		// SQL
 		if reqs.Requests[i].IsSQLStatement {
 			resp, err = s.executeSQL(reqs.Requests[i].SQLStatement)
@@ -104,7 +121,7 @@ func (s *DataService) Query(r *http.Request, reqs *MultiQueryRequest, response *
 			}
 		} else {
 			// Query
-			resp, err = s.executeQuery(&reqs.Requests[i])
+			resp, err = s.executeQuery(ctx, &reqs.Requests[i])
 			if err != nil {
 				return err
 			}
@@ -124,10 +141,13 @@ func (s *DataService) executeSQL(sqlStatement string) (*QueryResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	cs, err := es.Materialize(s.aggRunner, s.catalogDir)
+	cs, err := es.Materialize(s.aggRunner, s.catalogDir, s.writer)
 	if err != nil {
 		return nil, err
 	}
+	if s.cache != nil {
+		s.cache.invalidateDroppedTables(es)
+	}
 	nds, err := io.NewNumpyDataset(cs)
 	if err != nil {
 		return nil, err
@@ -140,7 +160,7 @@ func (s *DataService) executeSQL(sqlStatement string) (*QueryResponse, error) {
 	return &QueryResponse{nmds}, nil
 }
 
-func (s *DataService) executeQuery(req *QueryRequest) (*QueryResponse, error) {
+func (s *DataService) executeQuery(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
 	/*
 		Assumption: Within each TimeBucketKey, we have one or more of each category, with the exception of
 		the AttributeGroup (aka Record Format) and Timeframe
@@ -161,15 +181,10 @@ func (s *DataService) executeQuery(req *QueryRequest) (*QueryResponse, error) {
 	if len(Timeframe) == 0 || len(RecordFormat) == 0 || len(Symbols) == 0 {
 		return nil, fmt.Errorf("destinations must have a Symbol, Timeframe and AttributeGroup, have: %s",
 			dest.String())
-	} else if len(Symbols) == 1 && Symbols[0] == "*" {
-		// replace the * "symbol" with a list all known actual symbols
-		symbols, err := gatherAllSymbols(s.catalogDir)
-		if err != nil {
-			return nil, err
-		}
-		keyParts := []string{strings.Join(symbols, ","), Timeframe, RecordFormat}
-		itemKey := strings.Join(keyParts, "/")
-		dest = io.NewTimeBucketKey(itemKey, req.KeyCategory)
+	}
+	dest, err := expandSymbols(s.catalogDir, dest, req.KeyCategory, req.SymbolFilter)
+	if err != nil {
+		return nil, err
 	}
 
 	epochStart := int64(0)
@@ -200,31 +215,21 @@ func (s *DataService) executeQuery(req *QueryRequest) (*QueryResponse, error) {
 		columns = req.Columns
 	}
 
-	start := io.ToSystemTimezone(time.Unix(epochStart, epochStartNanos))
-	end := io.ToSystemTimezone(time.Unix(epochEnd, epochEndNanos))
-	csm, err := s.query.ExecuteQuery(
-		dest,
-		start, end,
-		limitRecordCount, limitFromStart,
-		columns,
-	)
+	csm, err := runQuery(ctx, s.query, s.aggRunner, s.cache, &queryParams{
+		dest:             dest,
+		epochStart:       epochStart,
+		epochStartNanos:  epochStartNanos,
+		epochEnd:         epochEnd,
+		epochEndNanos:    epochEndNanos,
+		limitRecordCount: limitRecordCount,
+		limitFromStart:   limitFromStart,
+		columns:          columns,
+		functions:        req.Functions,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	/*
-		Execute function pipeline, if requested
-	*/
-	if len(req.Functions) != 0 {
-		for tbkStr, cs := range csm {
-			csOut, err2 := s.aggRunner.Run(req.Functions, cs, tbkStr)
-			if err2 != nil {
-				return nil, err2
-			}
-			csm[tbkStr] = csOut
-		}
-	}
-
 	/*
 		Separate each TimeBucket from the result and compose a NumpyMultiDataset
 	*/
@@ -259,6 +264,8 @@ type ListSymbolsResponse struct {
 type ListSymbolsRequest struct {
 	// "symbol", or "tbk"
 	Format string `msgpack:"format,omitempty"`
+	// Lists only the symbols whose metadata matches the filter (e.g. "exchange=NASDAQ AND sector=Tech")
+	Filter string `msgpack:"filter,omitempty"`
 }
 
 func (s *DataService) ListSymbols(r *http.Request, req *ListSymbolsRequest, response *ListSymbolsResponse) (err error) {
@@ -266,25 +273,79 @@ func (s *DataService) ListSymbols(r *http.Request, req *ListSymbolsRequest, resp
 		return errNotQueryable
 	}
 
-	// TBK format (e.g. ["AMZN/1Min/TICK", "AAPL/1Sec/OHLCV", ...])
-	if req != nil && req.Format == "tbk" {
-		response.Results = catalog.ListTimeBucketKeyNames(s.catalogDir)
-		return nil
+	if req == nil {
+		req = &ListSymbolsRequest{}
 	}
+	// TBK format (e.g. ["AMZN/1Min/TICK", "AAPL/1Sec/OHLCV", ...]) or Symbol format (e.g. ["AMZN", "AAPL", ...])
+	response.Results, err = listSymbols(s.catalogDir, req.Format == "tbk", req.Filter)
+	return err
+}
 
-	// Symbol format (e.g. ["AMZN", "AAPL", ...])
-	ret, err := s.catalogDir.GatherCategoriesAndItems()
+// queryParams are the parameters of a query request of the JSON-RPC and gRPC APIs.
+type queryParams struct {
+	dest             *io.TimeBucketKey
+	epochStart       int64
+	epochStartNanos  int64
+	epochEnd         int64
+	epochEndNanos    int64
+	limitRecordCount int
+	limitFromStart   bool
+	columns          []string
+	functions        []string
+}
+
+// runQuery executes the query and the function pipeline on the result until the context is done,
+// or returns the result of the same query in the cache if it's not nil.
+// The returned result can be shared by the cache and must not be modified.
+func runQuery(ctx context.Context, q QueryInterface, aggRunner *sqlparser.AggRunner, cache *QueryCache,
+	p *queryParams,
+) (io.ColumnSeriesMap, error) {
+	var (
+		key        string
+		deps       []queryCacheDependency
+		generation uint64
+	)
+	if cache != nil {
+		var err error
+		// ExecuteQuery alters the timeframe of the destination, so the key is made before it
+		if key, deps, err = p.queryCacheKey(); err != nil {
+			return nil, err
+		}
+		var csm io.ColumnSeriesMap
+		var found bool
+		if csm, generation, found = cache.get(key); found {
+			return csm, nil
+		}
+	}
+
+	start := io.ToSystemTimezone(time.Unix(p.epochStart, p.epochStartNanos))
+	end := io.ToSystemTimezone(time.Unix(p.epochEnd, p.epochEndNanos))
+	csm, err := q.ExecuteQuery(
+		ctx,
+		p.dest,
+		start, end,
+		p.limitRecordCount, p.limitFromStart,
+		p.columns,
+	)
 	if err != nil {
-		return fmt.Errorf("gather categories and items from catalog dir to list symbols: %w", err)
+		countLimitError(err)
+		return nil, err
 	}
-	symbols := ret["Symbol"]
-	response.Results = make([]string, len(symbols))
-	cnt := 0
-	for symbol := range symbols {
-		response.Results[cnt] = symbol
-		cnt++
+
+	/*
+		Execute function pipeline, if requested
+	*/
+	if len(p.functions) != 0 {
+		if csm, err = aggRunner.RunMap(ctx, p.functions, csm); err != nil {
+			countLimitError(err)
+			return nil, err
+		}
 	}
-	return nil
+
+	if cache != nil {
+		cache.put(key, deps, csm, generation)
+	}
+	return csm, nil
 }
 
 /*
@@ -293,6 +354,7 @@ Utility functions
 
 type QueryService struct {
 	catalogDir *catalog.Directory
+	limits     executor.ResultLimits
 }
 
 func NewQueryService(catDir *catalog.Directory) *QueryService {
@@ -301,8 +363,14 @@ func NewQueryService(catDir *catalog.Directory) *QueryService {
 	}
 }
 
-func (qs *QueryService) ExecuteQuery(tbk *io.TimeBucketKey, start, end time.Time, limitRecordCount int,
-	limitFromStart bool, columns []string,
+// SetResultLimits makes the queries fail when their result exceeds the limits.
+func (qs *QueryService) SetResultLimits(limits executor.ResultLimits) {
+	qs.limits = limits
+}
+
+// ExecuteQuery reads the data of the time bucket key until the context is done.
+func (qs *QueryService) ExecuteQuery(ctx context.Context, tbk *io.TimeBucketKey, start, end time.Time,
+	limitRecordCount int, limitFromStart bool, columns []string,
 ) (io.ColumnSeriesMap, error) {
 	query := planner.NewQuery(qs.catalogDir)
 
@@ -334,7 +402,7 @@ func (qs *QueryService) ExecuteQuery(tbk *io.TimeBucketKey, start, end time.Time
 	}
 
 	query.SetRange(start, end)
-	parseResult, err := query.Parse()
+	parseResult, err := query.ParseContext(ctx)
 	if err != nil {
 		// No results from query
 		if err.Error() == "no files returned from query parse" {
@@ -350,7 +418,8 @@ func (qs *QueryService) ExecuteQuery(tbk *io.TimeBucketKey, start, end time.Time
 		log.Error("Unable to create scanner: %s\n", err)
 		return nil, err
 	}
-	csm, err := scanner.Read()
+	scanner.SetResultLimits(qs.limits)
+	csm, err := scanner.ReadContext(ctx)
 	if err != nil {
 		log.Error("Error returned from query scanner: %s\n", err)
 		return nil, err
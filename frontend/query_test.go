@@ -13,6 +13,8 @@ import (
 
 	"github.com/alpacahq/marketstore/v4/executor"
 	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/models/enum"
 	"github.com/alpacahq/marketstore/v4/sqlparser"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 	"github.com/alpacahq/marketstore/v4/utils/test"
@@ -359,6 +361,14 @@ func TestFunctions(t *testing.T) {
 	assert.Equal(t, pList[4], "Sum::P5")
 	assert.Equal(t, pList[5], "Avg::P6")
 
+	// unquoted numbers are literals of the functions that take numeric literals
+	call = "rolling_mean('Close', 20, 'MA20')"
+	fname, lList, pList, err = sqlparser.NewDefaultAggRunner(metadata.CatalogDir).ParseFunctionCall(call)
+	assert.Nil(t, err)
+	assert.Equal(t, "rolling_mean", fname)
+	assert.Equal(t, []string{"Close", "20", "MA20"}, lList)
+	assert.Empty(t, pList)
+
 	args := &frontend.MultiQueryRequest{
 		Requests: []frontend.QueryRequest{
 			frontend.NewQueryRequestBuilder("USDJPY/1Min/OHLC").
@@ -385,3 +395,86 @@ func TestFunctions(t *testing.T) {
 	tref := time.Date(2002, time.December, 31, 23, 55, 0, 0, time.UTC)
 	assert.Equal(t, ti, tref)
 }
+
+func TestResampleFunction(t *testing.T) {
+	rootDir, metadata, writer, q := setup(t)
+
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir,
+		sqlparser.NewDefaultAggRunner(metadata.CatalogDir), writer, q,
+	)
+	service.Init()
+
+	args := &frontend.MultiQueryRequest{
+		Requests: []frontend.QueryRequest{
+			frontend.NewQueryRequestBuilder("USDJPY,EURUSD/1Min/OHLC").
+				LimitRecordCount(200).
+				Functions([]string{"resample('5Min', 'ffill', 'pivot')"}).
+				End(),
+		},
+	}
+
+	var response frontend.MultiQueryResponse
+	if err := service.Query(nil, args, &response); err != nil {
+		t.Fatalf("error returned: %s", err)
+	}
+
+	csm, err := response.Responses[0].Result.ToColumnSeriesMap()
+	assert.Nil(t, err)
+	assert.Len(t, csm, 1)
+	cs := csm[*io.NewTimeBucketKey("EURUSD,USDJPY/5Min/OHLC")]
+	if cs == nil {
+		t.Fatalf("no pivoted result in %v", csm.GetMetadataKeys())
+	}
+	assert.Equal(t, []string{
+		"Epoch",
+		"EURUSD_Open", "EURUSD_High", "EURUSD_Low", "EURUSD_Close",
+		"USDJPY_Open", "USDJPY_High", "USDJPY_Low", "USDJPY_Close",
+	}, cs.GetColumnNames())
+	index := cs.GetEpoch()
+	assert.Len(t, index, 40)
+	tref := time.Date(2002, time.December, 31, 23, 55, 0, 0, time.UTC)
+	assert.Equal(t, tref, time.Unix(index[len(index)-1], 0).UTC())
+}
+
+func TestOrderBookFunction(t *testing.T) {
+	rootDir, metadata, writer, q := setup(t)
+
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir,
+		sqlparser.NewDefaultAggRunner(metadata.CatalogDir), writer, q,
+	)
+	service.Init()
+
+	t1 := time.Date(2021, 12, 1, 10, 0, 0, 100, time.UTC)
+	t2 := t1.Add(1200 * time.Millisecond)
+	snapshot := models.NewOrderBookSnapshot("BOOK", 0)
+	snapshot.Add(t1.Unix(), t1.Nanosecond(), enum.Bid, 100, 10)
+	snapshot.Add(t1.Unix(), t1.Nanosecond(), enum.Bid, 99, 5)
+	snapshot.Add(t1.Unix(), t1.Nanosecond(), enum.Ask, 101, 7)
+	assert.Nil(t, writer.WriteCSM(*snapshot.BuildCsm(), true))
+	delta := models.NewOrderBookDelta("BOOK", 0)
+	delta.Add(t2.Unix(), t2.Nanosecond(), enum.Bid, 100, 0)
+	delta.Add(t2.Unix(), t2.Nanosecond(), enum.Ask, 100.5, 3)
+	assert.Nil(t, writer.WriteCSM(*delta.BuildCsm(), true))
+
+	// the order book is reconstructed as of the last delta of the query
+	args := &frontend.MultiQueryRequest{
+		Requests: []frontend.QueryRequest{
+			frontend.NewQueryRequestBuilder(models.OrderBookDeltaBucketKey("BOOK")).
+				Functions([]string{"orderbook('1')"}).
+				End(),
+		},
+	}
+
+	var response frontend.MultiQueryResponse
+	if err := service.Query(nil, args, &response); err != nil {
+		t.Fatalf("error returned: %s", err)
+	}
+
+	cs, err := response.Responses[0].Result.ToColumnSeries()
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{t2.Unix(), t2.Unix()}, cs.GetEpoch())
+	assert.Equal(t, []byte{byte(enum.Bid), byte(enum.Ask)}, cs.GetColumn("Side"))
+	assert.Equal(t, []int32{1, 1}, cs.GetColumn("Level"))
+	assert.Equal(t, []float64{99, 100.5}, cs.GetColumn("Price"))
+	assert.Equal(t, []uint64{5, 3}, cs.GetColumn("Size"))
+}
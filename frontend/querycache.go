@@ -0,0 +1,301 @@
+package frontend
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/metrics"
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// QueryCache is an in-memory LRU cache of the query results of the Query APIs, with a memory budget.
+// Each result depends on the years of the time buckets that the query reads,
+// and is invalidated when the WAL flushes a write to one of them (see executor.FlushListener).
+type QueryCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	bytes    int64
+	// lru has the most recently used entry at the front
+	lru     *list.List
+	entries map[string]*list.Element
+	// dependents are the entries that depend on each time bucket key
+	dependents map[string]map[*list.Element]struct{}
+	// generation is incremented by each invalidation, and invalidated has the generation of
+	// the last invalidation of each time bucket key, so that a result read before an invalidation
+	// of its time buckets isn't cached after it
+	generation  uint64
+	invalidated map[string]uint64
+	// invalidatedSymbols has the generation of the last invalidation of each symbol
+	invalidatedSymbols map[string]uint64
+	// resolveSymbol returns the symbol that an alias refers to, so that a result queried with the alias
+	// depends on the time buckets of the symbol. nil if the symbols are not resolved
+	resolveSymbol func(symbol string) string
+}
+
+type queryCacheEntry struct {
+	key  string
+	csm  io.ColumnSeriesMap
+	size int64
+	deps []queryCacheDependency
+}
+
+// queryCacheDependency is a time bucket and the range of the years that a query reads.
+type queryCacheDependency struct {
+	tbk                string
+	startYear, endYear int
+}
+
+func NewQueryCache(maxBytes int64) *QueryCache {
+	return &QueryCache{
+		maxBytes:    maxBytes,
+		lru:         list.New(),
+		entries:     map[string]*list.Element{},
+		dependents:  map[string]map[*list.Element]struct{}{},
+		invalidated: map[string]uint64{},
+
+		invalidatedSymbols: map[string]uint64{},
+	}
+}
+
+// SetSymbolResolver resolves the symbols of the cached results with the function,
+// e.g. catalog.Directory.ResolveSymbol for the symbol aliases.
+func (qc *QueryCache) SetSymbolResolver(resolve func(symbol string) string) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	qc.resolveSymbol = resolve
+}
+
+// Flushed invalidates the cached results that depend on the written years of the time buckets.
+func (qc *QueryCache) Flushed(written map[io.TimeBucketKey][]int16) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	qc.generation++
+	for tbk, years := range written {
+		qc.invalidated[tbk.String()] = qc.generation
+		for elem := range qc.dependents[tbk.String()] {
+			//nolint:forcetypeassert // the list only has entries
+			entry := elem.Value.(*queryCacheEntry)
+			if entry.dependsOn(tbk.String(), years) {
+				qc.remove(elem)
+				metrics.QueryCacheEvictions.WithLabelValues("write").Inc()
+			}
+		}
+	}
+	metrics.QueryCacheBytes.Set(float64(qc.bytes))
+}
+
+// InvalidateKey removes the cached results that depend on any year of the time bucket,
+// e.g. when the time bucket is destroyed.
+func (qc *QueryCache) InvalidateKey(tbk *io.TimeBucketKey) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	qc.generation++
+	qc.invalidated[tbk.String()] = qc.generation
+	for elem := range qc.dependents[tbk.String()] {
+		qc.remove(elem)
+		metrics.QueryCacheEvictions.WithLabelValues("write").Inc()
+	}
+	metrics.QueryCacheBytes.Set(float64(qc.bytes))
+}
+
+// InvalidateSymbols removes the cached results that depend on any time bucket of the symbols,
+// e.g. when the corporate actions used to adjust their prices are imported.
+func (qc *QueryCache) InvalidateSymbols(symbols ...string) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	qc.generation++
+	for _, symbol := range symbols {
+		qc.invalidatedSymbols[symbol] = qc.generation
+	}
+	for tbk, elems := range qc.dependents {
+		if qc.invalidatedSymbols[tbkSymbol(tbk)] != qc.generation {
+			continue
+		}
+		for elem := range elems {
+			qc.remove(elem)
+			metrics.QueryCacheEvictions.WithLabelValues("write").Inc()
+		}
+	}
+	metrics.QueryCacheBytes.Set(float64(qc.bytes))
+}
+
+// invalidateDroppedTables removes the cached results of the tables dropped by the SQL statement.
+func (qc *QueryCache) invalidateDroppedTables(es *sqlparser.ExecutableStatement) {
+	for _, child := range es.GetChildren() {
+		if ds, ok := child.(*sqlparser.DropTableStatement); ok {
+			qc.InvalidateKey(io.NewTimeBucketKey(ds.TableName))
+		}
+	}
+}
+
+// Len returns the number of the cached results.
+func (qc *QueryCache) Len() int {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	return qc.lru.Len()
+}
+
+// get returns the cached result of the query and the current generation to put the result after a miss.
+// The returned result is shared and must not be modified.
+func (qc *QueryCache) get(key string) (csm io.ColumnSeriesMap, generation uint64, ok bool) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	elem, found := qc.entries[key]
+	if !found {
+		metrics.QueryCacheMisses.Inc()
+		return nil, qc.generation, false
+	}
+	metrics.QueryCacheHits.Inc()
+	qc.lru.MoveToFront(elem)
+	//nolint:forcetypeassert // the list only has entries
+	return elem.Value.(*queryCacheEntry).csm, qc.generation, true
+}
+
+// put caches the result of the query unless its time buckets were invalidated since the generation,
+// evicting the least recently used results to stay within the memory budget.
+func (qc *QueryCache) put(key string, deps []queryCacheDependency, csm io.ColumnSeriesMap, generation uint64) {
+	size := columnSeriesMapSize(csm)
+	if size > qc.maxBytes {
+		return
+	}
+
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	deps = qc.resolveDependencies(deps)
+	for _, dep := range deps {
+		if qc.invalidated[dep.tbk] > generation || qc.invalidatedSymbols[tbkSymbol(dep.tbk)] > generation {
+			return
+		}
+	}
+	if elem, found := qc.entries[key]; found {
+		qc.remove(elem)
+	}
+	for qc.bytes+size > qc.maxBytes {
+		qc.remove(qc.lru.Back())
+		metrics.QueryCacheEvictions.WithLabelValues("size").Inc()
+	}
+
+	elem := qc.lru.PushFront(&queryCacheEntry{key: key, csm: csm, size: size, deps: deps})
+	qc.entries[key] = elem
+	for _, dep := range deps {
+		if qc.dependents[dep.tbk] == nil {
+			qc.dependents[dep.tbk] = map[*list.Element]struct{}{}
+		}
+		qc.dependents[dep.tbk][elem] = struct{}{}
+	}
+	qc.bytes += size
+	metrics.QueryCacheBytes.Set(float64(qc.bytes))
+}
+
+func (qc *QueryCache) remove(elem *list.Element) {
+	//nolint:forcetypeassert // the list only has entries
+	entry := elem.Value.(*queryCacheEntry)
+	qc.lru.Remove(elem)
+	delete(qc.entries, entry.key)
+	for _, dep := range entry.deps {
+		delete(qc.dependents[dep.tbk], elem)
+		if len(qc.dependents[dep.tbk]) == 0 {
+			delete(qc.dependents, dep.tbk)
+		}
+	}
+	qc.bytes -= entry.size
+}
+
+// resolveDependencies returns the dependencies with the symbols of the time buckets resolved.
+// The caller must hold the lock.
+func (qc *QueryCache) resolveDependencies(deps []queryCacheDependency) []queryCacheDependency {
+	if qc.resolveSymbol == nil {
+		return deps
+	}
+	resolved := make([]queryCacheDependency, len(deps))
+	for i, dep := range deps {
+		resolved[i] = dep
+		symbol := tbkSymbol(dep.tbk)
+		if target := qc.resolveSymbol(symbol); target != symbol {
+			resolved[i].tbk = target + dep.tbk[len(symbol):]
+		}
+	}
+	return resolved
+}
+
+// tbkSymbol returns the symbol of a time bucket key string, e.g. "AAPL" of "AAPL/1Min/OHLCV:Symbol/...".
+func tbkSymbol(tbk string) string {
+	if i := strings.IndexByte(tbk, '/'); i >= 0 {
+		return tbk[:i]
+	}
+	return tbk
+}
+
+func (e *queryCacheEntry) dependsOn(tbk string, years []int16) bool {
+	for _, dep := range e.deps {
+		if dep.tbk != tbk {
+			continue
+		}
+		for _, year := range years {
+			if int(year) >= dep.startYear && int(year) <= dep.endYear {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// columnSeriesMapSize returns the approximate memory used by the column data and the null masks.
+func columnSeriesMapSize(csm io.ColumnSeriesMap) int64 {
+	var size int64
+	for _, cs := range csm {
+		for _, name := range cs.GetColumnNames() {
+			col := reflect.ValueOf(cs.GetColumn(name))
+			if col.Kind() == reflect.Slice {
+				size += int64(col.Len()) * int64(col.Type().Elem().Size())
+			}
+			size += int64(len(cs.GetNullMask(name)) + len(name))
+		}
+	}
+	return size
+}
+
+// queryCacheKey returns the key of the query in the cache, and the time buckets and years that it reads.
+// The symbols in the destination are sorted, so that the same symbols in a different order share the result.
+func (p *queryParams) queryCacheKey() (key string, deps []queryCacheDependency, err error) {
+	symbols := p.dest.GetMultiItemInCategory("Symbol")
+	sorted := make([]string, len(symbols))
+	copy(sorted, symbols)
+	sort.Strings(sorted)
+	timeframe := p.dest.GetItemInCategory("Timeframe")
+	recordFormat := p.dest.GetItemInCategory("AttributeGroup")
+	catKey := p.dest.GetCatKey()
+
+	key = strings.Join([]string{
+		strings.Join(sorted, ",") + "/" + timeframe + "/" + recordFormat + ":" + catKey,
+		fmt.Sprint(p.epochStart, p.epochStartNanos, p.epochEnd, p.epochEndNanos),
+		fmt.Sprint(p.limitRecordCount, p.limitFromStart),
+		strings.Join(p.columns, ","),
+		// the function calls can have commas in them
+		strings.Join(p.functions, "\x00"),
+	}, "\x1f")
+
+	// the buckets read for the timeframe
+	cd, err := utils.CandleDurationFromString(timeframe)
+	if err != nil {
+		return "", nil, fmt.Errorf("timeframe not found in TimeBucketKey=%s: %w", p.dest.String(), err)
+	}
+	startYear := io.ToSystemTimezone(time.Unix(p.epochStart, p.epochStartNanos)).Year()
+	endYear := math.MaxInt32
+	if p.epochEnd != math.MaxInt64 {
+		endYear = io.ToSystemTimezone(time.Unix(p.epochEnd, p.epochEndNanos)).Year()
+	}
+	for _, symbol := range sorted {
+		tbk := io.NewTimeBucketKey(symbol+"/"+cd.QueryableTimeframe()+"/"+recordFormat, catKey)
+		deps = append(deps, queryCacheDependency{tbk: tbk.String(), startYear: startYear, endYear: endYear})
+	}
+	return key, deps, nil
+}
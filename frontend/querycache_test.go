@@ -0,0 +1,162 @@
+package frontend_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func lastOpen(t *testing.T, service *frontend.DataService, dest string, end *time.Time) float32 {
+	t.Helper()
+
+	builder := frontend.NewQueryRequestBuilder(dest).LimitRecordCount(10)
+	if end != nil {
+		builder = builder.EpochEnd(end.Unix())
+	}
+	var response frontend.MultiQueryResponse
+	err := service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{builder.End()}}, &response)
+	require.Nil(t, err)
+	cs, err := response.Responses[0].Result.ToColumnSeries()
+	require.Nil(t, err)
+	opens, ok := cs.GetColumn("Open").([]float32)
+	require.True(t, ok)
+	return opens[len(opens)-1]
+}
+
+func TestQueryCache(t *testing.T) {
+	rootDir, metadata, writer, q := setup(t)
+
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	service.Init()
+	cache := frontend.NewQueryCache(1 << 20)
+	metadata.WALFile.AddFlushListener(cache)
+	service.SetQueryCache(cache)
+
+	// --- when the same queries are repeated ---
+	end2000 := time.Date(2000, time.December, 31, 0, 0, 0, 0, time.UTC)
+	open := lastOpen(t, service, "USDJPY/1Min/OHLC", nil)
+	open2000 := lastOpen(t, service, "USDJPY/1Min/OHLC", &end2000)
+	lastOpen(t, service, "EURUSD/1Min/OHLC", nil)
+	assert.Equal(t, open, lastOpen(t, service, "USDJPY/1Min/OHLC", nil))
+	assert.Equal(t, open2000, lastOpen(t, service, "USDJPY/1Min/OHLC", &end2000))
+
+	// --- then the results are cached ---
+	assert.Equal(t, 3, cache.Len())
+
+	// --- when the last bar of USDJPY in 2002 is overwritten ---
+	var response frontend.MultiQueryResponse
+	err := service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{
+		frontend.NewQueryRequestBuilder("USDJPY/1Min/OHLC").LimitRecordCount(1).End(),
+	}}, &response)
+	require.Nil(t, err)
+	csm, err := response.Responses[0].Result.ToColumnSeriesMap()
+	require.Nil(t, err)
+	for tbk, cs := range csm {
+		// the columns are written in the order of the time bucket
+		overwritten := io.NewColumnSeries()
+		overwritten.AddColumn("Epoch", cs.GetEpoch())
+		overwritten.AddColumn("Open", []float32{open + 100})
+		for _, name := range []string{"High", "Low", "Close"} {
+			overwritten.AddColumn(name, cs.GetColumn(name))
+		}
+		csm[tbk] = overwritten
+	}
+	assert.Equal(t, 4, cache.Len())
+	require.Nil(t, writer.WriteCSM(csm, false))
+
+	// --- then only the results that read 2002 of USDJPY are invalidated ---
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, open+100, lastOpen(t, service, "USDJPY/1Min/OHLC", nil))
+	assert.Equal(t, open2000, lastOpen(t, service, "USDJPY/1Min/OHLC", &end2000))
+	assert.Equal(t, 3, cache.Len())
+
+	// --- when the time bucket is destroyed ---
+	var destroyResponse frontend.MultiServerResponse
+	err = service.Destroy(nil, &frontend.MultiKeyRequest{
+		Requests: []frontend.KeyRequest{{Key: "USDJPY/1Min/OHLC"}},
+	}, &destroyResponse)
+	require.Nil(t, err)
+
+	// --- then all the results of the time bucket are invalidated ---
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestQueryCache_SQLDelete(t *testing.T) {
+	rootDir, metadata, writer, q := setup(t)
+
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	service.Init()
+	cache := frontend.NewQueryCache(1 << 20)
+	metadata.WALFile.AddFlushListener(cache)
+	service.SetQueryCache(cache)
+	lastEpoch := func() int64 {
+		t.Helper()
+		var response frontend.MultiQueryResponse
+		err := service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{
+			frontend.NewQueryRequestBuilder("USDJPY/1Min/OHLC").LimitRecordCount(1).End(),
+		}}, &response)
+		require.Nil(t, err)
+		cs, err := response.Responses[0].Result.ToColumnSeries()
+		require.Nil(t, err)
+		require.Equal(t, 1, cs.Len())
+		return cs.GetEpoch()[0]
+	}
+
+	// --- given the result of the query is cached ---
+	epoch := lastEpoch()
+	assert.Equal(t, epoch, lastEpoch())
+	assert.Equal(t, 1, cache.Len())
+
+	// --- when the last bar is deleted by SQL ---
+	var response frontend.MultiQueryResponse
+	err := service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{{
+		IsSQLStatement: true,
+		SQLStatement:   fmt.Sprintf("DELETE FROM `USDJPY/1Min/OHLC` WHERE Epoch = %d;", epoch),
+	}}}, &response)
+	require.Nil(t, err)
+
+	// --- then the cached result is invalidated and the query reads the bar before it ---
+	assert.Equal(t, 0, cache.Len())
+	assert.Less(t, lastEpoch(), epoch)
+}
+
+func TestQueryCacheEviction(t *testing.T) {
+	rootDir, metadata, writer, q := setup(t)
+
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	service.Init()
+	// 10 rows of Epoch and OHLC are 10*(8+4*4) = 240 bytes + the column names
+	cache := frontend.NewQueryCache(600)
+	service.SetQueryCache(cache)
+
+	lastOpen(t, service, "USDJPY/1Min/OHLC", nil)
+	lastOpen(t, service, "EURUSD/1Min/OHLC", nil)
+	assert.Equal(t, 2, cache.Len())
+
+	// the least recently used result of USDJPY is evicted
+	lastOpen(t, service, "EURUSD/1Min/OHLC", nil)
+	lastOpen(t, service, "NZDUSD/1Min/OHLC", nil)
+	assert.Equal(t, 2, cache.Len())
+	lastOpen(t, service, "EURUSD/1Min/OHLC", nil)
+	lastOpen(t, service, "NZDUSD/1Min/OHLC", nil)
+	assert.Equal(t, 2, cache.Len())
+
+	// the results over the memory budget are not cached
+	var response frontend.MultiQueryResponse
+	err := service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{
+		frontend.NewQueryRequestBuilder("USDJPY,EURUSD,NZDUSD/1Min/OHLC").LimitRecordCount(10).End(),
+	}}, &response)
+	require.Nil(t, err)
+	assert.Equal(t, 2, cache.Len())
+	csm, err := response.Responses[0].Result.ToColumnSeriesMap()
+	require.Nil(t, err)
+	assert.Len(t, csm, 3)
+	assert.NotNil(t, csm[*io.NewTimeBucketKey("NZDUSD/1Min/OHLC")])
+}
@@ -0,0 +1,75 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/metrics"
+)
+
+// QueryLimiter limits the query requests of the JSON-RPC and gRPC APIs, shared by both of them.
+// Each request has a deadline, and the requests over the max concurrent queries wait in a queue
+// until a running query finishes or their deadline passes.
+type QueryLimiter struct {
+	timeout time.Duration
+	// slots has a token per running query, or is nil for unlimited concurrent queries
+	slots chan struct{}
+}
+
+// NewQueryLimiter returns a limiter with the timeout of each request and the max concurrent queries.
+// Zero is unlimited.
+func NewQueryLimiter(timeout time.Duration, maxConcurrent int) *QueryLimiter {
+	l := &QueryLimiter{timeout: timeout}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// acquire waits for the turn of a query request, and returns the context of the request with its deadline
+// and a function to call when the request is done. A nil limiter doesn't limit the requests.
+func (l *QueryLimiter) acquire(ctx context.Context) (context.Context, func(), error) {
+	if l == nil {
+		return ctx, func() {}, nil
+	}
+	cancel := context.CancelFunc(func() {})
+	if l.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+	}
+	if l.slots == nil {
+		return ctx, cancel, nil
+	}
+
+	metrics.QueriesQueued.Inc()
+	select {
+	case l.slots <- struct{}{}:
+		metrics.QueriesQueued.Dec()
+	case <-ctx.Done():
+		metrics.QueriesQueued.Dec()
+		cancel()
+		err := fmt.Errorf("query request waited for the %d concurrent queries: %w", cap(l.slots), ctx.Err())
+		countLimitError(err)
+		return nil, nil, err
+	}
+	metrics.QueriesRunning.Inc()
+	return ctx, func() {
+		<-l.slots
+		metrics.QueriesRunning.Dec()
+		cancel()
+	}, nil
+}
+
+// countLimitError counts the error in the metrics if it's caused by a limit of the query requests.
+func countLimitError(err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		metrics.QueryLimitErrors.WithLabelValues("timeout").Inc()
+	case errors.Is(err, context.Canceled):
+		metrics.QueryLimitErrors.WithLabelValues("canceled").Inc()
+	case errors.Is(err, executor.ErrResultTooLarge):
+		metrics.QueryLimitErrors.WithLabelValues("result_size").Inc()
+	}
+}
@@ -0,0 +1,122 @@
+package frontend_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// blockingQuery is a query that runs until it's released or its context is done.
+type blockingQuery struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (q *blockingQuery) ExecuteQuery(ctx context.Context, _ *io.TimeBucketKey, _, _ time.Time, _ int,
+	_ bool, _ []string,
+) (io.ColumnSeriesMap, error) {
+	q.started <- struct{}{}
+	select {
+	case <-q.release:
+		return io.NewColumnSeriesMap(), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func queryRequest(t *testing.T, ctx context.Context) (*http.Request, *frontend.MultiQueryRequest) {
+	t.Helper()
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "/rpc", http.NoBody)
+	require.Nil(t, err)
+	return r, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{
+		frontend.NewQueryRequestBuilder("USDJPY/1Min/OHLC").End(),
+	}}
+}
+
+func TestQueryLimiterConcurrency(t *testing.T) {
+	t.Parallel()
+
+	q := &blockingQuery{started: make(chan struct{}, 2), release: make(chan struct{})}
+	service := frontend.NewDataService("", nil, sqlparser.NewAggRunner(nil), nil, q)
+	service.SetQueryLimiter(frontend.NewQueryLimiter(0, 1))
+
+	// --- when a query is running ---
+	running := make(chan error)
+	go func() {
+		r, reqs := queryRequest(t, context.Background())
+		running <- service.Query(r, reqs, &frontend.MultiQueryResponse{})
+	}()
+	<-q.started
+
+	// --- then the other queries wait in the queue until their deadline ---
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r, reqs := queryRequest(t, ctx)
+	err := service.Query(r, reqs, &frontend.MultiQueryResponse{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// --- or until the running query finishes ---
+	queued := make(chan error)
+	go func() {
+		r, reqs := queryRequest(t, context.Background())
+		queued <- service.Query(r, reqs, &frontend.MultiQueryResponse{})
+	}()
+	select {
+	case <-q.started:
+		t.Fatal("the queued query must not run with the running query")
+	case <-time.After(50 * time.Millisecond):
+	}
+	q.release <- struct{}{}
+	assert.Nil(t, <-running)
+	<-q.started
+	q.release <- struct{}{}
+	assert.Nil(t, <-queued)
+}
+
+func TestQueryLimiterTimeout(t *testing.T) {
+	t.Parallel()
+
+	q := &blockingQuery{started: make(chan struct{}, 1), release: make(chan struct{})}
+	service := frontend.NewDataService("", nil, sqlparser.NewAggRunner(nil), nil, q)
+	service.SetQueryLimiter(frontend.NewQueryLimiter(50*time.Millisecond, 0))
+
+	r, reqs := queryRequest(t, context.Background())
+	err := service.Query(r, reqs, &frontend.MultiQueryResponse{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQueryResultLimits(t *testing.T) {
+	rootDir, metadata, writer, _ := setup(t)
+
+	qs := frontend.NewQueryService(metadata.CatalogDir)
+	qs.SetResultLimits(executor.ResultLimits{MaxRows: 100})
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, qs)
+	service.Init()
+
+	// --- when the result of a query exceeds the limit ---
+	var response frontend.MultiQueryResponse
+	err := service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{
+		frontend.NewQueryRequestBuilder("USDJPY/1Min/OHLC").End(),
+	}}, &response)
+	// --- then an error is returned ---
+	assert.ErrorIs(t, err, executor.ErrResultTooLarge)
+
+	// --- when the result is within the limit ---
+	err = service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{
+		frontend.NewQueryRequestBuilder("USDJPY/1Min/OHLC").LimitRecordCount(100).End(),
+	}}, &response)
+	// --- then the result is returned ---
+	require.Nil(t, err)
+	cs, err := response.Responses[0].Result.ToColumnSeries()
+	require.Nil(t, err)
+	assert.Equal(t, 100, cs.Len())
+}
@@ -0,0 +1,44 @@
+package frontend
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ConfigReloader reloads the triggers and the bgworkers from the config file without restarting the server.
+type ConfigReloader interface {
+	ReloadConfig() (*ReloadConfigResponse, error)
+}
+
+var errReloadNotEnabled = errors.New("config reload is not enabled")
+
+type ReloadConfigRequest struct{}
+
+// ReloadConfigResponse is the changes made by the reload of the config.
+type ReloadConfigResponse struct {
+	// TriggersAdded and TriggersRemoved are the triggers in "{module} on {on}" format
+	TriggersAdded   []string `msgpack:"triggers_added"`
+	TriggersRemoved []string `msgpack:"triggers_removed"`
+	// BgWorkersStarted and BgWorkersStopped are the names of the bgworkers,
+	// or their modules if they have no name
+	BgWorkersStarted []string `msgpack:"bgworkers_started"`
+	BgWorkersStopped []string `msgpack:"bgworkers_stopped"`
+	// Errors are the triggers and the bgworkers that failed to be loaded or stopped,
+	// while the other changes are applied
+	Errors []string `msgpack:"errors"`
+}
+
+// ReloadConfig reloads the triggers and the bgworkers from the config file.
+// The triggers and the bgworkers whose settings are changed are replaced, and the others keep running.
+func (s *DataService) ReloadConfig(_ *http.Request, _ *ReloadConfigRequest, response *ReloadConfigResponse,
+) (err error) {
+	if s.reloader == nil {
+		return errReloadNotEnabled
+	}
+	resp, err := s.reloader.ReloadConfig()
+	if err != nil {
+		return err
+	}
+	*response = *resp
+	return nil
+}
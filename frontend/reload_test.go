@@ -0,0 +1,58 @@
+package frontend_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/proto"
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+)
+
+type fakeConfigReloader struct {
+	resp *frontend.ReloadConfigResponse
+}
+
+func (r *fakeConfigReloader) ReloadConfig() (*frontend.ReloadConfigResponse, error) {
+	return r.resp, nil
+}
+
+func TestReloadConfig(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	grpcService := frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	var response frontend.ReloadConfigResponse
+
+	// --- when the reload is not enabled ---
+	err := service.ReloadConfig(nil, &frontend.ReloadConfigRequest{}, &response)
+	_, grpcErr := grpcService.ReloadConfig(context.Background(), &proto.ReloadConfigRequest{})
+
+	// --- then ---
+	assert.NotNil(t, err)
+	assert.NotNil(t, grpcErr)
+
+	// --- when ---
+	reloader := &fakeConfigReloader{resp: &frontend.ReloadConfigResponse{
+		TriggersAdded:    []string{"ondiskagg.so on */1Min/OHLCV"},
+		TriggersRemoved:  []string{},
+		BgWorkersStarted: []string{"feeder"},
+		BgWorkersStopped: []string{"feeder"},
+		Errors:           []string{"failed to load trigger broken.so on */1Sec/OHLCV"},
+	}}
+	service.SetConfigReloader(reloader)
+	grpcService.SetConfigReloader(reloader)
+	err = service.ReloadConfig(nil, &frontend.ReloadConfigRequest{}, &response)
+	grpcResponse, grpcErr := grpcService.ReloadConfig(context.Background(), &proto.ReloadConfigRequest{})
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, *reloader.resp, response)
+	require.Nil(t, grpcErr)
+	assert.Equal(t, reloader.resp.TriggersAdded, grpcResponse.TriggersAdded)
+	assert.Equal(t, reloader.resp.BgWorkersStarted, grpcResponse.BgworkersStarted)
+	assert.Equal(t, reloader.resp.Errors, grpcResponse.Errors)
+}
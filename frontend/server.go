@@ -10,6 +10,8 @@ import (
 	"github.com/alpacahq/rpc/rpc2/json2"
 
 	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 	"github.com/alpacahq/marketstore/v4/metrics"
 	"github.com/alpacahq/marketstore/v4/sqlparser"
 	"github.com/alpacahq/marketstore/v4/utils"
@@ -22,10 +24,16 @@ var errNotQueryable = errors.New("server is not queryable")
 
 type Writer interface {
 	WriteCSM(csm io.ColumnSeriesMap, isVariableLength bool) error
+	WriteCSMWithOptions(csm io.ColumnSeriesMap, isVariableLength bool, opts executor.WriteOptions,
+	) ([]validation.Violation, error)
+	// WaitFlush waits for the WAL flush of the records written before the call.
+	WaitFlush()
+	DeleteRange(tbk *io.TimeBucketKey, start, end time.Time) (deleted int, err error)
+	RenameSymbol(oldSymbol, newSymbol string, link bool) error
 }
 
 type QueryInterface interface {
-	ExecuteQuery(tbk *io.TimeBucketKey, start, end time.Time, LimitRecordCount int,
+	ExecuteQuery(ctx context.Context, tbk *io.TimeBucketKey, start, end time.Time, LimitRecordCount int,
 		LimitFromStart bool, columns []string,
 	) (io.ColumnSeriesMap, error)
 }
@@ -48,10 +56,40 @@ type DataService struct {
 	aggRunner  *sqlparser.AggRunner
 	writer     Writer
 	query      QueryInterface
+	cache      *QueryCache
+	limiter    *QueryLimiter
+	stats      *executor.StatsTracker
+	reloader   ConfigReloader
+	bgWorkers  BgWorkerManager
 }
 
 func (s *DataService) Init() {}
 
+// SetQueryCache enables the cache of the query results, shared with the other services.
+func (s *DataService) SetQueryCache(qc *QueryCache) {
+	s.cache = qc
+}
+
+// SetQueryLimiter limits the query requests with the limiter shared with the other services.
+func (s *DataService) SetQueryLimiter(l *QueryLimiter) {
+	s.limiter = l
+}
+
+// SetStatsTracker sets the tracker of the statistics of the time buckets, shared with the other services.
+func (s *DataService) SetStatsTracker(st *executor.StatsTracker) {
+	s.stats = st
+}
+
+// SetConfigReloader enables the ReloadConfig API.
+func (s *DataService) SetConfigReloader(r ConfigReloader) {
+	s.reloader = r
+}
+
+// SetBgWorkerManager enables the ListBgWorkers, StartBgWorker, StopBgWorker and RestartBgWorker APIs.
+func (s *DataService) SetBgWorkerManager(m BgWorkerManager) {
+	s.bgWorkers = m
+}
+
 type RPCServer struct {
 	*rpc.Server
 }
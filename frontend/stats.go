@@ -0,0 +1,108 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+type CatalogStatsRequest struct {
+	// Pattern is a glob of the {symbol}/{timeframe}/{attributeGroup} keys (e.g. "AAPL/*/*", "*/1D/OHLCV"),
+	// or of the symbols if it has no "/" (e.g. "AA*"). Empty for all the time buckets.
+	Pattern string `msgpack:"pattern,omitempty"`
+}
+
+// BucketStats are the statistics of a time bucket.
+type BucketStats struct {
+	// Key is {symbol}/{timeframe}/{attributeGroup}
+	Key         string   `msgpack:"key"`
+	ColumnNames []string `msgpack:"column_names"`
+	ColumnTypes []string `msgpack:"column_types"`
+	// RecordType is "fixed" or "variable"
+	RecordType string `msgpack:"record_type"`
+	// Years are the years of the data files in ascending order
+	Years []int `msgpack:"years"`
+	// FirstEpoch and LastEpoch are the unix epoch seconds of the first and the last records
+	// to the resolution of the timeframe, 0 if the bucket has no records
+	FirstEpoch int64 `msgpack:"first_epoch"`
+	LastEpoch  int64 `msgpack:"last_epoch"`
+	// Rows is the approximate number of the records
+	Rows int64 `msgpack:"rows"`
+	// DiskBytes is the number of the bytes allocated to the data files on disk
+	DiskBytes int64 `msgpack:"disk_bytes"`
+}
+
+type CatalogStatsResponse struct {
+	Buckets []BucketStats `msgpack:"buckets"`
+}
+
+// CatalogStats returns the statistics of the time buckets that match the pattern, sorted by the keys.
+func (s *DataService) CatalogStats(_ *http.Request, req *CatalogStatsRequest, response *CatalogStatsResponse,
+) (err error) {
+	stats, err := catalogStats(s.catalogDir, s.stats, req.Pattern)
+	if err != nil {
+		return err
+	}
+	response.Buckets = make([]BucketStats, len(stats))
+	for i := range stats {
+		bs := &stats[i]
+		names, types, err := columnNamesAndTypes(bs.DataShapes)
+		if err != nil {
+			return fmt.Errorf("get the columns of %s: %w", bs.Key.GetItemKey(), err)
+		}
+		first, last := epochRange(bs)
+		response.Buckets[i] = BucketStats{
+			Key:         bs.Key.GetItemKey(),
+			ColumnNames: names,
+			ColumnTypes: types,
+			RecordType:  strings.ToLower(bs.RecordType.String()),
+			Years:       years(bs.Years),
+			FirstEpoch:  first,
+			LastEpoch:   last,
+			Rows:        bs.Rows,
+			DiskBytes:   bs.DiskSize,
+		}
+	}
+	return nil
+}
+
+// catalogStats returns the statistics from the tracker, or scans the data files if the tracker is not set.
+func catalogStats(catalogDir *catalog.Directory, tracker *executor.StatsTracker, pattern string,
+) ([]executor.BucketStats, error) {
+	if tracker == nil {
+		tracker = executor.NewStatsTracker(catalogDir)
+	}
+	return tracker.Stats(pattern)
+}
+
+func columnNamesAndTypes(dataShapes []io.DataShape) (names, types []string, err error) {
+	names = make([]string, len(dataShapes))
+	types = make([]string, len(dataShapes))
+	for i, ds := range dataShapes {
+		typeStr, ok := io.DataShapeTypeStr(ds)
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported type %s of column %s", ds.Type, ds.Name)
+		}
+		names[i], types[i] = ds.Name, typeStr
+	}
+	return names, types, nil
+}
+
+func epochRange(bs *executor.BucketStats) (first, last int64) {
+	if bs.Rows == 0 {
+		return 0, 0
+	}
+	return bs.FirstTime.Unix(), bs.LastTime.Unix()
+}
+
+func years(yrs []int16) []int {
+	ret := make([]int, len(yrs))
+	for i, year := range yrs {
+		ret[i] = int(year)
+	}
+	return ret
+}
@@ -0,0 +1,119 @@
+package frontend_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/proto"
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func TestCatalogStats(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	tracker := executor.NewStatsTracker(metadata.CatalogDir)
+	metadata.WALFile.AddFlushListener(tracker)
+	service.SetStatsTracker(tracker)
+
+	// --- when ---
+	var response frontend.CatalogStatsResponse
+	err := service.CatalogStats(nil, &frontend.CatalogStatsRequest{Pattern: "*/1D/*"}, &response)
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Buckets, 3)
+	bs := response.Buckets[0]
+	assert.Equal(t, "EURUSD/1D/OHLC", bs.Key)
+	assert.Equal(t, []string{"Epoch", "Open", "High", "Low", "Close"}, bs.ColumnNames)
+	assert.Equal(t, []string{"i8", "f4", "f4", "f4", "f4"}, bs.ColumnTypes)
+	assert.Equal(t, "fixed", bs.RecordType)
+	assert.Equal(t, []int{2000, 2001, 2002}, bs.Years)
+	assert.Positive(t, bs.Rows)
+	assert.Less(t, bs.FirstEpoch, bs.LastEpoch)
+	assert.Positive(t, bs.DiskBytes)
+
+	// --- when a bar is written ---
+	epoch := time.Date(2003, 1, 2, 0, 0, 0, 0, time.UTC)
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{epoch.Unix()})
+	for _, name := range []string{"Open", "High", "Low", "Close"} {
+		cs.AddColumn(name, []float32{123})
+	}
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*io.NewTimeBucketKey("EURUSD/1D/OHLC"), cs)
+	require.Nil(t, writer.WriteCSM(csm, false))
+	require.Nil(t, metadata.WALFile.FlushToWAL())
+	var written frontend.CatalogStatsResponse
+	err = service.CatalogStats(nil, &frontend.CatalogStatsRequest{Pattern: "EURUSD/1D/OHLC"}, &written)
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, written.Buckets, 1)
+	assert.Equal(t, []int{2000, 2001, 2002, 2003}, written.Buckets[0].Years)
+	assert.Equal(t, bs.Rows+1, written.Buckets[0].Rows)
+	assert.Equal(t, bs.FirstEpoch, written.Buckets[0].FirstEpoch)
+	assert.Equal(t, epoch.Unix(), written.Buckets[0].LastEpoch)
+
+	err = service.CatalogStats(nil, &frontend.CatalogStatsRequest{Pattern: "EURUSD/["}, &response)
+	assert.NotNil(t, err)
+}
+
+func TestCatalogStats_SQLDelete(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	tracker := executor.NewStatsTracker(metadata.CatalogDir)
+	metadata.WALFile.AddFlushListener(tracker)
+	service.SetStatsTracker(tracker)
+	var before frontend.CatalogStatsResponse
+	err := service.CatalogStats(nil, &frontend.CatalogStatsRequest{Pattern: "EURUSD/1D/OHLC"}, &before)
+	require.Nil(t, err)
+	require.Len(t, before.Buckets, 1)
+
+	// --- when the first bar is deleted by SQL ---
+	var response frontend.MultiQueryResponse
+	err = service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{{
+		IsSQLStatement: true,
+		SQLStatement: fmt.Sprintf("DELETE FROM `EURUSD/1D/OHLC` WHERE Epoch = %d;",
+			before.Buckets[0].FirstEpoch),
+	}}}, &response)
+	require.Nil(t, err)
+	var after frontend.CatalogStatsResponse
+	err = service.CatalogStats(nil, &frontend.CatalogStatsRequest{Pattern: "EURUSD/1D/OHLC"}, &after)
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, after.Buckets, 1)
+	assert.Equal(t, before.Buckets[0].Rows-1, after.Buckets[0].Rows)
+	assert.Greater(t, after.Buckets[0].FirstEpoch, before.Buckets[0].FirstEpoch)
+}
+
+func TestGRPCCatalogStats(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+
+	// --- when ---
+	response, err := service.CatalogStats(context.Background(), &proto.CatalogStatsRequest{Pattern: "USD*"})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Buckets, 6)
+	bs := response.Buckets[0]
+	assert.Equal(t, "USDJPY/15Min/OHLC", bs.Key)
+	assert.Equal(t, "Epoch", bs.DataShapes[0].Name)
+	assert.Equal(t, "i8", bs.DataShapes[0].Type)
+	assert.Equal(t, "fixed", bs.RecordType)
+	assert.Equal(t, []int32{2000, 2001, 2002}, bs.Years)
+	assert.Positive(t, bs.Rows)
+	assert.Less(t, bs.FirstEpoch, bs.LastEpoch)
+}
@@ -0,0 +1,245 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/uda/adjust"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// RenameSymbolRequest renames a symbol, e.g. when the company changes its ticker.
+// The data of the old symbol is moved to the new symbol, and the old symbol becomes an alias of the new one
+// so that the queries and writes for either symbol resolve.
+type RenameSymbolRequest struct {
+	OldSymbol string `msgpack:"old_symbol"`
+	NewSymbol string `msgpack:"new_symbol"`
+	// Link hard-links the year files of the old symbol to the new symbol instead of moving them,
+	// and keeps the old symbol
+	Link bool `msgpack:"link,omitempty"`
+}
+
+type MultiRenameSymbolRequest struct {
+	Requests []RenameSymbolRequest `msgpack:"requests"`
+}
+
+// SymbolAliasRequest makes an alias refer to a symbol.
+type SymbolAliasRequest struct {
+	Alias string `msgpack:"alias"`
+	// Symbol is the symbol that the alias refers to, or empty to remove the alias
+	Symbol string `msgpack:"symbol,omitempty"`
+}
+
+type MultiSymbolAliasRequest struct {
+	Requests []SymbolAliasRequest `msgpack:"requests"`
+}
+
+type ListSymbolAliasesRequest struct{}
+
+type ListSymbolAliasesResponse struct {
+	// Aliases are the symbols that the aliases refer to
+	Aliases map[string]string `msgpack:"aliases"`
+}
+
+// SymbolMetadataRequest merges the static attributes (e.g. CUSIP, exchange, sector or lot size)
+// to the metadata of a symbol. An attribute with an empty value is removed.
+type SymbolMetadataRequest struct {
+	Symbol   string            `msgpack:"symbol"`
+	Metadata map[string]string `msgpack:"metadata"`
+}
+
+type MultiSymbolMetadataRequest struct {
+	Requests []SymbolMetadataRequest `msgpack:"requests"`
+}
+
+type GetSymbolMetadataRequest struct {
+	Symbols []string `msgpack:"symbols"`
+}
+
+type GetSymbolMetadataResponse struct {
+	// Metadata is the metadata of each requested symbol
+	Metadata map[string]map[string]string `msgpack:"metadata"`
+}
+
+// RenameSymbols renames the symbols in order, with a response for each request.
+func (s *DataService) RenameSymbols(_ *http.Request, reqs *MultiRenameSymbolRequest,
+	response *MultiServerResponse,
+) (err error) {
+	for _, err2 := range renameSymbols(s.writer, reqs.Requests, s.cache) {
+		response.appendResponse(err2)
+	}
+	return nil
+}
+
+// SetSymbolAliases adds or removes the aliases in order, with a response for each request.
+func (s *DataService) SetSymbolAliases(_ *http.Request, reqs *MultiSymbolAliasRequest,
+	response *MultiServerResponse,
+) (err error) {
+	for _, err2 := range setSymbolAliases(s.catalogDir, reqs.Requests, s.cache) {
+		response.appendResponse(err2)
+	}
+	return nil
+}
+
+// ListSymbolAliases returns the alias table of the symbols.
+func (s *DataService) ListSymbolAliases(_ *http.Request, _ *ListSymbolAliasesRequest,
+	response *ListSymbolAliasesResponse,
+) (err error) {
+	response.Aliases = s.catalogDir.SymbolAliases()
+	return nil
+}
+
+// SetSymbolMetadata merges the attributes to the metadata of the symbols in order, with a response for each request.
+func (s *DataService) SetSymbolMetadata(_ *http.Request, reqs *MultiSymbolMetadataRequest,
+	response *MultiServerResponse,
+) (err error) {
+	for _, req := range reqs.Requests {
+		response.appendResponse(setSymbolMetadata(s.catalogDir, req.Symbol, req.Metadata))
+	}
+	return nil
+}
+
+// GetSymbolMetadata returns the metadata of the symbols.
+func (s *DataService) GetSymbolMetadata(_ *http.Request, req *GetSymbolMetadataRequest,
+	response *GetSymbolMetadataResponse,
+) (err error) {
+	response.Metadata, err = getSymbolMetadata(s.catalogDir, req.Symbols)
+	return err
+}
+
+// renameSymbols renames the symbols, and returns the error of each request.
+// The cached adjustments and query results of the symbols are invalidated.
+func renameSymbols(w Writer, reqs []RenameSymbolRequest, cache *QueryCache) []error {
+	errs := make([]error, len(reqs))
+	for i, req := range reqs {
+		if errs[i] = w.RenameSymbol(req.OldSymbol, req.NewSymbol, req.Link); errs[i] != nil {
+			continue
+		}
+		adjust.InvalidateRateChanges(req.OldSymbol, req.NewSymbol)
+		if cache != nil {
+			cache.InvalidateSymbols(req.OldSymbol, req.NewSymbol)
+		}
+	}
+	return errs
+}
+
+// setSymbolAliases adds or removes the aliases, and returns the error of each request.
+// The cached query results of the aliases are invalidated.
+func setSymbolAliases(catalogDir *catalog.Directory, reqs []SymbolAliasRequest, cache *QueryCache) []error {
+	errs := make([]error, len(reqs))
+	for i, req := range reqs {
+		if req.Symbol == "" {
+			errs[i] = catalogDir.RemoveSymbolAlias(req.Alias)
+		} else {
+			errs[i] = catalogDir.AddSymbolAlias(req.Alias, req.Symbol)
+		}
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("set symbol alias %s: %w", req.Alias, errs[i])
+			continue
+		}
+		if cache != nil {
+			cache.InvalidateSymbols(req.Alias)
+		}
+	}
+	return errs
+}
+
+func setSymbolMetadata(catalogDir *catalog.Directory, symbol string, metadata map[string]string) error {
+	if err := catalogDir.SetSymbolMetadata(symbol, metadata); err != nil {
+		return fmt.Errorf("set symbol metadata of %s: %w", symbol, err)
+	}
+	return nil
+}
+
+func getSymbolMetadata(catalogDir *catalog.Directory, symbols []string) (map[string]map[string]string, error) {
+	metadata := make(map[string]map[string]string, len(symbols))
+	for _, symbol := range symbols {
+		md, err := catalogDir.GetSymbolMetadata(symbol)
+		if err != nil {
+			return nil, fmt.Errorf("get symbol metadata of %s: %w", symbol, err)
+		}
+		metadata[symbol] = md
+	}
+	return metadata, nil
+}
+
+// listSymbols returns the symbols in the catalog, or the time bucket keys of them if tbkFormat is true.
+// Only the symbols whose metadata matches the filter are listed if it's not empty.
+func listSymbols(catalogDir *catalog.Directory, tbkFormat bool, filter string) ([]string, error) {
+	var symbols []string
+	if filter != "" {
+		var err error
+		if symbols, err = catalogDir.FilterSymbols(filter); err != nil {
+			return nil, err
+		}
+	}
+
+	if tbkFormat {
+		keys := catalog.ListTimeBucketKeyNames(catalogDir)
+		if filter == "" {
+			return keys, nil
+		}
+		matched := make(map[string]struct{}, len(symbols))
+		for _, symbol := range symbols {
+			matched[symbol] = struct{}{}
+		}
+		filtered := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if _, ok := matched[strings.SplitN(key, "/", 2)[0]]; ok {
+				filtered = append(filtered, key)
+			}
+		}
+		return filtered, nil
+	}
+
+	if filter != "" {
+		return symbols, nil
+	}
+	return gatherAllSymbols(catalogDir)
+}
+
+// expandSymbols replaces the * "symbol" of the destination with all the symbols in the catalog,
+// and narrows down the symbols to the ones whose metadata matches the filter if it's not empty.
+func expandSymbols(catalogDir *catalog.Directory, dest *io.TimeBucketKey, keyCategory, filter string,
+) (*io.TimeBucketKey, error) {
+	symbols := dest.GetMultiItemInCategory("Symbol")
+	all := len(symbols) == 1 && symbols[0] == "*"
+	if !all && filter == "" {
+		return dest, nil
+	}
+
+	var err error
+	if all {
+		// replace the * "symbol" with a list all known actual symbols
+		if symbols, err = gatherAllSymbols(catalogDir); err != nil {
+			return nil, err
+		}
+	}
+	if filter != "" {
+		matched, err := catalogDir.FilterSymbols(filter)
+		if err != nil {
+			return nil, err
+		}
+		matchedSet := make(map[string]struct{}, len(matched))
+		for _, symbol := range matched {
+			matchedSet[symbol] = struct{}{}
+		}
+		filtered := make([]string, 0, len(symbols))
+		for _, symbol := range symbols {
+			if _, ok := matchedSet[catalogDir.ResolveSymbol(symbol)]; ok {
+				filtered = append(filtered, symbol)
+			}
+		}
+		if len(filtered) == 0 {
+			return nil, fmt.Errorf("no symbols match the filter %q", filter)
+		}
+		symbols = filtered
+	}
+
+	keyParts := []string{
+		strings.Join(symbols, ","), dest.GetItemInCategory("Timeframe"), dest.GetItemInCategory("AttributeGroup"),
+	}
+	return io.NewTimeBucketKey(strings.Join(keyParts, "/"), keyCategory), nil
+}
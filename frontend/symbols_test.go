@@ -0,0 +1,208 @@
+package frontend_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/proto"
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func TestRenameSymbols(t *testing.T) {
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	cache := frontend.NewQueryCache(1 << 20)
+	cache.SetSymbolResolver(metadata.CatalogDir.ResolveSymbol)
+	metadata.WALFile.AddFlushListener(cache)
+	service.SetQueryCache(cache)
+	open := lastOpen(t, service, "USDJPY/1Min/OHLC", nil)
+
+	// --- when ---
+	var response frontend.MultiServerResponse
+	err := service.RenameSymbols(nil, &frontend.MultiRenameSymbolRequest{
+		Requests: []frontend.RenameSymbolRequest{
+			{OldSymbol: "USDJPY", NewSymbol: "JPYUSD"},
+			// the new symbol exists
+			{OldSymbol: "EURUSD", NewSymbol: "JPYUSD"},
+		},
+	}, &response)
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Responses, 2)
+	assert.Empty(t, response.Responses[0].Error)
+	assert.NotEmpty(t, response.Responses[1].Error)
+
+	// the queries for either symbol resolve
+	assert.Equal(t, open, lastOpen(t, service, "JPYUSD/1Min/OHLC", nil))
+	assert.Equal(t, open, lastOpen(t, service, "USDJPY/1Min/OHLC", nil))
+
+	// --- when a bar is written to the old symbol ---
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{time.Date(2003, 1, 2, 0, 0, 0, 0, time.UTC).Unix()})
+	for _, name := range []string{"Open", "High", "Low", "Close"} {
+		cs.AddColumn(name, []float32{123})
+	}
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*io.NewTimeBucketKey("USDJPY/1Min/OHLC"), cs)
+	require.Nil(t, writer.WriteCSM(csm, false))
+	require.Nil(t, metadata.WALFile.FlushToWAL())
+
+	// --- then it's written to the new symbol, and the cached result of the old symbol is invalidated ---
+	assert.Equal(t, float32(123), lastOpen(t, service, "JPYUSD/1Min/OHLC", nil))
+	assert.Equal(t, float32(123), lastOpen(t, service, "USDJPY/1Min/OHLC", nil))
+
+	var listResponse frontend.ListSymbolsResponse
+	require.Nil(t, service.ListSymbols(nil, &frontend.ListSymbolsRequest{}, &listResponse))
+	assert.ElementsMatch(t, []string{"EURUSD", "JPYUSD", "NZDUSD"}, listResponse.Results)
+}
+
+func TestGRPCSymbolAliases(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	ctx := context.Background()
+
+	// --- when ---
+	renameResponse, err := service.RenameSymbols(ctx, &proto.MultiRenameSymbolRequest{
+		Requests: []*proto.RenameSymbolRequest{{OldSymbol: "EURUSD", NewSymbol: "EUR", Link: true}},
+	})
+	require.Nil(t, err)
+	aliasResponse, err := service.SetSymbolAliases(ctx, &proto.MultiSymbolAliasRequest{
+		Requests: []*proto.SymbolAliasRequest{
+			{Alias: "YEN", Symbol: "USDJPY"},
+			{Alias: "KIWI", Symbol: "NZDUSD"},
+			{Alias: "KIWI"},
+			// the alias doesn't exist
+			{Alias: "GBP"},
+		},
+	})
+	require.Nil(t, err)
+	listResponse, err := service.ListSymbolAliases(ctx, &proto.ListSymbolAliasesRequest{})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, renameResponse.Responses, 1)
+	assert.Empty(t, renameResponse.Responses[0].Error)
+	require.Len(t, aliasResponse.Responses, 4)
+	assert.Empty(t, aliasResponse.Responses[0].Error)
+	assert.Empty(t, aliasResponse.Responses[1].Error)
+	assert.Empty(t, aliasResponse.Responses[2].Error)
+	assert.NotEmpty(t, aliasResponse.Responses[3].Error)
+	assert.Equal(t, map[string]string{"YEN": "USDJPY"}, listResponse.Aliases)
+
+	// the linked symbol is kept
+	symbols, err := service.ListSymbols(ctx, &proto.ListSymbolsRequest{})
+	require.Nil(t, err)
+	assert.ElementsMatch(t, []string{"EUR", "EURUSD", "NZDUSD", "USDJPY"}, symbols.Results)
+}
+
+func TestSymbolMetadata(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+
+	// --- when ---
+	var response frontend.MultiServerResponse
+	err := service.SetSymbolMetadata(nil, &frontend.MultiSymbolMetadataRequest{
+		Requests: []frontend.SymbolMetadataRequest{
+			{Symbol: "EURUSD", Metadata: map[string]string{"exchange": "FX", "region": "EU"}},
+			{Symbol: "USDJPY", Metadata: map[string]string{"exchange": "FX", "region": "JP"}},
+			{Symbol: "NZDUSD", Metadata: map[string]string{"exchange": "OTC", "region": "NZ"}},
+			// the symbol doesn't exist
+			{Symbol: "GBPUSD", Metadata: map[string]string{"exchange": "FX"}},
+		},
+	}, &response)
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Responses, 4)
+	assert.Empty(t, response.Responses[0].Error)
+	assert.NotEmpty(t, response.Responses[3].Error)
+
+	var getResponse frontend.GetSymbolMetadataResponse
+	err = service.GetSymbolMetadata(nil, &frontend.GetSymbolMetadataRequest{Symbols: []string{"EURUSD"}}, &getResponse)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]map[string]string{"EURUSD": {"exchange": "FX", "region": "EU"}}, getResponse.Metadata)
+
+	// the symbols are listed by the filter
+	var listResponse frontend.ListSymbolsResponse
+	err = service.ListSymbols(nil, &frontend.ListSymbolsRequest{Filter: "exchange=FX"}, &listResponse)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"EURUSD", "USDJPY"}, listResponse.Results)
+	err = service.ListSymbols(nil, &frontend.ListSymbolsRequest{Format: "tbk", Filter: "region=NZ"}, &listResponse)
+	require.Nil(t, err)
+	assert.Contains(t, listResponse.Results, "NZDUSD/1Min/OHLC")
+	for _, key := range listResponse.Results {
+		assert.True(t, strings.HasPrefix(key, "NZDUSD/"), key)
+	}
+
+	// the filter selects the symbols to query in place of the symbol list
+	queryFiltered := func(dest, filter string) ([]string, error) {
+		var queryResponse frontend.MultiQueryResponse
+		err2 := service.Query(nil, &frontend.MultiQueryRequest{Requests: []frontend.QueryRequest{
+			frontend.NewQueryRequestBuilder(dest).LimitRecordCount(1).SymbolFilter(filter).End(),
+		}}, &queryResponse)
+		if err2 != nil {
+			return nil, err2
+		}
+		csm, err2 := queryResponse.ToColumnSeriesMap()
+		require.Nil(t, err2)
+		symbols := make([]string, 0)
+		for tbk := range *csm {
+			symbols = append(symbols, tbk.GetItemInCategory("Symbol"))
+		}
+		return symbols, nil
+	}
+	symbols, err := queryFiltered("*/1Min/OHLC", "exchange=FX")
+	require.Nil(t, err)
+	assert.ElementsMatch(t, []string{"EURUSD", "USDJPY"}, symbols)
+	symbols, err = queryFiltered("USDJPY,NZDUSD/1Min/OHLC", "exchange!=OTC")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"USDJPY"}, symbols)
+	_, err = queryFiltered("*/1Min/OHLC", "exchange=NYSE")
+	assert.NotNil(t, err)
+}
+
+func TestGRPCSymbolMetadata(t *testing.T) {
+	t.Parallel()
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	ctx := context.Background()
+
+	// --- when ---
+	setResponse, err := service.SetSymbolMetadata(ctx, &proto.MultiSymbolMetadataRequest{
+		Requests: []*proto.SymbolMetadataRequest{
+			{Symbol: "EURUSD", Metadata: map[string]string{"exchange": "FX", "sector": "Currency"}},
+			{Symbol: "USDJPY", Metadata: map[string]string{"exchange": "FX"}},
+		},
+	})
+	require.Nil(t, err)
+	getResponse, err := service.GetSymbolMetadata(ctx, &proto.GetSymbolMetadataRequest{Symbols: []string{"EURUSD", "NZDUSD"}})
+	require.Nil(t, err)
+	listResponse, err := service.ListSymbols(ctx, &proto.ListSymbolsRequest{Filter: "exchange=FX AND sector=Currency"})
+	require.Nil(t, err)
+	queryResponse, err := service.Query(ctx, &proto.MultiQueryRequest{Requests: []*proto.QueryRequest{
+		{Destination: "*/1Min/OHLC", LimitRecordCount: 1, SymbolFilter: "exchange=FX"},
+	}})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, setResponse.Responses, 2)
+	assert.Empty(t, setResponse.Responses[0].Error)
+	assert.Equal(t, map[string]string{"exchange": "FX", "sector": "Currency"}, getResponse.Metadata["EURUSD"].Metadata)
+	assert.Empty(t, getResponse.Metadata["NZDUSD"].Metadata)
+	assert.Equal(t, []string{"EURUSD"}, listResponse.Results)
+	require.Len(t, queryResponse.Responses, 1)
+	assert.Len(t, queryResponse.Responses[0].Result.StartIndex, 2)
+
+	_, err = service.GetSymbolMetadata(ctx, &proto.GetSymbolMetadataRequest{Symbols: []string{"GBPUSD"}})
+	assert.NotNil(t, err)
+}
@@ -2,8 +2,10 @@ package frontend
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/pprof"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -26,6 +28,12 @@ func NewUtilityAPIHandlers(startTime time.Time) *UtilityAPIHandlers {
 
 type UtilityAPIHandlers struct {
 	startTime time.Time
+	bgWorkers BgWorkerManager
+}
+
+// SetBgWorkerManager enables the /bgworkers endpoints.
+func (uah *UtilityAPIHandlers) SetBgWorkerManager(m BgWorkerManager) {
+	uah.bgWorkers = m
 }
 
 func (uah *UtilityAPIHandlers) Handle(url string) error {
@@ -43,6 +51,12 @@ func (uah *UtilityAPIHandlers) Handle(url string) error {
 	http.Handle("/pprof/threadcreate", pprof.Handler("threadcreate"))
 	http.Handle("/pprof/block", pprof.Handler("block"))
 
+	// bgworkers
+	if uah.bgWorkers != nil {
+		http.HandleFunc("/bgworkers", uah.listBgWorkers)
+		http.HandleFunc("/bgworkers/", uah.controlBgWorker)
+	}
+
 	return http.ListenAndServe(url, nil)
 }
 
@@ -75,3 +89,58 @@ func (uah *UtilityAPIHandlers) heartbeat(rw http.ResponseWriter, _ *http.Request
 		}
 	}
 }
+
+// listBgWorkers returns the status of the bgworkers on GET /bgworkers.
+func (uah *UtilityAPIHandlers) listBgWorkers(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(rw, http.StatusOK, uah.bgWorkers.ListBgWorkers())
+}
+
+// controlBgWorker starts, stops or restarts a bgworker on POST /bgworkers/{name}/{start|stop|restart},
+// and returns its status.
+func (uah *UtilityAPIHandlers) controlBgWorker(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/bgworkers/")
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		http.NotFound(rw, r)
+		return
+	}
+	name, action := path[:i], path[i+1:]
+
+	var control func(name string) (BgWorkerStatus, error)
+	switch action {
+	case "start":
+		control = uah.bgWorkers.StartBgWorker
+	case "stop":
+		control = uah.bgWorkers.StopBgWorker
+	case "restart":
+		control = uah.bgWorkers.RestartBgWorker
+	default:
+		http.NotFound(rw, r)
+		return
+	}
+	status, err := control(name)
+	switch {
+	case errors.Is(err, ErrBgWorkerNotFound):
+		http.Error(rw, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(rw, err.Error(), http.StatusConflict)
+	default:
+		writeJSON(rw, http.StatusOK, status)
+	}
+}
+
+func writeJSON(rw http.ResponseWriter, statusCode int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		log.Error("Failed to write response - Error: %v", err)
+	}
+}
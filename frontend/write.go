@@ -6,6 +6,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
@@ -15,6 +17,10 @@ const colonSeparatedPartsLen = 2 // expecting a key string like "TSLA/1Min/OHLCV
 type WriteRequest struct {
 	Data             *io.NumpyMultiDataset `msgpack:"dataset"`
 	IsVariableLength bool                  `msgpack:"is_variable_length"`
+	// WriteMode is "append" (default), "replace" or "dedupe" for the variable-length records
+	WriteMode string `msgpack:"write_mode,omitempty"`
+	// DedupeKey is the columns that identify a record in the "dedupe" write mode, Epoch and Nanoseconds by default
+	DedupeKey []string `msgpack:"dedupe_key,omitempty"`
 }
 
 type MultiWriteRequest struct {
@@ -27,6 +33,21 @@ type MultiWriteRequest struct {
 type ServerResponse struct {
 	Error   string `msgpack:"error"`
 	Version string `msgpack:"version"` // Server Version
+	// Violations is the violations of the write validation rules by a write
+	Violations []WriteViolation `msgpack:"violations,omitempty"`
+}
+
+// WriteViolation is the rows of a write that violated a write validation rule.
+type WriteViolation struct {
+	// Key is the time bucket key, e.g. "AAPL/1Min/OHLCV"
+	Key    string `msgpack:"key"`
+	Rule   string `msgpack:"rule"`
+	Action string `msgpack:"action"` // "reject", "quarantine" or "fix"
+	// Rows is the number of the violating rows
+	Rows int `msgpack:"rows"`
+	// Epoch is the epoch of the first violating row
+	Epoch   int64  `msgpack:"epoch"`
+	Message string `msgpack:"message"`
 }
 
 type MultiServerResponse struct {
@@ -40,16 +61,33 @@ func (s *DataService) Write(_ *http.Request, reqs *MultiWriteRequest, response *
 			response.appendResponse(err)
 			continue
 		}
-		if err = s.writer.WriteCSM(csm, req.IsVariableLength); err != nil {
+		opts, err := writeOptions(req.WriteMode, req.DedupeKey)
+		if err != nil {
 			response.appendResponse(err)
 			continue
 		}
+		violations, err := s.writer.WriteCSMWithOptions(csm, req.IsVariableLength, opts)
+		if err != nil || len(violations) > 0 {
+			response.appendWriteResponse(err, violations)
+			continue
+		}
 		// TODO: There should be an error response for every server request, need to add the below commented line
 		// appendResponse(err, response)
 	}
 	return nil
 }
 
+func writeOptions(writeMode string, dedupeKey []string) (executor.WriteOptions, error) {
+	mode, err := executor.ParseWriteMode(writeMode)
+	if err != nil {
+		return executor.WriteOptions{}, err
+	}
+	if len(dedupeKey) > 0 && mode != executor.WriteModeDedupe {
+		return executor.WriteOptions{}, fmt.Errorf("dedupe_key is only for the %s write mode", executor.WriteModeDedupe)
+	}
+	return executor.WriteOptions{Mode: mode, DedupeKey: dedupeKey}, nil
+}
+
 /*
 	Create: Creates a new time bucket in the DB
 */
@@ -107,13 +145,13 @@ func (s *DataService) Create(_ *http.Request, reqs *MultiCreateRequest, response
 		// --- DataShapes
 		dsv := make([]io.DataShape, len(req.ColumnNames))
 		for i, name := range req.ColumnNames {
-			t, ok := io.TypeStrToElemType(req.ColumnTypes[i])
+			shape, ok := io.NewDataShapeFromTypeStr(name, req.ColumnTypes[i])
 			if !ok {
 				response.appendResponse(fmt.Errorf("unexpected data type:%v", req.ColumnTypes[i]))
 				return nil
 			}
 
-			dsv[i] = io.DataShape{Name: name, Type: t}
+			dsv[i] = shape
 		}
 
 		tbinfo := io.NewTimeBucketInfo(*tf, tbk.GetPathToYearFiles(s.rootDir), "Default", year, dsv, recordType)
@@ -203,6 +241,9 @@ func (s *DataService) Destroy(_ *http.Request, reqs *MultiKeyRequest, response *
 			response.appendResponse(err)
 			continue
 		}
+		if s.cache != nil {
+			s.cache.InvalidateKey(tbk)
+		}
 		response.appendResponse(err)
 	}
 
@@ -222,12 +263,21 @@ func (mr *MultiServerResponse) appendResponse(err error) {
 	}
 	mr.Responses = append(mr.Responses,
 		ServerResponse{
-			errorText,
-			utils.GitHash,
+			Error:   errorText,
+			Version: utils.GitHash,
 		},
 	)
 }
 
+// appendWriteResponse appends the response of a write with the violations of the write validation rules.
+func (mr *MultiServerResponse) appendWriteResponse(err error, violations []validation.Violation) {
+	mr.appendResponse(err)
+	resp := &mr.Responses[len(mr.Responses)-1]
+	for _, v := range violations {
+		resp.Violations = append(resp.Violations, WriteViolation(v))
+	}
+}
+
 func (mg *MultiGetInfoResponse) appendResponse(tbi *io.TimeBucketInfo, err error) {
 	var errorText string
 	if err == nil {
@@ -243,8 +293,8 @@ func (mg *MultiGetInfoResponse) appendResponse(tbi *io.TimeBucketInfo, err error
 				DSV:        tbi.GetDataShapesWithEpoch(),
 				RecordType: tbi.GetRecordType(),
 				ServerResp: ServerResponse{
-					errorText,
-					utils.GitHash,
+					Error:   errorText,
+					Version: utils.GitHash,
 				},
 			},
 		)
@@ -256,8 +306,8 @@ func (mg *MultiGetInfoResponse) appendResponse(tbi *io.TimeBucketInfo, err error
 				DSV:        nil,
 				RecordType: 0,
 				ServerResp: ServerResponse{
-					errorText,
-					utils.GitHash,
+					Error:   errorText,
+					Version: utils.GitHash,
 				},
 			},
 		)
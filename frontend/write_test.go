@@ -1,14 +1,23 @@
 package frontend_test
 
 import (
+	"context"
+	"net"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
 
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 	"github.com/alpacahq/marketstore/v4/frontend"
+	"github.com/alpacahq/marketstore/v4/frontend/client"
+	"github.com/alpacahq/marketstore/v4/proto"
 	"github.com/alpacahq/marketstore/v4/sqlparser"
+	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
 
@@ -102,3 +111,170 @@ func TestWrite(t *testing.T) {
 		assert.Equal(t, ti, tref)
 	}
 }
+
+func TestWrite_Violations(t *testing.T) {
+	// --- given ---
+	rootDir, metadata, writer, q := setup(t)
+	v, err := validation.New([]*utils.WriteValidationSetting{{On: "TEST/1Min/OHLC", Rules: []*utils.ValidationRuleSetting{
+		{Check: validation.CheckRange, Columns: []string{"Close"}, NonZero: true, Action: validation.Reject},
+	}}}, nil)
+	require.Nil(t, err)
+	writer.SetValidator(v)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	service.Init()
+	grpcService := frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+
+	tbk := io.NewTimeBucketKey("TEST/1Min/OHLC")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{epoch, epoch + 60})
+	cs.AddColumn("Open", []float32{1, 1})
+	cs.AddColumn("High", []float32{1, 1})
+	cs.AddColumn("Low", []float32{1, 1})
+	cs.AddColumn("Close", []float32{1, 0})
+	nds, err := io.NewNumpyDataset(cs)
+	require.Nil(t, err)
+	nmds, err := io.NewNumpyMultiDataset(nds, *tbk)
+	require.Nil(t, err)
+
+	// --- when ---
+	var response frontend.MultiServerResponse
+	err = service.Write(nil, &frontend.MultiWriteRequest{Requests: []frontend.WriteRequest{{Data: nmds}}}, &response)
+	grpcResponse, grpcErr := grpcService.Write(context.Background(), &proto.MultiWriteRequest{
+		Requests: []*proto.WriteRequest{{Data: frontend.ToProtoNumpyMultiDataSet(nmds)}},
+	})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Responses, 1)
+	assert.Empty(t, response.Responses[0].Error)
+	assert.Equal(t, []frontend.WriteViolation{{
+		Key: "TEST/1Min/OHLC", Rule: "range", Action: "reject", Rows: 1, Epoch: epoch + 60,
+		Message: "Close=0 is out of range",
+	}}, response.Responses[0].Violations)
+	require.Nil(t, grpcErr)
+	require.Len(t, grpcResponse.Responses, 1)
+	require.Len(t, grpcResponse.Responses[0].Violations, 1)
+	assert.Equal(t, int64(1), grpcResponse.Responses[0].Violations[0].Rows)
+	assert.Equal(t, "Close=0 is out of range", grpcResponse.Responses[0].Violations[0].Message)
+}
+
+func TestWrite_WriteMode(t *testing.T) {
+	// --- given ---
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	service.Init()
+	grpcService := frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+
+	tbk := io.NewTimeBucketKey("TEST/1Min/TICK")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	tickData := func(bids ...float32) *io.NumpyMultiDataset {
+		epochs := make([]int64, len(bids))
+		for i := range epochs {
+			epochs[i] = epoch
+		}
+		cs := io.NewColumnSeries()
+		cs.AddColumn("Epoch", epochs)
+		cs.AddColumn("Nanoseconds", make([]int32, len(bids)))
+		cs.AddColumn("Bid", bids)
+		nds, err := io.NewNumpyDataset(cs)
+		require.Nil(t, err)
+		nmds, err := io.NewNumpyMultiDataset(nds, *tbk)
+		require.Nil(t, err)
+		return nmds
+	}
+
+	// --- when ---
+	var response frontend.MultiServerResponse
+	err := service.Write(nil, &frontend.MultiWriteRequest{Requests: []frontend.WriteRequest{
+		{Data: tickData(1, 2), IsVariableLength: true},
+		{Data: tickData(3), IsVariableLength: true, WriteMode: "replace"},
+		{Data: tickData(4), IsVariableLength: true, WriteMode: "upsert"},
+	}}, &response)
+	grpcResponse, grpcErr := grpcService.Write(context.Background(), &proto.MultiWriteRequest{
+		Requests: []*proto.WriteRequest{{
+			Data: frontend.ToProtoNumpyMultiDataSet(tickData(5)), IsVariableLength: true,
+			DedupeKey: []string{"Epoch"},
+		}},
+	})
+
+	// --- then ---
+	require.Nil(t, err)
+	require.Len(t, response.Responses, 1)
+	assert.Equal(t, `unknown write mode "upsert": must be one of append, replace or dedupe`,
+		response.Responses[0].Error)
+	require.Nil(t, grpcErr)
+	require.Len(t, grpcResponse.Responses, 1)
+	assert.Equal(t, "dedupe_key is only for the dedupe write mode", grpcResponse.Responses[0].Error)
+
+	require.Nil(t, metadata.WALFile.FlushToWAL())
+	var qresponse frontend.MultiQueryResponse
+	err = service.Query(nil, &frontend.MultiQueryRequest{
+		Requests: []frontend.QueryRequest{frontend.NewQueryRequestBuilder(tbk.String()).End()},
+	}, &qresponse)
+	require.Nil(t, err)
+	csm, err := qresponse.Responses[0].Result.ToColumnSeriesMap()
+	require.Nil(t, err)
+	assert.Equal(t, []float32{3}, csm[*tbk].GetColumn("Bid"))
+}
+
+func TestWriteStream(t *testing.T) {
+	// --- given ---
+	rootDir, metadata, writer, q := setup(t)
+	service := frontend.NewDataService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q)
+	service.Init()
+	grpcServer := grpc.NewServer()
+	proto.RegisterMarketstoreServer(grpcServer,
+		frontend.NewGRPCService(rootDir, metadata.CatalogDir, sqlparser.NewAggRunner(nil), writer, q))
+	const bufSize = 1024 * 1024
+	lis := bufconn.Listen(bufSize)
+	go func() { _ = grpcServer.Serve(lis) }()
+	defer grpcServer.Stop()
+	cl, err := client.NewGRPCClient("bufnet", grpc.WithContextDialer(
+		func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) },
+	))
+	require.Nil(t, err)
+	defer cl.Close()
+
+	tbk := io.NewTimeBucketKey("TEST/1Min/OHLC")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	chunk := func(epochs ...int64) *io.NumpyMultiDataset {
+		cs := io.NewColumnSeries()
+		cs.AddColumn("Epoch", epochs)
+		for _, name := range []string{"Open", "High", "Low", "Close"} {
+			cs.AddColumn(name, make([]float32, len(epochs)))
+		}
+		nds, err2 := io.NewNumpyDataset(cs)
+		require.Nil(t, err2)
+		nmds, err2 := io.NewNumpyMultiDataset(nds, *tbk)
+		require.Nil(t, err2)
+		return nmds
+	}
+
+	// --- when ---
+	ws, err := cl.WriteStream(context.Background())
+	require.Nil(t, err)
+	require.Nil(t, ws.Send(&frontend.WriteRequest{Data: chunk(epoch, epoch+60)}))
+	require.Nil(t, ws.Send(&frontend.WriteRequest{Data: chunk(epoch + 120)}))
+	require.Nil(t, ws.Send(&frontend.WriteRequest{Data: chunk(epoch + 180), WriteMode: "upsert"}))
+	resp, err := ws.CloseAndRecv()
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, map[string]*frontend.WriteStreamResult{
+		"TEST/1Min/OHLC": {
+			Rows:   3,
+			Errors: []string{`unknown write mode "upsert": must be one of append, replace or dedupe`},
+		},
+	}, resp.Results)
+	assert.Empty(t, resp.Errors)
+	// the written rows are flushed before the response
+	var qresponse frontend.MultiQueryResponse
+	err = service.Query(nil, &frontend.MultiQueryRequest{
+		Requests: []frontend.QueryRequest{frontend.NewQueryRequestBuilder(tbk.String()).End()},
+	}, &qresponse)
+	require.Nil(t, err)
+	csm, err := qresponse.Responses[0].Result.ToColumnSeriesMap()
+	require.Nil(t, err)
+	assert.Equal(t, []int64{epoch, epoch + 60, epoch + 120}, csm[*tbk].GetEpoch())
+}
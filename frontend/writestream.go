@@ -0,0 +1,213 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	goio "io"
+
+	"github.com/alpacahq/marketstore/v4/executor/validation"
+	"github.com/alpacahq/marketstore/v4/proto"
+)
+
+// writeStreamFlushRows is the number of the rows written by a write stream between the WAL flushes.
+// The requests of a stream only queue their rows to the WAL, and the stream waits for the flush every
+// writeStreamFlushRows rows before it receives the next requests, which applies the backpressure
+// of the WAL to the client through the flow control of gRPC.
+const writeStreamFlushRows = 1_000_000
+
+// WriteStreamResponse is the result of the write requests of a write stream.
+type WriteStreamResponse struct {
+	// Results is the results of the writes to each time bucket key, e.g. "AAPL/1Min/OHLCV"
+	Results map[string]*WriteStreamResult `msgpack:"results"`
+	// Errors is the errors of the requests whose data couldn't be read
+	Errors []string `msgpack:"errors"`
+}
+
+// WriteStreamResult is the result of the writes to a time bucket key in a write stream.
+type WriteStreamResult struct {
+	// Rows is the number of the rows written
+	Rows int64 `msgpack:"rows"`
+	// Errors is the errors of the requests that failed to be written
+	Errors []string `msgpack:"errors"`
+	// Violations is the violations of the write validation rules
+	Violations []WriteViolation `msgpack:"violations"`
+}
+
+// WriteStreamer is a stream of the write requests, whose results are returned when it's closed.
+type WriteStreamer interface {
+	// Send writes the request.
+	Send(req *WriteRequest) error
+	// CloseAndRecv closes the stream, and returns the results of the requests.
+	CloseAndRecv() (*WriteStreamResponse, error)
+}
+
+// StreamWriter writes the requests of a write stream in order, waiting for the WAL flush
+// every writeStreamFlushRows rows, and collects their results.
+// A failed request doesn't stop the stream and its error is returned in the response.
+type StreamWriter struct {
+	writer    Writer
+	flushRows int
+	unflushed int
+	response  *WriteStreamResponse
+}
+
+// NewStreamWriter returns a writer of a write stream.
+func NewStreamWriter(w Writer) *StreamWriter {
+	return &StreamWriter{
+		writer:    w,
+		flushRows: writeStreamFlushRows,
+		response:  &WriteStreamResponse{Results: map[string]*WriteStreamResult{}},
+	}
+}
+
+// Write writes the request.
+func (sw *StreamWriter) Write(req *WriteRequest) {
+	csm, err := req.Data.ToColumnSeriesMap()
+	if err != nil {
+		sw.response.Errors = append(sw.response.Errors, err.Error())
+		return
+	}
+	rows := make(map[string]int, len(csm))
+	for key, cs := range csm {
+		rows[key.GetItemKey()] = cs.Len()
+		sw.unflushed += cs.Len()
+	}
+
+	opts, err := writeOptions(req.WriteMode, req.DedupeKey)
+	var violations []validation.Violation
+	if err == nil {
+		opts.QueueOnly = true
+		violations, err = sw.writer.WriteCSMWithOptions(csm, req.IsVariableLength, opts)
+	}
+	for key, n := range rows {
+		result := sw.result(key)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		result.Rows += int64(n)
+	}
+	for _, v := range violations {
+		result := sw.result(v.Key)
+		result.Violations = append(result.Violations, WriteViolation(v))
+		if err == nil && v.Action != validation.Fix {
+			// the rejected and the quarantined rows are not written to the time bucket
+			result.Rows -= int64(v.Rows)
+		}
+	}
+
+	if sw.unflushed >= sw.flushRows {
+		sw.writer.WaitFlush()
+		sw.unflushed = 0
+	}
+}
+
+// Close waits for the WAL flush of the written rows, and returns the results of the requests.
+func (sw *StreamWriter) Close() *WriteStreamResponse {
+	if sw.unflushed > 0 {
+		sw.writer.WaitFlush()
+		sw.unflushed = 0
+	}
+	return sw.response
+}
+
+func (sw *StreamWriter) result(key string) *WriteStreamResult {
+	result, found := sw.response.Results[key]
+	if !found {
+		result = &WriteStreamResult{}
+		sw.response.Results[key] = result
+	}
+	return result
+}
+
+// WriteStream writes the requests received from the stream until the client closes it,
+// and returns the results of the requests.
+func (s GRPCService) WriteStream(stream proto.Marketstore_WriteStreamServer) error {
+	sw := NewStreamWriter(s.writer)
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, goio.EOF) {
+			return stream.SendAndClose(ToProtoWriteStreamResponse(sw.Close()))
+		}
+		if err != nil {
+			// the rows received before the error are written
+			sw.Close()
+			return fmt.Errorf("receive a write request: %w", err)
+		}
+		sw.Write(ToWriteRequest(req))
+	}
+}
+
+// ToWriteRequest converts the write request of gRPC to WriteRequest.
+func ToWriteRequest(req *proto.WriteRequest) *WriteRequest {
+	return &WriteRequest{
+		Data:             ToNumpyMultiDataSet(req.Data),
+		IsVariableLength: req.IsVariableLength,
+		WriteMode:        req.WriteMode,
+		DedupeKey:        req.DedupeKey,
+	}
+}
+
+// ToProtoWriteRequest converts the write request to the one of gRPC.
+func ToProtoWriteRequest(req *WriteRequest) *proto.WriteRequest {
+	return &proto.WriteRequest{
+		Data:             ToProtoNumpyMultiDataSet(req.Data),
+		IsVariableLength: req.IsVariableLength,
+		WriteMode:        req.WriteMode,
+		DedupeKey:        req.DedupeKey,
+	}
+}
+
+// ToProtoWriteStreamResponse converts the response of a write stream to the one of gRPC.
+func ToProtoWriteStreamResponse(resp *WriteStreamResponse) *proto.WriteStreamResponse {
+	ret := &proto.WriteStreamResponse{
+		Results: make(map[string]*proto.WriteStreamResult, len(resp.Results)),
+		Errors:  resp.Errors,
+	}
+	for key, result := range resp.Results {
+		r := &proto.WriteStreamResult{Rows: result.Rows, Errors: result.Errors}
+		for _, v := range result.Violations {
+			r.Violations = append(r.Violations, &proto.WriteViolation{
+				Key:     v.Key,
+				Rule:    v.Rule,
+				Action:  v.Action,
+				Rows:    int64(v.Rows),
+				Epoch:   v.Epoch,
+				Message: v.Message,
+			})
+		}
+		ret.Results[key] = r
+	}
+	return ret
+}
+
+// ToWriteStreamResponse converts the response of a write stream of gRPC to WriteStreamResponse.
+func ToWriteStreamResponse(resp *proto.WriteStreamResponse) *WriteStreamResponse {
+	ret := &WriteStreamResponse{
+		Results: make(map[string]*WriteStreamResult, len(resp.Results)),
+		Errors:  resp.Errors,
+	}
+	for key, result := range resp.Results {
+		r := &WriteStreamResult{Rows: result.Rows, Errors: result.Errors}
+		for _, v := range result.Violations {
+			r.Violations = append(r.Violations, WriteViolation{
+				Key:     v.Key,
+				Rule:    v.Rule,
+				Action:  v.Action,
+				Rows:    int(v.Rows),
+				Epoch:   v.Epoch,
+				Message: v.Message,
+			})
+		}
+		ret.Results[key] = r
+	}
+	return ret
+}
+
+// Rows returns the number of the rows written to all the time bucket keys.
+func (resp *WriteStreamResponse) Rows() (rows int64) {
+	for _, result := range resp.Results {
+		rows += result.Rows
+	}
+	return rows
+}
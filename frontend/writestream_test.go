@@ -0,0 +1,62 @@
+package frontend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// flushCountingWriter counts the writes that request the WAL flush and the waits for the flush.
+type flushCountingWriter struct {
+	Writer
+	writes        int
+	flushRequests int
+	flushes       int
+}
+
+func (w *flushCountingWriter) WriteCSMWithOptions(_ io.ColumnSeriesMap, _ bool, opts executor.WriteOptions,
+) ([]validation.Violation, error) {
+	w.writes++
+	if !opts.QueueOnly {
+		w.flushRequests++
+	}
+	return nil, nil
+}
+
+func (w *flushCountingWriter) WaitFlush() {
+	w.flushes++
+}
+
+func TestStreamWriter_Flush(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	w := &flushCountingWriter{}
+	sw := NewStreamWriter(w)
+	sw.flushRows = 4
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{0, 60})
+	cs.AddColumn("Close", []float32{1, 2})
+	nds, err := io.NewNumpyDataset(cs)
+	require.Nil(t, err)
+	nmds, err := io.NewNumpyMultiDataset(nds, *io.NewTimeBucketKey("TEST/1Min/OHLC"))
+	require.Nil(t, err)
+
+	// --- when ---
+	for i := 0; i < 5; i++ {
+		sw.Write(&WriteRequest{Data: nmds})
+	}
+	resp := sw.Close()
+
+	// --- then ---
+	assert.Equal(t, int64(10), resp.Rows())
+	assert.Equal(t, 5, w.writes)
+	// the chunks only queue the rows, which are flushed every 4 rows and at the close
+	assert.Zero(t, w.flushRequests)
+	assert.Equal(t, 3, w.flushes)
+}
@@ -16,7 +16,17 @@ func (c *Container) GetCatalogDir() *catalog.Directory {
 	}
 
 	// Initialize a global catalog
-	catalogDir, err := catalog.NewDirectory(c.GetAbsRootDir())
+	var (
+		catalogDir *catalog.Directory
+		err        error
+	)
+	if c.mktsConfig.CatalogIndex {
+		catalogDir, err = catalog.NewIndexedDirectory(c.GetAbsRootDir())
+	} else {
+		// the index is not kept in sync with the catalog
+		catalog.RemoveIndex(c.GetAbsRootDir())
+		catalogDir, err = catalog.NewDirectory(c.GetAbsRootDir())
+	}
 	if err != nil {
 		var e catalog.ErrCategoryFileNotFound
 		if errors.As(err, &e) {
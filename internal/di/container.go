@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 	"github.com/alpacahq/marketstore/v4/plugins/trigger"
 	"github.com/alpacahq/marketstore/v4/sqlparser"
 
@@ -25,6 +26,7 @@ type Container struct {
 	replicationSender     *replication.Sender
 	replicationClient     *replication.Retryer
 	writer                frontend.Writer
+	writeValidator        *validation.Validator
 	catalogDir            *catalog.Directory
 	wal                   *executor.WALFileType
 	tpd                   *executor.TriggerPluginDispatcher
@@ -34,6 +36,11 @@ type Container struct {
 	grpcServer            *grpc.Server
 	httpService           *frontend.QueryService
 	httpServer            *frontend.RPCServer
+	queryCache            *frontend.QueryCache
+	queryLimiter          *frontend.QueryLimiter
+	statsTracker          *executor.StatsTracker
+	configReloader        frontend.ConfigReloader
+	bgWorkerManager       frontend.BgWorkerManager
 	replicationServer     *replication.GRPCReplicationServer
 	grpcReplicationServer *grpc.Server
 }
@@ -1,6 +1,7 @@
 package di
 
 import (
+	"github.com/alpacahq/marketstore/v4/executor"
 	"github.com/alpacahq/marketstore/v4/frontend"
 	"google.golang.org/grpc"
 )
@@ -10,6 +11,10 @@ func (c *Container) GetHTTPService() *frontend.QueryService {
 		return c.httpService
 	}
 	c.httpService = frontend.NewQueryService(c.GetCatalogDir())
+	c.httpService.SetResultLimits(executor.ResultLimits{
+		MaxRows:  c.mktsConfig.QueryLimits.MaxRows,
+		MaxBytes: c.mktsConfig.QueryLimits.MaxBytes,
+	})
 	return c.httpService
 }
 
@@ -17,9 +22,20 @@ func (c *Container) GetHTTPServer() *frontend.RPCServer {
 	if c.httpServer != nil {
 		return c.httpServer
 	}
-	server, _ := frontend.NewServer(c.GetAbsRootDir(), c.GetCatalogDir(), c.GetAggRunner(),
+	server, service := frontend.NewServer(c.GetAbsRootDir(), c.GetCatalogDir(), c.GetAggRunner(),
 		c.GetWriter(), c.GetHTTPService(),
 	)
+	if qc := c.GetQueryCache(); qc != nil {
+		service.SetQueryCache(qc)
+	}
+	service.SetQueryLimiter(c.GetQueryLimiter())
+	service.SetStatsTracker(c.GetStatsTracker())
+	if c.configReloader != nil {
+		service.SetConfigReloader(c.configReloader)
+	}
+	if c.bgWorkerManager != nil {
+		service.SetBgWorkerManager(c.bgWorkerManager)
+	}
 	c.httpServer = server
 	return server
 }
@@ -30,9 +46,67 @@ func (c *Container) GetGRPCService() *frontend.GRPCService {
 	}
 	c.grpcService = frontend.NewGRPCService(c.GetAbsRootDir(),
 		c.GetCatalogDir(), c.GetAggRunner(), c.GetWriter(), c.GetHTTPService())
+	if qc := c.GetQueryCache(); qc != nil {
+		c.grpcService.SetQueryCache(qc)
+	}
+	c.grpcService.SetQueryLimiter(c.GetQueryLimiter())
+	c.grpcService.SetStatsTracker(c.GetStatsTracker())
+	if c.configReloader != nil {
+		c.grpcService.SetConfigReloader(c.configReloader)
+	}
+	if c.bgWorkerManager != nil {
+		c.grpcService.SetBgWorkerManager(c.bgWorkerManager)
+	}
 	return c.grpcService
 }
 
+// InjectConfigReloader enables the ReloadConfig API of the JSON-RPC and gRPC services created after this.
+func (c *Container) InjectConfigReloader(r frontend.ConfigReloader) {
+	c.configReloader = r
+}
+
+// InjectBgWorkerManager enables the bgworker APIs of the JSON-RPC and gRPC services created after this.
+func (c *Container) InjectBgWorkerManager(m frontend.BgWorkerManager) {
+	c.bgWorkerManager = m
+}
+
+// GetQueryCache returns the query result cache shared by the JSON-RPC and gRPC APIs,
+// or nil if it's not enabled. The cache is invalidated by the flushes of the WAL.
+func (c *Container) GetQueryCache() *frontend.QueryCache {
+	if c.queryCache != nil || !c.mktsConfig.QueryCache.Enabled {
+		return c.queryCache
+	}
+	c.queryCache = frontend.NewQueryCache(c.mktsConfig.QueryCache.MaxBytes)
+	c.queryCache.SetSymbolResolver(c.GetCatalogDir().ResolveSymbol)
+	if wf := c.GetInitWALFile(); wf != nil {
+		wf.AddFlushListener(c.queryCache)
+	}
+	return c.queryCache
+}
+
+// GetQueryLimiter returns the limiter of the query requests shared by the JSON-RPC and gRPC APIs,
+// so that the max concurrent queries are counted across both of them.
+func (c *Container) GetQueryLimiter() *frontend.QueryLimiter {
+	if c.queryLimiter != nil {
+		return c.queryLimiter
+	}
+	c.queryLimiter = frontend.NewQueryLimiter(c.mktsConfig.QueryLimits.Timeout, c.mktsConfig.QueryLimits.MaxConcurrent)
+	return c.queryLimiter
+}
+
+// GetStatsTracker returns the tracker of the statistics of the time buckets shared by the JSON-RPC and gRPC APIs.
+// The statistics are updated by the flushes of the WAL.
+func (c *Container) GetStatsTracker() *executor.StatsTracker {
+	if c.statsTracker != nil {
+		return c.statsTracker
+	}
+	c.statsTracker = executor.NewStatsTracker(c.GetCatalogDir())
+	if wf := c.GetInitWALFile(); wf != nil {
+		wf.AddFlushListener(c.statsTracker)
+	}
+	return c.statsTracker
+}
+
 // GetGRPCServer returns the grpc server for marketstore API.
 func (c *Container) GetGRPCServer() *grpc.Server {
 	if c.grpcServer != nil {
@@ -13,6 +13,7 @@ import (
 
 	pb "github.com/alpacahq/marketstore/v4/proto"
 	"github.com/alpacahq/marketstore/v4/replication"
+	"github.com/alpacahq/marketstore/v4/utils/io"
 	"github.com/alpacahq/marketstore/v4/utils/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -141,7 +142,17 @@ func (c *Container) GetReplicationClientWithRetry() ReplicationClient {
 
 	cli := replication.NewGRPCReplicationClient(pb.NewReplicationClient(conn))
 
-	replayer := replication.NewReplayer(executor.ParseTGData, c.GetDefaultWriter().WriteCSM, c.GetAbsRootDir())
+	writer, err := executor.NewWriter(c.GetCatalogDir(), c.GetInitWALFile())
+	if err != nil {
+		panic(errors.Wrap(err, "failed to initialize writer for replication"))
+	}
+	queueCSM := func(csm io.ColumnSeriesMap, isVariableLength bool) error {
+		_, err2 := writer.WriteCSMWithOptions(csm, isVariableLength, executor.WriteOptions{QueueOnly: true})
+		return err2
+	}
+	replayer := replication.NewReplayer(executor.ParseTGData, queueCSM, writer.QueueDeletion, writer.WaitFlush,
+		c.GetAbsRootDir(),
+	)
 	replicationReceiver := replication.NewReceiver(cli, replayer)
 
 	c.replicationClient = replication.NewRetryer(replicationReceiver.Run, c.mktsConfig.Replication.RetryInterval,
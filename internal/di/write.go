@@ -2,6 +2,7 @@ package di
 
 import (
 	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/executor/validation"
 	"github.com/alpacahq/marketstore/v4/frontend"
 )
 
@@ -18,14 +19,21 @@ func (c *Container) GetWriter() frontend.Writer {
 		return c.writer
 	}
 
-	c.writer = c.GetDefaultWriter()
+	writer := c.GetDefaultWriter()
+	writer.SetValidator(c.writeValidator)
+	c.writer = writer
 
 	return c.writer
 }
 
+// InjectWriteValidator enables the validation of the writes by the writer created after this.
+func (c *Container) InjectWriteValidator(v *validation.Validator) {
+	c.writeValidator = v
+}
+
 // GetDefaultWriter returns a writable writer.
-// Replica instances can use it only for data writes for replication.
-func (c *Container) GetDefaultWriter() frontend.Writer {
+// Replica instances can use it only for data writes for replication. The writes are not validated.
+func (c *Container) GetDefaultWriter() *executor.Writer {
 	writer, err := executor.NewWriter(c.GetCatalogDir(), c.GetInitWALFile())
 	if err != nil {
 		panic(err)
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BgWorkerMetric is the status of a bgworker exported as the metrics.
+type BgWorkerMetric struct {
+	Name     string
+	Running  bool
+	Restarts int64
+	Errors   int64
+	// LastWrite is the time of the last successful write in unix seconds, or 0 if unknown
+	LastWrite int64
+}
+
+var (
+	bgWorkerRunningDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "bgworker_running"),
+		"1 if the bgworker is running, 0 otherwise, partitioned by name",
+		[]string{"name"}, nil,
+	)
+	bgWorkerRestartsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "bgworker_restarts_total"),
+		"Number of the restarts of the bgworker after it panicked or its RunContext returned, partitioned by name",
+		[]string{"name"}, nil,
+	)
+	bgWorkerErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "bgworker_errors"),
+		"Number of the errors reported by the bgworker and its restarts, partitioned by name",
+		[]string{"name"}, nil,
+	)
+	bgWorkerLastWriteDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "bgworker_last_write_timestamp_seconds"),
+		"Unix time of the last successful write reported by the bgworker, partitioned by name",
+		[]string{"name"}, nil,
+	)
+)
+
+// BgWorkerCollector collects the metrics of the bgworkers on each scrape,
+// so that the bgworkers removed by a reload are not exported anymore.
+type BgWorkerCollector struct {
+	metrics func() []BgWorkerMetric
+}
+
+// NewBgWorkerCollector returns a collector of the metrics returned by the function.
+// It needs to be registered by prometheus.MustRegister.
+func NewBgWorkerCollector(metrics func() []BgWorkerMetric) *BgWorkerCollector {
+	return &BgWorkerCollector{metrics: metrics}
+}
+
+// Describe implements prometheus.Collector.
+func (c *BgWorkerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bgWorkerRunningDesc
+	ch <- bgWorkerRestartsDesc
+	ch <- bgWorkerErrorsDesc
+	ch <- bgWorkerLastWriteDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *BgWorkerCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.metrics() {
+		running := 0.0
+		if m.Running {
+			running = 1
+		}
+		ch <- prometheus.MustNewConstMetric(bgWorkerRunningDesc, prometheus.GaugeValue, running, m.Name)
+		ch <- prometheus.MustNewConstMetric(bgWorkerRestartsDesc, prometheus.CounterValue, float64(m.Restarts), m.Name)
+		ch <- prometheus.MustNewConstMetric(bgWorkerErrorsDesc, prometheus.GaugeValue, float64(m.Errors), m.Name)
+		if m.LastWrite != 0 {
+			ch <- prometheus.MustNewConstMetric(bgWorkerLastWriteDesc, prometheus.GaugeValue, float64(m.LastWrite), m.Name)
+		}
+	}
+}
@@ -58,6 +58,76 @@ var (
 		Buckets:   []float64{.0001, .001, .005, .01, .05, .1, .25, .5, 1},
 	})
 
+	// QueryCacheHits counts the queries answered by the query result cache.
+	QueryCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "query_cache_hits_total",
+		Help:      "Number of queries answered by the query result cache",
+	})
+
+	// QueryCacheMisses counts the queries that weren't in the query result cache.
+	QueryCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "query_cache_misses_total",
+		Help:      "Number of queries not found in the query result cache",
+	})
+
+	// QueryCacheEvictions counts the results removed from the query result cache
+	// partitioned by reason ("size" or "write").
+	QueryCacheEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "query_cache_evictions_total",
+		Help:      "Number of results removed from the query result cache partitioned by reason",
+	}, []string{"reason"})
+
+	// QueryCacheBytes stores the memory used by the results in the query result cache.
+	QueryCacheBytes = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "query_cache_bytes",
+			Help:      "Memory [bytes] used by the results in the query result cache",
+		})
+
+	// QueriesRunning stores the number of the query requests being executed.
+	QueriesRunning = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "queries_running",
+			Help:      "Number of query requests being executed",
+		})
+
+	// QueriesQueued stores the number of the query requests waiting for the max concurrent queries limit.
+	QueriesQueued = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "queries_queued",
+			Help:      "Number of query requests waiting for the max concurrent queries limit",
+		})
+
+	// QueryLimitErrors counts the query requests that failed by a limit,
+	// partitioned by limit ("timeout", "canceled" or "result_size").
+	QueryLimitErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "query_limit_errors_total",
+		Help:      "Number of query requests that failed by a limit partitioned by limit",
+	}, []string{"limit"})
+
+	// WriteValidationViolations counts the rows that violated the write validation rules,
+	// partitioned by rule and action ("reject", "quarantine" or "fix").
+	WriteValidationViolations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "write_validation_violations_total",
+		Help:      "Number of rows that violated the write validation rules partitioned by rule and action",
+	}, []string{"rule", "action"})
+
 	// TotalDiskUsageBytes stores the total size of DB files managed by Marketstore.
 	TotalDiskUsageBytes = promauto.NewGauge(
 		prometheus.GaugeOpts{
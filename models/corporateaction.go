@@ -0,0 +1,307 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/planner"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+const (
+	CorporateActionsSuffix    string = "CORPACTIONS"
+	CorporateActionsTimeframe string = "1D"
+)
+
+// CorporateActionsBucketKey returns a string bucket key of the corporate actions for a given symbol.
+//
+// The bucket has variable length records, one for each revision of an action:
+//
+//	Epoch         int64    - the knowledge date, when the revision was known
+//	ID            int64    - identifies the action among its revisions, unique per symbol
+//	Type          byte     - enum.CorporateActionType
+//	ExDate        int64    - the first day the prices reflect the action, in unix epoch second
+//	EffectiveDate int64    - the date the action takes effect (e.g. the payable date of a dividend), or 0
+//	Ratio         float64  - the new shares per old share of a split or stock dividend
+//	CashAmount    float64  - the cash dividend per share, in the currency of the prices
+//	OldSymbol     string16 - the previous symbol of a ticker change
+//	Canceled      byte     - 1 if the action is canceled as of the knowledge date
+func CorporateActionsBucketKey(symbol string) string {
+	return symbol + "/" + CorporateActionsTimeframe + "/" + CorporateActionsSuffix
+}
+
+// corporateActionTypeNames are the names of the corporate action types in the import APIs.
+var corporateActionTypeNames = map[string]enum.CorporateActionType{
+	"split":          enum.StockSplit,
+	"stock_dividend": enum.StockDividend,
+	"cash_dividend":  enum.CashDividend,
+	"ticker_change":  enum.TickerChange,
+}
+
+// ParseCorporateActionType returns the corporate action type of a name
+// ("split", "stock_dividend", "cash_dividend" or "ticker_change").
+func ParseCorporateActionType(name string) (enum.CorporateActionType, error) {
+	typ, found := corporateActionTypeNames[strings.ToLower(name)]
+	if !found {
+		return 0, fmt.Errorf("unknown corporate action type: %s", name)
+	}
+	return typ, nil
+}
+
+// CorporateAction is a revision of a split, a dividend or a ticker change of a symbol.
+// The latest revision of an action known at a date is the effective one, so that
+// the actions and the prices adjusted by them are reproducible as of any knowledge date.
+type CorporateAction struct {
+	ID            int64
+	KnownAt       time.Time
+	Type          enum.CorporateActionType
+	ExDate        time.Time
+	EffectiveDate time.Time
+	Ratio         float64
+	CashAmount    float64
+	OldSymbol     string
+	Canceled      bool
+}
+
+// Validate returns an error if the action is missing a field required by its type.
+func (ca *CorporateAction) Validate() error {
+	if ca.KnownAt.IsZero() || ca.ExDate.IsZero() {
+		return errors.New("the knowledge date and the ex-date are required")
+	}
+	if ca.Canceled {
+		return nil
+	}
+	switch ca.Type {
+	case enum.StockSplit, enum.StockDividend:
+		if ca.Ratio <= 0 {
+			return fmt.Errorf("the ratio of a split or stock dividend must be positive, got %v", ca.Ratio)
+		}
+	case enum.CashDividend:
+		if ca.CashAmount <= 0 {
+			return fmt.Errorf("the amount of a cash dividend must be positive, got %v", ca.CashAmount)
+		}
+	case enum.TickerChange:
+		if ca.OldSymbol == "" {
+			return errors.New("the old symbol of a ticker change is required")
+		}
+		if len([]rune(ca.OldSymbol)) > 16 {
+			return fmt.Errorf("the old symbol of a ticker change is longer than 16 characters: %s", ca.OldSymbol)
+		}
+	default:
+		return fmt.Errorf("unknown corporate action type: %q", byte(ca.Type))
+	}
+	return nil
+}
+
+// CorporateActions is a data model to persist the corporate actions of a symbol.
+type CorporateActions struct {
+	Tbk           *io.TimeBucketKey
+	Epoch         []int64
+	ID            []int64
+	Type          []byte
+	ExDate        []int64
+	EffectiveDate []int64
+	Ratio         []float64
+	CashAmount    []float64
+	OldSymbol     [][16]rune
+	Canceled      []byte
+	WriteTime     time.Duration
+}
+
+// NewCorporateActions creates a new CorporateActions object and initializes it's internal column buffers
+// to the given capacity.
+func NewCorporateActions(symbol string, capacity int) *CorporateActions {
+	return &CorporateActions{
+		Tbk:           io.NewTimeBucketKey(CorporateActionsBucketKey(symbol)),
+		Epoch:         make([]int64, 0, capacity),
+		ID:            make([]int64, 0, capacity),
+		Type:          make([]byte, 0, capacity),
+		ExDate:        make([]int64, 0, capacity),
+		EffectiveDate: make([]int64, 0, capacity),
+		Ratio:         make([]float64, 0, capacity),
+		CashAmount:    make([]float64, 0, capacity),
+		OldSymbol:     make([][16]rune, 0, capacity),
+		Canceled:      make([]byte, 0, capacity),
+	}
+}
+
+// Key returns the key of the model's time bucket.
+func (model *CorporateActions) Key() string {
+	return model.Tbk.GetItemKey()
+}
+
+// Len returns the length of the internal column buffers.
+func (model *CorporateActions) Len() int {
+	return len(model.Epoch)
+}
+
+// Symbol returns the Symbol part if the TimeBucketKey of this model.
+func (model *CorporateActions) Symbol() string {
+	return model.Tbk.GetItemInCategory("Symbol")
+}
+
+// Add adds a revision of an action to the internal buffers.
+func (model *CorporateActions) Add(ca *CorporateAction) {
+	var oldSymbol [16]rune
+	copy(oldSymbol[:], []rune(ca.OldSymbol))
+	var canceled byte
+	if ca.Canceled {
+		canceled = 1
+	}
+	var effectiveDate int64
+	if !ca.EffectiveDate.IsZero() {
+		effectiveDate = ca.EffectiveDate.Unix()
+	}
+	model.Epoch = append(model.Epoch, ca.KnownAt.Unix())
+	model.ID = append(model.ID, ca.ID)
+	model.Type = append(model.Type, byte(ca.Type))
+	model.ExDate = append(model.ExDate, ca.ExDate.Unix())
+	model.EffectiveDate = append(model.EffectiveDate, effectiveDate)
+	model.Ratio = append(model.Ratio, ca.Ratio)
+	model.CashAmount = append(model.CashAmount, ca.CashAmount)
+	model.OldSymbol = append(model.OldSymbol, oldSymbol)
+	model.Canceled = append(model.Canceled, canceled)
+}
+
+func (model *CorporateActions) GetCs() *io.ColumnSeries {
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", model.Epoch)
+	cs.AddColumn("ID", model.ID)
+	cs.AddColumn("Type", model.Type)
+	cs.AddColumn("ExDate", model.ExDate)
+	cs.AddColumn("EffectiveDate", model.EffectiveDate)
+	cs.AddColumn("Ratio", model.Ratio)
+	cs.AddColumn("CashAmount", model.CashAmount)
+	cs.AddColumn("OldSymbol", model.OldSymbol)
+	cs.AddColumn("Canceled", model.Canceled)
+	return cs
+}
+
+// BuildCsm prepares an io.ColumnSeriesMap object and populates it's columns with the contents of the internal buffers.
+func (model *CorporateActions) BuildCsm() *io.ColumnSeriesMap {
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*model.Tbk, model.GetCs())
+	return &csm
+}
+
+// Write persist the internal buffers to disk.
+func (model *CorporateActions) Write() error {
+	start := time.Now()
+	err := executor.WriteCSM(*model.BuildCsm(), true)
+	model.WriteTime = time.Since(start)
+	if err != nil {
+		log.Error("Failed to write corporate actions for %s (%+v)", model.Key(), err)
+	}
+	return err
+}
+
+// CorporateActionsFromColumnSeries converts the rows of a corporate actions bucket to the actions.
+func CorporateActionsFromColumnSeries(cs *io.ColumnSeries) ([]CorporateAction, error) {
+	epoch, ok1 := cs.GetColumn("Epoch").([]int64)
+	id, ok2 := cs.GetColumn("ID").([]int64)
+	typ, ok3 := cs.GetColumn("Type").([]byte)
+	exDate, ok4 := cs.GetColumn("ExDate").([]int64)
+	effectiveDate, ok5 := cs.GetColumn("EffectiveDate").([]int64)
+	ratio, ok6 := cs.GetColumn("Ratio").([]float64)
+	cashAmount, ok7 := cs.GetColumn("CashAmount").([]float64)
+	oldSymbol, ok8 := cs.GetColumn("OldSymbol").([][16]rune)
+	canceled, ok9 := cs.GetColumn("Canceled").([]byte)
+	if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6 && ok7 && ok8 && ok9) {
+		return nil, fmt.Errorf("cast a column series to corporate actions: %v", cs.GetDataShapes())
+	}
+
+	actions := make([]CorporateAction, len(epoch))
+	for i := range epoch {
+		actions[i] = CorporateAction{
+			ID:         id[i],
+			KnownAt:    time.Unix(epoch[i], 0).UTC(),
+			Type:       enum.CorporateActionType(typ[i]),
+			ExDate:     time.Unix(exDate[i], 0).UTC(),
+			Ratio:      ratio[i],
+			CashAmount: cashAmount[i],
+			OldSymbol:  string16ToString(oldSymbol[i]),
+			Canceled:   canceled[i] != 0,
+		}
+		if effectiveDate[i] != 0 {
+			actions[i].EffectiveDate = time.Unix(effectiveDate[i], 0).UTC()
+		}
+	}
+	return actions, nil
+}
+
+// ReadCorporateActions returns all the revisions of the corporate actions of the symbol in the order of
+// their knowledge dates. nil is returned when the bucket doesn't exist.
+func ReadCorporateActions(catalogDir *catalog.Directory, symbol string) ([]CorporateAction, error) {
+	tbk := io.NewTimeBucketKey(CorporateActionsBucketKey(symbol))
+	if _, err := catalogDir.GetLatestTimeBucketInfoFromKey(tbk); err != nil {
+		// the bucket has not been created yet
+		return nil, nil
+	}
+
+	query := planner.NewQuery(catalogDir)
+	query.AddTargetKey(tbk)
+	query.SetRange(planner.MinTime, planner.MaxTime)
+	parsed, err := query.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("parse query for %s: %w", tbk, err)
+	}
+	reader, err := executor.NewReader(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("create query reader for %s: %w", tbk, err)
+	}
+	csm, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read query for %s: %w", tbk, err)
+	}
+	cs := csm[*tbk]
+	if cs == nil || cs.Len() == 0 {
+		return nil, nil
+	}
+	return CorporateActionsFromColumnSeries(cs)
+}
+
+// EffectiveCorporateActions returns the latest revision of each action known before the time,
+// excluding the canceled actions, in the order of their ex-dates.
+// The revisions must be in the order of their knowledge dates.
+func EffectiveCorporateActions(revisions []CorporateAction, knownBefore time.Time) []CorporateAction {
+	latest := map[int64]int{}
+	for i := range revisions {
+		if !revisions[i].KnownAt.Before(knownBefore) {
+			continue
+		}
+		// the revisions known at the same time are applied in the order they were written
+		if prev, found := latest[revisions[i].ID]; found && revisions[i].KnownAt.Before(revisions[prev].KnownAt) {
+			continue
+		}
+		latest[revisions[i].ID] = i
+	}
+
+	ret := make([]CorporateAction, 0, len(latest))
+	for _, i := range latest {
+		if !revisions[i].Canceled {
+			ret = append(ret, revisions[i])
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].ExDate.Equal(ret[j].ExDate) {
+			return ret[i].ID < ret[j].ID
+		}
+		return ret[i].ExDate.Before(ret[j].ExDate)
+	})
+	return ret
+}
+
+func string16ToString(s [16]rune) string {
+	n := 0
+	for n < len(s) && s[n] != 0 {
+		n++
+	}
+	return string(s[:n])
+}
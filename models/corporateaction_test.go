@@ -0,0 +1,123 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/utils"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestReadCorporateActions(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+
+	revisions := []models.CorporateAction{
+		{
+			ID: 1, KnownAt: day(2021, 1, 4), Type: enum.StockSplit,
+			ExDate: day(2021, 2, 1), EffectiveDate: day(2021, 1, 29), Ratio: 2,
+		},
+		{ID: 2, KnownAt: day(2021, 1, 5), Type: enum.TickerChange, ExDate: day(2021, 3, 1), OldSymbol: "OLDSYM"},
+		// the split ratio is corrected
+		{ID: 1, KnownAt: day(2021, 1, 6), Type: enum.StockSplit, ExDate: day(2021, 2, 1), Ratio: 3},
+		{ID: 3, KnownAt: day(2021, 1, 7), Type: enum.CashDividend, ExDate: day(2021, 1, 15), CashAmount: 0.5},
+		// the dividend is canceled
+		{ID: 3, KnownAt: day(2021, 1, 10), Type: enum.CashDividend, ExDate: day(2021, 1, 15), Canceled: true},
+	}
+	model := models.NewCorporateActions("NEWSYM", len(revisions))
+	for i := range revisions {
+		require.Nil(t, revisions[i].Validate())
+		model.Add(&revisions[i])
+	}
+
+	// --- when ---
+	require.Nil(t, c.GetWriter().WriteCSM(*model.BuildCsm(), true))
+	got, err := models.ReadCorporateActions(c.GetCatalogDir(), "NEWSYM")
+
+	// --- then ---
+	require.Nil(t, err)
+	assert.Equal(t, revisions, got)
+
+	tests := map[string]struct {
+		knownBefore time.Time
+		want        []models.CorporateAction
+	}{
+		"ok/ nothing is known": {
+			knownBefore: day(2021, 1, 4),
+			want:        []models.CorporateAction{},
+		},
+		"ok/ the first revision": {
+			knownBefore: day(2021, 1, 5),
+			want:        []models.CorporateAction{revisions[0]},
+		},
+		"ok/ the corrected revision and the dividend before it's canceled, in the order of the ex-dates": {
+			knownBefore: day(2021, 1, 8),
+			want:        []models.CorporateAction{revisions[3], revisions[2], revisions[1]},
+		},
+		"ok/ the canceled dividend is excluded": {
+			knownBefore: day(2021, 12, 31),
+			want:        []models.CorporateAction{revisions[2], revisions[1]},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, models.EffectiveCorporateActions(got, tt.knownBefore))
+		})
+	}
+}
+
+func TestCorporateActionValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		action  models.CorporateAction
+		wantErr bool
+	}{
+		"ok/ canceled action without the fields of the type": {
+			action: models.CorporateAction{KnownAt: day(2021, 1, 4), ExDate: day(2021, 2, 1), Canceled: true},
+		},
+		"ng/ no ex-date": {
+			action:  models.CorporateAction{KnownAt: day(2021, 1, 4), Type: enum.StockSplit, Ratio: 2},
+			wantErr: true,
+		},
+		"ng/ split without ratio": {
+			action:  models.CorporateAction{KnownAt: day(2021, 1, 4), ExDate: day(2021, 2, 1), Type: enum.StockSplit},
+			wantErr: true,
+		},
+		"ng/ dividend without amount": {
+			action:  models.CorporateAction{KnownAt: day(2021, 1, 4), ExDate: day(2021, 2, 1), Type: enum.CashDividend},
+			wantErr: true,
+		},
+		"ng/ ticker change without old symbol": {
+			action:  models.CorporateAction{KnownAt: day(2021, 1, 4), ExDate: day(2021, 2, 1), Type: enum.TickerChange},
+			wantErr: true,
+		},
+		"ng/ unknown type": {
+			action:  models.CorporateAction{KnownAt: day(2021, 1, 4), ExDate: day(2021, 2, 1), Type: 'X'},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.action.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
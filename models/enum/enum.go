@@ -134,3 +134,27 @@ const (
 	// OnDemandIntraDayAuction QuoteCondition = '4' // Same as Nasdaq.
 
 )
+
+// BookSide is the side of an order book level.
+type BookSide byte
+
+// Order book sides.
+const (
+	Bid BookSide = 'B'
+	Ask BookSide = 'A'
+)
+
+// CorporateActionType is the type of a corporate action.
+type CorporateActionType byte
+
+// Corporate action types.
+const (
+	// StockSplit divides each share into Ratio shares, e.g. 2 for a 2-for-1 split and 0.5 for a 1-for-2 reverse split.
+	StockSplit CorporateActionType = 'S'
+	// StockDividend distributes shares, adjusted by the Ratio of the shares after and before the distribution.
+	StockDividend CorporateActionType = 'V'
+	// CashDividend pays CashAmount per share.
+	CashDividend CorporateActionType = 'D'
+	// TickerChange renames OldSymbol to the symbol of the action.
+	TickerChange CorporateActionType = 'T'
+)
@@ -0,0 +1,329 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor"
+	"github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/planner"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+	"github.com/alpacahq/marketstore/v4/utils/log"
+)
+
+const (
+	OrderBookSnapshotSuffix string = "BOOKSNAP"
+	OrderBookDeltaSuffix    string = "BOOKDELTA"
+	OrderBookTimeframe      string = "1Sec"
+)
+
+// OrderBookSnapshotBucketKey returns a string bucket key of the order book snapshots for a given symbol.
+func OrderBookSnapshotBucketKey(symbol string) string {
+	return symbol + "/" + OrderBookTimeframe + "/" + OrderBookSnapshotSuffix
+}
+
+// OrderBookDeltaBucketKey returns a string bucket key of the order book deltas for a given symbol.
+func OrderBookDeltaBucketKey(symbol string) string {
+	return symbol + "/" + OrderBookTimeframe + "/" + OrderBookDeltaSuffix
+}
+
+// bookLevels holds the column buffers shared by the order book snapshot and delta.
+// Each row is a price level of one side of the book.
+type bookLevels struct {
+	Tbk   *io.TimeBucketKey
+	Epoch []int64
+	Nanos []int32
+	Side  []enum.BookSide
+	Price []enum.Price
+	Size  []enum.Size
+
+	WriteTime time.Duration
+}
+
+func newBookLevels(bucketKey string, capacity int) bookLevels {
+	return bookLevels{
+		Tbk:   io.NewTimeBucketKey(bucketKey),
+		Epoch: make([]int64, 0, capacity),
+		Nanos: make([]int32, 0, capacity),
+		Side:  make([]enum.BookSide, 0, capacity),
+		Price: make([]enum.Price, 0, capacity),
+		Size:  make([]enum.Size, 0, capacity),
+	}
+}
+
+// Key returns the key of the model's time bucket.
+func (model *bookLevels) Key() string {
+	return model.Tbk.GetItemKey()
+}
+
+// Len returns the length of the internal column buffers.
+func (model *bookLevels) Len() int {
+	return len(model.Epoch)
+}
+
+// Symbol returns the Symbol part if the TimeBucketKey of this model.
+func (model *bookLevels) Symbol() string {
+	return model.Tbk.GetItemInCategory("Symbol")
+}
+
+// Add adds a price level to the internal buffers.
+func (model *bookLevels) Add(epoch int64, nanos int, side enum.BookSide, price enum.Price, size enum.Size) {
+	model.Epoch = append(model.Epoch, epoch)
+	model.Nanos = append(model.Nanos, int32(nanos))
+	model.Side = append(model.Side, side)
+	model.Price = append(model.Price, price)
+	model.Size = append(model.Size, size)
+}
+
+// Reset empties the internal buffers so that the model can be reused.
+func (model *bookLevels) Reset() {
+	model.Epoch = model.Epoch[:0]
+	model.Nanos = model.Nanos[:0]
+	model.Side = model.Side[:0]
+	model.Price = model.Price[:0]
+	model.Size = model.Size[:0]
+}
+
+func (model *bookLevels) GetCs() *io.ColumnSeries {
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", model.Epoch)
+	cs.AddColumn("Nanoseconds", model.Nanos)
+	cs.AddColumn("Side", model.Side)
+	cs.AddColumn("Price", model.Price)
+	cs.AddColumn("Size", model.Size)
+	return cs
+}
+
+// BuildCsm prepares an io.ColumnSeriesMap object and populates it's columns with the contents of the internal buffers
+// it is included in the .Write() method
+// so use only when you need to work with the ColumnSeriesMap before writing it to disk.
+func (model *bookLevels) BuildCsm() *io.ColumnSeriesMap {
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*model.Tbk, model.GetCs())
+	return &csm
+}
+
+// Write persist the internal buffers to disk.
+func (model *bookLevels) Write() error {
+	start := time.Now()
+	csm := model.BuildCsm()
+	err := executor.WriteCSM(*csm, true)
+	model.WriteTime = time.Since(start)
+	if err != nil {
+		log.Error("Failed to write order book for %s (%+v)", model.Key(), err)
+		return err
+	}
+
+	return nil
+}
+
+// OrderBookSnapshot defines schema and helper functions for storing the full depth of an order book
+// at a point in time. All the levels of a snapshot share the same timestamp, and a snapshot
+// is expected to include every OrderBookDelta whose timestamp is not after it.
+type OrderBookSnapshot struct {
+	bookLevels
+}
+
+// NewOrderBookSnapshot creates a new OrderBookSnapshot object
+// and initializes it's internal column buffers to the given capacity.
+func NewOrderBookSnapshot(symbol string, capacity int) *OrderBookSnapshot {
+	return &OrderBookSnapshot{bookLevels: newBookLevels(OrderBookSnapshotBucketKey(symbol), capacity)}
+}
+
+// OrderBookDelta defines schema and helper functions for storing changes of price levels of an order book.
+// Each row replaces the size of the price level on the side, and a zero size removes the level.
+type OrderBookDelta struct {
+	bookLevels
+}
+
+// NewOrderBookDelta creates a new OrderBookDelta object
+// and initializes it's internal column buffers to the given capacity.
+func NewOrderBookDelta(symbol string, capacity int) *OrderBookDelta {
+	return &OrderBookDelta{bookLevels: newBookLevels(OrderBookDeltaBucketKey(symbol), capacity)}
+}
+
+// BookLevel is a price level of an order book.
+type BookLevel struct {
+	Price enum.Price
+	Size  enum.Size
+}
+
+// OrderBook is an order book reconstructed from the stored snapshots and deltas.
+// Bids are sorted by descending price and Asks by ascending price.
+type OrderBook struct {
+	Time time.Time
+	Bids []BookLevel
+	Asks []BookLevel
+}
+
+// ReconstructOrderBook rebuilds the order book of the symbol as of the given time from
+// the latest OrderBookSnapshot not after it and the OrderBookDeltas written since the snapshot.
+// When there is no snapshot, the deltas are applied to an empty book from the beginning.
+// Only the best depth levels of each side are returned. A non-positive depth returns all the levels.
+// It's exposed to the queries as the orderbook function (see uda/orderbook).
+func ReconstructOrderBook(catalogDir *catalog.Directory, symbol string, at time.Time, depth int,
+) (*OrderBook, error) {
+	bids := map[enum.Price]enum.Size{}
+	asks := map[enum.Price]enum.Size{}
+
+	snapshot, err := latestBookSnapshot(catalogDir, io.NewTimeBucketKey(OrderBookSnapshotBucketKey(symbol)), at)
+	if err != nil {
+		return nil, fmt.Errorf("read order book snapshot of %s: %w", symbol, err)
+	}
+	from := time.Unix(0, 0)
+	if snapshot != nil {
+		from = snapshot.times[0]
+		applyBookRows(snapshot, bids, asks)
+	}
+
+	deltas, err := readBookRows(catalogDir, io.NewTimeBucketKey(OrderBookDeltaBucketKey(symbol)), from, at, 0)
+	if err != nil {
+		return nil, fmt.Errorf("read order book deltas of %s: %w", symbol, err)
+	}
+	if deltas != nil {
+		if snapshot != nil {
+			// the deltas at the snapshot time are already included in the snapshot
+			deltas = deltas.filter(func(t time.Time) bool { return t.After(from) })
+		}
+		applyBookRows(deltas, bids, asks)
+	}
+
+	return &OrderBook{
+		Time: at,
+		Bids: sortBookLevels(bids, depth, func(l, r enum.Price) bool { return l > r }),
+		Asks: sortBookLevels(asks, depth, func(l, r enum.Price) bool { return l < r }),
+	}, nil
+}
+
+// bookRows is the order book rows read from a bucket.
+type bookRows struct {
+	times []time.Time
+	side  []byte
+	price []float64
+	size  []uint64
+}
+
+func (rows *bookRows) filter(keep func(t time.Time) bool) *bookRows {
+	ret := &bookRows{}
+	for i, t := range rows.times {
+		if keep(t) {
+			ret.times = append(ret.times, t)
+			ret.side = append(ret.side, rows.side[i])
+			ret.price = append(ret.price, rows.price[i])
+			ret.size = append(ret.size, rows.size[i])
+		}
+	}
+	return ret
+}
+
+// latestBookSnapshot returns the levels of the latest snapshot not after the time,
+// or nil when there is no such snapshot.
+func latestBookSnapshot(catalogDir *catalog.Directory, tbk *io.TimeBucketKey, at time.Time) (*bookRows, error) {
+	// the query range is in seconds, so the snapshots in the same second as "at" are read separately
+	// to exclude the ones after it.
+	sec := time.Unix(at.Unix(), 0)
+	rows, err := readBookRows(catalogDir, tbk, sec, at, 0)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil || len(rows.times) == 0 {
+		// the last level of the latest snapshot before the second
+		last, err2 := readBookRows(catalogDir, tbk, time.Unix(0, 0), sec.Add(-time.Nanosecond), 1)
+		if err2 != nil {
+			return nil, err2
+		}
+		if last == nil || len(last.times) == 0 {
+			return nil, nil
+		}
+		sec = time.Unix(last.times[0].Unix(), 0)
+		if rows, err = readBookRows(catalogDir, tbk, sec, last.times[0], 0); err != nil {
+			return nil, err
+		}
+	}
+
+	latest := rows.times[len(rows.times)-1]
+	return rows.filter(func(t time.Time) bool { return t.Equal(latest) }), nil
+}
+
+// readBookRows reads the order book rows between start and end (both inclusive, in nanoseconds).
+// rowLimit > 0 returns only the last rowLimit rows. nil is returned when the bucket doesn't exist.
+func readBookRows(catalogDir *catalog.Directory, tbk *io.TimeBucketKey, start, end time.Time, rowLimit int,
+) (*bookRows, error) {
+	if _, err := catalogDir.GetLatestTimeBucketInfoFromKey(tbk); err != nil {
+		// the bucket has not been created yet
+		return nil, nil
+	}
+
+	query := planner.NewQuery(catalogDir)
+	query.AddTargetKey(tbk)
+	query.SetRange(start, end)
+	if rowLimit > 0 {
+		query.SetRowLimit(io.LAST, rowLimit)
+	}
+	parsed, err := query.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("parse query for %s: %w", tbk, err)
+	}
+	reader, err := executor.NewReader(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("create query reader for %s: %w", tbk, err)
+	}
+	csm, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read query for %s: %w", tbk, err)
+	}
+	cs := csm[*tbk]
+	if cs == nil || cs.Len() == 0 {
+		return &bookRows{}, nil
+	}
+
+	times, err := cs.GetTime()
+	if err != nil {
+		return nil, fmt.Errorf("get time from query for %s: %w", tbk, err)
+	}
+	side, ok := cs.GetColumn("Side").([]byte)
+	if !ok {
+		return nil, errors.New("unexpected data type for Side column")
+	}
+	price, ok := cs.GetColumn("Price").([]float64)
+	if !ok {
+		return nil, errors.New("unexpected data type for Price column")
+	}
+	size, ok := cs.GetColumn("Size").([]uint64)
+	if !ok {
+		return nil, errors.New("unexpected data type for Size column")
+	}
+
+	rows := &bookRows{times: times, side: side, price: price, size: size}
+	return rows.filter(func(t time.Time) bool { return !t.Before(start) && !t.After(end) }), nil
+}
+
+func applyBookRows(rows *bookRows, bids, asks map[enum.Price]enum.Size) {
+	for i := range rows.times {
+		levels := bids
+		if enum.BookSide(rows.side[i]) == enum.Ask {
+			levels = asks
+		}
+		price := enum.Price(rows.price[i])
+		if rows.size[i] == 0 {
+			delete(levels, price)
+			continue
+		}
+		levels[price] = enum.Size(rows.size[i])
+	}
+}
+
+func sortBookLevels(levels map[enum.Price]enum.Size, depth int, less func(l, r enum.Price) bool) []BookLevel {
+	ret := make([]BookLevel, 0, len(levels))
+	for price, size := range levels {
+		ret = append(ret, BookLevel{Price: price, Size: size})
+	}
+	sort.Slice(ret, func(i, j int) bool { return less(ret[i].Price, ret[j].Price) })
+	if depth > 0 && len(ret) > depth {
+		ret = ret[:depth]
+	}
+	return ret
+}
@@ -0,0 +1,113 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/utils"
+)
+
+func TestReconstructOrderBook(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+
+	const symbol = "TEST"
+	t1 := time.Date(2021, 12, 1, 10, 0, 0, 100, time.UTC)
+	t2 := t1.Add(500 * time.Millisecond)
+	t3 := t1.Add(1200 * time.Millisecond)
+	t4 := t1.Add(time.Minute)
+
+	snapshot := models.NewOrderBookSnapshot(symbol, 0)
+	snapshot.Add(t1.Unix(), t1.Nanosecond(), enum.Bid, 100, 10)
+	snapshot.Add(t1.Unix(), t1.Nanosecond(), enum.Bid, 99, 5)
+	snapshot.Add(t1.Unix(), t1.Nanosecond(), enum.Ask, 101, 7)
+	snapshot.Add(t1.Unix(), t1.Nanosecond(), enum.Ask, 102, 3)
+	snapshot.Add(t4.Unix(), t4.Nanosecond(), enum.Bid, 90, 1)
+	snapshot.Add(t4.Unix(), t4.Nanosecond(), enum.Ask, 110, 1)
+	require.NoError(t, c.GetWriter().WriteCSM(*snapshot.BuildCsm(), true))
+
+	delta := models.NewOrderBookDelta(symbol, 0)
+	// already included in the snapshot at t1
+	delta.Add(t1.Unix(), t1.Nanosecond(), enum.Bid, 98, 1)
+	delta.Add(t2.Unix(), t2.Nanosecond(), enum.Bid, 100, 0)
+	delta.Add(t2.Unix(), t2.Nanosecond(), enum.Ask, 101, 8)
+	delta.Add(t3.Unix(), t3.Nanosecond(), enum.Bid, 99.5, 2)
+	require.NoError(t, c.GetWriter().WriteCSM(*delta.BuildCsm(), true))
+
+	tests := map[string]struct {
+		at       time.Time
+		depth    int
+		wantBids []models.BookLevel
+		wantAsks []models.BookLevel
+	}{
+		"ok/ empty book before the first snapshot": {
+			at:       t1.Add(-time.Nanosecond),
+			wantBids: []models.BookLevel{},
+			wantAsks: []models.BookLevel{},
+		},
+		"ok/ snapshot": {
+			at:       t1,
+			wantBids: []models.BookLevel{{Price: 100, Size: 10}, {Price: 99, Size: 5}},
+			wantAsks: []models.BookLevel{{Price: 101, Size: 7}, {Price: 102, Size: 3}},
+		},
+		"ok/ deltas after the time are not applied": {
+			at:       t2.Add(-time.Nanosecond),
+			wantBids: []models.BookLevel{{Price: 100, Size: 10}, {Price: 99, Size: 5}},
+			wantAsks: []models.BookLevel{{Price: 101, Size: 7}, {Price: 102, Size: 3}},
+		},
+		"ok/ deltas are applied to the snapshot": {
+			at:       t3,
+			wantBids: []models.BookLevel{{Price: 99.5, Size: 2}, {Price: 99, Size: 5}},
+			wantAsks: []models.BookLevel{{Price: 101, Size: 8}, {Price: 102, Size: 3}},
+		},
+		"ok/ only the best levels up to the depth": {
+			at:       t3,
+			depth:    1,
+			wantBids: []models.BookLevel{{Price: 99.5, Size: 2}},
+			wantAsks: []models.BookLevel{{Price: 101, Size: 8}},
+		},
+		"ok/ the latest snapshot is used": {
+			at:       t4.Add(time.Hour),
+			wantBids: []models.BookLevel{{Price: 90, Size: 1}},
+			wantAsks: []models.BookLevel{{Price: 110, Size: 1}},
+		},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- when ---
+			got, err := models.ReconstructOrderBook(c.GetCatalogDir(), symbol, tt.at, tt.depth)
+
+			// --- then ---
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBids, got.Bids)
+			require.Equal(t, tt.wantAsks, got.Asks)
+		})
+	}
+}
+
+func TestReconstructOrderBook_NoData(t *testing.T) {
+	t.Parallel()
+
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+
+	got, err := models.ReconstructOrderBook(c.GetCatalogDir(), "NOTEXIST", time.Now(), 10)
+
+	require.NoError(t, err)
+	require.Empty(t, got.Bids)
+	require.Empty(t, got.Asks)
+}
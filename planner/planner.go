@@ -1,6 +1,7 @@
 package planner
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"time"
@@ -77,6 +78,8 @@ type ParseResult struct {
 	IntervalsPerDay int64
 	RootDir         string
 	TimeQuals       []TimeQualFunc
+	// Mappings are the memory mappings of the files to read them in the mmap read mode
+	Mappings *catalog.FileMappings
 }
 
 func NewParseResult() *ParseResult {
@@ -100,11 +103,7 @@ func (pr *ParseResult) GetDataShapes() (dsv map[io.TimeBucketKey][]io.DataShape)
 		/*
 			Prepend the Epoch column info, as it is not present in the file info but it is in the query data
 		*/
-		names := []string{"Epoch"}
-		types := []io.EnumElementType{io.INT64}
-		names = append(names, qf.File.GetElementNames()...)
-		types = append(types, qf.File.GetElementTypes()...)
-		dsv[qf.Key] = io.NewDataShapeVector(names, types)
+		dsv[qf.Key] = qf.File.GetDataShapesWithEpoch()
 	}
 	return dsv
 }
@@ -201,6 +200,14 @@ func (q *Query) AddTimeQual(timeQual TimeQualFunc) {
 }
 
 func (q *Query) Parse() (pr *ParseResult, err error) {
+	return q.ParseContext(context.Background())
+}
+
+// ParseContext is Parse that stops when the context is done, e.g. when the client of the query has gone away.
+func (q *Query) ParseContext(ctx context.Context) (pr *ParseResult, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
 	// Check to see that the categories in the query are present in the DB directory
 	categorySet, err := q.DataDir.GatherCategoriesFromCache()
 	if err != nil {
@@ -232,6 +239,10 @@ func (q *Query) Parse() (pr *ParseResult, err error) {
 				// Load subdirs matching restriction
 				for _, itemName := range list {
 					subdirWithItemName := d.GetSubDirWithItemName(itemName)
+					if subdirWithItemName == nil && d == q.DataDir {
+						// the symbol can be an alias of a renamed symbol, read with the queried name
+						subdirWithItemName = d.GetSubDirWithItemName(d.ResolveSymbol(itemName))
+					}
 					if subdirWithItemName != nil {
 						getFileList(subdirWithItemName, f, itemKey+itemName+"/", categoryKey)
 					}
@@ -266,10 +277,14 @@ func (q *Query) Parse() (pr *ParseResult, err error) {
 	// ParseConfig the query in the first pass by finding qualified files
 	pr = NewParseResult()
 	pr.RootDir = q.DataDir.GetPath()
+	pr.Mappings = q.DataDir.Mappings()
 	/*
 		Recurse the directory to produce the QualifiedFiles set
 	*/
 	getFileList(q.DataDir, &pr.QualifiedFiles, "", "")
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
 	if len(pr.QualifiedFiles) == 0 {
 		return pr, fmt.Errorf("no files returned from query parse")
 	}
@@ -4,25 +4,52 @@
 //
 // Background workers run under the marketstore server by implementing the
 // interface, started at the very beginning of the server lifecycle before the
-// query interface is started, but internal state shuold be fledged. The server
-// does not handle panics that happen within the plugin.  A plugin can recover
-// from panics, but be careful not to screw the server state if touching
-// internal API.  It is often better to just let it go.
+// query interface is started, but internal state shuold be fledged.  Run may
+// return once the worker starts its own goroutines, and such a worker is never
+// started again.  If Run panics, or RunContext of a StoppableBgWorker returns
+// before its context is canceled, the server loads a new instance of the worker
+// and starts it again after a backoff, which doubles for each restart in a row.
+// Panics in the other goroutines of the plugin are not handled by the server.  A plugin
+// can recover from panics, but be careful not to screw the server state if
+// touching internal API.  It is often better to just let it go.
 //
 // Configuration is as follows.
 //  bgworkers:
 //    - module: xxxWorker.so
 //      name: datafeed
 //      config: <according to the plulgin>
+//
+// The bgworkers are stopped and started when their settings in the config are
+// changed and the config is reloaded (SIGHUP or the ReloadConfig API).
+// A bgworker that only implements BgWorker can't be stopped, so it keeps
+// running until the server is restarted.  Implement StoppableBgWorker to
+// support the reload and the StopBgWorker and RestartBgWorker APIs.
+//
+// A bgworker can implement StatusReporter to report its state, the time of the
+// last write and its errors, which are returned by the ListBgWorkers API and
+// exported as the prometheus metrics.  Embedding StatusTracker is the easiest
+// way to implement it.
 package bgworker
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // BgWorker implements Run().  It will be running under a separate goroutine.
 type BgWorker interface {
 	Run()
 }
 
+// StoppableBgWorker is a BgWorker that can be stopped without restarting the server.
+// RunContext is called instead of Run under a separate goroutine. It must return
+// after the worker stops its work once ctx is canceled, and must not write any more
+// data after that, as another instance of the worker may be started with a new config.
+type StoppableBgWorker interface {
+	BgWorker
+	RunContext(ctx context.Context)
+}
+
 // SymbolLoader is an interface to retrieve symbol object from plugin.
 type SymbolLoader interface {
 	LoadSymbol(symbolName string) (interface{}, error)
@@ -0,0 +1,72 @@
+package bgworker
+
+import (
+	"sync"
+	"time"
+)
+
+// StatusReporter is a BgWorker that reports its status for the admin API and
+// the prometheus metrics.  Status is called from other goroutines while the
+// worker is running, so it must be safe for concurrent use.
+type StatusReporter interface {
+	BgWorker
+	Status() Status
+}
+
+// Status is the status reported by a bgworker.
+type Status struct {
+	// State is a free-form state of the worker, e.g. "connected" or "backfilling".
+	State string
+	// LastWrite is the time of the last successful write, or zero if nothing is written yet.
+	LastWrite time.Time
+	// Errors is the number of errors since the worker started.
+	Errors int64
+	// LastError is the message of the last error, or empty if there is no error.
+	LastError string
+}
+
+// StatusTracker records the status of a bgworker, and implements Status() of
+// StatusReporter.  A bgworker can embed it to report its status.
+//
+//	type Fetcher struct {
+//	  bgworker.StatusTracker
+//	  ...
+//	}
+//
+// The zero value is ready to use.
+type StatusTracker struct {
+	mu     sync.Mutex
+	status Status
+}
+
+// SetState sets the free-form state of the worker.
+func (t *StatusTracker) SetState(state string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.State = state
+}
+
+// RecordWrite records a successful write at the current time.
+func (t *StatusTracker) RecordWrite() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.LastWrite = time.Now()
+}
+
+// RecordError counts the error and keeps it as the last error.
+func (t *StatusTracker) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.Errors++
+	t.status.LastError = err.Error()
+}
+
+// Status returns the recorded status.
+func (t *StatusTracker) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
@@ -21,9 +21,23 @@ type MarketstoreClient interface {
 	Query(ctx context.Context, in *MultiQueryRequest, opts ...grpc.CallOption) (*MultiQueryResponse, error)
 	Create(ctx context.Context, in *MultiCreateRequest, opts ...grpc.CallOption) (*MultiServerResponse, error)
 	Write(ctx context.Context, in *MultiWriteRequest, opts ...grpc.CallOption) (*MultiServerResponse, error)
+	WriteStream(ctx context.Context, opts ...grpc.CallOption) (Marketstore_WriteStreamClient, error)
 	Destroy(ctx context.Context, in *MultiKeyRequest, opts ...grpc.CallOption) (*MultiServerResponse, error)
+	Delete(ctx context.Context, in *MultiDeleteRequest, opts ...grpc.CallOption) (*MultiDeleteResponse, error)
 	ListSymbols(ctx context.Context, in *ListSymbolsRequest, opts ...grpc.CallOption) (*ListSymbolsResponse, error)
 	ServerVersion(ctx context.Context, in *ServerVersionRequest, opts ...grpc.CallOption) (*ServerVersionResponse, error)
+	ImportCorporateActions(ctx context.Context, in *MultiCorporateActionRequest, opts ...grpc.CallOption) (*MultiServerResponse, error)
+	RenameSymbols(ctx context.Context, in *MultiRenameSymbolRequest, opts ...grpc.CallOption) (*MultiServerResponse, error)
+	SetSymbolAliases(ctx context.Context, in *MultiSymbolAliasRequest, opts ...grpc.CallOption) (*MultiServerResponse, error)
+	ListSymbolAliases(ctx context.Context, in *ListSymbolAliasesRequest, opts ...grpc.CallOption) (*ListSymbolAliasesResponse, error)
+	SetSymbolMetadata(ctx context.Context, in *MultiSymbolMetadataRequest, opts ...grpc.CallOption) (*MultiServerResponse, error)
+	GetSymbolMetadata(ctx context.Context, in *GetSymbolMetadataRequest, opts ...grpc.CallOption) (*GetSymbolMetadataResponse, error)
+	CatalogStats(ctx context.Context, in *CatalogStatsRequest, opts ...grpc.CallOption) (*CatalogStatsResponse, error)
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	ListBgWorkers(ctx context.Context, in *ListBgWorkersRequest, opts ...grpc.CallOption) (*ListBgWorkersResponse, error)
+	StartBgWorker(ctx context.Context, in *BgWorkerRequest, opts ...grpc.CallOption) (*BgWorkerResponse, error)
+	StopBgWorker(ctx context.Context, in *BgWorkerRequest, opts ...grpc.CallOption) (*BgWorkerResponse, error)
+	RestartBgWorker(ctx context.Context, in *BgWorkerRequest, opts ...grpc.CallOption) (*BgWorkerResponse, error)
 }
 
 type marketstoreClient struct {
@@ -61,6 +75,40 @@ func (c *marketstoreClient) Write(ctx context.Context, in *MultiWriteRequest, op
 	return out, nil
 }
 
+func (c *marketstoreClient) WriteStream(ctx context.Context, opts ...grpc.CallOption) (Marketstore_WriteStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Marketstore_ServiceDesc.Streams[0], "/proto.Marketstore/WriteStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &marketstoreWriteStreamClient{stream}
+	return x, nil
+}
+
+type Marketstore_WriteStreamClient interface {
+	Send(*WriteRequest) error
+	CloseAndRecv() (*WriteStreamResponse, error)
+	grpc.ClientStream
+}
+
+type marketstoreWriteStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *marketstoreWriteStreamClient) Send(m *WriteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *marketstoreWriteStreamClient) CloseAndRecv() (*WriteStreamResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *marketstoreClient) Destroy(ctx context.Context, in *MultiKeyRequest, opts ...grpc.CallOption) (*MultiServerResponse, error) {
 	out := new(MultiServerResponse)
 	err := c.cc.Invoke(ctx, "/proto.Marketstore/Destroy", in, out, opts...)
@@ -70,6 +118,15 @@ func (c *marketstoreClient) Destroy(ctx context.Context, in *MultiKeyRequest, op
 	return out, nil
 }
 
+func (c *marketstoreClient) Delete(ctx context.Context, in *MultiDeleteRequest, opts ...grpc.CallOption) (*MultiDeleteResponse, error) {
+	out := new(MultiDeleteResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *marketstoreClient) ListSymbols(ctx context.Context, in *ListSymbolsRequest, opts ...grpc.CallOption) (*ListSymbolsResponse, error) {
 	out := new(ListSymbolsResponse)
 	err := c.cc.Invoke(ctx, "/proto.Marketstore/ListSymbols", in, out, opts...)
@@ -88,6 +145,114 @@ func (c *marketstoreClient) ServerVersion(ctx context.Context, in *ServerVersion
 	return out, nil
 }
 
+func (c *marketstoreClient) ImportCorporateActions(ctx context.Context, in *MultiCorporateActionRequest, opts ...grpc.CallOption) (*MultiServerResponse, error) {
+	out := new(MultiServerResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/ImportCorporateActions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) RenameSymbols(ctx context.Context, in *MultiRenameSymbolRequest, opts ...grpc.CallOption) (*MultiServerResponse, error) {
+	out := new(MultiServerResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/RenameSymbols", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) SetSymbolAliases(ctx context.Context, in *MultiSymbolAliasRequest, opts ...grpc.CallOption) (*MultiServerResponse, error) {
+	out := new(MultiServerResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/SetSymbolAliases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) ListSymbolAliases(ctx context.Context, in *ListSymbolAliasesRequest, opts ...grpc.CallOption) (*ListSymbolAliasesResponse, error) {
+	out := new(ListSymbolAliasesResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/ListSymbolAliases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) SetSymbolMetadata(ctx context.Context, in *MultiSymbolMetadataRequest, opts ...grpc.CallOption) (*MultiServerResponse, error) {
+	out := new(MultiServerResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/SetSymbolMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) GetSymbolMetadata(ctx context.Context, in *GetSymbolMetadataRequest, opts ...grpc.CallOption) (*GetSymbolMetadataResponse, error) {
+	out := new(GetSymbolMetadataResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/GetSymbolMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) CatalogStats(ctx context.Context, in *CatalogStatsRequest, opts ...grpc.CallOption) (*CatalogStatsResponse, error) {
+	out := new(CatalogStatsResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/CatalogStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) ListBgWorkers(ctx context.Context, in *ListBgWorkersRequest, opts ...grpc.CallOption) (*ListBgWorkersResponse, error) {
+	out := new(ListBgWorkersResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/ListBgWorkers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) StartBgWorker(ctx context.Context, in *BgWorkerRequest, opts ...grpc.CallOption) (*BgWorkerResponse, error) {
+	out := new(BgWorkerResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/StartBgWorker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) StopBgWorker(ctx context.Context, in *BgWorkerRequest, opts ...grpc.CallOption) (*BgWorkerResponse, error) {
+	out := new(BgWorkerResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/StopBgWorker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketstoreClient) RestartBgWorker(ctx context.Context, in *BgWorkerRequest, opts ...grpc.CallOption) (*BgWorkerResponse, error) {
+	out := new(BgWorkerResponse)
+	err := c.cc.Invoke(ctx, "/proto.Marketstore/RestartBgWorker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MarketstoreServer is the server API for Marketstore service.
 // All implementations must embed UnimplementedMarketstoreServer
 // for forward compatibility
@@ -95,9 +260,23 @@ type MarketstoreServer interface {
 	Query(context.Context, *MultiQueryRequest) (*MultiQueryResponse, error)
 	Create(context.Context, *MultiCreateRequest) (*MultiServerResponse, error)
 	Write(context.Context, *MultiWriteRequest) (*MultiServerResponse, error)
+	WriteStream(Marketstore_WriteStreamServer) error
 	Destroy(context.Context, *MultiKeyRequest) (*MultiServerResponse, error)
+	Delete(context.Context, *MultiDeleteRequest) (*MultiDeleteResponse, error)
 	ListSymbols(context.Context, *ListSymbolsRequest) (*ListSymbolsResponse, error)
 	ServerVersion(context.Context, *ServerVersionRequest) (*ServerVersionResponse, error)
+	ImportCorporateActions(context.Context, *MultiCorporateActionRequest) (*MultiServerResponse, error)
+	RenameSymbols(context.Context, *MultiRenameSymbolRequest) (*MultiServerResponse, error)
+	SetSymbolAliases(context.Context, *MultiSymbolAliasRequest) (*MultiServerResponse, error)
+	ListSymbolAliases(context.Context, *ListSymbolAliasesRequest) (*ListSymbolAliasesResponse, error)
+	SetSymbolMetadata(context.Context, *MultiSymbolMetadataRequest) (*MultiServerResponse, error)
+	GetSymbolMetadata(context.Context, *GetSymbolMetadataRequest) (*GetSymbolMetadataResponse, error)
+	CatalogStats(context.Context, *CatalogStatsRequest) (*CatalogStatsResponse, error)
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	ListBgWorkers(context.Context, *ListBgWorkersRequest) (*ListBgWorkersResponse, error)
+	StartBgWorker(context.Context, *BgWorkerRequest) (*BgWorkerResponse, error)
+	StopBgWorker(context.Context, *BgWorkerRequest) (*BgWorkerResponse, error)
+	RestartBgWorker(context.Context, *BgWorkerRequest) (*BgWorkerResponse, error)
 	mustEmbedUnimplementedMarketstoreServer()
 }
 
@@ -114,15 +293,57 @@ func (UnimplementedMarketstoreServer) Create(context.Context, *MultiCreateReques
 func (UnimplementedMarketstoreServer) Write(context.Context, *MultiWriteRequest) (*MultiServerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Write not implemented")
 }
+func (UnimplementedMarketstoreServer) WriteStream(Marketstore_WriteStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteStream not implemented")
+}
 func (UnimplementedMarketstoreServer) Destroy(context.Context, *MultiKeyRequest) (*MultiServerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
 }
+func (UnimplementedMarketstoreServer) Delete(context.Context, *MultiDeleteRequest) (*MultiDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
 func (UnimplementedMarketstoreServer) ListSymbols(context.Context, *ListSymbolsRequest) (*ListSymbolsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSymbols not implemented")
 }
 func (UnimplementedMarketstoreServer) ServerVersion(context.Context, *ServerVersionRequest) (*ServerVersionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ServerVersion not implemented")
 }
+func (UnimplementedMarketstoreServer) ImportCorporateActions(context.Context, *MultiCorporateActionRequest) (*MultiServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportCorporateActions not implemented")
+}
+func (UnimplementedMarketstoreServer) RenameSymbols(context.Context, *MultiRenameSymbolRequest) (*MultiServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameSymbols not implemented")
+}
+func (UnimplementedMarketstoreServer) SetSymbolAliases(context.Context, *MultiSymbolAliasRequest) (*MultiServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSymbolAliases not implemented")
+}
+func (UnimplementedMarketstoreServer) ListSymbolAliases(context.Context, *ListSymbolAliasesRequest) (*ListSymbolAliasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSymbolAliases not implemented")
+}
+func (UnimplementedMarketstoreServer) SetSymbolMetadata(context.Context, *MultiSymbolMetadataRequest) (*MultiServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSymbolMetadata not implemented")
+}
+func (UnimplementedMarketstoreServer) GetSymbolMetadata(context.Context, *GetSymbolMetadataRequest) (*GetSymbolMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSymbolMetadata not implemented")
+}
+func (UnimplementedMarketstoreServer) CatalogStats(context.Context, *CatalogStatsRequest) (*CatalogStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CatalogStats not implemented")
+}
+func (UnimplementedMarketstoreServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedMarketstoreServer) ListBgWorkers(context.Context, *ListBgWorkersRequest) (*ListBgWorkersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBgWorkers not implemented")
+}
+func (UnimplementedMarketstoreServer) StartBgWorker(context.Context, *BgWorkerRequest) (*BgWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBgWorker not implemented")
+}
+func (UnimplementedMarketstoreServer) StopBgWorker(context.Context, *BgWorkerRequest) (*BgWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopBgWorker not implemented")
+}
+func (UnimplementedMarketstoreServer) RestartBgWorker(context.Context, *BgWorkerRequest) (*BgWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartBgWorker not implemented")
+}
 func (UnimplementedMarketstoreServer) mustEmbedUnimplementedMarketstoreServer() {}
 
 // UnsafeMarketstoreServer may be embedded to opt out of forward compatibility for this service.
@@ -190,6 +411,32 @@ func _Marketstore_Write_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Marketstore_WriteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MarketstoreServer).WriteStream(&marketstoreWriteStreamServer{stream})
+}
+
+type Marketstore_WriteStreamServer interface {
+	SendAndClose(*WriteStreamResponse) error
+	Recv() (*WriteRequest, error)
+	grpc.ServerStream
+}
+
+type marketstoreWriteStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *marketstoreWriteStreamServer) SendAndClose(m *WriteStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *marketstoreWriteStreamServer) Recv() (*WriteRequest, error) {
+	m := new(WriteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Marketstore_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MultiKeyRequest)
 	if err := dec(in); err != nil {
@@ -208,6 +455,24 @@ func _Marketstore_Destroy_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Marketstore_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).Delete(ctx, req.(*MultiDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Marketstore_ListSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSymbolsRequest)
 	if err := dec(in); err != nil {
@@ -244,6 +509,222 @@ func _Marketstore_ServerVersion_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Marketstore_ImportCorporateActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiCorporateActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).ImportCorporateActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/ImportCorporateActions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).ImportCorporateActions(ctx, req.(*MultiCorporateActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_RenameSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiRenameSymbolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).RenameSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/RenameSymbols",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).RenameSymbols(ctx, req.(*MultiRenameSymbolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_SetSymbolAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiSymbolAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).SetSymbolAliases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/SetSymbolAliases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).SetSymbolAliases(ctx, req.(*MultiSymbolAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_ListSymbolAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSymbolAliasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).ListSymbolAliases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/ListSymbolAliases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).ListSymbolAliases(ctx, req.(*ListSymbolAliasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_SetSymbolMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiSymbolMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).SetSymbolMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/SetSymbolMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).SetSymbolMetadata(ctx, req.(*MultiSymbolMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_GetSymbolMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSymbolMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).GetSymbolMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/GetSymbolMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).GetSymbolMetadata(ctx, req.(*GetSymbolMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_CatalogStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CatalogStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).CatalogStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/CatalogStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).CatalogStats(ctx, req.(*CatalogStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_ListBgWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBgWorkersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).ListBgWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/ListBgWorkers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).ListBgWorkers(ctx, req.(*ListBgWorkersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_StartBgWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BgWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).StartBgWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/StartBgWorker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).StartBgWorker(ctx, req.(*BgWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_StopBgWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BgWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).StopBgWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/StopBgWorker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).StopBgWorker(ctx, req.(*BgWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Marketstore_RestartBgWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BgWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketstoreServer).RestartBgWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Marketstore/RestartBgWorker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketstoreServer).RestartBgWorker(ctx, req.(*BgWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Marketstore_ServiceDesc is the grpc.ServiceDesc for Marketstore service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +748,10 @@ var Marketstore_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Destroy",
 			Handler:    _Marketstore_Destroy_Handler,
 		},
+		{
+			MethodName: "Delete",
+			Handler:    _Marketstore_Delete_Handler,
+		},
 		{
 			MethodName: "ListSymbols",
 			Handler:    _Marketstore_ListSymbols_Handler,
@@ -275,7 +760,61 @@ var Marketstore_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ServerVersion",
 			Handler:    _Marketstore_ServerVersion_Handler,
 		},
+		{
+			MethodName: "ImportCorporateActions",
+			Handler:    _Marketstore_ImportCorporateActions_Handler,
+		},
+		{
+			MethodName: "RenameSymbols",
+			Handler:    _Marketstore_RenameSymbols_Handler,
+		},
+		{
+			MethodName: "SetSymbolAliases",
+			Handler:    _Marketstore_SetSymbolAliases_Handler,
+		},
+		{
+			MethodName: "ListSymbolAliases",
+			Handler:    _Marketstore_ListSymbolAliases_Handler,
+		},
+		{
+			MethodName: "SetSymbolMetadata",
+			Handler:    _Marketstore_SetSymbolMetadata_Handler,
+		},
+		{
+			MethodName: "GetSymbolMetadata",
+			Handler:    _Marketstore_GetSymbolMetadata_Handler,
+		},
+		{
+			MethodName: "CatalogStats",
+			Handler:    _Marketstore_CatalogStats_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _Marketstore_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "ListBgWorkers",
+			Handler:    _Marketstore_ListBgWorkers_Handler,
+		},
+		{
+			MethodName: "StartBgWorker",
+			Handler:    _Marketstore_StartBgWorker_Handler,
+		},
+		{
+			MethodName: "StopBgWorker",
+			Handler:    _Marketstore_StopBgWorker_Handler,
+		},
+		{
+			MethodName: "RestartBgWorker",
+			Handler:    _Marketstore_RestartBgWorker_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WriteStream",
+			Handler:       _Marketstore_WriteStream_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "marketstore.proto",
 }
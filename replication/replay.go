@@ -17,8 +17,14 @@ type ReplayerImpl struct {
 	// parseTGFunc is a function to parse Transaction Group byte array to writeTransactionSet.
 	// wal.ParseTGData is always used, but abstracted for testability
 	parseTGFunc func(tgSerialized []byte, rootPath string) (tgID int64, wtSets []wal.WTSet)
-	// WriteFunc is a function to write CSM to marketstore.
+	// writeFunc is a function to queue CSM to the WAL of marketstore without flushing it.
 	writeFunc func(csm io.ColumnSeriesMap, isVariableLength bool) (err error)
+	// writeDeletionFunc is a function to queue a deletion (see wal.OffsetIndexBuffer.IsDeletion)
+	// to the WAL of marketstore without flushing it.
+	writeDeletionFunc func(wtSet *wal.WTSet) error
+	// flushFunc is a function to flush the queued writes of a transaction group in one transaction group,
+	// so that e.g. the deletion and the write of a replace are applied atomically as on the master.
+	flushFunc func()
 	// rootDir is the path to the directory in which Marketstore database resides(e.g. "data")
 	rootDir string
 }
@@ -26,12 +32,16 @@ type ReplayerImpl struct {
 func NewReplayer(
 	parseTGFunc func(tgSerialized []byte, rootPath string) (TGID int64, wtSets []wal.WTSet),
 	writeFunc func(csm io.ColumnSeriesMap, isVariableLength bool) (err error),
+	writeDeletionFunc func(wtSet *wal.WTSet) error,
+	flushFunc func(),
 	rootDir string,
 ) *ReplayerImpl {
 	return &ReplayerImpl{
-		parseTGFunc: parseTGFunc,
-		writeFunc:   writeFunc,
-		rootDir:     rootDir,
+		parseTGFunc:       parseTGFunc,
+		writeFunc:         writeFunc,
+		writeDeletionFunc: writeDeletionFunc,
+		flushFunc:         flushFunc,
+		rootDir:           rootDir,
 	}
 }
 
@@ -48,6 +58,13 @@ func (r *ReplayerImpl) Replay(transactionGroup []byte) error {
 
 	for _, wtSet := range wtsets {
 		wtSet := wtSet
+		if wtSet.Buffer.IsDeletion() {
+			if err := r.writeDeletionFunc(&wtSet); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to queue deletion. filePath:%v", wtSet.FilePath))
+			}
+			continue
+		}
+
 		csm, err := WTSetToCSM(&wtSet)
 		if err != nil {
 			return errors.Wrap(err, "failed to convert WTSet to CSM")
@@ -55,9 +72,11 @@ func (r *ReplayerImpl) Replay(transactionGroup []byte) error {
 
 		err = r.writeFunc(csm, wtsets[0].RecordType == io.VARIABLE)
 		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed to WriteCSM. csm:%v", csm))
+			return errors.Wrap(err, fmt.Sprintf("failed to queue CSM. csm:%v", csm))
 		}
 	}
+	// the writes of the transaction group are flushed together
+	r.flushFunc()
 	log.Debug("[replica] successfully replayed the WAL message")
 	return nil
 }
@@ -145,23 +164,25 @@ func serializeVariableRecords(epoch time.Time, intervalsPerDay uint32, wtSet *wa
 	// 1 record size = 8byte(Epoch) + columns + intervalTicks(4byte) = 8byte(Epoch) + VariableLengthRecord
 	cursor := 0
 	for i := 0; i < numRows; i++ {
-		// serialize Epoch (variable length records in a WTSet have the same Epoch value)
-		buf, err = io.Serialize(buf[:cursor], epoch.Unix())
+		record := payload[i*varRecLen : (i+1)*varRecLen]
+		// last 4 byte of each record is an intervalTick
+		intervalTicks := io.ToUInt32(record[len(record)-IntervalTicksBytes:])
+		// expand intervalTicks(32bit) to Epoch and Nanosecond
+		sec, nanosecond := executor.GetTimeFromTicks(uint64(epoch.Unix()), intervalsPerDay, intervalTicks)
+
+		// serialize Epoch of the record (variable length records in a WTSet are in the same interval)
+		buf, err = io.Serialize(buf[:cursor], int64(sec))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to serialize Epoch to buffer:"+epoch.String())
 		}
 		cursor += EpochBytes
 
 		// append the payload (= columns + intervalTicks) for a record
-		buf, err = io.Serialize(buf[:cursor], payload[i*varRecLen:(i+1)*varRecLen])
+		buf, err = io.Serialize(buf[:cursor], record)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to serialize Payload to buffer:"+epoch.String())
 		}
 
-		// last 4 byte of each record is an intervalTick
-		intervalTicks := io.ToUInt32(buf[len(buf)-IntervalTicksBytes:])
-		// expand intervalTicks(32bit) to Epoch and Nanosecond
-		_, nanosecond := executor.GetTimeFromTicks(uint64(epoch.Unix()), intervalsPerDay, intervalTicks)
 		// replace intervalTick with Nanosecond
 		buf, err = io.Serialize(buf[:len(buf)-IntervalTicksBytes], int32(nanosecond))
 		if err != nil {
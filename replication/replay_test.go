@@ -1,14 +1,21 @@
 package replication_test
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/executor"
 	"github.com/alpacahq/marketstore/v4/executor/wal"
+	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/planner"
 	"github.com/alpacahq/marketstore/v4/replication"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/io"
@@ -85,6 +92,7 @@ func TestReplayerImpl_Replay(t *testing.T) {
 		writeErr             bool
 		wantCSM              io.ColumnSeriesMap
 		wantIsVariableLength bool
+		wantDeletion         string
 		wantErr              bool
 	}{
 		{
@@ -116,6 +124,28 @@ func TestReplayerImpl_Replay(t *testing.T) {
 			wantIsVariableLength: false,
 			wantErr:              false,
 		},
+		{
+			name: "success/deletion",
+			wtSets: []wal.WTSet{
+				{
+					RecordType: io.FIXED,
+					FilePath:   "/data/AMZN/1Min/OHLC/2020.bin",
+					DataLen:    32,
+					Buffer:     makeMockOffsetIndexBuffer(offset, make([]byte, 8), make([]byte, 32)),
+					DataShapes: []io.DataShape{
+						{Name: "Epoch", Type: io.INT64},
+						{Name: "Open", Type: io.INT64},
+						{Name: "High", Type: io.INT64},
+						{Name: "Low", Type: io.INT64},
+						{Name: "Close", Type: io.INT64},
+					},
+				},
+			},
+			writeErr:     false,
+			wantCSM:      nil,
+			wantDeletion: "/data/AMZN/1Min/OHLC/2020.bin",
+			wantErr:      false,
+		},
 		{
 			name: "success/Variable Length record",
 			wtSets: []wal.WTSet{
@@ -145,6 +175,49 @@ func TestReplayerImpl_Replay(t *testing.T) {
 			wantIsVariableLength: true,
 			wantErr:              false,
 		},
+		{
+			name: "success/replace is replayed as the deletion followed by the write",
+			wtSets: []wal.WTSet{
+				{
+					RecordType: io.VARIABLE,
+					FilePath:   "/data/AMZN/1Sec/OHLC/2020.bin",
+					VarRecLen:  32 + 4,
+					Buffer:     makeMockOffsetIndexBuffer(offset, make([]byte, 8), nil),
+					DataShapes: []io.DataShape{
+						{Name: "Epoch", Type: io.INT64},
+						{Name: "Open", Type: io.INT64},
+						{Name: "High", Type: io.INT64},
+						{Name: "Low", Type: io.INT64},
+						{Name: "Close", Type: io.INT64},
+					},
+				},
+				{
+					RecordType: io.VARIABLE,
+					FilePath:   "/data/AMZN/1Sec/OHLC/2020.bin",
+					DataLen:    24,
+					VarRecLen:  32 + 4,
+					Buffer: makeMockOffsetIndexBufferVariable(
+						variableRecordDate, utils.TimeframeFromDuration(1*time.Second), buffer32,
+					),
+					DataShapes: []io.DataShape{
+						{Name: "Epoch", Type: io.INT64},
+						{Name: "Open", Type: io.INT64},
+						{Name: "High", Type: io.INT64},
+						{Name: "Low", Type: io.INT64},
+						{Name: "Close", Type: io.INT64},
+					},
+				},
+			},
+			writeErr: false,
+			wantCSM: io.ColumnSeriesMap(
+				map[io.TimeBucketKey]*io.ColumnSeries{
+					*io.NewTimeBucketKey("AMZN/1Sec/OHLC"): makeMockOHLCColumnSeries(variableRecordDate, 1, 2, 3, 4),
+				},
+			),
+			wantIsVariableLength: true,
+			wantDeletion:         "/data/AMZN/1Sec/OHLC/2020.bin",
+			wantErr:              false,
+		},
 		{
 			name:                 "empty WTset parsed/no error is returned",
 			wtSets:               []wal.WTSet{},
@@ -224,7 +297,11 @@ func TestReplayerImpl_Replay(t *testing.T) {
 
 			// --- given ---
 			// mock function to assert if expected parameters are passed to the writeCSM function
+			var flushes int
 			writeFunc := func(csm io.ColumnSeriesMap, isVariableLength bool) (err error) {
+				if flushes > 0 {
+					t.Errorf("CSM is queued after the flush")
+				}
 				if tt.writeErr {
 					return errors.New("some error")
 				}
@@ -246,7 +323,18 @@ func TestReplayerImpl_Replay(t *testing.T) {
 				return 1, tt.wtSets
 			}
 
-			r := replication.NewReplayer(parseTGFunc, writeFunc, "/file/path")
+			// mock function to assert if the deletion is passed to the writeDeletion function
+			var deletion string
+			writeDeletionFunc := func(wtSet *wal.WTSet) error {
+				if flushes > 0 {
+					t.Errorf("deletion is queued after the flush")
+				}
+				deletion = wtSet.FilePath
+				return nil
+			}
+			flushFunc := func() { flushes++ }
+
+			r := replication.NewReplayer(parseTGFunc, writeFunc, writeDeletionFunc, flushFunc, "/file/path")
 
 			// --- when ---
 			err := r.Replay(nil)
@@ -255,6 +343,91 @@ func TestReplayerImpl_Replay(t *testing.T) {
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Replay() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if deletion != tt.wantDeletion {
+				t.Errorf("Replayed deletion: want=%v, got=%v", tt.wantDeletion, deletion)
+			}
+			// the writes of the transaction group are flushed once
+			wantFlushes := 0
+			if !tt.wantErr && len(tt.wtSets) > 0 {
+				wantFlushes = 1
+			}
+			if flushes != wantFlushes {
+				t.Errorf("Replayed flushes: want=%v, got=%v", wantFlushes, flushes)
+			}
 		})
 	}
 }
+
+type fakeReplicationSender struct {
+	sent [][]byte
+}
+
+func (s *fakeReplicationSender) Run(_ context.Context) {}
+func (s *fakeReplicationSender) Send(transactionGroup []byte) {
+	s.sent = append(s.sent, transactionGroup)
+}
+
+func TestReplayerImpl_Replay_DeleteRange(t *testing.T) {
+	t.Parallel()
+
+	// --- given ---
+	newInstance := func() (rootDir string, writer *executor.Writer, sender *fakeReplicationSender) {
+		rootDir = t.TempDir()
+		cfg := utils.NewDefaultConfig(rootDir)
+		cfg.BackgroundSync = false
+		c := di.NewContainer(cfg)
+		sender = &fakeReplicationSender{}
+		c.GetInitWALFile().ReplicationSender = sender
+		return rootDir, c.GetDefaultWriter(), sender
+	}
+	_, master, masterSender := newInstance()
+	replicaDir, replica, replicaSender := newInstance()
+	queueCSM := func(csm io.ColumnSeriesMap, isVariableLength bool) error {
+		_, err := replica.WriteCSMWithOptions(csm, isVariableLength, executor.WriteOptions{QueueOnly: true})
+		return err
+	}
+	r := replication.NewReplayer(executor.ParseTGData, queueCSM, replica.QueueDeletion, replica.WaitFlush,
+		replicaDir)
+
+	tbk := io.NewTimeBucketKey("TEST/1Min/TICK")
+	epoch := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{epoch.Unix(), epoch.Unix() + 10, epoch.Unix() + 20})
+	cs.AddColumn("Nanoseconds", []int32{0, 0, 0})
+	cs.AddColumn("Bid", []float32{1, 2, 3})
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	require.Nil(t, master.WriteCSM(csm, true))
+
+	// --- when ---
+	// the record in the middle of the interval is deleted, and the rest of the interval is replaced
+	deleted, err := master.DeleteRange(tbk, epoch.Add(5*time.Second), epoch.Add(15*time.Second))
+	require.Nil(t, err)
+	require.Equal(t, 1, deleted)
+	for _, tg := range masterSender.sent {
+		require.Nil(t, r.Replay(tg))
+	}
+
+	// --- then ---
+	// each transaction group of the master is replayed as one transaction group,
+	// and the replace is the deletion followed by the write of the kept records
+	require.Len(t, masterSender.sent, 2)
+	require.Len(t, replicaSender.sent, 2)
+	_, wtSets := executor.ParseTGData(replicaSender.sent[1], replicaDir)
+	require.Len(t, wtSets, 2)
+	assert.True(t, wtSets[0].Buffer.IsDeletion())
+	assert.False(t, wtSets[1].Buffer.IsDeletion())
+
+	catDir, err := catalog.NewDirectory(replicaDir)
+	require.Nil(t, err)
+	q := planner.NewQuery(catDir)
+	q.AddTargetKey(tbk)
+	pr, err := q.Parse()
+	require.Nil(t, err)
+	rd, err := executor.NewReader(pr)
+	require.Nil(t, err)
+	got, err := rd.Read()
+	require.Nil(t, err)
+	assert.Equal(t, []int64{epoch.Unix(), epoch.Unix() + 20}, got[*tbk].GetEpoch())
+	assert.Equal(t, []float32{1, 3}, got[*tbk].GetColumn("Bid"))
+}
@@ -1,6 +1,7 @@
 package sqlparser_test
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -122,7 +123,7 @@ func TestExecutableStatement(t *testing.T) {
 	// PrintExplain(queryTree, stmt)
 	es, err := sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err := es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err := es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 29)
 
@@ -131,7 +132,7 @@ func TestExecutableStatement(t *testing.T) {
 	evalAndPrint(t, err, false, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 29)
 
@@ -141,7 +142,7 @@ func TestExecutableStatement(t *testing.T) {
 	evalAndPrint(t, err, false, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 0)
 	assert.Nil(t, err)
@@ -153,7 +154,7 @@ func TestExecutableStatement(t *testing.T) {
 	// PrintExplain(queryTree, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 0)
 	assert.Nil(t, err)
@@ -165,7 +166,7 @@ func TestExecutableStatement(t *testing.T) {
 	// PrintExplain(queryTree, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 29)
 	assert.Nil(t, err)
@@ -176,7 +177,7 @@ func TestExecutableStatement(t *testing.T) {
 	T_PrintExplain(queryTree, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 1)
 	assert.Nil(t, err)
@@ -187,7 +188,7 @@ func TestExecutableStatement(t *testing.T) {
 	T_PrintExplain(queryTree, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Nil(t, err)
 	assert.Equal(t, cs.Len(), 0)
@@ -202,7 +203,7 @@ func TestStatementErrors(t *testing.T) {
 	evalAndPrint(t, err, false, stmt)
 	es, err := sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err := es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err := es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, true, stmt)
 	_ = cs
 }
@@ -217,7 +218,7 @@ func TestInsertInto(t *testing.T) {
 	// PrintExplain(queryTree, stmt)
 	es, err := sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err := es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err := es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 1)
 }
@@ -273,7 +274,7 @@ func TestAggregation(t *testing.T) {
 	T_PrintExplain(queryTree, stmt)
 	es, err := sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 29)
 
@@ -283,7 +284,7 @@ func TestAggregation(t *testing.T) {
 	T_PrintExplain(queryTree, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	assert.Equal(t, cs.Len(), 6)
 	// fmt.Println(cs)
@@ -312,7 +313,7 @@ func TestCount(t *testing.T) {
 	T_PrintExplain(queryTree, stmt)
 	es, err := sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	count, ok = cs.GetColumn("Count").([]int64)
 	assert.True(t, ok)
@@ -327,7 +328,7 @@ func TestCount(t *testing.T) {
 	T_PrintExplain(queryTree, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	count, ok = cs.GetColumn("Count").([]int64)
 	assert.True(t, ok)
@@ -339,7 +340,7 @@ func TestCount(t *testing.T) {
 	T_PrintExplain(queryTree, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	cs, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	cs, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, false, stmt)
 	count, ok = cs.GetColumn("Count").([]int64)
 	assert.True(t, ok)
@@ -353,7 +354,7 @@ func TestCount(t *testing.T) {
 	evalAndPrint(t, err, false, stmt)
 	es, err = sqlparser.NewExecutableStatement(queryTree)
 	evalAndPrint(t, err, false, stmt)
-	_, err = es.Materialize(aggRunner, metadata.CatalogDir)
+	_, err = es.Materialize(aggRunner, metadata.CatalogDir, nil)
 	evalAndPrint(t, err, true, stmt)
 }
 
@@ -385,7 +386,7 @@ var testStatements = []TestStmt{
 	//{n: 16, stmt: "SHOW SCHEMAS;", expectErr: false},
 	//{n: 17, stmt: "SHOW SCHEMAS FROM foo;", expectErr: false},
 	//{n: 18, stmt: "SHOW SCHEMAS IN foo LIKE '%';", expectErr: false},
-	//{n: 19, stmt: "SHOW TABLES;", expectErr: false},
+	{n: 19, stmt: "SHOW TABLES;", expectErr: false},
 	//{n: 20, stmt: "SHOW TABLES FROM a;", expectErr: false},
 	//{n: 21, stmt: "SHOW TABLES IN a LIKE '%';", expectErr: false},
 	//{n: 22, stmt: "SHOW PARTITIONS FROM t;", expectErr: false},
@@ -432,12 +433,12 @@ var testStatements = []TestStmt{
 	//	{n: 52, stmt: "CREATE TABLE foo " +
 	//		"WITH ( string = 'bar', long = 42, computed = 'ban' || 'ana', a  = ARRAY[ 'v1', 'v2' ] ) " +
 	//		"AS SELECT * FROM t WITH NO DATA;", expectErr: true},
-	//{n: 53, stmt: "DROP TABLE a;", expectErr: false},
-	//{n: 54, stmt: "DROP TABLE a.b;", expectErr: false},
-	//{n: 55, stmt: "DROP TABLE a.b.c;", expectErr: false},
-	//{n: 56, stmt: "DROP TABLE IF EXISTS a;", expectErr: false},
-	//{n: 57, stmt: "DROP TABLE IF EXISTS a.b;", expectErr: false},
-	//{n: 58, stmt: "DROP TABLE IF EXISTS a.b.c;", expectErr: false},
+	{n: 53, stmt: "DROP TABLE a;", expectErr: false},
+	{n: 54, stmt: "DROP TABLE a.b;", expectErr: false},
+	{n: 55, stmt: "DROP TABLE a.b.c;", expectErr: false},
+	{n: 56, stmt: "DROP TABLE IF EXISTS a;", expectErr: false},
+	{n: 57, stmt: "DROP TABLE IF EXISTS a.b;", expectErr: false},
+	{n: 58, stmt: "DROP TABLE IF EXISTS a.b.c;", expectErr: false},
 	//{n: 59, stmt: "DROP VIEW a;", expectErr: false},
 	//{n: 60, stmt: "DROP VIEW a.b;", expectErr: false},
 	//{n: 61, stmt: "DROP VIEW a.b.c;", expectErr: false},
@@ -446,8 +447,8 @@ var testStatements = []TestStmt{
 	//{n: 64, stmt: "DROP VIEW IF EXISTS a.b.c;", expectErr: false},
 	{n: 65, stmt: "INSERT INTO a SELECT * FROM t;", expectErr: false},
 	{n: 66, stmt: "INSERT INTO a (c1, c2) SELECT * FROM t;", expectErr: false},
-	//{n: 67, stmt: "DELETE FROM t;", expectErr: false},
-	//{n: 68, stmt: "DELETE FROM t WHERE a = b;", expectErr: false},
+	{n: 67, stmt: "DELETE FROM t;", expectErr: false},
+	{n: 68, stmt: "DELETE FROM t WHERE a = b;", expectErr: false},
 	//{n: 69, stmt: "ALTER TABLE a RENAME TO b;", expectErr: false},
 	//{n: 70, stmt: "ALTER TABLE foo.t RENAME COLUMN a TO b;", expectErr: false},
 	//{n: 71, stmt: "ALTER TABLE foo.t ADD COLUMN c bigint;", expectErr: false},
@@ -593,3 +594,385 @@ func makeTestCS() (csA *io.ColumnSeries) {
 	csA.AddColumn("Five", col5)
 	return csA
 }
+
+func TestDecimal(t *testing.T) {
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	aggRunner := sqlparser.NewDefaultAggRunner(c.GetCatalogDir())
+
+	// 3 ticks in 2 minutes
+	tbk := io.NewTimeBucketKey("DEC/1Min/TICK")
+	base := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{base, base + 30, base + 60})
+	cs.AddColumn("Price", []io.Decimal64{1001, 1003, 1002}) // 0.1001, 0.1003, 0.1002
+	cs.SetScale("Price", 4)
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	assert.Nil(t, c.GetWriter().WriteCSM(csm, true))
+
+	materialize := func(stmt string) *io.ColumnSeries {
+		t.Helper()
+		queryTree, err := sqlparser.BuildQueryTree(stmt)
+		evalAndPrint(t, err, false, stmt)
+		es, err := sqlparser.NewExecutableStatement(queryTree)
+		evalAndPrint(t, err, false, stmt)
+		cs, err := es.Materialize(aggRunner, c.GetCatalogDir(), c.GetWriter())
+		evalAndPrint(t, err, false, stmt)
+		return cs
+	}
+
+	// predicates are evaluated with the scale of the column
+	cs = materialize("SELECT Epoch, Price from `DEC/1Min/TICK` WHERE Price = 0.1003;")
+	assert.Equal(t, []io.Decimal64{1003}, cs.GetColumn("Price"))
+	cs = materialize("SELECT Epoch, Price from `DEC/1Min/TICK` WHERE Price >= 0.1002;")
+	assert.Equal(t, []io.Decimal64{1003, 1002}, cs.GetColumn("Price"))
+	cs = materialize("SELECT Epoch, Price from `DEC/1Min/TICK` WHERE Price < 0.1002;")
+	assert.Equal(t, []io.Decimal64{1001}, cs.GetColumn("Price"))
+
+	// candles are made in decimal
+	cs = materialize("SELECT TickCandler('1Min', Price) from `DEC/1Min/TICK`;")
+	assert.Equal(t, []io.Decimal64{1001, 1002}, cs.GetColumn("Open"))
+	assert.Equal(t, []io.Decimal64{1003, 1002}, cs.GetColumn("High"))
+	assert.Equal(t, []io.Decimal64{1001, 1002}, cs.GetColumn("Low"))
+	assert.Equal(t, []io.Decimal64{1003, 1002}, cs.GetColumn("Close"))
+	assert.Equal(t, int8(4), cs.GetScale("Close"))
+}
+
+func TestNullable(t *testing.T) {
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	aggRunner := sqlparser.NewDefaultAggRunner(c.GetCatalogDir())
+
+	tbk := io.NewTimeBucketKey("NULL/1Min/OHLCV")
+	base := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{base, base + 60, base + 120})
+	cs.AddColumn("Volume", []int64{0, 0, 10})
+	cs.SetNullMask("Volume", []bool{false, true, false})
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	assert.Nil(t, c.GetWriter().WriteCSM(csm, false))
+
+	materialize := func(stmt string) *io.ColumnSeries {
+		t.Helper()
+		queryTree, err := sqlparser.BuildQueryTree(stmt)
+		evalAndPrint(t, err, false, stmt)
+		es, err := sqlparser.NewExecutableStatement(queryTree)
+		evalAndPrint(t, err, false, stmt)
+		cs, err := es.Materialize(aggRunner, c.GetCatalogDir(), c.GetWriter())
+		evalAndPrint(t, err, false, stmt)
+		return cs
+	}
+
+	cs = materialize("SELECT Epoch, Volume from `NULL/1Min/OHLCV` WHERE Volume IS NULL;")
+	assert.Equal(t, []int64{base + 60}, cs.GetColumn("Epoch"))
+	assert.Equal(t, []bool{true}, cs.GetNullMask("Volume"))
+	cs = materialize("SELECT Epoch, Volume from `NULL/1Min/OHLCV` WHERE Volume IS NOT NULL;")
+	assert.Equal(t, []int64{base, base + 120}, cs.GetColumn("Epoch"))
+	assert.Equal(t, []bool{false, false}, cs.GetNullMask("Volume"))
+
+	// a null value doesn't match a comparison
+	cs = materialize("SELECT Epoch, Volume from `NULL/1Min/OHLCV` WHERE Volume = 0;")
+	assert.Equal(t, []int64{base}, cs.GetColumn("Epoch"))
+	cs = materialize("SELECT Epoch, Volume from `NULL/1Min/OHLCV` WHERE Volume < 100;")
+	assert.Equal(t, []int64{base, base + 120}, cs.GetColumn("Epoch"))
+
+	// the null masks are kept without predicates
+	cs = materialize("SELECT * from `NULL/1Min/OHLCV`;")
+	assert.Equal(t, []bool{false, true, false}, cs.GetNullMask("Volume"))
+	assert.False(t, cs.Exists(io.NullMaskColumn))
+}
+
+func TestExpressions(t *testing.T) {
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	aggRunner := sqlparser.NewDefaultAggRunner(c.GetCatalogDir())
+
+	tbk := io.NewTimeBucketKey("EXPR/1Min/OHLCV")
+	base := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{base, base + 60, base + 3600})
+	cs.AddColumn("Open", []float32{10, 20, 30})
+	cs.AddColumn("High", []float32{12, 24, 36})
+	cs.AddColumn("Low", []float32{8, 18, 27})
+	cs.AddColumn("Close", []float32{11, 19, 33})
+	cs.AddColumn("Volume", []int64{100, 200, 300})
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	assert.Nil(t, c.GetWriter().WriteCSM(csm, false))
+
+	materialize := func(stmt string, shouldErr bool) *io.ColumnSeries {
+		t.Helper()
+		queryTree, err := sqlparser.BuildQueryTree(stmt)
+		evalAndPrint(t, err, false, stmt)
+		es, err := sqlparser.NewExecutableStatement(queryTree)
+		evalAndPrint(t, err, false, stmt)
+		cs, err := es.Materialize(aggRunner, c.GetCatalogDir(), c.GetWriter())
+		evalAndPrint(t, err, shouldErr, stmt)
+		return cs
+	}
+
+	// computed columns follow the select list, and a literal takes the type of the column
+	cs = materialize("SELECT Epoch, (High+Low)/2 AS Mid, CAST(Volume AS float64) * Close AS Notional "+
+		"FROM `EXPR/1Min/OHLCV`;", false)
+	assert.Equal(t, []string{"Epoch", "Mid", "Notional"}, cs.GetColumnNames())
+	assert.Equal(t, []float32{10, 21, 31.5}, cs.GetColumn("Mid"))
+	assert.Equal(t, []float64{1100, 3800, 9900}, cs.GetColumn("Notional"))
+
+	// integer arithmetic stays in integers unless a float is involved
+	cs = materialize("SELECT Epoch, Volume / 3 AS Third, Volume % 7 AS Mod, -Volume AS Neg, Volume * 1.5 AS F "+
+		"FROM `EXPR/1Min/OHLCV`;", false)
+	assert.Equal(t, []int64{33, 66, 100}, cs.GetColumn("Third"))
+	assert.Equal(t, []int64{2, 4, 6}, cs.GetColumn("Mod"))
+	assert.Equal(t, []int64{-100, -200, -300}, cs.GetColumn("Neg"))
+	assert.Equal(t, []float64{150, 300, 450}, cs.GetColumn("F"))
+
+	// an integer division by zero is null
+	cs = materialize("SELECT Epoch, Volume / (Volume - 200) AS D FROM `EXPR/1Min/OHLCV`;", false)
+	assert.Equal(t, []int64{-1, 0, 3}, cs.GetColumn("D"))
+	assert.Equal(t, []bool{false, true, false}, cs.GetNullMask("D"))
+
+	// CASE WHEN and EXTRACT
+	cs = materialize("SELECT Epoch, CASE WHEN Close > Open THEN 1 WHEN Close < Open THEN -1 ELSE 0 END AS Direction, "+
+		"EXTRACT(hour FROM Epoch) AS H, EXTRACT(MINUTE FROM Epoch) AS M FROM `EXPR/1Min/OHLCV`;", false)
+	assert.Equal(t, []int64{1, -1, 1}, cs.GetColumn("Direction"))
+	assert.Equal(t, []int64{0, 0, 1}, cs.GetColumn("H"))
+	assert.Equal(t, []int64{0, 1, 0}, cs.GetColumn("M"))
+
+	// a computed column without an alias is named by its position
+	cs = materialize("SELECT Epoch, Close * 2 FROM `EXPR/1Min/OHLCV`;", false)
+	assert.Equal(t, []float32{22, 38, 66}, cs.GetColumn("_col1"))
+
+	// expressions in WHERE clauses, with static predicates
+	cs = materialize("SELECT Epoch, Close FROM `EXPR/1Min/OHLCV` WHERE Close > Open;", false)
+	assert.Equal(t, []int64{base, base + 3600}, cs.GetColumn("Epoch"))
+	cs = materialize("SELECT Epoch, Close FROM `EXPR/1Min/OHLCV` WHERE (High + Low) / 2 > 15 AND Volume < 300;", false)
+	assert.Equal(t, []int64{base + 60}, cs.GetColumn("Epoch"))
+	cs = materialize("SELECT Epoch, Close FROM `EXPR/1Min/OHLCV` WHERE EXTRACT(hour FROM Epoch) = 1;", false)
+	assert.Equal(t, []int64{base + 3600}, cs.GetColumn("Epoch"))
+
+	// errors
+	materialize("SELECT Epoch, Close + Missing AS X FROM `EXPR/1Min/OHLCV`;", true)
+	materialize("SELECT Epoch, Close FROM `EXPR/1Min/OHLCV` WHERE Close + Missing > 0;", true)
+}
+
+func TestWindowFunctions(t *testing.T) {
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	aggRunner := sqlparser.NewDefaultAggRunner(c.GetCatalogDir())
+
+	tbk := io.NewTimeBucketKey("WIN/1Min/OHLCV")
+	base := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{base, base + 60, base + 120, base + 180, base + 600})
+	cs.AddColumn("Close", []float32{10, 11, 13, 12, 15})
+	cs.AddColumn("Volume", []int64{100, 200, 300, 400, 500})
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*tbk, cs)
+	assert.Nil(t, c.GetWriter().WriteCSM(csm, false))
+
+	materialize := func(stmt string, shouldErr bool) *io.ColumnSeries {
+		t.Helper()
+		queryTree, err := sqlparser.BuildQueryTree(stmt)
+		evalAndPrint(t, err, false, stmt)
+		es, err := sqlparser.NewExecutableStatement(queryTree)
+		if shouldErr && err != nil {
+			return nil
+		}
+		evalAndPrint(t, err, false, stmt)
+		cs, err := es.Materialize(aggRunner, c.GetCatalogDir(), c.GetWriter())
+		evalAndPrint(t, err, shouldErr, stmt)
+		return cs
+	}
+
+	// LAG and LEAD keep the type of the value, and are null out of the rows
+	cs = materialize("SELECT Epoch, LAG(Close, 1) OVER (ORDER BY Epoch) AS Prev, LEAD(Close) OVER (ORDER BY Epoch) AS Next, "+
+		"Close / LAG(Close) OVER (ORDER BY Epoch) - 1 AS Ret FROM `WIN/1Min/OHLCV`;", false)
+	assert.Equal(t, []float32{0, 10, 11, 13, 12}, cs.GetColumn("Prev"))
+	assert.Equal(t, []bool{true, false, false, false, false}, cs.GetNullMask("Prev"))
+	assert.Equal(t, []float32{11, 13, 12, 15, 0}, cs.GetColumn("Next"))
+	assert.Equal(t, []bool{false, false, false, false, true}, cs.GetNullMask("Next"))
+	assert.Equal(t, []bool{true, false, false, false, false}, cs.GetNullMask("Ret"))
+	assert.InDelta(t, 0.1, cs.GetColumn("Ret").([]float32)[1], 1e-6)
+
+	// rolling and cumulative aggregates
+	cs = materialize("SELECT Epoch, AVG(Close) OVER (ROWS 1 PRECEDING) AS MA2, "+
+		"SUM(Volume) OVER (ORDER BY Epoch) AS CumVol, MAX(Close) OVER () AS Top, "+
+		"MIN(Close) OVER (ORDER BY Epoch ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS Bottom "+
+		"FROM `WIN/1Min/OHLCV`;", false)
+	assert.Equal(t, []float64{10, 10.5, 12, 12.5, 13.5}, cs.GetColumn("MA2"))
+	assert.Equal(t, []float64{100, 300, 600, 1000, 1500}, cs.GetColumn("CumVol"))
+	assert.Equal(t, []float64{15, 15, 15, 15, 15}, cs.GetColumn("Top"))
+	assert.Equal(t, []float64{10, 10, 10, 10, 10}, cs.GetColumn("Bottom"))
+
+	// a RANGE frame is a time range over Epoch
+	cs = materialize("SELECT Epoch, SUM(Volume) OVER (ORDER BY Epoch RANGE INTERVAL '2' MINUTE PRECEDING) AS V, "+
+		"SUM(Volume) OVER (RANGE BETWEEN 60 PRECEDING AND CURRENT ROW) AS V1 FROM `WIN/1Min/OHLCV`;", false)
+	assert.Equal(t, []float64{100, 300, 600, 900, 500}, cs.GetColumn("V"))
+	assert.Equal(t, []float64{100, 300, 500, 700, 500}, cs.GetColumn("V1"))
+
+	// the sample standard deviation needs two values
+	cs = materialize("SELECT Epoch, STDDEV(Close) OVER (ROWS 2 PRECEDING) AS Vol FROM `WIN/1Min/OHLCV`;", false)
+	assert.Equal(t, []bool{true, false, false, false, false}, cs.GetNullMask("Vol"))
+	assert.InDelta(t, 1.0, cs.GetColumn("Vol").([]float64)[3], 1e-9)
+
+	// the same operations as functions
+	cs = materialize("SELECT rolling_mean('Close', 2) FROM `WIN/1Min/OHLCV`;", false)
+	assert.Equal(t, []float64{10, 10.5, 12, 12.5, 13.5}, cs.GetColumn("Close_rolling_mean"))
+	ctx := context.Background()
+	cs, err := aggRunner.Run(ctx, []string{"rolling_mean('Close',2)", "lag('Close_rolling_mean', 1, 'Prev')", "cumsum(Volume)"},
+		materialize("SELECT * FROM `WIN/1Min/OHLCV`;", false), *tbk)
+	assert.NotNil(t, err) // Volume must be quoted
+	cs, err = aggRunner.Run(ctx, []string{"rolling_mean('Close',2)", "lag('Close_rolling_mean', 1, 'Prev')", "cumsum('Volume')"},
+		materialize("SELECT * FROM `WIN/1Min/OHLCV`;", false), *tbk)
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{0, 10, 10.5, 12, 12.5}, cs.GetColumn("Prev"))
+	assert.Equal(t, []bool{true, false, false, false, false}, cs.GetNullMask("Prev"))
+	assert.Equal(t, []float64{100, 300, 600, 1000, 1500}, cs.GetColumn("Volume_cumsum"))
+	assert.Equal(t, []float32{10, 11, 13, 12, 15}, cs.GetColumn("Close"))
+
+	// the indicators can be chained, e.g. the Bollinger bands of the EMA
+	cs, err = aggRunner.Run(ctx, []string{"ema(2)", "bollinger(2, 1, EMA)", "rsi(2)"},
+		materialize("SELECT * FROM `WIN/1Min/OHLCV`;", false), *tbk)
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{0, 10.5, 12.166666666666666, 12.055555555555555, 14.018518518518519}, cs.GetColumn("EMA"))
+	assert.Equal(t, []float64{0, 0, 11.333333333333332, 12.11111111111111, 13.037037037037038}, cs.GetColumn("BBMiddle"))
+	assert.Equal(t, []bool{true, true, false, false, false}, cs.GetNullMask("RSI"))
+
+	// errors
+	materialize("SELECT Epoch, LAG(Close) OVER (ORDER BY Close) AS X FROM `WIN/1Min/OHLCV`;", true)
+	materialize("SELECT Epoch, LAG(Close) OVER (PARTITION BY Volume) AS X FROM `WIN/1Min/OHLCV`;", true)
+	materialize("SELECT Epoch, LAG(Close, Volume) OVER () AS X FROM `WIN/1Min/OHLCV`;", true)
+	materialize("SELECT Epoch, SUM(Close) OVER (ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING) AS X FROM `WIN/1Min/OHLCV`;", true)
+	materialize("SELECT Epoch, MEDIAN(Close) OVER () AS X FROM `WIN/1Min/OHLCV`;", true)
+	materialize("SELECT Epoch, SUM(Close) OVER (RANGE INTERVAL '1' MONTH PRECEDING) AS X FROM `WIN/1Min/OHLCV`;", true)
+}
+
+func TestDDL(t *testing.T) {
+	cfg := utils.NewDefaultConfig(t.TempDir())
+	cfg.BackgroundSync = false
+	c := di.NewContainer(cfg)
+	aggRunner := sqlparser.NewDefaultAggRunner(c.GetCatalogDir())
+
+	materialize := func(stmt string, shouldErr bool) *io.ColumnSeries {
+		t.Helper()
+		queryTree, err := sqlparser.BuildQueryTree(stmt)
+		evalAndPrint(t, err, false, stmt)
+		es, err := sqlparser.NewExecutableStatement(queryTree)
+		if shouldErr && err != nil {
+			return nil
+		}
+		evalAndPrint(t, err, false, stmt)
+		cs, err := es.Materialize(aggRunner, c.GetCatalogDir(), c.GetWriter())
+		evalAndPrint(t, err, shouldErr, stmt)
+		return cs
+	}
+	string16 := func(s string) (ret [16]rune) {
+		copy(ret[:], []rune(s))
+		return ret
+	}
+
+	// --- CREATE TABLE ---
+	cs := materialize("CREATE TABLE `DDL/1Min/OHLCV` (Open float32, Close d8[2], Volume int64?);", false)
+	assert.Equal(t, []int64{1}, cs.GetColumn("Tables Created"))
+	cs = materialize("CREATE TABLE IF NOT EXISTS `DDL/1Min/OHLCV` (Open float32);", false)
+	assert.Equal(t, []int64{0}, cs.GetColumn("Tables Created"))
+	materialize("CREATE TABLE `DDL/1Min/OHLCV` (Open float32);", true)
+	materialize("CREATE TABLE `DDL/1Min/BAD` (Open foo);", true)
+	cs = materialize("CREATE TABLE `DDL/1Sec/TICK` (Price float64) WITH (record_type = 'variable');", false)
+	assert.Equal(t, []int64{1}, cs.GetColumn("Tables Created"))
+
+	tbi, err := c.GetCatalogDir().GetLatestTimeBucketInfoFromKey(io.NewTimeBucketKey("DDL/1Min/OHLCV"))
+	assert.Nil(t, err)
+	assert.Equal(t, io.FIXED, tbi.GetRecordType())
+	assert.Equal(t, []io.DataShape{
+		{Name: "Epoch", Type: io.INT64},
+		{Name: "Open", Type: io.FLOAT32},
+		{Name: "Close", Type: io.DECIMAL64, Scale: 2},
+		{Name: "Volume", Type: io.INT64, Nullable: true},
+	}, tbi.GetDataShapesWithEpoch()[:4])
+	tbi, err = c.GetCatalogDir().GetLatestTimeBucketInfoFromKey(io.NewTimeBucketKey("DDL/1Sec/TICK"))
+	assert.Nil(t, err)
+	assert.Equal(t, io.VARIABLE, tbi.GetRecordType())
+
+	// --- SHOW TABLES / SHOW COLUMNS ---
+	cs = materialize("SHOW TABLES;", false)
+	assert.Equal(t, [][16]rune{string16("DDL"), string16("DDL")}, cs.GetColumn("Symbol"))
+	assert.Equal(t, [][16]rune{string16("1Min"), string16("1Sec")}, cs.GetColumn("Timeframe"))
+	assert.Equal(t, [][16]rune{string16("fixed"), string16("variable")}, cs.GetColumn("RecordType"))
+	cs = materialize("SHOW TABLES LIKE '%/TICK';", false)
+	assert.Equal(t, [][16]rune{string16("TICK")}, cs.GetColumn("AttributeGroup"))
+	cs = materialize("SHOW COLUMNS FROM `DDL/1Min/OHLCV`;", false)
+	assert.Equal(t, [][16]rune{string16("Epoch"), string16("Open"), string16("Close"), string16("Volume")},
+		cs.GetColumn("Column"))
+	assert.Equal(t, [][16]rune{string16("i8"), string16("f4"), string16("d8[2]"), string16("i8?")},
+		cs.GetColumn("Type"))
+	cs = materialize("DESCRIBE `DDL/1Sec/TICK`;", false)
+	assert.Equal(t, [][16]rune{string16("Epoch"), string16("Price")}, cs.GetColumn("Column"))
+
+	// --- DELETE ---
+	base := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC).Unix()
+	data := io.NewColumnSeries()
+	data.AddColumn("Epoch", []int64{base, base + 60, base + 120, base + 180})
+	data.AddColumn("Open", []float32{1, 2, 3, 4})
+	data.AddColumn("Close", []io.Decimal64{100, 200, 300, 400})
+	data.SetScale("Close", 2)
+	data.AddColumn("Volume", []int64{10, 20, 30, 40})
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*io.NewTimeBucketKey("DDL/1Min/OHLCV"), data)
+	assert.Nil(t, c.GetWriter().WriteCSM(csm, false))
+
+	materialize("DELETE FROM `DDL/1Min/OHLCV` WHERE Open > 1;", true)
+	cs = materialize("DELETE FROM `DDL/1Min/OHLCV` WHERE Epoch > '2021-12-01-00:00' AND Epoch <= '2021-12-01-00:02';",
+		false)
+	assert.Equal(t, []int64{2}, cs.GetColumn("Rows Deleted"))
+	cs = materialize("SELECT * FROM `DDL/1Min/OHLCV`;", false)
+	assert.Equal(t, []int64{base, base + 180}, cs.GetColumn("Epoch"))
+	cs = materialize("DELETE FROM `DDL/1Min/OHLCV`;", false)
+	assert.Equal(t, []int64{2}, cs.GetColumn("Rows Deleted"))
+	cs = materialize("SELECT * FROM `DDL/1Min/OHLCV`;", false)
+	assert.Equal(t, 0, cs.Len())
+
+	// --- DROP TABLE ---
+	cs = materialize("DROP TABLE `DDL/1Min/OHLCV`;", false)
+	assert.Equal(t, []int64{1}, cs.GetColumn("Tables Dropped"))
+	cs = materialize("DROP TABLE IF EXISTS `DDL/1Min/OHLCV`;", false)
+	assert.Equal(t, []int64{0}, cs.GetColumn("Tables Dropped"))
+	materialize("DROP TABLE `DDL/1Min/OHLCV`;", true)
+	cs = materialize("SHOW TABLES;", false)
+	assert.Equal(t, [][16]rune{string16("1Sec")}, cs.GetColumn("Timeframe"))
+}
+
+func TestDDLParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stmt string
+	}{
+		"ng/ missing semicolon":          {stmt: "DROP TABLE a"},
+		"ng/ missing column type":        {stmt: "CREATE TABLE a (b);"},
+		"ng/ unclosed column list":       {stmt: "CREATE TABLE a (b float32;"},
+		"ng/ unsupported CREATE":         {stmt: "CREATE VIEW a AS SELECT * FROM t;"},
+		"ng/ missing FROM":               {stmt: "SHOW COLUMNS a;"},
+		"ng/ unterminated quote":         {stmt: "DROP TABLE `a;"},
+		"ng/ invalid DELETE predicate":   {stmt: "DELETE FROM t WHERE;"},
+		"ng/ extraneous input":           {stmt: "SHOW TABLES; foo"},
+		"ng/ non-string LIKE pattern":    {stmt: "SHOW TABLES LIKE a;"},
+		"ng/ missing table name":         {stmt: "DESCRIBE ;"},
+		"ng/ DELETE without FROM clause": {stmt: "DELETE t;"},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := sqlparser.BuildQueryTree(tt.stmt)
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package sqlparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+type CreateTableStatement struct {
+	ExecutableStatement
+	QueryText  string
+	TableName  string
+	DataShapes []io.DataShape
+	RecordType io.EnumRecordType
+	// IfNotExists is true when the table is created with "IF NOT EXISTS".
+	IfNotExists bool
+}
+
+func NewCreateTableStatement(tableName, queryText string, dsv []io.DataShape, recordType io.EnumRecordType,
+) (ct *CreateTableStatement) {
+	ct = new(CreateTableStatement)
+	ct.QueryText = queryText
+	ct.TableName = tableName
+	ct.DataShapes = dsv
+	ct.RecordType = recordType
+	return ct
+}
+
+func (ct *CreateTableStatement) Materialize(catDir *catalog.Directory) (outputColumnSeries *io.ColumnSeries, err error) {
+	tbk := io.NewTimeBucketKey(ct.TableName)
+	if tbk == nil {
+		return nil, fmt.Errorf("table name must be in the format `one/two/three`, have: %s",
+			ct.TableName)
+	}
+
+	if _, err = catDir.GetLatestTimeBucketInfoFromKey(tbk); err == nil {
+		if ct.IfNotExists {
+			return newStatementResult("Tables Created", 0), nil
+		}
+		return nil, fmt.Errorf("table %s already exists", ct.TableName)
+	}
+
+	tf, err := tbk.GetTimeFrame()
+	if err != nil {
+		return nil, err
+	}
+	year := int16(time.Now().Year())
+	tbinfo := io.NewTimeBucketInfo(*tf, tbk.GetPathToYearFiles(catDir.GetPath()), "Default", year,
+		ct.DataShapes, ct.RecordType)
+	if err = catDir.AddTimeBucket(tbk, tbinfo); err != nil {
+		return nil, fmt.Errorf("creation of new catalog entry failed: %w", err)
+	}
+
+	return newStatementResult("Tables Created", 1), nil
+}
+
+func (ct *CreateTableStatement) Explain() string {
+	if ct != nil {
+		jsonStruct, _ := json.Marshal(*ct)
+		return string(jsonStruct)
+	} else {
+		return "{}"
+	}
+}
+
+// newTableDataShapes returns the data shapes of the column definitions of CREATE TABLE.
+// The type names are the same as the ones of the \create command (e.g. "float32", "decimal64[2]", "int64?"),
+// and the numpy type strings (e.g. "f4", "d8[2]", "i8?") are also accepted.
+func newTableDataShapes(tableElements []IMSTree) (dsv []io.DataShape, err error) {
+	for _, element := range tableElements {
+		te, ok := element.(*TableElementParse)
+		if !ok {
+			return nil, fmt.Errorf("unexpected table element: %v", element)
+		}
+		if shapes, err2 := io.DataShapesFromInputString(te.name + "/" + te.typeName); err2 == nil {
+			dsv = append(dsv, shapes...)
+			continue
+		}
+		shape, ok := io.NewDataShapeFromTypeStr(te.name, te.typeName)
+		if !ok {
+			return nil, fmt.Errorf("unexpected data type of column %s: %s", te.name, te.typeName)
+		}
+		dsv = append(dsv, shape)
+	}
+	return dsv, nil
+}
+
+// newTableRecordType returns the record type in the table properties of CREATE TABLE,
+// e.g. WITH (record_type = 'variable'). The default is a fixed length record.
+func newTableRecordType(tableProperties IMSTree) (recordType io.EnumRecordType, err error) {
+	recordType = io.FIXED
+	if tableProperties == nil {
+		return recordType, nil
+	}
+	for _, child := range tableProperties.GetChildren() {
+		property, ok := child.(*TablePropertyParse)
+		if !ok {
+			return io.NOTYPE, fmt.Errorf("unexpected table property: %v", child)
+		}
+		switch property.name {
+		case "record_type":
+			if recordType = io.EnumRecordTypeByName(property.value); recordType == io.NOTYPE {
+				return io.NOTYPE, fmt.Errorf("record_type must be 'fixed' or 'variable', have: %s", property.value)
+			}
+		default:
+			return io.NOTYPE, fmt.Errorf("unsupported table property: %s", property.name)
+		}
+	}
+	return recordType, nil
+}
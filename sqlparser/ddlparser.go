@@ -0,0 +1,357 @@
+package sqlparser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+/*
+The DDL statements are commented out in the ANTLR grammar (see parser/SQLBase.g4),
+so they are parsed here into the same StatementParse terms as the other statements:
+
+	CREATE TABLE (IF NOT EXISTS)? qualifiedName
+		'(' identifier type (',' identifier type)* ')'
+		(WITH '(' identifier EQ value (',' identifier EQ value)* ')')?
+	DROP TABLE (IF EXISTS)? qualifiedName
+	DELETE FROM qualifiedName (WHERE booleanExpression)?
+	SHOW TABLES (LIKE pattern=STRING)?
+	SHOW COLUMNS (FROM | IN) qualifiedName
+	(DESCRIBE | DESC) qualifiedName
+
+The WHERE clause of DELETE is parsed by the ANTLR grammar as a SELECT query on the table.
+*/
+
+var ddlKeywords = map[string]struct{}{
+	"CREATE": {}, "DROP": {}, "DELETE": {}, "SHOW": {}, "DESCRIBE": {}, "DESC": {},
+}
+
+// isDDLStatement returns true if the statement is one of the DDL statements parsed by the DDL parser.
+func isDDLStatement(sourceString string) bool {
+	tokens, err := scanDDLTokens(sourceString)
+	if err != nil || len(tokens) == 0 || tokens[0].typ != ddlWord {
+		return false
+	}
+	_, ok := ddlKeywords[strings.ToUpper(tokens[0].text)]
+	return ok
+}
+
+// buildDDLTree returns the query tree of a DDL statement.
+func buildDDLTree(sourceString string) (tree IMSTree, err error) {
+	tokens, err := scanDDLTokens(sourceString)
+	if err != nil {
+		return nil, err
+	}
+	p := &ddlParser{source: sourceString, tokens: tokens}
+	statement, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+	statements := new(StatementsParse)
+	statements.QueryText = sourceString
+	statements.AddChild(statement)
+	return statements, nil
+}
+
+type ddlTokenType int
+
+const (
+	ddlWord             ddlTokenType = iota // a keyword, an unquoted identifier or a type name
+	ddlQuotedIdentifier                     // `identifier`
+	ddlString                               // 'string'
+	ddlSymbol                               // ( ) , . = ;
+	ddlEOF
+)
+
+type ddlToken struct {
+	typ  ddlTokenType
+	text string
+	pos  int // byte offset in the statement
+}
+
+const ddlSymbols = "(),.=;"
+
+func scanDDLTokens(source string) (tokens []ddlToken, err error) {
+	i := 0
+	for i < len(source) {
+		c := rune(source[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.HasPrefix(source[i:], "--"):
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case strings.ContainsRune(ddlSymbols, c):
+			tokens = append(tokens, ddlToken{typ: ddlSymbol, text: string(c), pos: i})
+			i++
+		case c == '`' || c == '\'':
+			end := i + 1
+			for end < len(source) && rune(source[end]) != c {
+				end++
+			}
+			if end == len(source) {
+				return nil, syntaxError(source, i, fmt.Sprintf("unterminated quote %c", c))
+			}
+			typ := ddlQuotedIdentifier
+			if c == '\'' {
+				typ = ddlString
+			}
+			tokens = append(tokens, ddlToken{typ: typ, text: source[i+1 : end], pos: i})
+			i = end + 1
+		default:
+			end := i
+			for end < len(source) && !unicode.IsSpace(rune(source[end])) &&
+				!strings.ContainsRune(ddlSymbols+"`'", rune(source[end])) {
+				end++
+			}
+			tokens = append(tokens, ddlToken{typ: ddlWord, text: source[i:end], pos: i})
+			i = end
+		}
+	}
+	return append(tokens, ddlToken{typ: ddlEOF, pos: len(source)}), nil
+}
+
+// syntaxError returns an error in the same format as the ANTLR parser errors.
+func syntaxError(source string, pos int, msg string) error {
+	line := strings.Count(source[:pos], "\n") + 1
+	column := pos - (strings.LastIndex(source[:pos], "\n") + 1)
+	return fmt.Errorf("syntax Error[%d:%d]: %s", line, column, msg)
+}
+
+type ddlParser struct {
+	source string
+	tokens []ddlToken
+	pos    int
+}
+
+func (p *ddlParser) peek() ddlToken {
+	return p.tokens[p.pos]
+}
+
+func (p *ddlParser) next() ddlToken {
+	tok := p.tokens[p.pos]
+	if tok.typ != ddlEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *ddlParser) errorf(tok ddlToken, format string, args ...interface{}) error {
+	return syntaxError(p.source, tok.pos, fmt.Sprintf(format, args...))
+}
+
+// accept consumes the next token if it's one of the keywords or symbols.
+func (p *ddlParser) accept(texts ...string) bool {
+	tok := p.peek()
+	if tok.typ != ddlWord && tok.typ != ddlSymbol {
+		return false
+	}
+	for _, text := range texts {
+		if strings.EqualFold(tok.text, text) {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ddlParser) expect(texts ...string) error {
+	if !p.accept(texts...) {
+		tok := p.peek()
+		return p.errorf(tok, "mismatched input '%s' expecting %s", tok.text, strings.Join(texts, " or "))
+	}
+	return nil
+}
+
+func (p *ddlParser) parseStatement() (term *StatementParse, err error) {
+	term = new(StatementParse)
+	term.QueryText = p.source
+	tok := p.next()
+	switch strings.ToUpper(tok.text) {
+	case "CREATE":
+		err = p.parseCreateTable(term)
+	case "DROP":
+		err = p.parseDropTable(term)
+	case "DELETE":
+		err = p.parseDelete(term)
+	case "SHOW":
+		err = p.parseShow(term)
+	case "DESCRIBE", "DESC":
+		term.statementType = SHOW_COLUMNS_STMT
+		term.tableName, err = p.parseQualifiedName()
+	default:
+		err = p.errorf(tok, "unsupported statement '%s'", tok.text)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if term.statementType != DELETE_STMT {
+		if err = p.expect(";"); err != nil {
+			return nil, err
+		}
+	}
+	if tok = p.peek(); tok.typ != ddlEOF {
+		return nil, p.errorf(tok, "extraneous input '%s'", tok.text)
+	}
+	return term, nil
+}
+
+func (p *ddlParser) parseCreateTable(term *StatementParse) (err error) {
+	term.statementType = CREATE_TABLE_STMT
+	if err = p.expect("TABLE"); err != nil {
+		return err
+	}
+	if p.accept("IF") {
+		if err = p.expect("NOT"); err != nil {
+			return err
+		}
+		if err = p.expect("EXISTS"); err != nil {
+			return err
+		}
+		term.IsExists = true
+	}
+	if term.tableName, err = p.parseQualifiedName(); err != nil {
+		return err
+	}
+
+	if err = p.expect("("); err != nil {
+		return err
+	}
+	for {
+		name, err2 := p.parseIdentifier()
+		if err2 != nil {
+			return err2
+		}
+		typeName := p.next()
+		if typeName.typ != ddlWord {
+			return p.errorf(typeName, "missing type name of column '%s'", name)
+		}
+		term.tableElements = append(term.tableElements, NewTableElementParse(name, typeName.text))
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err = p.expect(")"); err != nil {
+		return err
+	}
+
+	if p.accept("WITH") {
+		var properties []*TablePropertyParse
+		if err = p.expect("("); err != nil {
+			return err
+		}
+		for {
+			name, err2 := p.parseIdentifier()
+			if err2 != nil {
+				return err2
+			}
+			if err = p.expect("="); err != nil {
+				return err
+			}
+			value := p.next()
+			if value.typ != ddlWord && value.typ != ddlString {
+				return p.errorf(value, "missing value of table property '%s'", name)
+			}
+			properties = append(properties, NewTablePropertyParse(name, value.text))
+			if !p.accept(",") {
+				break
+			}
+		}
+		if err = p.expect(")"); err != nil {
+			return err
+		}
+		term.tableProperties = NewTablePropertiesParse(properties...)
+	}
+	return nil
+}
+
+func (p *ddlParser) parseDropTable(term *StatementParse) (err error) {
+	term.statementType = DROP_TABLE_STMT
+	if err = p.expect("TABLE"); err != nil {
+		return err
+	}
+	if p.accept("IF") {
+		if err = p.expect("EXISTS"); err != nil {
+			return err
+		}
+		term.IsExists = true
+	}
+	term.tableName, err = p.parseQualifiedName()
+	return err
+}
+
+func (p *ddlParser) parseDelete(term *StatementParse) (err error) {
+	term.statementType = DELETE_STMT
+	if err = p.expect("FROM"); err != nil {
+		return err
+	}
+	nameStart := p.peek().pos
+	if term.tableName, err = p.parseQualifiedName(); err != nil {
+		return err
+	}
+	nameEnd := p.peek().pos
+
+	// The rest of the statement is parsed as a query on the table, so that the WHERE clause
+	// is evaluated in the same way as the one of a SELECT statement
+	rest := p.peek()
+	if rest.typ != ddlEOF && !strings.EqualFold(rest.text, "WHERE") && rest.text != ";" {
+		return p.errorf(rest, "mismatched input '%s' expecting WHERE", rest.text)
+	}
+	queryText := "SELECT * FROM " + p.source[nameStart:nameEnd] + " " + p.source[rest.pos:]
+	tree, err := BuildQueryTree(queryText)
+	if err != nil {
+		return err
+	}
+	//nolint:forcetypeassert // BuildQueryTree always returns a statement
+	query := tree.GetChild(0).(*StatementParse)
+	term.query = query.query
+	p.pos = len(p.tokens) - 1
+	return nil
+}
+
+func (p *ddlParser) parseShow(term *StatementParse) (err error) {
+	tok := p.next()
+	switch strings.ToUpper(tok.text) {
+	case "TABLES":
+		term.statementType = SHOW_TABLES_STMT
+		if p.accept("LIKE") {
+			pattern := p.next()
+			if pattern.typ != ddlString {
+				return p.errorf(pattern, "mismatched input '%s' expecting STRING", pattern.text)
+			}
+			term.pattern = pattern.text
+		}
+	case "COLUMNS":
+		term.statementType = SHOW_COLUMNS_STMT
+		if err = p.expect("FROM", "IN"); err != nil {
+			return err
+		}
+		term.tableName, err = p.parseQualifiedName()
+	default:
+		return p.errorf(tok, "mismatched input '%s' expecting TABLES or COLUMNS", tok.text)
+	}
+	return err
+}
+
+func (p *ddlParser) parseQualifiedName() (term *QualifiedNameParse, err error) {
+	term = new(QualifiedNameParse)
+	for {
+		name, err2 := p.parseIdentifier()
+		if err2 != nil {
+			return nil, err2
+		}
+		term.AddChild(&IDParse{name: name})
+		if !p.accept(".") {
+			return term, nil
+		}
+	}
+}
+
+func (p *ddlParser) parseIdentifier() (string, error) {
+	tok := p.next()
+	if (tok.typ != ddlWord && tok.typ != ddlQuotedIdentifier) || tok.text == "" {
+		return "", p.errorf(tok, "mismatched input '%s' expecting identifier", tok.text)
+	}
+	return tok.text, nil
+}
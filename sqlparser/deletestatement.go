@@ -0,0 +1,160 @@
+package sqlparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/planner"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// RangeDeleter deletes the records of a time bucket in a time range, e.g. executor.Writer.
+type RangeDeleter interface {
+	DeleteRange(tbk *io.TimeBucketKey, start, end time.Time) (deleted int, err error)
+}
+
+type DeleteStatement struct {
+	ExecutableStatement
+	// SelectRelation is the query of the records to delete, i.e. SELECT * FROM table WHERE ...
+	SelectRelation *SelectRelation
+	QueryText      string
+	TableName      string
+}
+
+func NewDeleteStatement(tableName, queryText string, selectRelation *SelectRelation) (ds *DeleteStatement) {
+	ds = new(DeleteStatement)
+	ds.QueryText = queryText
+	ds.TableName = tableName
+	ds.SelectRelation = selectRelation
+	return ds
+}
+
+// Materialize deletes the records in the Epoch range of the WHERE clause, and returns the number of deleted rows.
+// Only the predicates on Epoch are supported, and the records are deleted by the time intervals of the bucket,
+// so the range is narrowed to the intervals that are entirely in it.
+// The records are deleted through the WAL by the deleter, so that the deletion is replicated
+// and seen by the flush listeners, e.g. the query cache.
+func (ds *DeleteStatement) Materialize(catDir *catalog.Directory, deleter RangeDeleter,
+) (outputColumnSeries *io.ColumnSeries, err error) {
+	tbk := io.NewTimeBucketKey(ds.TableName)
+	if tbk == nil {
+		return nil, fmt.Errorf("table name must be in the format `one/two/three`, have: %s",
+			ds.TableName)
+	}
+	tbi, err := catDir.GetLatestTimeBucketInfoFromKey(tbk)
+	if err != nil {
+		return nil, fmt.Errorf("table %s does not exist", ds.TableName)
+	}
+
+	if ds.SelectRelation.WherePredicate != nil {
+		return nil, fmt.Errorf("only predicates on Epoch are supported in DELETE")
+	}
+	for name, sp := range ds.SelectRelation.StaticPredicates {
+		if name != "Epoch" {
+			return nil, fmt.Errorf("only predicates on Epoch are supported in DELETE, have: %s", name)
+		}
+		if sp.IsFalse() {
+			return newStatementResult("Rows Deleted", 0), nil
+		}
+	}
+
+	start, end, err := ds.deleteRange(tbi.GetTimeframe(), tbi.GetRecordType() == io.VARIABLE)
+	if err != nil {
+		return nil, err
+	}
+	if start.After(end) {
+		return newStatementResult("Rows Deleted", 0), nil
+	}
+
+	if deleter == nil {
+		return nil, fmt.Errorf("DELETE is not supported without a writer")
+	}
+	rowsDeleted, err := deleter.DeleteRange(tbk, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStatementResult("Rows Deleted", int64(rowsDeleted)), nil
+}
+
+// deleteRange returns the time range to delete from the Epoch predicates.
+// A record of a fixed length bucket is at the beginning of its interval, and the records of a variable length bucket
+// are anywhere in the interval, so an interval is deleted only when all of it is in the range.
+func (ds *DeleteStatement) deleteRange(timeframe time.Duration, isVariableLength bool,
+) (start, end time.Time, err error) {
+	dateRange := planner.NewDateRange()
+	start, end = dateRange.Start, dateRange.End
+
+	sp, ok := ds.SelectRelation.StaticPredicates["Epoch"]
+	if !ok {
+		return start, end, nil
+	}
+
+	bound := func(value interface{}) (int64, error) {
+		val, err2 := io.GetValueAsInt64(value)
+		if err2 != nil {
+			return 0, fmt.Errorf("non date predicate found for Epoch")
+		}
+		return convertUnitToNanosec(val), nil
+	}
+	tf := timeframe.Nanoseconds()
+
+	var minNanos, maxNanos int64
+	hasMin, hasMax := sp.ContentsEnum.IsSet(MINBOUND), sp.ContentsEnum.IsSet(MAXBOUND)
+	if sp.ContentsEnum.IsSet(EQUALITY) {
+		if minNanos, err = bound(sp.equal); err != nil {
+			return start, end, err
+		}
+		maxNanos = minNanos
+		hasMin, hasMax = true, true
+	} else {
+		if hasMin {
+			if minNanos, err = bound(sp.min); err != nil {
+				return start, end, err
+			}
+			if !sp.ContentsEnum.IsSet(INCLUSIVEMIN) {
+				minNanos++
+			}
+		}
+		if hasMax {
+			if maxNanos, err = bound(sp.max); err != nil {
+				return start, end, err
+			}
+			if !sp.ContentsEnum.IsSet(INCLUSIVEMAX) {
+				maxNanos--
+			}
+		}
+	}
+
+	if hasMin {
+		// the beginning of the first interval in the range
+		start = time.Unix(0, floorToInterval(minNanos+tf-1, tf))
+	}
+	if hasMax {
+		if isVariableLength {
+			// the end of the last interval in the range
+			maxNanos = floorToInterval(maxNanos+1, tf) - 1
+		}
+		end = time.Unix(0, maxNanos)
+	}
+	return start, end, nil
+}
+
+func floorToInterval(nanos, interval int64) int64 {
+	mod := nanos % interval
+	if mod < 0 {
+		mod += interval
+	}
+	return nanos - mod
+}
+
+func (ds *DeleteStatement) Explain() string {
+	if ds != nil {
+		jsonStruct, _ := json.Marshal(*ds)
+		return string(jsonStruct)
+	} else {
+		return "{}"
+	}
+}
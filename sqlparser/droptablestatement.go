@@ -0,0 +1,54 @@
+package sqlparser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+type DropTableStatement struct {
+	ExecutableStatement
+	QueryText string
+	TableName string
+	// IfExists is true when the table is dropped with "IF EXISTS".
+	IfExists bool
+}
+
+func NewDropTableStatement(tableName, queryText string) (ds *DropTableStatement) {
+	ds = new(DropTableStatement)
+	ds.QueryText = queryText
+	ds.TableName = tableName
+	return ds
+}
+
+func (ds *DropTableStatement) Materialize(catDir *catalog.Directory) (outputColumnSeries *io.ColumnSeries, err error) {
+	tbk := io.NewTimeBucketKey(ds.TableName)
+	if tbk == nil {
+		return nil, fmt.Errorf("table name must be in the format `one/two/three`, have: %s",
+			ds.TableName)
+	}
+
+	if _, err = catDir.GetLatestTimeBucketInfoFromKey(tbk); err != nil {
+		if ds.IfExists {
+			return newStatementResult("Tables Dropped", 0), nil
+		}
+		return nil, fmt.Errorf("table %s does not exist", ds.TableName)
+	}
+
+	if err = catDir.RemoveTimeBucket(tbk); err != nil {
+		return nil, fmt.Errorf("removal of catalog entry failed: %w", err)
+	}
+
+	return newStatementResult("Tables Dropped", 1), nil
+}
+
+func (ds *DropTableStatement) Explain() string {
+	if ds != nil {
+		jsonStruct, _ := json.Marshal(*ds)
+		return string(jsonStruct)
+	} else {
+		return "{}"
+	}
+}
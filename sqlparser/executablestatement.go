@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/uda/window"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
 
@@ -38,7 +41,9 @@ func (es *ExecutableStatement) GetPendingStaticPredicateGroup() (spg StaticPredi
 	}
 }
 
-func (es *ExecutableStatement) Materialize(aggRunner *AggRunner, catDir *catalog.Directory,
+// Materialize executes the statement. The deleter executes DELETE statements, and can be nil
+// when they are not supported, e.g. on replicas.
+func (es *ExecutableStatement) Materialize(aggRunner *AggRunner, catDir *catalog.Directory, deleter RangeDeleter,
 ) (cs *io.ColumnSeries, err error) {
 	var child_cs *io.ColumnSeries
 	if es.GetChildCount() != 0 {
@@ -46,7 +51,7 @@ func (es *ExecutableStatement) Materialize(aggRunner *AggRunner, catDir *catalog
 		switch ctx := node.(type) {
 		case *ExecutableStatement:
 			// fmt.Println("Materialize Executable Statement")
-			child_cs, err = ctx.Materialize(aggRunner, catDir)
+			child_cs, err = ctx.Materialize(aggRunner, catDir, deleter)
 		case *SelectRelation:
 			// fmt.Println("Materialize Select Relation")
 			child_cs, err = ctx.Materialize(aggRunner, catDir)
@@ -56,6 +61,16 @@ func (es *ExecutableStatement) Materialize(aggRunner *AggRunner, catDir *catalog
 		case *InsertIntoStatement:
 			// fmt.Println("Materialize InsertInto Statement")
 			child_cs, err = ctx.Materialize(aggRunner, catDir)
+		case *CreateTableStatement:
+			child_cs, err = ctx.Materialize(catDir)
+		case *DropTableStatement:
+			child_cs, err = ctx.Materialize(catDir)
+		case *DeleteStatement:
+			child_cs, err = ctx.Materialize(catDir, deleter)
+		case *ShowTablesStatement:
+			child_cs, err = ctx.Materialize(catDir)
+		case *ShowColumnsStatement:
+			child_cs, err = ctx.Materialize(catDir)
 		}
 		if err != nil {
 			return nil, err
@@ -136,6 +151,50 @@ func (es *ExecutableStatement) VisitStatementParse(ctx *StatementParse) interfac
 		is.ColumnAliases = columnAliases
 
 		es.AddChild(is)
+	case CREATE_TABLE_STMT:
+		//nolint:forcetypeassert // hard to refactor for now
+		tableName := es.nodeCursor.Visit(ctx.tableName).(string)
+		dsv, err := newTableDataShapes(ctx.tableElements)
+		if err != nil {
+			return err
+		}
+		recordType, err := newTableRecordType(ctx.tableProperties)
+		if err != nil {
+			return err
+		}
+		ct := NewCreateTableStatement(tableName, ctx.QueryText, dsv, recordType)
+		ct.IfNotExists = ctx.IsExists
+		es.AddChild(ct)
+	case DROP_TABLE_STMT:
+		//nolint:forcetypeassert // hard to refactor for now
+		tableName := es.nodeCursor.Visit(ctx.tableName).(string)
+		ds := NewDropTableStatement(tableName, ctx.QueryText)
+		ds.IfExists = ctx.IsExists
+		es.AddChild(ds)
+	case DELETE_STMT:
+		// The records to delete are selected by the query on the table
+		var err error
+		es.nodeCursor, err = NewExecutableStatement(ctx.query)
+		if err != nil {
+			return fmt.Errorf("unable to create executable query")
+		}
+		retval := QueryWalk(es.nodeCursor, ctx.query)
+		if err, ok := retval.(error); ok {
+			return err
+		}
+		//nolint:forcetypeassert // hard to refactor for now
+		sr := es.nodeCursor.payload.(*SelectRelation)
+		es.nodeCursor = es
+
+		//nolint:forcetypeassert // hard to refactor for now
+		tableName := es.nodeCursor.Visit(ctx.tableName).(string)
+		es.AddChild(NewDeleteStatement(tableName, ctx.QueryText, sr))
+	case SHOW_TABLES_STMT:
+		es.AddChild(NewShowTablesStatement(ctx.pattern, ctx.QueryText))
+	case SHOW_COLUMNS_STMT:
+		//nolint:forcetypeassert // hard to refactor for now
+		tableName := es.nodeCursor.Visit(ctx.tableName).(string)
+		es.AddChild(NewShowColumnsStatement(tableName, ctx.QueryText))
 	default:
 		return fmt.Errorf("unsupported statement type: %s", ctx.statementType.String())
 	}
@@ -239,7 +298,7 @@ func (es *ExecutableStatement) VisitQuerySpecificationParse(ctx *QuerySpecificat
 	/*
 		Gather Select list
 	*/
-	for _, item := range ctx.selectItems {
+	for i, item := range ctx.selectItems {
 		//nolint:forcetypeassert // hard to refactor for now
 		cctx := item.(*SelectItemParse)
 		if cctx.IsSelectAll {
@@ -251,7 +310,17 @@ func (es *ExecutableStatement) VisitQuerySpecificationParse(ctx *QuerySpecificat
 			//nolint:forcetypeassert // hard to refactor for now
 			aliasName = es.nodeCursor.Visit(cctx.alias).(string)
 		}
-		icr := es.nodeCursor.Visit(cctx.expression)
+		var icr interface{}
+		if booleanExpressionOf(cctx.expression) != nil {
+			// a boolean value, e.g. Close > Open AS Up
+			if expr, err := es.nodeCursor.newExpression(cctx.expression); err != nil {
+				icr = err
+			} else {
+				icr = expr
+			}
+		} else {
+			icr = es.nodeCursor.Visit(cctx.expression)
+		}
 		switch cr := icr.(type) {
 		// TODO: Function Call goes here
 		case *ColumnReference:
@@ -271,6 +340,18 @@ func (es *ExecutableStatement) VisitQuerySpecificationParse(ctx *QuerySpecificat
 				ai.IsAliased = true
 			}
 			sr.SelectList = append(sr.SelectList, ai)
+		case Expression:
+			// the name of a computed column is the alias, or "_col<position>" without it
+			ai := NewAliasedIdentifier()
+			ai.AddRuntimeExpression(cr)
+			if len(aliasName) != 0 {
+				ai.AddAlias(aliasName)
+			} else {
+				ai.AddAlias(fmt.Sprintf("_col%d", i))
+			}
+			sr.SelectList = append(sr.SelectList, ai)
+		case error:
+			return cr
 		}
 	}
 	if sr.IsSelectAll && len(ctx.selectItems) > 1 {
@@ -327,14 +408,46 @@ func (es *ExecutableStatement) VisitValueExpressionParse(ctx *ValueExpressionPar
 	*/
 	child := ctx.GetChild(0)
 	switch cctx := child.(type) {
-	case *PrimaryExpressionParse: // Primary Expression
+	case *PrimaryExpressionParse, *ArithmeticUnaryParse, *ArithmeticBinaryParse:
 		return es.nodeCursor.Visit(cctx)
 	default:
-		// TODO: Support non primary expressions
-		return fmt.Errorf("only Primary Expressions supported")
+		// TODO: Support AT TIME ZONE and concatenation
+		return fmt.Errorf("only Primary and Arithmetic Expressions supported")
 	}
 }
 
+func (es *ExecutableStatement) VisitArithmeticUnaryParse(ctx *ArithmeticUnaryParse) interface{} {
+	value, err := es.newExpression(ctx.value)
+	if err != nil {
+		return err
+	}
+	if ctx.operator == PLUS {
+		return value
+	}
+	// negative numbers are literals so that they can be static predicate bounds
+	if literal, ok := value.(*Literal); ok {
+		switch v := literal.Value.(type) {
+		case int64:
+			return NewLiteral(-v, literal.Type)
+		case float64:
+			return NewLiteral(-v, literal.Type)
+		}
+	}
+	return NewArithmeticExpression(MINUS, NewLiteral(int64(0), INTEGER_LITERAL), value)
+}
+
+func (es *ExecutableStatement) VisitArithmeticBinaryParse(ctx *ArithmeticBinaryParse) interface{} {
+	left, err := es.newExpression(ctx.left)
+	if err != nil {
+		return err
+	}
+	right, err := es.newExpression(ctx.right)
+	if err != nil {
+		return err
+	}
+	return NewArithmeticExpression(ctx.operator, left, right)
+}
+
 func (es *ExecutableStatement) VisitPrimaryExpressionParse(ctx *PrimaryExpressionParse) interface{} {
 	/*
 		1 Child, lots of options
@@ -354,13 +467,16 @@ func (es *ExecutableStatement) VisitPrimaryExpressionParse(ctx *PrimaryExpressio
 	case FUNCTION_CALL:
 		retval := es.nodeCursor.Visit(ctx.GetChild(0))
 		switch value := retval.(type) {
-		case *FunctionCallReference:
+		case *FunctionCallReference, Expression, error:
+			// window functions are expressions
 			return value
 		default:
 			return fmt.Errorf("unexpected non FunctionCall returned")
 		}
 	case PARENTHESIZED_EXPRESSION:
 		return es.nodeCursor.Visit(ctx.GetChild(0))
+	case CAST, SEARCHED_CASE, EXTRACT, INTERVAL_LITERAL:
+		return es.nodeCursor.Visit(ctx.GetChild(0))
 	default:
 		// TODO: Support other than column refs
 		return fmt.Errorf("unsupported primary expression found: %s",
@@ -471,6 +587,14 @@ func (es *ExecutableStatement) VisitBooleanExpressionParse(ctx *BooleanExpressio
 		} else {
 			switch value := i_value.(type) {
 			case *ColumnReference:
+				if !es.hasLiteralBounds(ctx.predicate) {
+					// e.g. Close > Open
+					if err := es.addRuntimePredicate(value, ctx.predicate); err != nil {
+						return err
+					}
+					done = true
+					break
+				}
 				// Create new predicate for this column
 				es.nodeCursor.pendingSP = NewStaticPredicate(value)
 				// Descend to merge static predicates into this column
@@ -484,6 +608,12 @@ func (es *ExecutableStatement) VisitBooleanExpressionParse(ctx *BooleanExpressio
 				}
 				es.nodeCursor.pendingSP = nil
 				done = true
+			case Expression:
+				// e.g. (High+Low)/2 > 100 or EXTRACT(hour FROM Epoch) = 10
+				if err := es.addRuntimePredicate(value, ctx.predicate); err != nil {
+					return err
+				}
+				done = true
 			case *ExpressionParse:
 				node = value // Continue to descend left
 			case *ValueExpressionParse:
@@ -505,11 +635,11 @@ func (es *ExecutableStatement) VisitBooleanExpressionParse(ctx *BooleanExpressio
 func (es *ExecutableStatement) VisitPredicateParse(ctx *PredicateParse) interface{} {
 	node := ctx.GetChild(0)
 	switch node.(type) {
-	case *ComparisonParse, *BetweenParse:
+	case *ComparisonParse, *BetweenParse, *NullPredicateParse:
 		return es.nodeCursor.Visit(node)
 	case *QuantifiedComparisonParse:
 		return fmt.Errorf("quantified Comparisons (ALL/ANY/SOME) not supported")
-	case *InListParse, *InSubqueryParse, *LikeParse, *NullPredicateParse, *DistinctFromParse:
+	case *InListParse, *InSubqueryParse, *LikeParse, *DistinctFromParse:
 		// TODO: Implement dynamic predicates (and inlist)
 		return fmt.Errorf("unsupported predicate type, only static types are supported")
 	}
@@ -555,6 +685,15 @@ func (es *ExecutableStatement) VisitBetweenParse(ctx *BetweenParse) interface{}
 	return nil
 }
 
+func (es *ExecutableStatement) VisitNullPredicateParse(ctx *NullPredicateParse) interface{} {
+	if ctx.IsNot {
+		es.nodeCursor.pendingSP.ContentsEnum.AddOption(ISNOTNULL)
+	} else {
+		es.nodeCursor.pendingSP.ContentsEnum.AddOption(ISNULL)
+	}
+	return nil
+}
+
 func (es *ExecutableStatement) VisitComparisonParse(ctx *ComparisonParse) interface{} {
 	i_literal := es.nodeCursor.Visit(ctx.right)
 	if literal, ok := i_literal.(*Literal); !ok {
@@ -572,6 +711,384 @@ func (es *ExecutableStatement) VisitComparisonParse(ctx *ComparisonParse) interf
 	return nil
 }
 
+// hasLiteralBounds returns true if the bounds of the predicate are literals, so it can be a static predicate.
+func (es *ExecutableStatement) hasLiteralBounds(predicate IMSTree) bool {
+	if predicate == nil {
+		return true
+	}
+	var bounds []IMSTree
+	switch cctx := predicate.GetChild(0).(type) {
+	case *ComparisonParse:
+		bounds = []IMSTree{cctx.right}
+	case *BetweenParse:
+		bounds = []IMSTree{cctx.lower, cctx.upper}
+	}
+	for _, bound := range bounds {
+		if _, ok := es.nodeCursor.Visit(bound).(*Literal); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// addRuntimePredicate adds the predicate on the value to the runtime predicates of the Select Relation,
+// which are evaluated on the rows read from the table.
+func (es *ExecutableStatement) addRuntimePredicate(value Expression, predicate IMSTree) error {
+	sr, ok := es.nodeCursor.payload.(*SelectRelation)
+	if !ok {
+		return fmt.Errorf("no Select Relation in progress")
+	}
+	expr, err := es.newPredicateExpression(value, predicate)
+	if err != nil {
+		return err
+	}
+	if sr.WherePredicate == nil {
+		sr.WherePredicate = expr
+	} else {
+		sr.WherePredicate = NewLogicalExpression(AND_OP, sr.WherePredicate, expr)
+	}
+	return nil
+}
+
+func (es *ExecutableStatement) VisitCastParse(ctx *CastParse) interface{} {
+	value, err := es.newExpression(ctx.expression)
+	if err != nil {
+		return err
+	}
+	typ, err := es.castElementType(ctx.typeT)
+	if err != nil {
+		return err
+	}
+	return NewCastExpression(value, typ)
+}
+
+// castTypeNames are the SQL names of the types for CAST in addition to the element type names (e.g. "float64").
+var castTypeNames = map[string]io.EnumElementType{
+	"double":   io.FLOAT64,
+	"real":     io.FLOAT32,
+	"bigint":   io.INT64,
+	"integer":  io.INT32,
+	"int":      io.INT32,
+	"smallint": io.INT16,
+}
+
+func (es *ExecutableStatement) castElementType(typeT IMSTree) (io.EnumElementType, error) {
+	tt, ok := typeT.(*TypeTParse)
+	if !ok || tt.baseType == nil {
+		return io.NONE, fmt.Errorf("only numeric types are supported for CAST")
+	}
+	//nolint:forcetypeassert // baseType is always a BaseTypeParse
+	bt := tt.baseType.(*BaseTypeParse)
+	if bt.typeID == DOUBLE_PRECISION {
+		return io.FLOAT64, nil
+	}
+	if bt.GetChildCount() == 0 {
+		return io.NONE, fmt.Errorf("only numeric types are supported for CAST")
+	}
+	name, _ := es.nodeCursor.Visit(bt.GetChild(0)).(string)
+	typ, ok := castTypeNames[strings.ToLower(name)]
+	if !ok {
+		typ = io.EnumElementTypeFromName(name)
+	}
+	if !typ.IsNumeric() || typ == io.DECIMAL64 {
+		return io.NONE, fmt.Errorf("unsupported type for CAST: %s", name)
+	}
+	return typ, nil
+}
+
+func (es *ExecutableStatement) VisitSearchedCaseParse(ctx *SearchedCaseParse) interface{} {
+	whenClauses := make([]*WhenClause, 0, len(ctx.whenClause))
+	for _, item := range ctx.whenClause {
+		//nolint:forcetypeassert // hard to refactor for now
+		wctx := item.(*WhenParse)
+		condition, err := es.newExpression(wctx.condition)
+		if err != nil {
+			return err
+		}
+		result, err := es.newExpression(wctx.result)
+		if err != nil {
+			return err
+		}
+		whenClauses = append(whenClauses, &WhenClause{Condition: condition, Result: result})
+	}
+	var elseExpression Expression
+	if ctx.elseExpression != nil {
+		var err error
+		if elseExpression, err = es.newExpression(ctx.elseExpression); err != nil {
+			return err
+		}
+	}
+	return NewCaseExpression(whenClauses, elseExpression)
+}
+
+func (es *ExecutableStatement) VisitExtractParse(ctx *ExtractParse) interface{} {
+	field, _ := es.nodeCursor.Visit(ctx.left).(string)
+	value, err := es.newExpression(ctx.right)
+	if err != nil {
+		return err
+	}
+	expr, err := NewExtractExpression(field, value)
+	if err != nil {
+		return err
+	}
+	return expr
+}
+
+// VisitIntervalParse returns an interval literal as an integer literal of its number of seconds,
+// e.g. INTERVAL '5' MINUTE is 300.
+func (es *ExecutableStatement) VisitIntervalParse(ctx *IntervalParse) interface{} {
+	if ctx.toField != nil {
+		return fmt.Errorf("unsupported interval with a range of fields")
+	}
+	value, err := strconv.ParseInt(strings.Trim(ctx.stringValue, "'"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid interval value: %s", ctx.stringValue)
+	}
+	//nolint:forcetypeassert // the fields of an interval are IntervalFieldParse
+	switch ctx.fromField.(*IntervalFieldParse).value {
+	case DAY:
+		value *= 24 * 60 * 60
+	case HOUR:
+		value *= 60 * 60
+	case MINUTE:
+		value *= 60
+	case SECOND:
+	default:
+		return fmt.Errorf("unsupported interval field, YEAR and MONTH intervals have no fixed length")
+	}
+	if ctx.IsMinus {
+		value = -value
+	}
+	return NewLiteral(value, INTEGER_LITERAL)
+}
+
+// newWindowExpression returns the runtime expression of a function call with an OVER clause,
+// e.g. LAG(Close, 1) OVER (ORDER BY Epoch).
+func (es *ExecutableStatement) newWindowExpression(name string, ctx *FunctionCallParse) (Expression, error) {
+	if ctx.hasAsterisk || ctx.hasFilter || ctx.hasSetQuantifier {
+		return nil, fmt.Errorf("unsupported window function call: %s", name)
+	}
+	//nolint:forcetypeassert // the OVER clause is an OverParse
+	over := ctx.over.(*OverParse)
+	if len(over.partitions) != 0 {
+		return nil, fmt.Errorf("PARTITION BY is not supported, the window functions are computed per symbol")
+	}
+	switch len(over.sortItems) {
+	case 0:
+	case 1:
+		//nolint:forcetypeassert // the sort items are SortItemParse
+		sortItem := over.sortItems[0].(*SortItemParse)
+		expr, err := es.newExpression(sortItem.expression)
+		if err != nil {
+			return nil, err
+		}
+		if cr, ok := expr.(*ColumnReference); !ok || cr.GetName() != "Epoch" ||
+			sortItem.sortOrdering == DESCENDING {
+			return nil, fmt.Errorf("window functions can only be ordered by Epoch ascending")
+		}
+	default:
+		return nil, fmt.Errorf("window functions can only be ordered by Epoch ascending")
+	}
+
+	var args []Expression
+	for _, arg := range ctx.expressionList {
+		expr, err := es.newExpression(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, expr)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("window function %s needs an argument", name)
+	}
+
+	fn := strings.ToUpper(name)
+	switch fn {
+	case "LAG", "LEAD":
+		offset := int64(1)
+		switch len(args) {
+		case 1:
+		case 2:
+			li, ok := args[1].(*Literal)
+			if !ok || li.Type != INTEGER_LITERAL {
+				return nil, fmt.Errorf("the offset of %s must be an integer", fn)
+			}
+			var err error
+			if offset, err = io.GetValueAsInt64(li.Value); err != nil || offset < 0 {
+				return nil, fmt.Errorf("invalid offset of %s: %v", fn, li.Value)
+			}
+		default:
+			return nil, fmt.Errorf("too many arguments for %s", fn)
+		}
+		if fn == "LEAD" {
+			offset = -offset
+		}
+		return NewShiftExpression(args[0], int(offset)), nil
+	}
+
+	aggFunc, ok := windowAggregates[fn]
+	if !ok {
+		return nil, fmt.Errorf("unsupported window function: %s", name)
+	}
+	if len(args) != 1 {
+		return nil, fmt.Errorf("too many arguments for %s", fn)
+	}
+	if over.GetChildCount() == 0 {
+		// without a frame, the aggregate is computed over the preceding rows when ordered,
+		// and over all the rows when not
+		return NewWindowAggregateExpression(aggFunc, args[0], window.Frame{}, len(over.sortItems) == 0), nil
+	}
+	//nolint:forcetypeassert // the child of an OverParse is a WindowFrameParse
+	frame, err := es.windowFrame(over.GetChild(0).(*WindowFrameParse))
+	if err != nil {
+		return nil, err
+	}
+	return NewWindowAggregateExpression(aggFunc, args[0], frame, false), nil
+}
+
+// windowFrame returns the frame of a ROWS or RANGE clause. The frames must end at the current row.
+func (es *ExecutableStatement) windowFrame(ctx *WindowFrameParse) (window.Frame, error) {
+	//nolint:forcetypeassert // the children of a WindowFrameParse are FrameBoundParse
+	start := ctx.GetChild(0).(*FrameBoundParse)
+	if ctx.IsBetween {
+		//nolint:forcetypeassert // the children of a WindowFrameParse are FrameBoundParse
+		if end := ctx.GetChild(1).(*FrameBoundParse); !end.IsCurrentRow {
+			return window.Frame{}, fmt.Errorf("window frames must end at the current row")
+		}
+	}
+	switch {
+	case start.IsCurrentRow:
+		return window.Frame{Rows: 1}, nil
+	case start.IsFollowing:
+		return window.Frame{}, fmt.Errorf("FOLLOWING window frames are not supported")
+	case start.IsUnbounded:
+		return window.Frame{}, nil
+	}
+
+	expr, err := es.newExpression(start.GetChild(0))
+	if err != nil {
+		return window.Frame{}, err
+	}
+	li, ok := expr.(*Literal)
+	if !ok || li.Type != INTEGER_LITERAL {
+		return window.Frame{}, fmt.Errorf("the bound of a window frame must be an integer or an interval")
+	}
+	preceding, err := io.GetValueAsInt64(li.Value)
+	if err != nil || preceding < 0 {
+		return window.Frame{}, fmt.Errorf("invalid bound of a window frame: %v", li.Value)
+	}
+	if ctx.IsRange {
+		if preceding == 0 {
+			return window.Frame{Rows: 1}, nil
+		}
+		// the frame of a RANGE is in seconds of Epoch
+		return window.Frame{Range: preceding}, nil
+	}
+	return window.Frame{Rows: int(preceding) + 1}, nil
+}
+
+// newExpression returns the runtime expression of an Expression or ValueExpression node.
+func (es *ExecutableStatement) newExpression(tree IMSTree) (Expression, error) {
+	if bctx := booleanExpressionOf(tree); bctx != nil {
+		return es.newBooleanExpression(bctx)
+	}
+	switch value := es.nodeCursor.Visit(tree).(type) {
+	case Expression:
+		return value, nil
+	case *FunctionCallReference:
+		return nil, fmt.Errorf("function calls are not supported in expressions: %s", value.Name)
+	case error:
+		return nil, value
+	default:
+		return nil, fmt.Errorf("unsupported expression: %v", value)
+	}
+}
+
+// booleanExpressionOf returns the BooleanExpression node of a boolean Expression node or a parenthesized boolean
+// value (e.g. "(Close > Open)"), or nil for the other nodes.
+// The BooleanExpression nodes are visited only for the static predicates of the WHERE clause.
+func booleanExpressionOf(tree IMSTree) *BooleanExpressionParse {
+	switch ctx := tree.(type) {
+	case *BooleanExpressionParse:
+		return ctx
+	case *ExpressionParse, *ValueExpressionParse:
+		return booleanExpressionOf(ctx.GetChild(0))
+	case *PrimaryExpressionParse:
+		if ctx.primaryType == PARENTHESIZED_EXPRESSION {
+			return booleanExpressionOf(ctx.GetChild(0))
+		}
+	}
+	return nil
+}
+
+// newBooleanExpression returns the runtime expression of a BooleanExpression node,
+// e.g. the condition of a CASE WHEN clause.
+func (es *ExecutableStatement) newBooleanExpression(ctx *BooleanExpressionParse) (Expression, error) {
+	switch {
+	case ctx.IsLiteral:
+		return NewLiteral(ctx.value, BOOLEAN_LITERAL), nil
+	case ctx.right != nil: // AND/OR
+		bctx, ok := ctx.left.(*BooleanExpressionParse)
+		if !ok {
+			return nil, fmt.Errorf("unsupported boolean expression")
+		}
+		left, err := es.newBooleanExpression(bctx)
+		if err != nil {
+			return nil, err
+		}
+		right, err := es.newExpression(ctx.right)
+		if err != nil {
+			return nil, err
+		}
+		return NewLogicalExpression(ctx.operator, left, right), nil
+	case ctx.left != nil:
+		value, err := es.newExpression(ctx.left)
+		if err != nil {
+			return nil, err
+		}
+		return es.newPredicateExpression(value, ctx.predicate)
+	default:
+		return nil, fmt.Errorf("unsupported boolean expression")
+	}
+}
+
+// newPredicateExpression returns the runtime expression of a predicate on the value, e.g. "> Open".
+// Like the static predicates, the bounds of BETWEEN are exclusive.
+func (es *ExecutableStatement) newPredicateExpression(value Expression, predicate IMSTree) (Expression, error) {
+	if predicate == nil {
+		return nil, fmt.Errorf("unsupported boolean expression")
+	}
+	switch cctx := predicate.GetChild(0).(type) {
+	case *ComparisonParse:
+		right, err := es.newExpression(cctx.right)
+		if err != nil {
+			return nil, err
+		}
+		return NewComparisonExpression(cctx.comparisonOperator, value, right), nil
+	case *BetweenParse:
+		lower, err := es.newExpression(cctx.lower)
+		if err != nil {
+			return nil, err
+		}
+		upper, err := es.newExpression(cctx.upper)
+		if err != nil {
+			return nil, err
+		}
+		if cctx.IsNot {
+			return NewLogicalExpression(OR_OP,
+				NewComparisonExpression(io.LTE, value, lower),
+				NewComparisonExpression(io.GTE, value, upper)), nil
+		}
+		return NewLogicalExpression(AND_OP,
+			NewComparisonExpression(io.GT, value, lower),
+			NewComparisonExpression(io.LT, value, upper)), nil
+	case *NullPredicateParse:
+		return NewIsNullExpression(value, cctx.IsNot), nil
+	default:
+		return nil, fmt.Errorf("unsupported predicate type in expressions")
+	}
+}
+
 func (es *ExecutableStatement) VisitFunctionCallParse(ctx *FunctionCallParse) interface{} {
 	i_name := es.nodeCursor.Visit(ctx.qualifiedName)
 	name, ok := i_name.(string)
@@ -579,6 +1096,14 @@ func (es *ExecutableStatement) VisitFunctionCallParse(ctx *FunctionCallParse) in
 		return fmt.Errorf("error parsing function name")
 	}
 
+	if ctx.over != nil {
+		expr, err := es.newWindowExpression(name, ctx)
+		if err != nil {
+			return err
+		}
+		return expr
+	}
+
 	var args []interface{}
 	if ctx.hasAsterisk {
 		fc := NewFunctionCallReference(name, args)
@@ -0,0 +1,826 @@
+package sqlparser
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/uda/window"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+/*
+Runtime Expressions
+
+An Expression is evaluated over all the rows of a column series at once, e.g. (High+Low)/2 AS Mid
+in a select list or EXTRACT(hour FROM Epoch) = 10 in a WHERE clause.
+The operands of an arithmetic operation are converted to the type given by io.PromoteElementTypes,
+except for a constant (e.g. a literal) that takes the type of the other operand when it is of the same kind,
+so "Close * 2" of a float32 column is float32. DECIMAL64 columns are evaluated as float64.
+A null operand makes the result null, and so does an integer division by zero.
+*/
+
+// Expression is an expression evaluated over the columns of a column series.
+type Expression interface {
+	// Evaluate returns the values of the expression for all the rows of the column series.
+	Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error)
+	// GetIDs returns the names of the columns referenced by the expression.
+	GetIDs() []string
+}
+
+// ExpressionValue is the result column of an expression.
+type ExpressionValue struct {
+	// Column is a slice of the element type, e.g. []float32 for FLOAT32 and []bool for BOOL
+	Column interface{}
+	Type   io.EnumElementType
+	// NullMask is true for the null values, or nil if no value is null
+	NullMask []bool
+	// IsConstant is true when the value does not depend on any column (e.g. a literal)
+	IsConstant bool
+}
+
+func (ev *ExpressionValue) isNull(i int) bool {
+	return ev.NullMask != nil && ev.NullMask[i]
+}
+
+// Evaluate returns the literal value for all the rows. NULL is a null INT64 value.
+func (li *Literal) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	n := cs.Len()
+	ev := &ExpressionValue{IsConstant: true}
+	switch li.Type {
+	case INTEGER_LITERAL, DECIMAL_LITERAL:
+		if value, ok := li.Value.(float64); ok {
+			col := make([]float64, n)
+			for i := range col {
+				col[i] = value
+			}
+			ev.Column, ev.Type = col, io.FLOAT64
+			return ev, nil
+		}
+		value, err := io.GetValueAsInt64(li.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected numeric literal: %v", li.Value)
+		}
+		col := make([]int64, n)
+		for i := range col {
+			col[i] = value
+		}
+		ev.Column, ev.Type = col, io.INT64
+	case BOOLEAN_LITERAL:
+		//nolint:forcetypeassert // boolean literals are parsed as bool
+		value := li.Value.(bool)
+		col := make([]bool, n)
+		for i := range col {
+			col[i] = value
+		}
+		ev.Column, ev.Type = col, io.BOOL
+	case NULL_LITERAL:
+		ev.Column, ev.Type = make([]int64, n), io.INT64
+		ev.NullMask = make([]bool, n)
+		for i := range ev.NullMask {
+			ev.NullMask[i] = true
+		}
+	default:
+		return nil, fmt.Errorf("unsupported literal in expression: %v", li.Value)
+	}
+	return ev, nil
+}
+
+func (li *Literal) GetIDs() []string {
+	return nil
+}
+
+// Evaluate returns the values of the referenced column.
+func (cr *ColumnReference) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	name := cr.GetName()
+	col := cs.GetColumn(name)
+	if col == nil {
+		return nil, fmt.Errorf("column %s not found", name)
+	}
+	ev := &ExpressionValue{Column: col, Type: io.GetElementType(col), NullMask: cs.GetNullMask(name)}
+	switch {
+	case ev.Type == io.DECIMAL64:
+		//nolint:forcetypeassert // checked by the element type
+		dec := col.([]io.Decimal64)
+		scale := cs.GetScale(name)
+		floats := make([]float64, len(dec))
+		for i, v := range dec {
+			floats[i] = v.Float64(scale)
+		}
+		ev.Column, ev.Type = floats, io.FLOAT64
+	case ev.Type == io.BOOL, ev.Type.IsNumeric():
+	default:
+		return nil, fmt.Errorf("unsupported type of column %s in expression: %s", name, ev.Type)
+	}
+	return ev, nil
+}
+
+func (cr *ColumnReference) GetIDs() []string {
+	return []string{cr.GetName()}
+}
+
+// ArithmeticExpression is a binary arithmetic operation, e.g. High + Low.
+// An unary minus is an operation with a zero on the left.
+type ArithmeticExpression struct {
+	Operator    ArithmeticOperatorEnum
+	Left, Right Expression
+}
+
+func NewArithmeticExpression(operator ArithmeticOperatorEnum, left, right Expression) *ArithmeticExpression {
+	return &ArithmeticExpression{Operator: operator, Left: left, Right: right}
+}
+
+func (ae *ArithmeticExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	left, right, err := evaluatePair(cs, ae.Left, ae.Right)
+	if err != nil {
+		return nil, err
+	}
+	typ := promoteValues(left, right)
+	if typ == io.NONE {
+		return nil, fmt.Errorf("unsupported operand types for arithmetic: %s and %s", left.Type, right.Type)
+	}
+
+	n := cs.Len()
+	nulls := mergeNullMasks(n, left.NullMask, right.NullMask)
+	setNull := func(i int) {
+		if nulls == nil {
+			nulls = make([]bool, n)
+		}
+		nulls[i] = true
+	}
+
+	var result interface{}
+	switch {
+	case isFloatType(typ):
+		l, r, err2 := castPair(left, right, io.FLOAT64)
+		if err2 != nil {
+			return nil, err2
+		}
+		//nolint:forcetypeassert // cast to FLOAT64
+		lf, rf := l.([]float64), r.([]float64)
+		out := make([]float64, n)
+		for i := range out {
+			switch ae.Operator {
+			case PLUS:
+				out[i] = lf[i] + rf[i]
+			case MINUS:
+				out[i] = lf[i] - rf[i]
+			case MULTIPLY:
+				out[i] = lf[i] * rf[i]
+			case DIVIDE:
+				out[i] = lf[i] / rf[i]
+			case PERCENT:
+				out[i] = math.Mod(lf[i], rf[i])
+			}
+		}
+		result = out
+	case isUnsignedType(typ):
+		l, r, err2 := castPair(left, right, io.UINT64)
+		if err2 != nil {
+			return nil, err2
+		}
+		//nolint:forcetypeassert // cast to UINT64
+		lu, ru := l.([]uint64), r.([]uint64)
+		out := make([]uint64, n)
+		for i := range out {
+			switch ae.Operator {
+			case PLUS:
+				out[i] = lu[i] + ru[i]
+			case MINUS:
+				out[i] = lu[i] - ru[i]
+			case MULTIPLY:
+				out[i] = lu[i] * ru[i]
+			case DIVIDE, PERCENT:
+				if ru[i] == 0 {
+					setNull(i)
+				} else if ae.Operator == DIVIDE {
+					out[i] = lu[i] / ru[i]
+				} else {
+					out[i] = lu[i] % ru[i]
+				}
+			}
+		}
+		result = out
+	default:
+		l, r, err2 := castPair(left, right, io.INT64)
+		if err2 != nil {
+			return nil, err2
+		}
+		//nolint:forcetypeassert // cast to INT64
+		li, ri := l.([]int64), r.([]int64)
+		out := make([]int64, n)
+		for i := range out {
+			switch ae.Operator {
+			case PLUS:
+				out[i] = li[i] + ri[i]
+			case MINUS:
+				out[i] = li[i] - ri[i]
+			case MULTIPLY:
+				out[i] = li[i] * ri[i]
+			case DIVIDE, PERCENT:
+				if ri[i] == 0 {
+					setNull(i)
+				} else if ae.Operator == DIVIDE {
+					out[i] = li[i] / ri[i]
+				} else {
+					out[i] = li[i] % ri[i]
+				}
+			}
+		}
+		result = out
+	}
+
+	if result, err = io.CastColumn(result, typ); err != nil {
+		return nil, err
+	}
+	return &ExpressionValue{
+		Column: result, Type: typ, NullMask: nulls,
+		IsConstant: left.IsConstant && right.IsConstant,
+	}, nil
+}
+
+func (ae *ArithmeticExpression) GetIDs() []string {
+	return append(ae.Left.GetIDs(), ae.Right.GetIDs()...)
+}
+
+// CastExpression converts the value to a numeric type, e.g. CAST(Volume AS float64).
+type CastExpression struct {
+	Value Expression
+	Type  io.EnumElementType
+}
+
+func NewCastExpression(value Expression, typ io.EnumElementType) *CastExpression {
+	return &CastExpression{Value: value, Type: typ}
+}
+
+func (ce *CastExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	value, err := ce.Value.Evaluate(cs)
+	if err != nil {
+		return nil, err
+	}
+	col, err := castValue(value, ce.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &ExpressionValue{Column: col, Type: ce.Type, NullMask: value.NullMask, IsConstant: value.IsConstant}, nil
+}
+
+func (ce *CastExpression) GetIDs() []string {
+	return ce.Value.GetIDs()
+}
+
+// ComparisonExpression compares two values, e.g. Close > Open. The result is BOOL.
+type ComparisonExpression struct {
+	Operator    io.ComparisonOperatorEnum
+	Left, Right Expression
+}
+
+func NewComparisonExpression(operator io.ComparisonOperatorEnum, left, right Expression) *ComparisonExpression {
+	return &ComparisonExpression{Operator: operator, Left: left, Right: right}
+}
+
+func (ce *ComparisonExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	left, right, err := evaluatePair(cs, ce.Left, ce.Right)
+	if err != nil {
+		return nil, err
+	}
+	n := cs.Len()
+	out := make([]bool, n)
+
+	// compare returns the result of the comparison from the sign of (left - right)
+	compare := func(sign int) bool {
+		switch ce.Operator {
+		case io.EQ:
+			return sign == 0
+		case io.NEQ:
+			return sign != 0
+		case io.LT:
+			return sign < 0
+		case io.LTE:
+			return sign <= 0
+		case io.GT:
+			return sign > 0
+		case io.GTE:
+			return sign >= 0
+		}
+		return false
+	}
+
+	switch typ := promoteValues(left, right); {
+	case left.Type == io.BOOL && right.Type == io.BOOL:
+		if ce.Operator != io.EQ && ce.Operator != io.NEQ {
+			return nil, fmt.Errorf("boolean values can only be compared with = or !=")
+		}
+		//nolint:forcetypeassert // BOOL values
+		lb, rb := left.Column.([]bool), right.Column.([]bool)
+		for i := range out {
+			out[i] = (lb[i] == rb[i]) == (ce.Operator == io.EQ)
+		}
+	case typ == io.NONE:
+		return nil, fmt.Errorf("unsupported operand types for comparison: %s and %s", left.Type, right.Type)
+	case isFloatType(typ):
+		l, r, err2 := castPair(left, right, io.FLOAT64)
+		if err2 != nil {
+			return nil, err2
+		}
+		//nolint:forcetypeassert // cast to FLOAT64
+		lf, rf := l.([]float64), r.([]float64)
+		for i := range out {
+			switch {
+			case lf[i] < rf[i]:
+				out[i] = compare(-1)
+			case lf[i] > rf[i]:
+				out[i] = compare(1)
+			case lf[i] == rf[i]:
+				out[i] = compare(0)
+			default: // NaN
+				out[i] = ce.Operator == io.NEQ
+			}
+		}
+	case isUnsignedType(typ):
+		l, r, err2 := castPair(left, right, io.UINT64)
+		if err2 != nil {
+			return nil, err2
+		}
+		//nolint:forcetypeassert // cast to UINT64
+		lu, ru := l.([]uint64), r.([]uint64)
+		for i := range out {
+			switch {
+			case lu[i] < ru[i]:
+				out[i] = compare(-1)
+			case lu[i] > ru[i]:
+				out[i] = compare(1)
+			default:
+				out[i] = compare(0)
+			}
+		}
+	default:
+		l, r, err2 := castPair(left, right, io.INT64)
+		if err2 != nil {
+			return nil, err2
+		}
+		//nolint:forcetypeassert // cast to INT64
+		li, ri := l.([]int64), r.([]int64)
+		for i := range out {
+			switch {
+			case li[i] < ri[i]:
+				out[i] = compare(-1)
+			case li[i] > ri[i]:
+				out[i] = compare(1)
+			default:
+				out[i] = compare(0)
+			}
+		}
+	}
+	return &ExpressionValue{
+		Column: out, Type: io.BOOL, NullMask: mergeNullMasks(n, left.NullMask, right.NullMask),
+		IsConstant: left.IsConstant && right.IsConstant,
+	}, nil
+}
+
+func (ce *ComparisonExpression) GetIDs() []string {
+	return append(ce.Left.GetIDs(), ce.Right.GetIDs()...)
+}
+
+// LogicalExpression is an AND/OR of two boolean values with the three-valued logic of SQL,
+// e.g. NULL AND FALSE is FALSE, and NULL AND TRUE is NULL.
+type LogicalExpression struct {
+	Operator    BinaryOperatorEnum
+	Left, Right Expression
+}
+
+func NewLogicalExpression(operator BinaryOperatorEnum, left, right Expression) *LogicalExpression {
+	return &LogicalExpression{Operator: operator, Left: left, Right: right}
+}
+
+func (le *LogicalExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	left, right, err := evaluatePair(cs, le.Left, le.Right)
+	if err != nil {
+		return nil, err
+	}
+	if left.Type != io.BOOL || right.Type != io.BOOL {
+		return nil, fmt.Errorf("unsupported operand types for AND/OR: %s and %s", left.Type, right.Type)
+	}
+	//nolint:forcetypeassert // BOOL values
+	lb, rb := left.Column.([]bool), right.Column.([]bool)
+
+	n := cs.Len()
+	out := make([]bool, n)
+	var nulls []bool
+	// the value that decides the result regardless of the other operand
+	dominant := le.Operator == OR_OP
+	for i := range out {
+		lNull, rNull := left.isNull(i), right.isNull(i)
+		switch {
+		case !lNull && lb[i] == dominant, !rNull && rb[i] == dominant:
+			out[i] = dominant
+		case lNull || rNull:
+			if nulls == nil {
+				nulls = make([]bool, n)
+			}
+			nulls[i] = true
+		default:
+			out[i] = !dominant
+		}
+	}
+	return &ExpressionValue{
+		Column: out, Type: io.BOOL, NullMask: nulls,
+		IsConstant: left.IsConstant && right.IsConstant,
+	}, nil
+}
+
+func (le *LogicalExpression) GetIDs() []string {
+	return append(le.Left.GetIDs(), le.Right.GetIDs()...)
+}
+
+// IsNullExpression is true for the null values, or for the non-null values with IS NOT NULL.
+type IsNullExpression struct {
+	Value Expression
+	IsNot bool
+}
+
+func NewIsNullExpression(value Expression, isNot bool) *IsNullExpression {
+	return &IsNullExpression{Value: value, IsNot: isNot}
+}
+
+func (ne *IsNullExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	value, err := ne.Value.Evaluate(cs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]bool, cs.Len())
+	for i := range out {
+		out[i] = value.isNull(i) != ne.IsNot
+	}
+	return &ExpressionValue{Column: out, Type: io.BOOL, IsConstant: value.IsConstant}, nil
+}
+
+func (ne *IsNullExpression) GetIDs() []string {
+	return ne.Value.GetIDs()
+}
+
+// WhenClause is a "WHEN condition THEN result" of a CASE expression.
+type WhenClause struct {
+	Condition, Result Expression
+}
+
+// CaseExpression is a searched CASE expression. The value of a row is the result of the first WHEN clause
+// whose condition is true, the ELSE value when there is no such clause, or null when there is no ELSE either.
+type CaseExpression struct {
+	WhenClauses []*WhenClause
+	Else        Expression
+}
+
+func NewCaseExpression(whenClauses []*WhenClause, elseExpression Expression) *CaseExpression {
+	return &CaseExpression{WhenClauses: whenClauses, Else: elseExpression}
+}
+
+func (ce *CaseExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	n := cs.Len()
+	results := make([]*ExpressionValue, 0, len(ce.WhenClauses)+1)
+	// the index of the result of each row, -1 for null
+	choices := make([]int, n)
+	for i := range choices {
+		choices[i] = -1
+	}
+	isConstant := true
+	for k, wc := range ce.WhenClauses {
+		condition, err := wc.Condition.Evaluate(cs)
+		if err != nil {
+			return nil, err
+		}
+		if condition.Type != io.BOOL {
+			return nil, fmt.Errorf("CASE WHEN condition must be boolean, have: %s", condition.Type)
+		}
+		result, err := wc.Result.Evaluate(cs)
+		if err != nil {
+			return nil, err
+		}
+		//nolint:forcetypeassert // BOOL values
+		matched := condition.Column.([]bool)
+		for i := range choices {
+			if choices[i] == -1 && matched[i] && !condition.isNull(i) {
+				choices[i] = k
+			}
+		}
+		results = append(results, result)
+		isConstant = isConstant && condition.IsConstant && result.IsConstant
+	}
+	if ce.Else != nil {
+		elseValue, err := ce.Else.Evaluate(cs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range choices {
+			if choices[i] == -1 {
+				choices[i] = len(results)
+			}
+		}
+		results = append(results, elseValue)
+		isConstant = isConstant && elseValue.IsConstant
+	}
+
+	// all the results are converted to a common type, where a NULL takes the type of the others
+	common := results[0]
+	for _, result := range results[1:] {
+		switch {
+		case isNullConstant(result):
+		case isNullConstant(common):
+			common = result
+		case common.Type == io.BOOL && result.Type == io.BOOL:
+		case common.Type == io.BOOL || result.Type == io.BOOL:
+			return nil, fmt.Errorf("CASE results have incompatible types: %s and %s", common.Type, result.Type)
+		default:
+			common = &ExpressionValue{
+				Type:       promoteValues(common, result),
+				IsConstant: common.IsConstant && result.IsConstant,
+			}
+		}
+	}
+	typ := common.Type
+	columns := make([]reflect.Value, len(results))
+	for k, result := range results {
+		if isNullConstant(result) {
+			columns[k] = reflect.MakeSlice(reflect.SliceOf(typ.TypeOf()), n, n)
+			continue
+		}
+		col, err := castValue(result, typ)
+		if err != nil {
+			return nil, err
+		}
+		columns[k] = reflect.ValueOf(col)
+	}
+
+	out := reflect.MakeSlice(reflect.SliceOf(typ.TypeOf()), n, n)
+	var nulls []bool
+	for i, k := range choices {
+		if k == -1 || results[k].isNull(i) {
+			if nulls == nil {
+				nulls = make([]bool, n)
+			}
+			nulls[i] = true
+			continue
+		}
+		out.Index(i).Set(columns[k].Index(i))
+	}
+	return &ExpressionValue{Column: out.Interface(), Type: typ, NullMask: nulls, IsConstant: isConstant}, nil
+}
+
+func (ce *CaseExpression) GetIDs() (ids []string) {
+	for _, wc := range ce.WhenClauses {
+		ids = append(ids, wc.Condition.GetIDs()...)
+		ids = append(ids, wc.Result.GetIDs()...)
+	}
+	if ce.Else != nil {
+		ids = append(ids, ce.Else.GetIDs()...)
+	}
+	return ids
+}
+
+// ExtractExpression extracts a field of the time from an epoch second value in the system timezone,
+// e.g. EXTRACT(hour FROM Epoch). The result is INT64.
+type ExtractExpression struct {
+	Field string
+	Value Expression
+}
+
+// extractFields are the fields supported by EXTRACT.
+var extractFields = map[string]func(t time.Time) int64{
+	"YEAR":    func(t time.Time) int64 { return int64(t.Year()) },
+	"QUARTER": func(t time.Time) int64 { return int64(t.Month()-1)/3 + 1 },
+	"MONTH":   func(t time.Time) int64 { return int64(t.Month()) },
+	"WEEK": func(t time.Time) int64 {
+		_, week := t.ISOWeek()
+		return int64(week)
+	},
+	"DAY":          func(t time.Time) int64 { return int64(t.Day()) },
+	"DAY_OF_MONTH": func(t time.Time) int64 { return int64(t.Day()) },
+	// ISO day of the week, from 1 (Monday) to 7 (Sunday)
+	"DAY_OF_WEEK": isoWeekday,
+	"DOW":         isoWeekday,
+	"DAY_OF_YEAR": func(t time.Time) int64 { return int64(t.YearDay()) },
+	"DOY":         func(t time.Time) int64 { return int64(t.YearDay()) },
+	"HOUR":        func(t time.Time) int64 { return int64(t.Hour()) },
+	"MINUTE":      func(t time.Time) int64 { return int64(t.Minute()) },
+	"SECOND":      func(t time.Time) int64 { return int64(t.Second()) },
+}
+
+func isoWeekday(t time.Time) int64 {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int64(t.Weekday())
+}
+
+func NewExtractExpression(field string, value Expression) (*ExtractExpression, error) {
+	field = strings.ToUpper(field)
+	if _, ok := extractFields[field]; !ok {
+		return nil, fmt.Errorf("unsupported field for EXTRACT: %s", field)
+	}
+	return &ExtractExpression{Field: field, Value: value}, nil
+}
+
+func (ee *ExtractExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	value, err := ee.Value.Evaluate(cs)
+	if err != nil {
+		return nil, err
+	}
+	if !value.Type.IsNumeric() || isFloatType(value.Type) {
+		return nil, fmt.Errorf("EXTRACT needs an epoch second value, have: %s", value.Type)
+	}
+	col, err := castValue(value, io.INT64)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:forcetypeassert // cast to INT64
+	epochs := col.([]int64)
+	extract := extractFields[ee.Field]
+	out := make([]int64, len(epochs))
+	for i, epoch := range epochs {
+		out[i] = extract(io.ToSystemTimezone(time.Unix(epoch, 0)))
+	}
+	return &ExpressionValue{Column: out, Type: io.INT64, NullMask: value.NullMask, IsConstant: value.IsConstant}, nil
+}
+
+func (ee *ExtractExpression) GetIDs() []string {
+	return ee.Value.GetIDs()
+}
+
+// ShiftExpression is the value of a row some rows before or after each row, i.e. LAG and LEAD.
+// A positive offset takes the value of the preceding rows. The rows without a source row are null.
+type ShiftExpression struct {
+	Value  Expression
+	Offset int
+}
+
+func NewShiftExpression(value Expression, offset int) *ShiftExpression {
+	return &ShiftExpression{Value: value, Offset: offset}
+}
+
+func (se *ShiftExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	value, err := se.Value.Evaluate(cs)
+	if err != nil {
+		return nil, err
+	}
+	col, nulls := window.Shift(value.Column, value.NullMask, se.Offset)
+	return &ExpressionValue{Column: col, Type: value.Type, NullMask: nulls}, nil
+}
+
+func (se *ShiftExpression) GetIDs() []string {
+	return se.Value.GetIDs()
+}
+
+// windowAggregates are the aggregate functions supported with an OVER clause.
+var windowAggregates = map[string]window.Func{
+	"SUM":         window.Sum,
+	"AVG":         window.Mean,
+	"MIN":         window.Min,
+	"MAX":         window.Max,
+	"STDDEV":      window.Std,
+	"STDDEV_SAMP": window.Std,
+}
+
+// WindowAggregateExpression is an aggregate computed over the frame of each row in time order,
+// e.g. AVG(Close) OVER (ROWS 20 PRECEDING). The result is FLOAT64.
+// When IsWholeSet is true, every row has the aggregate of all the rows.
+type WindowAggregateExpression struct {
+	Func       window.Func
+	Value      Expression
+	Frame      window.Frame
+	IsWholeSet bool
+}
+
+func NewWindowAggregateExpression(fn window.Func, value Expression, frame window.Frame, isWholeSet bool,
+) *WindowAggregateExpression {
+	return &WindowAggregateExpression{Func: fn, Value: value, Frame: frame, IsWholeSet: isWholeSet}
+}
+
+func (we *WindowAggregateExpression) Evaluate(cs *io.ColumnSeries) (*ExpressionValue, error) {
+	value, err := we.Value.Evaluate(cs)
+	if err != nil {
+		return nil, err
+	}
+	col, err := castValue(value, io.FLOAT64)
+	if err != nil {
+		return nil, err
+	}
+	epochs := cs.GetEpoch()
+	if epochs == nil && we.Frame.Range > 0 {
+		return nil, fmt.Errorf("a RANGE window frame needs the Epoch column")
+	}
+	//nolint:forcetypeassert // cast to FLOAT64
+	out, nulls := window.Aggregate(we.Func, col.([]float64), value.NullMask, epochs, we.Frame)
+	if we.IsWholeSet && len(out) > 0 {
+		last := len(out) - 1
+		for i := range out {
+			out[i], nulls[i] = out[last], nulls[last]
+		}
+	}
+	return &ExpressionValue{Column: out, Type: io.FLOAT64, NullMask: nulls}, nil
+}
+
+func (we *WindowAggregateExpression) GetIDs() []string {
+	return we.Value.GetIDs()
+}
+
+/*
+Utility Functions
+*/
+
+func evaluatePair(cs *io.ColumnSeries, left, right Expression) (lv, rv *ExpressionValue, err error) {
+	if lv, err = left.Evaluate(cs); err != nil {
+		return nil, nil, err
+	}
+	if rv, err = right.Evaluate(cs); err != nil {
+		return nil, nil, err
+	}
+	return lv, rv, nil
+}
+
+// promoteValues returns the type that both values are converted to in an arithmetic operation or a comparison.
+// A constant takes the type of the other value when it is of the same kind (integer or floating point),
+// or when it is an integer and the other is floating point.
+func promoteValues(left, right *ExpressionValue) io.EnumElementType {
+	if !left.Type.IsNumeric() || !right.Type.IsNumeric() {
+		return io.NONE
+	}
+	switch {
+	case left.IsConstant && !right.IsConstant && (!isFloatType(left.Type) || isFloatType(right.Type)):
+		return right.Type
+	case right.IsConstant && !left.IsConstant && (!isFloatType(right.Type) || isFloatType(left.Type)):
+		return left.Type
+	default:
+		return io.PromoteElementTypes(left.Type, right.Type)
+	}
+}
+
+func castPair(left, right *ExpressionValue, typ io.EnumElementType) (l, r interface{}, err error) {
+	if l, err = castValue(left, typ); err != nil {
+		return nil, nil, err
+	}
+	if r, err = castValue(right, typ); err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
+
+// castValue returns the values converted to the type. A boolean value is converted to 1 or 0.
+func castValue(value *ExpressionValue, typ io.EnumElementType) (interface{}, error) {
+	if value.Type == typ {
+		return value.Column, nil
+	}
+	col := value.Column
+	if value.Type == io.BOOL {
+		//nolint:forcetypeassert // BOOL values
+		bools := col.([]bool)
+		ints := make([]int64, len(bools))
+		for i, b := range bools {
+			if b {
+				ints[i] = 1
+			}
+		}
+		col = ints
+	}
+	if !typ.IsNumeric() || typ == io.DECIMAL64 {
+		return nil, fmt.Errorf("can not convert %s values to %s", value.Type, typ)
+	}
+	return io.CastColumn(col, typ)
+}
+
+// isNullConstant returns true for a NULL literal.
+func isNullConstant(value *ExpressionValue) bool {
+	if !value.IsConstant || value.NullMask == nil {
+		return false
+	}
+	for _, isNull := range value.NullMask {
+		if !isNull {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeNullMasks(n int, masks ...[]bool) (merged []bool) {
+	for _, mask := range masks {
+		if mask == nil {
+			continue
+		}
+		if merged == nil {
+			merged = make([]bool, n)
+		}
+		for i, isNull := range mask {
+			merged[i] = merged[i] || isNull
+		}
+	}
+	return merged
+}
+
+func isFloatType(typ io.EnumElementType) bool {
+	return typ == io.FLOAT32 || typ == io.FLOAT64
+}
+
+func isUnsignedType(typ io.EnumElementType) bool {
+	return typ == io.BYTE || typ == io.UINT8 || typ == io.UINT16 || typ == io.UINT32 || typ == io.UINT64
+}
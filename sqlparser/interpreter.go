@@ -10,6 +10,10 @@ import (
 
 // BuildQueryTree returns the query tree built from the parse tree.
 func BuildQueryTree(sourceString string) (tree IMSTree, err error) {
+	if isDDLStatement(sourceString) {
+		return buildDDLTree(sourceString)
+	}
+
 	input := NewInputStream(sourceString)
 	lexer := NewSQLBaseLexer(input)
 	lexErr := new(DescriptiveErrorListener)
@@ -1,7 +1,9 @@
 package sqlparser
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/alpacahq/marketstore/v4/catalog"
@@ -9,11 +11,21 @@ import (
 	"github.com/alpacahq/marketstore/v4/contrib/candler/tickcandler"
 	"github.com/alpacahq/marketstore/v4/uda"
 	"github.com/alpacahq/marketstore/v4/uda/adjust"
+	"github.com/alpacahq/marketstore/v4/uda/atr"
 	"github.com/alpacahq/marketstore/v4/uda/avg"
+	"github.com/alpacahq/marketstore/v4/uda/bollinger"
 	"github.com/alpacahq/marketstore/v4/uda/count"
+	"github.com/alpacahq/marketstore/v4/uda/ema"
 	"github.com/alpacahq/marketstore/v4/uda/gap"
+	"github.com/alpacahq/marketstore/v4/uda/macd"
 	"github.com/alpacahq/marketstore/v4/uda/max"
 	"github.com/alpacahq/marketstore/v4/uda/min"
+	"github.com/alpacahq/marketstore/v4/uda/orderbook"
+	"github.com/alpacahq/marketstore/v4/uda/realizedvol"
+	"github.com/alpacahq/marketstore/v4/uda/resample"
+	"github.com/alpacahq/marketstore/v4/uda/rsi"
+	"github.com/alpacahq/marketstore/v4/uda/vwap"
+	"github.com/alpacahq/marketstore/v4/uda/window"
 	"github.com/alpacahq/marketstore/v4/utils/functions"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
@@ -40,6 +52,22 @@ func NewDefaultAggRunner(catDir *catalog.Directory) *AggRunner {
 			"avg":           &avg.Avg{},
 			"gap":           &gap.Gap{},
 			"adjust":        &adjust.Adjust{CatalogDir: catDir},
+			"lag":           &window.Lag{},
+			"lead":          &window.Lag{Lead: true},
+			"rolling_sum":   &window.Rolling{Func: window.Sum},
+			"rolling_mean":  &window.Rolling{Func: window.Mean},
+			"rolling_min":   &window.Rolling{Func: window.Min},
+			"rolling_max":   &window.Rolling{Func: window.Max},
+			"rolling_std":   &window.Rolling{Func: window.Std},
+			"cumsum":        &window.Rolling{Func: window.Sum, Cumulative: true},
+			"ema":           &ema.EMA{},
+			"vwap":          &vwap.VWAP{},
+			"rsi":           &rsi.RSI{},
+			"macd":          &macd.MACD{},
+			"bollinger":     &bollinger.Bollinger{},
+			"atr":           &atr.ATR{},
+			"realizedvol":   &realizedvol.RealizedVol{},
+			"orderbook":     &orderbook.OrderBook{CatalogDir: catDir},
 		},
 	)
 }
@@ -48,14 +76,18 @@ func (ar *AggRunner) GetFunc(aggName string) uda.AggInterface {
 	return ar.registry[strings.ToLower(aggName)]
 }
 
-func (ar *AggRunner) Run(callChain []string, csInput *io.ColumnSeries, tbk io.TimeBucketKey,
+// Run executes the call chain on the column series. It stops between the calls when the context is done.
+func (ar *AggRunner) Run(ctx context.Context, callChain []string, csInput *io.ColumnSeries, tbk io.TimeBucketKey,
 ) (cs *io.ColumnSeries, err error) {
 	cs = nil
 	for _, call := range callChain {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
 		if cs != nil {
 			csInput = cs
 		}
-		aggName, literalList, parameterList, err := ParseFunctionCall(call)
+		aggName, literalList, parameterList, err := ar.ParseFunctionCall(call)
 		if err != nil {
 			return nil, err
 		}
@@ -110,10 +142,83 @@ func (ar *AggRunner) Run(callChain []string, csInput *io.ColumnSeries, tbk io.Ti
 	return cs, nil
 }
 
+// RunMap executes the call chain on all the column series of a query result.
+// The resample function aligns all the column series to a common index, so it's applied to the whole map,
+// and the other functions are applied to each column series.
+func (ar *AggRunner) RunMap(ctx context.Context, callChain []string, csm io.ColumnSeriesMap,
+) (io.ColumnSeriesMap, error) {
+	var perKey []string
+	runPerKey := func() error {
+		if len(perKey) == 0 {
+			return nil
+		}
+		for tbk, cs := range csm {
+			csOut, err := ar.Run(ctx, perKey, cs, tbk)
+			if err != nil {
+				return err
+			}
+			csm[tbk] = csOut
+		}
+		perKey = nil
+		return nil
+	}
+
+	for _, call := range callChain {
+		funcName, literalList, _, err := ar.ParseFunctionCall(call)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(funcName, resample.FunctionName) {
+			perKey = append(perKey, call)
+			continue
+		}
+		if err = runPerKey(); err != nil {
+			return nil, err
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		opts, err := resample.ParseOptions(literalList)
+		if err != nil {
+			return nil, err
+		}
+		if csm, err = resample.Resample(csm, opts); err != nil {
+			return nil, err
+		}
+		if opts.Pivot {
+			if csm, err = resample.Pivot(csm); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := runPerKey(); err != nil {
+		return nil, err
+	}
+	return csm, nil
+}
+
 // ParseFunctionCall parses a string to call an aggregator function.
 // e.g. "FuncName (P1, 'Lit1', P2,P3,P4, 'Lit2' , Sum::P5, Avg::P6)"
 // -> funcName="FuncName" , literalList=["Lit1", "Lit2"], parameterList=["P1","P2","P3","P4","Sum::P5", "Avg::P6"].
+// Unquoted numbers are parameters. Use AggRunner.ParseFunctionCall for the functions that take them as literals.
 func ParseFunctionCall(call string) (funcName string, literalList, parameterList []string, err error) {
+	return parseFunctionCall(call, func(string) bool { return false })
+}
+
+// ParseFunctionCall parses a string to call an aggregator function as the ParseFunctionCall function does,
+// except that unquoted numbers are literals too when the function in the registry implements
+// uda.NumericLiteralFunction, e.g. "rolling_mean('Close', 20)" -> literalList=["Close", "20"].
+func (ar *AggRunner) ParseFunctionCall(call string) (funcName string, literalList, parameterList []string, err error,
+) {
+	return parseFunctionCall(call, func(funcName string) bool {
+		f, ok := ar.GetFunc(funcName).(uda.NumericLiteralFunction)
+		return ok && f.NumericLiterals()
+	})
+}
+
+func parseFunctionCall(call string, numericLiteralsOf func(funcName string) bool,
+) (funcName string, literalList, parameterList []string, err error) {
 	call = strings.Trim(call, " ")
 	left := strings.Index(call, "(")
 	right := strings.LastIndex(call, ")")
@@ -122,6 +227,7 @@ func ParseFunctionCall(call string) (funcName string, literalList, parameterList
 	}
 	funcName = strings.Trim(call[:left], " ")
 	call = call[left+1 : right]
+	numericLiterals := numericLiteralsOf(funcName)
 	/*
 		First parse for literals and re-form a string without them for the last stage of parsing
 	*/
@@ -129,10 +235,14 @@ func ParseFunctionCall(call string) (funcName string, literalList, parameterList
 	for {
 		left = strings.Index(call, "'")
 		if left == -1 {
-			newCall = newCall + call
+			segment, numbers := splitNumbers(call, numericLiterals)
+			newCall += segment
+			literalList = append(literalList, numbers...)
 			break
 		} else if left != 0 {
-			newCall = newCall + call[:left]
+			segment, numbers := splitNumbers(call[:left], numericLiterals)
+			newCall += segment
+			literalList = append(literalList, numbers...)
 		}
 		call = call[left+1:]
 		right = strings.Index(call, "'")
@@ -151,3 +261,20 @@ func ParseFunctionCall(call string) (funcName string, literalList, parameterList
 	}
 	return funcName, literalList, parameterList, nil
 }
+
+// splitNumbers removes the numeric arguments from a comma separated list of arguments,
+// and returns the rest of the list and the numbers. The list is returned as is unless numeric is true.
+func splitNumbers(args string, numeric bool) (rest string, numbers []string) {
+	if !numeric {
+		return args, nil
+	}
+	tokens := strings.Split(args, ",")
+	for i, token := range tokens {
+		trimmed := strings.Trim(token, " ")
+		if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			numbers = append(numbers, trimmed)
+			tokens[i] = ""
+		}
+	}
+	return strings.Join(tokens, ","), numbers
+}
@@ -0,0 +1,75 @@
+package sqlparser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/sqlparser"
+)
+
+func TestParseFunctionCall(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		call           string
+		wantFuncName   string
+		wantLiterals   []string
+		wantParameters []string
+	}{
+		"ok/ candlecandler": {
+			call:           "candlecandler('1Min',Open,High,Low,Close,Sum::Volume)",
+			wantFuncName:   "candlecandler",
+			wantLiterals:   []string{"1Min"},
+			wantParameters: []string{"Open", "High", "Low", "Close", "Sum::Volume"},
+		},
+		"ok/ unquoted numbers are parameters of the functions that don't take numeric literals": {
+			call:           "count(1)",
+			wantFuncName:   "count",
+			wantLiterals:   nil,
+			wantParameters: []string{"1"},
+		},
+		"ok/ unquoted numbers are literals of the window functions": {
+			call:           "rolling_mean('Close', 20, 'MA20')",
+			wantFuncName:   "rolling_mean",
+			wantLiterals:   []string{"Close", "20", "MA20"},
+			wantParameters: nil,
+		},
+		"ok/ function names are case-insensitive": {
+			call:           "EMA(20, Price::Mid)",
+			wantFuncName:   "EMA",
+			wantLiterals:   []string{"20"},
+			wantParameters: []string{"Price::Mid"},
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// --- when ---
+			funcName, literals, parameters, err := sqlparser.NewDefaultAggRunner(nil).ParseFunctionCall(tt.call)
+
+			// --- then ---
+			require.Nil(t, err)
+			assert.Equal(t, tt.wantFuncName, funcName)
+			assert.Equal(t, tt.wantLiterals, literals)
+			assert.Equal(t, tt.wantParameters, parameters)
+		})
+	}
+}
+
+func TestParseFunctionCall_NoRegistry(t *testing.T) {
+	t.Parallel()
+
+	// --- when ---
+	funcName, literals, parameters, err := sqlparser.ParseFunctionCall("rolling_mean('Close', 20)")
+
+	// --- then ---
+	// without the registry, the function is unknown and the unquoted numbers are parameters
+	require.Nil(t, err)
+	assert.Equal(t, "rolling_mean", funcName)
+	assert.Equal(t, []string{"Close"}, literals)
+	assert.Equal(t, []string{"20"}, parameters)
+}
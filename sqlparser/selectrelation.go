@@ -22,7 +22,7 @@ type SelectRelation struct {
 	IsPrimary, IsSelectAll bool
 	PrimaryTargetName      []string
 	Subquery               *SelectRelation
-	WherePredicate         IMSTree // Runtime predicates
+	WherePredicate         Expression // Runtime predicates
 	SetQuantifier          SetQuantifierEnum
 	StaticPredicates       StaticPredicateGroup
 }
@@ -177,7 +177,7 @@ func (sr *SelectRelation) Materialize(aggRunner *AggRunner, catDir *catalog.Dire
 		// TODO: push down range predicates on Epoch column
 		checkForPredicatesAndFunctions := func() bool {
 			// First check for predicates - we don't push these down (even though we can for Epoch predicates)
-			if len(sr.StaticPredicates) != 0 {
+			if len(sr.StaticPredicates) != 0 || sr.WherePredicate != nil {
 				return true
 			}
 			// Check for functions on the relation
@@ -453,12 +453,81 @@ func (sr *SelectRelation) Materialize(aggRunner *AggRunner, catDir *catalog.Dire
 							}
 						}
 					}
+				case []io.Decimal64:
+					// compare in the scaled integer space to avoid float rounding noise
+					scale := outputColumnSeries.GetScale(name)
+					if sp.ContentsEnum.IsSet(EQUALITY) {
+						eqval, _ := io.GetValueAsFloat64(sp.equal)
+						for i, val := range col {
+							if io.CompareDecimal64(val, scale, eqval) != 0 {
+								removalBitmap[i] = true // remove
+							}
+						}
+					}
+					if sp.ContentsEnum.IsSet(MINBOUND) {
+						minval, _ := io.GetValueAsFloat64(sp.min)
+						for i, val := range col {
+							cmp := io.CompareDecimal64(val, scale, minval)
+							if sp.ContentsEnum.IsSet(INCLUSIVEMIN) {
+								if cmp < 0 {
+									removalBitmap[i] = true // remove
+								}
+							} else {
+								if cmp <= 0 {
+									removalBitmap[i] = true // remove
+								}
+							}
+						}
+					}
+					if sp.ContentsEnum.IsSet(MAXBOUND) {
+						maxval, _ := io.GetValueAsFloat64(sp.max)
+						for i, val := range col {
+							cmp := io.CompareDecimal64(val, scale, maxval)
+							if sp.ContentsEnum.IsSet(INCLUSIVEMAX) {
+								if cmp > 0 {
+									removalBitmap[i] = true // remove
+								}
+							} else {
+								if cmp >= 0 {
+									removalBitmap[i] = true // remove
+								}
+							}
+						}
+					}
+				}
+				/*
+					A null value only matches IS NULL, and never matches a comparison
+				*/
+				for i := range removalBitmap {
+					isNull := outputColumnSeries.IsNull(name, i)
+					switch {
+					case sp.ContentsEnum.IsSet(ISNULL):
+						if !isNull {
+							removalBitmap[i] = true // remove
+						}
+					case isNull && sp.ContentsEnum.AnySet(ISNOTNULL, EQUALITY, MINBOUND, MAXBOUND):
+						removalBitmap[i] = true // remove
+					}
 				}
 			}
 		}
 		_ = outputColumnSeries.RestrictViaBitmap(removalBitmap)
 	}
 
+	/*
+		Evaluate the runtime predicates and expressions in Select List
+	*/
+	if sr.WherePredicate != nil && outputColumnSeries.Len() != 0 {
+		if err = applyRuntimePredicate(outputColumnSeries, sr.WherePredicate); err != nil {
+			return nil, err
+		}
+	}
+	if !sr.IsSelectAll {
+		if err = sr.addComputedColumns(outputColumnSeries); err != nil {
+			return nil, err
+		}
+	}
+
 	/*
 		Handle functions in Select List
 	*/
@@ -518,8 +587,11 @@ func (sr *SelectRelation) Materialize(aggRunner *AggRunner, catDir *catalog.Dire
 				// TODO: Handle different argument types from string
 				var initArgList []string
 				for _, lit := range initList {
-					//nolint:forcetypeassert // hard to refactor for now
-					value := lit.Value.(string)
+					value, ok := lit.Value.(string)
+					if !ok { // a number, e.g. rolling_mean('Close', 20)
+						initArgList = append(initArgList, fmt.Sprint(lit.Value))
+						continue
+					}
 					value = value[1 : len(value)-1] // Strip the quotes
 					initArgList = append(
 						initArgList,
@@ -557,9 +629,13 @@ func (sr *SelectRelation) Materialize(aggRunner *AggRunner, catDir *catalog.Dire
 							outname = sl.Alias
 						}
 					}
-					selectListOutput.AddColumn(
+					outname = selectListOutput.AddColumn(
 						outname,
 						functionResult.GetColumn(name))
+					selectListOutput.SetScale(outname, functionResult.GetScale(name))
+					if functionResult.IsNullable(name) {
+						selectListOutput.SetNullMask(outname, functionResult.GetNullMask(name))
+					}
 				}
 			}
 		}
@@ -579,6 +655,10 @@ func (sr *SelectRelation) Materialize(aggRunner *AggRunner, catDir *catalog.Dire
 			for _, name := range selectListOutput.GetColumnNames() {
 				outputColumnSeries.AddColumn(name,
 					selectListOutput.GetColumn(name))
+				outputColumnSeries.SetScale(name, selectListOutput.GetScale(name))
+				if selectListOutput.IsNullable(name) {
+					outputColumnSeries.SetNullMask(name, selectListOutput.GetNullMask(name))
+				}
 			}
 		}
 	}
@@ -594,7 +674,7 @@ func (sr *SelectRelation) Materialize(aggRunner *AggRunner, catDir *catalog.Dire
 		}
 		// Column alias remapping on exit
 		for _, item := range sr.SelectList {
-			if item.IsAliased {
+			if item.IsAliased && item.IsPrimary {
 				err := outputColumnSeries.Rename(item.Alias, item.PrimaryName)
 				if err != nil {
 					return nil, err
@@ -613,6 +693,64 @@ func (sr *SelectRelation) Materialize(aggRunner *AggRunner, catDir *catalog.Dire
 	return outputColumnSeries, nil
 }
 
+// applyRuntimePredicate removes the rows for which the predicate is false or null.
+func applyRuntimePredicate(cs *io.ColumnSeries, predicate Expression) error {
+	value, err := predicate.Evaluate(cs)
+	if err != nil {
+		return err
+	}
+	matched, ok := value.Column.([]bool)
+	if !ok {
+		return fmt.Errorf("WHERE clause must be boolean, have: %s", value.Type)
+	}
+	removalBitmap := make([]bool, len(matched)) // true means we ditch the value, default is keep
+	for i := range matched {
+		removalBitmap[i] = !matched[i] || value.isNull(i)
+	}
+	_ = cs.RestrictViaBitmap(removalBitmap)
+	return nil
+}
+
+// addComputedColumns evaluates the expressions in the Select List and adds the results to the column series
+// under their aliases. All the expressions are evaluated before adding any, so that an alias can have the name of
+// a source column used by another expression.
+func (sr *SelectRelation) addComputedColumns(cs *io.ColumnSeries) error {
+	var hasFunctionCall bool
+	for _, sl := range sr.SelectList {
+		hasFunctionCall = hasFunctionCall || sl.IsFunctionCall
+	}
+
+	var names []string
+	var values []*ExpressionValue
+	for _, sl := range sr.SelectList {
+		if sl.RuntimeExpression == nil {
+			continue
+		}
+		if hasFunctionCall {
+			return fmt.Errorf("expressions can not be mixed with function calls in the select list")
+		}
+		value, err := sl.RuntimeExpression.Evaluate(cs)
+		if err != nil {
+			return fmt.Errorf("evaluate %s: %w", sl.Alias, err)
+		}
+		names = append(names, sl.Alias)
+		values = append(values, value)
+	}
+
+	for i, name := range names {
+		if cs.Exists(name) {
+			if err := cs.Remove(name); err != nil {
+				return err
+			}
+		}
+		cs.AddColumn(name, values[i].Column)
+		if values[i].NullMask != nil {
+			cs.SetNullMask(name, values[i].NullMask)
+		}
+	}
+	return nil
+}
+
 func (sr *SelectRelation) Explain() string {
 	if sr != nil {
 		jsonStruct, _ := json.Marshal(*sr)
@@ -702,6 +840,12 @@ func (spg StaticPredicateGroup) Merge(sp *StaticPredicate, IsOr bool) error {
 		tgtSP.ContentsEnum.AddOption(INLIST)
 		tgtSP.inlist = append(tgtSP.inlist, sp.inlist...)
 	}
+	if sp.ContentsEnum.IsSet(ISNULL) {
+		tgtSP.ContentsEnum.AddOption(ISNULL)
+	}
+	if sp.ContentsEnum.IsSet(ISNOTNULL) {
+		tgtSP.ContentsEnum.AddOption(ISNOTNULL)
+	}
 	return nil
 }
 
@@ -774,6 +918,8 @@ const (
 	INCLUSIVEMAX // Boundary should include the max value
 	EQUALITY
 	LIKEPATTERN
+	ISNULL    // Only the null values match
+	ISNOTNULL // Only the non-null values match
 )
 
 func (cat *StaticPredicateContentsEnum) AddOption(option StaticPredicateContentsEnum) {
@@ -848,7 +994,7 @@ func (sp *StaticPredicate) AddComparison(op io.ComparisonOperatorEnum,
 type AliasedIdentifier struct {
 	IsPrimary, IsAliased, IsFunctionCall bool
 	PrimaryName, Alias                   string
-	RuntimeExpression                    *ExpressionParse
+	RuntimeExpression                    Expression
 	FunctionCall                         *FunctionCallReference
 }
 
@@ -861,7 +1007,7 @@ func NewAliasedIdentifier(name ...string) (ai *AliasedIdentifier) {
 	return ai
 }
 
-func (ai *AliasedIdentifier) AddRuntimeExpression(ep *ExpressionParse) {
+func (ai *AliasedIdentifier) AddRuntimeExpression(ep Expression) {
 	ai.RuntimeExpression = ep
 }
 
@@ -898,26 +1044,38 @@ func SourceValidator(sourceDSV []io.DataShape, selectList []*AliasedIdentifier)
 		Given a source's DataShapes, verify that the target ID list is found within it
 	*/
 	// Get target names from identifiers
+	var requiredList []string // source columns needed by the target
 	for _, id := range selectList {
 		switch {
 		case id.IsFunctionCall:
 			if id.FunctionCall.IsAsterisk {
 				keepList = append(keepList, "Epoch")
+				requiredList = append(requiredList, "Epoch")
 			} else {
 				/*
 					Preprocess the parameters for parameterName::COLUMN_NAME pairs
 				*/
-				for _, token := range id.FunctionCall.GetIDs() {
+				ids := id.FunctionCall.GetIDs()
+				if len(ids) == 0 { // the arguments are all literals, e.g. rolling_mean('Close', 20)
+					ids = []string{"Epoch"}
+				}
+				for _, token := range ids {
 					args := strings.Split(token, "::")
 					keepList = append(keepList, args[len(args)-1])
+					requiredList = append(requiredList, args[len(args)-1])
 				}
 			}
+		case id.RuntimeExpression != nil:
+			// the computed column is added to the source under the alias
+			keepList = append(keepList, id.Alias)
+			requiredList = append(requiredList, id.RuntimeExpression.GetIDs()...)
 		case id.IsPrimary:
 			keepList = append(keepList, id.PrimaryName)
+			requiredList = append(requiredList, id.PrimaryName)
 		}
 	}
 	sourceNames := io.GetNamesFromDSV(sourceDSV)
-	targetNamesSet, err := io.NewAnySet(keepList)
+	targetNamesSet, err := io.NewAnySet(requiredList)
 	if err != nil {
 		return false, nil, nil, nil, fmt.Errorf("unable to build set for target")
 	}
@@ -0,0 +1,183 @@
+package sqlparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// ShowTablesStatement lists the tables (time buckets) in the catalog.
+// The result has Symbol, Timeframe, AttributeGroup and RecordType columns of STRING16, sorted by the table name.
+type ShowTablesStatement struct {
+	ExecutableStatement
+	QueryText string
+	// Pattern is the LIKE pattern of the table names, e.g. 'AAPL/%'. An empty pattern matches all the tables.
+	Pattern string
+}
+
+func NewShowTablesStatement(pattern, queryText string) (ss *ShowTablesStatement) {
+	ss = new(ShowTablesStatement)
+	ss.QueryText = queryText
+	ss.Pattern = pattern
+	return ss
+}
+
+func (ss *ShowTablesStatement) Materialize(catDir *catalog.Directory) (outputColumnSeries *io.ColumnSeries, err error) {
+	var re *regexp.Regexp
+	if ss.Pattern != "" {
+		if re, err = likePatternToRegexp(ss.Pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	var names []string
+	for _, name := range catalog.ListTimeBucketKeyNames(catDir) {
+		if re == nil || re.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	symbols := make([][16]rune, 0, len(names))
+	timeframes := make([][16]rune, 0, len(names))
+	attributeGroups := make([][16]rune, 0, len(names))
+	recordTypes := make([][16]rune, 0, len(names))
+	for _, name := range names {
+		tbk := io.NewTimeBucketKey(name)
+		tbi, err2 := catDir.GetLatestTimeBucketInfoFromKey(tbk)
+		if err2 != nil {
+			return nil, err2
+		}
+		symbols = append(symbols, toString16(tbk.GetItemInCategory("Symbol")))
+		timeframes = append(timeframes, toString16(tbk.GetItemInCategory("Timeframe")))
+		attributeGroups = append(attributeGroups, toString16(tbk.GetItemInCategory("AttributeGroup")))
+		recordTypes = append(recordTypes, toString16(strings.ToLower(tbi.GetRecordType().String())))
+	}
+
+	outputColumnSeries = io.NewColumnSeries()
+	outputColumnSeries.AddColumn("Epoch", nowEpochs(len(names)))
+	outputColumnSeries.AddColumn("Symbol", symbols)
+	outputColumnSeries.AddColumn("Timeframe", timeframes)
+	outputColumnSeries.AddColumn("AttributeGroup", attributeGroups)
+	outputColumnSeries.AddColumn("RecordType", recordTypes)
+	return outputColumnSeries, nil
+}
+
+func (ss *ShowTablesStatement) Explain() string {
+	if ss != nil {
+		jsonStruct, _ := json.Marshal(*ss)
+		return string(jsonStruct)
+	} else {
+		return "{}"
+	}
+}
+
+// ShowColumnsStatement lists the columns of a table.
+// The result has Column and Type columns of STRING16, where Type is the type string of the column (e.g. "f4", "i8?").
+type ShowColumnsStatement struct {
+	ExecutableStatement
+	QueryText string
+	TableName string
+}
+
+func NewShowColumnsStatement(tableName, queryText string) (ss *ShowColumnsStatement) {
+	ss = new(ShowColumnsStatement)
+	ss.QueryText = queryText
+	ss.TableName = tableName
+	return ss
+}
+
+func (ss *ShowColumnsStatement) Materialize(catDir *catalog.Directory) (outputColumnSeries *io.ColumnSeries, err error) {
+	tbk := io.NewTimeBucketKey(ss.TableName)
+	if tbk == nil {
+		return nil, fmt.Errorf("table name must be in the format `one/two/three`, have: %s",
+			ss.TableName)
+	}
+	tbi, err := catDir.GetLatestTimeBucketInfoFromKey(tbk)
+	if err != nil {
+		return nil, fmt.Errorf("table %s does not exist", ss.TableName)
+	}
+
+	dsv := tbi.GetDataShapesWithEpoch()
+	columns := make([][16]rune, 0, len(dsv))
+	types := make([][16]rune, 0, len(dsv))
+	for _, shape := range dsv {
+		if shape.Name == io.NullMaskColumn {
+			continue
+		}
+		typeStr, ok := io.DataShapeTypeStr(shape)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %v", shape.String())
+		}
+		columns = append(columns, toString16(shape.Name))
+		types = append(types, toString16(typeStr))
+	}
+
+	outputColumnSeries = io.NewColumnSeries()
+	outputColumnSeries.AddColumn("Epoch", nowEpochs(len(columns)))
+	outputColumnSeries.AddColumn("Column", columns)
+	outputColumnSeries.AddColumn("Type", types)
+	return outputColumnSeries, nil
+}
+
+func (ss *ShowColumnsStatement) Explain() string {
+	if ss != nil {
+		jsonStruct, _ := json.Marshal(*ss)
+		return string(jsonStruct)
+	} else {
+		return "{}"
+	}
+}
+
+/*
+Utility Functions
+*/
+
+// likePatternToRegexp converts a LIKE pattern, where % matches any string and _ matches any character,
+// to a regular expression.
+func likePatternToRegexp(pattern string) (*regexp.Regexp, error) {
+	var buffer strings.Builder
+	buffer.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			buffer.WriteString(".*")
+		case '_':
+			buffer.WriteString(".")
+		default:
+			buffer.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	buffer.WriteString("$")
+	return regexp.Compile(buffer.String())
+}
+
+// toString16 converts a string to a STRING16 value. Characters beyond 16 are truncated.
+func toString16(s string) (ret [16]rune) {
+	copy(ret[:], []rune(s))
+	return ret
+}
+
+// nowEpochs returns an Epoch column of the current time for a result that is not a time series.
+func nowEpochs(length int) []int64 {
+	now := time.Now().UTC().Unix()
+	epochs := make([]int64, length)
+	for i := range epochs {
+		epochs[i] = now
+	}
+	return epochs
+}
+
+// newStatementResult returns the result of a statement that is not a query, e.g. "Tables Created" = 1.
+func newStatementResult(name string, count int64) *io.ColumnSeries {
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", nowEpochs(1))
+	cs.AddColumn(name, []int64{count})
+	return cs
+}
@@ -47,6 +47,7 @@ type StatementParse struct {
 	sortItems, transactionModes                                         []IMSTree
 	statement, tableProperties                                          IMSTree
 	IsFrom, IsGrantOption, IsAll, IsExists                              bool
+	pattern                                                             string
 	QueryText                                                           string
 }
 
@@ -721,6 +722,9 @@ func NewBooleanExpressionParse(node antlr.Tree) (term *BooleanExpressionParse) {
 				term.value = true
 			}
 			done = true
+		default:
+			// e.g. an empty expression recovered from a syntax error
+			done = true
 		}
 		if done {
 			break
@@ -1147,7 +1151,7 @@ func NewPrimaryExpressionParse(node antlr.Tree) (term *PrimaryExpressionParse) {
 		}
 	case *parser.IntervalLiteralContext:
 		term.primaryType = INTERVAL_LITERAL
-		term.AddChild(NewIntervalParse(ctx))
+		term.AddChild(NewIntervalParse(ctx.Interval()))
 	case *parser.PositionContext:
 		term.primaryType = POSITION
 		for _, cctx := range ctx.AllValueExpression() {
@@ -1500,7 +1504,7 @@ func NewExtractParse(node antlr.Tree) (term *ExtractParse) {
 	ctx := node.(*parser.ExtractContext)
 	term = new(ExtractParse)
 	term.left = NewIDParse(ctx.Identifier())
-	term.right = NewIDParse(ctx.ValueExpression())
+	term.right = NewValueExpressionParse(ctx.ValueExpression())
 	return term
 }
 
@@ -1544,11 +1548,13 @@ func NewIntervalParse(node antlr.Tree) (term *IntervalParse) {
 	//nolint:forcetypeassert // hard to refactor for now
 	ctx := node.(*parser.IntervalContext)
 	term = new(IntervalParse)
-	switch ctx.GetSign().GetText() {
-	case "+":
-		term.IsPlus = true
-	case "-":
-		term.IsMinus = true
+	if ctx.GetSign() != nil {
+		switch ctx.GetSign().GetText() {
+		case "+":
+			term.IsPlus = true
+		case "-":
+			term.IsMinus = true
+		}
 	}
 	term.stringValue = ctx.STRING().GetText()
 	term.fromField = NewIntervalFieldParse(ctx.GetFrom())
@@ -1778,26 +1784,25 @@ type FrameBoundParse struct {
 
 func NewFrameBoundParse(node antlr.Tree) (term *FrameBoundParse) {
 	term = new(FrameBoundParse)
-	ictx := node.GetChild(0)
-	switch childCtx := ictx.(type) {
+	switch ctx := node.(type) {
 	case *parser.CurrentRowBoundContext:
 		term.IsCurrentRow = true
 	case *parser.UnboundedFrameContext:
 		term.IsUnbounded = true
 		switch {
-		case strings.EqualFold(childCtx.GetBoundType().GetText(), "PRECEDING"):
-			term.IsPreceding = true
-		case strings.EqualFold(childCtx.GetBoundType().GetText(), "FOLLOWING"):
+		case strings.EqualFold(ctx.GetBoundType().GetText(), "PRECEDING"):
 			term.IsPreceding = true
+		case strings.EqualFold(ctx.GetBoundType().GetText(), "FOLLOWING"):
+			term.IsFollowing = true
 		}
 	case *parser.BoundedFrameContext:
 		switch {
-		case strings.EqualFold(childCtx.GetBoundType().GetText(), "PRECEDING"):
-			term.IsPreceding = true
-		case strings.EqualFold(childCtx.GetBoundType().GetText(), "FOLLOWING"):
+		case strings.EqualFold(ctx.GetBoundType().GetText(), "PRECEDING"):
 			term.IsPreceding = true
+		case strings.EqualFold(ctx.GetBoundType().GetText(), "FOLLOWING"):
+			term.IsFollowing = true
 		}
-		term.AddChild(NewExpressionParse(childCtx))
+		term.AddChild(NewExpressionParse(ctx.Expression()))
 	}
 	return term
 }
@@ -1857,6 +1862,9 @@ func NewIDParse(node antlr.Tree) (term *IDParse) {
 	case *parser.BackQuotedIdentifierContext:
 		term.name = ctx.BACKQUOTED_IDENTIFIER().GetText()
 		term.name = term.name[1 : len(term.name)-1]
+	case *parser.NonReservedIdentifierContext:
+		// keywords usable as identifiers, e.g. HOUR in EXTRACT(HOUR FROM Epoch)
+		term.name = ctx.GetText()
 	}
 	return term
 }
@@ -1865,6 +1873,61 @@ func (v *IDParse) String(level int) (out []string) {
 	return append(out, PrependLevel(GetStructString(v), level))
 }
 
+/*
+================================================
+DDL block
+
+The DDL statements are not in the ANTLR grammar, and these terms are built
+by the DDL statement parser instead of the parse tree (see ddlparser.go).
+================================================.
+*/
+
+type TableElementParse struct {
+	MSTree
+	name, typeName string
+}
+
+func NewTableElementParse(name, typeName string) (term *TableElementParse) {
+	term = new(TableElementParse)
+	term.name = name
+	term.typeName = typeName
+	return term
+}
+
+func (v *TableElementParse) String(level int) (out []string) {
+	return append(out, PrependLevel(GetStructString(v), level))
+}
+
+type TablePropertiesParse struct{ MSTree }
+
+func NewTablePropertiesParse(properties ...*TablePropertyParse) (term *TablePropertiesParse) {
+	term = new(TablePropertiesParse)
+	for _, property := range properties {
+		term.AddChild(property)
+	}
+	return term
+}
+
+func (v *TablePropertiesParse) String(level int) (out []string) {
+	return append(out, PrependLevel(GetStructString(v), level))
+}
+
+type TablePropertyParse struct {
+	MSTree
+	name, value string
+}
+
+func NewTablePropertyParse(name, value string) (term *TablePropertyParse) {
+	term = new(TablePropertyParse)
+	term.name = name
+	term.value = value
+	return term
+}
+
+func (v *TablePropertyParse) String(level int) (out []string) {
+	return append(out, PrependLevel(GetStructString(v), level))
+}
+
 /*
 Utility Functions.
 */
@@ -488,6 +488,30 @@ func (v *IDParse) Accept(visitor IMSTreeVisitor) interface{} {
 		return t.VisitChildren(v)
 	}
 }
+func (v *TableElementParse) Accept(visitor IMSTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ISQLQueryTreeVisitor:
+		return t.VisitTableElementParse(v)
+	default:
+		return t.VisitChildren(v)
+	}
+}
+func (v *TablePropertiesParse) Accept(visitor IMSTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ISQLQueryTreeVisitor:
+		return t.VisitTablePropertiesParse(v)
+	default:
+		return t.VisitChildren(v)
+	}
+}
+func (v *TablePropertyParse) Accept(visitor IMSTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ISQLQueryTreeVisitor:
+		return t.VisitTablePropertyParse(v)
+	default:
+		return t.VisitChildren(v)
+	}
+}
 
 type ISQLQueryTreeVisitor interface {
 	IMSTreeVisitor
@@ -552,6 +576,9 @@ type ISQLQueryTreeVisitor interface {
 	VisitExplainOptionParse(ctx *ExplainOptionParse) interface{}
 	VisitQualifiedNameParse(ctx *QualifiedNameParse) interface{}
 	VisitIDParse(ctx *IDParse) interface{}
+	VisitTableElementParse(ctx *TableElementParse) interface{}
+	VisitTablePropertiesParse(ctx *TablePropertiesParse) interface{}
+	VisitTablePropertyParse(ctx *TablePropertyParse) interface{}
 }
 
 type BaseSQLQueryTreeVisitor struct {
@@ -755,3 +782,12 @@ func (v *BaseSQLQueryTreeVisitor) VisitQualifiedNameParse(ctx *QualifiedNamePars
 	return v.VisitChildren(ctx)
 }
 func (v *BaseSQLQueryTreeVisitor) VisitIDParse(ctx *IDParse) interface{} { return v.VisitChildren(ctx) }
+func (v *BaseSQLQueryTreeVisitor) VisitTableElementParse(ctx *TableElementParse) interface{} {
+	return v.VisitChildren(ctx)
+}
+func (v *BaseSQLQueryTreeVisitor) VisitTablePropertiesParse(ctx *TablePropertiesParse) interface{} {
+	return v.VisitChildren(ctx)
+}
+func (v *BaseSQLQueryTreeVisitor) VisitTablePropertyParse(ctx *TablePropertyParse) interface{} {
+	return v.VisitChildren(ctx)
+}
@@ -2,8 +2,11 @@ package adjust
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/alpacahq/marketstore/v4/catalog"
 	"github.com/alpacahq/marketstore/v4/uda"
@@ -18,6 +21,9 @@ const (
 	decimal         = 10
 )
 
+// knowledgeDateLayout is the layout of the knowledge date argument.
+const knowledgeDateLayout = "2006-01-02"
+
 var (
 	requiredColumns []io.DataShape
 
@@ -33,6 +39,8 @@ type Adjust struct {
 
 	AdjustDividend bool
 	AdjustSplit    bool
+	// KnownBefore limits the corporate actions to the ones known before the time, or is zero for all of them
+	KnownBefore time.Time
 
 	epochs         []int64
 	output         map[io.DataShape]interface{}
@@ -65,34 +73,37 @@ func (adj *Adjust) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggIn
 	return rn, err
 }
 
+// init parses the arguments of the adjust function: any of 'split' and 'dividend' (both by default),
+// and a knowledge date (e.g. '2021-06-30') to adjust by the corporate actions known on or before the date.
 func (adj *Adjust) init(args ...interface{}) error {
-	if len(args) == 0 {
-		adj.AdjustSplit = true
-		adj.AdjustDividend = true
-		return nil
-	}
-	adj.AdjustSplit = false
-	adj.AdjustDividend = false
+	var params []string
 	for _, arg := range args {
 		switch _arg := arg.(type) {
 		case []string:
-			for _, p := range _arg {
-				switch strings.ToLower(p) {
-				case calcSplit:
-					adj.AdjustSplit = true
-				case calcDividend:
-					adj.AdjustDividend = true
-				}
-			}
+			params = append(params, _arg...)
 		case string:
-			switch strings.ToLower(_arg) {
-			case calcSplit:
-				adj.AdjustSplit = true
-			case calcDividend:
-				adj.AdjustDividend = true
+			params = append(params, _arg)
+		}
+	}
+
+	for _, p := range params {
+		switch strings.ToLower(p) {
+		case calcSplit:
+			adj.AdjustSplit = true
+		case calcDividend:
+			adj.AdjustDividend = true
+		default:
+			knownAt, err := time.Parse(knowledgeDateLayout, p)
+			if err != nil {
+				return fmt.Errorf("adjust: unknown option %s", p)
 			}
+			adj.KnownBefore = knownAt.AddDate(0, 0, 1)
 		}
 	}
+	if !adj.AdjustSplit && !adj.AdjustDividend {
+		adj.AdjustSplit = true
+		adj.AdjustDividend = true
+	}
 	return nil
 }
 
@@ -108,7 +119,7 @@ func (adj *Adjust) Accum(tbk io.TimeBucketKey, _ *functions.ArgumentMap, cols io
 			continue
 		}
 		// hacky, hacky...
-		if ds.Type == io.FLOAT64 || ds.Name == "Volume" {
+		if ds.Type == io.FLOAT64 || ds.Type == io.DECIMAL64 || ds.Name == "Volume" {
 			adj.output[ds] = cols.GetColumn(ds.Name)
 		} else {
 			adj.skippedColumns[ds.Name] = cols.GetColumn(ds.Name)
@@ -116,19 +127,22 @@ func (adj *Adjust) Accum(tbk io.TimeBucketKey, _ *functions.ArgumentMap, cols io
 	}
 
 	symbol := tbk.GetItemInCategory("Symbol")
-	rateChanges := GetRateChanges(symbol, adj.AdjustSplit, adj.AdjustDividend,
+	adjustments := getAdjustments(symbol, adj.AdjustSplit, adj.AdjustDividend, adj.KnownBefore,
 		adj.CatalogDir,
 	)
-	if len(rateChanges) == 0 {
+	if len(adjustments.Changes) == 0 && len(adjustments.CashDividends) == 0 {
 		return adj.Output(), nil
 	}
+	rateChanges := adj.rateEvents(adjustments)
 
 	// always append a default no-op rate change to help avoid handling edge cases below
-	rateChanges = append(rateChanges, RateChange{Epoch: math.MaxInt64, Rate: 1, Textnumber: 0, Type: 0})
+	rateChanges = append(rateChanges, rateEvent{epoch: math.MaxInt64, rateAt: func(int) float64 { return 1 }})
 
 	// start with the default no-op rate 1.0
 	ri := len(rateChanges) - 1
-	rate := rateChanges[ri].Rate
+	rate := 1.0
+	// the volumes are not adjusted for the cash dividends
+	volumeRate := rate
 
 	// start from the end of the buffer and iterate backwards toward the beginning,
 	// applying rate changes as they occur in time
@@ -137,26 +151,82 @@ func (adj *Adjust) Accum(tbk io.TimeBucketKey, _ *functions.ArgumentMap, cols io
 		// 	- mainly for taking care of events occurred after the last epoch in the current dataseet
 		// 	- also handles a highly unlikely case when multiple rate change events occurs
 		//	    at the same time (e.g. split and dividend)
-		for ; ri > 0 && (epochs[i] < rateChanges[ri-1].Epoch); ri-- {
-			rate *= rateChanges[ri-1].Rate
+		for ; ri > 0 && (epochs[i] < rateChanges[ri-1].epoch); ri-- {
+			r := rateChanges[ri-1].rateAt(i)
+			rate *= r
+			if !rateChanges[ri-1].cash {
+				volumeRate *= r
+			}
 		}
 		for _, col := range adj.output {
 			switch c := col.(type) {
 			case []float64:
 				c[i] = math.Round((c[i]/rate)*RounderNum) / RounderNum
 			case []int64:
-				c[i] = int64(float64(c[i]) * rate)
+				c[i] = int64(float64(c[i]) * volumeRate)
+			case []io.Decimal64:
+				// the unscaled value is rounded to the scale of the column instead of roundToDecimals
+				c[i] = io.Decimal64(math.Round(float64(c[i]) / rate))
 			}
 		}
 	}
 	return adj.Output(), nil
 }
 
+// rateEvent is a rate change, or a cash dividend whose rate depends on the close price before its ex-date.
+type rateEvent struct {
+	epoch  int64
+	rateAt func(i int) float64
+	cash   bool
+}
+
+// rateEvents merges the rate changes and the cash dividends in the order of their epochs.
+// The rate of a cash dividend is Close / (Close - Amount) where Close is the (unadjusted) close price
+// of the last row before the ex-date, so the dividends are not adjusted without a Close column.
+func (adj *Adjust) rateEvents(adjustments RateChangeCache) []rateEvent {
+	events := make([]rateEvent, 0, len(adjustments.Changes)+len(adjustments.CashDividends)+1)
+	for _, rc := range adjustments.Changes {
+		rate := rc.Rate
+		events = append(events, rateEvent{epoch: rc.Epoch, rateAt: func(int) float64 { return rate }})
+	}
+	closePrice := adj.closePrice()
+	for _, d := range adjustments.CashDividends {
+		amount := d.Amount
+		events = append(events, rateEvent{epoch: d.Epoch, cash: true, rateAt: func(i int) float64 {
+			p, ok := closePrice(i)
+			if !ok || p <= amount {
+				return 1
+			}
+			return p / (p - amount)
+		}})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].epoch < events[j].epoch })
+	return events
+}
+
+// closePrice returns a function to get the value of the Close column at a row.
+func (adj *Adjust) closePrice() func(i int) (float64, bool) {
+	for ds, col := range adj.output {
+		if ds.Name != "Close" {
+			continue
+		}
+		switch c := col.(type) {
+		case []float64:
+			return func(i int) (float64, bool) { return c[i], true }
+		case []io.Decimal64:
+			scale := math.Pow(decimal, float64(ds.Scale))
+			return func(i int) (float64, bool) { return float64(c[i]) / scale, true }
+		}
+	}
+	return func(int) (float64, bool) { return 0, false }
+}
+
 func (adj *Adjust) Output() *io.ColumnSeries {
 	cs := io.NewColumnSeries()
 	cs.AddColumn("Epoch", adj.epochs)
 	for ds, column := range adj.output {
 		cs.AddColumn(ds.Name, column)
+		cs.SetScale(ds.Name, ds.Scale)
 	}
 	for name, column := range adj.skippedColumns {
 		cs.AddColumn(name, column)
@@ -6,10 +6,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/alpacahq/marketstore/v4/contrib/ice/enum"
 	"github.com/alpacahq/marketstore/v4/executor"
 	"github.com/alpacahq/marketstore/v4/internal/di"
+	"github.com/alpacahq/marketstore/v4/models"
+	modelsenum "github.com/alpacahq/marketstore/v4/models/enum"
 	"github.com/alpacahq/marketstore/v4/uda/adjust"
 	"github.com/alpacahq/marketstore/v4/utils"
 	"github.com/alpacahq/marketstore/v4/utils/functions"
@@ -359,3 +362,147 @@ func TestMultipleEventsOnDifferentDates(t *testing.T) {
 		evalCase(t, &testCase)
 	}
 }
+
+func TestDecimal(t *testing.T) {
+	symbol := "DEC"
+	tbk := io.NewTimeBucketKeyFromString(symbol + "/1D/OHLCV")
+	adj := adjust.Adjust{}
+	am := functions.NewArgumentMap(adj.GetRequiredArgs(), adj.GetOptionalArgs()...)
+	adjust.RateChangeCacheMap[adjust.CacheKey{symbol, true, true}] = adjust.RateChangeCache{
+		Changes:   []adjust.RateChange{{1, unixDate(2020, time.January, 2), enum.StockSplit, 3}},
+		Access:    0,
+		CreatedAt: time.Now(),
+	}
+
+	inputCs := io.NewColumnSeries()
+	inputCs.AddColumn("Epoch", []int64{unixDate(2020, time.January, 1), unixDate(2020, time.January, 2)})
+	inputCs.AddColumn("Price", []io.Decimal64{100000, 33333}) // 10.0000, 3.3333
+	inputCs.SetScale("Price", 4)
+
+	aggfunc, _ := adj.New(am)
+	outputCs, err := aggfunc.Accum(*tbk, am, inputCs)
+	assert.Nil(t, err)
+
+	// the adjusted price is rounded to the scale of the column
+	assert.Equal(t, []io.Decimal64{33333, 33333}, outputCs.GetColumn("Price"))
+	assert.Equal(t, int8(4), outputCs.GetScale("Price"))
+}
+
+func TestCorporateActions(t *testing.T) {
+	metadata := setup(t)
+
+	// OLD was renamed to NEW on Jan 6, after a 2-for-1 split on Jan 3
+	old := models.NewCorporateActions("OLD", 0)
+	old.Add(&models.CorporateAction{
+		ID: 1, KnownAt: date(2020, time.January, 2), Type: modelsenum.StockSplit,
+		ExDate: date(2020, time.January, 3), Ratio: 2,
+	})
+	// the actions of OLD after the ticker change are not applied to NEW
+	old.Add(&models.CorporateAction{
+		ID: 2, KnownAt: date(2020, time.January, 2), Type: modelsenum.StockSplit,
+		ExDate: date(2020, time.January, 8), Ratio: 10,
+	})
+	require.Nil(t, old.Write())
+	actions := models.NewCorporateActions("NEW", 0)
+	actions.Add(&models.CorporateAction{
+		ID: 1, KnownAt: date(2020, time.January, 2), Type: modelsenum.TickerChange,
+		ExDate: date(2020, time.January, 6), OldSymbol: "OLD",
+	})
+	// a cash dividend of 1 on the close price 9 before the ex-date, announced on Jan 5
+	actions.Add(&models.CorporateAction{
+		ID: 2, KnownAt: date(2020, time.January, 5), Type: modelsenum.CashDividend,
+		ExDate: date(2020, time.January, 5), CashAmount: 1,
+	})
+	require.Nil(t, actions.Write())
+	require.Nil(t, metadata.WALFile.FlushToWAL())
+
+	tests := map[string]struct {
+		args      []string
+		wantClose []float64
+	}{
+		"ok/ all the actions": {
+			wantClose: []float64{4.444, 4.444, 8, 8, 8},
+		},
+		"ok/ splits only": {
+			args:      []string{"split"},
+			wantClose: []float64{5, 5, 9, 8, 8},
+		},
+		"ok/ the actions known on Jan 4": {
+			args:      []string{"2020-01-04"},
+			wantClose: []float64{5, 5, 9, 8, 8},
+		},
+		"ok/ the actions known on Jan 5": {
+			args:      []string{"split", "dividend", "2020-01-05"},
+			wantClose: []float64{4.444, 4.444, 8, 8, 8},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// --- given ---
+			adjust.InvalidateRateChanges("NEW")
+			cs := io.NewColumnSeries()
+			cs.AddColumn("Epoch", []int64{
+				unixDate(2020, time.January, 1), unixDate(2020, time.January, 2), unixDate(2020, time.January, 4),
+				unixDate(2020, time.January, 6), unixDate(2020, time.January, 7),
+			})
+			cs.AddColumn("Close", []float64{10, 10, 9, 8, 8})
+			cs.AddColumn("Volume", []int64{100, 100, 100, 100, 100})
+			adj := adjust.Adjust{CatalogDir: metadata.CatalogDir}
+			am := functions.NewArgumentMap(adj.GetRequiredArgs(), adj.GetOptionalArgs()...)
+			aggfunc, err := adj.New(am, tt.args)
+			require.Nil(t, err)
+
+			// --- when ---
+			got, err := aggfunc.Accum(*io.NewTimeBucketKey("NEW/1D/OHLCV"), am, cs)
+
+			// --- then ---
+			require.Nil(t, err)
+			assert.Equal(t, tt.wantClose, got.GetColumn("Close"))
+			// the volumes are adjusted for the split only
+			assert.Equal(t, []int64{200, 200, 100, 100, 100}, got.GetColumn("Volume"))
+		})
+	}
+
+	// --- unknown arguments are rejected ---
+	_, err := (&adjust.Adjust{}).New(nil, []string{"2020/01/05"})
+	assert.NotNil(t, err)
+}
+
+func TestCorporateActionsOfRenamedSymbol(t *testing.T) {
+	metadata := setup(t)
+
+	// OLD was renamed to RENAMED on Jan 6 after a 2-for-1 split on Jan 3, and its data was moved to RENAMED
+	old := models.NewCorporateActions("OLD", 0)
+	old.Add(&models.CorporateAction{
+		ID: 1, KnownAt: date(2020, time.January, 2), Type: modelsenum.StockSplit,
+		ExDate: date(2020, time.January, 3), Ratio: 2,
+	})
+	require.Nil(t, old.Write())
+	require.Nil(t, metadata.WALFile.FlushToWAL())
+	require.Nil(t, metadata.CatalogDir.RenameSymbol("OLD", "RENAMED"))
+	actions := models.NewCorporateActions("RENAMED", 0)
+	actions.Add(&models.CorporateAction{
+		ID: 2, KnownAt: date(2020, time.January, 2), Type: modelsenum.TickerChange,
+		ExDate: date(2020, time.January, 6), OldSymbol: "OLD",
+	})
+	require.Nil(t, actions.Write())
+	require.Nil(t, metadata.WALFile.FlushToWAL())
+
+	// the split is applied once for either symbol
+	for _, symbol := range []string{"RENAMED", "OLD"} {
+		cs := io.NewColumnSeries()
+		cs.AddColumn("Epoch", []int64{unixDate(2020, time.January, 2), unixDate(2020, time.January, 7)})
+		cs.AddColumn("Close", []float64{10, 8})
+		adj := adjust.Adjust{CatalogDir: metadata.CatalogDir}
+		am := functions.NewArgumentMap(adj.GetRequiredArgs(), adj.GetOptionalArgs()...)
+		aggfunc, err := adj.New(am, nil)
+		require.Nil(t, err)
+
+		// --- when ---
+		got, err := aggfunc.Accum(*io.NewTimeBucketKey(symbol + "/1D/OHLCV"), am, cs)
+
+		// --- then ---
+		require.Nil(t, err)
+		assert.Equal(t, []float64{5, 8}, got.GetColumn("Close"), symbol)
+	}
+}
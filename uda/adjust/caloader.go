@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/alpacahq/marketstore/v4/catalog"
@@ -67,9 +68,11 @@ type Actions struct {
 }
 
 type RateChangeCache struct {
-	Changes   []RateChange
-	Access    int64
-	CreatedAt time.Time
+	Changes []RateChange
+	// CashDividends are the cash dividends of the vendor-neutral corporate actions
+	CashDividends []CashDividend
+	Access        int64
+	CreatedAt     time.Time
 }
 
 type CacheKey struct {
@@ -82,30 +85,81 @@ type RateChangeGetter func(string, bool, bool) []RateChange
 
 const CacheLifetime = 24 * time.Hour
 
-var RateChangeCacheMap = map[CacheKey]RateChangeCache{}
+var (
+	RateChangeCacheMap = map[CacheKey]RateChangeCache{}
+	rateChangeCacheMu  sync.Mutex
+)
 
 func GetRateChanges(symbol string, includeSplits, includeDividends bool,
 	catalogDir *catalog.Directory,
 ) []RateChange {
+	return getAdjustments(symbol, includeSplits, includeDividends, time.Time{}, catalogDir).Changes
+}
+
+// getAdjustments returns the rate changes and the cash dividends of the symbol known before the time.
+// The adjustments of all the known actions are cached when the time is zero.
+// An alias of a symbol has the adjustments of the symbol.
+func getAdjustments(symbol string, includeSplits, includeDividends bool, knownBefore time.Time,
+	catalogDir *catalog.Directory,
+) RateChangeCache {
+	if catalogDir != nil {
+		symbol = catalogDir.ResolveSymbol(symbol)
+	}
+	if !knownBefore.IsZero() {
+		return loadAdjustments(symbol, includeSplits, includeDividends, knownBefore, catalogDir)
+	}
+
 	key := CacheKey{Symbol: symbol, Splits: includeSplits, Dividends: includeDividends}
+	rateChangeCacheMu.Lock()
 	rateCache, present := RateChangeCacheMap[key]
+	rateChangeCacheMu.Unlock()
 	if present && time.Since(rateCache.CreatedAt) > CacheLifetime {
 		present = false
 	}
 	if !present {
-		ca := NewCorporateActions(symbol)
-		err := ca.Load(catalogDir)
-		if err != nil {
-			log.Error("load corporate actions from catalog: %v", err)
-		}
-		rateCache = RateChangeCache{
-			Changes:   ca.RateChangeEvents(includeSplits, includeDividends),
-			Access:    0,
-			CreatedAt: time.Now(),
-		}
+		rateCache = loadAdjustments(symbol, includeSplits, includeDividends, planner.MaxTime, catalogDir)
+		rateChangeCacheMu.Lock()
 		RateChangeCacheMap[key] = rateCache
+		rateChangeCacheMu.Unlock()
+	}
+	return rateCache
+}
+
+// InvalidateRateChanges removes the cached adjustments of the symbols, e.g. when their corporate actions are imported.
+func InvalidateRateChanges(symbols ...string) {
+	rateChangeCacheMu.Lock()
+	defer rateChangeCacheMu.Unlock()
+	for _, symbol := range symbols {
+		for key := range RateChangeCacheMap {
+			if key.Symbol == symbol {
+				delete(RateChangeCacheMap, key)
+			}
+		}
+	}
+}
+
+// loadAdjustments loads the adjustments from the ICE reorg records and the vendor-neutral corporate actions.
+func loadAdjustments(symbol string, includeSplits, includeDividends bool, knownBefore time.Time,
+	catalogDir *catalog.Directory,
+) RateChangeCache {
+	ca := NewCorporateActions(symbol)
+	err := ca.Load(catalogDir)
+	if err != nil {
+		log.Error("load corporate actions from catalog: %v", err)
+	}
+	changes, dividends, err := loadCorporateActions(catalogDir, symbol, includeSplits, includeDividends,
+		knownBefore, map[string]bool{})
+	if err != nil {
+		log.Error("load vendor-neutral corporate actions from catalog: %v", err)
+	}
+	changes = append(ca.KnownBefore(knownBefore).RateChangeEvents(includeSplits, includeDividends), changes...)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Epoch < changes[j].Epoch })
+	return RateChangeCache{
+		Changes:       changes,
+		CashDividends: dividends,
+		Access:        0,
+		CreatedAt:     time.Now(),
 	}
-	return rateCache.Changes
 }
 
 func NewCorporateActions(symbol string) *Actions {
@@ -190,6 +244,32 @@ func CARowsFromColumnSeries(cs *io.ColumnSeries) (*CARows, error) {
 	return caRows, nil
 }
 
+// KnownBefore returns the actions with the rows entered before the time.
+func (act *Actions) KnownBefore(t time.Time) *Actions {
+	rows := NewCARows(0)
+	for i := 0; i < act.Len(); i++ {
+		if act.Rows.EntryDates[i] >= t.Unix() {
+			continue
+		}
+		rows.EntryDates = append(rows.EntryDates, act.Rows.EntryDates[i])
+		rows.TextNumbers = append(rows.TextNumbers, act.Rows.TextNumbers[i])
+		rows.UpdateTextNumbers = append(rows.UpdateTextNumbers, act.Rows.UpdateTextNumbers[i])
+		rows.DeleteTextNumbers = append(rows.DeleteTextNumbers, act.Rows.DeleteTextNumbers[i])
+		rows.NotificationTypes = append(rows.NotificationTypes, act.Rows.NotificationTypes[i])
+		rows.Statuses = append(rows.Statuses, act.Rows.Statuses[i])
+		rows.UpdatedNotificationTypes = append(rows.UpdatedNotificationTypes, act.Rows.UpdatedNotificationTypes[i])
+		rows.SecurityTypes = append(rows.SecurityTypes, act.Rows.SecurityTypes[i])
+		rows.VoluntaryMandatoryCodes = append(rows.VoluntaryMandatoryCodes, act.Rows.VoluntaryMandatoryCodes[i])
+		rows.EffectiveDates = append(rows.EffectiveDates, act.Rows.EffectiveDates[i])
+		rows.RecordDates = append(rows.RecordDates, act.Rows.RecordDates[i])
+		rows.ExpirationDates = append(rows.ExpirationDates, act.Rows.ExpirationDates[i])
+		rows.NewRates = append(rows.NewRates, act.Rows.NewRates[i])
+		rows.OldRates = append(rows.OldRates, act.Rows.OldRates[i])
+		rows.Rates = append(rows.Rates, act.Rows.Rates[i])
+	}
+	return &Actions{Symbol: act.Symbol, Tbk: act.Tbk, Rows: rows}
+}
+
 func (act *Actions) Len() int {
 	return len(act.Rows.EntryDates)
 }
@@ -0,0 +1,75 @@
+package adjust
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/contrib/ice/enum"
+	"github.com/alpacahq/marketstore/v4/models"
+	modelsenum "github.com/alpacahq/marketstore/v4/models/enum"
+)
+
+// CashDividend is a cash dividend per share paid to the holders before the ex-date (Epoch).
+type CashDividend struct {
+	Epoch  int64
+	Amount float64
+}
+
+// loadCorporateActions returns the rate changes and the cash dividends of the vendor-neutral corporate actions
+// of the symbol known before the time, in the order of their ex-dates.
+// The actions of the previous symbols of the ticker changes are included until the ex-date of the changes,
+// unless the previous symbol has been renamed to the symbol, as its actions have been moved with the rename.
+func loadCorporateActions(catalogDir *catalog.Directory, symbol string, includeSplits, includeDividends bool,
+	knownBefore time.Time, visited map[string]bool,
+) (changes []RateChange, dividends []CashDividend, err error) {
+	if catalogDir == nil {
+		return nil, nil, nil
+	}
+	symbol = catalogDir.ResolveSymbol(symbol)
+	if visited[symbol] {
+		return nil, nil, nil
+	}
+	visited[symbol] = true
+
+	revisions, err := models.ReadCorporateActions(catalogDir, symbol)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read corporate actions of %s: %w", symbol, err)
+	}
+	for _, action := range models.EffectiveCorporateActions(revisions, knownBefore) {
+		exDate := action.ExDate.Unix()
+		switch action.Type {
+		case modelsenum.StockSplit:
+			if includeSplits {
+				changes = append(changes,
+					RateChange{Textnumber: action.ID, Epoch: exDate, Type: enum.StockSplit, Rate: action.Ratio})
+			}
+		case modelsenum.StockDividend:
+			if includeDividends {
+				changes = append(changes,
+					RateChange{Textnumber: action.ID, Epoch: exDate, Type: enum.StockDividend, Rate: action.Ratio})
+			}
+		case modelsenum.CashDividend:
+			if includeDividends {
+				dividends = append(dividends, CashDividend{Epoch: exDate, Amount: action.CashAmount})
+			}
+		case modelsenum.TickerChange:
+			oldChanges, oldDividends, err2 := loadCorporateActions(catalogDir, action.OldSymbol,
+				includeSplits, includeDividends, knownBefore, visited)
+			if err2 != nil {
+				return nil, nil, err2
+			}
+			for _, c := range oldChanges {
+				if c.Epoch < exDate {
+					changes = append(changes, c)
+				}
+			}
+			for _, d := range oldDividends {
+				if d.Epoch < exDate {
+					dividends = append(dividends, d)
+				}
+			}
+		}
+	}
+	return changes, dividends, nil
+}
@@ -0,0 +1,96 @@
+package atr
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/uda/ema"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+const defaultPeriod = 14
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns = []io.DataShape{
+		{Name: "High", Type: io.FLOAT32},
+		{Name: "Low", Type: io.FLOAT32},
+		{Name: "Close", Type: io.FLOAT32},
+	}
+
+	initArgs []io.DataShape
+)
+
+// ATR adds the average true range to the input columns as "ATR", e.g. atr(14) or atr(14, Bid, Ask, Mid).
+// The true ranges are smoothed by Wilder's moving average, and the first value is at the row after
+// the first period true ranges. The columns are High, Low and Close of OHLCV buckets, or Price of tick buckets
+// unless specified. The period is 14 by default.
+type ATR struct {
+	uda.AggInterface
+
+	period int
+}
+
+func (a *ATR) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (a *ATR) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (a *ATR) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+// NumericLiterals makes the unquoted numbers of the call init arguments, e.g. the 14 of atr(14).
+func (a *ATR) NumericLiterals() bool {
+	return true
+}
+
+func (a *ATR) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	period, err := uda.IntInitArg(uda.InitArgs(args...), 0, defaultPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("atr: %w", err)
+	}
+	return &ATR{period: period}, nil
+}
+
+func (a *ATR) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	var highs, lows, closes []float64
+	for _, arg := range []struct {
+		name   string
+		values *[]float64
+	}{
+		{name: "High", values: &highs},
+		{name: "Low", values: &lows},
+		{name: "Close", values: &closes},
+	} {
+		colName, err := uda.MappedColumn(argMap, cols, arg.name, arg.name, "Price")
+		if err != nil {
+			return nil, err
+		}
+		if *arg.values, err = uda.ColumnToFloat64WithNaN(cols, colName); err != nil {
+			return nil, err
+		}
+	}
+
+	// the true range of the first row is unknown without the previous close
+	trueRanges := make([]float64, len(closes))
+	for i := range closes {
+		if i == 0 {
+			trueRanges[i] = math.NaN()
+			continue
+		}
+		trueRanges[i] = math.Max(highs[i]-lows[i],
+			math.Max(math.Abs(highs[i]-closes[i-1]), math.Abs(lows[i]-closes[i-1])))
+	}
+
+	cs := uda.CopyColumns(cols, "ATR")
+	uda.AddColumnWithNaN(cs, "ATR", ema.Series(trueRanges, a.period, ema.WilderAlpha(a.period)))
+	return cs, nil
+}
@@ -0,0 +1,108 @@
+package bollinger
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+const (
+	defaultPeriod = 20
+	defaultWidth  = 2
+)
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns = []io.DataShape{
+		{Name: "Price", Type: io.FLOAT32},
+	}
+
+	initArgs []io.DataShape
+
+	outputColumns = []string{"BBMiddle", "BBUpper", "BBLower"}
+)
+
+// Bollinger adds the Bollinger bands of the price to the input columns: "BBMiddle" is the simple moving average,
+// and "BBUpper" and "BBLower" are the average plus and minus a number of (population) standard deviations,
+// e.g. bollinger(20, 2). The price is the Close column of OHLCV buckets or the Price column of tick buckets
+// unless specified. The period is 20 and the width is 2 standard deviations by default.
+type Bollinger struct {
+	uda.AggInterface
+
+	period int
+	width  float64
+}
+
+func (b *Bollinger) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (b *Bollinger) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (b *Bollinger) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+// NumericLiterals makes the unquoted numbers of the call init arguments, e.g. the period and the width of bollinger(20, 2).
+func (b *Bollinger) NumericLiterals() bool {
+	return true
+}
+
+func (b *Bollinger) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	params := uda.InitArgs(args...)
+	bn := &Bollinger{}
+	var err error
+	if bn.period, err = uda.IntInitArg(params, 0, defaultPeriod); err != nil {
+		return nil, fmt.Errorf("bollinger: %w", err)
+	}
+	if bn.width, err = uda.FloatInitArg(params, 1, defaultWidth); err != nil {
+		return nil, fmt.Errorf("bollinger: %w", err)
+	}
+	return bn, nil
+}
+
+func (b *Bollinger) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	priceCol, err := uda.MappedColumn(argMap, cols, "Price", "Close", "Price")
+	if err != nil {
+		return nil, err
+	}
+	prices, err := uda.ColumnToFloat64WithNaN(cols, priceCol)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(prices)
+	middle := make([]float64, n)
+	upper := make([]float64, n)
+	lower := make([]float64, n)
+	for i := range prices {
+		if i+1 < b.period {
+			middle[i], upper[i], lower[i] = math.NaN(), math.NaN(), math.NaN()
+			continue
+		}
+		// a NaN price makes the bands of its windows NaN
+		var sum, sumSq float64
+		for _, p := range prices[i+1-b.period : i+1] {
+			sum += p
+			sumSq += p * p
+		}
+		mean := sum / float64(b.period)
+		std := math.Sqrt(math.Max(sumSq/float64(b.period)-mean*mean, 0))
+		middle[i] = mean
+		upper[i] = mean + b.width*std
+		lower[i] = mean - b.width*std
+	}
+
+	cs := uda.CopyColumns(cols, outputColumns...)
+	uda.AddColumnWithNaN(cs, "BBMiddle", middle)
+	uda.AddColumnWithNaN(cs, "BBUpper", upper)
+	uda.AddColumnWithNaN(cs, "BBLower", lower)
+	return cs, nil
+}
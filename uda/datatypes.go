@@ -45,6 +45,13 @@ type FunctionInterface interface {
 	GetInitArgs() []io.DataShape
 }
 
+// NumericLiteralFunction is implemented by the functions whose unquoted numeric arguments are init arguments
+// like the quoted literals, e.g. rolling_mean('Close', 20).
+// The unquoted numeric arguments of the other functions are column parameters.
+type NumericLiteralFunction interface {
+	NumericLiterals() bool
+}
+
 /*
 Utility Datatypes
 */
@@ -0,0 +1,112 @@
+package ema
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+const defaultPeriod = 20
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns = []io.DataShape{
+		{Name: "Price", Type: io.FLOAT32},
+	}
+
+	initArgs []io.DataShape
+)
+
+// EMA adds the exponential moving average of the price to the input columns as "EMA",
+// e.g. ema(20) or ema(20, Price::Mid). The price is the Close column of OHLCV buckets or the Price column
+// of tick buckets unless specified. The period is 20 by default.
+type EMA struct {
+	uda.AggInterface
+
+	period int
+}
+
+func (e *EMA) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (e *EMA) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (e *EMA) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+// NumericLiterals makes the unquoted numbers of the call init arguments, e.g. the 20 of ema(20).
+func (e *EMA) NumericLiterals() bool {
+	return true
+}
+
+func (e *EMA) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	period, err := uda.IntInitArg(uda.InitArgs(args...), 0, defaultPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("ema: %w", err)
+	}
+	return &EMA{period: period}, nil
+}
+
+func (e *EMA) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	priceCol, err := uda.MappedColumn(argMap, cols, "Price", "Close", "Price")
+	if err != nil {
+		return nil, err
+	}
+	prices, err := uda.ColumnToFloat64WithNaN(cols, priceCol)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := uda.CopyColumns(cols, "EMA")
+	uda.AddColumnWithNaN(cs, "EMA", Series(prices, e.period, Alpha(e.period)))
+	return cs, nil
+}
+
+// Alpha returns the smoothing factor of the exponential moving average of a period.
+func Alpha(period int) float64 {
+	return 2 / (float64(period) + 1)
+}
+
+// WilderAlpha returns the smoothing factor of Wilder's moving average of a period, used by RSI and ATR.
+func WilderAlpha(period int) float64 {
+	return 1 / float64(period)
+}
+
+// Series returns the exponential moving average of the values with the smoothing factor alpha.
+// The average is seeded with the simple average of the first period values, and is NaN before it.
+// A NaN value is skipped: the average of its row is NaN and the next row continues from the previous average.
+func Series(values []float64, period int, alpha float64) []float64 {
+	out := make([]float64, len(values))
+	var (
+		avg   float64
+		count int
+	)
+	for i, v := range values {
+		if math.IsNaN(v) {
+			out[i] = math.NaN()
+			continue
+		}
+		count++
+		switch {
+		case count < period:
+			avg += v
+			out[i] = math.NaN()
+			continue
+		case count == period:
+			avg = (avg + v) / float64(period)
+		default:
+			avg += alpha * (v - avg)
+		}
+		out[i] = avg
+	}
+	return out
+}
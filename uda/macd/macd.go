@@ -0,0 +1,105 @@
+package macd
+
+import (
+	"fmt"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/uda/ema"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+const (
+	defaultFastPeriod   = 12
+	defaultSlowPeriod   = 26
+	defaultSignalPeriod = 9
+)
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns = []io.DataShape{
+		{Name: "Price", Type: io.FLOAT32},
+	}
+
+	initArgs []io.DataShape
+
+	outputColumns = []string{"MACD", "MACDSignal", "MACDHist"}
+)
+
+// MACD adds the moving average convergence divergence of the price to the input columns:
+// "MACD" is the fast EMA minus the slow EMA, "MACDSignal" is the EMA of MACD and "MACDHist" is their difference.
+// e.g. macd(12, 26, 9). The price is the Close column of OHLCV buckets or the Price column of tick buckets
+// unless specified. The periods are 12, 26 and 9 by default.
+type MACD struct {
+	uda.AggInterface
+
+	fastPeriod, slowPeriod, signalPeriod int
+}
+
+func (m *MACD) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (m *MACD) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (m *MACD) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+// NumericLiterals makes the unquoted numbers of the call init arguments, e.g. the periods of macd(12, 26, 9).
+func (m *MACD) NumericLiterals() bool {
+	return true
+}
+
+func (m *MACD) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	params := uda.InitArgs(args...)
+	mn := &MACD{}
+	var err error
+	if mn.fastPeriod, err = uda.IntInitArg(params, 0, defaultFastPeriod); err != nil {
+		return nil, fmt.Errorf("macd: %w", err)
+	}
+	if mn.slowPeriod, err = uda.IntInitArg(params, 1, defaultSlowPeriod); err != nil {
+		return nil, fmt.Errorf("macd: %w", err)
+	}
+	if mn.signalPeriod, err = uda.IntInitArg(params, 2, defaultSignalPeriod); err != nil {
+		return nil, fmt.Errorf("macd: %w", err)
+	}
+	if mn.fastPeriod >= mn.slowPeriod {
+		return nil, fmt.Errorf("macd: the fast period %d must be shorter than the slow period %d",
+			mn.fastPeriod, mn.slowPeriod)
+	}
+	return mn, nil
+}
+
+func (m *MACD) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	priceCol, err := uda.MappedColumn(argMap, cols, "Price", "Close", "Price")
+	if err != nil {
+		return nil, err
+	}
+	prices, err := uda.ColumnToFloat64WithNaN(cols, priceCol)
+	if err != nil {
+		return nil, err
+	}
+
+	fast := ema.Series(prices, m.fastPeriod, ema.Alpha(m.fastPeriod))
+	slow := ema.Series(prices, m.slowPeriod, ema.Alpha(m.slowPeriod))
+	macd := make([]float64, len(prices))
+	for i := range macd {
+		macd[i] = fast[i] - slow[i]
+	}
+	signal := ema.Series(macd, m.signalPeriod, ema.Alpha(m.signalPeriod))
+	hist := make([]float64, len(prices))
+	for i := range hist {
+		hist[i] = macd[i] - signal[i]
+	}
+
+	cs := uda.CopyColumns(cols, outputColumns...)
+	uda.AddColumnWithNaN(cs, "MACD", macd)
+	uda.AddColumnWithNaN(cs, "MACDSignal", signal)
+	uda.AddColumnWithNaN(cs, "MACDHist", hist)
+	return cs, nil
+}
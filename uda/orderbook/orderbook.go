@@ -0,0 +1,125 @@
+package orderbook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/catalog"
+	"github.com/alpacahq/marketstore/v4/models"
+	"github.com/alpacahq/marketstore/v4/models/enum"
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns []io.DataShape
+
+	initArgs []io.DataShape
+)
+
+// OrderBook returns the order book of the symbol as of the last input row, reconstructed from
+// the stored snapshots and deltas (see models.ReconstructOrderBook), e.g. orderbook() or orderbook('10')
+// for the best 10 levels of each side. It's called on the query of the order book deltas or snapshots
+// of the symbol, e.g. "AAPL/1Sec/BOOKDELTA", so that the book is as of the end of the query.
+// Each output row is a level: the bids from the best to the worst followed by the asks,
+// with the Epoch and Nanoseconds of the time of the book, Side, Level (from 1), Price and Size.
+type OrderBook struct {
+	uda.AggInterface
+
+	// Depth is the number of the best levels of each side, or 0 for all the levels
+	Depth int
+
+	CatalogDir *catalog.Directory
+}
+
+func (ob *OrderBook) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (ob *OrderBook) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (ob *OrderBook) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+func (ob *OrderBook) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	depth, err := uda.IntInitArg(uda.InitArgs(args...), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderBook{Depth: depth, CatalogDir: ob.CatalogDir}, nil
+}
+
+func (ob *OrderBook) Accum(tbk io.TimeBucketKey, _ *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	epochs, ok := cols.GetColumn("Epoch").([]int64)
+	if !ok {
+		return nil, errors.New("orderbook: input data must have an Epoch column")
+	}
+	if len(epochs) == 0 {
+		return newOutput(0).columnSeries(), nil
+	}
+	last := len(epochs) - 1
+	at := time.Unix(epochs[last], 0)
+	if nanos, ok2 := cols.GetColumn("Nanoseconds").([]int32); ok2 {
+		at = time.Unix(epochs[last], int64(nanos[last]))
+	}
+
+	book, err := models.ReconstructOrderBook(ob.CatalogDir, tbk.GetItemInCategory("Symbol"), at, ob.Depth)
+	if err != nil {
+		return nil, err
+	}
+
+	out := newOutput(len(book.Bids) + len(book.Asks))
+	out.add(at, enum.Bid, book.Bids)
+	out.add(at, enum.Ask, book.Asks)
+	return out.columnSeries(), nil
+}
+
+// output is the column buffers of the levels of the order book.
+type output struct {
+	epoch []int64
+	nanos []int32
+	side  []byte
+	level []int32
+	price []float64
+	size  []uint64
+}
+
+func newOutput(capacity int) *output {
+	return &output{
+		epoch: make([]int64, 0, capacity),
+		nanos: make([]int32, 0, capacity),
+		side:  make([]byte, 0, capacity),
+		level: make([]int32, 0, capacity),
+		price: make([]float64, 0, capacity),
+		size:  make([]uint64, 0, capacity),
+	}
+}
+
+func (o *output) add(at time.Time, side enum.BookSide, levels []models.BookLevel) {
+	for i, l := range levels {
+		o.epoch = append(o.epoch, at.Unix())
+		o.nanos = append(o.nanos, int32(at.Nanosecond()))
+		o.side = append(o.side, byte(side))
+		o.level = append(o.level, int32(i+1))
+		o.price = append(o.price, float64(l.Price))
+		o.size = append(o.size, uint64(l.Size))
+	}
+}
+
+func (o *output) columnSeries() *io.ColumnSeries {
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", o.epoch)
+	cs.AddColumn("Nanoseconds", o.nanos)
+	cs.AddColumn("Side", o.side)
+	cs.AddColumn("Level", o.level)
+	cs.AddColumn("Price", o.price)
+	cs.AddColumn("Size", o.size)
+	return cs
+}
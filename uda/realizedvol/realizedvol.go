@@ -0,0 +1,105 @@
+package realizedvol
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+const defaultWindow = 20
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns = []io.DataShape{
+		{Name: "Price", Type: io.FLOAT32},
+	}
+
+	initArgs []io.DataShape
+)
+
+// RealizedVol adds the realized volatility to the input columns as "RealizedVol": the square root of the sum
+// of the squared log returns of the last window rows, e.g. realizedvol(20).
+// With the number of periods per year, the volatility is annualized, e.g. realizedvol(20, 252) for daily bars.
+// The price is the Close column of OHLCV buckets or the Price column of tick buckets unless specified.
+// The window is 20 returns by default.
+type RealizedVol struct {
+	uda.AggInterface
+
+	window         int
+	periodsPerYear float64
+}
+
+func (r *RealizedVol) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (r *RealizedVol) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (r *RealizedVol) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+// NumericLiterals makes the unquoted numbers of the call init arguments, e.g. the 20 of realizedvol(20).
+func (r *RealizedVol) NumericLiterals() bool {
+	return true
+}
+
+func (r *RealizedVol) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	params := uda.InitArgs(args...)
+	rn := &RealizedVol{}
+	var err error
+	if rn.window, err = uda.IntInitArg(params, 0, defaultWindow); err != nil {
+		return nil, fmt.Errorf("realizedvol: %w", err)
+	}
+	if rn.periodsPerYear, err = uda.FloatInitArg(params, 1, 0); err != nil || rn.periodsPerYear < 0 {
+		return nil, fmt.Errorf("realizedvol: invalid number of periods per year: %v", params[1])
+	}
+	return rn, nil
+}
+
+func (r *RealizedVol) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	priceCol, err := uda.MappedColumn(argMap, cols, "Price", "Close", "Price")
+	if err != nil {
+		return nil, err
+	}
+	prices, err := uda.ColumnToFloat64WithNaN(cols, priceCol)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(prices)
+	squaredReturns := make([]float64, n)
+	for i := 1; i < n; i++ {
+		logReturn := math.Log(prices[i] / prices[i-1])
+		squaredReturns[i] = logReturn * logReturn
+	}
+	scale := 1.0
+	if r.periodsPerYear > 0 {
+		scale = math.Sqrt(r.periodsPerYear / float64(r.window))
+	}
+
+	vol := make([]float64, n)
+	for i := range vol {
+		if i < r.window {
+			vol[i] = math.NaN()
+			continue
+		}
+		// a NaN price makes the volatility of its windows NaN
+		var sum float64
+		for _, sq := range squaredReturns[i+1-r.window : i+1] {
+			sum += sq
+		}
+		vol[i] = math.Sqrt(sum) * scale
+	}
+
+	cs := uda.CopyColumns(cols, "RealizedVol")
+	uda.AddColumnWithNaN(cs, "RealizedVol", vol)
+	return cs, nil
+}
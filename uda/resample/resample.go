@@ -0,0 +1,355 @@
+// Package resample aligns the column series of a query result to a common Epoch index.
+//
+// resample('5Min', 'ffill', 'nasdaq', 'pivot') aggregates the rows of each symbol into 5 minute buckets,
+// and fills the buckets that a symbol doesn't have. The index is the union of the buckets of all the symbols,
+// or, with a market calendar, all the buckets of the market hours between the first and the last buckets.
+// With 'pivot', the result is a single wide column series with columns like AAPL_Close and MSFT_Close.
+package resample
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/contrib/calendar"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// FunctionName is the name of the function in a call chain.
+const FunctionName = "resample"
+
+// Fill is the policy to fill the buckets that a symbol has no row in.
+type Fill int
+
+const (
+	// FillNone leaves the values null.
+	FillNone Fill = iota
+	// FillForward repeats the previous values, except for the summed columns (Volume and Size) that are zero.
+	FillForward
+	// FillZero sets zero values.
+	FillZero
+	// FillNaN sets NaN to floating point columns and leaves the others null.
+	FillNaN
+)
+
+var fillNames = map[string]Fill{
+	"none":  FillNone,
+	"ffill": FillForward,
+	"zero":  FillZero,
+	"nan":   FillNaN,
+}
+
+// Options are the arguments of the resample function.
+type Options struct {
+	Timeframe *utils.CandleDuration
+	Fill      Fill
+	// Calendar restricts the index to the market hours, or is nil
+	Calendar *calendar.Calendar
+	// Pivot returns a single column series with a column per symbol and column
+	Pivot bool
+}
+
+// calendars are the market calendars available for the session option.
+var calendars = map[string]*calendar.Calendar{
+	"nasdaq": calendar.Nasdaq,
+}
+
+// ParseOptions parses the arguments of the resample function: the target timeframe,
+// followed by any of a fill policy (none, ffill, zero or nan), a market calendar (nasdaq) and 'pivot'.
+func ParseOptions(args []string) (*Options, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("resample: the timeframe is required")
+	}
+	cd, err := utils.CandleDurationFromString(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("resample: %w", err)
+	}
+	opts := &Options{Timeframe: cd}
+	for _, arg := range args[1:] {
+		name := strings.ToLower(arg)
+		if fill, ok := fillNames[name]; ok {
+			opts.Fill = fill
+			continue
+		}
+		if cal, ok := calendars[name]; ok {
+			opts.Calendar = cal
+			continue
+		}
+		if name == "pivot" {
+			opts.Pivot = true
+			continue
+		}
+		return nil, fmt.Errorf("resample: unknown option %s", arg)
+	}
+	return opts, nil
+}
+
+// Resample aggregates the rows of each column series into the buckets of the timeframe and aligns them
+// to a common index. In each bucket, Open is the first value, High the maximum, Low the minimum,
+// Volume and Size are summed, and the other columns have the last value. The null values are skipped.
+func Resample(csm io.ColumnSeriesMap, opts *Options) (io.ColumnSeriesMap, error) {
+	groups := make(map[io.TimeBucketKey]map[int64][]int, len(csm))
+	var buckets []int64
+	seen := map[int64]bool{}
+	for tbk, cs := range csm {
+		epochs := cs.GetEpoch()
+		if epochs == nil {
+			return nil, fmt.Errorf("resample: no Epoch column in %s", tbk.String())
+		}
+		rows := map[int64][]int{}
+		for i, epoch := range epochs {
+			if opts.Calendar != nil && !opts.rowInSession(epoch) {
+				continue
+			}
+			bucket := opts.truncate(epoch)
+			rows[bucket] = append(rows[bucket], i)
+			if !seen[bucket] {
+				seen[bucket] = true
+				buckets = append(buckets, bucket)
+			}
+		}
+		groups[tbk] = rows
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+	index := buckets
+	if opts.Calendar != nil && len(buckets) != 0 {
+		index = opts.sessionIndex(buckets[0], buckets[len(buckets)-1])
+	}
+
+	out := io.NewColumnSeriesMap()
+	for tbk, cs := range csm {
+		rcs, err := resampleSeries(cs, groups[tbk], index, opts.Fill)
+		if err != nil {
+			return nil, fmt.Errorf("resample %s: %w", tbk.String(), err)
+		}
+		key := tbk
+		key.SetItemInCategory("Timeframe", opts.Timeframe.String)
+		out.AddColumnSeries(key, rcs)
+	}
+	return out, nil
+}
+
+// Pivot returns the column series as a single column series keyed by all the symbols,
+// with a column named <Symbol>_<Column> for each column. The column series must share the Epoch index.
+func Pivot(csm io.ColumnSeriesMap) (io.ColumnSeriesMap, error) {
+	keys := csm.GetMetadataKeys()
+	if len(keys) == 0 {
+		return csm, nil
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].GetItemInCategory("Symbol") < keys[j].GetItemInCategory("Symbol")
+	})
+
+	wide := io.NewColumnSeries()
+	wide.AddColumn("Epoch", csm[keys[0]].GetEpoch())
+	symbols := make([]string, len(keys))
+	for i, tbk := range keys {
+		cs := csm[tbk]
+		if !reflect.DeepEqual(cs.GetEpoch(), wide.GetEpoch()) {
+			return nil, fmt.Errorf("pivot: the Epoch index of %s is different, resample first", tbk.String())
+		}
+		symbols[i] = tbk.GetItemInCategory("Symbol")
+		for _, name := range cs.GetColumnNames() {
+			if name == "Epoch" {
+				continue
+			}
+			wideName := symbols[i] + "_" + name
+			wide.AddColumn(wideName, cs.GetColumn(name))
+			wide.SetScale(wideName, cs.GetScale(name))
+			if cs.IsNullable(name) {
+				wide.SetNullMask(wideName, cs.GetNullMask(name))
+			}
+		}
+	}
+
+	key := keys[0]
+	key.SetItemInCategory("Symbol", strings.Join(symbols, ","))
+	out := io.NewColumnSeriesMap()
+	out.AddColumnSeries(key, wide)
+	return out, nil
+}
+
+// truncate returns the Epoch of the bucket of the epoch, in the system timezone.
+func (opts *Options) truncate(epoch int64) int64 {
+	return opts.Timeframe.Truncate(io.ToSystemTimezone(time.Unix(epoch, 0))).Unix()
+}
+
+// rowInSession returns true if the row is in the market hours, or on a market day for daily and longer buckets.
+func (opts *Options) rowInSession(epoch int64) bool {
+	if opts.Timeframe.Duration() >= utils.Day {
+		return opts.Calendar.IsMarketDay(time.Unix(epoch, 0).In(opts.Calendar.Tz()))
+	}
+	return opts.Calendar.EpochIsMarketOpen(epoch)
+}
+
+// bucketInSession returns true if the bucket overlaps the market hours, e.g. the 9:00-10:00 bucket of
+// a market opening at 9:30, or starts on a market day for daily and longer buckets.
+func (opts *Options) bucketInSession(bucket int64) bool {
+	if opts.Timeframe.Duration() >= utils.Day {
+		return opts.rowInSession(bucket)
+	}
+	end := bucket + int64(opts.Timeframe.Duration()/time.Second) - 1
+	return opts.Calendar.EpochIsMarketOpen(bucket) || opts.Calendar.EpochIsMarketOpen(end)
+}
+
+// sessionIndex returns all the buckets in the market hours from first to last.
+func (opts *Options) sessionIndex(first, last int64) (index []int64) {
+	t := io.ToSystemTimezone(time.Unix(first, 0))
+	for bucket := first; bucket <= last; {
+		if opts.bucketInSession(bucket) {
+			index = append(index, bucket)
+		}
+		t = opts.Timeframe.Ceil(t)
+		bucket = t.Unix()
+	}
+	return index
+}
+
+// aggregation is how the values of the rows in a bucket are combined.
+type aggregation int
+
+const (
+	aggLast aggregation = iota
+	aggFirst
+	aggMax
+	aggMin
+	aggSum
+)
+
+func aggregationOf(column string) aggregation {
+	switch column {
+	case "Open":
+		return aggFirst
+	case "High":
+		return aggMax
+	case "Low":
+		return aggMin
+	case "Volume", "Size":
+		return aggSum
+	default:
+		return aggLast
+	}
+}
+
+func resampleSeries(cs *io.ColumnSeries, rows map[int64][]int, index []int64, fill Fill) (*io.ColumnSeries, error) {
+	out := io.NewColumnSeries()
+	epochs := make([]int64, len(index))
+	copy(epochs, index)
+	out.AddColumn("Epoch", epochs)
+
+	for _, name := range cs.GetColumnNames() {
+		// the nanoseconds of the ticks are meaningless in the buckets
+		if name == "Epoch" || name == "Nanoseconds" {
+			continue
+		}
+		src := reflect.ValueOf(cs.GetColumn(name))
+		if src.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("column %s is not a slice", name)
+		}
+		srcNulls := cs.GetNullMask(name)
+		agg := aggregationOf(name)
+		if agg != aggLast && agg != aggFirst && !isNumeric(src.Type().Elem().Kind()) {
+			agg = aggLast
+		}
+
+		dst := reflect.MakeSlice(src.Type(), len(index), len(index))
+		nulls := make([]bool, len(index))
+		var hasNull bool
+		for k, bucket := range index {
+			value, ok := aggregate(src, srcNulls, rows[bucket], agg)
+			if !ok {
+				value, ok = fillValue(dst, nulls, k, src.Type().Elem(), agg, fill)
+			}
+			if !ok {
+				nulls[k] = true
+				hasNull = true
+				continue
+			}
+			dst.Index(k).Set(value)
+		}
+
+		out.AddColumn(name, dst.Interface())
+		out.SetScale(name, cs.GetScale(name))
+		if hasNull || cs.IsNullable(name) {
+			out.SetNullMask(name, nulls)
+		}
+	}
+	return out, nil
+}
+
+// aggregate returns the combined value of the non-null rows, or false if there is none.
+func aggregate(src reflect.Value, srcNulls []bool, rows []int, agg aggregation) (reflect.Value, bool) {
+	var (
+		result reflect.Value
+		acc    float64
+		found  bool
+	)
+	for _, i := range rows {
+		if i < len(srcNulls) && srcNulls[i] {
+			continue
+		}
+		v := src.Index(i)
+		switch {
+		case !found:
+			result = v
+			if agg == aggSum {
+				acc = toFloat(v)
+			}
+		case agg == aggFirst:
+		case agg == aggMax:
+			if toFloat(v) > toFloat(result) {
+				result = v
+			}
+		case agg == aggMin:
+			if toFloat(v) < toFloat(result) {
+				result = v
+			}
+		case agg == aggSum:
+			acc += toFloat(v)
+		default:
+			result = v
+		}
+		found = true
+	}
+	if found && agg == aggSum {
+		result = reflect.ValueOf(acc).Convert(src.Type().Elem())
+	}
+	return result, found
+}
+
+// fillValue returns the value of the k-th bucket that has no row, or false if it's null.
+func fillValue(dst reflect.Value, nulls []bool, k int, elemType reflect.Type, agg aggregation, fill Fill,
+) (reflect.Value, bool) {
+	switch {
+	case fill == FillZero, fill == FillForward && agg == aggSum:
+		return reflect.Zero(elemType), true
+	case fill == FillForward:
+		if k == 0 || nulls[k-1] {
+			return reflect.Value{}, false
+		}
+		return dst.Index(k - 1), true
+	case fill == FillNaN && (elemType.Kind() == reflect.Float32 || elemType.Kind() == reflect.Float64):
+		return reflect.ValueOf(math.NaN()).Convert(elemType), true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat(v reflect.Value) float64 {
+	return v.Convert(reflect.TypeOf(float64(0))).Float()
+}
@@ -0,0 +1,194 @@
+package resample_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/uda/resample"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+var t0 = time.Date(2021, time.March, 1, 15, 0, 0, 0, time.UTC).Unix()
+
+func bars(epochs []int64, closes []float64, volumes []int32) *io.ColumnSeries {
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", epochs)
+	cs.AddColumn("Open", closes)
+	cs.AddColumn("High", closes)
+	cs.AddColumn("Low", closes)
+	cs.AddColumn("Close", closes)
+	cs.AddColumn("Volume", volumes)
+	return cs
+}
+
+func minutes(ms ...int64) []int64 {
+	epochs := make([]int64, len(ms))
+	for i, m := range ms {
+		epochs[i] = t0 + m*60
+	}
+	return epochs
+}
+
+func testMap() io.ColumnSeriesMap {
+	csm := io.NewColumnSeriesMap()
+	// AAPL has bars in the 3 buckets, MSFT misses the second one
+	csm.AddColumnSeries(*io.NewTimeBucketKey("AAPL/1Min/OHLCV"),
+		bars(minutes(0, 1, 4, 5, 12), []float64{1, 3, 2, 4, 5}, []int32{10, 20, 30, 40, 50}))
+	csm.AddColumnSeries(*io.NewTimeBucketKey("MSFT/1Min/OHLCV"),
+		bars(minutes(2, 11), []float64{7, 8}, []int32{1, 2}))
+	return csm
+}
+
+func TestParseOptions(t *testing.T) {
+	t.Parallel()
+
+	opts, err := resample.ParseOptions([]string{"5Min", "FFILL", "nasdaq", "pivot"})
+	require.Nil(t, err)
+	assert.Equal(t, "5Min", opts.Timeframe.String)
+	assert.Equal(t, resample.FillForward, opts.Fill)
+	assert.NotNil(t, opts.Calendar)
+	assert.True(t, opts.Pivot)
+
+	_, err = resample.ParseOptions(nil)
+	assert.NotNil(t, err)
+	_, err = resample.ParseOptions([]string{"5Min", "backfill"})
+	assert.NotNil(t, err)
+	_, err = resample.ParseOptions([]string{"five minutes"})
+	assert.NotNil(t, err)
+}
+
+func TestResample(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fill        resample.Fill
+		wantClose   []float64
+		wantVolume  []int32
+		wantNulls   []bool
+		wantNullVol []bool
+	}{
+		"none": {
+			fill:        resample.FillNone,
+			wantClose:   []float64{7, 0, 8},
+			wantVolume:  []int32{1, 0, 2},
+			wantNulls:   []bool{false, true, false},
+			wantNullVol: []bool{false, true, false},
+		},
+		"forward fill repeats the prices and zeroes the volume": {
+			fill:        resample.FillForward,
+			wantClose:   []float64{7, 7, 8},
+			wantVolume:  []int32{1, 0, 2},
+			wantNulls:   nil,
+			wantNullVol: nil,
+		},
+		"zero": {
+			fill:        resample.FillZero,
+			wantClose:   []float64{7, 0, 8},
+			wantVolume:  []int32{1, 0, 2},
+			wantNulls:   nil,
+			wantNullVol: nil,
+		},
+		"nan is null for integers": {
+			fill:        resample.FillNaN,
+			wantClose:   []float64{7, math.NaN(), 8},
+			wantVolume:  []int32{1, 0, 2},
+			wantNulls:   nil,
+			wantNullVol: []bool{false, true, false},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := resample.ParseOptions([]string{"5Min"})
+			require.Nil(t, err)
+			opts.Fill = tt.fill
+			csm, err := resample.Resample(testMap(), opts)
+			require.Nil(t, err)
+			require.Len(t, csm, 2)
+
+			aapl := csm[*io.NewTimeBucketKey("AAPL/5Min/OHLCV")]
+			require.NotNil(t, aapl)
+			assert.Equal(t, minutes(0, 5, 10), aapl.GetEpoch())
+			assert.Equal(t, []float64{1, 4, 5}, aapl.GetColumn("Open"))
+			assert.Equal(t, []float64{3, 4, 5}, aapl.GetColumn("High"))
+			assert.Equal(t, []float64{1, 4, 5}, aapl.GetColumn("Low"))
+			assert.Equal(t, []float64{2, 4, 5}, aapl.GetColumn("Close"))
+			assert.Equal(t, []int32{60, 40, 50}, aapl.GetColumn("Volume"))
+
+			msft := csm[*io.NewTimeBucketKey("MSFT/5Min/OHLCV")]
+			require.NotNil(t, msft)
+			assert.Equal(t, minutes(0, 5, 10), msft.GetEpoch())
+			closes, ok := msft.GetColumn("Close").([]float64)
+			require.True(t, ok)
+			for i, want := range tt.wantClose {
+				if math.IsNaN(want) {
+					assert.True(t, math.IsNaN(closes[i]))
+					continue
+				}
+				assert.Equal(t, want, closes[i])
+			}
+			assert.Equal(t, tt.wantNulls, msft.GetNullMask("Close"))
+			assert.Equal(t, tt.wantVolume, msft.GetColumn("Volume"))
+			assert.Equal(t, tt.wantNullVol, msft.GetNullMask("Volume"))
+		})
+	}
+}
+
+func TestResampleSession(t *testing.T) {
+	t.Parallel()
+
+	// 2021-03-01 is a Monday, the market opens at 14:30 UTC and closes at 21:00 UTC
+	open := time.Date(2021, time.March, 1, 14, 30, 0, 0, time.UTC).Unix()
+	csm := io.NewColumnSeriesMap()
+	csm.AddColumnSeries(*io.NewTimeBucketKey("AAPL/1Min/OHLCV"), bars(
+		[]int64{open - 3600, open, open + 3*3600},
+		[]float64{1, 2, 3}, []int32{1, 2, 3},
+	))
+
+	opts, err := resample.ParseOptions([]string{"1H", "ffill", "nasdaq"})
+	require.Nil(t, err)
+	out, err := resample.Resample(csm, opts)
+	require.Nil(t, err)
+
+	cs := out[*io.NewTimeBucketKey("AAPL/1H/OHLCV")]
+	require.NotNil(t, cs)
+	// the pre-market bar is dropped, the index starts at the 14:00 bucket that contains the open,
+	// and the session hours without a bar are filled
+	hour := open - 1800
+	assert.Equal(t, []int64{hour, hour + 3600, hour + 2*3600, hour + 3*3600}, cs.GetEpoch())
+	assert.Equal(t, []float64{2, 2, 2, 3}, cs.GetColumn("Close"))
+	assert.Equal(t, []int32{2, 0, 0, 3}, cs.GetColumn("Volume"))
+}
+
+func TestPivot(t *testing.T) {
+	t.Parallel()
+
+	opts, err := resample.ParseOptions([]string{"5Min", "none"})
+	require.Nil(t, err)
+	csm, err := resample.Resample(testMap(), opts)
+	require.Nil(t, err)
+
+	wide, err := resample.Pivot(csm)
+	require.Nil(t, err)
+	require.Len(t, wide, 1)
+	cs := wide[*io.NewTimeBucketKey("AAPL,MSFT/5Min/OHLCV")]
+	require.NotNil(t, cs)
+	assert.Equal(t, []string{
+		"Epoch",
+		"AAPL_Open", "AAPL_High", "AAPL_Low", "AAPL_Close", "AAPL_Volume",
+		"MSFT_Open", "MSFT_High", "MSFT_Low", "MSFT_Close", "MSFT_Volume",
+	}, cs.GetColumnNames())
+	assert.Equal(t, []float64{2, 4, 5}, cs.GetColumn("AAPL_Close"))
+	assert.False(t, cs.IsNullable("AAPL_Close"))
+	assert.Equal(t, []bool{false, true, false}, cs.GetNullMask("MSFT_Close"))
+
+	// the column series must be aligned
+	_, err = resample.Pivot(testMap())
+	assert.NotNil(t, err)
+}
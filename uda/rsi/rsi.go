@@ -0,0 +1,109 @@
+package rsi
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/uda/ema"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+const (
+	defaultPeriod = 14
+	maxRSI        = 100
+)
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns = []io.DataShape{
+		{Name: "Price", Type: io.FLOAT32},
+	}
+
+	initArgs []io.DataShape
+)
+
+// RSI adds Wilder's relative strength index of the price to the input columns as "RSI", e.g. rsi(14).
+// The price is the Close column of OHLCV buckets or the Price column of tick buckets unless specified.
+// The first value is at the row after the first period price changes. The period is 14 by default.
+type RSI struct {
+	uda.AggInterface
+
+	period int
+}
+
+func (r *RSI) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (r *RSI) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (r *RSI) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+// NumericLiterals makes the unquoted numbers of the call init arguments, e.g. the 14 of rsi(14).
+func (r *RSI) NumericLiterals() bool {
+	return true
+}
+
+func (r *RSI) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	period, err := uda.IntInitArg(uda.InitArgs(args...), 0, defaultPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("rsi: %w", err)
+	}
+	return &RSI{period: period}, nil
+}
+
+func (r *RSI) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	priceCol, err := uda.MappedColumn(argMap, cols, "Price", "Close", "Price")
+	if err != nil {
+		return nil, err
+	}
+	prices, err := uda.ColumnToFloat64WithNaN(cols, priceCol)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := uda.CopyColumns(cols, "RSI")
+	uda.AddColumnWithNaN(cs, "RSI", Series(prices, r.period))
+	return cs, nil
+}
+
+// Series returns the relative strength index of the prices, with the average gains and losses
+// smoothed by Wilder's moving average.
+func Series(prices []float64, period int) []float64 {
+	n := len(prices)
+	gains := make([]float64, n)
+	losses := make([]float64, n)
+	for i := range prices {
+		if i == 0 {
+			gains[i], losses[i] = math.NaN(), math.NaN()
+			continue
+		}
+		change := prices[i] - prices[i-1]
+		// NaN if a price is NaN
+		gains[i] = math.Max(change, 0)
+		losses[i] = math.Max(-change, 0)
+	}
+	avgGains := ema.Series(gains, period, ema.WilderAlpha(period))
+	avgLosses := ema.Series(losses, period, ema.WilderAlpha(period))
+
+	out := make([]float64, n)
+	for i := range out {
+		switch {
+		case math.IsNaN(avgGains[i]):
+			out[i] = math.NaN()
+		case avgLosses[i] == 0:
+			out[i] = maxRSI
+		default:
+			out[i] = maxRSI - maxRSI/(1+avgGains[i]/avgLosses[i])
+		}
+	}
+	return out
+}
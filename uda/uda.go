@@ -2,7 +2,10 @@ package uda
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 
+	"github.com/alpacahq/marketstore/v4/utils/functions"
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
 
@@ -37,6 +40,12 @@ func ColumnToFloat32(cols io.ColumnInterface, name string) (outCol []float32, er
 		for i := range cc {
 			outCol[i] = float32(cc[i])
 		}
+	case []io.Decimal64:
+		scale := ColumnScale(cols, name)
+		outCol = make([]float32, len(cc))
+		for i := range cc {
+			outCol[i] = float32(cc[i].Float64(scale))
+		}
 	}
 	return outCol, nil
 }
@@ -72,6 +81,171 @@ func ColumnToFloat64(cols io.ColumnInterface, name string) (outCol []float64, er
 		for i := range cc {
 			outCol[i] = float64(cc[i])
 		}
+	case []int16:
+		outCol = make([]float64, len(cc))
+		for i := range cc {
+			outCol[i] = float64(cc[i])
+		}
+	case []int8:
+		outCol = make([]float64, len(cc))
+		for i := range cc {
+			outCol[i] = float64(cc[i])
+		}
+	case []uint64:
+		outCol = make([]float64, len(cc))
+		for i := range cc {
+			outCol[i] = float64(cc[i])
+		}
+	case []uint32:
+		outCol = make([]float64, len(cc))
+		for i := range cc {
+			outCol[i] = float64(cc[i])
+		}
+	case []uint16:
+		outCol = make([]float64, len(cc))
+		for i := range cc {
+			outCol[i] = float64(cc[i])
+		}
+	case []uint8:
+		outCol = make([]float64, len(cc))
+		for i := range cc {
+			outCol[i] = float64(cc[i])
+		}
+	case []io.Decimal64:
+		scale := ColumnScale(cols, name)
+		outCol = make([]float64, len(cc))
+		for i := range cc {
+			outCol[i] = cc[i].Float64(scale)
+		}
 	}
 	return outCol, nil
 }
+
+// ColumnScale returns the scale of the specified DECIMAL64 column, or 0 if it's not found.
+func ColumnScale(cols io.ColumnInterface, name string) int8 {
+	for _, ds := range cols.GetDataShapes() {
+		if ds.Name == name {
+			return ds.Scale
+		}
+	}
+	return 0
+}
+
+// ColumnToFloat64WithNaN converts the specified column to a new slice of float64, where the null values are NaN.
+func ColumnToFloat64WithNaN(cols io.ColumnInterface, name string) ([]float64, error) {
+	col, err := ColumnToFloat64(cols, name)
+	if err != nil {
+		return nil, err
+	}
+	if col == nil {
+		return nil, fmt.Errorf("unsupported type of column %s", name)
+	}
+	out := make([]float64, len(col))
+	copy(out, col)
+	for i, isNull := range NullMask(cols, name) {
+		if isNull {
+			out[i] = math.NaN()
+		}
+	}
+	return out, nil
+}
+
+// AddColumnWithNaN adds the values to the column series as a nullable column, where the NaN values are null.
+// The NaN values are replaced with zeros.
+func AddColumnWithNaN(cs *io.ColumnSeries, name string, values []float64) {
+	nulls := make([]bool, len(values))
+	for i, v := range values {
+		if math.IsNaN(v) {
+			values[i] = 0
+			nulls[i] = true
+		}
+	}
+	cs.AddColumn(name, values)
+	cs.SetNullMask(name, nulls)
+}
+
+// NullMask returns the null mask of the specified column, or nil if it's not nullable.
+func NullMask(cols io.ColumnInterface, name string) []bool {
+	if cs, ok := cols.(*io.ColumnSeries); ok {
+		return cs.GetNullMask(name)
+	}
+	return nil
+}
+
+// CopyColumns returns a new column series with the columns of the input except the excluded ones.
+// The column data is shared with the input.
+func CopyColumns(cols io.ColumnInterface, excluded ...string) *io.ColumnSeries {
+	cs := io.NewColumnSeries()
+	for _, ds := range cols.GetDataShapes() {
+		if contains(excluded, ds.Name) {
+			continue
+		}
+		cs.AddColumn(ds.Name, cols.GetColumn(ds.Name))
+		cs.SetScale(ds.Name, ds.Scale)
+		if mask := NullMask(cols, ds.Name); mask != nil {
+			cs.SetNullMask(ds.Name, mask)
+		}
+	}
+	return cs
+}
+
+// MappedColumn returns the name of the input column mapped to the argument, or the first of the default
+// columns found in the input when it's not mapped, e.g. "Close" for OHLCV buckets or "Price" for tick buckets.
+func MappedColumn(argMap *functions.ArgumentMap, cols io.ColumnInterface, argName string, defaults ...string,
+) (string, error) {
+	if mapped := argMap.GetMappedColumns(argName); len(mapped) != 0 {
+		return mapped[0].Name, nil
+	}
+	for _, name := range defaults {
+		if cols.GetColumn(name) != nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no column for %s, specify one of the input columns", argName)
+}
+
+// InitArgs returns the init arguments passed to New() as strings.
+func InitArgs(args ...interface{}) (params []string) {
+	for _, arg := range args {
+		switch val := arg.(type) {
+		case []string:
+			params = append(params, val...)
+		case string:
+			params = append(params, val)
+		}
+	}
+	return params
+}
+
+// IntInitArg returns the i-th init argument as a positive integer, or the default value if it's not given.
+func IntInitArg(params []string, i, defaultValue int) (int, error) {
+	if i >= len(params) {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(params[i])
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid argument, need a positive integer: %s", params[i])
+	}
+	return value, nil
+}
+
+// FloatInitArg returns the i-th init argument as a number, or the default value if it's not given.
+func FloatInitArg(params []string, i int, defaultValue float64) (float64, error) {
+	if i >= len(params) {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseFloat(params[i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid argument, need a number: %s", params[i])
+	}
+	return value, nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package uda_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/uda/atr"
+	"github.com/alpacahq/marketstore/v4/uda/bollinger"
+	"github.com/alpacahq/marketstore/v4/uda/ema"
+	"github.com/alpacahq/marketstore/v4/uda/macd"
+	"github.com/alpacahq/marketstore/v4/uda/realizedvol"
+	"github.com/alpacahq/marketstore/v4/uda/rsi"
+	"github.com/alpacahq/marketstore/v4/uda/vwap"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+var nan = math.NaN()
+
+// ohlcv returns 1Min bars that cross midnight UTC after the 6th bar.
+func ohlcv() *io.ColumnSeries {
+	base := time.Date(2021, 12, 1, 23, 54, 0, 0, time.UTC).Unix()
+	closes := []float64{44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08, 45.89, 46.03}
+	highs := []float64{44.84, 44.59, 44.65, 44.9, 44.83, 45.33, 45.6, 46.2, 46.34, 46.58, 46.39, 46.53}
+	lows := make([]float64, len(closes))
+	epochs := make([]int64, len(closes))
+	for i := range closes {
+		lows[i] = closes[i] - 0.4
+		epochs[i] = base + int64(i)*60
+	}
+	lows[5], lows[9] = 43.9, 45.5
+
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", epochs)
+	cs.AddColumn("High", highs)
+	cs.AddColumn("Low", lows)
+	cs.AddColumn("Close", closes)
+	cs.AddColumn("Volume", []int64{100, 200, 150, 300, 250, 100, 400, 350, 200, 150, 300, 250})
+	return cs
+}
+
+func TestIndicators(t *testing.T) {
+	t.Parallel()
+
+	// golden values computed independently, NaN for the null values
+	tests := map[string]struct {
+		agg      uda.AggInterface
+		args     []string
+		params   []string
+		expected map[string][]float64
+	}{
+		"ema": {
+			agg:  &ema.EMA{},
+			args: []string{"5"},
+			expected: map[string][]float64{"EMA": {
+				nan, nan, nan, nan, 44.104, 44.346, 44.5973333333, 44.8715555556, 45.1943703704,
+				45.4895802469, 45.6230534979, 45.758702332,
+			}},
+		},
+		"rsi": {
+			agg:  &rsi.RSI{},
+			args: []string{"5"},
+			expected: map[string][]float64{"RSI": {
+				nan, nan, nan, nan, nan, 61.8357487923, 67.1858774663, 72.8288907997, 78.8079470199,
+				81.6864676905, 72.0075513417, 74.7618931954,
+			}},
+		},
+		"macd": {
+			agg:  &macd.MACD{},
+			args: []string{"3", "6", "3"},
+			expected: map[string][]float64{
+				"MACD": {
+					nan, nan, nan, nan, nan, 0.2479166667, 0.3114583333, 0.3582291667, 0.4137574405,
+					0.4259093325, 0.3286908178, 0.2770140886,
+				},
+				"MACDSignal": {
+					nan, nan, nan, nan, nan, nan, nan, 0.3058680556, 0.359812748, 0.3928610402,
+					0.360775929, 0.3188950088,
+				},
+				"MACDHist": {
+					nan, nan, nan, nan, nan, nan, nan, 0.0523611111, 0.0539446925, 0.0330482922,
+					-0.0320851112, -0.0418809202,
+				},
+			},
+		},
+		"bollinger": {
+			agg:  &bollinger.Bollinger{},
+			args: []string{"5", "2"},
+			expected: map[string][]float64{
+				"BBMiddle": {nan, nan, nan, nan, 44.104, 44.202, 44.404, 44.658, 45.104, 45.454, 45.666, 45.852},
+				"BBUpper": {
+					nan, nan, nan, nan, 44.6355035277, 44.9901522696, 45.4494931851, 45.9265361642,
+					46.1299512659, 46.3734433098, 46.3774604697, 46.3183732411,
+				},
+				"BBLower": {
+					nan, nan, nan, nan, 43.5724964723, 43.4138477304, 43.3585068149, 43.3894638358,
+					44.0780487341, 44.5345566902, 44.9545395303, 45.3856267589,
+				},
+			},
+		},
+		"atr": {
+			agg:  &atr.ATR{},
+			args: []string{"5"},
+			expected: map[string][]float64{"ATR": {
+				nan, nan, nan, nan, nan, 1.228, 1.1624, 1.16592, 1.116736, 1.1093888, 1.06751104, 1.034008832,
+			}},
+		},
+		"realizedvol annualized": {
+			agg:  &realizedvol.RealizedVol{},
+			args: []string{"5", "252"},
+			expected: map[string][]float64{"RealizedVol": {
+				nan, nan, nan, nan, nan, 0.1708573132, 0.1714589411, 0.1783939582, 0.1687052415,
+				0.1277546004, 0.1041225257, 0.0974264878,
+			}},
+		},
+		"vwap resets at midnight": {
+			agg:    &vwap.VWAP{},
+			params: []string{"Price::Close", "Volume::Volume"},
+			expected: map[string][]float64{"VWAP": {
+				44.34, 44.1733333333, 44.1655555556, 43.9433333333, 44.04, 44.1118181818, 45.1,
+				45.2493333333, 45.3736842105, 45.47, 45.56, 45.6312121212,
+			}},
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			input := ohlcv()
+			argMap := functions.NewArgumentMap(tt.agg.GetRequiredArgs(), tt.agg.GetOptionalArgs()...)
+			require.Nil(t, argMap.PrepareArguments(tt.params))
+			agg, err := tt.agg.New(argMap, tt.args)
+			require.Nil(t, err)
+			cs, err := agg.Accum(io.TimeBucketKey{}, argMap, input)
+			require.Nil(t, err)
+
+			// the input columns are kept
+			assert.Equal(t, input.GetColumn("Close"), cs.GetColumn("Close"))
+			for column, expected := range tt.expected {
+				nulls := make([]bool, len(expected))
+				values := make([]float64, len(expected))
+				for i, v := range expected {
+					if math.IsNaN(v) {
+						nulls[i] = true
+						continue
+					}
+					values[i] = v
+				}
+				assert.Equal(t, nulls, cs.GetNullMask(column), column)
+				assert.InDeltaSlice(t, values, cs.GetColumn(column), 1e-9, column)
+			}
+		})
+	}
+}
+
+func TestIndicatorsOnTicks(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC).Unix()
+	ticks := io.NewColumnSeries()
+	ticks.AddColumn("Epoch", []int64{base, base + 1, base + 2, base + 3})
+	ticks.AddColumn("Price", []float32{10, 12, 11, 13})
+	ticks.AddColumn("Size", []int32{1, 3, 2, 2})
+
+	run := func(agg uda.AggInterface, args ...string) *io.ColumnSeries {
+		t.Helper()
+		argMap := functions.NewArgumentMap(agg.GetRequiredArgs(), agg.GetOptionalArgs()...)
+		require.Nil(t, argMap.PrepareArguments(nil))
+		aggfunc, err := agg.New(argMap, args)
+		require.Nil(t, err)
+		cs, err := aggfunc.Accum(io.TimeBucketKey{}, argMap, ticks)
+		require.Nil(t, err)
+		return cs
+	}
+
+	// the Price and Size columns are used by default
+	cs := run(&vwap.VWAP{})
+	assert.InDeltaSlice(t, []float64{10, 11.5, 11.333333333, 11.75}, cs.GetColumn("VWAP"), 1e-8)
+	cs = run(&ema.EMA{}, "2")
+	assert.Equal(t, []float64{0, 11, 11, 12.333333333333334}, cs.GetColumn("EMA"))
+	// the true range of a tick is the absolute price change
+	cs = run(&atr.ATR{}, "2")
+	assert.Equal(t, []float64{0, 0, 1.5, 1.75}, cs.GetColumn("ATR"))
+	assert.Equal(t, []bool{true, true, false, false}, cs.GetNullMask("ATR"))
+}
+
+func TestIndicatorArguments(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&ema.EMA{}).New(nil, []string{"0"})
+	assert.NotNil(t, err)
+	_, err = (&macd.MACD{}).New(nil, []string{"26", "12"})
+	assert.NotNil(t, err)
+	_, err = (&bollinger.Bollinger{}).New(nil, []string{"20", "two"})
+	assert.NotNil(t, err)
+
+	// a missing price column
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{1, 2})
+	cs.AddColumn("Bid", []float64{1, 2})
+	argMap := functions.NewArgumentMap(nil, (&rsi.RSI{}).GetOptionalArgs()...)
+	agg, err := (&rsi.RSI{}).New(argMap)
+	require.Nil(t, err)
+	_, err = agg.Accum(io.TimeBucketKey{}, argMap, cs)
+	assert.NotNil(t, err)
+}
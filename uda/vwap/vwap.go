@@ -0,0 +1,98 @@
+package vwap
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns = []io.DataShape{
+		{Name: "Price", Type: io.FLOAT32},
+		{Name: "Volume", Type: io.FLOAT32},
+	}
+
+	initArgs []io.DataShape
+)
+
+// VWAP adds the volume weighted average price of the session to the input columns as "VWAP", e.g. vwap()
+// or vwap(Price::Mid, Volume::Size). The average restarts at the first row of each day in the system timezone.
+// The price is the Close column of OHLCV buckets or the Price column of tick buckets, and the volume is
+// the Volume or Size column unless specified.
+type VWAP struct {
+	uda.AggInterface
+}
+
+func (v *VWAP) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (v *VWAP) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (v *VWAP) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+func (v *VWAP) New(_ *functions.ArgumentMap, _ ...interface{}) (uda.AggInterface, error) {
+	return &VWAP{}, nil
+}
+
+func (v *VWAP) Accum(_ io.TimeBucketKey, argMap *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	epochs, ok := cols.GetColumn("Epoch").([]int64)
+	if !ok {
+		return nil, errors.New("vwap: input data must have an Epoch column")
+	}
+	priceCol, err := uda.MappedColumn(argMap, cols, "Price", "Close", "Price")
+	if err != nil {
+		return nil, err
+	}
+	volumeCol, err := uda.MappedColumn(argMap, cols, "Volume", "Volume", "Size")
+	if err != nil {
+		return nil, err
+	}
+	prices, err := uda.ColumnToFloat64WithNaN(cols, priceCol)
+	if err != nil {
+		return nil, err
+	}
+	volumes, err := uda.ColumnToFloat64WithNaN(cols, volumeCol)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]float64, len(epochs))
+	var (
+		notional, volume float64
+		session          time.Time
+	)
+	for i, epoch := range epochs {
+		t := io.ToSystemTimezone(time.Unix(epoch, 0))
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		if !day.Equal(session) {
+			session = day
+			notional, volume = 0, 0
+		}
+		// the rows with a null price or volume are skipped
+		if !math.IsNaN(prices[i]) && !math.IsNaN(volumes[i]) {
+			notional += prices[i] * volumes[i]
+			volume += volumes[i]
+		}
+		if volume == 0 {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = notional / volume
+	}
+
+	cs := uda.CopyColumns(cols, "VWAP")
+	uda.AddColumnWithNaN(cs, "VWAP", out)
+	return cs, nil
+}
@@ -0,0 +1,90 @@
+package window
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+// Lag adds the value of a column some rows before (or after, for a lead) each row to the input columns.
+// The init arguments are the column name, the number of rows (1 by default) and the output column name, e.g.
+// lag('Close') or lead('Close', 5, 'Close5').
+type Lag struct {
+	uda.AggInterface
+
+	Lead bool
+
+	column, output string
+	offset         int
+}
+
+func (l *Lag) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (l *Lag) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (l *Lag) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+// NumericLiterals makes the unquoted numbers of the call init arguments, e.g. the 5 of lead('Close', 5).
+func (l *Lag) NumericLiterals() bool {
+	return true
+}
+
+func (l *Lag) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	params := uda.InitArgs(args...)
+	if len(params) == 0 {
+		return nil, errors.New("lag: the column name is required")
+	}
+	ln := &Lag{
+		Lead:   l.Lead,
+		column: params[0],
+		offset: 1,
+	}
+	if len(params) > 1 {
+		offset, err := strconv.Atoi(params[1])
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("lag: invalid number of rows: %s", params[1])
+		}
+		ln.offset = offset
+	}
+	if ln.Lead {
+		ln.output = fmt.Sprintf("%s_lead%d", ln.column, ln.offset)
+	} else {
+		ln.output = fmt.Sprintf("%s_lag%d", ln.column, ln.offset)
+	}
+	switch len(params) {
+	case 1, 2:
+	case 3:
+		ln.output = params[2]
+	default:
+		return nil, fmt.Errorf("lag: too many arguments: %v", params)
+	}
+	return ln, nil
+}
+
+func (l *Lag) Accum(_ io.TimeBucketKey, _ *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	column := cols.GetColumn(l.column)
+	if column == nil {
+		return nil, fmt.Errorf("unable to retrieve column named %s", l.column)
+	}
+	offset := l.offset
+	if l.Lead {
+		offset = -offset
+	}
+	result, nulls := Shift(column, uda.NullMask(cols, l.column), offset)
+	cs := uda.CopyColumns(cols, l.output)
+	cs.AddColumn(l.output, result)
+	cs.SetScale(l.output, uda.ColumnScale(cols, l.column))
+	cs.SetNullMask(l.output, nulls)
+	return cs, nil
+}
@@ -0,0 +1,129 @@
+package window
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/alpacahq/marketstore/v4/uda"
+	"github.com/alpacahq/marketstore/v4/utils"
+	"github.com/alpacahq/marketstore/v4/utils/functions"
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+var (
+	requiredColumns []io.DataShape
+
+	optionalColumns []io.DataShape
+
+	initArgs = []io.DataShape{
+		{Name: "Column", Type: io.STRING},
+	}
+)
+
+// Rolling computes a rolling aggregate of a column and adds it to the input columns.
+// The init arguments are the column name, the window and the output column name, e.g.
+// rolling_mean('Close', 20) for the mean of the last 20 rows, or
+// rolling_sum('Volume', '5Min', 'Volume5Min') for the sum over the last 5 minutes.
+// A cumulative aggregate has no window, e.g. cumsum('Volume').
+type Rolling struct {
+	uda.AggInterface
+
+	Func       Func
+	Cumulative bool
+
+	column, output string
+	frame          Frame
+}
+
+func (r *Rolling) GetRequiredArgs() []io.DataShape {
+	return requiredColumns
+}
+
+func (r *Rolling) GetOptionalArgs() []io.DataShape {
+	return optionalColumns
+}
+
+func (r *Rolling) GetInitArgs() []io.DataShape {
+	return initArgs
+}
+
+// NumericLiterals makes the unquoted numbers of the call init arguments, e.g. the 20 of rolling_mean('Close', 20).
+func (r *Rolling) NumericLiterals() bool {
+	return true
+}
+
+func (r *Rolling) New(_ *functions.ArgumentMap, args ...interface{}) (uda.AggInterface, error) {
+	params := uda.InitArgs(args...)
+	if len(params) == 0 {
+		return nil, errors.New("rolling: the column name is required")
+	}
+	rn := &Rolling{
+		Func:       r.Func,
+		Cumulative: r.Cumulative,
+		column:     params[0],
+	}
+	params = params[1:]
+
+	if r.Cumulative {
+		rn.output = fmt.Sprintf("%s_cum%s", rn.column, r.Func)
+	} else {
+		if len(params) == 0 {
+			return nil, errors.New("rolling: the window is required")
+		}
+		frame, err := ParseWindow(params[0])
+		if err != nil {
+			return nil, fmt.Errorf("rolling: %w", err)
+		}
+		rn.frame = frame
+		rn.output = fmt.Sprintf("%s_rolling_%s", rn.column, r.Func)
+		params = params[1:]
+	}
+
+	switch len(params) {
+	case 0:
+	case 1:
+		rn.output = params[0]
+	default:
+		return nil, fmt.Errorf("rolling: too many arguments: %v", params)
+	}
+	return rn, nil
+}
+
+func (r *Rolling) Accum(_ io.TimeBucketKey, _ *functions.ArgumentMap, cols io.ColumnInterface,
+) (*io.ColumnSeries, error) {
+	values, err := uda.ColumnToFloat64(cols, r.column)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return nil, fmt.Errorf("rolling: unsupported type of column %s", r.column)
+	}
+	epochs, ok := cols.GetColumn("Epoch").([]int64)
+	if !ok && r.frame.Range > 0 {
+		return nil, errors.New("rolling: input data must have an Epoch column")
+	}
+
+	result, nulls := Aggregate(r.Func, values, uda.NullMask(cols, r.column), epochs, r.frame)
+	cs := uda.CopyColumns(cols, r.output)
+	cs.AddColumn(r.output, result)
+	cs.SetNullMask(r.output, nulls)
+	return cs, nil
+}
+
+// ParseWindow parses the window of the rolling aggregates: a number of rows (e.g. "20")
+// or a duration (e.g. "5Min").
+func ParseWindow(window string) (Frame, error) {
+	if rows, err := strconv.Atoi(window); err == nil {
+		if rows <= 0 {
+			return Frame{}, fmt.Errorf("the number of rows must be positive: %s", window)
+		}
+		return Frame{Rows: rows}, nil
+	}
+	cd, err := utils.CandleDurationFromString(window)
+	if err != nil || cd.Duration() < time.Second {
+		return Frame{}, fmt.Errorf("invalid window: %s", window)
+	}
+	return Frame{Range: int64(cd.Duration() / time.Second)}, nil
+}
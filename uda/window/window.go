@@ -0,0 +1,149 @@
+package window
+
+import (
+	"math"
+	"reflect"
+)
+
+// Func is an aggregate function computed over the frame of each row.
+type Func int
+
+const (
+	Sum Func = iota
+	Mean
+	Min
+	Max
+	Std
+)
+
+func (f Func) String() string {
+	switch f {
+	case Sum:
+		return "sum"
+	case Mean:
+		return "mean"
+	case Min:
+		return "min"
+	case Max:
+		return "max"
+	case Std:
+		return "std"
+	default:
+		return "unknown"
+	}
+}
+
+// Frame is the set of rows that the aggregate of a row is computed over. A frame always ends at the current row.
+type Frame struct {
+	// Rows is the number of rows in the frame including the current row. Zero means all the preceding rows.
+	Rows int
+	// Range is the length of the frame in seconds. When positive, the frame holds the rows whose Epoch is
+	// at most Range seconds before the Epoch of the current row, and Rows is ignored.
+	Range int64
+}
+
+// excludes returns true if the j-th row is out of the frame of the i-th row (j <= i).
+func (fr Frame) excludes(epochs []int64, j, i int) bool {
+	switch {
+	case fr.Range > 0:
+		return epochs[i]-epochs[j] > fr.Range
+	case fr.Rows > 0:
+		return i-j+1 > fr.Rows
+	default:
+		return false
+	}
+}
+
+// Aggregate computes the aggregate over the frame of each row.
+// The null and NaN values are skipped, and the result is null when the frame has no value
+// (or less than two for the sample standard deviation).
+// The epochs are used only by Range frames and must be in ascending order.
+func Aggregate(fn Func, values []float64, nulls []bool, epochs []int64, frame Frame) (out []float64, outNulls []bool) {
+	n := len(values)
+	out = make([]float64, n)
+	outNulls = make([]bool, n)
+	skip := func(i int) bool {
+		return (i < len(nulls) && nulls[i]) || math.IsNaN(values[i])
+	}
+
+	var (
+		sum, sumSq float64
+		count      int
+		// indexes of the candidates for the minimum (or maximum) of the frame, with their values in
+		// ascending (or descending) order
+		candidates []int
+		start      int
+	)
+	for i := 0; i < n; i++ {
+		if !skip(i) {
+			v := values[i]
+			sum += v
+			sumSq += v * v
+			count++
+			switch fn {
+			case Min:
+				for len(candidates) > 0 && values[candidates[len(candidates)-1]] >= v {
+					candidates = candidates[:len(candidates)-1]
+				}
+				candidates = append(candidates, i)
+			case Max:
+				for len(candidates) > 0 && values[candidates[len(candidates)-1]] <= v {
+					candidates = candidates[:len(candidates)-1]
+				}
+				candidates = append(candidates, i)
+			}
+		}
+		for ; start < i && frame.excludes(epochs, start, i); start++ {
+			if !skip(start) {
+				v := values[start]
+				sum -= v
+				sumSq -= v * v
+				count--
+			}
+		}
+		for len(candidates) > 0 && candidates[0] < start {
+			candidates = candidates[1:]
+		}
+
+		if count == 0 || (fn == Std && count < 2) {
+			outNulls[i] = true
+			continue
+		}
+		switch fn {
+		case Sum:
+			out[i] = sum
+		case Mean:
+			out[i] = sum / float64(count)
+		case Min, Max:
+			out[i] = values[candidates[0]]
+		case Std:
+			c := float64(count)
+			variance := (sumSq - sum*sum/c) / (c - 1)
+			if variance < 0 { // rounding error
+				variance = 0
+			}
+			out[i] = math.Sqrt(variance)
+		}
+	}
+	return out, outNulls
+}
+
+// Shift returns the column shifted by offset rows, with the same type. A positive offset takes the values
+// of the preceding rows (lag) and a negative one the values of the following rows (lead).
+// The rows without a source row are null.
+func Shift(column interface{}, nulls []bool, offset int) (out interface{}, outNulls []bool) {
+	src := reflect.ValueOf(column)
+	n := src.Len()
+	dst := reflect.MakeSlice(src.Type(), n, n)
+	outNulls = make([]bool, n)
+	for i := 0; i < n; i++ {
+		j := i - offset
+		if j < 0 || j >= n {
+			outNulls[i] = true
+			continue
+		}
+		dst.Index(i).Set(src.Index(j))
+		outNulls[i] = j < len(nulls) && nulls[j]
+	}
+	return dst.Interface(), outNulls
+}
@@ -0,0 +1,109 @@
+package window_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/uda/window"
+)
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	values := []float64{1, 3, 2, math.NaN(), 5, 4}
+	nulls := []bool{false, false, false, false, true, false}
+	epochs := []int64{0, 60, 120, 180, 240, 600}
+
+	tests := map[string]struct {
+		fn            window.Func
+		frame         window.Frame
+		expected      []float64
+		expectedNulls []bool
+	}{
+		"ok/ rolling sum of rows skips NaN and nulls": {
+			fn:            window.Sum,
+			frame:         window.Frame{Rows: 2},
+			expected:      []float64{1, 4, 5, 2, 0, 4},
+			expectedNulls: []bool{false, false, false, false, true, false},
+		},
+		"ok/ cumulative sum": {
+			fn:            window.Sum,
+			frame:         window.Frame{},
+			expected:      []float64{1, 4, 6, 6, 6, 10},
+			expectedNulls: []bool{false, false, false, false, false, false},
+		},
+		"ok/ mean over a time range": {
+			fn:            window.Mean,
+			frame:         window.Frame{Range: 120},
+			expected:      []float64{1, 2, 2, 2.5, 2, 4},
+			expectedNulls: []bool{false, false, false, false, false, false},
+		},
+		"ok/ rolling min": {
+			fn:            window.Min,
+			frame:         window.Frame{Rows: 3},
+			expected:      []float64{1, 1, 1, 2, 2, 4},
+			expectedNulls: []bool{false, false, false, false, false, false},
+		},
+		"ok/ rolling max": {
+			fn:            window.Max,
+			frame:         window.Frame{Rows: 3},
+			expected:      []float64{1, 3, 3, 3, 2, 4},
+			expectedNulls: []bool{false, false, false, false, false, false},
+		},
+		"ok/ sample standard deviation needs two values": {
+			fn:            window.Std,
+			frame:         window.Frame{Rows: 2},
+			expected:      []float64{0, math.Sqrt2, math.Sqrt2 / 2, 0, 0, 0},
+			expectedNulls: []bool{true, false, false, true, true, true},
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, outNulls := window.Aggregate(tt.fn, values, nulls, epochs, tt.frame)
+			assert.Equal(t, tt.expectedNulls, outNulls)
+			assert.InDeltaSlice(t, tt.expected, out, 1e-9)
+		})
+	}
+}
+
+func TestShift(t *testing.T) {
+	t.Parallel()
+
+	column := []int64{10, 20, 30, 40}
+	nulls := []bool{false, true, false, false}
+
+	out, outNulls := window.Shift(column, nulls, 1)
+	assert.Equal(t, []int64{0, 10, 20, 30}, out)
+	assert.Equal(t, []bool{true, false, true, false}, outNulls)
+
+	out, outNulls = window.Shift(column, nil, -2)
+	assert.Equal(t, []int64{30, 40, 0, 0}, out)
+	assert.Equal(t, []bool{false, false, true, true}, outNulls)
+
+	out, outNulls = window.Shift(column, nil, 5)
+	assert.Equal(t, []int64{0, 0, 0, 0}, out)
+	assert.Equal(t, []bool{true, true, true, true}, outNulls)
+}
+
+func TestParseWindow(t *testing.T) {
+	t.Parallel()
+
+	frame, err := window.ParseWindow("20")
+	require.Nil(t, err)
+	assert.Equal(t, window.Frame{Rows: 20}, frame)
+
+	frame, err = window.ParseWindow("5Min")
+	require.Nil(t, err)
+	assert.Equal(t, window.Frame{Range: 300}, frame)
+
+	_, err = window.ParseWindow("0")
+	assert.NotNil(t, err)
+	_, err = window.ParseWindow("foo")
+	assert.NotNil(t, err)
+}
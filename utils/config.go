@@ -30,6 +30,33 @@ type ReplicationSetting struct {
 	RetryBackoffCoeff int
 }
 
+type QueryCacheSetting struct {
+	Enabled bool
+	// MaxBytes is the memory budget of the cached query results
+	MaxBytes int64
+}
+
+type QueryLimitSetting struct {
+	// Timeout is the deadline of each query request, or zero for no deadline
+	Timeout time.Duration
+	// MaxConcurrent is the number of query requests executed at the same time, the others wait in a queue.
+	// Zero is unlimited
+	MaxConcurrent int
+	// MaxRows and MaxBytes are the maximum size of the result of each query, or zero for no limit
+	MaxRows  int
+	MaxBytes int64
+}
+
+// ReadMode is how the year files are read by the queries.
+type ReadMode string
+
+const (
+	// ReadModePread reads the year files with read system calls.
+	ReadModePread ReadMode = "pread"
+	// ReadModeMmap reads the year files through their memory mappings.
+	ReadModeMmap ReadMode = "mmap"
+)
+
 type TriggerSetting struct {
 	Module string
 	On     string
@@ -42,6 +69,29 @@ type BgWorkerSetting struct {
 	Config map[string]interface{}
 }
 
+// WriteValidationSetting is the validation rules of the records written to the time buckets
+// whose key matches the On glob, e.g. "*/1Min/OHLCV".
+type WriteValidationSetting struct {
+	On    string
+	Rules []*ValidationRuleSetting
+}
+
+type ValidationRuleSetting struct {
+	// Name is used in the violations and the metrics. The Check is used if it's empty
+	Name string
+	// Check is one of "range", "ohlc", "monotonic", "max_jump" and "dedup"
+	Check string
+	// Action is one of "reject", "quarantine" and "fix"
+	Action  string
+	Columns []string
+	// Min, Max and NonZero are used by the range check
+	Min     *float64
+	Max     *float64
+	NonZero bool
+	// MaxChange is the maximum change ratio from the previous record used by the max_jump check, e.g. 0.5 for 50%
+	MaxChange float64
+}
+
 type MktsConfig struct {
 	// RootDirectory is the absolute path to the data directory
 	RootDirectory              string
@@ -54,21 +104,30 @@ type MktsConfig struct {
 	StopGracePeriod            time.Duration
 	WALRotateInterval          int
 	DisableVariableCompression bool
+	ReadMode                   ReadMode
+	ReadParallelism            int
 	InitCatalog                bool
+	CatalogIndex               bool
 	InitWALCache               bool
 	BackgroundSync             bool
 	WALBypass                  bool
 	StartTime                  time.Time
 	Replication                ReplicationSetting
+	QueryCache                 QueryCacheSetting
+	QueryLimits                QueryLimitSetting
 	Triggers                   []*TriggerSetting
 	BgWorkers                  []*BgWorkerSetting
+	WriteValidation            []*WriteValidationSetting
 }
 
 const (
 	// 2^20 = 1048576.
 	megabyteToByte                     = 1 << 20
 	defaultReplicationMasterListenPort = 5996
-	defaultWALRotateInterval           = 5 // * DiskRefreshInterval
+	defaultWALRotateInterval           = 5   // * DiskRefreshInterval
+	defaultQueryCacheMaxSize           = 256 // in MB
+	// defaultReadParallelism is small, as each reader of a query allocates the read buffers per worker.
+	defaultReadParallelism = 4
 )
 
 func NewDefaultConfig(rootDir string) *MktsConfig {
@@ -83,7 +142,10 @@ func NewDefaultConfig(rootDir string) *MktsConfig {
 		StopGracePeriod:            0,
 		WALRotateInterval:          defaultWALRotateInterval,
 		DisableVariableCompression: false,
+		ReadMode:                   ReadModePread,
+		ReadParallelism:            defaultReadParallelism,
 		InitCatalog:                true,
+		CatalogIndex:               false,
 		InitWALCache:               true,
 		BackgroundSync:             true,
 		WALBypass:                  false,
@@ -99,6 +161,10 @@ func NewDefaultConfig(rootDir string) *MktsConfig {
 			RetryInterval:     10 * time.Second,
 			RetryBackoffCoeff: 2,
 		},
+		QueryCache: QueryCacheSetting{
+			Enabled:  false,
+			MaxBytes: defaultQueryCacheMaxSize * megabyteToByte,
+		},
 		Triggers:  nil,
 		BgWorkers: nil,
 	}
@@ -118,7 +184,10 @@ type aux struct {
 	StopGracePeriod            int    `yaml:"stop_grace_period"`
 	WALRotateInterval          int    `yaml:"wal_rotate_interval"`
 	DisableVariableCompression string `yaml:"disable_variable_compression"`
+	ReadMode                   string `yaml:"read_mode"`
+	ReadParallelism            int    `yaml:"read_parallelism"`
 	InitCatalog                string `yaml:"init_catalog"`
+	CatalogIndex               string `yaml:"catalog_index"`
 	InitWALCache               string `yaml:"init_wal_cache"`
 	BackgroundSync             string `yaml:"background_sync"`
 	WALBypass                  string `yaml:"wal_bypass"`
@@ -133,6 +202,16 @@ type aux struct {
 		RetryInterval     time.Duration `yaml:"retry_interval"`
 		RetryBackoffCoeff int           `yaml:"retry_backoff_coeff"`
 	} `yaml:"replication"`
+	QueryCache struct {
+		Enabled   bool  `yaml:"enabled"`
+		MaxSizeMB int64 `yaml:"max_size_mb"`
+	} `yaml:"query_cache"`
+	QueryLimits struct {
+		Timeout       time.Duration `yaml:"timeout"`
+		MaxConcurrent int           `yaml:"max_concurrent"`
+		MaxRows       int           `yaml:"max_rows"`
+		MaxSizeMB     int64         `yaml:"max_size_mb"`
+	} `yaml:"query_limits"`
 	Triggers []struct {
 		Module string                 `yaml:"module"`
 		On     string                 `yaml:"on"`
@@ -143,6 +222,19 @@ type aux struct {
 		Name   string                 `yaml:"name"`
 		Config map[string]interface{} `yaml:"config"`
 	} `yaml:"bgworkers"`
+	WriteValidation []struct {
+		On    string `yaml:"on"`
+		Rules []struct {
+			Name      string   `yaml:"name"`
+			Check     string   `yaml:"check"`
+			Action    string   `yaml:"action"`
+			Columns   []string `yaml:"columns"`
+			Min       *float64 `yaml:"min"`
+			Max       *float64 `yaml:"max"`
+			NonZero   bool     `yaml:"nonzero"`
+			MaxChange float64  `yaml:"max_change"`
+		} `yaml:"rules"`
+	} `yaml:"write_validation"`
 }
 
 func ParseConfig(data []byte) (*MktsConfig, error) {
@@ -220,6 +312,23 @@ func ParseConfig(data []byte) (*MktsConfig, error) {
 		}
 	}
 
+	switch ReadMode(strings.ToLower(a.ReadMode)) {
+	case "":
+	case ReadModePread:
+		m.ReadMode = ReadModePread
+	case ReadModeMmap:
+		m.ReadMode = ReadModeMmap
+	default:
+		return nil, fmt.Errorf("invalid read_mode: %s", a.ReadMode)
+	}
+
+	if a.ReadParallelism < 0 {
+		return nil, fmt.Errorf("invalid read_parallelism: %d", a.ReadParallelism)
+	}
+	if a.ReadParallelism != 0 {
+		m.ReadParallelism = a.ReadParallelism
+	}
+
 	if a.InitCatalog != "" {
 		m.InitCatalog, err = strconv.ParseBool(a.InitCatalog)
 		if err != nil {
@@ -227,6 +336,13 @@ func ParseConfig(data []byte) (*MktsConfig, error) {
 		}
 	}
 
+	if a.CatalogIndex != "" {
+		m.CatalogIndex, err = strconv.ParseBool(a.CatalogIndex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for CatalogIndex: %w", err)
+		}
+	}
+
 	if a.InitWALCache != "" {
 		m.InitWALCache, err = strconv.ParseBool(a.InitWALCache)
 		if err != nil {
@@ -265,6 +381,25 @@ func ParseConfig(data []byte) (*MktsConfig, error) {
 		m.Replication.RetryBackoffCoeff = a.Replication.RetryBackoffCoeff
 	}
 
+	m.QueryCache.Enabled = a.QueryCache.Enabled
+	if a.QueryCache.MaxSizeMB < 0 {
+		return nil, fmt.Errorf("invalid query_cache.max_size_mb: %d", a.QueryCache.MaxSizeMB)
+	}
+	if a.QueryCache.MaxSizeMB != 0 {
+		m.QueryCache.MaxBytes = a.QueryCache.MaxSizeMB * megabyteToByte
+	}
+
+	if a.QueryLimits.Timeout < 0 || a.QueryLimits.MaxConcurrent < 0 ||
+		a.QueryLimits.MaxRows < 0 || a.QueryLimits.MaxSizeMB < 0 {
+		return nil, fmt.Errorf("invalid query_limits: %+v", a.QueryLimits)
+	}
+	m.QueryLimits = QueryLimitSetting{
+		Timeout:       a.QueryLimits.Timeout,
+		MaxConcurrent: a.QueryLimits.MaxConcurrent,
+		MaxRows:       a.QueryLimits.MaxRows,
+		MaxBytes:      a.QueryLimits.MaxSizeMB * megabyteToByte,
+	}
+
 	m.ListenURL = fmt.Sprintf("%v:%v", a.ListenHost, a.ListenPort)
 	if a.GRPCListenPort != "" {
 		m.GRPCListenURL = fmt.Sprintf("%v:%v", a.ListenHost, a.GRPCListenPort)
@@ -289,5 +424,22 @@ func ParseConfig(data []byte) (*MktsConfig, error) {
 		m.BgWorkers = append(m.BgWorkers, bgWorkerSetting)
 	}
 
+	for _, wv := range a.WriteValidation {
+		validationSetting := &WriteValidationSetting{On: wv.On}
+		for _, r := range wv.Rules {
+			validationSetting.Rules = append(validationSetting.Rules, &ValidationRuleSetting{
+				Name:      r.Name,
+				Check:     r.Check,
+				Action:    r.Action,
+				Columns:   r.Columns,
+				Min:       r.Min,
+				Max:       r.Max,
+				NonZero:   r.NonZero,
+				MaxChange: r.MaxChange,
+			})
+		}
+		m.WriteValidation = append(m.WriteValidation, validationSetting)
+	}
+
 	return m, nil
 }
@@ -106,6 +106,26 @@ func TestParameters(t *testing.T) {
 	assert.Equal(t, argMap.nameMap["E"][0].Name, "m")
 	assert.Equal(t, argMap.nameMap["F"][0].Name, "n")
 
+	/*
+		Some of the optional columns positionally specified
+	*/
+	argMap = NewArgumentMap(requiredColumns, optionalColumns...)
+	idList = []string{"i", "j", "k", "l", "m"}
+	err = argMap.PrepareArguments(idList)
+	assert.Nil(t, err)
+	assert.Equal(t, argMap.nameMap["E"][0].Name, "m")
+	assert.Nil(t, argMap.GetMappedColumns("F"))
+
+	/*
+		Only optional columns
+	*/
+	argMap = NewArgumentMap(nil, optionalColumns...)
+	err = argMap.PrepareArguments([]string{"m"})
+	assert.Nil(t, err)
+	assert.Equal(t, argMap.nameMap["E"][0].Name, "m")
+	err = argMap.PrepareArguments(nil)
+	assert.Nil(t, err)
+
 	/*
 		Insufficient params (error)
 	*/
@@ -211,17 +211,17 @@ func (am *ArgumentMap) PrepareArguments(inputs []string) (err error) {
 	/*
 		Consume any remaining inputs as positional optional parameters
 	*/
-	numRemaining := len(inputsRemaining) - (i + 1)
-	if numRemaining > 0 {
-		for _, optionalName := range unmappedOpts {
-			am.MapRequiredColumn(optionalName, io.DataShape{
-				Name: inputsRemaining[i], Type: io.FLOAT32,
-			})
-			i++
+	for _, optionalName := range unmappedOpts {
+		if i == len(inputsRemaining) {
+			break
 		}
+		am.MapRequiredColumn(optionalName, io.DataShape{
+			Name: inputsRemaining[i], Type: io.FLOAT32,
+		})
+		i++
 	}
 
-	numRemaining = len(inputsRemaining) - i
+	numRemaining := len(inputsRemaining) - i
 	if numRemaining != 0 {
 		return fmt.Errorf("extra args used: have %s, required %s, optional %s",
 			inputs, am.requiredNames, am.optionalNames)
@@ -14,18 +14,85 @@ func isIterable(i interface{}) bool {
 	return kind == reflect.Array || kind == reflect.Slice
 }
 
+// CoerceColumn replaces the data type of values in a column that has the name of the shape
+// to the type of the shape. DECIMAL64 values are converted to the scale of the shape.
+func (cs *ColumnSeries) CoerceColumn(shape DataShape) error {
+	if shape.Type != DECIMAL64 {
+		return cs.CoerceColumnType(shape.Name, shape.Type)
+	}
+
+	iCol := cs.GetColumn(shape.Name)
+	if !isIterable(iCol) {
+		return errors.New("bug! column values should be a slice or array")
+	}
+
+	var newCol []Decimal64
+	switch col := iCol.(type) {
+	case []Decimal64:
+		// rescale
+		from := cs.GetScale(shape.Name)
+		newCol = make([]Decimal64, len(col))
+		for i, v := range col {
+			newCol[i] = v.Rescale(from, shape.Scale)
+		}
+	default:
+		columnValues := reflect.ValueOf(iCol)
+		newCol = make([]Decimal64, columnValues.Len())
+		for i := 0; i < columnValues.Len(); i++ {
+			newCol[i] = NewDecimal64FromFloat(toFloat(columnValues.Index(i)), shape.Scale)
+		}
+	}
+	cs.columns[shape.Name] = newCol
+	cs.SetScale(shape.Name, shape.Scale)
+	return nil
+}
+
 // CoerceColumnType replaces the data type of values
 // in a column that has the specified name to the specified elementType.
+// Use CoerceColumn to coerce a column to DECIMAL64 with a scale.
 func (cs *ColumnSeries) CoerceColumnType(columnName string, elementType EnumElementType) (err error) {
 	if elementType == BOOL || elementType == STRING || elementType == STRING16 {
 		return fmt.Errorf("can not cast to boolean or string")
 	}
+	if elementType == DECIMAL64 {
+		return cs.CoerceColumn(DataShape{Name: columnName, Type: DECIMAL64})
+	}
 
 	iCol := cs.GetColumn(columnName)
 	if !isIterable(iCol) {
 		return errors.New("bug! column values should be a slice or array")
 	}
 
+	// decimal values are converted with their scale
+	if dec, ok := iCol.([]Decimal64); ok {
+		scale := cs.GetScale(columnName)
+		floats := make([]float64, len(dec))
+		for i, v := range dec {
+			floats[i] = v.Float64(scale)
+		}
+		iCol = floats
+		cs.SetScale(columnName, 0)
+	}
+
+	newCol, err := CastColumn(iCol, elementType)
+	if err != nil {
+		log.Error("unknown column type specified for column coerce:", elementType.Kind())
+		return nil
+	}
+	cs.columns[columnName] = newCol
+	return nil
+}
+
+// CastColumn returns the values of a column (e.g. []float32) converted to a slice of the specified numeric
+// elementType. Decimal values are converted as the scaled integers, so convert them with the scale beforehand.
+func CastColumn(iCol interface{}, elementType EnumElementType) (interface{}, error) {
+	if !isIterable(iCol) {
+		return nil, errors.New("bug! column values should be a slice or array")
+	}
+	switch from := GetElementType(iCol); from {
+	case BOOL, STRING, STRING16:
+		return nil, fmt.Errorf("can not cast %s values", from)
+	}
 	columnValues := reflect.ValueOf(iCol)
 
 	switch elementType.Kind() {
@@ -34,65 +101,64 @@ func (cs *ColumnSeries) CoerceColumnType(columnName string, elementType EnumElem
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = byte(toInt(columnValues.Index(i)))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Int16:
 		newCol := make([]int16, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = int16(toInt(columnValues.Index(i)))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Int32:
 		newCol := make([]int32, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = int32(toInt(columnValues.Index(i)))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Int64:
 		newCol := make([]int64, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = toInt(columnValues.Index(i))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Uint8:
 		newCol := make([]uint8, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = uint8(toUint(columnValues.Index(i)))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Uint16:
 		newCol := make([]uint16, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = uint16(toUint(columnValues.Index(i)))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Uint32:
 		newCol := make([]uint32, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = uint32(toUint(columnValues.Index(i)))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Uint64:
 		newCol := make([]uint64, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = toUint(columnValues.Index(i))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Float32:
 		newCol := make([]float32, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = float32(toFloat(columnValues.Index(i)))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	case reflect.Float64:
 		newCol := make([]float64, columnValues.Len())
 		for i := 0; i < columnValues.Len(); i++ {
 			newCol[i] = toFloat(columnValues.Index(i))
 		}
-		cs.columns[columnName] = newCol
+		return newCol, nil
 	default:
-		log.Error("unknown column type specified for column coerce:", elementType.Kind())
+		return nil, fmt.Errorf("unknown column type specified for column cast: %v", elementType.Kind())
 	}
-	return nil
 }
 
 var group = map[string]map[reflect.Kind]struct{}{
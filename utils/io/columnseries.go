@@ -37,15 +37,37 @@ type ColumnSeries struct {
 	columns       map[string]interface{} // key: column name, value: a slice of values of the column
 	orderedNames  []string
 	nameIncrement map[string]int
+	scales        map[string]int8   // key: column name, value: the scale of a DECIMAL64 column
+	nulls         map[string][]bool // key: column name, value: the null mask of a nullable column (true=null)
 }
 
 func NewColumnSeries() *ColumnSeries {
 	cs := new(ColumnSeries)
 	cs.columns = make(map[string]interface{})
 	cs.nameIncrement = make(map[string]int)
+	cs.scales = make(map[string]int8)
+	cs.nulls = make(map[string][]bool)
 	return cs
 }
 
+// SetScale sets the number of digits after the decimal point of a DECIMAL64 column.
+// The scale of the other types of columns is ignored.
+func (cs *ColumnSeries) SetScale(name string, scale int8) {
+	if cs.scales == nil {
+		cs.scales = make(map[string]int8)
+	}
+	if scale == 0 {
+		delete(cs.scales, name)
+		return
+	}
+	cs.scales[name] = scale
+}
+
+// GetScale returns the number of digits after the decimal point of a DECIMAL64 column.
+func (cs *ColumnSeries) GetScale(name string) int8 {
+	return cs.scales[name]
+}
+
 func (cs *ColumnSeries) GetColumn(name string) interface{} {
 	if !cs.Exists(name) {
 		return nil
@@ -59,7 +81,14 @@ func (cs *ColumnSeries) GetDataShapes() (ds []DataShape) {
 		// fmt.Printf("name %v, type %v\n", name, GetElementType(cs.columns[name]))
 		et[i] = GetElementType(cs.columns[cs.orderedNames[i]])
 	}
-	return NewDataShapeVector(cs.orderedNames, et)
+	dsv := NewDataShapeVector(cs.orderedNames, et)
+	for i := range dsv {
+		if dsv[i].Type == DECIMAL64 {
+			dsv[i].Scale = cs.GetScale(dsv[i].Name)
+		}
+		dsv[i].Nullable = cs.IsNullable(dsv[i].Name)
+	}
+	return dsv
 }
 
 func (cs *ColumnSeries) Len() int {
@@ -160,7 +189,13 @@ func (cs *ColumnSeries) Rename(newName, oldName string) error {
 		}
 	}
 
+	scale := cs.GetScale(oldName)
+	mask, nullable := cs.nulls[oldName]
 	cs.AddColumn(newName, oldColumn)
+	cs.SetScale(newName, scale)
+	if nullable {
+		cs.SetNullMask(newName, mask)
+	}
 	if err := cs.Remove(oldName); err != nil {
 		return fmt.Errorf("remove a column of old name(%s) for column renaming: %w", oldName, err)
 	}
@@ -169,10 +204,16 @@ func (cs *ColumnSeries) Rename(newName, oldName string) error {
 }
 
 func (cs *ColumnSeries) Replace(targetName string, col interface{}) error {
+	scale := cs.GetScale(targetName)
+	mask, nullable := cs.nulls[targetName]
 	if err := cs.Remove(targetName); err != nil {
 		return err
 	}
 	cs.AddColumn(targetName, col)
+	cs.SetScale(targetName, scale)
+	if nullable {
+		cs.SetNullMask(targetName, mask)
+	}
 	return nil
 }
 
@@ -190,11 +231,14 @@ func (cs *ColumnSeries) Remove(targetName string) error {
 	}
 	cs.orderedNames = newNames
 	delete(cs.columns, targetName)
+	delete(cs.scales, targetName)
+	delete(cs.nulls, targetName)
 	return nil
 }
 
 func (cs *ColumnSeries) Project(keepList []string) error {
 	newCols := make(map[string]interface{})
+	newNulls := make(map[string][]bool)
 
 	var newNames []string
 	for _, name := range keepList {
@@ -204,9 +248,13 @@ func (cs *ColumnSeries) Project(keepList []string) error {
 			continue
 		}
 		newCols[name] = col
+		if mask, ok := cs.nulls[name]; ok {
+			newNulls[name] = mask
+		}
 		newNames = append(newNames, name)
 	}
 	cs.columns = newCols
+	cs.nulls = newNulls
 	cs.orderedNames = newNames
 	return nil
 }
@@ -221,6 +269,13 @@ func (cs *ColumnSeries) RestrictLength(newLen int, direction DirectionEnum) (err
 			return err
 		}
 	}
+	for key, mask := range cs.nulls {
+		iMask, err := DownSizeSlice(mask, newLen, direction)
+		if err != nil {
+			return err
+		}
+		cs.nulls[key], _ = iMask.([]bool)
+	}
 	return nil
 }
 
@@ -254,8 +309,18 @@ func (cs *ColumnSeries) ToRowSeries(itemKey TimeBucketKey, alignData bool) (rs *
 	return rs, nil
 }
 
+// AddNullColumn adds a column of zero values for the data shape.
+// All the values are marked as null if the data shape is nullable.
 func (cs *ColumnSeries) AddNullColumn(ds DataShape) {
-	cs.AddColumn(ds.Name, ds.Type.SliceOf(cs.Len()))
+	length := cs.Len()
+	name := cs.AddColumn(ds.Name, ds.Type.SliceOf(length))
+	if ds.Nullable {
+		mask := make([]bool, length)
+		for i := range mask {
+			mask[i] = true
+		}
+		cs.SetNullMask(name, mask)
+	}
 }
 
 // ApplyTimeQual takes a function that determines whether or
@@ -264,18 +329,24 @@ func (cs *ColumnSeries) AddNullColumn(ds DataShape) {
 func (cs *ColumnSeries) ApplyTimeQual(tq func(epoch int64) bool) *ColumnSeries {
 	var indexes []int
 
-	out := &ColumnSeries{
-		orderedNames:  cs.orderedNames,
-		nameIncrement: cs.nameIncrement,
-		columns:       map[string]interface{}{},
-	}
-
 	for i, epoch := range cs.GetEpoch() {
 		if tq(epoch) {
 			indexes = append(indexes, i)
 		}
 	}
 
+	return cs.SelectRows(indexes)
+}
+
+// SelectRows returns a new ColumnSeries that has the rows at the indexes.
+func (cs *ColumnSeries) SelectRows(indexes []int) *ColumnSeries {
+	out := &ColumnSeries{
+		orderedNames:  cs.orderedNames,
+		nameIncrement: cs.nameIncrement,
+		columns:       map[string]interface{}{},
+		scales:        cs.scales,
+	}
+
 	for name, col := range cs.columns {
 		iv := reflect.ValueOf(col)
 		slc := reflect.MakeSlice(reflect.TypeOf(col), 0, 0)
@@ -287,6 +358,15 @@ func (cs *ColumnSeries) ApplyTimeQual(tq func(epoch int64) bool) *ColumnSeries {
 		out.columns[name] = slc.Interface()
 	}
 
+	out.nulls = make(map[string][]bool, len(cs.nulls))
+	for name, mask := range cs.nulls {
+		newMask := make([]bool, len(indexes))
+		for i, index := range indexes {
+			newMask[i] = mask[index]
+		}
+		out.nulls[name] = newMask
+	}
+
 	return out
 }
 
@@ -300,11 +380,16 @@ func SliceColumnSeriesByEpoch(cs ColumnSeries, start, end *int64) (slc ColumnSer
 		orderedNames:  cs.orderedNames,
 		nameIncrement: cs.nameIncrement,
 		columns:       map[string]interface{}{},
+		scales:        cs.scales,
+		nulls:         map[string][]bool{},
 	}
 
 	for name, col := range cs.columns {
 		slc.columns[name] = col
 	}
+	for name, mask := range cs.nulls {
+		slc.nulls[name] = mask
+	}
 
 	epochs := slc.GetEpoch()
 
@@ -348,6 +433,14 @@ func ColumnSeriesUnion(left, right *ColumnSeries) *ColumnSeries {
 	for k, v := range left.nameIncrement {
 		out.nameIncrement[k] = v
 	}
+	for k, v := range left.scales {
+		out.scales[k] = v
+	}
+	for _, series := range []*ColumnSeries{left, right} {
+		for name := range series.nulls {
+			out.nulls[name] = []bool{}
+		}
+	}
 
 	type entry struct {
 		epoch     int64
@@ -390,6 +483,9 @@ func ColumnSeriesUnion(left, right *ColumnSeries) *ColumnSeries {
 				out.columns[name] = ov.Interface()
 			}
 		}
+		for name, mask := range out.nulls {
+			out.nulls[name] = append(mask, rs.IsNull(name, entry.index))
+		}
 	}
 
 	return out
@@ -416,6 +512,10 @@ func (csm ColumnSeriesMap) GetMetadataKeys() (keys []TimeBucketKey) {
 func (csm ColumnSeriesMap) AddColumnSeries(key TimeBucketKey, cs *ColumnSeries) {
 	for _, name := range cs.orderedNames {
 		csm.AddColumn(key, name, cs.columns[name])
+		csm[key].SetScale(name, cs.GetScale(name))
+		if mask, ok := cs.nulls[name]; ok {
+			csm[key].SetNullMask(name, mask)
+		}
 	}
 }
 
@@ -564,7 +664,7 @@ func SerializeColumnsToRows(cs *ColumnSeries, dataShapes []DataShape, align64 bo
 	}
 	// Coerce column types as needed
 	for _, shape := range needcoercion {
-		err = cs.CoerceColumnType(shape.Name, shape.Type)
+		err = cs.CoerceColumn(shape)
 		if err != nil {
 			log.Error(fmt.Sprintf("failed to coerce column (name=%s, type=%s)", shape.Name, shape.Type))
 		}
@@ -2,6 +2,7 @@ package io
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -10,13 +11,25 @@ import (
 type DataShape struct {
 	Name string
 	Type EnumElementType
+	// Scale is the number of digits after the decimal point of a DECIMAL64 column.
+	// It's always 0 for the other types.
+	Scale int8
+	// Nullable is true when the column can have null values.
+	// The validity of the nullable columns is stored in the NullMaskColumn of the bucket.
+	Nullable bool
 }
 
+// nullableTypeSuffix is appended to the type name of a nullable column, e.g. "int64?".
+const nullableTypeSuffix = "?"
+
+// dataTypeNullableBit is the bit of the serialized data type byte set for a nullable column.
+const dataTypeNullableBit byte = 0x80
+
 // NewDataShapeVector returns a new array of DataShapes for the given array of
 // names and element types.
 func NewDataShapeVector(names []string, etypes []EnumElementType) (dsv []DataShape) {
 	for i, name := range names {
-		dsv = append(dsv, DataShape{name, etypes[i]})
+		dsv = append(dsv, DataShape{Name: name, Type: etypes[i]})
 	}
 	return dsv
 }
@@ -27,14 +40,24 @@ func (ds *DataShape) Len() (out int) {
 }
 
 // String returns the colon-separated string of the DataShapes name and type.
-func (ds *DataShape) String() (st string) {
-	return ds.Name + ":" + ds.Type.String()
+// The scale of a DECIMAL64 column is appended to the type, e.g. "Close:DECIMAL64[4]",
+// and "?" is appended to the type of a nullable column, e.g. "Volume:INT64?".
+func (ds DataShape) String() (st string) {
+	st = ds.Name + ":" + ds.Type.String()
+	if ds.Type == DECIMAL64 {
+		st = fmt.Sprintf("%s:%s[%d]", ds.Name, ds.Type.String(), ds.Scale)
+	}
+	if ds.Nullable {
+		st += nullableTypeSuffix
+	}
+	return st
 }
 
-// Equal compares both the name and type of two DataShapes, only returning true
-// if both are equal.
+// Equal compares the name, type, scale and nullability of two DataShapes, only returning true
+// if all are equal.
 func (ds *DataShape) Equal(shape DataShape) bool {
-	return ds.Name == shape.Name && ds.Type == shape.Type
+	return ds.Name == shape.Name && ds.Type == shape.Type && ds.Scale == shape.Scale &&
+		ds.Nullable == shape.Nullable
 }
 
 func DataShapesFromInputString(inputStr string) (dsa []DataShape, err error) {
@@ -47,19 +70,41 @@ func DataShapesFromInputString(inputStr string) (dsa []DataShape, err error) {
 			return nil, err
 		}
 		elementNames := strings.Split(twoParts[0], ",")
-		elementType := twoParts[1]
-		eType := EnumElementTypeFromName(elementType)
-		if eType == NONE {
-			err = fmt.Errorf("error: %s: Data type is not a supported type", group)
-			return nil, err
+		typeName := strings.TrimSuffix(twoParts[1], nullableTypeSuffix)
+		nullable := typeName != twoParts[1]
+		eType, scale, err := parseElementTypeName(typeName)
+		if err != nil {
+			return nil, fmt.Errorf("error: %s: %w", group, err)
 		}
 		for _, name := range elementNames {
-			dsa = append(dsa, DataShape{Name: name, Type: eType})
+			dsa = append(dsa, DataShape{Name: name, Type: eType, Scale: scale, Nullable: nullable})
 		}
 	}
 	return dsa, nil
 }
 
+// parseElementTypeName parses a data type name such as "float32" or "decimal64[4]"
+// into the element type and the scale.
+func parseElementTypeName(typeName string) (EnumElementType, int8, error) {
+	var scale int8
+	if i := strings.Index(typeName, "["); i >= 0 && strings.HasSuffix(typeName, "]") {
+		s, err := strconv.ParseInt(typeName[i+1:len(typeName)-1], 10, 8)
+		if err != nil || s < 0 || s > MaxDecimal64Scale {
+			return NONE, 0, fmt.Errorf("invalid decimal scale: %s", typeName)
+		}
+		scale = int8(s)
+		typeName = typeName[:i]
+	}
+	eType := EnumElementTypeFromName(typeName)
+	if eType == NONE {
+		return NONE, 0, errors.New("Data type is not a supported type")
+	}
+	if scale != 0 && eType != DECIMAL64 {
+		return NONE, 0, fmt.Errorf("scale is only supported for decimal64: %s", typeName)
+	}
+	return eType, scale, nil
+}
+
 func (ds *DataShape) toBytes() ([]byte, error) {
 	buffer := make([]byte, 0)
 
@@ -77,11 +122,23 @@ func (ds *DataShape) toBytes() ([]byte, error) {
 		return nil, errors.Wrap(err, "failed to serialize column name:"+ds.Name)
 	}
 
-	// data type
-	buffer, err = Serialize(buffer, byte(ds.Type))
+	// data type. the highest bit is set for a nullable column
+	dataType := byte(ds.Type)
+	if ds.Nullable {
+		dataType |= dataTypeNullableBit
+	}
+	buffer, err = Serialize(buffer, dataType)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to serialize data type:"+string(ds.Type))
 	}
+
+	// the scale is written only for DECIMAL64 so that the format of the other types is unchanged
+	if ds.Type == DECIMAL64 {
+		buffer, err = Serialize(buffer, ds.Scale)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to serialize decimal scale:"+ds.Name)
+		}
+	}
 	return buffer, nil
 }
 
@@ -92,10 +149,16 @@ func dsFromBytes(buf []byte) (ds DataShape, cursor int) {
 	cursor++
 	dsName := ToString(buf[cursor : cursor+dsNameLen])
 	cursor += dsNameLen
-	dsType := EnumElementType(buf[cursor])
+	nullable := buf[cursor]&dataTypeNullableBit != 0
+	dsType := EnumElementType(buf[cursor] &^ dataTypeNullableBit)
 	cursor++
+	var scale int8
+	if dsType == DECIMAL64 {
+		scale = ToInt8(buf[cursor : cursor+1])
+		cursor++
+	}
 
-	return DataShape{Name: dsName, Type: dsType}, cursor
+	return DataShape{Name: dsName, Type: dsType, Scale: scale, Nullable: nullable}, cursor
 }
 
 // DSVFromBytes deserializes bytes into an array of datashape (=Data Shape Vector)
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
 
 	"github.com/alpacahq/marketstore/v4/utils/io"
 )
@@ -13,6 +14,8 @@ func TestDSVSerialize(t *testing.T) {
 		name        string
 		columnNames []string
 		elemTypes   []io.EnumElementType
+		scales      []int8
+		nullable    []bool
 	}{
 		{
 			name:        "success",
@@ -29,12 +32,31 @@ func TestDSVSerialize(t *testing.T) {
 			columnNames: []string{"column"},
 			elemTypes:   []io.EnumElementType{io.INT64},
 		},
+		{
+			name:        "success/decimal column with scale",
+			columnNames: []string{"column1", "column2"},
+			elemTypes:   []io.EnumElementType{io.DECIMAL64, io.FLOAT32},
+			scales:      []int8{4, 0},
+		},
+		{
+			name:        "success/nullable columns",
+			columnNames: []string{"column1", "column2"},
+			elemTypes:   []io.EnumElementType{io.DECIMAL64, io.INT64},
+			scales:      []int8{2, 0},
+			nullable:    []bool{true, true},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
 
 		t.Run(tt.name, func(t *testing.T) {
 			dsv := io.NewDataShapeVector(tt.columnNames, tt.elemTypes)
+			for i, scale := range tt.scales {
+				dsv[i].Scale = scale
+			}
+			for i, nullable := range tt.nullable {
+				dsv[i].Nullable = nullable
+			}
 
 			serialized, err := io.DSVToBytes(dsv)
 			if err != nil {
@@ -48,3 +70,64 @@ func TestDSVSerialize(t *testing.T) {
 		})
 	}
 }
+
+func TestDataShapesFromInputString(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		inputStr string
+		want     []io.DataShape
+		wantErr  bool
+	}{
+		"ok/ float32 and int32": {
+			inputStr: "Epoch/int64:Open,Close/float32:Volume/int32",
+			want: []io.DataShape{
+				{Name: "Epoch", Type: io.INT64},
+				{Name: "Open", Type: io.FLOAT32},
+				{Name: "Close", Type: io.FLOAT32},
+				{Name: "Volume", Type: io.INT32},
+			},
+		},
+		"ok/ decimal with scale": {
+			inputStr: "Epoch/int64:Price/decimal64[4]",
+			want: []io.DataShape{
+				{Name: "Epoch", Type: io.INT64},
+				{Name: "Price", Type: io.DECIMAL64, Scale: 4},
+			},
+		},
+		"ok/ nullable columns": {
+			inputStr: "Epoch/int64:Close/decimal64[2]?:Volume/int32?",
+			want: []io.DataShape{
+				{Name: "Epoch", Type: io.INT64},
+				{Name: "Close", Type: io.DECIMAL64, Scale: 2, Nullable: true},
+				{Name: "Volume", Type: io.INT32, Nullable: true},
+			},
+		},
+		"NG/ scale for a non-decimal type": {
+			inputStr: "Epoch/int64:Price/float32[4]",
+			wantErr:  true,
+		},
+		"NG/ too large scale": {
+			inputStr: "Epoch/int64:Price/decimal64[19]",
+			wantErr:  true,
+		},
+		"NG/ unknown type": {
+			inputStr: "Epoch/int64:Price/float128",
+			wantErr:  true,
+		},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := io.DataShapesFromInputString(tt.inputStr)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
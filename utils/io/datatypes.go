@@ -54,6 +54,9 @@ const (
 	UINT32
 	UINT64
 	STRING16
+	// DECIMAL64 is a fixed-point decimal stored as a scaled int64 (see Decimal64).
+	// The scale is defined per column in DataShape and the TimeBucketInfo header.
+	DECIMAL64
 )
 
 var attributeMap = map[EnumElementType]struct {
@@ -62,26 +65,31 @@ var attributeMap = map[EnumElementType]struct {
 	size   int
 	typeOf reflect.Type
 }{
-	FLOAT32:  {reflect.Float32, "float32", 4, reflect.TypeOf(float32(0))},
-	INT32:    {reflect.Int32, "int32", 4, reflect.TypeOf(int32(0))},
-	FLOAT64:  {reflect.Float64, "float64", 8, reflect.TypeOf(float64(0))},
-	INT64:    {reflect.Int64, "int64", 8, reflect.TypeOf(int64(0))},
-	BYTE:     {reflect.Int8, "byte", 1, reflect.TypeOf(byte(0))},
-	BOOL:     {reflect.Bool, "bool", 1, reflect.TypeOf(false)},
-	NONE:     {reflect.Invalid, "none", 0, reflect.TypeOf(byte(0))},
-	STRING:   {reflect.String, "string", 0, reflect.TypeOf("")},
-	INT16:    {reflect.Int16, "int16", 2, reflect.TypeOf(int16(0))},
-	UINT8:    {reflect.Uint8, "uint8", 1, reflect.TypeOf(uint8(0))},
-	UINT16:   {reflect.Uint16, "uint16", 2, reflect.TypeOf(uint16(0))},
-	UINT32:   {reflect.Uint32, "uint32", 4, reflect.TypeOf(uint32(0))},
-	UINT64:   {reflect.Uint64, "uint64", 8, reflect.TypeOf(uint64(0))},
-	STRING16: {reflect.Array, "string16", 64, reflect.TypeOf([16]rune{})},
+	FLOAT32:   {reflect.Float32, "float32", 4, reflect.TypeOf(float32(0))},
+	INT32:     {reflect.Int32, "int32", 4, reflect.TypeOf(int32(0))},
+	FLOAT64:   {reflect.Float64, "float64", 8, reflect.TypeOf(float64(0))},
+	INT64:     {reflect.Int64, "int64", 8, reflect.TypeOf(int64(0))},
+	BYTE:      {reflect.Int8, "byte", 1, reflect.TypeOf(byte(0))},
+	BOOL:      {reflect.Bool, "bool", 1, reflect.TypeOf(false)},
+	NONE:      {reflect.Invalid, "none", 0, reflect.TypeOf(byte(0))},
+	STRING:    {reflect.String, "string", 0, reflect.TypeOf("")},
+	INT16:     {reflect.Int16, "int16", 2, reflect.TypeOf(int16(0))},
+	UINT8:     {reflect.Uint8, "uint8", 1, reflect.TypeOf(uint8(0))},
+	UINT16:    {reflect.Uint16, "uint16", 2, reflect.TypeOf(uint16(0))},
+	UINT32:    {reflect.Uint32, "uint32", 4, reflect.TypeOf(uint32(0))},
+	UINT64:    {reflect.Uint64, "uint64", 8, reflect.TypeOf(uint64(0))},
+	STRING16:  {reflect.Array, "string16", 64, reflect.TypeOf([16]rune{})},
+	DECIMAL64: {reflect.Int64, "decimal64", 8, reflect.TypeOf(Decimal64(0))},
 }
 
 // reverse map to convert reflect.Kind to EnumElementType generated by attributeMap.
 var kindMap = func() map[reflect.Kind]EnumElementType {
 	ret := make(map[reflect.Kind]EnumElementType)
 	for enumElementType, attribute := range attributeMap {
+		// DECIMAL64 has the same kind as INT64, and is identified by its Go type instead
+		if enumElementType == DECIMAL64 {
+			continue
+		}
 		ret[attribute.typ] = enumElementType
 	}
 	return ret
@@ -120,7 +128,7 @@ func (e EnumElementType) SliceInBytesAt(bs []byte, index int) []byte {
 
 func (e EnumElementType) SliceOf(length int) (sliceOf interface{}) {
 	typeOf := attributeMap[e].typeOf
-	return reflect.MakeSlice(typeOf, length, length)
+	return reflect.MakeSlice(reflect.SliceOf(typeOf), length, length).Interface()
 }
 
 func (e EnumElementType) ConvertByteSliceInto(data []byte) (interface{}, error) {
@@ -197,6 +205,12 @@ func (e EnumElementType) ConvertByteSliceInto(data []byte) (interface{}, error)
 				return slc, nil
 			}
 		}
+	case DECIMAL64:
+		if val, err := SwapSliceByte(data, Decimal64(0)); err == nil {
+			if slc, ok := val.([]Decimal64); ok {
+				return slc, nil
+			}
+		}
 	default:
 		return nil, errors.New("unknown column type specified for ConvertByteSliceInfo")
 	}
@@ -208,9 +222,14 @@ func GetElementType(datum interface{}) EnumElementType {
 	kind := value.Kind()
 	switch kind {
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Ptr, reflect.Slice:
+		if reflect.TypeOf(datum).Elem() == decimal64Type {
+			return DECIMAL64
+		}
 		kind = reflect.TypeOf(datum).Elem().Kind()
 	default:
-		// pass
+		if value.Type() == decimal64Type {
+			return DECIMAL64
+		}
 	}
 
 	switch kind {
@@ -236,6 +255,82 @@ func GetElementType(datum interface{}) EnumElementType {
 	return NONE
 }
 
+// IsNumeric returns true if the element type is an integer, floating point or decimal number.
+func (e EnumElementType) IsNumeric() bool {
+	switch e {
+	case FLOAT32, FLOAT64, INT16, INT32, INT64, BYTE, UINT8, UINT16, UINT32, UINT64, DECIMAL64:
+		return true
+	default:
+		return false
+	}
+}
+
+// PromoteElementTypes returns the element type that the values of both element types are converted to
+// in an arithmetic operation between them, or NONE if either is not numeric.
+// The result is the smallest type that can represent the values of both types like numpy, e.g.
+//   - INT32, INT64 => INT64
+//   - UINT16, INT16 => INT32
+//   - FLOAT32, INT16 => FLOAT32
+//   - FLOAT32, INT32 => FLOAT64
+//
+// DECIMAL64 values have a scale per column, so they are promoted to FLOAT64.
+func PromoteElementTypes(a, b EnumElementType) EnumElementType {
+	if !a.IsNumeric() || !b.IsNumeric() {
+		return NONE
+	}
+	if a == DECIMAL64 || b == DECIMAL64 {
+		return FLOAT64
+	}
+	if a == b {
+		return a
+	}
+
+	ka, kb := a.TypeOf().Kind(), b.TypeOf().Kind()
+	isFloat := func(k reflect.Kind) bool { return k == reflect.Float32 || k == reflect.Float64 }
+	isUnsigned := func(k reflect.Kind) bool { return k >= reflect.Uint8 && k <= reflect.Uint64 }
+	larger := func(x, y EnumElementType) EnumElementType {
+		if x.Size() >= y.Size() {
+			return x
+		}
+		return y
+	}
+
+	switch {
+	case isFloat(ka) && isFloat(kb):
+		return larger(a, b)
+	case isFloat(ka) || isFloat(kb):
+		f, i := a, b
+		if isFloat(kb) {
+			f, i = b, a
+		}
+		if f.Size() > i.Size() {
+			return f
+		}
+		return FLOAT64
+	case isUnsigned(ka) == isUnsigned(kb):
+		return larger(a, b)
+	default:
+		s, u := a, b
+		if isUnsigned(ka) {
+			s, u = b, a
+		}
+		if s.Size() > u.Size() {
+			return s
+		}
+		// a signed integer type that is larger than the unsigned one
+		switch u.Size() {
+		case 1:
+			return INT16
+		case 2:
+			return INT32
+		case 4:
+			return INT64
+		default:
+			return FLOAT64
+		}
+	}
+}
+
 type DirectionEnum uint8
 
 const (
@@ -279,6 +374,20 @@ func getFloat64Column(offset, reclen, nrecs int, data []byte) (col []float64) {
 	return col
 }
 
+func getDecimal64Column(offset, reclen, nrecs int, data []byte) (col []Decimal64) {
+	col = make([]Decimal64, nrecs)
+	if nrecs == 0 {
+		return col
+	}
+
+	cursor := offset
+	for i := 0; i < nrecs; i++ {
+		col[i] = Decimal64(ToInt64(data[cursor : cursor+8]))
+		cursor += reclen
+	}
+	return col
+}
+
 func getInt16Column(offset, reclen, nrecs int, data []byte) (col []int16) {
 	col = make([]int16, nrecs)
 	if nrecs == 0 {
@@ -291,3 +291,34 @@ func TestGetElementType(t *testing.T) {
 		})
 	}
 }
+
+func TestPromoteElementTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		a, b EnumElementType
+		want EnumElementType
+	}{
+		"same type":                           {a: FLOAT32, b: FLOAT32, want: FLOAT32},
+		"larger integer":                      {a: INT32, b: INT64, want: INT64},
+		"larger unsigned integer":             {a: UINT8, b: UINT32, want: UINT32},
+		"larger float":                        {a: FLOAT32, b: FLOAT64, want: FLOAT64},
+		"small integer and float32":           {a: INT16, b: FLOAT32, want: FLOAT32},
+		"int32 and float32":                   {a: FLOAT32, b: INT32, want: FLOAT64},
+		"int64 and float64":                   {a: INT64, b: FLOAT64, want: FLOAT64},
+		"signed integer larger than unsigned": {a: INT32, b: UINT16, want: INT32},
+		"unsigned integer as large as signed": {a: UINT16, b: INT16, want: INT32},
+		"uint64 and int64":                    {a: UINT64, b: INT64, want: FLOAT64},
+		"decimal":                             {a: DECIMAL64, b: INT64, want: FLOAT64},
+		"not numeric":                         {a: STRING16, b: INT64, want: NONE},
+		"bool":                                {a: BOOL, b: BOOL, want: NONE},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, PromoteElementTypes(tt.a, tt.b))
+			assert.Equal(t, tt.want, PromoteElementTypes(tt.b, tt.a))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package io
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MaxDecimal64Scale is the maximum number of digits after the decimal point of a Decimal64.
+const MaxDecimal64Scale = 18
+
+// decimalTolerance is the relative tolerance used to snap a scaled float value
+// to the nearest integer when a float is compared with a Decimal64.
+const decimalTolerance = 1e-9
+
+var decimal64Type = reflect.TypeOf(Decimal64(0))
+
+// Decimal64 is a fixed-point decimal number stored as an unscaled int64 value.
+// The scale (the number of digits after the decimal point) is not stored in the value
+// but defined per column, e.g. Decimal64(12345) with scale 2 represents 123.45 .
+type Decimal64 int64
+
+// NewDecimal64FromFloat converts a float value to a Decimal64 with the scale,
+// rounding half away from zero.
+func NewDecimal64FromFloat(f float64, scale int8) Decimal64 {
+	return Decimal64(math.Round(f * pow10(scale)))
+}
+
+// Float64 returns the float value of the Decimal64 with the scale.
+func (d Decimal64) Float64(scale int8) float64 {
+	return float64(d) / pow10(scale)
+}
+
+// Rescale converts the Decimal64 from a scale to another, rounding half away from zero
+// when the number of digits decreases.
+func (d Decimal64) Rescale(from, to int8) Decimal64 {
+	switch {
+	case from == to:
+		return d
+	case from < to:
+		return d * Decimal64(ipow10(to-from))
+	default:
+		div := ipow10(from - to)
+		q, r := int64(d)/div, int64(d)%div
+		if 2*r >= div {
+			q++
+		} else if 2*r <= -div {
+			q--
+		}
+		return Decimal64(q)
+	}
+}
+
+// Format returns the string representation of the Decimal64 with the scale, e.g. "123.45".
+func (d Decimal64) Format(scale int8) string {
+	s := strconv.FormatInt(int64(d), 10)
+	if scale <= 0 {
+		return s
+	}
+	sign := ""
+	if d < 0 {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= int(scale) {
+		s = strings.Repeat("0", int(scale)-len(s)+1) + s
+	}
+	return sign + s[:len(s)-int(scale)] + "." + s[len(s)-int(scale):]
+}
+
+// ParseDecimal64 parses a decimal string such as "123.45" into a Decimal64 with the scale
+// without going through a float, so that the value is exact.
+// Digits beyond the scale are rounded half away from zero.
+func ParseDecimal64(s string, scale int8) (Decimal64, error) {
+	if scale < 0 || scale > MaxDecimal64Scale {
+		return 0, fmt.Errorf("invalid decimal scale: %d", scale)
+	}
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("failed to parse %q as a decimal", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(ipow10(scale)))
+
+	// round half away from zero
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Abs(m).Lsh(m, 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("decimal value %q overflows with scale %d", s, scale)
+	}
+	return Decimal64(q.Int64()), nil
+}
+
+// CompareDecimal64 compares the Decimal64 with the scale to a float value,
+// and returns -1, 0 or +1 if d is less than, equal to or greater than v.
+// v is considered equal when it's the nearest float of the decimal value (e.g. 0.1 == Decimal64(1) with scale 1).
+func CompareDecimal64(d Decimal64, scale int8, v float64) int {
+	scaled := v * pow10(scale)
+	if rounded := math.Round(scaled); math.Abs(scaled-rounded) <= decimalTolerance*math.Max(1, math.Abs(scaled)) {
+		scaled = rounded
+	}
+	switch {
+	case float64(d) < scaled:
+		return -1
+	case float64(d) > scaled:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func pow10(scale int8) float64 {
+	return math.Pow10(int(scale))
+}
+
+func ipow10(n int8) int64 {
+	ret := int64(1)
+	for i := int8(0); i < n; i++ {
+		ret *= 10
+	}
+	return ret
+}
@@ -0,0 +1,113 @@
+package io_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func TestParseDecimal64(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		s       string
+		scale   int8
+		want    io.Decimal64
+		wantErr bool
+	}{
+		"ok/ exact":                     {s: "123.45", scale: 2, want: 12345},
+		"ok/ padded with zeros":         {s: "1.5", scale: 4, want: 15000},
+		"ok/ negative":                  {s: "-0.0001", scale: 4, want: -1},
+		"ok/ rounded half away from 0":  {s: "0.125", scale: 2, want: 13},
+		"ok/ negative rounded":          {s: "-0.125", scale: 2, want: -13},
+		"ok/ integer with scale 0":      {s: "42", scale: 0, want: 42},
+		"ok/ surrounding spaces":        {s: " 0.1 ", scale: 1, want: 1},
+		"NG/ not a number":              {s: "abc", scale: 2, wantErr: true},
+		"NG/ overflow":                  {s: "100000000000", scale: 9, wantErr: true},
+		"NG/ scale larger than the max": {s: "1", scale: 19, wantErr: true},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := io.ParseDecimal64(tt.s, tt.scale)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecimal64_Format(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		d     io.Decimal64
+		scale int8
+		want  string
+	}{
+		"ok/ scale 2":         {d: 12345, scale: 2, want: "123.45"},
+		"ok/ less than 1":     {d: 5, scale: 4, want: "0.0005"},
+		"ok/ negative":        {d: -5, scale: 2, want: "-0.05"},
+		"ok/ scale 0":         {d: 42, scale: 0, want: "42"},
+		"ok/ trailing zeroes": {d: 1000, scale: 3, want: "1.000"},
+	}
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, tt.d.Format(tt.scale))
+		})
+	}
+}
+
+func TestDecimal64_Rescale(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, io.Decimal64(123400), io.Decimal64(1234).Rescale(2, 4))
+	require.Equal(t, io.Decimal64(123), io.Decimal64(12345).Rescale(4, 2))
+	require.Equal(t, io.Decimal64(124), io.Decimal64(12350).Rescale(4, 2))
+	require.Equal(t, io.Decimal64(-124), io.Decimal64(-12350).Rescale(4, 2))
+	require.Equal(t, io.Decimal64(7), io.Decimal64(7).Rescale(3, 3))
+}
+
+func TestCompareDecimal64(t *testing.T) {
+	t.Parallel()
+
+	// 0.1 + 0.2 != 0.3 in float, but the decimal is compared with the nearest value
+	require.Equal(t, 0, io.CompareDecimal64(3, 1, 0.1+0.2))
+	require.Equal(t, 0, io.CompareDecimal64(io.NewDecimal64FromFloat(100.12, 4), 4, 100.12))
+	require.Equal(t, -1, io.CompareDecimal64(10011, 2, 100.12))
+	require.Equal(t, 1, io.CompareDecimal64(10013, 2, 100.12))
+	require.Equal(t, -1, io.CompareDecimal64(10012, 2, 100.125))
+}
+
+func TestColumnSeries_CoerceColumn_Decimal(t *testing.T) {
+	t.Parallel()
+
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{1, 2})
+	cs.AddColumn("Float", []float32{1.5, 100.25})
+	cs.AddColumn("Decimal", []io.Decimal64{15, 10025})
+	cs.SetScale("Decimal", 1)
+
+	// float -> decimal
+	require.NoError(t, cs.CoerceColumn(io.DataShape{Name: "Float", Type: io.DECIMAL64, Scale: 2}))
+	require.Equal(t, []io.Decimal64{150, 10025}, cs.GetColumn("Float"))
+	require.Equal(t, int8(2), cs.GetScale("Float"))
+
+	// decimal -> decimal with another scale
+	require.NoError(t, cs.CoerceColumn(io.DataShape{Name: "Decimal", Type: io.DECIMAL64, Scale: 3}))
+	require.Equal(t, []io.Decimal64{1500, 1002500}, cs.GetColumn("Decimal"))
+	require.Equal(t, io.DataShape{Name: "Decimal", Type: io.DECIMAL64, Scale: 3}, cs.GetDataShapes()[2])
+
+	// decimal -> float
+	require.NoError(t, cs.CoerceColumnType("Decimal", io.FLOAT64))
+	require.Equal(t, []float64{1.5, 1002.5}, cs.GetColumn("Decimal"))
+	require.Equal(t, int8(0), cs.GetScale("Decimal"))
+}
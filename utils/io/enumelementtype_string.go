@@ -23,11 +23,12 @@ func _() {
 	_ = x[UINT32-12]
 	_ = x[UINT64-13]
 	_ = x[STRING16-14]
+	_ = x[DECIMAL64-15]
 }
 
-const _EnumElementType_name = "FLOAT32INT32FLOAT64INT64EPOCHBYTEBOOLNONESTRINGINT16UINT8UINT16UINT32UINT64STRING16"
+const _EnumElementType_name = "FLOAT32INT32FLOAT64INT64EPOCHBYTEBOOLNONESTRINGINT16UINT8UINT16UINT32UINT64STRING16DECIMAL64"
 
-var _EnumElementType_index = [...]uint8{0, 7, 12, 19, 24, 29, 33, 37, 41, 47, 52, 57, 63, 69, 75, 83}
+var _EnumElementType_index = [...]uint8{0, 7, 12, 19, 24, 29, 33, 37, 41, 47, 52, 57, 63, 69, 75, 83, 92}
 
 func (i EnumElementType) String() string {
 	if i >= EnumElementType(len(_EnumElementType_index)-1) {
@@ -28,12 +28,15 @@ const (
 	reservedHeader1Bytes   = 8
 	elementNameHeaderBytes = 32   // 32bytes per element
 	maxNumElements         = 1024 // max number of elements in a bucket
-	reservedHeader2Bytes   = 365
+	reservedHeader2Bytes   = 109
 	Headersize             = 37024
 	FileinfoVersion        = int64(2.0)
 	epochLenBytes          = 8
 )
 
+// elementFlagNullable is the bit of Header.ElementFlags set for a nullable element.
+const elementFlagNullable byte = 1 << 0
+
 func nanosecondsInYear(year int) int64 {
 	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
 	end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.Local)
@@ -75,6 +78,12 @@ type TimeBucketInfo struct {
 	elementNames []string
 	// e.g. []io.EnumElementType{FLOAT32, FLOAT32}. elementTypes doesn't include "Epoch" column or "Nanoseconds" column.
 	elementTypes []EnumElementType
+	// e.g. []int8{0, 4}. the number of digits after the decimal point of each DECIMAL64 element.
+	// It's 0 for the other types. elementScales doesn't include "Epoch" column or "Nanoseconds" column.
+	elementScales []int8
+	// e.g. []bool{false, true}. true when the element is nullable and its validity is stored in the
+	// NullMaskColumn element. elementNullable doesn't include "Epoch" column or "Nanoseconds" column.
+	elementNullable []bool
 
 	once sync.Once
 }
@@ -91,7 +100,16 @@ func AlignedSize(unalignedSize int) (alignedSize int) {
 func NewTimeBucketInfo(tf utils.Timeframe, path, description string, year int16,
 	dsv []DataShape, recordType EnumRecordType,
 ) (f *TimeBucketInfo) {
+	dsv = withNullMaskColumn(dsv)
 	elementTypes, elementNames := CreateShapesForTimeBucketInfo(dsv)
+	var elementScales []int8
+	var elementNullable []bool
+	for _, shape := range dsv {
+		if shape.Name != epochColumnName {
+			elementScales = append(elementScales, shape.Scale)
+			elementNullable = append(elementNullable, shape.Nullable)
+		}
+	}
 	f = &TimeBucketInfo{
 		version:      FileinfoVersion,
 		Path:         filepath.Join(path, strconv.Itoa(int(year))+".bin"),
@@ -103,6 +121,9 @@ func NewTimeBucketInfo(tf utils.Timeframe, path, description string, year int16,
 		elementTypes: elementTypes,
 		elementNames: elementNames,
 		recordType:   recordType,
+
+		elementScales:   elementScales,
+		elementNullable: elementNullable,
 	}
 	if f.recordType == FIXED {
 		f.recordLength = int32(AlignedSize(f.getFieldRecordLength())) + epochLenBytes // add an 8-byte epoch field
@@ -113,6 +134,24 @@ func NewTimeBucketInfo(tf utils.Timeframe, path, description string, year int16,
 	return f
 }
 
+// withNullMaskColumn appends the NullMaskColumn to the data shapes
+// if any of them is nullable and the NullMaskColumn is not included yet.
+func withNullMaskColumn(dsv []DataShape) []DataShape {
+	var hasNullable bool
+	for _, shape := range dsv {
+		if shape.Name == NullMaskColumn {
+			return dsv
+		}
+		hasNullable = hasNullable || shape.Nullable
+	}
+	if !hasNullable {
+		return dsv
+	}
+	out := make([]DataShape, 0, len(dsv)+1)
+	out = append(out, dsv...)
+	return append(out, DataShape{Name: NullMaskColumn, Type: UINT64})
+}
+
 func CreateShapesForTimeBucketInfo(dsv []DataShape) (elementTypes []EnumElementType, elementNames []string) {
 	/*
 		Takes a datashape array and returns elementTypes and elementNames
@@ -129,9 +168,20 @@ func CreateShapesForTimeBucketInfo(dsv []DataShape) (elementTypes []EnumElementT
 }
 
 func (f *TimeBucketInfo) GetDataShapes() []DataShape {
-	return NewDataShapeVector(
+	dsv := NewDataShapeVector(
 		f.GetElementNames(),
 		f.GetElementTypes())
+	scales := f.GetElementScales()
+	nullable := f.GetElementNullable()
+	for i := range dsv {
+		if i < len(scales) {
+			dsv[i].Scale = scales[i]
+		}
+		if i < len(nullable) {
+			dsv[i].Nullable = nullable[i]
+		}
+	}
+	return dsv
 }
 
 func (f *TimeBucketInfo) GetDataShapesWithEpoch() (out []DataShape) {
@@ -168,6 +218,14 @@ func (f *TimeBucketInfo) GetDeepCopy() *TimeBucketInfo {
 	fcopy.elementTypes = make([]EnumElementType, len(f.elementTypes))
 	copy(fcopy.elementNames, f.elementNames)
 	copy(fcopy.elementTypes, f.elementTypes)
+	if f.elementScales != nil {
+		fcopy.elementScales = make([]int8, len(f.elementScales))
+		copy(fcopy.elementScales, f.elementScales)
+	}
+	if f.elementNullable != nil {
+		fcopy.elementNullable = make([]bool, len(f.elementNullable))
+		copy(fcopy.elementNullable, f.elementNullable)
+	}
 	return &fcopy
 }
 
@@ -256,6 +314,20 @@ func (f *TimeBucketInfo) GetElementTypes() []EnumElementType {
 	return f.elementTypes
 }
 
+// GetElementScales returns the number of digits after the decimal point of each field
+// contained by the file described by the given TimeBucketInfo. It's 0 for non-DECIMAL64 fields.
+func (f *TimeBucketInfo) GetElementScales() []int8 {
+	f.once.Do(f.initFromFile)
+	return f.elementScales
+}
+
+// GetElementNullable returns whether each field contained by the file described by
+// the given TimeBucketInfo is nullable.
+func (f *TimeBucketInfo) GetElementNullable() []bool {
+	f.once.Do(f.initFromFile)
+	return f.elementNullable
+}
+
 // SetElementTypes sets the field types contained by the file described by
 // the given TimeBucketInfo.
 func (f *TimeBucketInfo) SetElementTypes(newTypes []EnumElementType) error {
@@ -311,14 +383,12 @@ func (f *TimeBucketInfo) readHeader(path string) (err error) {
 		log.Error("Failed to read header part3 from file: %v - Error: %v", path, err)
 		return err
 	}
-	if EnumRecordType(header.RecordType) == VARIABLE {
-		// Read to end of header
-		start += int(header.NElements)
-		n, err = file.Read(buffer[start:Headersize])
-		if err != nil || n != (Headersize-start) {
-			log.Error("Failed to read header part4 from file: %v - Error: %v", path, err)
-			return err
-		}
+	// Read to end of header, which includes the element scales and flags
+	start += int(header.NElements)
+	n, err = file.Read(buffer[start:Headersize])
+	if err != nil || n != (Headersize-start) {
+		log.Error("Failed to read header part4 from file: %v - Error: %v", path, err)
+		return err
 	}
 	f.load(header, path)
 	return nil
@@ -336,10 +406,14 @@ func (f *TimeBucketInfo) load(hp *Header, path string) {
 	f.recordType = EnumRecordType(hp.RecordType)
 	f.elementNames = nil
 	f.elementTypes = nil
+	f.elementScales = nil
+	f.elementNullable = nil
 	for i := 0; i < int(f.nElements); i++ {
 		baseName := string(bytes.Trim(hp.ElementNames[i][:], "\x00"))
 		f.elementNames = append(f.elementNames, baseName)
 		f.elementTypes = append(f.elementTypes, EnumElementType(hp.ElementTypes[i]))
+		f.elementScales = append(f.elementScales, int8(hp.ElementScales[i]))
+		f.elementNullable = append(f.elementNullable, hp.ElementFlags[i]&elementFlagNullable != 0)
 	}
 }
 
@@ -364,6 +438,10 @@ type Header struct {
 	// Above is the fixed header portion - size is 312 Bytes = (7*8 + 256)
 	ElementNames [maxNumElements][elementNameHeaderBytes]byte
 	ElementTypes [maxNumElements]byte
+	// ElementScales was added to the reserved space in FileinfoVersion 2, so it's 0 in the older files.
+	ElementScales [maxNumElements]byte
+	// ElementFlags was added to the reserved space in FileinfoVersion 2, so it's 0 in the older files.
+	ElementFlags [maxNumElements]byte
 
 	reserved2 [reservedHeader2Bytes]int64
 }
@@ -395,6 +473,12 @@ func (hp *Header) Load(f *TimeBucketInfo) {
 	for i := 0; i < int(hp.NElements); i++ {
 		copy(hp.ElementNames[i][:], f.GetElementNames()[i])
 		hp.ElementTypes[i] = byte(f.GetElementTypes()[i])
+		if scales := f.GetElementScales(); i < len(scales) {
+			hp.ElementScales[i] = byte(scales[i])
+		}
+		if nullable := f.GetElementNullable(); i < len(nullable) && nullable[i] {
+			hp.ElementFlags[i] |= elementFlagNullable
+		}
 	}
 	hp.RecordType = int64(f.GetRecordType())
 }
@@ -0,0 +1,145 @@
+package io
+
+import (
+	"fmt"
+)
+
+const (
+	// NullMaskColumn is the name of the hidden column that stores the validity bitmap
+	// of the nullable columns of a bucket. The k-th bit of the mask of a record is set
+	// when the k-th nullable column of the record is null.
+	NullMaskColumn = "_NullMask"
+	// MaxNullableColumns is the maximum number of nullable columns in a bucket.
+	MaxNullableColumns = 64
+)
+
+// SetNullMask makes the column nullable and sets its null mask, where true means the value is null.
+// A nil mask marks the column nullable without null values.
+func (cs *ColumnSeries) SetNullMask(name string, mask []bool) {
+	if cs.nulls == nil {
+		cs.nulls = make(map[string][]bool)
+	}
+	if mask == nil {
+		mask = make([]bool, cs.Len())
+	}
+	cs.nulls[name] = mask
+}
+
+// GetNullMask returns the null mask of the column, or nil if the column is not nullable.
+func (cs *ColumnSeries) GetNullMask(name string) []bool {
+	return cs.nulls[name]
+}
+
+// IsNullable returns true if the column has a null mask.
+func (cs *ColumnSeries) IsNullable(name string) bool {
+	_, ok := cs.nulls[name]
+	return ok
+}
+
+// IsNull returns true if the i-th value of the column is null.
+func (cs *ColumnSeries) IsNull(name string, i int) bool {
+	mask := cs.nulls[name]
+	return i < len(mask) && mask[i]
+}
+
+// RemoveNullMask makes the column not nullable. The null values are left as zero values.
+func (cs *ColumnSeries) RemoveNullMask(name string) {
+	delete(cs.nulls, name)
+}
+
+// NullableColumnNames returns the names of the nullable columns in the order of the bits of the NullMaskColumn.
+func NullableColumnNames(dsv []DataShape) (names []string) {
+	for _, ds := range dsv {
+		if ds.Nullable && ds.Name != NullMaskColumn {
+			names = append(names, ds.Name)
+		}
+	}
+	return names
+}
+
+// PackNullMask encodes the null masks of the nullable columns in the data shapes
+// into the NullMaskColumn so that the column series can be serialized to the bucket with the data shapes.
+// The columns that are nullable in the data shapes but missing in the column series are added as null columns,
+// and the null masks of the columns that are not nullable in the data shapes are removed.
+func (cs *ColumnSeries) PackNullMask(dsv []DataShape) error {
+	nullable := NullableColumnNames(dsv)
+	if len(nullable) > MaxNullableColumns {
+		return fmt.Errorf("too many nullable columns: %d > %d", len(nullable), MaxNullableColumns)
+	}
+
+	isNullable := make(map[string]bool, len(nullable))
+	for _, name := range nullable {
+		isNullable[name] = true
+	}
+	for name := range cs.nulls {
+		if !isNullable[name] {
+			cs.RemoveNullMask(name)
+		}
+	}
+	if len(nullable) == 0 {
+		if cs.Exists(NullMaskColumn) {
+			return cs.Remove(NullMaskColumn)
+		}
+		return nil
+	}
+
+	for _, ds := range dsv {
+		if !isNullable[ds.Name] {
+			continue
+		}
+		if !cs.Exists(ds.Name) {
+			cs.AddNullColumn(ds)
+		} else if !cs.IsNullable(ds.Name) {
+			cs.SetNullMask(ds.Name, nil)
+		}
+	}
+
+	packed := make([]uint64, cs.Len())
+	for k, name := range nullable {
+		for i, isNull := range cs.nulls[name] {
+			if isNull {
+				packed[i] |= 1 << uint(k)
+			}
+		}
+	}
+	if cs.Exists(NullMaskColumn) {
+		return cs.Replace(NullMaskColumn, packed)
+	}
+	cs.AddColumn(NullMaskColumn, packed)
+	return nil
+}
+
+// unpackNullMask decodes the NullMaskColumn into the null masks of the nullable columns
+// in the data shapes, and removes the NullMaskColumn from the column series.
+func (cs *ColumnSeries) unpackNullMask(dsv []DataShape) {
+	packed, ok := cs.GetColumn(NullMaskColumn).([]uint64)
+	if !ok {
+		return
+	}
+	for k, name := range NullableColumnNames(dsv) {
+		if k >= MaxNullableColumns {
+			break
+		}
+		mask := make([]bool, len(packed))
+		for i, bits := range packed {
+			mask[i] = bits&(1<<uint(k)) != 0
+		}
+		cs.SetNullMask(name, mask)
+	}
+	_ = cs.Remove(NullMaskColumn)
+}
+
+// filterNullMasks applies a row filter to all the null masks, keeping the i-th value when keep(i) is true.
+func (cs *ColumnSeries) filterNullMasks(keep func(i int) bool) map[string][]bool {
+	out := make(map[string][]bool, len(cs.nulls))
+	for name, mask := range cs.nulls {
+		newMask := make([]bool, 0, len(mask))
+		for i, isNull := range mask {
+			if keep(i) {
+				newMask = append(newMask, isNull)
+			}
+		}
+		out[name] = newMask
+	}
+	return out
+}
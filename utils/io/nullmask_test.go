@@ -0,0 +1,185 @@
+package io_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/marketstore/v4/utils/io"
+)
+
+func newNullableColumnSeries() *io.ColumnSeries {
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{1, 2, 3, 4})
+	cs.AddColumn("Close", []float32{1, 0, 3, 0})
+	cs.AddColumn("Volume", []int64{0, 20, 0, 40})
+	cs.SetNullMask("Close", []bool{false, true, false, true})
+	cs.SetNullMask("Volume", []bool{true, false, false, false})
+	return cs
+}
+
+func TestColumnSeries_PackNullMask(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		dsv          []io.DataShape
+		wantMask     []uint64
+		wantNullable []string
+		wantErr      bool
+	}{
+		"ok/ a bit per nullable column": {
+			dsv: []io.DataShape{
+				{Name: "Epoch", Type: io.INT64},
+				{Name: "Close", Type: io.FLOAT32, Nullable: true},
+				{Name: "Volume", Type: io.INT64, Nullable: true},
+				{Name: io.NullMaskColumn, Type: io.UINT64},
+			},
+			wantMask:     []uint64{0b10, 0b01, 0, 0b01},
+			wantNullable: []string{"Close", "Volume"},
+		},
+		"ok/ the null mask of a non-nullable column is removed": {
+			dsv: []io.DataShape{
+				{Name: "Epoch", Type: io.INT64},
+				{Name: "Close", Type: io.FLOAT32},
+				{Name: "Volume", Type: io.INT64, Nullable: true},
+			},
+			wantMask:     []uint64{0b1, 0, 0, 0},
+			wantNullable: []string{"Volume"},
+		},
+		"ok/ a missing nullable column is added as null": {
+			dsv: []io.DataShape{
+				{Name: "Epoch", Type: io.INT64},
+				{Name: "Volume", Type: io.INT64, Nullable: true},
+				{Name: "Open", Type: io.FLOAT32, Nullable: true},
+			},
+			wantMask:     []uint64{0b11, 0b10, 0b10, 0b10},
+			wantNullable: []string{"Volume", "Open"},
+		},
+		"ok/ no nullable column": {
+			dsv: []io.DataShape{
+				{Name: "Epoch", Type: io.INT64},
+				{Name: "Close", Type: io.FLOAT32},
+				{Name: "Volume", Type: io.INT64},
+			},
+			wantMask: nil,
+		},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cs := newNullableColumnSeries()
+
+			err := cs.PackNullMask(tt.dsv)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantMask == nil {
+				require.False(t, cs.Exists(io.NullMaskColumn))
+			} else {
+				require.Equal(t, tt.wantMask, cs.GetColumn(io.NullMaskColumn))
+			}
+			require.Equal(t, tt.wantNullable, io.NullableColumnNames(cs.GetDataShapes()))
+		})
+	}
+}
+
+func TestColumnSeries_NullMaskRoundTrip(t *testing.T) {
+	t.Parallel()
+	cs := newNullableColumnSeries()
+	dsv := cs.GetDataShapes()
+	require.NoError(t, cs.PackNullMask(dsv))
+
+	rs, err := cs.ToRowSeries(*io.NewTimeBucketKey("TEST/1Min/OHLCV"), false)
+	require.NoError(t, err)
+	_, got := rs.ToColumnSeries()
+
+	require.False(t, got.Exists(io.NullMaskColumn))
+	require.Equal(t, []bool{false, true, false, true}, got.GetNullMask("Close"))
+	require.Equal(t, []bool{true, false, false, false}, got.GetNullMask("Volume"))
+	require.Equal(t, dsv, got.GetDataShapes())
+}
+
+func TestColumnSeries_NullMaskFollowsRows(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		apply     func(cs *io.ColumnSeries) *io.ColumnSeries
+		wantClose []bool
+	}{
+		"ok/ RestrictViaBitmap": {
+			apply: func(cs *io.ColumnSeries) *io.ColumnSeries {
+				_ = cs.RestrictViaBitmap([]bool{true, false, true, false})
+				return cs
+			},
+			wantClose: []bool{true, true},
+		},
+		"ok/ RestrictLength": {
+			apply: func(cs *io.ColumnSeries) *io.ColumnSeries {
+				_ = cs.RestrictLength(3, io.LAST)
+				return cs
+			},
+			wantClose: []bool{true, false, true},
+		},
+		"ok/ ApplyTimeQual": {
+			apply: func(cs *io.ColumnSeries) *io.ColumnSeries {
+				return cs.ApplyTimeQual(func(epoch int64) bool { return epoch%2 == 1 })
+			},
+			wantClose: []bool{false, false},
+		},
+		"ok/ SliceColumnSeriesByEpoch": {
+			apply: func(cs *io.ColumnSeries) *io.ColumnSeries {
+				start, end := int64(2), int64(4)
+				slc, _ := io.SliceColumnSeriesByEpoch(*cs, &start, &end)
+				return &slc
+			},
+			wantClose: []bool{true, false},
+		},
+		"ok/ ColumnSeriesUnion": {
+			apply: func(cs *io.ColumnSeries) *io.ColumnSeries {
+				right := io.NewColumnSeries()
+				right.AddColumn("Epoch", []int64{4, 5})
+				right.AddColumn("Close", []float32{4, 5})
+				right.AddColumn("Volume", []int64{4, 5})
+				return io.ColumnSeriesUnion(cs, right)
+			},
+			wantClose: []bool{false, true, false, false, false},
+		},
+		"ok/ Rename": {
+			apply: func(cs *io.ColumnSeries) *io.ColumnSeries {
+				_ = cs.Rename("Close", "Volume")
+				return cs
+			},
+			wantClose: []bool{true, false, false, false},
+		},
+	}
+
+	for name := range tests {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.apply(newNullableColumnSeries())
+
+			require.Equal(t, tt.wantClose, got.GetNullMask("Close"))
+		})
+	}
+}
+
+func TestColumnSeries_AddNullColumn(t *testing.T) {
+	t.Parallel()
+	cs := io.NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{1, 2})
+
+	cs.AddNullColumn(io.DataShape{Name: "Volume", Type: io.INT64, Nullable: true})
+	cs.AddNullColumn(io.DataShape{Name: "Close", Type: io.FLOAT32})
+
+	require.Equal(t, []int64{0, 0}, cs.GetColumn("Volume"))
+	require.Equal(t, []bool{true, true}, cs.GetNullMask("Volume"))
+	require.Equal(t, []float32{0, 0}, cs.GetColumn("Close"))
+	require.False(t, cs.IsNullable("Close"))
+}
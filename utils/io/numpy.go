@@ -4,6 +4,8 @@ package io
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/alpacahq/marketstore/v4/utils/log"
 )
@@ -20,6 +22,8 @@ var typeMap = map[EnumElementType]string{
 	FLOAT32:  "f4",
 	FLOAT64:  "f8",
 	STRING16: "U16",
+	// a decimal type string has the scale in brackets, e.g. "d8[4]"
+	DECIMAL64: "d8",
 }
 
 var typeStrMap = func() map[string]EnumElementType {
@@ -33,15 +37,58 @@ var typeStrMap = func() map[string]EnumElementType {
 // TypeStrToElemType converts a numpy type string (e.g. "i8", "f4") to an element type
 // ok=false is returned when unknown string is specified.
 func TypeStrToElemType(typeStr string) (elemType EnumElementType, ok bool) {
-	elemType, ok = typeStrMap[typeStr]
+	elemType, _, ok = ParseTypeStr(typeStr)
 	return elemType, ok
 }
 
+// ParseTypeStr converts a type string (e.g. "i8", "d8[4]") to an element type and its scale.
+// ok=false is returned when unknown string or invalid scale is specified.
+func ParseTypeStr(typeStr string) (elemType EnumElementType, scale int8, ok bool) {
+	if i := strings.Index(typeStr, "["); i >= 0 && strings.HasSuffix(typeStr, "]") {
+		s, err := strconv.ParseInt(typeStr[i+1:len(typeStr)-1], 10, 8)
+		if err != nil || s < 0 || s > MaxDecimal64Scale {
+			return NONE, 0, false
+		}
+		scale = int8(s)
+		typeStr = typeStr[:i]
+	}
+	elemType, ok = typeStrMap[typeStr]
+	if !ok || (scale != 0 && elemType != DECIMAL64) {
+		return NONE, 0, false
+	}
+	return elemType, scale, true
+}
+
 func ToTypeStr(elemType EnumElementType) (typeStr string, ok bool) {
 	typeStr, ok = typeMap[elemType]
 	return typeStr, ok
 }
 
+// DataShapeTypeStr returns the type string of the data shape, including the scale of a DECIMAL64 column
+// and the "?" suffix of a nullable column (e.g. "d8[4]", "i8?").
+func DataShapeTypeStr(ds DataShape) (typeStr string, ok bool) {
+	typeStr, ok = ToTypeStr(ds.Type)
+	if ok && ds.Type == DECIMAL64 {
+		typeStr = fmt.Sprintf("%s[%d]", typeStr, ds.Scale)
+	}
+	if ok && ds.Nullable {
+		typeStr += nullableTypeSuffix
+	}
+	return typeStr, ok
+}
+
+// NewDataShapeFromTypeStr returns a data shape of the column name and the type string
+// such as "i8", "d8[4]" and "i8?" (nullable).
+// ok=false is returned when unknown string or invalid scale is specified.
+func NewDataShapeFromTypeStr(name, typeStr string) (ds DataShape, ok bool) {
+	baseTypeStr := strings.TrimSuffix(typeStr, nullableTypeSuffix)
+	elemType, scale, ok := ParseTypeStr(baseTypeStr)
+	if !ok {
+		return DataShape{}, false
+	}
+	return DataShape{Name: name, Type: elemType, Scale: scale, Nullable: baseTypeStr != typeStr}, true
+}
+
 type NumpyDataset struct {
 	// a list of type strings such as i4 and f8
 	ColumnTypes []string `msgpack:"types"`
@@ -54,6 +101,10 @@ type NumpyDataset struct {
 	// => ColumnData[index of columnA] = "\x01\x02\x03\x04\x05\x06\x07\x08"
 	ColumnData [][]byte `msgpack:"data"`
 	Length     int      `msgpack:"length"`
+	// two dimensional byte arrays holding the null masks of the columns, aligned with ColumnData.
+	// ColumnMasks[columnIndex] has one byte per row (1=null, 0=valid) for a nullable column,
+	// and is empty for a column that is not nullable.
+	ColumnMasks [][]byte `msgpack:"masks,omitempty"`
 	// hidden
 	dataShapes []DataShape
 }
@@ -67,30 +118,70 @@ func NewNumpyDataset(cs *ColumnSeries) (nds *NumpyDataset, err error) {
 		colBytes := CastToByteSlice(cs.GetColumn(name))
 		nds.ColumnData = append(nds.ColumnData, colBytes)
 
-		typeStr, ok := typeMap[nds.dataShapes[i].Type]
+		// the nullability is represented by the column masks instead of the type string
+		typeStr, ok := DataShapeTypeStr(DataShape{Type: nds.dataShapes[i].Type, Scale: nds.dataShapes[i].Scale})
 		if !ok {
 			log.Error("unsupported type %v", nds.dataShapes[i].String())
 			return nil, fmt.Errorf("unsupported type")
 		}
 		nds.ColumnTypes = append(nds.ColumnTypes, typeStr)
 	}
+	nds.ColumnMasks = columnMasksFrom(cs)
 	return nds, nil
 }
 
+// columnMasksFrom returns the null masks of the columns in the column series,
+// or nil when there is no nullable column.
+func columnMasksFrom(cs *ColumnSeries) [][]byte {
+	if len(cs.nulls) == 0 {
+		return nil
+	}
+	masks := make([][]byte, len(cs.GetColumnNames()))
+	for i, name := range cs.GetColumnNames() {
+		masks[i] = maskToBytes(cs.GetNullMask(name))
+	}
+	return masks
+}
+
+func maskToBytes(mask []bool) []byte {
+	if mask == nil {
+		return []byte{}
+	}
+	ret := make([]byte, len(mask))
+	for i, isNull := range mask {
+		if isNull {
+			ret[i] = 1
+		}
+	}
+	return ret
+}
+
+// isNullableColumn returns true when the i-th column of the dataset has a null mask.
+func (nds *NumpyDataset) isNullableColumn(i int) bool {
+	return i < len(nds.ColumnMasks) && len(nds.ColumnMasks[i]) != 0
+}
+
 func (nds *NumpyDataset) Len() int {
 	return nds.Length
 }
 
 func (nds *NumpyDataset) buildDataShapes() ([]DataShape, error) {
 	etypes := []EnumElementType{}
+	scales := []int8{}
 	for _, typeStr := range nds.ColumnTypes {
-		typ, ok := typeStrMap[typeStr]
+		typ, scale, ok := ParseTypeStr(typeStr)
 		if !ok {
 			return nil, fmt.Errorf("unsupported type string %s", typeStr)
 		}
 		etypes = append(etypes, typ)
+		scales = append(scales, scale)
 	}
-	return NewDataShapeVector(nds.ColumnNames, etypes), nil
+	dsv := NewDataShapeVector(nds.ColumnNames, etypes)
+	for i := range dsv {
+		dsv[i].Scale = scales[i]
+		dsv[i].Nullable = nds.isNullableColumn(i)
+	}
+	return dsv, nil
 }
 
 func (nds *NumpyDataset) ToColumnSeries(options ...int) (cs *ColumnSeries, err error) {
@@ -126,6 +217,17 @@ func (nds *NumpyDataset) ToColumnSeries(options ...int) (cs *ColumnSeries, err e
 			return nil, fmt.Errorf("failed to convert column data slice: %w", err)
 		}
 		cs.AddColumn(shape.Name, newColData)
+		cs.SetScale(shape.Name, shape.Scale)
+		if nds.isNullableColumn(i) {
+			if len(nds.ColumnMasks[i]) < startIndex+length {
+				return nil, fmt.Errorf("null mask of %s is shorter than the column data", shape.Name)
+			}
+			mask := make([]bool, length)
+			for j, b := range nds.ColumnMasks[i][startIndex : startIndex+length] {
+				mask[j] = b != 0
+			}
+			cs.SetNullMask(shape.Name, mask)
+		}
 	}
 	return cs, nil
 }
@@ -142,6 +244,7 @@ func NewNumpyMultiDataset(nds *NumpyDataset, tbk TimeBucketKey) (nmds *NumpyMult
 			ColumnTypes: nds.ColumnTypes,
 			ColumnNames: nds.ColumnNames,
 			ColumnData:  nds.ColumnData,
+			ColumnMasks: nds.ColumnMasks,
 			Length:      nds.Length,
 			dataShapes:  nds.dataShapes,
 		},
@@ -185,6 +288,27 @@ func (nmds *NumpyMultiDataset) Append(cs *ColumnSeries, tbk TimeBucketKey) (err
 			return
 		}
 	}
+	if len(cs.nulls) != 0 && nmds.ColumnMasks == nil {
+		nmds.ColumnMasks = make([][]byte, len(nmds.ColumnData))
+	}
+	for idx, col := range colSeriesNames {
+		if idx >= len(nmds.ColumnMasks) {
+			break
+		}
+		mask := nmds.ColumnMasks[idx]
+		if !cs.IsNullable(col) && len(mask) == 0 {
+			continue
+		}
+		// the rows without a null mask are valid
+		if len(mask) < nmds.Length {
+			mask = append(mask, make([]byte, nmds.Length-len(mask))...)
+		}
+		newMask := maskToBytes(cs.GetNullMask(col))
+		if len(newMask) < cs.Len() {
+			newMask = make([]byte, cs.Len())
+		}
+		nmds.ColumnMasks[idx] = append(mask, newMask...)
+	}
 	nmds.StartIndex[tbk.String()] = nmds.Length
 	nmds.Lengths[tbk.String()] = cs.Len()
 	nmds.Length += cs.Len()
@@ -96,3 +96,76 @@ func TestToColumnSeries(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, reflect.DeepEqual(csReturned, cs))
 }
+
+func TestNumpyDataset_Decimal(t *testing.T) {
+	cs := NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{10, 11})
+	cs.AddColumn("Price", []Decimal64{12345, 12346})
+	cs.SetScale("Price", 2)
+
+	nds, err := NewNumpyDataset(cs)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"i8", "d8[2]"}, nds.ColumnTypes)
+
+	// the scale is restored from the type string
+	received := &NumpyDataset{
+		ColumnTypes: nds.ColumnTypes,
+		ColumnNames: nds.ColumnNames,
+		ColumnData:  nds.ColumnData,
+		Length:      nds.Length,
+	}
+	got, err := received.ToColumnSeries()
+	assert.Nil(t, err)
+	assert.Equal(t, []Decimal64{12345, 12346}, got.GetColumn("Price"))
+	assert.Equal(t, []DataShape{
+		{Name: "Epoch", Type: INT64},
+		{Name: "Price", Type: DECIMAL64, Scale: 2},
+	}, got.GetDataShapes())
+
+	_, _, ok := ParseTypeStr("f4[2]")
+	assert.False(t, ok)
+}
+
+func TestNumpyDataset_Nullable(t *testing.T) {
+	cs := NewColumnSeries()
+	cs.AddColumn("Epoch", []int64{10, 11, 12})
+	cs.AddColumn("Volume", []int64{0, 0, 5})
+	cs.SetNullMask("Volume", []bool{false, true, false})
+
+	nds, err := NewNumpyDataset(cs)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"i8", "i8"}, nds.ColumnTypes)
+	assert.Equal(t, [][]byte{{}, {0, 1, 0}}, nds.ColumnMasks)
+
+	// a non-nullable column series is appended as valid values
+	nmds, err := NewNumpyMultiDataset(nds, *NewTimeBucketKey("A/1Min/OHLCV"))
+	assert.Nil(t, err)
+	cs2 := NewColumnSeries()
+	cs2.AddColumn("Epoch", []int64{13})
+	cs2.AddColumn("Volume", []int64{0})
+	assert.Nil(t, nmds.Append(cs2, *NewTimeBucketKey("B/1Min/OHLCV")))
+	assert.Equal(t, [][]byte{{}, {0, 1, 0, 0}}, nmds.ColumnMasks)
+
+	// the null masks are restored from the column masks
+	received := &NumpyMultiDataset{
+		NumpyDataset: NumpyDataset{
+			ColumnTypes: nmds.ColumnTypes,
+			ColumnNames: nmds.ColumnNames,
+			ColumnData:  nmds.ColumnData,
+			ColumnMasks: nmds.ColumnMasks,
+			Length:      nmds.Length,
+		},
+		StartIndex: nmds.StartIndex,
+		Lengths:    nmds.Lengths,
+	}
+	csm, err := received.ToColumnSeriesMap()
+	assert.Nil(t, err)
+	got := csm[*NewTimeBucketKey("A/1Min/OHLCV")]
+	assert.Equal(t, []bool{false, true, false}, got.GetNullMask("Volume"))
+	assert.Nil(t, got.GetNullMask("Epoch"))
+	assert.Equal(t, []DataShape{
+		{Name: "Epoch", Type: INT64},
+		{Name: "Volume", Type: INT64, Nullable: true},
+	}, got.GetDataShapes())
+	assert.Equal(t, []bool{false}, csm[*NewTimeBucketKey("B/1Min/OHLCV")].GetNullMask("Volume"))
+}
@@ -142,7 +142,20 @@ func (cs *ColumnSeries) RestrictViaBitmap(bitmap []bool) (err error) {
 			if err := cs.Replace(key, newCol); err != nil {
 				return err
 			}
+		case []Decimal64:
+			newCol := make([]Decimal64, bitmapValidLength)
+			var newColCursor int
+			for i, val := range bitmap {
+				if !val { // If the bitmap is true, remove the value
+					newCol[newColCursor] = col[i]
+					newColCursor++
+				}
+			}
+			if err := cs.Replace(key, newCol); err != nil {
+				return err
+			}
 		}
 	}
+	cs.nulls = cs.filterNullMasks(func(i int) bool { return !bitmap[i] })
 	return nil
 }
@@ -55,6 +55,8 @@ func (rows *Rows) GetColumn(colname string) (col interface{}) {
 				return getUInt32Column(offset, rows.GetRowLen(), rows.GetNumRows(), rows.GetData())
 			case UINT64:
 				return getUInt64Column(offset, rows.GetRowLen(), rows.GetNumRows(), rows.GetData())
+			case DECIMAL64:
+				return getDecimal64Column(offset, rows.GetRowLen(), rows.GetNumRows(), rows.GetData())
 			case STRING16:
 				return getString16Column(offset, rows.GetRowLen(), rows.GetNumRows(), rows.GetData())
 			case BOOL, BYTE:
@@ -153,7 +155,9 @@ func (rows *Rows) ToColumnSeries() (*ColumnSeries, error) {
 			continue
 		}
 		cs.AddColumn(ds.Name, rows.GetColumn(ds.Name))
+		cs.SetScale(ds.Name, ds.Scale)
 	}
+	cs.unpackNullMask(rows.GetDataShapes())
 	return cs, nil
 }
 
@@ -177,7 +181,7 @@ func NewRowSeries(
 		This is true because the read() function for variable types inserts a 32-bit nanoseconds column
 	*/
 	if rowType == VARIABLE {
-		dataShape = append(dataShape, DataShape{"Nanoseconds", INT32})
+		dataShape = append(dataShape, DataShape{Name: "Nanoseconds", Type: INT32})
 	}
 	rows := NewRows(dataShape, data)
 	rows.SetRowLen(rowLen)
@@ -240,6 +244,8 @@ func (rs *RowSeries) ToColumnSeries() (key TimeBucketKey, cs *ColumnSeries) {
 			continue
 		}
 		cs.AddColumn(ds.Name, rs.GetColumn(ds.Name))
+		cs.SetScale(ds.Name, ds.Scale)
 	}
+	cs.unpackNullMask(rs.rows.GetDataShapes())
 	return key, cs
 }